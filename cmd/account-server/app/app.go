@@ -21,6 +21,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -33,6 +34,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the account server.
@@ -58,6 +60,8 @@ func Run(opt *options.Option) error {
 type accountServer struct {
 	svc *service.Service
 	sd  serviced.Service
+	// shutdownTracing 进程退出前导出剩余的链路数据
+	shutdownTracing func(ctx context.Context) error
 }
 
 // prepare do prepare jobs before run api discover.
@@ -75,6 +79,18 @@ func (ds *accountServer) prepare(opt *options.Option) error {
 	network := cc.AccountServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	shutdownTracing, err := tracing.Init(string(cc.AccountServerName), cc.AccountServer().Tracing.Config())
+	if err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+	ds.shutdownTracing = shutdownTracing
+
+	// load dynamic config overrides from etcd and keep watching the changes.
+	if err := serviced.WatchConfig(cc.AccountServer().Service); err != nil {
+		return fmt.Errorf("watch dynamic config failed, err: %v", err)
+	}
+
 	// init service discovery.
 	svcOpt := serviced.NewServiceOption(cc.AccountServerName, cc.AccountServer().Network)
 	discOpt := serviced.DiscoveryOption{
@@ -113,6 +129,8 @@ func (ds *accountServer) register() error {
 
 // finalizer ...
 func (ds *accountServer) finalizer() {
+	defer tracing.Shutdown(ds.shutdownTracing)
+
 	if err := ds.sd.Deregister(); err != nil {
 		logs.Errorf("process service shutdown, but deregister failed, err: %v", err)
 		return
@@ -0,0 +1,170 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+
+	"github.com/shopspring/decimal"
+)
+
+// costStat 预算范围内当月费用统计
+type costStat struct {
+	// MonthCost 当月累计费用
+	MonthCost decimal.Decimal
+	// LastDay 已出账的最后一天
+	LastDay int
+	// LastDayCost 最后一天的费用
+	LastDayCost decimal.Decimal
+	// PrevDayCost 最后一天前一天的费用
+	PrevDayCost decimal.Decimal
+	// Forecast 按已出账天数日均费用预测的月末费用
+	Forecast decimal.Decimal
+}
+
+func calcStat(dayCosts map[int]decimal.Decimal, daysInMonth int) costStat {
+	stat := costStat{}
+	for day, cost := range dayCosts {
+		stat.MonthCost = stat.MonthCost.Add(cost)
+		stat.LastDay = max(stat.LastDay, day)
+	}
+	if stat.LastDay == 0 {
+		return stat
+	}
+
+	stat.LastDayCost = dayCosts[stat.LastDay]
+	stat.PrevDayCost = dayCosts[stat.LastDay-1]
+	stat.Forecast = stat.MonthCost.Div(decimal.NewFromInt(int64(stat.LastDay))).
+		Mul(decimal.NewFromInt(int64(daysInMonth))).Round(2)
+	return stat
+}
+
+// checkBudget 根据费用统计生成告警，阈值与预测告警按月生成，日环比告警按出账日生成
+func checkBudget(budget bill.Budget, stat costStat, year, month int) []dsbill.BillBudgetAlertCreate {
+	newAlert := func(alertType enumor.BillBudgetAlertType, threshold int64, day int, cost decimal.Decimal,
+		msg string) dsbill.BillBudgetAlertCreate {
+
+		return dsbill.BillBudgetAlertCreate{
+			BudgetID:     budget.ID,
+			BillYear:     year,
+			BillMonth:    month,
+			BillDay:      day,
+			AlertType:    alertType,
+			Threshold:    threshold,
+			Currency:     budget.Currency,
+			BudgetAmount: budget.Amount,
+			Cost:         cost,
+			ForecastCost: stat.Forecast,
+			Message:      msg,
+		}
+	}
+
+	alerts := make([]dsbill.BillBudgetAlertCreate, 0)
+	if stat.LastDay == 0 || !budget.Amount.IsPositive() {
+		return alerts
+	}
+
+	thresholds := append([]int64(nil), budget.Thresholds...)
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] < thresholds[j] })
+	hundred := decimal.NewFromInt(100)
+	for _, threshold := range thresholds {
+		limit := budget.Amount.Mul(decimal.NewFromInt(threshold)).Div(hundred)
+		if stat.MonthCost.LessThan(limit) {
+			break
+		}
+		alerts = append(alerts, newAlert(enumor.BillBudgetAlertThreshold, threshold, 0, stat.MonthCost,
+			fmt.Sprintf("当月累计费用 %s %s 已达到预算的 %d%%", stat.MonthCost.StringFixed(2), budget.Currency,
+				threshold)))
+	}
+
+	// 已超出预算时不再进行预测告警
+	if budget.ForecastAlert && stat.MonthCost.LessThan(budget.Amount) && stat.Forecast.GreaterThan(budget.Amount) {
+		alerts = append(alerts, newAlert(enumor.BillBudgetAlertForecast, 0, 0, stat.MonthCost,
+			fmt.Sprintf("预测月末费用 %s %s 将超出预算 %s %s", stat.Forecast.StringFixed(2), budget.Currency,
+				budget.Amount.StringFixed(2), budget.Currency)))
+	}
+
+	if budget.AnomalyRatio > 0 && stat.PrevDayCost.IsPositive() {
+		ratio := stat.LastDayCost.Sub(stat.PrevDayCost).Div(stat.PrevDayCost).Mul(hundred)
+		if ratio.GreaterThanOrEqual(decimal.NewFromInt(budget.AnomalyRatio)) {
+			alerts = append(alerts, newAlert(enumor.BillBudgetAlertAnomaly, budget.AnomalyRatio, stat.LastDay,
+				stat.LastDayCost, fmt.Sprintf("%d日费用 %s %s 较前一日 %s %s 增长 %s%%", stat.LastDay,
+					stat.LastDayCost.StringFixed(2), budget.Currency, stat.PrevDayCost.StringFixed(2),
+					budget.Currency, ratio.StringFixed(2))))
+		}
+	}
+
+	return alerts
+}
+
+const (
+	alertMailTitle   = "【海垓】账单预算告警：%s"
+	alertMailContent = `<p>您好，预算【%s】（%s %s，月度预算 %s %s）在 %d 年 %d 月触发以下告警：</p>
+<table border="1" cellspacing="0" cellpadding="4">
+<tr><th>告警类型</th><th>告警信息</th></tr>
+%s
+</table>`
+	alertMailRow = "<tr><td>%s</td><td>%s</td></tr>"
+)
+
+var alertTypeNameMap = map[enumor.BillBudgetAlertType]string{
+	enumor.BillBudgetAlertThreshold: "预算阈值",
+	enumor.BillBudgetAlertForecast:  "月末预测",
+	enumor.BillBudgetAlertAnomaly:   "日环比异常",
+}
+
+// buildAlertMail 生成预算告警邮件，预算名称等用户输入的字段需转义后再拼入html
+func buildAlertMail(budget bill.Budget, alerts []dsbill.BillBudgetAlertCreate) *cmsi.CmsiMail {
+	rows := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		rows = append(rows, fmt.Sprintf(alertMailRow, alertTypeNameMap[alert.AlertType],
+			html.EscapeString(alert.Message)))
+	}
+
+	return &cmsi.CmsiMail{
+		ReceiverUserName: strings.Join(budget.Receivers, ","),
+		Title:            fmt.Sprintf(alertMailTitle, budget.Name),
+		Content: fmt.Sprintf(alertMailContent, html.EscapeString(budget.Name), budget.Scope,
+			html.EscapeString(scopeValue(budget)), budget.Amount.StringFixed(2), budget.Currency,
+			alerts[0].BillYear, alerts[0].BillMonth,
+			strings.Join(rows, "\n")),
+	}
+}
+
+func scopeValue(budget bill.Budget) string {
+	switch budget.Scope {
+	case enumor.BillBudgetScopeBiz:
+		return fmt.Sprintf("%d", budget.BkBizID)
+	case enumor.BillBudgetScopeMainAccount:
+		return budget.MainAccountID
+	case enumor.BillBudgetScopeProduct:
+		return fmt.Sprintf("%d", budget.ProductID)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package budget
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCheckBudget(t *testing.T) {
+	budget := bill.Budget{
+		ID:            "budget01",
+		Scope:         enumor.BillBudgetScopeBiz,
+		BkBizID:       100,
+		Currency:      enumor.CurrencyUSD,
+		Amount:        decimal.NewFromInt(1000),
+		Thresholds:    []int64{100, 50, 80},
+		ForecastAlert: true,
+		AnomalyRatio:  50,
+	}
+
+	// 前10天共消费600，第10天消费150较前一天100增长50%，预测月末 600/10*30=1800
+	dayCosts := map[int]decimal.Decimal{
+		1:  decimal.NewFromInt(350),
+		9:  decimal.NewFromInt(100),
+		10: decimal.NewFromInt(150),
+	}
+
+	stat := calcStat(dayCosts, 30)
+	if !stat.MonthCost.Equal(decimal.NewFromInt(600)) || stat.LastDay != 10 {
+		t.Fatalf("unexpected stat: %+v", stat)
+	}
+	if !stat.Forecast.Equal(decimal.NewFromInt(1800)) {
+		t.Fatalf("unexpected forecast: %s", stat.Forecast)
+	}
+
+	alerts := checkBudget(budget, stat, 2024, 10)
+	got := make(map[string]struct{})
+	for _, alert := range alerts {
+		got[alertKey(alert.AlertType, alert.Threshold, alert.BillDay)] = struct{}{}
+	}
+	expects := []string{
+		alertKey(enumor.BillBudgetAlertThreshold, 50, 0),
+		alertKey(enumor.BillBudgetAlertForecast, 0, 0),
+		alertKey(enumor.BillBudgetAlertAnomaly, 50, 10),
+	}
+	if len(got) != len(expects) {
+		t.Fatalf("expect %d alerts, got: %v", len(expects), got)
+	}
+	for _, key := range expects {
+		if _, ok := got[key]; !ok {
+			t.Errorf("alert %s is expected, got: %v", key, got)
+		}
+	}
+
+	// 无出账数据时不告警
+	if alerts = checkBudget(budget, calcStat(nil, 30), 2024, 10); len(alerts) != 0 {
+		t.Errorf("expect no alert without bill, got: %v", alerts)
+	}
+}
+
+func TestBuildAlertMail(t *testing.T) {
+	budget := bill.Budget{
+		Name:      "<b>test</b>",
+		Scope:     enumor.BillBudgetScopeBiz,
+		BkBizID:   100,
+		Receivers: []string{"user1", "user2"},
+		Currency:  enumor.CurrencyUSD,
+		Amount:    decimal.NewFromInt(1000),
+	}
+	alerts := []dsbill.BillBudgetAlertCreate{{BillYear: 2024, BillMonth: 10,
+		AlertType: enumor.BillBudgetAlertThreshold, Message: "cost > 80%"}}
+
+	mail := buildAlertMail(budget, alerts)
+	if mail.ReceiverUserName != "user1,user2" {
+		t.Errorf("unexpected receiver: %s", mail.ReceiverUserName)
+	}
+	if strings.Contains(mail.Content, "<b>test</b>") || !strings.Contains(mail.Content, "&lt;b&gt;test&lt;/b&gt;") {
+		t.Errorf("budget name is not escaped, content: %s", mail.Content)
+	}
+	if !strings.Contains(mail.Content, "<td>cost &gt; 80%</td>") {
+		t.Errorf("alert message is not escaped, content: %s", mail.Content)
+	}
+}
@@ -0,0 +1,266 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package budget 账单预算检测，按预算范围汇总当月每日账单，检测预算消耗、月末预测与日环比异常并发送告警
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+
+	"github.com/shopspring/decimal"
+)
+
+// Evaluator 预算检测器
+type Evaluator struct {
+	Sd     serviced.ServiceDiscover
+	Client *client.ClientSet
+	// CmsiCli 邮件通知客户端，为空时只记录告警。notice 客户端对接的是平台公告服务，没有面向指定用户的消息接口，
+	// 因此告警只通过 cmsi 邮件发送
+	CmsiCli cmsi.Client
+}
+
+// Run 定时检测所有预算，只在主节点执行
+func (e *Evaluator) Run(ctx context.Context) {
+	opt := cc.AccountServer().Budget
+	if opt.Disable {
+		logs.Infof("bill budget evaluator is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(*opt.EvaluateDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !e.Sd.IsMaster() {
+				continue
+			}
+
+			kt := core.NewBackendKit()
+			if err := e.EvaluateAll(kt, time.Now()); err != nil {
+				logs.Errorf("evaluate bill budget failed, err: %v, rid: %s", err, kt.Rid)
+			}
+		case <-ctx.Done():
+			logs.Infof("bill budget evaluator context done")
+			return
+		}
+	}
+}
+
+// EvaluateAll 检测所有预算在指定时间所在月份的费用情况
+func (e *Evaluator) EvaluateAll(kt *kit.Kit, now time.Time) error {
+	listReq := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	for {
+		result, err := e.Client.DataService().Global.Bill.ListBillBudget(kt, listReq)
+		if err != nil {
+			logs.Errorf("list bill budget failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, budget := range result.Details {
+			if err = e.evaluate(kt, budget, now); err != nil {
+				logs.Errorf("evaluate bill budget %s failed, err: %v, rid: %s", budget.ID, err, kt.Rid)
+			}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return nil
+}
+
+func (e *Evaluator) evaluate(kt *kit.Kit, budget bill.Budget, now time.Time) error {
+	year, month := now.Year(), int(now.Month())
+	dayCosts, err := e.listDailyCost(kt, budget, year, month)
+	if err != nil {
+		return err
+	}
+
+	stat := calcStat(dayCosts, times.DaysInMonth(year, time.Month(month)))
+	alerts := checkBudget(budget, stat, year, month)
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	alerts, err = e.filterAlerted(kt, budget.ID, year, month, alerts)
+	if err != nil {
+		return err
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	if e.CmsiCli != nil {
+		// 邮件发送失败时不记录告警，下个检测周期重试
+		if err = e.CmsiCli.SendMail(kt, buildAlertMail(budget, alerts)); err != nil {
+			logs.Errorf("send bill budget alert mail failed, err: %v, budget: %s, rid: %s", err, budget.ID, kt.Rid)
+			return err
+		}
+	}
+
+	_, err = e.Client.DataService().Global.Bill.BatchCreateBillBudgetAlert(kt,
+		&dsbill.BatchCreateBillBudgetAlertReq{Alerts: alerts})
+	if err != nil {
+		logs.Errorf("create bill budget alert failed, err: %v, budget: %s, rid: %s", err, budget.ID, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
+// listDailyCost 查询预算范围内各二级账号当前版本的每日费用，只统计与预算币种相同的费用
+func (e *Evaluator) listDailyCost(kt *kit.Kit, budget bill.Budget, year, month int) (map[int]decimal.Decimal,
+	error) {
+
+	rules := []*filter.AtomRule{tools.RuleEqual("bill_year", year), tools.RuleEqual("bill_month", month)}
+	switch budget.Scope {
+	case enumor.BillBudgetScopeBiz:
+		rules = append(rules, tools.RuleEqual("bk_biz_id", budget.BkBizID))
+	case enumor.BillBudgetScopeMainAccount:
+		rules = append(rules, tools.RuleEqual("main_account_id", budget.MainAccountID))
+	case enumor.BillBudgetScopeProduct:
+		rules = append(rules, tools.RuleEqual("product_id", budget.ProductID))
+	default:
+		return nil, fmt.Errorf("unsupported bill budget scope: %s", budget.Scope)
+	}
+
+	versionMap := make(map[string]int)
+	mainReq := &dsbill.BillSummaryMainListReq{
+		Filter: tools.ExpressionAnd(rules...),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"main_account_id", "current_version"},
+	}
+	for {
+		result, err := e.Client.DataService().Global.Bill.ListBillSummaryMain(kt, mainReq)
+		if err != nil {
+			logs.Errorf("list bill summary main for budget failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			versionMap[one.MainAccountID] = one.CurrentVersion
+		}
+
+		if uint(len(result.Details)) < mainReq.Page.Limit {
+			break
+		}
+		mainReq.Page.Start += uint32(mainReq.Page.Limit)
+	}
+
+	dayCosts := make(map[int]decimal.Decimal)
+	mainAccountIDs := make([]string, 0, len(versionMap))
+	for id := range versionMap {
+		mainAccountIDs = append(mainAccountIDs, id)
+	}
+	for _, ids := range slice.Split(mainAccountIDs, int(core.DefaultMaxPageLimit)) {
+		dailyReq := &dsbill.BillSummaryDailyListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("bill_year", year),
+				tools.RuleEqual("bill_month", month),
+				tools.RuleEqual("currency", budget.Currency),
+				tools.RuleIn("main_account_id", ids),
+			),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"main_account_id", "bill_day", "version_id", "cost"},
+		}
+		for {
+			result, err := e.Client.DataService().Global.Bill.ListBillSummaryDaily(kt, dailyReq)
+			if err != nil {
+				logs.Errorf("list bill summary daily for budget failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range result.Details {
+				if one.VersionID != versionMap[one.MainAccountID] {
+					continue
+				}
+				dayCosts[one.BillDay] = dayCosts[one.BillDay].Add(one.Cost)
+			}
+
+			if uint(len(result.Details)) < dailyReq.Page.Limit {
+				break
+			}
+			dailyReq.Page.Start += uint32(dailyReq.Page.Limit)
+		}
+	}
+
+	return dayCosts, nil
+}
+
+// filterAlerted 过滤当月已经产生过的告警
+func (e *Evaluator) filterAlerted(kt *kit.Kit, budgetID string, year, month int,
+	alerts []dsbill.BillBudgetAlertCreate) ([]dsbill.BillBudgetAlertCreate, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("budget_id", budgetID),
+			tools.RuleEqual("bill_year", year),
+			tools.RuleEqual("bill_month", month),
+		),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"alert_type", "threshold", "bill_day"},
+	}
+	existed := make(map[string]struct{})
+	for {
+		result, err := e.Client.DataService().Global.Bill.ListBillBudgetAlert(kt, listReq)
+		if err != nil {
+			logs.Errorf("list bill budget alert failed, err: %v, budget: %s, rid: %s", err, budgetID, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			existed[alertKey(one.AlertType, one.Threshold, one.BillDay)] = struct{}{}
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	newAlerts := make([]dsbill.BillBudgetAlertCreate, 0, len(alerts))
+	for _, alert := range alerts {
+		if _, ok := existed[alertKey(alert.AlertType, alert.Threshold, alert.BillDay)]; ok {
+			continue
+		}
+		newAlerts = append(newAlerts, alert)
+	}
+	return newAlerts, nil
+}
+
+func alertKey(alertType enumor.BillBudgetAlertType, threshold int64, day int) string {
+	return fmt.Sprintf("%s/%d/%d", alertType, threshold, day)
+}
@@ -0,0 +1,224 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package commitment
+
+import (
+	"sort"
+	"time"
+
+	typesbill "hcm/pkg/adaptor/types/bill"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	hcbill "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+// awsRunningStatus aws 运行中实例的状态
+const awsRunningStatus = "running"
+
+// awsInstanceKey 使用账号、地域、实例规格
+type awsInstanceKey struct {
+	UsageAccountID string
+	Region         string
+	InstanceType   string
+}
+
+// AnalyzeAwsSavingsPlan 结合一级账号CUR账单及已同步的EC2实例，分析 savings plans 使用率、覆盖率并给出追加购买建议
+func (a *Analyzer) AnalyzeAwsSavingsPlan(kt *kit.Kit, req *asbill.AwsSavingsPlanAnalysisReq) (
+	*asbill.AwsSavingsPlanAnalysisResult, error) {
+
+	spReq := &hcbill.AwsRootSpAnalysisReq{
+		RootAccountID: req.RootAccountID,
+		BillYear:      req.BillYear,
+		BillMonth:     req.BillMonth,
+		SpArnPrefix:   req.SpArnPrefix,
+	}
+	analysis, err := a.Client.HCService().Aws.Bill.GetRootAccountSpAnalysis(kt, spReq)
+	if err != nil {
+		logs.Errorf("get aws root account sp analysis failed, err: %v, req: %+v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	usageAccountIDs := make([]string, 0, len(analysis.Coverages))
+	for _, one := range analysis.Coverages {
+		usageAccountIDs = append(usageAccountIDs, one.UsageAccountID)
+	}
+	running, err := a.countAwsRunning(kt, slice.Unique(usageAccountIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	days := time.Date(int(req.BillYear), time.Month(req.BillMonth)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return buildAwsResult(analysis, running, days), nil
+}
+
+// countAwsRunning 按使用账号、地域、实例规格统计已同步的运行中实例数量
+func (a *Analyzer) countAwsRunning(kt *kit.Kit, usageAccountIDs []string) (map[awsInstanceKey]int, error) {
+	running := make(map[awsInstanceKey]int)
+	for _, cloudIDs := range slice.Split(usageAccountIDs, int(core.DefaultMaxPageLimit)) {
+		accountReq := &protocloud.AccountListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("vendor", enumor.Aws),
+				tools.RuleJsonIn("extension.cloud_account_id", cloudIDs),
+			),
+			Page: core.NewDefaultBasePage(),
+		}
+		accounts, err := a.Client.DataService().Global.Account.ListWithExtension(kt.Ctx, kt.Header(), accountReq)
+		if err != nil {
+			logs.Errorf("list aws account by cloud account ids failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		if len(accounts.Details) == 0 {
+			continue
+		}
+
+		cloudAccountMap := make(map[string]string, len(accounts.Details))
+		for _, one := range accounts.Details {
+			cloudID, _ := one.Extension["cloud_account_id"].(string)
+			cloudAccountMap[one.ID] = cloudID
+		}
+		accountIDs := make([]string, 0, len(cloudAccountMap))
+		for id := range cloudAccountMap {
+			accountIDs = append(accountIDs, id)
+		}
+
+		listReq := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("vendor", enumor.Aws),
+				tools.RuleEqual("status", awsRunningStatus),
+				tools.RuleIn("account_id", accountIDs),
+			),
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"account_id", "region", "machine_type"},
+		}
+		for {
+			result, err := a.Client.DataService().Global.Cvm.ListCvm(kt, listReq)
+			if err != nil {
+				logs.Errorf("list aws running cvm failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range result.Details {
+				key := awsInstanceKey{
+					UsageAccountID: cloudAccountMap[one.AccountID],
+					Region:         one.Region,
+					InstanceType:   one.MachineType,
+				}
+				running[key]++
+			}
+
+			if uint(len(result.Details)) < listReq.Page.Limit {
+				break
+			}
+			listReq.Page.Start += uint32(listReq.Page.Limit)
+		}
+	}
+
+	return running, nil
+}
+
+// buildAwsResult 汇总使用率、覆盖率，并以当月每日未覆盖按需费用的最小值作为稳定基线推荐追加的承诺金额
+func buildAwsResult(analysis *typesbill.AwsSpAnalysisResult, running map[awsInstanceKey]int,
+	days int) *asbill.AwsSavingsPlanAnalysisResult {
+
+	result := &asbill.AwsSavingsPlanAnalysisResult{
+		Currency:     enumor.CurrencyUSD,
+		SavingsPlans: make([]asbill.AwsSavingsPlanUtilization, 0, len(analysis.Utilizations)),
+		Coverages:    make([]asbill.AwsSavingsPlanCoverage, 0, len(analysis.Coverages)),
+	}
+
+	for _, one := range analysis.Utilizations {
+		unused := decimal.Max(one.Commitment.Sub(one.UsedCommitment), decimal.Zero)
+		result.SavingsPlans = append(result.SavingsPlans, asbill.AwsSavingsPlanUtilization{
+			SpArn:            one.SpArn,
+			Commitment:       one.Commitment,
+			UsedCommitment:   one.UsedCommitment,
+			UnusedCommitment: unused,
+			UtilizationRate:  percent(one.UsedCommitment, one.Commitment),
+		})
+		result.Commitment = result.Commitment.Add(one.Commitment)
+		result.UsedCommitment = result.UsedCommitment.Add(one.UsedCommitment)
+		result.UnusedCommitment = result.UnusedCommitment.Add(unused)
+	}
+	result.UtilizationRate = percent(result.UsedCommitment, result.Commitment)
+
+	spEffectiveCost := decimal.Zero
+	for _, one := range analysis.Coverages {
+		key := awsInstanceKey{UsageAccountID: one.UsageAccountID, Region: one.Region, InstanceType: one.InstanceType}
+		result.Coverages = append(result.Coverages, asbill.AwsSavingsPlanCoverage{
+			UsageAccountID:  one.UsageAccountID,
+			Region:          one.Region,
+			InstanceType:    one.InstanceType,
+			RunningCount:    running[key],
+			CoveredCost:     one.CoveredCost,
+			SpEffectiveCost: one.SpEffectiveCost,
+			OnDemandCost:    one.OnDemandCost,
+			CoverageRate:    percent(one.CoveredCost, one.CoveredCost.Add(one.OnDemandCost)),
+		})
+		result.CoveredCost = result.CoveredCost.Add(one.CoveredCost)
+		result.OnDemandCost = result.OnDemandCost.Add(one.OnDemandCost)
+		spEffectiveCost = spEffectiveCost.Add(one.SpEffectiveCost)
+	}
+	result.CoverageRate = percent(result.CoveredCost, result.CoveredCost.Add(result.OnDemandCost))
+	sort.Slice(result.Coverages, func(i, j int) bool {
+		return result.Coverages[i].OnDemandCost.GreaterThan(result.Coverages[j].OnDemandCost)
+	})
+
+	result.Recommendation = recommendAwsCommitment(analysis.DailyOnDemand, result.CoveredCost, spEffectiveCost,
+		days)
+	return result
+}
+
+// recommendAwsCommitment 折扣率取当月 savings plans 实际折扣，未出现覆盖或账单天数不足整月时不给出建议
+func recommendAwsCommitment(daily []typesbill.AwsSpDailyOnDemand, coveredCost, spEffectiveCost decimal.Decimal,
+	days int) *asbill.AwsSavingsPlanRecommendation {
+
+	if !coveredCost.IsPositive() || len(daily) < days {
+		return nil
+	}
+
+	baseline := daily[0].OnDemandCost
+	for _, one := range daily[1:] {
+		baseline = decimal.Min(baseline, one.OnDemandCost)
+	}
+	if !baseline.IsPositive() {
+		return nil
+	}
+
+	discount := decimal.NewFromInt(1).Sub(spEffectiveCost.Div(coveredCost))
+	if !discount.IsPositive() {
+		return nil
+	}
+
+	hourly := baseline.Div(decimal.NewFromInt(24)).Mul(decimal.NewFromInt(1).Sub(discount))
+	dayCount := decimal.NewFromInt(int64(days))
+	return &asbill.AwsSavingsPlanRecommendation{
+		DiscountRate:            discount.Mul(hundred).Round(2),
+		HourlyCommitment:        hourly.Round(4),
+		MonthlyCommitment:       hourly.Mul(decimal.NewFromInt(24)).Mul(dayCount).Round(2),
+		EstimatedMonthlySavings: baseline.Mul(discount).Mul(dayCount).Round(2),
+	}
+}
@@ -0,0 +1,45 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package commitment 预留实例、savings plans 等承诺类折扣的覆盖率及使用率分析
+package commitment
+
+import (
+	"hcm/pkg/client"
+
+	"github.com/shopspring/decimal"
+)
+
+// hoursPerMonth 承诺类费用按每月730小时均摊
+const hoursPerMonth = 730
+
+// Analyzer 承诺类折扣分析
+type Analyzer struct {
+	Client *client.ClientSet
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// percent 计算 num/den 的百分比，保留两位小数，den为0时返回0
+func percent(num, den decimal.Decimal) decimal.Decimal {
+	if !den.IsPositive() {
+		return decimal.Zero
+	}
+	return num.Div(den).Mul(hundred).Round(2)
+}
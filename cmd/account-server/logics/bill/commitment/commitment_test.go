@@ -0,0 +1,125 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package commitment
+
+import (
+	"testing"
+	"time"
+
+	typesbill "hcm/pkg/adaptor/types/bill"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+
+	"github.com/shopspring/decimal"
+	tcvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+func TestBuildAwsResult(t *testing.T) {
+	analysis := &typesbill.AwsSpAnalysisResult{
+		Utilizations: []typesbill.AwsSpUtilization{
+			{SpArn: "sp-1", Commitment: decimal.NewFromInt(100), UsedCommitment: decimal.NewFromInt(80)},
+		},
+		Coverages: []typesbill.AwsSpCoverage{{
+			UsageAccountID: "111", Region: "us-east-1", InstanceType: "m5.large",
+			CoveredCost: decimal.NewFromInt(160), SpEffectiveCost: decimal.NewFromInt(80),
+			OnDemandCost: decimal.NewFromInt(40),
+		}},
+	}
+	// 每日未覆盖按需费用最小值为24，即每小时1
+	for day := 1; day <= 30; day++ {
+		analysis.DailyOnDemand = append(analysis.DailyOnDemand,
+			typesbill.AwsSpDailyOnDemand{OnDemandCost: decimal.NewFromInt(int64(24 + day%3))})
+	}
+	running := map[awsInstanceKey]int{{UsageAccountID: "111", Region: "us-east-1", InstanceType: "m5.large"}: 3}
+
+	result := buildAwsResult(analysis, running, 30)
+	if !result.UnusedCommitment.Equal(decimal.NewFromInt(20)) || !result.UtilizationRate.Equal(decimal.NewFromInt(80)) {
+		t.Errorf("unexpected utilization: %+v", result)
+	}
+	if !result.CoverageRate.Equal(decimal.NewFromInt(80)) || result.Coverages[0].RunningCount != 3 {
+		t.Errorf("unexpected coverage: %+v", result.Coverages)
+	}
+	if result.Recommendation == nil {
+		t.Fatalf("expect recommendation")
+	}
+	// 折扣50%，每小时按需1折算承诺0.5，每月节省 24*30*0.5
+	if !result.Recommendation.DiscountRate.Equal(decimal.NewFromInt(50)) ||
+		!result.Recommendation.HourlyCommitment.Equal(decimal.NewFromFloat(0.5)) ||
+		!result.Recommendation.EstimatedMonthlySavings.Equal(decimal.NewFromInt(360)) {
+		t.Errorf("unexpected recommendation: %+v", result.Recommendation)
+	}
+
+	// 账单天数不足整月时不给出建议
+	analysis.DailyOnDemand = analysis.DailyOnDemand[:10]
+	if result = buildAwsResult(analysis, running, 30); result.Recommendation != nil {
+		t.Errorf("expect no recommendation, got: %+v", result.Recommendation)
+	}
+}
+
+func TestBuildTCloudResult(t *testing.T) {
+	now := time.Date(2024, 10, 28, 0, 0, 0, 0, time.UTC)
+	key := tcloudInstanceKey{Zone: "ap-guangzhou-3", InstanceType: "S5.MEDIUM4"}
+	unusedKey := tcloudInstanceKey{Zone: "ap-guangzhou-4", InstanceType: "S5.MEDIUM4"}
+	newRI := func(count int64) typecvm.TCloudReservedInstance {
+		return typecvm.TCloudReservedInstance{ReservedInstances: &tcvm.ReservedInstances{
+			InstanceCount: converter.ValToPtr(count), Duration: converter.ValToPtr(int64(tcloudOneYearDuration)),
+			OfferingType: converter.ValToPtr("All Upfront"), ProductDescription: converter.ValToPtr("linux"),
+		}}
+	}
+	// 一年期全预付 8760，每小时均摊 1
+	offering := typecvm.TCloudReservedInstanceOffering{ReservedInstancesOffering: &tcvm.ReservedInstancesOffering{
+		Duration: converter.ValToPtr(int64(tcloudOneYearDuration)), FixedPrice: converter.ValToPtr(8760.0),
+		UsagePrice: converter.ValToPtr(0.0), OfferingType: converter.ValToPtr("All Upfront"),
+		ProductDescription: converter.ValToPtr("linux"), CurrencyCode: converter.ValToPtr("CNY"),
+		ReservedInstancesOfferingId: converter.ValToPtr("offering-1"),
+	}}
+
+	old := now.AddDate(0, 0, -60)
+	groups := map[tcloudInstanceKey]*tcloudGroup{
+		key: {
+			Reserved: []typecvm.TCloudReservedInstance{newRI(1)},
+			Postpaid: []tcloudInstance{{CloudID: "ins-1", CreatedTime: old}, {CloudID: "ins-2", CreatedTime: old},
+				{CloudID: "ins-3", CreatedTime: now.AddDate(0, 0, -1)}},
+		},
+		unusedKey: {Reserved: []typecvm.TCloudReservedInstance{newRI(2)}},
+	}
+	offerings := map[tcloudInstanceKey][]typecvm.TCloudReservedInstanceOffering{
+		key:       {offering},
+		unusedKey: {offering},
+	}
+	costs := map[string]map[enumor.CurrencyCode]decimal.Decimal{
+		"ins-1": {enumor.CurrencyCNY: decimal.NewFromInt(1000)},
+		"ins-2": {enumor.CurrencyCNY: decimal.NewFromInt(1000)},
+	}
+
+	result := buildTCloudResult(groups, offerings, costs, now)
+	if result.ReservedCount != 3 || result.PostpaidCount != 3 || result.CoveredCount != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !result.Items[1].UnusedMonthlyCost.Equal(decimal.NewFromInt(2 * hoursPerMonth)) {
+		t.Errorf("unexpected unused cost: %s", result.Items[1].UnusedMonthlyCost)
+	}
+	// 两台运行超过30天的实例中一台已被覆盖，剩余一台推荐购买
+	if len(result.Recommendations) != 1 || result.Recommendations[0].Count != 1 ||
+		!result.Recommendations[0].EstimatedMonthlySavings.Equal(decimal.NewFromInt(1000-hoursPerMonth)) {
+		t.Errorf("unexpected recommendations: %+v", result.Recommendations)
+	}
+}
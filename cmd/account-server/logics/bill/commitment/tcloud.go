@@ -0,0 +1,403 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package commitment
+
+import (
+	"sort"
+	"time"
+
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	databill "hcm/pkg/api/data-service/bill"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// tcloudRunningStatus 腾讯云运行中实例的状态
+	tcloudRunningStatus = "RUNNING"
+	// tcloudPostpaidByHour 腾讯云按量计费模式
+	tcloudPostpaidByHour = "POSTPAID_BY_HOUR"
+	// tcloudRIActive 生效中的预留实例状态
+	tcloudRIActive = "active"
+	// tcloudOneYearDuration 一年期预留实例的购买时长，单位为秒
+	tcloudOneYearDuration = 31536000
+	// longRunningDays 持续运行超过该天数的按量计费实例作为预留实例的购买候选
+	longRunningDays = 30
+)
+
+// tcloudInstanceKey 可用区、实例规格
+type tcloudInstanceKey struct {
+	Zone         string
+	InstanceType string
+}
+
+// tcloudInstance 运行中的按量计费实例
+type tcloudInstance struct {
+	CloudID     string
+	CreatedTime time.Time
+}
+
+// tcloudGroup 同一可用区、实例规格下的预留实例及按量计费实例
+type tcloudGroup struct {
+	Reserved []typecvm.TCloudReservedInstance
+	Postpaid []tcloudInstance
+}
+
+func (g *tcloudGroup) reservedCount() int {
+	count := 0
+	for _, one := range g.Reserved {
+		count += int(converter.PtrToVal(one.InstanceCount))
+	}
+	return count
+}
+
+// candidates 覆盖之外持续运行超过30天的按量计费实例，预留实例优先抵扣运行时间最长的实例
+func (g *tcloudGroup) candidates(now time.Time) []tcloudInstance {
+	result := make([]tcloudInstance, 0)
+	deadline := now.AddDate(0, 0, -longRunningDays)
+	for _, one := range g.Postpaid {
+		if !one.CreatedTime.IsZero() && !one.CreatedTime.After(deadline) {
+			result = append(result, one)
+		}
+	}
+
+	uncovered := len(result) - g.reservedCount()
+	if uncovered <= 0 {
+		return nil
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedTime.After(result[j].CreatedTime) })
+	return result[:uncovered]
+}
+
+// AnalyzeTCloudReservedInstance 对比账号地域下生效中的预留实例与已同步的运行中按量计费实例，分析覆盖率、
+// 未使用预留实例的费用，并对持续运行的未覆盖实例推荐购买一年期预留实例
+func (a *Analyzer) AnalyzeTCloudReservedInstance(kt *kit.Kit, req *asbill.TCloudReservedInstanceAnalysisReq,
+	now time.Time) (*asbill.TCloudReservedInstanceAnalysisResult, error) {
+
+	riReq := &protocvm.TCloudListReservedInstanceReq{
+		AccountID: req.AccountID,
+		Region:    req.Region,
+		States:    []string{tcloudRIActive},
+	}
+	reserved, err := a.Client.HCService().TCloud.Cvm.ListReservedInstance(kt, riReq)
+	if err != nil {
+		logs.Errorf("list tcloud reserved instance failed, err: %v, req: %+v, rid: %s", err, req, kt.Rid)
+		return nil, err
+	}
+
+	groups, err := a.listTCloudPostpaid(kt, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range reserved {
+		key := tcloudInstanceKey{
+			Zone:         converter.PtrToVal(one.Zone),
+			InstanceType: converter.PtrToVal(one.InstanceType),
+		}
+		if _, ok := groups[key]; !ok {
+			groups[key] = new(tcloudGroup)
+		}
+		groups[key].Reserved = append(groups[key].Reserved, one)
+	}
+
+	offerings := make(map[tcloudInstanceKey][]typecvm.TCloudReservedInstanceOffering)
+	candidateIDs := make([]string, 0)
+	for key, group := range groups {
+		candidates := group.candidates(now)
+		if len(group.Reserved) == 0 && len(candidates) == 0 {
+			continue
+		}
+		for _, one := range candidates {
+			candidateIDs = append(candidateIDs, one.CloudID)
+		}
+
+		offeringReq := &protocvm.TCloudListReservedInstanceOfferingReq{
+			AccountID: req.AccountID,
+			TCloudListReservedInstanceOfferingOption: typecvm.TCloudListReservedInstanceOfferingOption{
+				Region:       req.Region,
+				Zone:         key.Zone,
+				InstanceType: key.InstanceType,
+			},
+		}
+		offerings[key], err = a.Client.HCService().TCloud.Cvm.ListReservedInstanceOffering(kt, offeringReq)
+		if err != nil {
+			logs.Errorf("list tcloud reserved instance offering failed, err: %v, key: %+v, rid: %s", err, key,
+				kt.Rid)
+			return nil, err
+		}
+	}
+
+	costs, err := a.listLastMonthCost(kt, candidateIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTCloudResult(groups, offerings, costs, now), nil
+}
+
+// listTCloudPostpaid 查询已同步的运行中按量计费实例，按可用区、实例规格分组
+func (a *Analyzer) listTCloudPostpaid(kt *kit.Kit, req *asbill.TCloudReservedInstanceAnalysisReq) (
+	map[tcloudInstanceKey]*tcloudGroup, error) {
+
+	groups := make(map[tcloudInstanceKey]*tcloudGroup)
+	listReq := &protocloud.CvmListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("account_id", req.AccountID),
+			tools.RuleEqual("region", req.Region),
+			tools.RuleEqual("status", tcloudRunningStatus),
+			tools.RuleJSONEqual("extension.instance_charge_type", tcloudPostpaidByHour),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := a.Client.DataService().TCloud.Cvm.ListCvmExt(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list tcloud postpaid cvm failed, err: %v, req: %+v, rid: %s", err, req, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			key := tcloudInstanceKey{Zone: one.Zone, InstanceType: one.MachineType}
+			if _, ok := groups[key]; !ok {
+				groups[key] = new(tcloudGroup)
+			}
+			groups[key].Postpaid = append(groups[key].Postpaid, newTCloudInstance(one))
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return groups, nil
+}
+
+func newTCloudInstance(cvm corecvm.Cvm[corecvm.TCloudCvmExtension]) tcloudInstance {
+	// 创建时间解析失败时不作为购买候选
+	created, _ := time.Parse(time.RFC3339, cvm.CloudCreatedTime)
+	return tcloudInstance{CloudID: cvm.CloudID, CreatedTime: created}
+}
+
+// listLastMonthCost 查询实例上月账单费用，按实例及币种汇总
+func (a *Analyzer) listLastMonthCost(kt *kit.Kit, cloudIDs []string, now time.Time) (
+	map[string]map[enumor.CurrencyCode]decimal.Decimal, error) {
+
+	costs := make(map[string]map[enumor.CurrencyCode]decimal.Decimal)
+	lastMonth := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	commonOpt := &databill.ItemCommonOpt{
+		Vendor: enumor.TCloud,
+		Year:   lastMonth.Year(),
+		Month:  int(lastMonth.Month()),
+	}
+	for _, ids := range slice.Split(cloudIDs, int(core.DefaultMaxPageLimit)) {
+		listReq := &databill.BillItemListReq{
+			ItemCommonOpt: commonOpt,
+			ListReq: &core.ListReq{
+				Filter: tools.ContainersExpression("cloud_res_id", ids),
+				Page:   core.NewDefaultBasePage(),
+			},
+		}
+		for {
+			result, err := a.Client.DataService().Global.Bill.ListBillItemResCost(kt, listReq)
+			if err != nil {
+				logs.Errorf("list tcloud cvm last month cost failed, err: %v, rid: %s", err, kt.Rid)
+				return nil, err
+			}
+			for _, one := range result.Details {
+				if _, ok := costs[one.CloudResID]; !ok {
+					costs[one.CloudResID] = make(map[enumor.CurrencyCode]decimal.Decimal)
+				}
+				costs[one.CloudResID][one.Currency] = costs[one.CloudResID][one.Currency].Add(one.Cost)
+			}
+
+			if len(result.Details) < int(core.DefaultMaxPageLimit) {
+				break
+			}
+			listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+		}
+	}
+
+	return costs, nil
+}
+
+// hourlyPrice 预留实例按购买时长均摊的每小时费用
+func hourlyPrice(offering typecvm.TCloudReservedInstanceOffering) decimal.Decimal {
+	hours := converter.PtrToVal(offering.Duration) / 3600
+	price := decimal.NewFromFloat(converter.PtrToVal(offering.UsagePrice))
+	if hours > 0 {
+		price = price.Add(decimal.NewFromFloat(converter.PtrToVal(offering.FixedPrice)).
+			Div(decimal.NewFromInt(hours)))
+	}
+	return price
+}
+
+// matchOffering 查找与预留实例购买时长、付费类型、平台一致的售卖规格
+func matchOffering(ri typecvm.TCloudReservedInstance, offerings []typecvm.TCloudReservedInstanceOffering) (
+	typecvm.TCloudReservedInstanceOffering, bool) {
+
+	for _, one := range offerings {
+		if converter.PtrToVal(one.Duration) == converter.PtrToVal(ri.Duration) &&
+			converter.PtrToVal(one.OfferingType) == converter.PtrToVal(ri.OfferingType) &&
+			converter.PtrToVal(one.ProductDescription) == converter.PtrToVal(ri.ProductDescription) {
+			return one, true
+		}
+	}
+	return typecvm.TCloudReservedInstanceOffering{}, false
+}
+
+// cheapestOneYear 均摊每小时费用最低的一年期售卖规格
+func cheapestOneYear(offerings []typecvm.TCloudReservedInstanceOffering) (typecvm.TCloudReservedInstanceOffering,
+	bool) {
+
+	var result typecvm.TCloudReservedInstanceOffering
+	found := false
+	for _, one := range offerings {
+		if converter.PtrToVal(one.Duration) != tcloudOneYearDuration {
+			continue
+		}
+		if !found || hourlyPrice(one).LessThan(hourlyPrice(result)) {
+			result, found = one, true
+		}
+	}
+	return result, found
+}
+
+func buildTCloudResult(groups map[tcloudInstanceKey]*tcloudGroup,
+	offerings map[tcloudInstanceKey][]typecvm.TCloudReservedInstanceOffering,
+	costs map[string]map[enumor.CurrencyCode]decimal.Decimal,
+	now time.Time) *asbill.TCloudReservedInstanceAnalysisResult {
+
+	result := &asbill.TCloudReservedInstanceAnalysisResult{
+		Items:           make([]asbill.TCloudReservedInstanceCoverage, 0, len(groups)),
+		Recommendations: make([]asbill.TCloudReservedInstanceRecommendation, 0),
+	}
+	monthHours := decimal.NewFromInt(hoursPerMonth)
+	for key, group := range groups {
+		reservedCount := group.reservedCount()
+		covered := min(reservedCount, len(group.Postpaid))
+		coveredNum := decimal.NewFromInt(int64(covered))
+		item := asbill.TCloudReservedInstanceCoverage{
+			Zone:            key.Zone,
+			InstanceType:    key.InstanceType,
+			ReservedCount:   reservedCount,
+			PostpaidCount:   len(group.Postpaid),
+			CoveredCount:    covered,
+			UnusedCount:     reservedCount - covered,
+			CoverageRate:    percent(coveredNum, decimal.NewFromInt(int64(len(group.Postpaid)))),
+			UtilizationRate: percent(coveredNum, decimal.NewFromInt(int64(reservedCount))),
+		}
+
+		// 未使用数量按各预留实例数量加权的均摊单价计算费用
+		if item.UnusedCount > 0 {
+			total, count := decimal.Zero, int64(0)
+			for _, ri := range group.Reserved {
+				offering, ok := matchOffering(ri, offerings[key])
+				if !ok {
+					continue
+				}
+				item.Currency = converter.PtrToVal(offering.CurrencyCode)
+				total = total.Add(hourlyPrice(offering).Mul(decimal.NewFromInt(converter.PtrToVal(ri.InstanceCount))))
+				count += converter.PtrToVal(ri.InstanceCount)
+			}
+			if count > 0 {
+				item.UnusedMonthlyCost = total.Div(decimal.NewFromInt(count)).
+					Mul(decimal.NewFromInt(int64(item.UnusedCount))).Mul(monthHours).Round(2)
+			}
+		}
+		result.Items = append(result.Items, item)
+		result.ReservedCount += reservedCount
+		result.PostpaidCount += len(group.Postpaid)
+		result.CoveredCount += covered
+
+		if recommend, ok := recommendTCloud(key, group.candidates(now), offerings[key], costs); ok {
+			result.Recommendations = append(result.Recommendations, recommend)
+		}
+	}
+	result.CoverageRate = percent(decimal.NewFromInt(int64(result.CoveredCount)),
+		decimal.NewFromInt(int64(result.PostpaidCount)))
+	result.UtilizationRate = percent(decimal.NewFromInt(int64(result.CoveredCount)),
+		decimal.NewFromInt(int64(result.ReservedCount)))
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		if result.Items[i].Zone != result.Items[j].Zone {
+			return result.Items[i].Zone < result.Items[j].Zone
+		}
+		return result.Items[i].InstanceType < result.Items[j].InstanceType
+	})
+	sort.Slice(result.Recommendations, func(i, j int) bool {
+		return result.Recommendations[i].EstimatedMonthlySavings.GreaterThan(
+			result.Recommendations[j].EstimatedMonthlySavings)
+	})
+	return result
+}
+
+// recommendTCloud 候选实例上月费用高于一年期预留实例均摊费用时推荐购买，无上月账单的实例不计入推荐数量
+func recommendTCloud(key tcloudInstanceKey, candidates []tcloudInstance,
+	offerings []typecvm.TCloudReservedInstanceOffering, costs map[string]map[enumor.CurrencyCode]decimal.Decimal) (
+	asbill.TCloudReservedInstanceRecommendation, bool) {
+
+	offering, ok := cheapestOneYear(offerings)
+	if !ok || len(candidates) == 0 {
+		return asbill.TCloudReservedInstanceRecommendation{}, false
+	}
+
+	currency := enumor.CurrencyCode(converter.PtrToVal(offering.CurrencyCode))
+	onDemand, count := decimal.Zero, 0
+	for _, one := range candidates {
+		cost, exists := costs[one.CloudID][currency]
+		if !exists || !cost.IsPositive() {
+			continue
+		}
+		onDemand = onDemand.Add(cost)
+		count++
+	}
+	if count == 0 {
+		return asbill.TCloudReservedInstanceRecommendation{}, false
+	}
+
+	reserved := hourlyPrice(offering).Mul(decimal.NewFromInt(hoursPerMonth)).Mul(decimal.NewFromInt(int64(count)))
+	savings := onDemand.Sub(reserved)
+	if !savings.IsPositive() {
+		return asbill.TCloudReservedInstanceRecommendation{}, false
+	}
+
+	return asbill.TCloudReservedInstanceRecommendation{
+		Zone:                    key.Zone,
+		InstanceType:            key.InstanceType,
+		Count:                   count,
+		OfferingID:              converter.PtrToVal(offering.ReservedInstancesOfferingId),
+		OfferingType:            converter.PtrToVal(offering.OfferingType),
+		Currency:                string(currency),
+		ReservedMonthlyCost:     reserved.Round(2),
+		OnDemandMonthlyCost:     onDemand.Round(2),
+		EstimatedMonthlySavings: savings.Round(2),
+	}, true
+}
@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"time"
 
+	"hcm/cmd/account-server/logics/bill/monthtask"
 	"hcm/cmd/account-server/logics/bill/puller"
 	"hcm/cmd/task-server/logics/action/bill/dailysplit"
 	"hcm/pkg/api/core"
@@ -102,17 +103,24 @@ func (msdc *MainDailySplitController) Start() error {
 	msdc.kt = kt
 	msdc.cancelFunc = cancelFunc
 
-	if msdc.Vendor == enumor.Aws {
-		if err := msdc.setAwsExtension(kt); err != nil {
-			return err
-		}
-	}
 	go msdc.runBillDailySplitLoop(kt)
 	return nil
 }
 
+// setAwsExtension 优先使用一级账号的savings plans分摊规则，未配置规则时使用配置文件，每轮同步前刷新以便规则变更生效
 func (msdc *MainDailySplitController) setAwsExtension(kt *kit.Kit) error {
+	spArnPrefix, found, err := monthtask.GetSavingsPlanArnPrefix(kt, msdc.Client.DataService(), msdc.RootAccountID)
+	if err != nil {
+		logs.Errorf("get savings plans allocation rule of root account %s failed, err: %v, rid: %s",
+			msdc.RootAccountID, err, kt.Rid)
+		return err
+	}
+	if found {
+		msdc.ext = dailysplit.BuildAwsDailySplitOptionExt(spArnPrefix)
+		return nil
+	}
 
+	msdc.ext = nil
 	billAllocation := cc.AccountServer().BillAllocation
 	// matching saving plan allocation option
 	for _, spOpt := range billAllocation.AwsSavingsPlans {
@@ -147,6 +155,11 @@ func (msdc *MainDailySplitController) runBillDailySplitLoop(kt *kit.Kit) {
 }
 
 func (msdc *MainDailySplitController) doSync(kt *kit.Kit) error {
+	if msdc.Vendor == enumor.Aws {
+		if err := msdc.setAwsExtension(kt); err != nil {
+			return err
+		}
+	}
 	curBillYear, curBillMonth := times.GetCurrentMonthUTC()
 	if err := msdc.syncDailySplit(kt.NewSubKit(), curBillYear, curBillMonth); err != nil {
 		return fmt.Errorf("ensure daily split for %d %d failed, err %s", curBillYear, curBillMonth, err.Error())
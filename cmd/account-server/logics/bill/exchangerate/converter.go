@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package exchangerate
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+// Converter 币种换算器，优先使用直接汇率，其次使用反向汇率，最后通过人民币中转
+type Converter struct {
+	rates map[string]decimal.Decimal
+}
+
+// NewConverter ...
+func NewConverter(rates []*bill.ExchangeRate) *Converter {
+	c := &Converter{rates: make(map[string]decimal.Decimal, len(rates))}
+	for _, rate := range rates {
+		if rate == nil || rate.ExchangeRate == nil || !rate.ExchangeRate.IsPositive() {
+			continue
+		}
+		c.rates[pairKey(rate.FromCurrency, rate.ToCurrency)] = *rate.ExchangeRate
+	}
+	return c
+}
+
+// Rate 获取 from 到 to 的汇率
+func (c *Converter) Rate(from, to enumor.CurrencyCode) (decimal.Decimal, error) {
+	if rate, ok := c.pairRate(from, to); ok {
+		return rate, nil
+	}
+
+	fromRMB, ok := c.pairRate(from, enumor.CurrencyRMB)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("exchange rate of %s->%s not found", from, to)
+	}
+	rmbTo, ok := c.pairRate(enumor.CurrencyRMB, to)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("exchange rate of %s->%s not found", from, to)
+	}
+	return fromRMB.Mul(rmbTo), nil
+}
+
+// Convert 将 from 币种的金额换算为 to 币种
+func (c *Converter) Convert(amount decimal.Decimal, from, to enumor.CurrencyCode) (decimal.Decimal, error) {
+	rate, err := c.Rate(from, to)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return amount.Mul(rate), nil
+}
+
+// ConvertCostMap 将各币种的原币费用换算为 to 币种后汇总
+func (c *Converter) ConvertCostMap(costMap map[enumor.CurrencyCode]*bill.CostWithCurrency,
+	to enumor.CurrencyCode) (*bill.ConvertedCost, error) {
+
+	result := &bill.ConvertedCost{Currency: to, Cost: decimal.Zero}
+	for currency, cost := range costMap {
+		if cost == nil {
+			continue
+		}
+		converted, err := c.Convert(cost.Cost, currency, to)
+		if err != nil {
+			return nil, err
+		}
+		result.Cost = result.Cost.Add(converted)
+	}
+	return result, nil
+}
+
+func (c *Converter) pairRate(from, to enumor.CurrencyCode) (decimal.Decimal, bool) {
+	if from == to {
+		return decimal.NewFromInt(1), true
+	}
+	if rate, ok := c.rates[pairKey(from, to)]; ok {
+		return rate, true
+	}
+	if rate, ok := c.rates[pairKey(to, from)]; ok {
+		return decimal.NewFromInt(1).Div(rate), true
+	}
+	return decimal.Zero, false
+}
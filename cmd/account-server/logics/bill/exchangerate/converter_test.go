@@ -0,0 +1,90 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package exchangerate
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestConverter(t *testing.T) {
+	newRate := func(from, to enumor.CurrencyCode, rate string) *bill.ExchangeRate {
+		d := decimal.RequireFromString(rate)
+		return &bill.ExchangeRate{FromCurrency: from, ToCurrency: to, ExchangeRate: &d}
+	}
+	c := NewConverter([]*bill.ExchangeRate{
+		newRate(enumor.CurrencyUSD, enumor.CurrencyCNY, "7"),
+		newRate(enumor.CurrencyHKD, enumor.CurrencyCNY, "0.9"),
+		newRate(enumor.CurrencyCNY, enumor.CurrencyJPY, "20"),
+	})
+
+	cases := []struct {
+		from, to enumor.CurrencyCode
+		amount   string
+		expect   string
+		wantErr  bool
+	}{
+		{from: enumor.CurrencyUSD, to: enumor.CurrencyUSD, amount: "10", expect: "10"},
+		{from: enumor.CurrencyUSD, to: enumor.CurrencyCNY, amount: "10", expect: "70"},
+		{from: enumor.CurrencyCNY, to: enumor.CurrencyUSD, amount: "70", expect: "10"},
+		{from: enumor.CurrencyUSD, to: enumor.CurrencyJPY, amount: "1", expect: "140"},
+		{from: enumor.CurrencyUSD, to: enumor.CurrencyHKD, amount: "9", expect: "70"},
+		{from: enumor.CurrencyUSD, to: enumor.CurrencyEUR, amount: "1", wantErr: true},
+	}
+	for _, one := range cases {
+		got, err := c.Convert(decimal.RequireFromString(one.amount), one.from, one.to)
+		if one.wantErr {
+			if err == nil {
+				t.Errorf("convert %s->%s expect error, got nil", one.from, one.to)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("convert %s->%s failed, err: %v", one.from, one.to, err)
+			continue
+		}
+		if !got.Round(6).Equal(decimal.RequireFromString(one.expect)) {
+			t.Errorf("convert %s->%s expect %s, got %s", one.from, one.to, one.expect, got.String())
+		}
+	}
+}
+
+func TestCSVProvider(t *testing.T) {
+	content := "from_currency,to_currency,exchange_rate\nusd, CNY, 7.12\nHKD,CNY,0.91\n"
+	rates, err := NewCSVProvider([]byte(content)).Fetch(nil, 2024, 10)
+	if err != nil {
+		t.Fatalf("parse csv failed, err: %v", err)
+	}
+	if len(rates) != 2 || rates[0].FromCurrency != enumor.CurrencyUSD ||
+		!rates[0].ExchangeRate.Equal(decimal.RequireFromString("7.12")) {
+		t.Errorf("unexpected rates: %+v", rates)
+	}
+
+	if _, err = NewCSVProvider([]byte("USD,CNY,7\nUSD,CNY,7.1\n")).Fetch(nil, 2024, 10); err == nil {
+		t.Errorf("duplicate rates expect error, got nil")
+	}
+	if _, err = NewCSVProvider([]byte("USD,XXX,7\n")).Fetch(nil, 2024, 10); err == nil {
+		t.Errorf("unsupported currency expect error, got nil")
+	}
+}
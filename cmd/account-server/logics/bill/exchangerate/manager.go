@@ -0,0 +1,246 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package exchangerate
+
+import (
+	"context"
+	"time"
+
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/serviced"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// Manager 汇率管理，负责保存汇率、定时从汇率服务同步及月末锁定
+type Manager struct {
+	Sd     serviced.ServiceDiscover
+	Client *client.ClientSet
+	// Provider 自动同步使用的汇率来源，为空时只做月末锁定
+	Provider Provider
+}
+
+// Run 定时同步当月汇率，并在月末之后同步并锁定上月汇率，只在主节点执行
+func (m *Manager) Run(ctx context.Context) {
+	opt := cc.AccountServer().ExchangeRate
+	if opt.Disable {
+		logs.Infof("bill exchange rate manager is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(*opt.SyncDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !m.Sd.IsMaster() {
+				continue
+			}
+
+			kt := core.NewBackendKit()
+			if err := m.syncAndClose(kt, time.Now()); err != nil {
+				logs.Errorf("sync bill exchange rate failed, err: %v, rid: %s", err, kt.Rid)
+			}
+		case <-ctx.Done():
+			logs.Infof("bill exchange rate manager context done")
+			return
+		}
+	}
+}
+
+func (m *Manager) syncAndClose(kt *kit.Kit, now time.Time) error {
+	// 上月已结束，先拉取最终汇率再锁定，锁定后同步会跳过该月
+	last := now.AddDate(0, 0, -now.Day())
+	if err := m.closeMonth(kt, last.Year(), int(last.Month())); err != nil {
+		return err
+	}
+
+	if m.Provider == nil {
+		return nil
+	}
+	_, err := m.Sync(kt, m.Provider, now.Year(), int(now.Month()))
+	return err
+}
+
+func (m *Manager) closeMonth(kt *kit.Kit, year, month int) error {
+	rates, err := m.ListMonthRates(kt, year, month)
+	if err != nil {
+		return err
+	}
+	unlocked := 0
+	for _, rate := range rates {
+		if !cvt.PtrToVal(rate.Locked) {
+			unlocked++
+		}
+	}
+	if len(rates) != 0 && unlocked == 0 {
+		return nil
+	}
+
+	if m.Provider != nil {
+		if _, err = m.Sync(kt, m.Provider, year, month); err != nil {
+			return err
+		}
+	}
+	result, err := m.Lock(kt, year, month)
+	if err != nil {
+		return err
+	}
+	logs.Infof("bill exchange rate of %d-%02d locked, count: %d, rid: %s", year, month, result.Locked, kt.Rid)
+	return nil
+}
+
+// Sync 从汇率来源获取指定月份汇率并保存
+func (m *Manager) Sync(kt *kit.Kit, provider Provider, year, month int) (*asbill.ExchangeRateSaveResult, error) {
+	rates, err := provider.Fetch(kt, year, month)
+	if err != nil {
+		logs.Errorf("fetch exchange rate of %d-%02d from %s failed, err: %v, rid: %s",
+			year, month, provider.Source(), err, kt.Rid)
+		return nil, err
+	}
+	return m.Save(kt, provider.Source(), year, month, rates)
+}
+
+// Save 保存指定月份的汇率，已存在的汇率覆盖更新，已锁定的汇率跳过
+func (m *Manager) Save(kt *kit.Kit, source enumor.ExchangeRateSource, year, month int, rates []Rate) (
+	*asbill.ExchangeRateSaveResult, error) {
+
+	existRates, err := m.ListMonthRates(kt, year, month)
+	if err != nil {
+		return nil, err
+	}
+	existMap := make(map[string]*bill.ExchangeRate, len(existRates))
+	for _, one := range existRates {
+		existMap[pairKey(one.FromCurrency, one.ToCurrency)] = one
+	}
+
+	result := new(asbill.ExchangeRateSaveResult)
+	createList := make([]dsbill.ExchangeRateCreate, 0)
+	for _, rate := range rates {
+		exist, ok := existMap[pairKey(rate.FromCurrency, rate.ToCurrency)]
+		if !ok {
+			createList = append(createList, dsbill.ExchangeRateCreate{
+				Year:         year,
+				Month:        month,
+				FromCurrency: rate.FromCurrency,
+				ToCurrency:   rate.ToCurrency,
+				ExchangeRate: cvt.ValToPtr(rate.ExchangeRate),
+				Source:       source,
+			})
+			continue
+		}
+		if cvt.PtrToVal(exist.Locked) {
+			result.SkippedLocked++
+			continue
+		}
+		if exist.ExchangeRate != nil && exist.ExchangeRate.Equal(rate.ExchangeRate) && exist.Source == source {
+			continue
+		}
+
+		updateReq := &dsbill.ExchangeRateUpdateReq{
+			ID:           exist.ID,
+			ExchangeRate: cvt.ValToPtr(rate.ExchangeRate),
+			Source:       source,
+		}
+		if err = m.Client.DataService().Global.Bill.UpdateExchangeRate(kt, updateReq); err != nil {
+			logs.Errorf("update exchange rate %s failed, err: %v, rid: %s", exist.ID, err, kt.Rid)
+			return nil, err
+		}
+		result.Updated++
+	}
+
+	for _, batch := range slice.Split(createList, constant.BatchOperationMaxLimit) {
+		createReq := &dsbill.BatchCreateBillExchangeRateReq{ExchangeRates: batch}
+		if _, err = m.Client.DataService().Global.Bill.BatchCreateExchangeRate(kt, createReq); err != nil {
+			logs.Errorf("create exchange rate of %d-%02d failed, err: %v, rid: %s", year, month, err, kt.Rid)
+			return nil, err
+		}
+		result.Created += len(batch)
+	}
+	return result, nil
+}
+
+// Lock 锁定指定月份的所有汇率
+func (m *Manager) Lock(kt *kit.Kit, year, month int) (*asbill.ExchangeRateLockResult, error) {
+	rates, err := m.ListMonthRates(kt, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(asbill.ExchangeRateLockResult)
+	for _, rate := range rates {
+		if cvt.PtrToVal(rate.Locked) {
+			continue
+		}
+		updateReq := &dsbill.ExchangeRateUpdateReq{ID: rate.ID, Locked: cvt.ValToPtr(true)}
+		if err = m.Client.DataService().Global.Bill.UpdateExchangeRate(kt, updateReq); err != nil {
+			logs.Errorf("lock exchange rate %s failed, err: %v, rid: %s", rate.ID, err, kt.Rid)
+			return nil, err
+		}
+		result.Locked++
+	}
+	return result, nil
+}
+
+// ListMonthRates 获取指定月份的所有汇率
+func (m *Manager) ListMonthRates(kt *kit.Kit, year, month int) ([]*bill.ExchangeRate, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("year", year),
+			tools.RuleEqual("month", month),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	rates := make([]*bill.ExchangeRate, 0)
+	for {
+		result, err := m.Client.DataService().Global.Bill.ListExchangeRate(kt, listReq)
+		if err != nil {
+			logs.Errorf("list exchange rate of %d-%02d failed, err: %v, rid: %s", year, month, err, kt.Rid)
+			return nil, err
+		}
+		for idx := range result.Details {
+			rates = append(rates, &result.Details[idx])
+		}
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+	return rates, nil
+}
+
+// NewConverter 根据指定月份的汇率创建币种换算器
+func (m *Manager) NewConverter(kt *kit.Kit, year, month int) (*Converter, error) {
+	rates, err := m.ListMonthRates(kt, year, month)
+	if err != nil {
+		return nil, err
+	}
+	return NewConverter(rates), nil
+}
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package exchangerate 汇率拉取、导入、月末锁定及币种换算
+package exchangerate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rate 单个币种对的汇率，1单位 FromCurrency 等于 ExchangeRate 单位 ToCurrency
+type Rate struct {
+	FromCurrency enumor.CurrencyCode `json:"from_currency"`
+	ToCurrency   enumor.CurrencyCode `json:"to_currency"`
+	ExchangeRate decimal.Decimal     `json:"exchange_rate"`
+}
+
+// Validate ...
+func (r Rate) Validate() error {
+	if err := r.FromCurrency.Validate(); err != nil {
+		return err
+	}
+	if err := r.ToCurrency.Validate(); err != nil {
+		return err
+	}
+	if r.FromCurrency == r.ToCurrency {
+		return fmt.Errorf("from_currency and to_currency can not be the same: %s", r.FromCurrency)
+	}
+	if !r.ExchangeRate.IsPositive() {
+		return fmt.Errorf("exchange rate of %s->%s must be positive", r.FromCurrency, r.ToCurrency)
+	}
+	return nil
+}
+
+// Provider 汇率来源
+type Provider interface {
+	// Source 汇率来源类型，记录在汇率表中
+	Source() enumor.ExchangeRateSource
+	// Fetch 获取指定月份的汇率
+	Fetch(kt *kit.Kit, year, month int) ([]Rate, error)
+}
+
+func validateRates(rates []Rate) error {
+	exists := make(map[string]struct{}, len(rates))
+	for idx, rate := range rates {
+		if err := rate.Validate(); err != nil {
+			return fmt.Errorf("rates[%d] is invalid, err: %v", idx, err)
+		}
+		key := pairKey(rate.FromCurrency, rate.ToCurrency)
+		if _, ok := exists[key]; ok {
+			return fmt.Errorf("duplicate exchange rate of %s->%s", rate.FromCurrency, rate.ToCurrency)
+		}
+		exists[key] = struct{}{}
+	}
+	return nil
+}
+
+func pairKey(from, to enumor.CurrencyCode) string {
+	return string(from) + "->" + string(to)
+}
+
+// NewCSVProvider 通过CSV文件导入汇率，每行格式为: from_currency,to_currency,exchange_rate，首行可以为表头
+func NewCSVProvider(content []byte) Provider {
+	return &csvProvider{content: content}
+}
+
+type csvProvider struct {
+	content []byte
+}
+
+// Source ...
+func (p *csvProvider) Source() enumor.ExchangeRateSource {
+	return enumor.ExchangeRateSourceImport
+}
+
+// Fetch 文件内容与月份无关，月份由导入请求指定
+func (p *csvProvider) Fetch(_ *kit.Kit, _, _ int) ([]Rate, error) {
+	reader := csv.NewReader(bytes.NewReader(p.content))
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	rates := make([]Rate, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv line %d failed, err: %v", line, err)
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "from_currency") {
+			continue
+		}
+
+		rate, err := decimal.NewFromString(strings.TrimSpace(record[2]))
+		if err != nil {
+			return nil, fmt.Errorf("parse exchange rate of csv line %d failed, err: %v", line, err)
+		}
+		rates = append(rates, Rate{
+			FromCurrency: enumor.CurrencyCode(strings.ToUpper(strings.TrimSpace(record[0]))),
+			ToCurrency:   enumor.CurrencyCode(strings.ToUpper(strings.TrimSpace(record[1]))),
+			ExchangeRate: rate,
+		})
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("no exchange rate found in csv file")
+	}
+
+	if err := validateRates(rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// NewHTTPProvider 从HTTP汇率服务拉取汇率，请求方式为 GET {endpoint}?year=2024&month=10，
+// 返回格式为 {"rates":[{"from_currency":"USD","to_currency":"CNY","exchange_rate":"7.1"}]}，
+// 可以用本地的桩服务替换真实的汇率服务
+func NewHTTPProvider(opt cc.ExchangeRateProviderOption) Provider {
+	cli := &http.Client{}
+	if opt.Timeout != nil {
+		cli.Timeout = *opt.Timeout
+	}
+	return &httpProvider{endpoint: opt.Endpoint, client: cli}
+}
+
+type httpProvider struct {
+	endpoint string
+	client   *http.Client
+}
+
+type httpRateResp struct {
+	Rates []Rate `json:"rates"`
+}
+
+// Source ...
+func (p *httpProvider) Source() enumor.ExchangeRateSource {
+	return enumor.ExchangeRateSourceHTTP
+}
+
+// Fetch ...
+func (p *httpProvider) Fetch(kt *kit.Kit, year, month int) ([]Rate, error) {
+	u, err := url.Parse(p.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse exchange rate endpoint failed, err: %v", err)
+	}
+	query := u.Query()
+	query.Set("year", strconv.Itoa(year))
+	query.Set("month", strconv.Itoa(month))
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(constant.RidKey, kt.Rid)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request exchange rate provider failed, err: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchange rate provider return unexpected status code: %d", resp.StatusCode)
+	}
+
+	result := new(httpRateResp)
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("decode exchange rate provider response failed, err: %v", err)
+	}
+
+	if err = validateRates(result.Rates); err != nil {
+		return nil, err
+	}
+	return result.Rates, nil
+}
@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import "hcm/pkg/logs"
+
+// AzureBillItemHeaders is the headers of Azure bill item.
+var AzureBillItemHeaders []string
+
+func init() {
+	var err error
+	AzureBillItemHeaders, err = AzureBillItemTable{}.GetHeaders()
+	if err != nil {
+		logs.Errorf("GetAzureHeader failed: %v", err)
+	}
+}
+
+var _ Table = (*AzureBillItemTable)(nil)
+
+// AzureBillItemTable azure账单导出表结构
+type AzureBillItemTable struct {
+	Site        string `header:"站点类型"`
+	AccountDate string `header:"核算年月"`
+
+	BizID   string `header:"业务"`
+	BizName string `header:"业务名称"`
+
+	RootAccountName string `header:"一级账号名称"`
+	MainAccountName string `header:"二级账号名称"`
+	Region          string `header:"地域"`
+
+	SubscriptionName string `header:"订阅名称"`
+	ResourceGroup    string `header:"资源组"`
+	ConsumedService  string `header:"服务"`
+	Product          string `header:"产品名称"`
+	MeterCategory    string `header:"计量类别"`
+	MeterSubCategory string `header:"计量子类别"`
+	MeterName        string `header:"计量名称"`
+	ResourceID       string `header:"资源ID"`
+	ChargeType       string `header:"计费类型"`
+	PricingModel     string `header:"计费方式"`
+	UsageDate        string `header:"使用日期"`
+	Quantity         string `header:"用量"`
+	UnitOfMeasure    string `header:"单位"`
+	UnitPrice        string `header:"单价"`
+	Cost             string `header:"外币成本（元）"`
+	Currency         string `header:"外币种类"`
+	RMBCost          string `header:"人民币成本（元）"`
+	Rate             string `header:"汇率"`
+}
+
+// GetHeaders ...
+func (t AzureBillItemTable) GetHeaders() ([]string, error) {
+	return parseHeader(t)
+}
+
+// GetHeaderValues 获取表头对应的数据
+func (t AzureBillItemTable) GetHeaderValues() ([]string, error) {
+	return parseHeaderFields(t)
+}
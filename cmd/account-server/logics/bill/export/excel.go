@@ -20,9 +20,7 @@
 package export
 
 import (
-	"bytes"
-
-	"hcm/pkg/logs"
+	"io"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -31,30 +29,56 @@ const (
 	defaultSheetName = "Sheet1"
 )
 
-// GenerateExcel 生成 Excel 文件
-func GenerateExcel(data [][]interface{}) (*bytes.Buffer, error) {
-	// 创建一个新的 Excel 文档
-	f := excelize.NewFile()
+var _ RowWriter = (*ExcelWriter)(nil)
+
+// ExcelWriter 按行流式写入 Excel 文件，写入完成后调用 Flush 输出文件内容
+type ExcelWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
 
-	// 设置工作表的名称为 "Sheet1"
-	_, err := f.NewSheet(defaultSheetName)
+// NewExcelWriter ...
+func NewExcelWriter() (*ExcelWriter, error) {
+	f := excelize.NewFile()
+	stream, err := f.NewStreamWriter(defaultSheetName)
 	if err != nil {
 		return nil, err
 	}
+	return &ExcelWriter{file: f, stream: stream}, nil
+}
+
+// Write 写入一行数据
+func (w *ExcelWriter) Write(record []string) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(record))
+	for idx := range record {
+		values[idx] = record[idx]
+	}
+	return w.stream.SetRow(cell, values)
+}
 
-	// 写入数据到 Excel
-	for row, rowData := range data {
-		for col, value := range rowData {
-			cell, err := excelize.CoordinatesToCellName(col+1, row+1)
-			if err != nil {
-				return nil, err
-			}
-			if err = f.SetCellValue(defaultSheetName, cell, value); err != nil {
-				logs.Errorf("write value (%v) to cell[%d,%d] error: %v", value, col+1, row+1, err.Error())
-				return nil, err
-			}
+// WriteAll 写入多行数据
+func (w *ExcelWriter) WriteAll(records [][]string) error {
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	return f.WriteToBuffer()
+// Flush 结束写入并将文件内容输出到 writer
+func (w *ExcelWriter) Flush(writer io.Writer) error {
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+	if _, err := w.file.WriteTo(writer); err != nil {
+		return err
+	}
+	return w.file.Close()
 }
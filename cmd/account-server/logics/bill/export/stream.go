@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"hcm/pkg/dal/objectstore"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+var _ RowWriter = (*ObjectStoreWriter)(nil)
+
+// ObjectStoreWriter 将导出数据按行写入zip压缩的csv文件，并通过管道流式上传至对象存储，导出数据不落地本地文件也不在内存中堆积
+type ObjectStoreWriter struct {
+	*csv.Writer
+	pipe   *io.PipeWriter
+	zip    *zip.Writer
+	done   chan error
+	closed bool
+}
+
+// NewObjectStoreWriter 创建对象存储流式写入器，filename 为zip包内的csv文件名，uploadPath 为对象存储路径。
+// 写入完成后必须调用 Close 等待上传结束，写入失败时调用 Abort 终止上传。
+func NewObjectStoreWriter(kt *kit.Kit, storage objectstore.Storage, uploadPath, filename string) (
+	*ObjectStoreWriter, error) {
+
+	if storage == nil {
+		return nil, fmt.Errorf("object store is not configured")
+	}
+
+	reader, pipe := io.Pipe()
+	w := &ObjectStoreWriter{pipe: pipe, done: make(chan error, 1)}
+	go func() {
+		err := storage.Upload(kt, uploadPath, reader)
+		// 上传提前结束时关闭读端，避免写端阻塞
+		reader.CloseWithError(err)
+		w.done <- err
+	}()
+
+	w.zip = zip.NewWriter(pipe)
+	file, err := w.zip.Create(filename)
+	if err != nil {
+		w.Abort(err)
+		return nil, err
+	}
+	if w.Writer, err = NewCsvWriter(kt, file); err != nil {
+		w.Abort(err)
+		return nil, err
+	}
+	return w, nil
+}
+
+// Close 结束写入并等待上传完成
+func (w *ObjectStoreWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+
+	w.Writer.Flush()
+	if err := w.Writer.Error(); err != nil {
+		w.Abort(err)
+		return err
+	}
+	if err := w.zip.Close(); err != nil {
+		w.Abort(err)
+		return err
+	}
+
+	w.closed = true
+	if err := w.pipe.Close(); err != nil {
+		<-w.done
+		return err
+	}
+	return <-w.done
+}
+
+// Abort 终止上传，已上传的部分数据由对象存储丢弃
+func (w *ObjectStoreWriter) Abort(cause error) {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	if err := w.pipe.CloseWithError(cause); err != nil {
+		logs.Errorf("close object store pipe failed, err: %v", err)
+	}
+	<-w.done
+}
@@ -0,0 +1,105 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"testing"
+
+	"hcm/pkg/dal/objectstore"
+	"hcm/pkg/kit"
+)
+
+type memStorage struct {
+	objectstore.Storage
+	data map[string][]byte
+	err  error
+}
+
+func (m *memStorage) Upload(_ *kit.Kit, uploadPath string, r io.Reader) error {
+	if m.err != nil {
+		return m.err
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.data[uploadPath] = content
+	return nil
+}
+
+func TestObjectStoreWriter(t *testing.T) {
+	storage := &memStorage{data: make(map[string][]byte)}
+	writer, err := NewObjectStoreWriter(kit.New(), storage, "bill/item.zip", "item.csv")
+	if err != nil {
+		t.Fatalf("new object store writer failed, err: %v", err)
+	}
+	if err = writer.Write([]string{"id", "cost"}); err != nil {
+		t.Fatalf("write header failed, err: %v", err)
+	}
+	if err = writer.WriteAll([][]string{{"1", "1.5"}, {"2", "2.5"}}); err != nil {
+		t.Fatalf("write rows failed, err: %v", err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatalf("close writer failed, err: %v", err)
+	}
+
+	content := storage.data["bill/item.zip"]
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("read uploaded zip failed, err: %v", err)
+	}
+	if len(zipReader.File) != 1 || zipReader.File[0].Name != "item.csv" {
+		t.Fatalf("uploaded zip should only contain item.csv")
+	}
+	file, err := zipReader.File[0].Open()
+	if err != nil {
+		t.Fatalf("open item.csv failed, err: %v", err)
+	}
+	defer file.Close()
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("read item.csv failed, err: %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(raw, bomHeader))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse item.csv failed, err: %v", err)
+	}
+	if len(records) != 3 || records[2][1] != "2.5" {
+		t.Errorf("unexpected csv records: %v", records)
+	}
+}
+
+func TestObjectStoreWriterUploadFailed(t *testing.T) {
+	storage := &memStorage{data: make(map[string][]byte), err: errors.New("upload failed")}
+	writer, err := NewObjectStoreWriter(kit.New(), storage, "bill/item.zip", "item.csv")
+	if err != nil {
+		// 上传协程先于zip头写入结束时，创建写入器即返回错误
+		return
+	}
+	_ = writer.Write([]string{"id", "cost"})
+	if err = writer.Close(); err == nil {
+		t.Errorf("close writer should return upload error")
+	}
+}
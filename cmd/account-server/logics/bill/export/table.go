@@ -33,6 +33,12 @@ type Table interface {
 	GetHeaders() ([]string, error)
 }
 
+// RowWriter 导出数据按行写入，csv.Writer 及 ExcelWriter 均实现了该接口
+type RowWriter interface {
+	Write(record []string) error
+	WriteAll(records [][]string) error
+}
+
 func parseHeaderFields(obj interface{}) ([]string, error) {
 	rt := reflect.TypeOf(obj)
 	rv := reflect.ValueOf(obj)
@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import "hcm/pkg/logs"
+
+// TCloudBillItemHeaders is the headers of TCloud bill item.
+var TCloudBillItemHeaders []string
+
+func init() {
+	var err error
+	TCloudBillItemHeaders, err = TCloudBillItemTable{}.GetHeaders()
+	if err != nil {
+		logs.Errorf("GetTCloudHeader failed: %v", err)
+	}
+}
+
+var _ Table = (*TCloudBillItemTable)(nil)
+
+// TCloudBillItemTable tcloud账单导出表结构
+type TCloudBillItemTable struct {
+	Site        string `header:"站点类型"`
+	AccountDate string `header:"核算年月"`
+
+	BizID   string `header:"业务"`
+	BizName string `header:"业务名称"`
+
+	RootAccountName string `header:"一级账号名称"`
+	MainAccountName string `header:"二级账号名称"`
+	Region          string `header:"地域"`
+
+	ZoneName       string `header:"可用区"`
+	BusinessCode   string `header:"产品编码"`
+	BusinessName   string `header:"产品名称"`
+	ProductCode    string `header:"子产品编码"`
+	ProductName    string `header:"子产品名称"`
+	ProjectName    string `header:"项目名称"`
+	PayModeName    string `header:"计费模式"`
+	ActionTypeName string `header:"交易类型"`
+	ResourceID     string `header:"资源ID"`
+	ResourceName   string `header:"资源名称"`
+	FeeBeginTime   string `header:"扣费开始时间"`
+	FeeEndTime     string `header:"扣费结束时间"`
+	Usage          string `header:"用量"`
+	UsageUnit      string `header:"用量单位"`
+	Currency       string `header:"币种"`
+	Cost           string `header:"成本（元）"`
+	RMBCost        string `header:"人民币成本（元）"`
+	Rate           string `header:"汇率"`
+}
+
+// GetHeaders ...
+func (t TCloudBillItemTable) GetHeaders() ([]string, error) {
+	return parseHeader(t)
+}
+
+// GetHeaderValues 获取表头对应的数据
+func (t TCloudBillItemTable) GetHeaderValues() ([]string, error) {
+	return parseHeaderFields(t)
+}
@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package export
+
+import "hcm/pkg/logs"
+
+// ZenlayerBillItemHeaders is the headers of Zenlayer bill item.
+var ZenlayerBillItemHeaders []string
+
+func init() {
+	var err error
+	ZenlayerBillItemHeaders, err = ZenlayerBillItemTable{}.GetHeaders()
+	if err != nil {
+		logs.Errorf("GetZenlayerHeader failed: %v", err)
+	}
+}
+
+var _ Table = (*ZenlayerBillItemTable)(nil)
+
+// ZenlayerBillItemTable zenlayer账单导出表结构
+type ZenlayerBillItemTable struct {
+	Site        string `header:"站点类型"`
+	AccountDate string `header:"核算年月"`
+
+	BizID   string `header:"业务"`
+	BizName string `header:"业务名称"`
+
+	RootAccountName string `header:"一级账号名称"`
+	MainAccountName string `header:"二级账号名称"`
+
+	BillID         string `header:"账单ID"`
+	ZenlayerOrder  string `header:"Zenlayer订单编号"`
+	CID            string `header:"CID"`
+	GroupID        string `header:"GROUP ID"`
+	BusinessGroup  string `header:"业务组"`
+	City           string `header:"城市"`
+	PayContent     string `header:"付费内容"`
+	Type           string `header:"类型"`
+	AcceptanceNum  string `header:"验收数量"`
+	PayNum         string `header:"付费数量"`
+	UnitPriceUSD   string `header:"单价USD"`
+	BillingPeriod  string `header:"账期"`
+	ContractPeriod string `header:"合约周期"`
+	CPU            string `header:"CPU"`
+	Memory         string `header:"内存"`
+	Disk           string `header:"硬盘"`
+	Remarks        string `header:"备注"`
+	Currency       string `header:"外币种类"`
+	Cost           string `header:"外币成本（元）"`
+	RMBCost        string `header:"人民币成本（元）"`
+	Rate           string `header:"汇率"`
+}
+
+// GetHeaders ...
+func (t ZenlayerBillItemTable) GetHeaders() ([]string, error) {
+	return parseHeader(t)
+}
+
+// GetHeaderValues 获取表头对应的数据
+func (t ZenlayerBillItemTable) GetHeaderValues() ([]string, error) {
+	return parseHeaderFields(t)
+}
@@ -0,0 +1,320 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package forecast
+
+import (
+	"sort"
+	"time"
+
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+// forecastMonths 预测当月之后的自然月数量
+const forecastMonths = 3
+
+// Forecaster 费用预测
+type Forecaster struct {
+	Client *client.ClientSet
+}
+
+// Forecast 读取历史每日账单汇总拟合模型，并叠加待审批购买申请的计划费用，预测月末及下季度费用
+func (f *Forecaster) Forecast(kt *kit.Kit, req *asbill.BillForecastReq, now time.Time) (*asbill.BillForecastResult,
+	error) {
+
+	today := dateOf(now)
+	start := today.AddDate(0, 0, -req.HistoryDays)
+
+	scopeRule := tools.RuleEqual("bk_biz_id", req.BkBizID)
+	if len(req.MainAccountID) != 0 {
+		scopeRule = tools.RuleEqual("main_account_id", req.MainAccountID)
+	}
+	history, err := f.listHistory(kt, scopeRule, start, today)
+	if err != nil {
+		return nil, err
+	}
+
+	planned, err := f.listPlanned(kt, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildResult(history, planned, today), nil
+}
+
+// listHistory 查询 [start, end) 范围内各二级账号当前版本的每日费用，按币种及日期汇总
+func (f *Forecaster) listHistory(kt *kit.Kit, scopeRule *filter.AtomRule, start, end time.Time) (
+	map[enumor.CurrencyCode]map[time.Time]decimal.Decimal, error) {
+
+	history := make(map[enumor.CurrencyCode]map[time.Time]decimal.Decimal)
+	for month := monthOf(start); month.Before(end); month = month.AddDate(0, 1, 0) {
+		year, mon := month.Year(), int(month.Month())
+		versionMap, err := f.listCurrentVersion(kt, scopeRule, year, mon)
+		if err != nil {
+			return nil, err
+		}
+
+		mainAccountIDs := make([]string, 0, len(versionMap))
+		for id := range versionMap {
+			mainAccountIDs = append(mainAccountIDs, id)
+		}
+		for _, ids := range slice.Split(mainAccountIDs, int(core.DefaultMaxPageLimit)) {
+			dailyReq := &dsbill.BillSummaryDailyListReq{
+				Filter: tools.ExpressionAnd(
+					tools.RuleEqual("bill_year", year),
+					tools.RuleEqual("bill_month", mon),
+					tools.RuleGreaterThan("bill_day", 0),
+					tools.RuleIn("main_account_id", ids),
+				),
+				Page:   core.NewDefaultBasePage(),
+				Fields: []string{"main_account_id", "bill_day", "version_id", "currency", "cost"},
+			}
+			for {
+				result, err := f.Client.DataService().Global.Bill.ListBillSummaryDaily(kt, dailyReq)
+				if err != nil {
+					logs.Errorf("list bill summary daily for forecast failed, err: %v, rid: %s", err, kt.Rid)
+					return nil, err
+				}
+				for _, one := range result.Details {
+					if one.VersionID != versionMap[one.MainAccountID] {
+						continue
+					}
+					date := time.Date(year, time.Month(mon), one.BillDay, 0, 0, 0, 0, time.UTC)
+					if date.Before(start) || !date.Before(end) {
+						continue
+					}
+					if _, ok := history[one.Currency]; !ok {
+						history[one.Currency] = make(map[time.Time]decimal.Decimal)
+					}
+					history[one.Currency][date] = history[one.Currency][date].Add(one.Cost)
+				}
+
+				if uint(len(result.Details)) < dailyReq.Page.Limit {
+					break
+				}
+				dailyReq.Page.Start += uint32(dailyReq.Page.Limit)
+			}
+		}
+	}
+
+	return history, nil
+}
+
+func (f *Forecaster) listCurrentVersion(kt *kit.Kit, scopeRule *filter.AtomRule, year, month int) (map[string]int,
+	error) {
+
+	versionMap := make(map[string]int)
+	mainReq := &dsbill.BillSummaryMainListReq{
+		Filter: tools.ExpressionAnd(scopeRule, tools.RuleEqual("bill_year", year),
+			tools.RuleEqual("bill_month", month)),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"main_account_id", "current_version"},
+	}
+	for {
+		result, err := f.Client.DataService().Global.Bill.ListBillSummaryMain(kt, mainReq)
+		if err != nil {
+			logs.Errorf("list bill summary main for forecast failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			versionMap[one.MainAccountID] = one.CurrentVersion
+		}
+
+		if uint(len(result.Details)) < mainReq.Page.Limit {
+			break
+		}
+		mainReq.Page.Start += uint32(mainReq.Page.Limit)
+	}
+	return versionMap, nil
+}
+
+// forecastBucket 单月的预测累计值
+type forecastBucket struct {
+	year    int
+	month   int
+	point   float64
+	days    int
+	planned decimal.Decimal
+}
+
+// buildResult 按币种拟合模型并汇总当月及之后三个自然月的预测结果。
+// 历史数据在最后出账日之后的日期使用模型预测，计划费用从今天开始计入
+func buildResult(history map[enumor.CurrencyCode]map[time.Time]decimal.Decimal,
+	planned []asbill.BillForecastPlanned, today time.Time) *asbill.BillForecastResult {
+
+	today = dateOf(today)
+	var firstDate, lastDate time.Time
+	currencySet := make(map[enumor.CurrencyCode]struct{})
+	for currency, days := range history {
+		currencySet[currency] = struct{}{}
+		for date := range days {
+			if firstDate.IsZero() || date.Before(firstDate) {
+				firstDate = date
+			}
+			if date.After(lastDate) {
+				lastDate = date
+			}
+		}
+	}
+	for _, one := range planned {
+		currencySet[one.Currency] = struct{}{}
+	}
+	currencies := make([]enumor.CurrencyCode, 0, len(currencySet))
+	for currency := range currencySet {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+
+	curMonth := monthOf(today)
+	result := &asbill.BillForecastResult{
+		BillYear:        curMonth.Year(),
+		BillMonth:       int(curMonth.Month()),
+		ConfidenceLevel: ConfidenceLevel,
+		Items:           make([]asbill.BillForecastItem, 0, len(currencies)),
+		Planned:         planned,
+	}
+	predictStart := curMonth
+	if !lastDate.IsZero() {
+		result.LastBillDate = lastDate.Format(time.DateOnly)
+		predictStart = lastDate.AddDate(0, 0, 1)
+	}
+	plannedStart := today
+	if predictStart.After(plannedStart) {
+		plannedStart = predictStart
+	}
+	end := curMonth.AddDate(0, forecastMonths+1, 0)
+
+	for _, currency := range currencies {
+		model := &Model{Kind: enumor.BillForecastLinear}
+		if !lastDate.IsZero() {
+			values := make([]float64, 0)
+			for date := firstDate; !date.After(lastDate); date = date.AddDate(0, 0, 1) {
+				values = append(values, history[currency][date].InexactFloat64())
+			}
+			model = Fit(firstDate, values)
+		}
+
+		monthToDate := decimal.Zero
+		for date, cost := range history[currency] {
+			if !date.Before(curMonth) {
+				monthToDate = monthToDate.Add(cost)
+			}
+		}
+
+		buckets := make([]forecastBucket, forecastMonths+1)
+		for i := range buckets {
+			month := curMonth.AddDate(0, i, 0)
+			buckets[i].year, buckets[i].month = month.Year(), int(month.Month())
+		}
+		for date := predictStart; date.Before(end); date = date.AddDate(0, 0, 1) {
+			if date.Before(curMonth) {
+				continue
+			}
+			bucket := &buckets[monthDiff(curMonth, date)]
+			bucket.point += model.Predict(date)
+			bucket.days++
+			if !date.Before(plannedStart) {
+				bucket.planned = bucket.planned.Add(plannedDailyCost(planned, currency, date))
+			}
+		}
+
+		item := asbill.BillForecastItem{
+			Currency:        currency,
+			Model:           model.Kind,
+			MonthToDateCost: monthToDate.Round(2),
+			MonthEnd:        newValue(model, buckets[0], monthToDate),
+			Months:          make([]asbill.BillForecastMonth, 0, forecastMonths),
+		}
+		quarter := forecastBucket{}
+		for _, bucket := range buckets[1:] {
+			item.Months = append(item.Months, asbill.BillForecastMonth{
+				BillYear:          bucket.year,
+				BillMonth:         bucket.month,
+				BillForecastValue: newValue(model, bucket, decimal.Zero),
+			})
+			quarter.point += bucket.point
+			quarter.days += bucket.days
+			quarter.planned = quarter.planned.Add(bucket.planned)
+		}
+		item.NextQuarter = newValue(model, quarter, decimal.Zero)
+		result.Items = append(result.Items, item)
+	}
+
+	return result
+}
+
+// newValue 将模型预测值、已出账费用及计划费用合并为预测结果，区间下限不小于已知费用
+func newValue(model *Model, bucket forecastBucket, actual decimal.Decimal) asbill.BillForecastValue {
+	point := decimal.NewFromFloat(bucket.point)
+	halfWidth := decimal.NewFromFloat(model.HalfWidth(bucket.days))
+	known := actual.Add(bucket.planned)
+	lower := point.Sub(halfWidth)
+	if lower.IsNegative() {
+		lower = decimal.Zero
+	}
+
+	return asbill.BillForecastValue{
+		Point:       point.Add(known).Round(2),
+		Lower:       lower.Add(known).Round(2),
+		Upper:       point.Add(halfWidth).Add(known).Round(2),
+		PlannedCost: bucket.planned.Round(2),
+	}
+}
+
+// plannedDailyCost 计算指定日期的计划费用，包年包月费用按当月天数均摊
+func plannedDailyCost(planned []asbill.BillForecastPlanned, currency enumor.CurrencyCode,
+	date time.Time) decimal.Decimal {
+
+	cost := decimal.Zero
+	days := decimal.NewFromInt(int64(daysIn(date)))
+	for _, one := range planned {
+		if one.Currency != currency {
+			continue
+		}
+		cost = cost.Add(one.DailyCost).Add(one.MonthlyCost.Div(days))
+	}
+	return cost
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func monthDiff(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+func daysIn(t time.Time) int {
+	return monthOf(t).AddDate(0, 1, -1).Day()
+}
@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package forecast
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFit(t *testing.T) {
+	start := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+
+	// 工作日每天10，周末为0，满8周时应选择周期模型
+	values := make([]float64, 56)
+	for i := range values {
+		if wd := start.AddDate(0, 0, i).Weekday(); wd != time.Saturday && wd != time.Sunday {
+			values[i] = 10
+		}
+	}
+	model := Fit(start, values)
+	if model.Kind != enumor.BillForecastWeeklySeasonal {
+		t.Fatalf("expect weekly seasonal model, got: %s", model.Kind)
+	}
+	monday := start.AddDate(0, 0, 56)
+	if got := model.Predict(monday); math.Abs(got-10) > 0.01 {
+		t.Errorf("expect monday cost 10, got: %f", got)
+	}
+	if got := model.Predict(monday.AddDate(0, 0, 5)); math.Abs(got) > 0.01 {
+		t.Errorf("expect saturday cost 0, got: %f", got)
+	}
+
+	// 线性增长的数据不足四周时使用线性模型
+	model = Fit(start, []float64{1, 2, 3, 4, 5})
+	if model.Kind != enumor.BillForecastLinear || math.Abs(model.Slope-1) > 1e-9 || model.Sigma != 0 {
+		t.Fatalf("unexpected linear model: %+v", model)
+	}
+	if got := model.Predict(start.AddDate(0, 0, 9)); math.Abs(got-10) > 1e-9 {
+		t.Errorf("expect cost 10, got: %f", got)
+	}
+}
+
+func TestBuildResult(t *testing.T) {
+	// 9月1日至10月10日每天10 USD，10月11日预测
+	history := map[enumor.CurrencyCode]map[time.Time]decimal.Decimal{enumor.CurrencyUSD: {}}
+	for date := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC); date.Before(time.Date(2024, 10, 11, 0, 0, 0, 0,
+		time.UTC)); date = date.AddDate(0, 0, 1) {
+		history[enumor.CurrencyUSD][date] = decimal.NewFromInt(10)
+	}
+	planned := []asbill.BillForecastPlanned{{Currency: enumor.CurrencyUSD, MonthlyCost: decimal.NewFromInt(310)}}
+
+	result := buildResult(history, planned, time.Date(2024, 10, 11, 8, 0, 0, 0, time.UTC))
+	if result.BillYear != 2024 || result.BillMonth != 10 || result.LastBillDate != "2024-10-10" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Items) != 1 {
+		t.Fatalf("expect 1 item, got: %d", len(result.Items))
+	}
+
+	item := result.Items[0]
+	// 月末：已出账100 + 剩余21天预测210 + 计划费用 310/31*21=210
+	expects := map[string][2]int64{
+		"month_to_date": {item.MonthToDateCost.IntPart(), 100},
+		"month_end":     {item.MonthEnd.Point.IntPart(), 520},
+		"month_planned": {item.MonthEnd.PlannedCost.IntPart(), 210},
+		"november":      {item.Months[0].Point.IntPart(), 610},
+		"next_quarter":  {item.NextQuarter.Point.IntPart(), 1850},
+	}
+	for name, pair := range expects {
+		if pair[0] != pair[1] {
+			t.Errorf("%s expect %d, got: %d", name, pair[1], pair[0])
+		}
+	}
+	if !item.MonthEnd.Lower.Equal(item.MonthEnd.Point) || !item.MonthEnd.Upper.Equal(item.MonthEnd.Point) {
+		t.Errorf("expect no interval for constant cost, got: %+v", item.MonthEnd)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package forecast 根据每日账单汇总预测月末及下季度费用
+package forecast
+
+import (
+	"math"
+	"time"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+const (
+	// seasonalMinDays 至少需要四周的数据才尝试拟合按星期的周期波动
+	seasonalMinDays = 28
+	// backfitIterations 周期模型交替拟合的迭代次数
+	backfitIterations = 20
+	// confidenceZ 95%置信水平对应的正态分布分位数
+	confidenceZ = 1.96
+	// ConfidenceLevel 预测区间的置信水平
+	ConfidenceLevel = 0.95
+)
+
+// Model 按日费用拟合的预测模型，第 t 天的费用为 Intercept + Slope*t + Weekly[星期]
+type Model struct {
+	Kind enumor.BillForecastModel
+	// Start 第0天对应的日期
+	Start     time.Time
+	Intercept float64
+	Slope     float64
+	Weekly    [7]float64
+	// Sigma 残差标准差，用于计算置信区间
+	Sigma float64
+}
+
+// Fit 拟合从 start 开始连续每日的费用，数据满四周时比较线性模型与周期模型的残差，选择残差较小的模型
+func Fit(start time.Time, values []float64) *Model {
+	m := &Model{Kind: enumor.BillForecastLinear, Start: start}
+	n := len(values)
+	if n == 0 {
+		return m
+	}
+
+	m.Intercept, m.Slope = fitLinear(values)
+	linearSSE := 0.0
+	for i, v := range values {
+		d := v - m.Intercept - m.Slope*float64(i)
+		linearSSE += d * d
+	}
+	m.Sigma = stdDev(linearSSE, n-2)
+	if n < seasonalMinDays {
+		return m
+	}
+
+	// 交替拟合趋势与星期波动，避免序列首尾星期分布不均导致趋势偏移
+	seasonal := &Model{Kind: enumor.BillForecastWeeklySeasonal, Start: start}
+	adjusted := make([]float64, n)
+	for iter := 0; iter < backfitIterations; iter++ {
+		for i, v := range values {
+			adjusted[i] = v - seasonal.Weekly[start.AddDate(0, 0, i).Weekday()]
+		}
+		seasonal.Intercept, seasonal.Slope = fitLinear(adjusted)
+		seasonal.Weekly = weeklyOffsets(start, values, seasonal.Intercept, seasonal.Slope)
+	}
+
+	seasonalSSE := 0.0
+	for i, v := range values {
+		d := v - seasonal.Intercept - seasonal.Slope*float64(i) - seasonal.Weekly[start.AddDate(0, 0, i).Weekday()]
+		seasonalSSE += d * d
+	}
+	// 周期模型多出6个自由参数
+	if seasonal.Sigma = stdDev(seasonalSSE, n-2-6); seasonal.Sigma < m.Sigma {
+		return seasonal
+	}
+
+	return m
+}
+
+// weeklyOffsets 计算去除趋势后各星期的平均残差，并使其均值为0
+func weeklyOffsets(start time.Time, values []float64, intercept, slope float64) [7]float64 {
+	var sums [7]float64
+	var counts [7]int
+	for i, v := range values {
+		wd := start.AddDate(0, 0, i).Weekday()
+		sums[wd] += v - intercept - slope*float64(i)
+		counts[wd]++
+	}
+
+	var weekly [7]float64
+	mean := 0.0
+	for wd := range weekly {
+		if counts[wd] > 0 {
+			weekly[wd] = sums[wd] / float64(counts[wd])
+		}
+		mean += weekly[wd]
+	}
+	mean /= float64(len(weekly))
+	for wd := range weekly {
+		weekly[wd] -= mean
+	}
+	return weekly
+}
+
+// Predict 预测指定日期的费用，费用不会小于0
+func (m *Model) Predict(date time.Time) float64 {
+	t := math.Round(date.Sub(m.Start).Hours() / 24)
+	v := m.Intercept + m.Slope*t + m.Weekly[date.Weekday()]
+	return math.Max(v, 0)
+}
+
+// HalfWidth 连续 days 天预测费用合计的置信区间半宽，假设各日残差相互独立
+func (m *Model) HalfWidth(days int) float64 {
+	return confidenceZ * m.Sigma * math.Sqrt(float64(days))
+}
+
+func fitLinear(values []float64) (intercept, slope float64) {
+	n := float64(len(values))
+	if len(values) == 1 {
+		return values[0], 0
+	}
+
+	meanX := (n - 1) / 2
+	meanY := 0.0
+	for _, v := range values {
+		meanY += v
+	}
+	meanY /= n
+
+	var sxy, sxx float64
+	for i, v := range values {
+		dx := float64(i) - meanX
+		sxy += dx * (v - meanY)
+		sxx += dx * dx
+	}
+	slope = sxy / sxx
+	return meanY - slope*meanX, slope
+}
+
+func stdDev(sse float64, dof int) float64 {
+	if dof <= 0 {
+		return 0
+	}
+	return math.Sqrt(sse / float64(dof))
+}
@@ -0,0 +1,208 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package forecast
+
+import (
+	"slices"
+
+	"hcm/cmd/cloud-server/service/common"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	asbill "hcm/pkg/api/account-server/bill"
+	cscvm "hcm/pkg/api/cloud-server/cvm"
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/account-set"
+	dataproto "hcm/pkg/api/data-service"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+
+	"github.com/shopspring/decimal"
+)
+
+// plannedContent 购买虚拟机申请单内容中用于确定预测范围的公共字段
+type plannedContent struct {
+	Vendor    enumor.Vendor `json:"vendor"`
+	BkBizID   int64         `json:"bk_biz_id"`
+	AccountID string        `json:"account_id"`
+}
+
+// plannedAccount 申请单所属资源账号信息
+type plannedAccount struct {
+	site enumor.AccountSiteType
+	// mainCloudID 资源账号对应的二级账号云ID
+	mainCloudID string
+}
+
+// listPlanned 查询待审批的购买虚拟机申请，按询价结果折算为计划费用。
+// 仅腾讯云、华为云支持询价，询价失败的申请单不计入计划费用
+func (f *Forecaster) listPlanned(kt *kit.Kit, req *asbill.BillForecastReq) ([]asbill.BillForecastPlanned, error) {
+	var mainAccount *protocore.BaseMainAccount
+	if len(req.MainAccountID) != 0 {
+		result, err := f.Client.DataService().Global.MainAccount.GetBasicInfo(kt, req.MainAccountID)
+		if err != nil {
+			logs.Errorf("get main account for forecast failed, err: %v, id: %s, rid: %s", err, req.MainAccountID,
+				kt.Rid)
+			return nil, err
+		}
+		mainAccount = &result.BaseMainAccount
+	}
+
+	listReq := &dataproto.ApplicationListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("type", enumor.CreateCvm),
+			tools.RuleEqual("status", enumor.Pending),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	applications := make([]*dataproto.ApplicationResp, 0)
+	for {
+		result, err := f.Client.DataService().Global.Application.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list pending create cvm application failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		applications = append(applications, result.Details...)
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	accounts := make(map[string]*plannedAccount)
+	planned := make([]asbill.BillForecastPlanned, 0)
+	for _, app := range applications {
+		content := new(plannedContent)
+		if err := json.UnmarshalFromString(app.Content, content); err != nil {
+			logs.Warnf("unmarshal application content failed, err: %v, id: %s, rid: %s", err, app.ID, kt.Rid)
+			continue
+		}
+		if content.Vendor != enumor.TCloud && content.Vendor != enumor.HuaWei {
+			continue
+		}
+		if req.BkBizID != 0 && content.BkBizID != req.BkBizID && !slices.Contains(app.BkBizIDs, req.BkBizID) {
+			continue
+		}
+
+		account, ok := accounts[content.AccountID]
+		if !ok {
+			var err error
+			if account, err = f.getPlannedAccount(kt, content.Vendor, content.AccountID); err != nil {
+				logs.Warnf("get account of application failed, err: %v, id: %s, rid: %s", err, app.ID, kt.Rid)
+				continue
+			}
+			accounts[content.AccountID] = account
+		}
+		if mainAccount != nil && (mainAccount.Vendor != content.Vendor || mainAccount.CloudID != account.mainCloudID) {
+			continue
+		}
+
+		one, err := f.inquiryPlanned(kt, content.Vendor, app.Content)
+		if err != nil {
+			logs.Warnf("inquiry price of application failed, err: %v, id: %s, rid: %s", err, app.ID, kt.Rid)
+			continue
+		}
+		one.ApplicationID = app.ID
+		one.SN = app.SN
+		one.Vendor = content.Vendor
+		one.AccountID = content.AccountID
+		one.BkBizID = content.BkBizID
+		one.Currency = enumor.CurrencyUSD
+		if account.site == enumor.ChinaSite {
+			one.Currency = enumor.CurrencyCNY
+		}
+		planned = append(planned, *one)
+	}
+
+	return planned, nil
+}
+
+func (f *Forecaster) getPlannedAccount(kt *kit.Kit, vendor enumor.Vendor, accountID string) (*plannedAccount,
+	error) {
+
+	switch vendor {
+	case enumor.TCloud:
+		account, err := f.Client.DataService().TCloud.Account.Get(kt.Ctx, kt.Header(), accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &plannedAccount{site: account.Site, mainCloudID: account.Extension.CloudMainAccountID}, nil
+	case enumor.HuaWei:
+		account, err := f.Client.DataService().HuaWei.Account.Get(kt.Ctx, kt.Header(), accountID)
+		if err != nil {
+			return nil, err
+		}
+		return &plannedAccount{site: account.Site, mainCloudID: account.Extension.CloudSubAccountID}, nil
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor %s does not support inquiry price", vendor)
+	}
+}
+
+// inquiryPlanned 对申请单询价，包年包月按购买时长均摊到每月，按需计费按小时单价折算为每日费用。
+// 腾讯云询价结果为申请数量的合计价格，华为云询价结果为单台价格
+func (f *Forecaster) inquiryPlanned(kt *kit.Kit, vendor enumor.Vendor, content string) (
+	*asbill.BillForecastPlanned, error) {
+
+	hoursPerDay := decimal.NewFromInt(24)
+	switch vendor {
+	case enumor.TCloud:
+		req := new(cscvm.TCloudCvmCreateReq)
+		if err := json.UnmarshalFromString(content, req); err != nil {
+			return nil, err
+		}
+		result, err := f.Client.HCService().TCloud.Cvm.InquiryPrice(kt, common.ConvTCloudCvmCreateReq(req))
+		if err != nil {
+			return nil, err
+		}
+
+		price := decimal.NewFromFloat(result.DiscountPrice)
+		planned := &asbill.BillForecastPlanned{ChargeType: string(req.InstanceChargeType)}
+		if req.InstanceChargeType == typecvm.Prepaid {
+			planned.MonthlyCost = price.Div(decimal.NewFromInt(req.InstanceChargePaidPeriod))
+		} else {
+			planned.DailyCost = price.Mul(hoursPerDay)
+		}
+		return planned, nil
+
+	case enumor.HuaWei:
+		req := new(cscvm.HuaWeiCvmCreateReq)
+		if err := json.UnmarshalFromString(content, req); err != nil {
+			return nil, err
+		}
+		result, err := f.Client.HCService().HuaWei.Cvm.InquiryPrice(kt, common.ConvHuaWeiCvmCreateReq(req))
+		if err != nil {
+			return nil, err
+		}
+
+		price := decimal.NewFromFloat(result.DiscountPrice).Mul(decimal.NewFromInt(req.RequiredCount))
+		planned := &asbill.BillForecastPlanned{ChargeType: string(req.InstanceChargeType)}
+		if req.InstanceChargeType == typecvm.PrePaid {
+			planned.MonthlyCost = price.Div(decimal.NewFromInt(req.InstanceChargePaidPeriod))
+		} else {
+			planned.DailyCost = price.Mul(hoursPerDay)
+		}
+		return planned, nil
+
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor %s does not support inquiry price", vendor)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package monthtask
+
+import (
+	"fmt"
+
+	"hcm/cmd/task-server/logics/action/bill/allocation"
+	protocore "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
+	"hcm/pkg/cc"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/json"
+)
+
+// returnSourceTypes 归还给指定账号的费用来源，一级账号配置了对应规则时替代配置文件中的awsSavingsPlans、gcpCredits
+var returnSourceTypes = map[enumor.Vendor]enumor.BillAllocationSourceType{
+	enumor.Aws: enumor.BillAllocationSourceSavingsPlans,
+	enumor.Gcp: enumor.BillAllocationSourceCredits,
+}
+
+// loadReturnRules 将一级账号的savings plans、credit分摊规则转换为月度任务扩展字段，覆盖配置文件中的对应配置
+func (r *DefaultMonthTaskRunner) loadReturnRules(kt *kit.Kit, allocator *allocation.Allocator) error {
+	sourceType, ok := returnSourceTypes[r.vendor]
+	if !ok {
+		return nil
+	}
+	rules, err := allocator.ListRules(kt, r.rootAccountID, sourceType)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	mainAccountMap, err := allocator.ListMainAccounts(kt, r.rootAccountID)
+	if err != nil {
+		return err
+	}
+
+	ext, err := buildReturnRuleExtension(sourceType, rules, mainAccountMap)
+	if err != nil {
+		return err
+	}
+	if len(rules) > 1 && sourceType == enumor.BillAllocationSourceSavingsPlans {
+		logs.Warnf("root account %s has %d savings plans allocation rules, only %s will be used, rid: %s",
+			r.rootAccountID, len(rules), rules[0].ID, kt.Rid)
+	}
+	if r.ext == nil {
+		r.ext = make(map[string]string)
+	}
+	for key, value := range ext {
+		r.ext[key] = value
+	}
+	return nil
+}
+
+// buildReturnRuleExtension 按分摊规则生成与配置文件相同格式的月度任务扩展字段，规则按ID排序，savings plans只取第一条
+func buildReturnRuleExtension(sourceType enumor.BillAllocationSourceType, rules []billcore.AllocationRule,
+	mainAccountMap map[string]*protocore.BaseMainAccount) (map[string]string, error) {
+
+	getTargetCloudID := func(rule *billcore.AllocationRule) (string, error) {
+		if len(rule.Targets) != 1 {
+			return "", fmt.Errorf("%s allocation rule %s should have exactly one target", sourceType, rule.ID)
+		}
+		account, ok := mainAccountMap[rule.Targets[0].MainAccountID]
+		if !ok {
+			return "", fmt.Errorf("target main account %s of allocation rule %s not found under root account",
+				rule.Targets[0].MainAccountID, rule.ID)
+		}
+		return account.CloudID, nil
+	}
+
+	switch sourceType {
+	case enumor.BillAllocationSourceSavingsPlans:
+		cloudID, err := getTargetCloudID(&rules[0])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			constant.AwsSavingsPlanARNPrefixKey:      rules[0].SpArnPrefix,
+			constant.AwsSavingsPlanAccountCloudIDKey: cloudID,
+		}, nil
+
+	case enumor.BillAllocationSourceCredits:
+		creditReturns := make([]cc.CreditReturn, 0)
+		creditRules := make(map[string]string)
+		for i := range rules {
+			cloudID, err := getTargetCloudID(&rules[i])
+			if err != nil {
+				return nil, err
+			}
+			for _, creditID := range rules[i].CreditIDs {
+				if ruleID, exists := creditRules[creditID]; exists {
+					return nil, fmt.Errorf("credit %s is returned by both allocation rule %s and %s", creditID,
+						ruleID, rules[i].ID)
+				}
+				creditRules[creditID] = rules[i].ID
+				creditReturns = append(creditReturns, cc.CreditReturn{CreditID: creditID, AccountCloudID: cloudID,
+					CreditName: rules[i].Name})
+			}
+		}
+		creditJson, err := json.MarshalToString(creditReturns)
+		if err != nil {
+			return nil, fmt.Errorf("fail to marshal gcp credit return config to json: %w", err)
+		}
+		return map[string]string{constant.GcpCreditReturnConfigKey: creditJson}, nil
+
+	default:
+		return nil, fmt.Errorf("allocation source type %s is not returned to main account", sourceType)
+	}
+}
+
+// GetSavingsPlanArnPrefix 获取一级账号savings plans分摊规则中的ARN前缀，未配置规则时返回false，由调用方使用配置文件
+func GetSavingsPlanArnPrefix(kt *kit.Kit, ds *dataservice.Client, rootAccountID string) (string, bool, error) {
+	rules, err := allocation.NewAllocator(ds).ListRules(kt, rootAccountID, enumor.BillAllocationSourceSavingsPlans)
+	if err != nil {
+		return "", false, err
+	}
+	if len(rules) == 0 {
+		return "", false, nil
+	}
+	return rules[0].SpArnPrefix, true, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package monthtask
+
+import (
+	"encoding/json"
+	"testing"
+
+	protocore "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBuildReturnRuleExtension(t *testing.T) {
+	mainAccountMap := map[string]*protocore.BaseMainAccount{
+		"m1": {ID: "m1", CloudID: "cloud-1"},
+		"m2": {ID: "m2", CloudID: "cloud-2"},
+	}
+	target := func(id string) []billcore.AllocationTarget {
+		return []billcore.AllocationTarget{{MainAccountID: id, Ratio: decimal.NewFromInt(1)}}
+	}
+
+	// savings plans 只取第一条规则
+	spRules := []billcore.AllocationRule{
+		{ID: "r1", SpArnPrefix: "arn:aws:savingsplans::1:", Targets: target("m1")},
+		{ID: "r2", SpArnPrefix: "arn:aws:savingsplans::2:", Targets: target("m2")},
+	}
+	ext, err := buildReturnRuleExtension(enumor.BillAllocationSourceSavingsPlans, spRules, mainAccountMap)
+	if err != nil {
+		t.Fatalf("build savings plans extension failed, err: %v", err)
+	}
+	if ext[constant.AwsSavingsPlanARNPrefixKey] != "arn:aws:savingsplans::1:" ||
+		ext[constant.AwsSavingsPlanAccountCloudIDKey] != "cloud-1" {
+		t.Errorf("unexpected savings plans extension: %v", ext)
+	}
+
+	creditRules := []billcore.AllocationRule{
+		{ID: "r1", Name: "credit-a", CreditIDs: []string{"c1", "c2"}, Targets: target("m1")},
+		{ID: "r2", Name: "credit-b", CreditIDs: []string{"c3"}, Targets: target("m2")},
+	}
+	ext, err = buildReturnRuleExtension(enumor.BillAllocationSourceCredits, creditRules, mainAccountMap)
+	if err != nil {
+		t.Fatalf("build credits extension failed, err: %v", err)
+	}
+	var creditReturns []cc.CreditReturn
+	if err = json.Unmarshal([]byte(ext[constant.GcpCreditReturnConfigKey]), &creditReturns); err != nil {
+		t.Fatalf("unmarshal credit return config failed, err: %v", err)
+	}
+	expect := map[string]string{"c1": "cloud-1", "c2": "cloud-1", "c3": "cloud-2"}
+	if len(creditReturns) != len(expect) {
+		t.Fatalf("expect %d credit return configs, got: %+v", len(expect), creditReturns)
+	}
+	for _, one := range creditReturns {
+		if expect[one.CreditID] != one.AccountCloudID {
+			t.Errorf("credit %s should return to %s, got: %s", one.CreditID, expect[one.CreditID],
+				one.AccountCloudID)
+		}
+	}
+
+	errCases := []struct {
+		name       string
+		sourceType enumor.BillAllocationSourceType
+		rules      []billcore.AllocationRule
+	}{
+		{name: "duplicate credit", sourceType: enumor.BillAllocationSourceCredits, rules: []billcore.AllocationRule{
+			{ID: "r1", CreditIDs: []string{"c1"}, Targets: target("m1")},
+			{ID: "r2", CreditIDs: []string{"c1"}, Targets: target("m2")},
+		}},
+		{name: "target not under root account", sourceType: enumor.BillAllocationSourceSavingsPlans,
+			rules: []billcore.AllocationRule{{ID: "r1", Targets: target("m3")}}},
+		{name: "common expense", sourceType: enumor.BillAllocationSourceCommonExpense,
+			rules: []billcore.AllocationRule{{ID: "r1"}}},
+	}
+	for _, c := range errCases {
+		if _, err = buildReturnRuleExtension(c.sourceType, c.rules, mainAccountMap); err == nil {
+			t.Errorf("%s: expect error", c.name)
+		}
+	}
+}
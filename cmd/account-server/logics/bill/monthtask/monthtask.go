@@ -24,6 +24,7 @@ package monthtask
 import (
 	"fmt"
 
+	"hcm/cmd/task-server/logics/action/bill/allocation"
 	"hcm/cmd/task-server/logics/action/bill/monthtask"
 	"hcm/pkg/api/core"
 	billcore "hcm/pkg/api/core/bill"
@@ -31,11 +32,13 @@ import (
 	dsbillapi "hcm/pkg/api/data-service/bill"
 	taskserver "hcm/pkg/api/task-server"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/json"
 )
 
 // MonthTaskDescriberRegistry month task describe registry
@@ -121,6 +124,13 @@ func (r *DefaultMonthTaskRunner) EnsureMonthTask(kt *kit.Kit, billYear, billMont
 		return err
 	}
 
+	hasBillItemRule, err := r.loadAllocationRules(kt)
+	if err != nil {
+		logs.Errorf("fail to load allocation rules for root account (%s, %s), err: %v, rid: %s",
+			r.rootAccountID, r.vendor, err, kt.Rid)
+		return err
+	}
+
 	logs.Infof("[%s] %s(%s) %d-%02d monthtask setting extension: %v, rid: %s",
 		r.vendor, r.rootAccountCloudID, r.rootAccountID, billYear, billMonth, r.ext, kt.Rid)
 
@@ -145,6 +155,10 @@ func (r *DefaultMonthTaskRunner) EnsureMonthTask(kt *kit.Kit, billYear, billMont
 		monthTaskTypeMap[task.Type] = task
 	}
 	monthTaskTypeOrders := monthDescribe.GetMonthTaskTypes()
+	if hasBillItemRule {
+		// 分摊任务依赖厂商月度任务生成的账单，放在最后执行
+		monthTaskTypeOrders = append(monthTaskTypeOrders, enumor.BillAllocationMonthTask)
+	}
 	for _, curType := range monthTaskTypeOrders {
 		monthTask := monthTaskTypeMap[curType]
 		if monthTask == nil {
@@ -187,6 +201,40 @@ func (r *DefaultMonthTaskRunner) EnsureMonthTask(kt *kit.Kit, billYear, billMont
 	return nil
 }
 
+// loadAllocationRules 加载一级账号的分摊规则，公共费用、savings plans、credit分摊规则通过扩展字段传递给厂商月度任务，
+// 替代配置文件中的对应配置，返回是否存在来源为账单明细的分摊规则
+func (r *DefaultMonthTaskRunner) loadAllocationRules(kt *kit.Kit) (hasBillItemRule bool, err error) {
+	allocator := allocation.NewAllocator(r.client.DataService())
+	commonRules, err := allocator.ListRules(kt, r.rootAccountID, enumor.BillAllocationSourceCommonExpense)
+	if err != nil {
+		return false, err
+	}
+	if len(commonRules) > 0 {
+		if len(commonRules) > 1 {
+			logs.Warnf("root account %s has %d common expense allocation rules, only %s will be used, rid: %s",
+				r.rootAccountID, len(commonRules), commonRules[0].ID, kt.Rid)
+		}
+		ruleJson, err := json.MarshalToString(commonRules[0])
+		if err != nil {
+			return false, fmt.Errorf("fail to marshal common expense allocation rule: %w", err)
+		}
+		if r.ext == nil {
+			r.ext = make(map[string]string)
+		}
+		r.ext[constant.BillCommonExpenseAllocationRuleKey] = ruleJson
+	}
+
+	if err = r.loadReturnRules(kt, allocator); err != nil {
+		return false, err
+	}
+
+	billItemRules, err := allocator.ListRules(kt, r.rootAccountID, enumor.BillAllocationSourceBillItem)
+	if err != nil {
+		return false, err
+	}
+	return len(billItemRules) > 0, nil
+}
+
 // return true if all main account state of current root version is in `accounted` or `wait_month_task` state
 func calculateAccountingState(mainSummaryList []*dsbillapi.BillSummaryMain, rootSummary *billcore.SummaryRoot) (
 	isAllAccounted bool) {
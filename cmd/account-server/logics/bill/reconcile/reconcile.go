@@ -0,0 +1,357 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package reconcile 账单对账，比较云厂商月度账单总额与一级账号账单汇总，差额超出允许范围时不允许确认账单
+package reconcile
+
+import (
+	"fmt"
+	"sort"
+
+	typesbill "hcm/pkg/adaptor/types/bill"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	billcore "hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	hcbill "hcm/pkg/api/hc-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+type vendorTotalFunc func(kt *kit.Kit, cli *client.ClientSet, req *hcbill.RootAccountMonthTotalReq) (
+	*hcbill.RootAccountMonthTotalResult, error)
+
+// vendorTotalFuncs 支持查询一级账号月度账单总额的云厂商
+var vendorTotalFuncs = map[enumor.Vendor]vendorTotalFunc{
+	enumor.Aws: func(kt *kit.Kit, cli *client.ClientSet, req *hcbill.RootAccountMonthTotalReq) (
+		*hcbill.RootAccountMonthTotalResult, error) {
+		return cli.HCService().Aws.Bill.GetRootAccountMonthTotal(kt, req)
+	},
+	enumor.Gcp: func(kt *kit.Kit, cli *client.ClientSet, req *hcbill.RootAccountMonthTotalReq) (
+		*hcbill.RootAccountMonthTotalResult, error) {
+		return cli.HCService().Gcp.Bill.GetRootAccountMonthTotal(kt, req)
+	},
+}
+
+// IsSupported 云厂商是否支持对账
+func IsSupported(vendor enumor.Vendor) bool {
+	_, ok := vendorTotalFuncs[vendor]
+	return ok
+}
+
+// Reconciler 账单对账
+type Reconciler struct {
+	Client *client.ClientSet
+}
+
+// Reconcile 对一级账号账单汇总进行对账并记录对账结果
+func (r *Reconciler) Reconcile(kt *kit.Kit, summary *billcore.SummaryRoot) (*billcore.Reconciliation, error) {
+	// 不支持对账的云厂商记录为unsupported，确认账单时不会被当作对账通过
+	state := enumor.BillReconciliationUnsupported
+	details := make([]billcore.ReconciliationDetail, 0)
+	if totalFunc, ok := vendorTotalFuncs[summary.Vendor]; ok {
+		req := &hcbill.RootAccountMonthTotalReq{
+			RootAccountID: summary.RootAccountID,
+			BillYear:      uint(summary.BillYear),
+			BillMonth:     uint(summary.BillMonth),
+		}
+		vendorTotal, err := totalFunc(kt, r.Client, req)
+		if err != nil {
+			logs.Errorf("get vendor month total of root account %s %d-%02d failed, err: %v, rid: %s",
+				summary.RootAccountID, summary.BillYear, summary.BillMonth, err, kt.Rid)
+			return nil, err
+		}
+
+		hcmTotals := map[enumor.CurrencyCode]decimal.Decimal{summary.Currency: summary.CurrentMonthCost}
+		details = Compare(vendorTotal.Details, hcmTotals, cc.AccountServer().Reconciliation)
+		state = matchState(details)
+	}
+
+	createReq := &dsbill.BillReconciliationCreateReq{
+		RootAccountID:  summary.RootAccountID,
+		Vendor:         summary.Vendor,
+		BillYear:       summary.BillYear,
+		BillMonth:      summary.BillMonth,
+		SummaryVersion: summary.CurrentVersion,
+		State:          state,
+		Details:        details,
+	}
+	result, err := r.Client.DataService().Global.Bill.CreateBillReconciliation(kt, createReq)
+	if err != nil {
+		logs.Errorf("create bill reconciliation failed, err: %v, req: %+v, rid: %s", err, createReq, kt.Rid)
+		return nil, err
+	}
+	logs.Infof("bill reconciliation of root account %s %d-%02d version %d is %s, rid: %s", summary.RootAccountID,
+		summary.BillYear, summary.BillMonth, summary.CurrentVersion, state, kt.Rid)
+
+	return &billcore.Reconciliation{
+		ID:             result.ID,
+		RootAccountID:  createReq.RootAccountID,
+		Vendor:         createReq.Vendor,
+		BillYear:       createReq.BillYear,
+		BillMonth:      createReq.BillMonth,
+		SummaryVersion: createReq.SummaryVersion,
+		State:          createReq.State,
+		Details:        createReq.Details,
+		Creator:        kt.User,
+	}, nil
+}
+
+func matchState(details []billcore.ReconciliationDetail) enumor.BillReconciliationState {
+	for _, detail := range details {
+		if !detail.Matched {
+			return enumor.BillReconciliationMismatched
+		}
+	}
+	return enumor.BillReconciliationMatched
+}
+
+// Compare 按币种比较云厂商账单金额与一级账号账单汇总金额，允许差额取绝对值与比例的较大值
+func Compare(vendorTotals []typesbill.CurrencyTotal, hcmTotals map[enumor.CurrencyCode]decimal.Decimal,
+	opt cc.BillReconciliationOption) []billcore.ReconciliationDetail {
+
+	tolerance, err := decimal.NewFromString(opt.Tolerance)
+	if err != nil {
+		tolerance = decimal.Zero
+	}
+	ratio := decimal.NewFromFloat(opt.ToleranceRatio)
+
+	vendorMap := make(map[enumor.CurrencyCode]decimal.Decimal, len(vendorTotals))
+	for _, total := range vendorTotals {
+		vendorMap[total.Currency] = vendorMap[total.Currency].Add(total.Cost)
+	}
+	currencies := make([]enumor.CurrencyCode, 0, len(vendorMap)+len(hcmTotals))
+	for currency := range vendorMap {
+		currencies = append(currencies, currency)
+	}
+	for currency := range hcmTotals {
+		if _, ok := vendorMap[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+
+	details := make([]billcore.ReconciliationDetail, 0, len(currencies))
+	for _, currency := range currencies {
+		vendorCost, hcmCost := vendorMap[currency], hcmTotals[currency]
+		// 两边金额都为0的币种不需要对账
+		if vendorCost.IsZero() && hcmCost.IsZero() {
+			continue
+		}
+		diff := hcmCost.Sub(vendorCost)
+		allowed := decimal.Max(tolerance, vendorCost.Abs().Mul(ratio))
+		details = append(details, billcore.ReconciliationDetail{
+			Currency:   currency,
+			VendorCost: vendorCost,
+			HcmCost:    hcmCost,
+			DiffCost:   diff,
+			Tolerance:  allowed,
+			Matched:    diff.Abs().LessThanOrEqual(allowed),
+		})
+	}
+	return details
+}
+
+// GetLatest 获取一级账号账单汇总当前版本的最近一次对账记录，不存在时返回nil
+func (r *Reconciler) GetLatest(kt *kit.Kit, summary *billcore.SummaryRoot) (*billcore.Reconciliation, error) {
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("root_account_id", summary.RootAccountID),
+			tools.RuleEqual("bill_year", summary.BillYear),
+			tools.RuleEqual("bill_month", summary.BillMonth),
+			tools.RuleEqual("summary_version", summary.CurrentVersion),
+		),
+		Page: &core.BasePage{Start: 0, Limit: 1, Sort: "created_at", Order: core.Descending},
+	}
+	result, err := r.Client.DataService().Global.Bill.ListBillReconciliation(kt, listReq)
+	if err != nil {
+		logs.Errorf("list bill reconciliation failed, err: %v, summary: %s, rid: %s", err, summary.ID, kt.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, nil
+	}
+	return &result.Details[0], nil
+}
+
+// CheckConfirm 开启对账时确认账单前检查对账结果，当前版本未对账时先进行对账，
+// 不支持对账的云厂商只有配置在skipVendors中时才跳过检查，否则不允许确认
+func (r *Reconciler) CheckConfirm(kt *kit.Kit, summary *billcore.SummaryRoot) error {
+	opt := cc.AccountServer().Reconciliation
+	if !opt.Enable {
+		return nil
+	}
+	if !IsSupported(summary.Vendor) && slice.IsItemInSlice(opt.SkipVendors, summary.Vendor) {
+		logs.Infof("vendor %s does not support bill reconciliation, skip check by config, root account: %s, "+
+			"rid: %s", summary.Vendor, summary.RootAccountID, kt.Rid)
+		return nil
+	}
+
+	record, err := r.GetLatest(kt, summary)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		if record, err = r.Reconcile(kt, summary); err != nil {
+			return err
+		}
+	}
+	return checkRecord(summary, record)
+}
+
+// checkRecord 根据对账记录判断是否允许确认账单，只有对账一致时允许
+func checkRecord(summary *billcore.SummaryRoot, record *billcore.Reconciliation) error {
+	switch record.State {
+	case enumor.BillReconciliationMatched:
+		return nil
+	case enumor.BillReconciliationUnsupported:
+		return errf.Newf(errf.Aborted, "vendor %s does not support bill reconciliation, root account %s %d-%02d "+
+			"can not be confirmed unless vendor is configured in reconciliation skipVendors", summary.Vendor,
+			summary.RootAccountID, summary.BillYear, summary.BillMonth)
+	}
+
+	for _, detail := range record.Details {
+		if !detail.Matched {
+			return errf.Newf(errf.Aborted, "bill of root account %s %d-%02d mismatch with vendor in %s, "+
+				"diff: %s, tolerance: %s, reconciliation: %s", summary.RootAccountID, summary.BillYear,
+				summary.BillMonth, detail.Currency, detail.DiffCost, detail.Tolerance, record.ID)
+		}
+	}
+	return fmt.Errorf("bill reconciliation %s is %s", record.ID, record.State)
+}
+
+// DrillDown 查看对账记录对应的二级账号及每日账单汇总，按二级账号当前账单版本统计
+func (r *Reconciler) DrillDown(kt *kit.Kit, record *billcore.Reconciliation, mainAccountID string) (
+	*asbill.BillReconciliationDrillDownResult, error) {
+
+	mainRules := []*filter.AtomRule{
+		tools.RuleEqual("root_account_id", record.RootAccountID),
+		tools.RuleEqual("bill_year", record.BillYear),
+		tools.RuleEqual("bill_month", record.BillMonth),
+	}
+	if len(mainAccountID) != 0 {
+		mainRules = append(mainRules, tools.RuleEqual("main_account_id", mainAccountID))
+	}
+	mainSummaries, err := r.listMainSummary(kt, tools.ExpressionAnd(mainRules...))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &asbill.BillReconciliationDrillDownResult{
+		ReconciliationID: record.ID,
+		MainAccounts:     make([]asbill.ReconciliationMainAccountCost, 0, len(mainSummaries)),
+		Days:             make([]asbill.ReconciliationDailyCost, 0),
+	}
+	versionMap := make(map[string]int, len(mainSummaries))
+	for _, summary := range mainSummaries {
+		versionMap[summary.MainAccountID] = summary.CurrentVersion
+		result.MainAccounts = append(result.MainAccounts, asbill.ReconciliationMainAccountCost{
+			MainAccountID:      summary.MainAccountID,
+			MainAccountCloudID: summary.MainAccountCloudID,
+			BkBizID:            summary.BkBizID,
+			VersionID:          summary.CurrentVersion,
+			Currency:           summary.Currency,
+			Cost:               summary.CurrentMonthCost,
+		})
+	}
+	if len(mainSummaries) == 0 {
+		return result, nil
+	}
+
+	dailyRules := append(mainRules, tools.RuleGreaterThan("bill_day", 0))
+	dailyList, err := r.listDailySummary(kt, tools.ExpressionAnd(dailyRules...))
+	if err != nil {
+		return nil, err
+	}
+
+	type dayKey struct {
+		day      int
+		currency enumor.CurrencyCode
+	}
+	dayMap := make(map[dayKey]*asbill.ReconciliationDailyCost)
+	for _, daily := range dailyList {
+		// 只统计二级账号当前版本的每日汇总
+		if version, ok := versionMap[daily.MainAccountID]; !ok || version != daily.VersionID {
+			continue
+		}
+		key := dayKey{day: daily.BillDay, currency: daily.Currency}
+		cost, ok := dayMap[key]
+		if !ok {
+			cost = &asbill.ReconciliationDailyCost{BillDay: daily.BillDay, Currency: daily.Currency}
+			dayMap[key] = cost
+		}
+		cost.Cost = cost.Cost.Add(daily.Cost)
+		cost.Count += daily.Count
+	}
+	for _, cost := range dayMap {
+		result.Days = append(result.Days, *cost)
+	}
+	sort.Slice(result.Days, func(i, j int) bool {
+		if result.Days[i].BillDay != result.Days[j].BillDay {
+			return result.Days[i].BillDay < result.Days[j].BillDay
+		}
+		return result.Days[i].Currency < result.Days[j].Currency
+	})
+	return result, nil
+}
+
+func (r *Reconciler) listMainSummary(kt *kit.Kit, expr *filter.Expression) ([]*dsbill.BillSummaryMain, error) {
+	listReq := &dsbill.BillSummaryMainListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	summaries := make([]*dsbill.BillSummaryMain, 0)
+	for {
+		result, err := r.Client.DataService().Global.Bill.ListBillSummaryMain(kt, listReq)
+		if err != nil {
+			logs.Errorf("list main account bill summary failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		summaries = append(summaries, result.Details...)
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+	return summaries, nil
+}
+
+func (r *Reconciler) listDailySummary(kt *kit.Kit, expr *filter.Expression) ([]billcore.SummaryDaily, error) {
+	listReq := &dsbill.BillSummaryDailyListReq{Filter: expr, Page: core.NewDefaultBasePage()}
+	dailyList := make([]billcore.SummaryDaily, 0)
+	for {
+		result, err := r.Client.DataService().Global.Bill.ListBillSummaryDaily(kt, listReq)
+		if err != nil {
+			logs.Errorf("list daily bill summary failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		dailyList = append(dailyList, result.Details...)
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+	return dailyList, nil
+}
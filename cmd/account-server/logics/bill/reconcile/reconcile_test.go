@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package reconcile
+
+import (
+	"testing"
+
+	typesbill "hcm/pkg/adaptor/types/bill"
+	billcore "hcm/pkg/api/core/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCompare(t *testing.T) {
+	vendorTotals := []typesbill.CurrencyTotal{
+		{Currency: enumor.CurrencyUSD, Cost: decimal.NewFromInt(1000)},
+		{Currency: enumor.CurrencyCNY, Cost: decimal.NewFromInt(20)},
+	}
+	hcmTotals := map[enumor.CurrencyCode]decimal.Decimal{
+		enumor.CurrencyUSD: decimal.NewFromInt(1004),
+	}
+
+	// USD 允许差额为 max(1, 1000*0.005)=5，差额4通过；CNY 一级账号汇总为0，差额-20不通过
+	opt := cc.BillReconciliationOption{Tolerance: "1", ToleranceRatio: 0.005}
+	details := Compare(vendorTotals, hcmTotals, opt)
+	if len(details) != 2 {
+		t.Fatalf("unexpected details: %+v", details)
+	}
+	if details[0].Currency != enumor.CurrencyCNY || details[0].Matched ||
+		!details[0].DiffCost.Equal(decimal.NewFromInt(-20)) {
+		t.Fatalf("unexpected cny detail: %+v", details[0])
+	}
+	if details[1].Currency != enumor.CurrencyUSD || !details[1].Matched ||
+		!details[1].Tolerance.Equal(decimal.NewFromInt(5)) {
+		t.Fatalf("unexpected usd detail: %+v", details[1])
+	}
+
+	opt.ToleranceRatio = 0
+	details = Compare(vendorTotals[:1], hcmTotals, opt)
+	if len(details) != 1 || details[0].Matched {
+		t.Fatalf("usd diff 4 should exceed tolerance 1, details: %+v", details)
+	}
+}
+
+func TestCheckRecord(t *testing.T) {
+	summary := &billcore.SummaryRoot{RootAccountID: "root", Vendor: enumor.HuaWei, BillYear: 2024, BillMonth: 9}
+	mismatched := []billcore.ReconciliationDetail{
+		{Currency: enumor.CurrencyUSD, Matched: true},
+		{Currency: enumor.CurrencyCNY, Matched: false},
+	}
+
+	cases := []struct {
+		name    string
+		record  *billcore.Reconciliation
+		wantErr bool
+	}{
+		{name: "matched", record: &billcore.Reconciliation{State: enumor.BillReconciliationMatched}},
+		{name: "mismatched", record: &billcore.Reconciliation{State: enumor.BillReconciliationMismatched,
+			Details: mismatched}, wantErr: true},
+		// 不支持对账的云厂商不能被当作对账通过
+		{name: "unsupported", record: &billcore.Reconciliation{State: enumor.BillReconciliationUnsupported},
+			wantErr: true},
+		{name: "unknown state", record: &billcore.Reconciliation{State: "unknown"}, wantErr: true},
+	}
+	for _, c := range cases {
+		err := checkRecord(summary, c.record)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: unexpected err: %v", c.name, err)
+		}
+	}
+
+	if details := mismatched[:1]; matchState(details) != enumor.BillReconciliationMatched {
+		t.Errorf("all matched details should be matched state")
+	}
+	if matchState(mismatched) != enumor.BillReconciliationMismatched {
+		t.Errorf("details with mismatch should be mismatched state")
+	}
+}
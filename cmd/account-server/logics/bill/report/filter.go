@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package report
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/runtime/filter"
+)
+
+// ValidateFilter 校验订阅报表过滤条件，过滤字段需为报表类型对应数据表的字段，业务账单汇总报表不支持过滤条件
+func ValidateFilter(reportType enumor.BillReportType, expr *filter.Expression) error {
+	if expr == nil {
+		return nil
+	}
+
+	var columns map[string]enumor.ColumnType
+	switch reportType {
+	case enumor.BillReportRootAccountSummary:
+		columns = tablebill.AccountBillSummaryRootColumns.ColumnTypes()
+	case enumor.BillReportMainAccountSummary:
+		columns = tablebill.AccountBillSummaryMainColumns.ColumnTypes()
+	case enumor.BillReportBillItem:
+		columns = tablebill.AccountBillItemColumns.ColumnTypes()
+	case enumor.BillReportBizSummary:
+		return errors.New("filter is not supported for biz_summary report")
+	default:
+		return fmt.Errorf("unsupported bill report type: %s", reportType)
+	}
+
+	return expr.Validate(filter.NewExprOption(filter.RuleFields(columns)))
+}
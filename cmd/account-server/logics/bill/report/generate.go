@@ -0,0 +1,175 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"hcm/cmd/account-server/logics/bill/export"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/objectstore"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+)
+
+const (
+	reportFilename   = "bill_report-%s-%s-%d%02d"
+	reportUploadPath = "bill_report/%s/%d%02d/%s"
+
+	reportMailTitle   = "【海垓】账单报表：%s（%d年%d月）"
+	reportMailContent = `<p>您好，您订阅的账单报表【%s】（%s）已生成，账单月份为 %d 年 %d 月。</p>
+<p><a href="%s">点击下载 %s</a>，下载链接 %d 小时内有效。</p>`
+)
+
+var reportTypeNameMap = map[enumor.BillReportType]string{
+	enumor.BillReportRootAccountSummary: "一级账号账单汇总",
+	enumor.BillReportMainAccountSummary: "二级账号账单汇总",
+	enumor.BillReportBizSummary:         "业务账单汇总",
+	enumor.BillReportBillItem:           "账单明细",
+}
+
+// generate 生成报表文件，返回文件名及本地文件路径
+func (s *Scheduler) generate(kt *kit.Kit, sub *bill.ReportSubscription, year, month int) (string, string, error) {
+	exporter, ok := s.exporters[sub.ReportType]
+	if !ok {
+		return "", "", fmt.Errorf("bill report type %s has no exporter", sub.ReportType)
+	}
+
+	name := fmt.Sprintf(reportFilename, sub.ReportType, sub.ID, year, month)
+	switch sub.Format {
+	case enumor.BillReportFormatCsv:
+		return generateCsv(kt, sub, year, month, name+".csv", exporter)
+	case enumor.BillReportFormatExcel:
+		return generateExcel(kt, sub, year, month, name+".xlsx", exporter)
+	default:
+		return "", "", fmt.Errorf("unsupported bill report format: %s", sub.Format)
+	}
+}
+
+func generateCsv(kt *kit.Kit, sub *bill.ReportSubscription, year, month int, name string,
+	exporter Exporter) (string, string, error) {
+
+	filename, localPath, writer, closeFunc, err := export.CreateWriterByFileName(kt, name)
+	if err != nil {
+		if closeFunc != nil {
+			closeFunc()
+		}
+		logs.Errorf("create bill report csv writer failed, err: %v, rid: %s", err, kt.Rid)
+		return "", "", err
+	}
+
+	if err = exporter(kt, sub, year, month, writer); err != nil {
+		closeFunc()
+		removeFile(kt, localPath)
+		logs.Errorf("export bill report failed, err: %v, id: %s, rid: %s", err, sub.ID, kt.Rid)
+		return "", "", err
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		closeFunc()
+		removeFile(kt, localPath)
+		return "", "", err
+	}
+	if err = closeFunc(); err != nil {
+		removeFile(kt, localPath)
+		return "", "", err
+	}
+	return filename, localPath, nil
+}
+
+func generateExcel(kt *kit.Kit, sub *bill.ReportSubscription, year, month int, filename string,
+	exporter Exporter) (string, string, error) {
+
+	writer, err := export.NewExcelWriter()
+	if err != nil {
+		logs.Errorf("create bill report excel writer failed, err: %v, rid: %s", err, kt.Rid)
+		return "", "", err
+	}
+	if err = exporter(kt, sub, year, month, writer); err != nil {
+		logs.Errorf("export bill report failed, err: %v, id: %s, rid: %s", err, sub.ID, kt.Rid)
+		return "", "", err
+	}
+
+	if err = os.MkdirAll(cc.AccountServer().TmpFileDir, 0700); err != nil {
+		return "", "", err
+	}
+	localPath := filepath.Join(cc.AccountServer().TmpFileDir, filename)
+	file, err := os.Create(localPath)
+	if err != nil {
+		logs.Errorf("create bill report file failed, err: %v, path: %s, rid: %s", err, localPath, kt.Rid)
+		return "", "", err
+	}
+	defer file.Close()
+
+	if err = writer.Flush(file); err != nil {
+		removeFile(kt, localPath)
+		logs.Errorf("write bill report excel file failed, err: %v, rid: %s", err, kt.Rid)
+		return "", "", err
+	}
+	return filename, localPath, nil
+}
+
+// upload 上传报表文件，返回预签名下载链接
+func (s *Scheduler) upload(kt *kit.Kit, sub *bill.ReportSubscription, year, month int, filename, localPath string,
+	ttl time.Duration) (string, error) {
+
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	uploadPath := fmt.Sprintf(reportUploadPath, sub.ID, year, month, filename)
+	if err = s.Storage.Upload(kt, uploadPath, file); err != nil {
+		logs.Errorf("upload bill report failed, err: %v, path: %s, rid: %s", err, uploadPath, kt.Rid)
+		return "", err
+	}
+
+	_, url, err := s.Storage.GetPreSignedURL(kt, objectstore.DownloadOperateAction, ttl, uploadPath)
+	if err != nil {
+		logs.Errorf("get bill report download url failed, err: %v, path: %s, rid: %s", err, uploadPath, kt.Rid)
+		return "", err
+	}
+	return url, nil
+}
+
+func buildReportMail(sub *bill.ReportSubscription, year, month int, filename, url string,
+	ttl time.Duration) *cmsi.CmsiMail {
+
+	return &cmsi.CmsiMail{
+		ReceiverUserName: strings.Join(sub.Receivers, ","),
+		Title:            fmt.Sprintf(reportMailTitle, sub.Name, year, month),
+		Content: fmt.Sprintf(reportMailContent, sub.Name, reportTypeNameMap[sub.ReportType], year, month, url,
+			filename, int(ttl.Hours())),
+	}
+}
+
+func removeFile(kt *kit.Kit, path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		logs.Warnf("remove bill report file failed, err: %v, path: %s, rid: %s", err, path, kt.Rid)
+	}
+}
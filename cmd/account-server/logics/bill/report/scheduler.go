@@ -0,0 +1,248 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package report 账单订阅报表定时生成与发送
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"hcm/cmd/account-server/logics/bill/export"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/objectstore"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+)
+
+// Exporter 生成订阅报表指定账单月份的数据并按行写入 writer，由各导出接口所在的服务注册
+type Exporter func(kt *kit.Kit, sub *bill.ReportSubscription, year, month int, writer export.RowWriter) error
+
+// Scheduler 订阅报表调度器，按订阅的发送周期生成上月报表，上传至对象存储后邮件发送下载链接
+type Scheduler struct {
+	Sd     serviced.ServiceDiscover
+	Client *client.ClientSet
+	// CmsiCli 邮件通知客户端，为空时不发送报表
+	CmsiCli cmsi.Client
+	// Storage 报表文件存储，为空时不发送报表
+	Storage objectstore.Storage
+
+	exporters map[enumor.BillReportType]Exporter
+}
+
+// Register 注册报表类型对应的导出方法，需要在 Run 之前调用
+func (s *Scheduler) Register(reportType enumor.BillReportType, exporter Exporter) {
+	if s.exporters == nil {
+		s.exporters = make(map[enumor.BillReportType]Exporter)
+	}
+	s.exporters[reportType] = exporter
+}
+
+// Run 定时检测所有订阅并发送到期的报表，只在主节点执行
+func (s *Scheduler) Run(ctx context.Context) {
+	opt := cc.AccountServer().Report
+	if opt.Disable {
+		logs.Infof("bill report scheduler is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(*opt.CheckDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !s.Sd.IsMaster() {
+				continue
+			}
+
+			kt := core.NewBackendKit()
+			if err := s.RunAll(kt, time.Now()); err != nil {
+				logs.Errorf("run bill report subscription failed, err: %v, rid: %s", err, kt.Rid)
+			}
+		case <-ctx.Done():
+			logs.Infof("bill report scheduler context done")
+			return
+		}
+	}
+}
+
+// RunAll 检测所有启用的订阅，发送到期的上月报表
+func (s *Scheduler) RunAll(kt *kit.Kit, now time.Time) error {
+	if s.CmsiCli == nil || s.Storage == nil {
+		logs.V(3).Infof("cmsi or object store is not configured, skip bill report delivery, rid: %s", kt.Rid)
+		return nil
+	}
+
+	subs, err := s.listEnabledSub(kt)
+	if err != nil {
+		return err
+	}
+
+	year, month := lastBillMonth(now)
+	for idx := range subs {
+		sub := &subs[idx]
+		if delivered(sub, year, month) {
+			continue
+		}
+
+		due, err := s.isDue(kt, sub, now, year, month)
+		if err != nil {
+			logs.Errorf("check bill report subscription due failed, err: %v, id: %s, rid: %s", err, sub.ID, kt.Rid)
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		// 单个订阅发送失败不影响其他订阅，未记录发送月份的订阅下个周期重试
+		if _, err = s.Deliver(kt, sub, year, month); err != nil {
+			logs.Errorf("deliver bill report failed, err: %v, id: %s, rid: %s", err, sub.ID, kt.Rid)
+			continue
+		}
+
+		updateReq := &dsbill.BillReportSubUpdateReq{ID: sub.ID, LastBillYear: year, LastBillMonth: month}
+		if err = s.Client.DataService().Global.Bill.UpdateBillReportSub(kt, updateReq); err != nil {
+			logs.Errorf("update bill report subscription last bill month failed, err: %v, id: %s, rid: %s",
+				err, sub.ID, kt.Rid)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) listEnabledSub(kt *kit.Kit) ([]bill.ReportSubscription, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+	subs := make([]bill.ReportSubscription, 0)
+	for {
+		result, err := s.Client.DataService().Global.Bill.ListBillReportSub(kt, listReq)
+		if err != nil {
+			logs.Errorf("list bill report subscription failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		subs = append(subs, result.Details...)
+		if len(result.Details) < int(listReq.Page.Limit) {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+	return subs, nil
+}
+
+func (s *Scheduler) isDue(kt *kit.Kit, sub *bill.ReportSubscription, now time.Time, year, month int) (bool,
+	error) {
+
+	switch sub.Cadence {
+	case enumor.BillReportCadenceMonthly:
+		return now.Day() >= sub.SendDay, nil
+	case enumor.BillReportCadenceOnConfirm:
+		return s.isRootSummaryConfirmed(kt, sub.Vendor, year, month)
+	default:
+		return false, fmt.Errorf("unsupported bill report cadence: %s", sub.Cadence)
+	}
+}
+
+// isRootSummaryConfirmed 指定月份的一级账号账单是否全部确认，vendor 为空时检查所有云厂商
+func (s *Scheduler) isRootSummaryConfirmed(kt *kit.Kit, vendor enumor.Vendor, year, month int) (bool, error) {
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("bill_year", year),
+		tools.RuleEqual("bill_month", month),
+	}
+	if len(vendor) != 0 {
+		rules = append(rules, tools.RuleEqual("vendor", vendor))
+	}
+
+	listReq := &dsbill.BillSummaryRootListReq{Filter: tools.ExpressionAnd(rules...), Page: core.NewCountPage()}
+	total, err := s.Client.DataService().Global.Bill.ListBillSummaryRoot(kt, listReq)
+	if err != nil {
+		logs.Errorf("count root account summary failed, err: %v, rid: %s", err, kt.Rid)
+		return false, err
+	}
+	if total.Count == nil || *total.Count == 0 {
+		return false, nil
+	}
+
+	rules = append(rules, tools.RuleNotEqual("state", enumor.RootAccountBillSummaryStateConfirmed))
+	listReq.Filter = tools.ExpressionAnd(rules...)
+	unconfirmed, err := s.Client.DataService().Global.Bill.ListBillSummaryRoot(kt, listReq)
+	if err != nil {
+		logs.Errorf("count unconfirmed root account summary failed, err: %v, rid: %s", err, kt.Rid)
+		return false, err
+	}
+	return unconfirmed.Count != nil && *unconfirmed.Count == 0, nil
+}
+
+// DeliverResult 报表发送结果
+type DeliverResult struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
+// Deliver 生成订阅指定账单月份的报表，上传至对象存储并将下载链接发送给订阅接收人
+func (s *Scheduler) Deliver(kt *kit.Kit, sub *bill.ReportSubscription, year, month int) (*DeliverResult, error) {
+	if s.CmsiCli == nil {
+		return nil, errors.New("cmsi is not configured, can not deliver bill report")
+	}
+	if s.Storage == nil {
+		return nil, errors.New("object store is not configured, can not deliver bill report")
+	}
+
+	filename, localPath, err := s.generate(kt, sub, year, month)
+	if err != nil {
+		return nil, err
+	}
+	defer removeFile(kt, localPath)
+
+	ttl := *cc.AccountServer().Report.LinkTTL
+	url, err := s.upload(kt, sub, year, month, filename, localPath, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.CmsiCli.SendMail(kt, buildReportMail(sub, year, month, filename, url, ttl)); err != nil {
+		logs.Errorf("send bill report mail failed, err: %v, id: %s, rid: %s", err, sub.ID, kt.Rid)
+		return nil, err
+	}
+
+	logs.Infof("bill report delivered, id: %s, month: %d-%02d, file: %s, rid: %s", sub.ID, year, month,
+		filename, kt.Rid)
+	return &DeliverResult{Filename: filename, URL: url}, nil
+}
+
+// lastBillMonth 订阅报表发送的是上一个自然月的账单
+func lastBillMonth(now time.Time) (int, int) {
+	last := now.AddDate(0, 0, -now.Day())
+	return last.Year(), int(last.Month())
+}
+
+func delivered(sub *bill.ReportSubscription, year, month int) bool {
+	return sub.LastBillYear*100+sub.LastBillMonth >= year*100+month
+}
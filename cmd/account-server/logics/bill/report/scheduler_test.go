@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package report
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/api/core/bill"
+)
+
+func TestLastBillMonth(t *testing.T) {
+	cases := []struct {
+		now         time.Time
+		year, month int
+	}{
+		{now: time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local), year: 2024, month: 9},
+		{now: time.Date(2024, 10, 31, 23, 0, 0, 0, time.Local), year: 2024, month: 9},
+		{now: time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local), year: 2023, month: 12},
+		{now: time.Date(2024, 3, 31, 0, 0, 0, 0, time.Local), year: 2024, month: 2},
+	}
+	for _, one := range cases {
+		year, month := lastBillMonth(one.now)
+		if year != one.year || month != one.month {
+			t.Errorf("last bill month of %s expect %d-%02d, got %d-%02d", one.now, one.year, one.month, year, month)
+		}
+	}
+}
+
+func TestDelivered(t *testing.T) {
+	sub := &bill.ReportSubscription{LastBillYear: 2024, LastBillMonth: 9}
+	if !delivered(sub, 2024, 9) || !delivered(sub, 2023, 12) {
+		t.Errorf("bill report of 2024-09 and before should be delivered")
+	}
+	if delivered(sub, 2024, 10) || delivered(sub, 2025, 1) {
+		t.Errorf("bill report after 2024-09 should not be delivered")
+	}
+	if delivered(&bill.ReportSubscription{}, 2024, 9) {
+		t.Errorf("new subscription should not be delivered")
+	}
+}
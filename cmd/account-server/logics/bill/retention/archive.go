@@ -0,0 +1,272 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package retention
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/cmd/task-server/logics/action/bill/archive"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	taskserver "hcm/pkg/api/task-server"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+)
+
+type partitionKey struct {
+	vendor    enumor.Vendor
+	billYear  int
+	billMonth int
+}
+
+// archive 检查全部账单明细分表，归档早于指定月份的分表，并删除恢复期满的分表
+func (m *Manager) archive(kt *kit.Kit, now time.Time, year, month int, restoreTTL time.Duration) error {
+	partitions, err := m.Client.DataService().Global.Bill.ListBillItemPartition(kt)
+	if err != nil {
+		logs.Errorf("list bill item partition failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+	archives, err := m.listAllArchive(kt)
+	if err != nil {
+		return err
+	}
+
+	existPartitions := make(map[partitionKey]struct{}, len(partitions.Details))
+	for _, partition := range partitions.Details {
+		key := partitionKey{vendor: partition.Vendor, billYear: partition.BillYear, billMonth: partition.BillMonth}
+		existPartitions[key] = struct{}{}
+		if !isBefore(partition.BillYear, partition.BillMonth, year, month) {
+			continue
+		}
+		if err = m.archivePartition(kt, &partition, archives[key]); err != nil {
+			logs.Errorf("archive bill item partition %s failed, err: %v, rid: %s", partition.TableName, err,
+				kt.Rid)
+		}
+	}
+
+	for key, one := range archives {
+		_, exist := existPartitions[key]
+		if err = m.checkArchive(kt, now, one, exist, restoreTTL); err != nil {
+			logs.Errorf("check bill archive %s failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+		}
+	}
+	return nil
+}
+
+func (m *Manager) listAllArchive(kt *kit.Kit) (map[partitionKey]*bill.Archive, error) {
+	result := make(map[partitionKey]*bill.Archive)
+	listReq := &core.ListReq{Filter: tools.AllExpression(), Page: core.NewDefaultBasePage()}
+	for {
+		resp, err := m.Client.DataService().Global.Bill.ListBillArchive(kt, listReq)
+		if err != nil {
+			logs.Errorf("list bill archive failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for idx := range resp.Details {
+			one := &resp.Details[idx]
+			result[partitionKey{vendor: one.Vendor, billYear: one.BillYear, billMonth: one.BillMonth}] = one
+		}
+		if uint(len(resp.Details)) < listReq.Page.Limit {
+			return result, nil
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+}
+
+// archivePartition 为超过保留期的分表创建归档任务，归档中、已恢复的分表跳过，已归档的分表为上次删除失败的残留
+func (m *Manager) archivePartition(kt *kit.Kit, partition *typesbill.BillItemPartition, one *bill.Archive) error {
+	opt := &archive.ArchiveOption{
+		Vendor:    partition.Vendor,
+		BillYear:  partition.BillYear,
+		BillMonth: partition.BillMonth,
+	}
+	if one == nil {
+		createReq := &dsbill.BillArchiveCreateReq{
+			Vendor:    partition.Vendor,
+			BillYear:  partition.BillYear,
+			BillMonth: partition.BillMonth,
+			State:     enumor.BillArchiveArchiving,
+		}
+		result, err := m.Client.DataService().Global.Bill.CreateBillArchive(kt, createReq)
+		if err != nil {
+			return fmt.Errorf("create bill archive failed, err: %v", err)
+		}
+		opt.ArchiveID = result.ID
+		_, err = m.startFlow(kt, enumor.FlowBillArchive, archive.BuildArchiveTask(opt), enumor.BillArchiveFailed,
+			opt.ArchiveID)
+		return err
+	}
+
+	opt.ArchiveID = one.ID
+	switch one.State {
+	case enumor.BillArchiveFailed:
+		updateReq := &dsbill.BillArchiveUpdateReq{ID: one.ID, State: enumor.BillArchiveArchiving}
+		if err := m.Client.DataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+			return fmt.Errorf("update bill archive to archiving failed, err: %v", err)
+		}
+		_, err := m.startFlow(kt, enumor.FlowBillArchive, archive.BuildArchiveTask(opt), enumor.BillArchiveFailed,
+			opt.ArchiveID)
+		return err
+	case enumor.BillArchiveArchived:
+		logs.Infof("drop archived bill item partition %s left by last archive, rid: %s", partition.TableName,
+			kt.Rid)
+		return m.dropPartition(kt, one)
+	default:
+		return nil
+	}
+}
+
+// checkArchive 修正异步任务异常结束的归档记录，并删除恢复期满的分表
+func (m *Manager) checkArchive(kt *kit.Kit, now time.Time, one *bill.Archive, partitionExist bool,
+	restoreTTL time.Duration) error {
+
+	switch one.State {
+	case enumor.BillArchiveArchiving, enumor.BillArchiveRestoring:
+		return m.checkFlow(kt, one)
+	case enumor.BillArchiveRestored:
+		if !partitionExist {
+			return m.updateState(kt, one.ID, enumor.BillArchiveArchived, "")
+		}
+		restoredAt, err := time.ParseInLocation(constant.TimeStdFormat, one.RestoredAt, time.Local)
+		if err != nil {
+			return fmt.Errorf("parse restored at %s failed, err: %v", one.RestoredAt, err)
+		}
+		if now.Sub(restoredAt) < restoreTTL {
+			return nil
+		}
+		if err = m.dropPartition(kt, one); err != nil {
+			return err
+		}
+		logs.Infof("restored bill item of %s %d-%02d expired and dropped, rid: %s", one.Vendor, one.BillYear,
+			one.BillMonth, kt.Rid)
+		return m.updateState(kt, one.ID, enumor.BillArchiveArchived, "")
+	default:
+		return nil
+	}
+}
+
+// checkFlow 任务服务异常时异步任务可能未更新归档记录就结束，此时按失败处理
+func (m *Manager) checkFlow(kt *kit.Kit, one *bill.Archive) error {
+	if len(one.FlowID) == 0 {
+		return nil
+	}
+	flow, err := m.Client.TaskServer().GetFlow(kt, one.FlowID)
+	if err != nil {
+		return fmt.Errorf("get flow %s failed, err: %v", one.FlowID, err)
+	}
+	if flow.State != enumor.FlowFailed && flow.State != enumor.FlowCancel {
+		return nil
+	}
+
+	reason := fmt.Sprintf("flow %s is %s", one.FlowID, flow.State)
+	if one.State == enumor.BillArchiveArchiving {
+		return m.updateState(kt, one.ID, enumor.BillArchiveFailed, reason)
+	}
+	return m.updateState(kt, one.ID, enumor.BillArchiveArchived, reason)
+}
+
+func (m *Manager) dropPartition(kt *kit.Kit, one *bill.Archive) error {
+	dropReq := &dsbill.BillItemPartitionReq{Vendor: one.Vendor, Year: one.BillYear, Month: one.BillMonth}
+	if err := m.Client.DataService().Global.Bill.DropBillItemPartition(kt, dropReq); err != nil {
+		return fmt.Errorf("drop bill item partition failed, err: %v", err)
+	}
+	return nil
+}
+
+func (m *Manager) updateState(kt *kit.Kit, id string, state enumor.BillArchiveState, reason string) error {
+	updateReq := &dsbill.BillArchiveUpdateReq{ID: id, State: state, Reason: cvt.ValToPtr(reason)}
+	if err := m.Client.DataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+		return fmt.Errorf("update bill archive to %s failed, err: %v", state, err)
+	}
+	return nil
+}
+
+// startFlow 创建归档或恢复异步任务并记录任务ID，创建失败时将归档记录置为 failState
+func (m *Manager) startFlow(kt *kit.Kit, flowName enumor.FlowName, task taskserver.CustomFlowTask,
+	failState enumor.BillArchiveState, archiveID string) (string, error) {
+
+	flowReq := &taskserver.AddCustomFlowReq{
+		Name:  flowName,
+		Memo:  fmt.Sprintf("%s %s", flowName, archiveID),
+		Tasks: []taskserver.CustomFlowTask{task},
+	}
+	result, err := m.Client.TaskServer().CreateCustomFlow(kt, flowReq)
+	if err != nil {
+		if updateErr := m.updateState(kt, archiveID, failState, err.Error()); updateErr != nil {
+			logs.Errorf("fail to reset bill archive %s, err: %v, rid: %s", archiveID, updateErr, kt.Rid)
+		}
+		return "", fmt.Errorf("create %s flow failed, err: %v", flowName, err)
+	}
+
+	updateReq := &dsbill.BillArchiveUpdateReq{ID: archiveID, FlowID: result.ID, Reason: cvt.ValToPtr("")}
+	if err = m.Client.DataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+		return "", fmt.Errorf("update flow id of bill archive failed, err: %v", err)
+	}
+	logs.Infof("create %s flow %s for bill archive %s, rid: %s", flowName, result.ID, archiveID, kt.Rid)
+	return result.ID, nil
+}
+
+// Restore 从归档恢复指定月份的账单明细分表，返回恢复任务ID，恢复的分表在保留期满后重新删除
+func (m *Manager) Restore(kt *kit.Kit, archiveID string) (string, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", archiveID),
+		Page:   core.NewDefaultBasePage(),
+	}
+	resp, err := m.Client.DataService().Global.Bill.ListBillArchive(kt, listReq)
+	if err != nil {
+		logs.Errorf("list bill archive %s failed, err: %v, rid: %s", archiveID, err, kt.Rid)
+		return "", err
+	}
+	if len(resp.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "bill archive %s not found", archiveID)
+	}
+	one := resp.Details[0]
+	if one.State != enumor.BillArchiveArchived {
+		return "", errf.Newf(errf.InvalidParameter, "bill archive in %s state can not be restored", one.State)
+	}
+
+	updateReq := &dsbill.BillArchiveUpdateReq{ID: one.ID, State: enumor.BillArchiveRestoring}
+	if err = m.Client.DataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+		logs.Errorf("update bill archive %s to restoring failed, err: %v, rid: %s", one.ID, err, kt.Rid)
+		return "", err
+	}
+
+	opt := &archive.ArchiveOption{
+		ArchiveID: one.ID,
+		Vendor:    one.Vendor,
+		BillYear:  one.BillYear,
+		BillMonth: one.BillMonth,
+	}
+	flowID, err := m.startFlow(kt, enumor.FlowBillArchiveRestore, archive.BuildRestoreTask(opt),
+		enumor.BillArchiveArchived, one.ID)
+	if err != nil {
+		logs.Errorf("start bill archive restore flow failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+	return flowID, nil
+}
@@ -0,0 +1,185 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package retention 账单数据保留策略，清理过期的账单中间数据，归档超过保留期的账单明细分表
+package retention
+
+import (
+	"context"
+	"time"
+
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+)
+
+// Manager 账单数据保留管理，定时清理过期数据并归档账单明细分表，只在主节点执行
+type Manager struct {
+	Sd     serviced.ServiceDiscover
+	Client *client.ClientSet
+}
+
+// Run 定时执行账单数据保留检查
+func (m *Manager) Run(ctx context.Context) {
+	opt := cc.AccountServer().Retention
+	if opt.Disable {
+		logs.Infof("bill retention manager is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(*opt.CheckDuration)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !m.Sd.IsMaster() {
+				continue
+			}
+
+			kt := core.NewBackendKit()
+			if err := m.RunAll(kt, time.Now()); err != nil {
+				logs.Errorf("run bill retention failed, err: %v, rid: %s", err, kt.Rid)
+			}
+		case <-ctx.Done():
+			logs.Infof("bill retention manager context done")
+			return
+		}
+	}
+}
+
+// RunAll 清理过期的账单中间数据，归档超过保留期的账单明细分表，并删除恢复期满的分表
+func (m *Manager) RunAll(kt *kit.Kit, now time.Time) error {
+	opt := cc.AccountServer().Retention
+	m.purge(kt, now, opt)
+
+	if opt.BillItemMonths == 0 {
+		return nil
+	}
+	year, month := cutoffMonth(now, opt.BillItemMonths)
+	return m.archive(kt, now, year, month, *opt.RestoreTTL)
+}
+
+type purgeFunc func(kt *kit.Kit, expr *filter.Expression) (uint64, error)
+
+// purge 删除保留期之前的日账单拉取任务、每日汇总及账单版本汇总，单张表失败不影响其他表
+func (m *Manager) purge(kt *kit.Kit, now time.Time, opt cc.BillRetentionOption) {
+	tables := []struct {
+		name   string
+		months int
+		purge  purgeFunc
+	}{
+		{name: "daily pull task", months: opt.DailyPullTaskMonths, purge: m.purgeDailyPullTask},
+		{name: "summary daily", months: opt.SummaryDailyMonths, purge: m.purgeSummaryDaily},
+		{name: "summary version", months: opt.SummaryVersionMonths, purge: m.purgeSummaryVersion},
+	}
+	for _, table := range tables {
+		if table.months == 0 {
+			continue
+		}
+		year, month := cutoffMonth(now, table.months)
+		count, err := table.purge(kt, beforeMonth(year, month))
+		if err != nil {
+			logs.Errorf("purge bill %s before %d-%02d failed, err: %v, rid: %s", table.name, year, month, err,
+				kt.Rid)
+			continue
+		}
+		if count > 0 {
+			logs.Infof("purged %d bill %s before %d-%02d, rid: %s", count, table.name, year, month, kt.Rid)
+		}
+	}
+}
+
+func (m *Manager) purgeDailyPullTask(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	resp, err := m.Client.DataService().Global.Bill.ListBillDailyPullTask(kt,
+		&dsbill.BillDailyPullTaskListReq{Filter: expr, Page: core.NewCountPage()})
+	if err != nil {
+		return 0, err
+	}
+	return deleteInBatch(*resp.Count, func() error {
+		return m.Client.DataService().Global.Bill.BatchDeleteBillDailyPullTask(kt,
+			&dataservice.BatchDeleteReq{Filter: expr})
+	})
+}
+
+func (m *Manager) purgeSummaryDaily(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	resp, err := m.Client.DataService().Global.Bill.ListBillSummaryDaily(kt,
+		&dsbill.BillSummaryDailyListReq{Filter: expr, Page: core.NewCountPage()})
+	if err != nil {
+		return 0, err
+	}
+	return deleteInBatch(*resp.Count, func() error {
+		return m.Client.DataService().Global.Bill.BatchDeleteBillSummaryDaily(kt,
+			&dataservice.BatchDeleteReq{Filter: expr})
+	})
+}
+
+func (m *Manager) purgeSummaryVersion(kt *kit.Kit, expr *filter.Expression) (uint64, error) {
+	resp, err := m.Client.DataService().Global.Bill.ListBillSummaryVersion(kt,
+		&dsbill.BillSummaryVersionListReq{Filter: expr, Page: core.NewCountPage()})
+	if err != nil {
+		return 0, err
+	}
+	return deleteInBatch(*resp.Count, func() error {
+		return m.Client.DataService().Global.Bill.BatchDeleteBillSummaryVersion(kt,
+			&dataservice.BatchDeleteReq{Filter: expr})
+	})
+}
+
+// deleteInBatch data-service 每次按条件最多删除一页数据，按总数分多次删除
+func deleteInBatch(count uint64, deleteOnce func() error) (uint64, error) {
+	for deleted := uint64(0); deleted < count; deleted += uint64(core.DefaultMaxPageLimit) {
+		if err := deleteOnce(); err != nil {
+			return deleted, err
+		}
+	}
+	return count, nil
+}
+
+// cutoffMonth 保留月数不包括当前月，早于返回月份的数据超过保留期
+func cutoffMonth(now time.Time, months int) (int, int) {
+	cur := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	cutoff := cur.AddDate(0, -months, 0)
+	return cutoff.Year(), int(cutoff.Month())
+}
+
+// isBefore 判断账单月份是否早于指定月份
+func isBefore(billYear, billMonth, year, month int) bool {
+	return billYear < year || (billYear == year && billMonth < month)
+}
+
+// beforeMonth 早于指定月份的过滤条件
+func beforeMonth(year, month int) *filter.Expression {
+	return &filter.Expression{
+		Op: filter.Or,
+		Rules: []filter.RuleFactory{
+			tools.RuleLessThan("bill_year", year),
+			tools.ExpressionAnd(
+				tools.RuleEqual("bill_year", year),
+				tools.RuleLessThan("bill_month", month),
+			),
+		},
+	}
+}
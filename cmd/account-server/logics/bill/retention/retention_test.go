@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package retention
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCutoffMonth(t *testing.T) {
+	cases := []struct {
+		now    time.Time
+		months int
+		year   int
+		month  int
+	}{
+		{now: time.Date(2024, 10, 28, 0, 0, 0, 0, time.UTC), months: 3, year: 2024, month: 7},
+		{now: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), months: 12, year: 2023, month: 2},
+		{now: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), months: 4, year: 2023, month: 11},
+	}
+	for _, c := range cases {
+		year, month := cutoffMonth(c.now, c.months)
+		if year != c.year || month != c.month {
+			t.Errorf("now: %s, months: %d, got: %d-%02d, expect: %d-%02d", c.now.Format(time.DateOnly), c.months,
+				year, month, c.year, c.month)
+		}
+	}
+
+	if !isBefore(2023, 12, 2024, 1) || !isBefore(2024, 6, 2024, 7) {
+		t.Errorf("month before cutoff should be expired")
+	}
+	if isBefore(2024, 7, 2024, 7) || isBefore(2025, 1, 2024, 7) {
+		t.Errorf("month not before cutoff should be kept")
+	}
+}
@@ -0,0 +1,303 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billallocation
+
+import (
+	"fmt"
+
+	"hcm/cmd/account-server/logics/bill/monthtask"
+	"hcm/cmd/task-server/logics/action/bill/allocation"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+)
+
+// CreateBillAllocationRule 创建共享费用分摊规则
+func (s *service) CreateBillAllocationRule(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillAllocationRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if _, ok := monthtask.GetMonthTaskDescriber(req.Vendor); !ok {
+		return nil, errf.New(errf.InvalidParameter, fmt.Sprintf("vendor %s has no month task, allocation rule "+
+			"is not supported", req.Vendor))
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Create}})
+	if err != nil {
+		return nil, err
+	}
+
+	rootAccount, err := s.client.DataService().Global.RootAccount.GetBasicInfo(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("get root account failed, err: %v, id: %s, rid: %s", err, req.RootAccountID, cts.Kit.Rid)
+		return nil, err
+	}
+	if rootAccount.Vendor != req.Vendor {
+		return nil, errf.New(errf.InvalidParameter, fmt.Sprintf("vendor of root account %s is %s, not %s",
+			req.RootAccountID, rootAccount.Vendor, req.Vendor))
+	}
+	rule := &billcore.AllocationRule{
+		RootAccountID:         req.RootAccountID,
+		SourceType:            req.SourceType,
+		SourceMainAccountIDs:  req.SourceMainAccountIDs,
+		ExcludeMainAccountIDs: req.ExcludeMainAccountIDs,
+		Targets:               req.Targets,
+		CreditIDs:             req.CreditIDs,
+	}
+	if err = s.validateRuleAccounts(cts.Kit, rule); err != nil {
+		return nil, err
+	}
+	if req.Enabled == nil || *req.Enabled {
+		if err = s.ensureNoConflictRule(cts.Kit, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.client.DataService().Global.Bill.CreateBillAllocationRule(cts.Kit, req)
+}
+
+// UpdateBillAllocationRule 更新共享费用分摊规则
+func (s *service) UpdateBillAllocationRule(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+	req := new(asbill.UpdateBillAllocationRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getBillAllocationRule(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+	updateReq := &dsbill.BillAllocationRuleUpdateReq{
+		ID:                    id,
+		Name:                  req.Name,
+		RuleType:              req.RuleType,
+		SourceMainAccountIDs:  req.SourceMainAccountIDs,
+		SourceHcProductCodes:  req.SourceHcProductCodes,
+		ExcludeMainAccountIDs: req.ExcludeMainAccountIDs,
+		Targets:               req.Targets,
+		SpArnPrefix:           req.SpArnPrefix,
+		CreditIDs:             req.CreditIDs,
+		Enabled:               req.Enabled,
+		Memo:                  req.Memo,
+	}
+
+	// 与当前规则合并后整体校验
+	merged := *rule
+	if len(req.RuleType) != 0 {
+		merged.RuleType = req.RuleType
+	}
+	if req.SourceMainAccountIDs != nil {
+		merged.SourceMainAccountIDs = req.SourceMainAccountIDs
+	}
+	if req.SourceHcProductCodes != nil {
+		merged.SourceHcProductCodes = req.SourceHcProductCodes
+	}
+	if req.ExcludeMainAccountIDs != nil {
+		merged.ExcludeMainAccountIDs = req.ExcludeMainAccountIDs
+	}
+	if req.Targets != nil {
+		merged.Targets = req.Targets
+	}
+	if req.SpArnPrefix != nil {
+		merged.SpArnPrefix = *req.SpArnPrefix
+	}
+	if req.CreditIDs != nil {
+		merged.CreditIDs = req.CreditIDs
+	}
+	if req.Enabled != nil {
+		merged.Enabled = *req.Enabled
+	}
+	if merged.RuleType != enumor.BillAllocationFixed && req.Targets == nil && len(rule.Targets) != 0 {
+		// 修改为按比例分摊时清空固定比例的目标
+		merged.Targets = nil
+		updateReq.Targets = make([]billcore.AllocationTarget, 0)
+	}
+	if err = dsbill.ValidateAllocationRule(&merged); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if err = s.validateRuleAccounts(cts.Kit, &merged); err != nil {
+		return nil, err
+	}
+	if merged.Enabled {
+		if err = s.ensureNoConflictRule(cts.Kit, &merged); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.client.DataService().Global.Bill.UpdateBillAllocationRule(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update bill allocation rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// DeleteBillAllocationRule 删除共享费用分摊规则
+func (s *service) DeleteBillAllocationRule(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Delete}})
+	if err != nil {
+		return nil, err
+	}
+
+	req := &dataservice.BatchDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err = s.client.DataService().Global.Bill.BatchDeleteBillAllocationRule(cts.Kit, req); err != nil {
+		logs.Errorf("delete bill allocation rule failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ListBillAllocationRule 查询共享费用分摊规则
+func (s *service) ListBillAllocationRule(cts *rest.Contexts) (any, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DataService().Global.Bill.ListBillAllocationRule(cts.Kit, req)
+}
+
+func (s *service) getBillAllocationRule(kt *kit.Kit, id string) (*billcore.AllocationRule, error) {
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := s.client.DataService().Global.Bill.ListBillAllocationRule(kt, listReq)
+	if err != nil {
+		logs.Errorf("get bill allocation rule failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.New(errf.RecordNotFound, fmt.Sprintf("bill allocation rule %s not found", id))
+	}
+	return &result.Details[0], nil
+}
+
+// validateRuleAccounts 规则中的二级账号必须属于该一级账号
+func (s *service) validateRuleAccounts(kt *kit.Kit, rule *billcore.AllocationRule) error {
+	mainAccountMap, err := allocation.NewAllocator(s.client.DataService()).ListMainAccounts(kt, rule.RootAccountID)
+	if err != nil {
+		return err
+	}
+	check := func(field string, ids []string) error {
+		for _, id := range ids {
+			if _, ok := mainAccountMap[id]; !ok {
+				return errf.New(errf.InvalidParameter, fmt.Sprintf("main account %s in %s not found under root "+
+					"account %s", id, field, rule.RootAccountID))
+			}
+		}
+		return nil
+	}
+	targetIDs := make([]string, 0, len(rule.Targets))
+	for _, target := range rule.Targets {
+		targetIDs = append(targetIDs, target.MainAccountID)
+	}
+	if err = check("source_main_account_ids", rule.SourceMainAccountIDs); err != nil {
+		return err
+	}
+	if err = check("exclude_main_account_ids", rule.ExcludeMainAccountIDs); err != nil {
+		return err
+	}
+	return check("targets", targetIDs)
+}
+
+// ensureNoConflictRule 每个一级账号只允许启用一条公共费用、savings plans分摊规则，同一个credit只能归还给一个账号
+func (s *service) ensureNoConflictRule(kt *kit.Kit, rule *billcore.AllocationRule) error {
+	switch rule.SourceType {
+	case enumor.BillAllocationSourceCommonExpense, enumor.BillAllocationSourceSavingsPlans,
+		enumor.BillAllocationSourceCredits:
+	default:
+		return nil
+	}
+
+	rules, err := allocation.NewAllocator(s.client.DataService()).ListRules(kt, rule.RootAccountID, rule.SourceType)
+	if err != nil {
+		return err
+	}
+	for _, one := range rules {
+		if one.ID == rule.ID {
+			continue
+		}
+		if rule.SourceType != enumor.BillAllocationSourceCredits {
+			return errf.New(errf.InvalidParameter, fmt.Sprintf("root account %s already has enabled %s "+
+				"allocation rule %s", rule.RootAccountID, rule.SourceType, one.ID))
+		}
+		for _, creditID := range one.CreditIDs {
+			if slice.IsItemInSlice(rule.CreditIDs, creditID) {
+				return errf.New(errf.InvalidParameter, fmt.Sprintf("credit %s already returned by allocation "+
+					"rule %s", creditID, one.ID))
+			}
+		}
+	}
+	return nil
+}
+
+// excludeRootAsMainAccount 作为二级账号录入的根账号用于冲平公共费用，不参与分摊
+func excludeRootAsMainAccount(mainAccountMap map[string]*protocore.BaseMainAccount,
+	rootCloudID string) map[string]*protocore.BaseMainAccount {
+
+	result := make(map[string]*protocore.BaseMainAccount, len(mainAccountMap))
+	for id, account := range mainAccountMap {
+		if account.CloudID != rootCloudID {
+			result[id] = account
+		}
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billallocation
+
+import (
+	"hcm/cmd/task-server/logics/action/bill/allocation"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// PreviewBillAllocationRule 预览分摊规则在指定账单月份的分摊结果，不落库
+func (s *service) PreviewBillAllocationRule(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+	req := new(asbill.PreviewBillAllocationRuleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.getBillAllocationRule(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+	allocator := allocation.NewAllocator(s.client.DataService())
+	mainAccountMap, err := allocator.ListMainAccounts(cts.Kit, rule.RootAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []allocation.SourceCost
+	switch rule.SourceType {
+	case enumor.BillAllocationSourceBillItem:
+		sources, err = allocator.SumBillItemSource(cts.Kit, rule, req.BillYear, req.BillMonth)
+	case enumor.BillAllocationSourceCommonExpense:
+		rootAccount, err := s.client.DataService().Global.RootAccount.GetBasicInfo(cts.Kit, rule.RootAccountID)
+		if err != nil {
+			logs.Errorf("get root account failed, err: %v, id: %s, rid: %s", err, rule.RootAccountID, cts.Kit.Rid)
+			return nil, err
+		}
+		mainAccountMap = excludeRootAsMainAccount(mainAccountMap, rootAccount.CloudID)
+		sources, err = allocator.SumCommonExpense(cts.Kit, rule, req.BillYear, req.BillMonth)
+		if err != nil {
+			return nil, err
+		}
+	case enumor.BillAllocationSourceSavingsPlans, enumor.BillAllocationSourceCredits:
+		sources, err = allocator.SumReturnedCost(cts.Kit, rule, req.BillYear, req.BillMonth)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "unsupported allocation source type: %s", rule.SourceType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	shares, err := allocator.ListShares(cts.Kit, rule, req.BillYear, req.BillMonth, mainAccountMap)
+	if err != nil {
+		logs.Errorf("list allocation shares failed, err: %v, rule: %s, rid: %s", err, rule.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	result := &asbill.PreviewBillAllocationRuleResult{
+		RuleID:  rule.ID,
+		Sources: make([]asbill.AllocationSourceCost, 0, len(sources)),
+		Details: make([]asbill.AllocationPreviewItem, 0),
+	}
+	for _, source := range sources {
+		result.Sources = append(result.Sources, asbill.AllocationSourceCost{
+			MainAccountID: source.MainAccountID,
+			Currency:      source.Currency,
+			Cost:          source.Cost,
+		})
+		if len(shares) == 0 {
+			continue
+		}
+		costs := allocation.SplitCost(source.Cost, shares)
+		for idx, share := range shares {
+			result.Details = append(result.Details, asbill.AllocationPreviewItem{
+				MainAccountID:       share.MainAccount.ID,
+				MainAccountCloudID:  share.MainAccount.CloudID,
+				BkBizID:             share.MainAccount.BkBizID,
+				SourceMainAccountID: source.MainAccountID,
+				Currency:            source.Currency,
+				Ratio:               share.Ratio,
+				Cost:                costs[idx],
+			})
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package billarchive 账单明细归档查询及恢复
+package billarchive
+
+import (
+	"net/http"
+
+	"hcm/cmd/account-server/logics/bill/retention"
+	"hcm/cmd/account-server/service/capability"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// InitService 注册账单明细归档服务
+func InitService(c *capability.Capability) {
+	svc := &service{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		retention:  c.Retention,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("ListBillArchive", http.MethodPost, "/bills/archives/list", svc.ListBillArchive)
+	h.Add("RestoreBillArchive", http.MethodPost, "/bills/archives/{id}/restore", svc.RestoreBillArchive)
+	h.Add("ListBillItemPartition", http.MethodPost, "/bills/items/partitions/list", svc.ListBillItemPartition)
+
+	h.Load(c.WebService)
+}
+
+type service struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	retention  *retention.Manager
+}
+
+// ListBillArchive 查询账单明细归档记录
+func (s *service) ListBillArchive(cts *rest.Contexts) (any, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DataService().Global.Bill.ListBillArchive(cts.Kit, req)
+}
+
+// RestoreBillArchive 从对象存储恢复已归档月份的账单明细，用于重新导出，恢复的分表在保留期满后重新删除
+func (s *service) RestoreBillArchive(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	flowID, err := s.retention.Restore(cts.Kit, id)
+	if err != nil {
+		logs.Errorf("restore bill archive failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return &asbill.BillArchiveRestoreResult{FlowID: flowID}, nil
+}
+
+// ListBillItemPartition 查询全部账单明细分表及其估算行数、占用空间
+func (s *service) ListBillItemPartition(cts *rest.Contexts) (any, error) {
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DataService().Global.Bill.ListBillItemPartition(cts.Kit)
+}
@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package billcommitment 预留实例、savings plans 覆盖率及使用率分析
+package billcommitment
+
+import (
+	"net/http"
+	"time"
+
+	"hcm/cmd/account-server/logics/bill/commitment"
+	"hcm/cmd/account-server/service/capability"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitService 注册承诺类折扣分析服务
+func InitService(c *capability.Capability) {
+	svc := &service{
+		authorizer: c.Authorizer,
+		analyzer:   &commitment.Analyzer{Client: c.ApiClient},
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("AnalyzeAwsSavingsPlan", http.MethodPost, "/bills/commitments/aws/savings_plans/analyze",
+		svc.AnalyzeAwsSavingsPlan)
+	h.Add("AnalyzeTCloudReservedInstance", http.MethodPost, "/bills/commitments/tcloud/reserved_instances/analyze",
+		svc.AnalyzeTCloudReservedInstance)
+
+	h.Load(c.WebService)
+}
+
+type service struct {
+	authorizer auth.Authorizer
+	analyzer   *commitment.Analyzer
+}
+
+// AnalyzeAwsSavingsPlan 分析一级账号 savings plans 使用率、EC2实例覆盖率及追加购买建议
+func (s *service) AnalyzeAwsSavingsPlan(cts *rest.Contexts) (any, error) {
+	req := new(asbill.AwsSavingsPlanAnalysisReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.analyzer.AnalyzeAwsSavingsPlan(cts.Kit, req)
+}
+
+// AnalyzeTCloudReservedInstance 分析腾讯云账号地域下预留实例覆盖率、未使用费用及购买建议
+func (s *service) AnalyzeTCloudReservedInstance(cts *rest.Contexts) (any, error) {
+	req := new(asbill.TCloudReservedInstanceAnalysisReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.analyzer.AnalyzeTCloudReservedInstance(cts.Kit, req, time.Now())
+}
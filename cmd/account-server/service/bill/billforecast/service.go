@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package billforecast 费用预测
+package billforecast
+
+import (
+	"net/http"
+	"time"
+
+	"hcm/cmd/account-server/logics/bill/forecast"
+	"hcm/cmd/account-server/service/capability"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/rest"
+)
+
+// InitService 注册费用预测服务
+func InitService(c *capability.Capability) {
+	svc := &service{
+		authorizer: c.Authorizer,
+		forecaster: &forecast.Forecaster{Client: c.ApiClient},
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("QueryBillForecast", http.MethodPost, "/bills/forecasts/query", svc.QueryBillForecast)
+
+	h.Load(c.WebService)
+}
+
+type service struct {
+	authorizer auth.Authorizer
+	forecaster *forecast.Forecaster
+}
+
+// QueryBillForecast 预测业务或二级账号的月末及下季度费用
+func (s *service) QueryBillForecast(cts *rest.Contexts) (any, error) {
+	req := new(asbill.BillForecastReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.forecaster.Forecast(cts.Kit, req, time.Now())
+}
@@ -37,29 +37,18 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-func (b *billItemSvc) exportAwsBillItems(kt *kit.Kit, req *bill.ExportBillItemReq,
-	rate *decimal.Decimal) (any, error) {
+func (b *billItemSvc) writeAwsBillItems(kt *kit.Kit, req *bill.ExportBillItemReq, rate *decimal.Decimal,
+	writer export.RowWriter) error {
 
 	rootAccountMap, mainAccountMap, bizNameMap, err := b.fetchAccountBizInfo(kt, enumor.Aws)
 	if err != nil {
 		logs.Errorf("[exportAwsBillItems] fetch account and biz info failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
-	}
-
-	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(kt, generateFilename(enumor.Aws))
-	defer func() {
-		if closeFunc != nil {
-			closeFunc()
-		}
-	}()
-	if err != nil {
-		logs.Errorf("create writer failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
 	if err = writer.Write(export.AwsBillItemHeaders); err != nil {
 		logs.Errorf("csv write header failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
 	convFunc := func(items []*billapi.AwsBillItem) error {
@@ -82,14 +71,10 @@ func (b *billItemSvc) exportAwsBillItems(kt *kit.Kit, req *bill.ExportBillItemRe
 	err = b.fetchAwsBillItems(kt, req, convFunc)
 	if err != nil {
 		logs.Errorf("fetch aws bill items for export failed, req: %v, err: %v, rid: %s", req, err, kt.Rid)
-		return nil, err
+		return err
 	}
 
-	return &bill.FileDownloadResp{
-		ContentTypeStr:        "application/octet-stream",
-		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
-		FilePath:              filepath,
-	}, nil
+	return nil
 }
 
 func convertAwsBillItems(kt *kit.Kit, items []*billapi.AwsBillItem, bizNameMap map[int64]string,
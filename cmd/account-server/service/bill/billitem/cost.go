@@ -0,0 +1,258 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billitem
+
+import (
+	"hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	corebill "hcm/pkg/api/core/bill"
+	databill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// costResource 已同步的云资源，通过cloud_id关联账单明细
+type costResource struct {
+	ResType enumor.CloudResourceType
+	ID      string
+	CloudID string
+	Name    string
+	BkBizID int64
+}
+
+// ListResourceCost 查询指定资源的账单费用
+func (b *billItemSvc) ListResourceCost(cts *rest.Contexts) (any, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(bill.ListResourceCostReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := b.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	expr := tools.ExpressionAnd(tools.RuleEqual("vendor", vendor), tools.RuleIn("id", req.IDs))
+	resources, err := b.listCostResource(cts.Kit, req.ResType, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudIDs := make([]string, 0, len(resources))
+	for _, res := range resources {
+		cloudIDs = append(cloudIDs, res.CloudID)
+	}
+
+	commonOpt := &databill.ItemCommonOpt{Vendor: vendor, Year: req.BillYear, Month: req.BillMonth}
+	costs, err := b.listAllResCost(cts.Kit, commonOpt, tools.ContainersExpression("cloud_res_id", cloudIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	costMap := make(map[string][]bill.CurrencyCost)
+	for _, cost := range costs {
+		costMap[cost.CloudResID] = append(costMap[cost.CloudResID],
+			bill.CurrencyCost{Currency: cost.Currency, Cost: cost.Cost})
+	}
+
+	details := make([]bill.ResourceCostItem, 0, len(resources))
+	for _, res := range resources {
+		item := bill.ResourceCostItem{
+			ResType: res.ResType,
+			ID:      res.ID,
+			CloudID: res.CloudID,
+			Name:    res.Name,
+			BkBizID: res.BkBizID,
+			Costs:   costMap[res.CloudID],
+		}
+		if item.Costs == nil {
+			item.Costs = make([]bill.CurrencyCost, 0)
+		}
+		details = append(details, item)
+	}
+
+	return &bill.ResourceCostResult{Details: details}, nil
+}
+
+// ListBizResourceCost 查询业务下按云资源聚合的账单费用
+func (b *billItemSvc) ListBizResourceCost(cts *rest.Contexts) (any, error) {
+	vendor := enumor.Vendor(cts.PathParameter("vendor").String())
+	if err := vendor.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	req := new(bill.ListBizResourceCostReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := b.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &databill.BillItemListReq{
+		ItemCommonOpt: &databill.ItemCommonOpt{Vendor: vendor, Year: req.BillYear, Month: req.BillMonth},
+		ListReq:       &core.ListReq{Filter: tools.EqualExpression("bk_biz_id", req.BkBizID), Page: req.Page},
+	}
+	costs, err := b.client.DataService().Global.Bill.ListBillItemResCost(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list biz resource cost failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if req.Page.Count {
+		return &bill.BizResourceCostResult{Count: costs.Count}, nil
+	}
+
+	cloudIDs := make([]string, 0, len(costs.Details))
+	for _, cost := range costs.Details {
+		if len(cost.CloudResID) != 0 {
+			cloudIDs = append(cloudIDs, cost.CloudResID)
+		}
+	}
+
+	resMap := make(map[string]costResource)
+	if len(cloudIDs) > 0 {
+		expr := tools.ExpressionAnd(tools.RuleEqual("vendor", vendor), tools.RuleIn("cloud_id", slice.Unique(cloudIDs)))
+		for resType := range bill.CostResTypes {
+			resources, err := b.listCostResource(cts.Kit, resType, expr)
+			if err != nil {
+				return nil, err
+			}
+			for _, res := range resources {
+				resMap[res.CloudID] = res
+			}
+		}
+	}
+
+	details := make([]bill.BizResourceCostItem, 0, len(costs.Details))
+	for _, cost := range costs.Details {
+		item := bill.BizResourceCostItem{CloudResID: cost.CloudResID, Currency: cost.Currency, Cost: cost.Cost}
+		if res, exists := resMap[cost.CloudResID]; exists {
+			item.ResType = res.ResType
+			item.ID = res.ID
+			item.Name = res.Name
+		}
+		details = append(details, item)
+	}
+
+	return &bill.BizResourceCostResult{Details: details}, nil
+}
+
+// listAllResCost 分页查询满足条件的全部资源费用
+func (b *billItemSvc) listAllResCost(kt *kit.Kit, commonOpt *databill.ItemCommonOpt, expr *filter.Expression) (
+	[]*corebill.ResourceCost, error) {
+
+	listReq := &databill.BillItemListReq{
+		ItemCommonOpt: commonOpt,
+		ListReq:       &core.ListReq{Filter: expr, Page: core.NewDefaultBasePage()},
+	}
+
+	costs := make([]*corebill.ResourceCost, 0)
+	for {
+		result, err := b.client.DataService().Global.Bill.ListBillItemResCost(kt, listReq)
+		if err != nil {
+			logs.Errorf("list resource cost failed, err: %v, opt: %+v, rid: %s", err, commonOpt, kt.Rid)
+			return nil, err
+		}
+		costs = append(costs, result.Details...)
+
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return costs, nil
+}
+
+// listCostResource 查询满足条件的已同步资源，条件中的ID数量不超过500
+func (b *billItemSvc) listCostResource(kt *kit.Kit, resType enumor.CloudResourceType, expr *filter.Expression) (
+	[]costResource, error) {
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "cloud_id", "name", "bk_biz_id"},
+	}
+
+	resources := make([]costResource, 0)
+	switch resType {
+	case enumor.CvmCloudResType:
+		result, err := b.client.DataService().Global.Cvm.ListCvm(kt, listReq)
+		if err != nil {
+			logs.Errorf("list cvm for cost failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, costResource{ResType: resType, ID: one.ID, CloudID: one.CloudID,
+				Name: one.Name, BkBizID: one.BkBizID})
+		}
+	case enumor.DiskCloudResType:
+		result, err := b.client.DataService().Global.ListDisk(kt, listReq)
+		if err != nil {
+			logs.Errorf("list disk for cost failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, costResource{ResType: resType, ID: one.ID, CloudID: one.CloudID,
+				Name: one.Name, BkBizID: one.BkBizID})
+		}
+	case enumor.EipCloudResType:
+		result, err := b.client.DataService().Global.ListEip(kt, listReq)
+		if err != nil {
+			logs.Errorf("list eip for cost failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, one := range result.Details {
+			resources = append(resources, costResource{ResType: resType, ID: one.ID, CloudID: one.CloudID,
+				Name: converter.PtrToVal(one.Name), BkBizID: one.BkBizID})
+		}
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "res_type: %s not support cost query", resType)
+	}
+
+	return resources, nil
+}
@@ -23,12 +23,16 @@ import (
 	"fmt"
 	"time"
 
+	"hcm/cmd/account-server/logics/bill/export"
 	"hcm/pkg/api/account-server/bill"
 	"hcm/pkg/api/core"
 	accountset "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
@@ -40,6 +44,10 @@ import (
 
 const (
 	defaultExportFilename = "bill_item-%s-%s.csv"
+	// objectStoreExportPath 对象存储导出路径，格式: bill_export/{vendor}/{year}{month}/{filename}
+	objectStoreExportPath = "bill_export/%s/%d%02d/%s"
+	// objectStoreExportLinkTTL 对象存储导出文件下载链接有效期
+	objectStoreExportLinkTTL = 24 * time.Hour
 )
 
 // ExportBillItems 导出账单明细
@@ -71,18 +79,111 @@ func (b *billItemSvc) ExportBillItems(cts *rest.Contexts) (any, error) {
 		return nil, err
 	}
 
+	if req.ObjectStore {
+		return b.exportBillItemsToObjectStore(cts.Kit, vendor, req, rate)
+	}
+
+	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(cts.Kit, generateFilename(vendor))
+	defer func() {
+		if closeFunc != nil {
+			closeFunc()
+		}
+	}()
+	if err != nil {
+		logs.Errorf("create writer failed: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = b.writeBillItems(cts.Kit, vendor, req, rate, writer); err != nil {
+		return nil, err
+	}
+
+	return &bill.FileDownloadResp{
+		ContentTypeStr:        "application/octet-stream",
+		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
+		FilePath:              filepath,
+	}, nil
+}
+
+// exportBillItemsToObjectStore 导出数据按行流式上传至对象存储，返回带有效期的下载链接
+func (b *billItemSvc) exportBillItemsToObjectStore(kt *kit.Kit, vendor enumor.Vendor, req *bill.ExportBillItemReq,
+	rate *decimal.Decimal) (*bill.ObjectStoreExportResult, error) {
+
+	if b.storage == nil {
+		return nil, errf.New(errf.InvalidParameter, "object store is not configured")
+	}
+
+	csvName := generateFilename(vendor)
+	filename := csvName + ".zip"
+	uploadPath := fmt.Sprintf(objectStoreExportPath, vendor, req.BillYear, req.BillMonth, filename)
+	writer, err := export.NewObjectStoreWriter(kt, b.storage, uploadPath, csvName)
+	if err != nil {
+		logs.Errorf("create object store writer failed: %v, path: %s, rid: %s", err, uploadPath, kt.Rid)
+		return nil, err
+	}
+
+	if err = b.writeBillItems(kt, vendor, req, rate, writer); err != nil {
+		writer.Abort(err)
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		logs.Errorf("upload bill items to object store failed: %v, path: %s, rid: %s", err, uploadPath, kt.Rid)
+		return nil, err
+	}
+
+	_, url, err := b.storage.GetPreSignedURL(kt, objectstore.DownloadOperateAction, objectStoreExportLinkTTL,
+		uploadPath)
+	if err != nil {
+		logs.Errorf("get bill items download url failed: %v, path: %s, rid: %s", err, uploadPath, kt.Rid)
+		return nil, err
+	}
+	return &bill.ObjectStoreExportResult{Filename: filename, URL: url}, nil
+}
+
+// writeBillItems 按云厂商写入账单明细
+func (b *billItemSvc) writeBillItems(kt *kit.Kit, vendor enumor.Vendor, req *bill.ExportBillItemReq,
+	rate *decimal.Decimal, writer export.RowWriter) error {
+
 	switch vendor {
 	case enumor.HuaWei:
-		return b.exportHuaweiBillItems(cts.Kit, req, rate)
+		return b.writeHuaweiBillItems(kt, req, rate, writer)
 	case enumor.Gcp:
-		return b.exportGcpBillItems(cts.Kit, req, rate)
+		return b.writeGcpBillItems(kt, req, rate, writer)
 	case enumor.Aws:
-		return b.exportAwsBillItems(cts.Kit, req, rate)
+		return b.writeAwsBillItems(kt, req, rate, writer)
+	case enumor.Azure:
+		return b.writeAzureBillItems(kt, req, rate, writer)
+	case enumor.TCloud:
+		return b.writeTCloudBillItems(kt, req, rate, writer)
+	case enumor.Zenlayer:
+		return b.writeZenlayerBillItems(kt, req, rate, writer)
+	case enumor.Kaopu:
+		return b.writeKaopuBillItems(kt, req, rate, writer)
 	default:
-		return nil, fmt.Errorf("unsupport %s vendor", vendor)
+		return fmt.Errorf("unsupport %s vendor", vendor)
 	}
 }
 
+// exportReport 生成账单明细订阅报表
+func (b *billItemSvc) exportReport(kt *kit.Kit, sub *billcore.ReportSubscription, year, month int,
+	writer export.RowWriter) error {
+
+	req := &bill.ExportBillItemReq{
+		BillYear:    year,
+		BillMonth:   month,
+		ExportLimit: constant.ExcelExportLimit,
+		Filter:      sub.Filter,
+	}
+	rate, err := b.getExchangeRate(kt, year, month)
+	if err != nil {
+		logs.Errorf("get exchange rate for bill item report failed, err: %v, year: %d, month: %d, rid: %s",
+			err, year, month, kt.Rid)
+		return err
+	}
+
+	return b.writeBillItems(kt, sub.Vendor, req, rate, writer)
+}
+
 func (b *billItemSvc) getExchangeRate(kt *kit.Kit, year, month int) (*decimal.Decimal, error) {
 	// 获取汇率
 	listReq := &core.ListReq{
@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billitem
+
+import (
+	"hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	billapi "hcm/pkg/api/core/bill"
+	databill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/shopspring/decimal"
+)
+
+// listBillItemFunc 云厂商账单明细查询方法
+type listBillItemFunc[E billapi.BillItemExtension] func(kt *kit.Kit, req *databill.BillItemListReq) (
+	*core.ListResultT[*billapi.BillItem[E]], error)
+
+// fetchBillItems 按id顺序分页查询导出范围内的账单明细，每页数据交由 convertFunc 处理，避免全量数据堆积在内存中
+func fetchBillItems[E billapi.BillItemExtension](kt *kit.Kit, vendor enumor.Vendor, req *bill.ExportBillItemReq,
+	listFunc listBillItemFunc[E], convertFunc func([]*billapi.BillItem[E]) error) error {
+
+	commonOpt := &databill.ItemCommonOpt{
+		Vendor: vendor,
+		Year:   req.BillYear,
+		Month:  req.BillMonth,
+	}
+	countReq := &databill.BillItemListReq{
+		ItemCommonOpt: commonOpt,
+		ListReq:       &core.ListReq{Filter: req.Filter, Page: core.NewCountPage()},
+	}
+	countResult, err := listFunc(kt, countReq)
+	if err != nil {
+		logs.Errorf("count %s bill item failed: %v, rid: %s", vendor, err, kt.Rid)
+		return err
+	}
+	exportLimit := min(countResult.Count, req.ExportLimit)
+
+	lastID := ""
+	for offset := uint64(0); offset < exportLimit; offset = offset + uint64(core.DefaultMaxPageLimit) {
+		left := exportLimit - offset
+		expr := req.Filter
+		if len(lastID) > 0 {
+			expr, err = tools.And(expr, tools.RuleIDGreaterThan(lastID))
+			if err != nil {
+				logs.Errorf("build filter failed: %v, rid: %s", err, kt.Rid)
+				return err
+			}
+		}
+		listReq := &databill.BillItemListReq{
+			ItemCommonOpt: commonOpt,
+			ListReq: &core.ListReq{
+				Filter: expr,
+				Page: &core.BasePage{
+					Start: 0,
+					Limit: min(uint(left), core.DefaultMaxPageLimit),
+					Sort:  "id",
+					Order: core.Ascending,
+				},
+			},
+		}
+		result, err := listFunc(kt, listReq)
+		if err != nil {
+			logs.Errorf("list %s bill item failed: %v, rid: %s", vendor, err, kt.Rid)
+			return err
+		}
+		if len(result.Details) == 0 {
+			break
+		}
+		if err = convertFunc(result.Details); err != nil {
+			logs.Errorf("convert %s bill item failed: %v, rid: %s", vendor, err, kt.Rid)
+			return err
+		}
+		lastID = result.Details[len(result.Details)-1].ID
+	}
+	return nil
+}
+
+// toRMBCost 换算人民币成本，账单币种为人民币时无需换算，汇率为1
+func toRMBCost(currency enumor.CurrencyCode, cost decimal.Decimal, rate *decimal.Decimal) (string, string) {
+	if currency == enumor.CurrencyRMB {
+		return cost.String(), decimal.NewFromInt(1).String()
+	}
+	return cost.Mul(*rate).String(), rate.String()
+}
+
+func decimalPtrToString(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
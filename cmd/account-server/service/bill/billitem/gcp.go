@@ -38,33 +38,23 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-func (b *billItemSvc) exportGcpBillItems(kt *kit.Kit, req *bill.ExportBillItemReq,
-	rate *decimal.Decimal) (any, error) {
+func (b *billItemSvc) writeGcpBillItems(kt *kit.Kit, req *bill.ExportBillItemReq, rate *decimal.Decimal,
+	writer export.RowWriter) error {
 
 	rootAccountMap, mainAccountMap, bizNameMap, err := b.fetchAccountBizInfo(kt, enumor.Gcp)
 	if err != nil {
 		logs.Errorf("[exportGcpBillItems] prepare related data failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 	regionMap, err := b.listGcpRegions(kt)
 	if err != nil {
 		logs.Errorf("list gcp regions failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
-	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(kt, generateFilename(enumor.Gcp))
-	defer func() {
-		if closeFunc != nil {
-			closeFunc()
-		}
-	}()
-	if err != nil {
-		logs.Errorf("create writer failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
-	}
 	if err = writer.Write(export.GcpBillItemHeaders); err != nil {
 		logs.Errorf("csv write header failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
 	convFunc := func(items []*billapi.GcpBillItem) error {
@@ -85,14 +75,10 @@ func (b *billItemSvc) exportGcpBillItems(kt *kit.Kit, req *bill.ExportBillItemRe
 	}
 	err = b.fetchGcpBillItems(kt, req, convFunc)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &bill.FileDownloadResp{
-		ContentTypeStr:        "application/octet-stream",
-		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
-		FilePath:              filepath,
-	}, nil
+	return nil
 }
 
 func convertGcpBillItem(kt *kit.Kit, items []*billapi.GcpBillItem, bizNameMap map[int64]string,
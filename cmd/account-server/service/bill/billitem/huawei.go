@@ -72,28 +72,18 @@ var (
 	}
 )
 
-func (b *billItemSvc) exportHuaweiBillItems(kt *kit.Kit, req *bill.ExportBillItemReq,
-	rate *decimal.Decimal) (any, error) {
+func (b *billItemSvc) writeHuaweiBillItems(kt *kit.Kit, req *bill.ExportBillItemReq, rate *decimal.Decimal,
+	writer export.RowWriter) error {
 
 	rootAccountMap, mainAccountMap, bizNameMap, err := b.fetchAccountBizInfo(kt, enumor.HuaWei)
 	if err != nil {
 		logs.Errorf("[exportHuaweiBillItems] prepare related data failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
-	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(kt, generateFilename(enumor.HuaWei))
-	defer func() {
-		if closeFunc != nil {
-			closeFunc()
-		}
-	}()
-	if err != nil {
-		logs.Errorf("create writer failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
-	}
 	if err = writer.Write(export.HuaweiBillItemHeaders); err != nil {
 		logs.Errorf("csv write header failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
 	convFunc := func(items []*billapi.HuaweiBillItem) error {
@@ -115,14 +105,10 @@ func (b *billItemSvc) exportHuaweiBillItems(kt *kit.Kit, req *bill.ExportBillIte
 	err = b.fetchHuaweiBillItems(kt, req, convFunc)
 	if err != nil {
 		logs.Errorf("fetch huawei bill items failed: %v, rid: %s", err, kt.Rid)
-		return nil, err
+		return err
 	}
 
-	return &bill.FileDownloadResp{
-		ContentTypeStr:        "application/octet-stream",
-		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
-		FilePath:              filepath,
-	}, nil
+	return nil
 }
 
 func convertHuaweiBillItems(kt *kit.Kit, items []*billapi.HuaweiBillItem, bizNameMap map[int64]string,
@@ -24,6 +24,8 @@ import (
 	"hcm/cmd/account-server/logics/audit"
 	"hcm/cmd/account-server/service/capability"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
 	"hcm/pkg/thirdparty/esb"
@@ -36,6 +38,7 @@ func InitBillItemService(c *capability.Capability) {
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
 		esbClient:  c.EsbClient,
+		storage:    c.ObjectStore,
 	}
 
 	h := rest.NewHandler()
@@ -51,6 +54,14 @@ func InitBillItemService(c *capability.Capability) {
 	h.Add("PullBillItemForThirdParty", "POST",
 		"/vendors/{vendor}/bills/items/pull", svc.PullBillItemForThirdParty)
 
+	h.Add("ListResourceCost", "POST", "/vendors/{vendor}/bills/costs/resources/list", svc.ListResourceCost)
+	h.Add("ListBizResourceCost", "POST", "/vendors/{vendor}/bills/costs/biz_resources/list",
+		svc.ListBizResourceCost)
+
+	if c.Report != nil {
+		c.Report.Register(enumor.BillReportBillItem, svc.exportReport)
+	}
+
 	h.Load(c.WebService)
 }
 
@@ -60,4 +71,5 @@ type billItemSvc struct {
 	authorizer auth.Authorizer
 	audit      audit.Interface
 	esbClient  esb.Client
+	storage    objectstore.Storage
 }
@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billitem
+
+import (
+	"fmt"
+
+	"hcm/cmd/account-server/logics/bill/export"
+	"hcm/pkg/api/account-server/bill"
+	protocore "hcm/pkg/api/core/account-set"
+	billapi "hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+	"github.com/shopspring/decimal"
+	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
+)
+
+func (b *billItemSvc) writeTCloudBillItems(kt *kit.Kit, req *bill.ExportBillItemReq, rate *decimal.Decimal,
+	writer export.RowWriter) error {
+
+	rootAccountMap, mainAccountMap, bizNameMap, err := b.fetchAccountBizInfo(kt, enumor.TCloud)
+	if err != nil {
+		logs.Errorf("[exportTCloudBillItems] prepare related data failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if err = writer.Write(export.TCloudBillItemHeaders); err != nil {
+		logs.Errorf("csv write header failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	convFunc := func(items []*billapi.TCloudBillItem) error {
+		table, err := convertTCloudBillItems(kt, items, bizNameMap, mainAccountMap, rootAccountMap, rate)
+		if err != nil {
+			logs.Errorf("[exportTCloudBillItems] convert to raw data error: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		if err = writer.WriteAll(table); err != nil {
+			logs.Errorf("csv write data failed: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		return nil
+	}
+	return fetchBillItems(kt, enumor.TCloud, req, b.client.DataService().TCloud.Bill.ListBillItem, convFunc)
+}
+
+func convertTCloudBillItems(kt *kit.Kit, items []*billapi.TCloudBillItem, bizNameMap map[int64]string,
+	mainAccountMap map[string]*protocore.BaseMainAccount, rootAccountMap map[string]*protocore.BaseRootAccount,
+	rate *decimal.Decimal) ([][]string, error) {
+
+	result := make([][]string, 0, len(items))
+	for _, item := range items {
+		mainAccount, ok := mainAccountMap[item.MainAccountID]
+		if !ok {
+			return nil, fmt.Errorf("main account(%s) not found", item.MainAccountID)
+		}
+		rootAccount, ok := rootAccountMap[item.RootAccountID]
+		if !ok {
+			return nil, fmt.Errorf("root account(%s) not found", item.RootAccountID)
+		}
+		bizName, ok := bizNameMap[item.BkBizID]
+		if !ok {
+			logs.Warnf("biz(%d) not found", item.BkBizID)
+		}
+
+		extension := &billing.BillDetail{}
+		if item.Extension != nil && item.Extension.BillDetail != nil {
+			extension = item.Extension.BillDetail
+		}
+
+		rmbCost, rmbRate := toRMBCost(item.Currency, item.Cost, rate)
+		table := &export.TCloudBillItemTable{
+			Site:            string(mainAccount.Site),
+			AccountDate:     fmt.Sprintf("%d-%02d", item.BillYear, item.BillMonth),
+			BizID:           conv.ToString(item.BkBizID),
+			BizName:         bizName,
+			RootAccountName: rootAccount.Name,
+			MainAccountName: mainAccount.Name,
+			Region:          converter.PtrToVal(extension.RegionName),
+			ZoneName:        converter.PtrToVal(extension.ZoneName),
+			BusinessCode:    converter.PtrToVal(extension.BusinessCode),
+			BusinessName:    converter.PtrToVal(extension.BusinessCodeName),
+			ProductCode:     converter.PtrToVal(extension.ProductCode),
+			ProductName:     converter.PtrToVal(extension.ProductCodeName),
+			ProjectName:     converter.PtrToVal(extension.ProjectName),
+			PayModeName:     converter.PtrToVal(extension.PayModeName),
+			ActionTypeName:  converter.PtrToVal(extension.ActionTypeName),
+			ResourceID:      converter.PtrToVal(extension.ResourceId),
+			ResourceName:    converter.PtrToVal(extension.ResourceName),
+			FeeBeginTime:    converter.PtrToVal(extension.FeeBeginTime),
+			FeeEndTime:      converter.PtrToVal(extension.FeeEndTime),
+			Usage:           item.ResAmount.String(),
+			UsageUnit:       item.ResAmountUnit,
+			Currency:        string(item.Currency),
+			Cost:            item.Cost.String(),
+			RMBCost:         rmbCost,
+			Rate:            rmbRate,
+		}
+		values, err := table.GetHeaderValues()
+		if err != nil {
+			logs.Errorf("get header fields failed, table: %v, error: %v, rid: %s", table, err, kt.Rid)
+			return nil, err
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
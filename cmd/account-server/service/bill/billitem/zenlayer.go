@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billitem
+
+import (
+	"fmt"
+
+	"hcm/cmd/account-server/logics/bill/export"
+	"hcm/pkg/api/account-server/bill"
+	protocore "hcm/pkg/api/core/account-set"
+	billapi "hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+
+	"github.com/TencentBlueKing/gopkg/conv"
+	"github.com/shopspring/decimal"
+)
+
+func (b *billItemSvc) writeZenlayerBillItems(kt *kit.Kit, req *bill.ExportBillItemReq, rate *decimal.Decimal,
+	writer export.RowWriter) error {
+
+	rootAccountMap, mainAccountMap, bizNameMap, err := b.fetchAccountBizInfo(kt, enumor.Zenlayer)
+	if err != nil {
+		logs.Errorf("[exportZenlayerBillItems] prepare related data failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if err = writer.Write(export.ZenlayerBillItemHeaders); err != nil {
+		logs.Errorf("csv write header failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	convFunc := func(items []*billapi.ZenlayerBillItem) error {
+		table, err := convertZenlayerBillItems(kt, items, bizNameMap, mainAccountMap, rootAccountMap, rate)
+		if err != nil {
+			logs.Errorf("[exportZenlayerBillItems] convert to raw data error: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		if err = writer.WriteAll(table); err != nil {
+			logs.Errorf("csv write data failed: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+		return nil
+	}
+	return fetchBillItems(kt, enumor.Zenlayer, req, b.client.DataService().Zenlayer.Bill.ListBillItem, convFunc)
+}
+
+func convertZenlayerBillItems(kt *kit.Kit, items []*billapi.ZenlayerBillItem, bizNameMap map[int64]string,
+	mainAccountMap map[string]*protocore.BaseMainAccount, rootAccountMap map[string]*protocore.BaseRootAccount,
+	rate *decimal.Decimal) ([][]string, error) {
+
+	result := make([][]string, 0, len(items))
+	for _, item := range items {
+		mainAccount, ok := mainAccountMap[item.MainAccountID]
+		if !ok {
+			return nil, fmt.Errorf("main account(%s) not found", item.MainAccountID)
+		}
+		rootAccount, ok := rootAccountMap[item.RootAccountID]
+		if !ok {
+			return nil, fmt.Errorf("root account(%s) not found", item.RootAccountID)
+		}
+		bizName, ok := bizNameMap[item.BkBizID]
+		if !ok {
+			logs.Warnf("biz(%d) not found", item.BkBizID)
+		}
+
+		extension := &billapi.ZenlayerRawBillItem{}
+		if item.Extension != nil && item.Extension.ZenlayerRawBillItem != nil {
+			extension = item.Extension.ZenlayerRawBillItem
+		}
+
+		rmbCost, rmbRate := toRMBCost(item.Currency, item.Cost, rate)
+		table := &export.ZenlayerBillItemTable{
+			Site:            string(mainAccount.Site),
+			AccountDate:     fmt.Sprintf("%d-%02d", item.BillYear, item.BillMonth),
+			BizID:           conv.ToString(item.BkBizID),
+			BizName:         bizName,
+			RootAccountName: rootAccount.Name,
+			MainAccountName: mainAccount.Name,
+			BillID:          converter.PtrToVal(extension.BillID),
+			ZenlayerOrder:   converter.PtrToVal(extension.ZenlayerOrder),
+			CID:             converter.PtrToVal(extension.CID),
+			GroupID:         converter.PtrToVal(extension.GroupID),
+			BusinessGroup:   converter.PtrToVal(extension.BusinessGroup),
+			City:            converter.PtrToVal(extension.City),
+			PayContent:      converter.PtrToVal(extension.PayContent),
+			Type:            converter.PtrToVal(extension.Type),
+			AcceptanceNum:   decimalPtrToString(extension.AcceptanceNum),
+			PayNum:          decimalPtrToString(extension.PayNum),
+			UnitPriceUSD:    decimalPtrToString(extension.UnitPriceUSD),
+			BillingPeriod:   converter.PtrToVal(extension.BillingPeriod),
+			ContractPeriod:  converter.PtrToVal(extension.ContractPeriod),
+			CPU:             converter.PtrToVal(extension.CPU),
+			Memory:          converter.PtrToVal(extension.Memory),
+			Disk:            converter.PtrToVal(extension.Disk),
+			Remarks:         converter.PtrToVal(extension.Remarks),
+			Currency:        string(item.Currency),
+			Cost:            item.Cost.String(),
+			RMBCost:         rmbCost,
+			Rate:            rmbRate,
+		}
+		values, err := table.GetHeaderValues()
+		if err != nil {
+			logs.Errorf("get header fields failed, table: %v, error: %v, rid: %s", table, err, kt.Rid)
+			return nil, err
+		}
+		result = append(result, values)
+	}
+	return result, nil
+}
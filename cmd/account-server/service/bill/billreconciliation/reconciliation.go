@@ -0,0 +1,122 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billreconciliation
+
+import (
+	"fmt"
+
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// RunBillReconciliation 对一级账号指定月份账单进行对账
+func (s *service) RunBillReconciliation(cts *rest.Contexts) (any, error) {
+	req := new(asbill.RunBillReconciliationReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &dsbill.BillSummaryRootListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("root_account_id", req.RootAccountID),
+			tools.RuleEqual("bill_year", req.BillYear),
+			tools.RuleEqual("bill_month", req.BillMonth),
+		),
+		Page: &core.BasePage{Start: 0, Limit: 1},
+	}
+	summaryResult, err := s.client.DataService().Global.Bill.ListBillSummaryRoot(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list root account bill summary failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(summaryResult.Details) == 0 {
+		return nil, errf.New(errf.RecordNotFound, fmt.Sprintf("bill summary of root account %s %d-%02d not found",
+			req.RootAccountID, req.BillYear, req.BillMonth))
+	}
+
+	return s.reconciler.Reconcile(cts.Kit, summaryResult.Details[0])
+}
+
+// ListBillReconciliation 查询账单对账记录
+func (s *service) ListBillReconciliation(cts *rest.Contexts) (any, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DataService().Global.Bill.ListBillReconciliation(cts.Kit, req)
+}
+
+// DrillDownBillReconciliation 查看对账记录对应的二级账号及每日账单汇总
+func (s *service) DrillDownBillReconciliation(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+	req := new(asbill.BillReconciliationDrillDownReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := s.client.DataService().Global.Bill.ListBillReconciliation(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("get bill reconciliation failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.New(errf.RecordNotFound, fmt.Sprintf("bill reconciliation %s not found", id))
+	}
+
+	return s.reconciler.DrillDown(cts.Kit, &result.Details[0], req.MainAccountID)
+}
@@ -0,0 +1,196 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billreport
+
+import (
+	"fmt"
+
+	"hcm/cmd/account-server/logics/bill/report"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	billcore "hcm/pkg/api/core/bill"
+	dataservice "hcm/pkg/api/data-service"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateBillReportSub 创建账单订阅报表
+func (s *service) CreateBillReportSub(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillReportSubCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if err := report.ValidateFilter(req.ReportType, req.Filter); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Create}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DataService().Global.Bill.CreateBillReportSub(cts.Kit, req)
+}
+
+// UpdateBillReportSub 更新账单订阅报表
+func (s *service) UpdateBillReportSub(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+	req := new(asbill.UpdateBillReportSubReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getBillReportSub(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = report.ValidateFilter(sub.ReportType, req.Filter); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if req.Cadence == enumor.BillReportCadenceMonthly && req.SendDay == 0 && sub.SendDay == 0 {
+		return nil, errf.New(errf.InvalidParameter, "send_day is required when cadence is monthly")
+	}
+
+	updateReq := &dsbill.BillReportSubUpdateReq{
+		ID:        id,
+		Name:      req.Name,
+		Filter:    req.Filter,
+		Format:    req.Format,
+		Cadence:   req.Cadence,
+		SendDay:   req.SendDay,
+		Receivers: req.Receivers,
+		Enabled:   req.Enabled,
+		Memo:      req.Memo,
+	}
+	if err = s.client.DataService().Global.Bill.UpdateBillReportSub(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update bill report subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// DeleteBillReportSub 删除账单订阅报表
+func (s *service) DeleteBillReportSub(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Delete}})
+	if err != nil {
+		return nil, err
+	}
+
+	req := &dataservice.BatchDeleteReq{Filter: tools.EqualExpression("id", id)}
+	if err = s.client.DataService().Global.Bill.BatchDeleteBillReportSub(cts.Kit, req); err != nil {
+		logs.Errorf("delete bill report subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ListBillReportSub 查询账单订阅报表
+func (s *service) ListBillReportSub(cts *rest.Contexts) (any, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.DataService().Global.Bill.ListBillReportSub(cts.Kit, req)
+}
+
+// DeliverBillReport 立即生成并发送指定账单月份的订阅报表，不影响订阅的定时发送
+func (s *service) DeliverBillReport(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+	req := new(asbill.DeliverBillReportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Find}})
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.getBillReportSub(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.report.Deliver(cts.Kit, sub, req.BillYear, req.BillMonth)
+	if err != nil {
+		logs.Errorf("deliver bill report failed, err: %v, id: %s, month: %d-%02d, rid: %s", err, id,
+			req.BillYear, req.BillMonth, cts.Kit.Rid)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *service) getBillReportSub(kt *kit.Kit, id string) (*billcore.ReportSubscription, error) {
+	listReq := &core.ListReq{Filter: tools.EqualExpression("id", id), Page: core.NewDefaultBasePage()}
+	result, err := s.client.DataService().Global.Bill.ListBillReportSub(kt, listReq)
+	if err != nil {
+		logs.Errorf("get bill report subscription failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.New(errf.RecordNotFound, fmt.Sprintf("bill report subscription %s not found", id))
+	}
+	return &result.Details[0], nil
+}
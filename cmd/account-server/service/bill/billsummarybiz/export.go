@@ -26,7 +26,9 @@ import (
 	"hcm/cmd/account-server/logics/bill/export"
 	"hcm/pkg/api/account-server/bill"
 	"hcm/pkg/api/core"
+	billcore "hcm/pkg/api/core/bill"
 	billproto "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/meta"
@@ -60,22 +62,6 @@ func (s *service) ExportBizSummary(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
-	result, err := s.fetchBizSummary(cts, req)
-	if err != nil {
-		logs.Errorf("fetch biz summary failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-
-	bkBizIDs := make([]int64, 0, len(result))
-	for _, detail := range result {
-		bkBizIDs = append(bkBizIDs, detail.BkBizID)
-	}
-	bizMap, err := s.listBiz(cts.Kit, bkBizIDs)
-	if err != nil {
-		logs.Errorf("list biz failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-
 	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(cts.Kit, generateFilename())
 	defer func() {
 		if closeFunc != nil {
@@ -86,18 +72,8 @@ func (s *service) ExportBizSummary(cts *rest.Contexts) (interface{}, error) {
 		logs.Errorf("create writer failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, err
 	}
-	if err := writer.Write(export.BillSummaryBizTableHeader); err != nil {
-		logs.Errorf("write header failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
 
-	table, err := toRawData(cts.Kit, result, bizMap)
-	if err != nil {
-		logs.Errorf("convert to raw data failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-	if err := writer.WriteAll(table); err != nil {
-		logs.Errorf("write data failed, err: %v, rid: %s", err, cts.Kit.Rid)
+	if err = s.writeBizSummary(cts.Kit, req, writer); err != nil {
 		return nil, err
 	}
 
@@ -108,6 +84,53 @@ func (s *service) ExportBizSummary(cts *rest.Contexts) (interface{}, error) {
 	}, nil
 }
 
+// exportReport 生成业务账单汇总订阅报表，订阅不支持过滤条件，导出全部业务
+func (s *service) exportReport(kt *kit.Kit, _ *billcore.ReportSubscription, year, month int,
+	writer export.RowWriter) error {
+
+	req := &bill.BizSummaryExportReq{
+		BillYear:    year,
+		BillMonth:   month,
+		ExportLimit: constant.ExcelExportLimit,
+	}
+	return s.writeBizSummary(kt, req, writer)
+}
+
+func (s *service) writeBizSummary(kt *kit.Kit, req *bill.BizSummaryExportReq, writer export.RowWriter) error {
+	result, err := s.fetchBizSummary(kt, req)
+	if err != nil {
+		logs.Errorf("fetch biz summary failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	bkBizIDs := make([]int64, 0, len(result))
+	for _, detail := range result {
+		bkBizIDs = append(bkBizIDs, detail.BkBizID)
+	}
+	bizMap, err := s.listBiz(kt, bkBizIDs)
+	if err != nil {
+		logs.Errorf("list biz failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if err = writer.Write(export.BillSummaryBizTableHeader); err != nil {
+		logs.Errorf("write header failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	table, err := toRawData(kt, result, bizMap)
+	if err != nil {
+		logs.Errorf("convert to raw data failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+	if err = writer.WriteAll(table); err != nil {
+		logs.Errorf("write data failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	return nil
+}
+
 func generateFilename() string {
 	return fmt.Sprintf(defaultExportFilename, time.Now().Format("2006-01-02-15_04_05"))
 }
@@ -133,11 +156,11 @@ func toRawData(kt *kit.Kit, details []*billproto.BillSummaryBizResult, bizMap ma
 	return result, nil
 }
 
-func (s *service) fetchBizSummary(cts *rest.Contexts, req *bill.BizSummaryExportReq) (
+func (s *service) fetchBizSummary(kt *kit.Kit, req *bill.BizSummaryExportReq) (
 	[]*billproto.BillSummaryBizResult, error) {
 
 	if len(req.BKBizIDs) == 0 {
-		return s.fetchAllBizSummary(cts, req)
+		return s.fetchAllBizSummary(kt, req)
 	}
 	result := make([]*billproto.BillSummaryBizResult, 0)
 	for _, bkBizIDs := range slice.Split(req.BKBizIDs, int(filter.DefaultMaxInLimit)) {
@@ -150,7 +173,7 @@ func (s *service) fetchBizSummary(cts *rest.Contexts, req *bill.BizSummaryExport
 			Filter: expression,
 			Page:   core.NewDefaultBasePage(),
 		}
-		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryBiz(cts.Kit, listReq)
+		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryBiz(kt, listReq)
 		if err != nil {
 			return nil, err
 		}
@@ -162,7 +185,7 @@ func (s *service) fetchBizSummary(cts *rest.Contexts, req *bill.BizSummaryExport
 	return result, nil
 }
 
-func (s *service) fetchAllBizSummary(cts *rest.Contexts, req *bill.BizSummaryExportReq) (
+func (s *service) fetchAllBizSummary(kt *kit.Kit, req *bill.BizSummaryExportReq) (
 	[]*billproto.BillSummaryBizResult, error) {
 
 	var expression = tools.ExpressionAnd(
@@ -173,7 +196,7 @@ func (s *service) fetchAllBizSummary(cts *rest.Contexts, req *bill.BizSummaryExp
 		Filter: expression,
 		Page:   core.NewCountPage(),
 	}
-	details, err := s.client.DataService().Global.Bill.ListBillSummaryBiz(cts.Kit, listReq)
+	details, err := s.client.DataService().Global.Bill.ListBillSummaryBiz(kt, listReq)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +213,7 @@ func (s *service) fetchAllBizSummary(cts *rest.Contexts, req *bill.BizSummaryExp
 				Limit: min(uint(left), core.DefaultMaxPageLimit),
 			},
 		}
-		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryBiz(cts.Kit, listReq)
+		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryBiz(kt, listReq)
 		if err != nil {
 			return nil, err
 		}
@@ -25,6 +25,7 @@ import (
 	"hcm/cmd/account-server/logics/audit"
 	"hcm/cmd/account-server/service/capability"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
 	"hcm/pkg/thirdparty/esb"
@@ -45,6 +46,10 @@ func InitService(c *capability.Capability) {
 	h.Add("ListBizSummary", http.MethodPost, "/bills/biz_summarys/list", svc.ListBizSummary)
 	h.Add("ExportBizSummary", http.MethodPost, "/bills/biz_summarys/export", svc.ExportBizSummary)
 
+	if c.Report != nil {
+		c.Report.Register(enumor.BillReportBizSummary, svc.exportReport)
+	}
+
 	h.Load(c.WebService)
 }
 
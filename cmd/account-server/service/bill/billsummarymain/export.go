@@ -27,7 +27,9 @@ import (
 	asbillapi "hcm/pkg/api/account-server/bill"
 	"hcm/pkg/api/core"
 	accountset "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
 	dsbillapi "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/meta"
@@ -59,12 +61,50 @@ func (s *service) ExportMainAccountSummary(cts *rest.Contexts) (interface{}, err
 		return nil, err
 	}
 
-	result, err := s.fetchMainAccountSummary(cts, req)
+	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(cts.Kit, generateFilename())
+	defer func() {
+		if closeFunc != nil {
+			closeFunc()
+		}
+	}()
 	if err != nil {
-		logs.Errorf("fetch main account summary error: %v, rid: %s", err, cts.Kit.Rid)
+		logs.Errorf("create writer failed: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if err = s.writeMainAccountSummary(cts.Kit, req, writer); err != nil {
 		return nil, err
 	}
 
+	return &asbillapi.FileDownloadResp{
+		ContentTypeStr:        "application/octet-stream",
+		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
+		FilePath:              filepath,
+	}, nil
+}
+
+// exportReport 生成二级账号账单汇总订阅报表
+func (s *service) exportReport(kt *kit.Kit, sub *billcore.ReportSubscription, year, month int,
+	writer export.RowWriter) error {
+
+	req := &asbillapi.MainAccountSummaryExportReq{
+		BillYear:    year,
+		BillMonth:   month,
+		ExportLimit: constant.ExcelExportLimit,
+		Filter:      sub.Filter,
+	}
+	return s.writeMainAccountSummary(kt, req, writer)
+}
+
+func (s *service) writeMainAccountSummary(kt *kit.Kit, req *asbillapi.MainAccountSummaryExportReq,
+	writer export.RowWriter) error {
+
+	result, err := s.fetchMainAccountSummary(kt, req)
+	if err != nil {
+		logs.Errorf("fetch main account summary error: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
 	mainAccountIDMap := make(map[string]struct{})
 	bizIDMap := make(map[int64]struct{})
 	rootAccountIDMap := make(map[string]struct{})
@@ -77,60 +117,45 @@ func (s *service) ExportMainAccountSummary(cts *rest.Contexts) (interface{}, err
 	rootAccountIDs := converter.MapKeyToSlice(rootAccountIDMap)
 	bizIDs := converter.MapKeyToSlice(bizIDMap)
 
-	mainAccountMap, err := s.listMainAccount(cts.Kit, mainAccountIDs)
+	mainAccountMap, err := s.listMainAccount(kt, mainAccountIDs)
 	if err != nil {
-		logs.Errorf("list main account error: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
+		logs.Errorf("list main account error: %v, rid: %s", err, kt.Rid)
+		return err
 	}
-	rootAccountMap, err := s.listRootAccount(cts.Kit, rootAccountIDs)
+	rootAccountMap, err := s.listRootAccount(kt, rootAccountIDs)
 	if err != nil {
-		logs.Errorf("list root account error: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
+		logs.Errorf("list root account error: %v, rid: %s", err, kt.Rid)
+		return err
 	}
-	bizMap, err := s.listBiz(cts.Kit, bizIDs)
+	bizMap, err := s.listBiz(kt, bizIDs)
 	if err != nil {
-		logs.Errorf("list biz, bizIDs: %v, error: %v, rid: %s", bizIDs, err, cts.Kit.Rid)
-		return nil, err
+		logs.Errorf("list biz, bizIDs: %v, error: %v, rid: %s", bizIDs, err, kt.Rid)
+		return err
 	}
 
-	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(cts.Kit, generateFilename())
-	defer func() {
-		if closeFunc != nil {
-			closeFunc()
-		}
-	}()
-	if err != nil {
-		logs.Errorf("create writer failed: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-
-	if err := writer.Write(export.BillSummaryMainTableHeader); err != nil {
-		logs.Errorf("write header failed: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
+	if err = writer.Write(export.BillSummaryMainTableHeader); err != nil {
+		logs.Errorf("write header failed: %v, rid: %s", err, kt.Rid)
+		return err
 	}
 
-	table, err := toRawData(cts.Kit, result, mainAccountMap, rootAccountMap, bizMap)
+	table, err := toRawData(kt, result, mainAccountMap, rootAccountMap, bizMap)
 	if err != nil {
-		logs.Errorf("convert to raw data error: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
+		logs.Errorf("convert to raw data error: %v, rid: %s", err, kt.Rid)
+		return err
 	}
-	if err := writer.WriteAll(table); err != nil {
-		logs.Errorf("write data failed: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
+	if err = writer.WriteAll(table); err != nil {
+		logs.Errorf("write data failed: %v, rid: %s", err, kt.Rid)
+		return err
 	}
 
-	return &asbillapi.FileDownloadResp{
-		ContentTypeStr:        "application/octet-stream",
-		ContentDispositionStr: fmt.Sprintf(`attachment; filename="%s"`, filename),
-		FilePath:              filepath,
-	}, nil
+	return nil
 }
 
 func generateFilename() string {
 	return fmt.Sprintf(defaultExportFilename, time.Now().Format("2006-01-02-15_04_05"))
 }
 
-func (s *service) fetchMainAccountSummary(cts *rest.Contexts, req *asbillapi.MainAccountSummaryExportReq) (
+func (s *service) fetchMainAccountSummary(kt *kit.Kit, req *asbillapi.MainAccountSummaryExportReq) (
 	[]*dsbillapi.BillSummaryMain, error) {
 
 	var expression = tools.ExpressionAnd(
@@ -141,7 +166,7 @@ func (s *service) fetchMainAccountSummary(cts *rest.Contexts, req *asbillapi.Mai
 		var err error
 		expression, err = tools.And(req.Filter, expression)
 		if err != nil {
-			logs.Errorf("build filter expression failed, error: %v, rid: %s", err, cts.Kit.Rid)
+			logs.Errorf("build filter expression failed, error: %v, rid: %s", err, kt.Rid)
 			return nil, err
 		}
 	}
@@ -150,7 +175,7 @@ func (s *service) fetchMainAccountSummary(cts *rest.Contexts, req *asbillapi.Mai
 		Filter: expression,
 		Page:   core.NewCountPage(),
 	}
-	details, err := s.client.DataService().Global.Bill.ListBillSummaryMain(cts.Kit, countReq)
+	details, err := s.client.DataService().Global.Bill.ListBillSummaryMain(kt, countReq)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +191,7 @@ func (s *service) fetchMainAccountSummary(cts *rest.Contexts, req *asbillapi.Mai
 				Limit: min(uint(left), core.DefaultMaxPageLimit),
 			},
 		}
-		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryMain(cts.Kit, listReq)
+		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryMain(kt, listReq)
 		if err != nil {
 			return nil, err
 		}
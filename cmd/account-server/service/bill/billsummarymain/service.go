@@ -23,8 +23,10 @@ import (
 	"net/http"
 
 	"hcm/cmd/account-server/logics/audit"
+	ratelogic "hcm/cmd/account-server/logics/bill/exchangerate"
 	"hcm/cmd/account-server/service/capability"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
 	"hcm/pkg/thirdparty/esb"
@@ -36,6 +38,7 @@ func InitService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		rate:       c.ExchangeRate,
 		esbClient:  c.EsbClient,
 	}
 
@@ -47,6 +50,10 @@ func InitService(c *capability.Capability) {
 	h.Add("ExportMainAccountSummary", http.MethodPost,
 		"/bills/main_account_summarys/export", svc.ExportMainAccountSummary)
 
+	if c.Report != nil {
+		c.Report.Register(enumor.BillReportMainAccountSummary, svc.exportReport)
+	}
+
 	h.Load(c.WebService)
 }
 
@@ -54,5 +61,6 @@ type service struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	rate       *ratelogic.Manager
 	esbClient  esb.Client
 }
@@ -80,10 +80,25 @@ func (s *service) SumMainAccountSummary(cts *rest.Contexts) (interface{}, error)
 		}
 		mainSummaryList = append(mainSummaryList, tmpResult.Details...)
 	}
-	return s.doCalcalcute(mainSummaryList, result.Count)
+	sumResult := s.doCalcalcute(mainSummaryList, result.Count)
+	if len(req.Currency) == 0 {
+		return sumResult, nil
+	}
+
+	converter, err := s.rate.NewConverter(cts.Kit, req.BillYear, req.BillMonth)
+	if err != nil {
+		return nil, err
+	}
+	sumResult.ConvertedCost, err = converter.ConvertCostMap(sumResult.CostMap, req.Currency)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	return sumResult, nil
 }
 
-func (s *service) doCalcalcute(mainSummaryList []*dsbillapi.BillSummaryMain, count uint64) (interface{}, error) {
+func (s *service) doCalcalcute(mainSummaryList []*dsbillapi.BillSummaryMain,
+	count uint64) *asbillapi.MainAccountSummarySumResult {
+
 	retMap := make(map[enumor.CurrencyCode]*billcore.CostWithCurrency)
 	for _, rootSummary := range mainSummaryList {
 		if _, ok := retMap[rootSummary.Currency]; !ok {
@@ -99,5 +114,5 @@ func (s *service) doCalcalcute(mainSummaryList []*dsbillapi.BillSummaryMain, cou
 	return &asbillapi.MainAccountSummarySumResult{
 		Count:   count,
 		CostMap: retMap,
-	}, nil
+	}
 }
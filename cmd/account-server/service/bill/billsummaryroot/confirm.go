@@ -46,6 +46,12 @@ func (s *service) ConfirmRootAccountSummary(cts *rest.Contexts) (interface{}, er
 		return nil, fmt.Errorf("bill of root account %s %d-%02d is in state %s, cannot do confirm",
 			rootSummary.RootAccountID, req.BillYear, req.BillMonth, rootSummary.State)
 	}
+	// 开启对账时，与云厂商账单差额超出允许范围不允许确认
+	if err = s.reconciler.CheckConfirm(cts.Kit, rootSummary); err != nil {
+		logs.Warnf("bill of root account %s in %d-%02d failed reconciliation check, err: %v, rid: %s",
+			rootSummary.RootAccountID, req.BillYear, req.BillMonth, err, cts.Kit.Rid)
+		return nil, err
+	}
 
 	updateReq := &bill.BillSummaryRootUpdateReq{
 		ID:    rootSummary.ID,
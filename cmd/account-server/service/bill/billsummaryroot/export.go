@@ -29,6 +29,7 @@ import (
 	accountset "hcm/pkg/api/core/account-set"
 	billcore "hcm/pkg/api/core/bill"
 	dsbillapi "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
@@ -60,23 +61,6 @@ func (s *service) ExportRootAccountSummary(cts *rest.Contexts) (interface{}, err
 		return nil, err
 	}
 
-	result, err := s.fetchRootAccountSummary(cts, req)
-	if err != nil {
-		logs.Errorf("fetch root account summary failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-
-	rootAccountIDMap := make(map[string]struct{})
-	for _, detail := range result {
-		rootAccountIDMap[detail.RootAccountID] = struct{}{}
-	}
-	rootAccountIDs := converter.MapKeyToSlice(rootAccountIDMap)
-	rootAccountMap, err := s.listRootAccount(cts.Kit, rootAccountIDs)
-	if err != nil {
-		logs.Errorf("list root account error: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-
 	filename, filepath, writer, closeFunc, err := export.CreateWriterByFileName(cts.Kit, generateFileName())
 	defer func() {
 		if closeFunc != nil {
@@ -88,17 +72,7 @@ func (s *service) ExportRootAccountSummary(cts *rest.Contexts) (interface{}, err
 		return nil, err
 	}
 
-	if err := writer.Write(export.BillSummaryRootTableHeader); err != nil {
-		logs.Errorf("write header failed: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-	table, err := toRawData(cts.Kit, result, rootAccountMap)
-	if err != nil {
-		logs.Errorf("convert to raw data failed, err: %v, rid: %s", err, cts.Kit.Rid)
-		return nil, err
-	}
-	if err := writer.WriteAll(table); err != nil {
-		logs.Errorf("write data failed: %v, rid: %s", err, cts.Kit.Rid)
+	if err = s.writeRootAccountSummary(cts.Kit, req, writer); err != nil {
 		return nil, err
 	}
 	return &asbillapi.FileDownloadResp{
@@ -108,11 +82,60 @@ func (s *service) ExportRootAccountSummary(cts *rest.Contexts) (interface{}, err
 	}, nil
 }
 
+// exportReport 生成一级账号账单汇总订阅报表
+func (s *service) exportReport(kt *kit.Kit, sub *billcore.ReportSubscription, year, month int,
+	writer export.RowWriter) error {
+
+	req := &asbillapi.RootAccountSummaryExportReq{
+		BillYear:    year,
+		BillMonth:   month,
+		ExportLimit: constant.ExcelExportLimit,
+		Filter:      sub.Filter,
+	}
+	return s.writeRootAccountSummary(kt, req, writer)
+}
+
+func (s *service) writeRootAccountSummary(kt *kit.Kit, req *asbillapi.RootAccountSummaryExportReq,
+	writer export.RowWriter) error {
+
+	result, err := s.fetchRootAccountSummary(kt, req)
+	if err != nil {
+		logs.Errorf("fetch root account summary failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	rootAccountIDMap := make(map[string]struct{})
+	for _, detail := range result {
+		rootAccountIDMap[detail.RootAccountID] = struct{}{}
+	}
+	rootAccountIDs := converter.MapKeyToSlice(rootAccountIDMap)
+	rootAccountMap, err := s.listRootAccount(kt, rootAccountIDs)
+	if err != nil {
+		logs.Errorf("list root account error: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+
+	if err = writer.Write(export.BillSummaryRootTableHeader); err != nil {
+		logs.Errorf("write header failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+	table, err := toRawData(kt, result, rootAccountMap)
+	if err != nil {
+		logs.Errorf("convert to raw data failed, err: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+	if err = writer.WriteAll(table); err != nil {
+		logs.Errorf("write data failed: %v, rid: %s", err, kt.Rid)
+		return err
+	}
+	return nil
+}
+
 func generateFileName() string {
 	return fmt.Sprintf(defaultExportFilename, time.Now().Format("2006-01-02-15_04_05"))
 }
 
-func (s *service) fetchRootAccountSummary(cts *rest.Contexts, req *asbillapi.RootAccountSummaryExportReq) (
+func (s *service) fetchRootAccountSummary(kt *kit.Kit, req *asbillapi.RootAccountSummaryExportReq) (
 	[]*billcore.SummaryRoot, error) {
 
 	var expression = tools.ExpressionAnd(
@@ -123,7 +146,7 @@ func (s *service) fetchRootAccountSummary(cts *rest.Contexts, req *asbillapi.Roo
 		var err error
 		expression, err = tools.And(req.Filter, expression)
 		if err != nil {
-			logs.Errorf("build filter expression failed, error: %v, rid: %s", err, cts.Kit.Rid)
+			logs.Errorf("build filter expression failed, error: %v, rid: %s", err, kt.Rid)
 			return nil, err
 		}
 	}
@@ -132,9 +155,9 @@ func (s *service) fetchRootAccountSummary(cts *rest.Contexts, req *asbillapi.Roo
 		Filter: expression,
 		Page:   core.NewCountPage(),
 	}
-	details, err := s.client.DataService().Global.Bill.ListBillSummaryRoot(cts.Kit, countReq)
+	details, err := s.client.DataService().Global.Bill.ListBillSummaryRoot(kt, countReq)
 	if err != nil {
-		logs.Errorf("list bill summary root failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		logs.Errorf("list bill summary root failed, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
 	}
 
@@ -149,9 +172,9 @@ func (s *service) fetchRootAccountSummary(cts *rest.Contexts, req *asbillapi.Roo
 				Limit: min(uint(left), core.DefaultMaxPageLimit),
 			},
 		}
-		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryRoot(cts.Kit, listReq)
+		tmpResult, err := s.client.DataService().Global.Bill.ListBillSummaryRoot(kt, listReq)
 		if err != nil {
-			logs.Errorf("list bill summary root failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			logs.Errorf("list bill summary root failed, err: %v, rid: %s", err, kt.Rid)
 			return nil, err
 		}
 		result = append(result, tmpResult.Details...)
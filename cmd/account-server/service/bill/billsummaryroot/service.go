@@ -23,8 +23,11 @@ import (
 	"net/http"
 
 	"hcm/cmd/account-server/logics/audit"
+	ratelogic "hcm/cmd/account-server/logics/bill/exchangerate"
+	"hcm/cmd/account-server/logics/bill/reconcile"
 	"hcm/cmd/account-server/service/capability"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/rest"
 )
@@ -35,6 +38,8 @@ func InitService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		rate:       c.ExchangeRate,
+		reconciler: &reconcile.Reconciler{Client: c.ApiClient},
 	}
 
 	h := rest.NewHandler()
@@ -49,6 +54,10 @@ func InitService(c *capability.Capability) {
 	h.Add("ExportRootAccountSummary", http.MethodPost, "/bills/root_account_summarys/export",
 		svc.ExportRootAccountSummary)
 
+	if c.Report != nil {
+		c.Report.Register(enumor.BillReportRootAccountSummary, svc.exportReport)
+	}
+
 	h.Load(c.WebService)
 }
 
@@ -56,4 +65,6 @@ type service struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	rate       *ratelogic.Manager
+	reconciler *reconcile.Reconciler
 }
@@ -79,10 +79,25 @@ func (s *service) SumRootAccountSummary(cts *rest.Contexts) (interface{}, error)
 		}
 		rootSummaryList = append(rootSummaryList, tmpResult.Details...)
 	}
-	return s.doCalcalcute(rootSummaryList, *result.Count)
+	sumResult := s.doCalcalcute(rootSummaryList, *result.Count)
+	if len(req.Currency) == 0 {
+		return sumResult, nil
+	}
+
+	converter, err := s.rate.NewConverter(cts.Kit, req.BillYear, req.BillMonth)
+	if err != nil {
+		return nil, err
+	}
+	sumResult.ConvertedCost, err = converter.ConvertCostMap(sumResult.CostMap, req.Currency)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	return sumResult, nil
 }
 
-func (s *service) doCalcalcute(rootSummaryList []*billcore.SummaryRoot, count uint64) (interface{}, error) {
+func (s *service) doCalcalcute(rootSummaryList []*billcore.SummaryRoot,
+	count uint64) *asbillapi.RootAccountSummarySumResult {
+
 	retMap := make(map[enumor.CurrencyCode]*billcore.CostWithCurrency)
 	for _, rootSummary := range rootSummaryList {
 		if _, ok := retMap[rootSummary.Currency]; !ok {
@@ -98,5 +113,5 @@ func (s *service) doCalcalcute(rootSummaryList []*billcore.SummaryRoot, count ui
 	return &asbillapi.RootAccountSummarySumResult{
 		Count:   count,
 		CostMap: retMap,
-	}, nil
+	}
 }
@@ -0,0 +1,207 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package exchangerate
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	ratelogic "hcm/cmd/account-server/logics/bill/exchangerate"
+	asbill "hcm/pkg/api/account-server/bill"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+)
+
+// CreateExchangeRate 手动录入汇率，已锁定的月份不允许录入
+func (s *service) CreateExchangeRate(cts *rest.Contexts) (any, error) {
+	req := new(asbill.CreateExchangeRateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Create}})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.rate.ListMonthRates(cts.Kit, req.Year, req.Month)
+	if err != nil {
+		return nil, err
+	}
+	for _, one := range rates {
+		if cvt.PtrToVal(one.Locked) {
+			return nil, errf.Newf(errf.InvalidParameter, "exchange rate of %d-%02d is locked", req.Year, req.Month)
+		}
+		if one.FromCurrency == req.FromCurrency && one.ToCurrency == req.ToCurrency {
+			return nil, errf.Newf(errf.RecordDuplicated, "exchange rate of %s->%s already exists",
+				req.FromCurrency, req.ToCurrency)
+		}
+	}
+
+	createReq := &dsbill.BatchCreateBillExchangeRateReq{
+		ExchangeRates: []dsbill.ExchangeRateCreate{{
+			Year:         req.Year,
+			Month:        req.Month,
+			FromCurrency: req.FromCurrency,
+			ToCurrency:   req.ToCurrency,
+			ExchangeRate: req.ExchangeRate,
+			Source:       enumor.ExchangeRateSourceManual,
+		}},
+	}
+	return s.client.DataService().Global.Bill.BatchCreateExchangeRate(cts.Kit, createReq)
+}
+
+// UpdateExchangeRate 修改汇率，已锁定的汇率不允许修改
+func (s *service) UpdateExchangeRate(cts *rest.Contexts) (any, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+	req := new(asbill.UpdateExchangeRateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := s.getExchangeRate(cts.Kit, id)
+	if err != nil {
+		return nil, err
+	}
+	if cvt.PtrToVal(rate.Locked) {
+		return nil, errf.Newf(errf.InvalidParameter, "exchange rate %s is locked", id)
+	}
+
+	updateReq := &dsbill.ExchangeRateUpdateReq{
+		ID:           id,
+		ExchangeRate: req.ExchangeRate,
+		Source:       enumor.ExchangeRateSourceManual,
+	}
+	if err = s.client.DataService().Global.Bill.UpdateExchangeRate(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update exchange rate failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *service) getExchangeRate(kt *kit.Kit, id string) (*bill.ExchangeRate, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := s.client.DataService().Global.Bill.ListExchangeRate(kt, listReq)
+	if err != nil {
+		logs.Errorf("get exchange rate failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return nil, err
+	}
+	if len(result.Details) == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "exchange rate %s not found", id)
+	}
+	return &result.Details[0], nil
+}
+
+// ImportExchangeRate 通过CSV文件导入指定月份的汇率，已锁定的汇率会被跳过
+func (s *service) ImportExchangeRate(cts *rest.Contexts) (any, error) {
+	req := new(asbill.ImportExchangeRateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Create}})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := base64.StdEncoding.DecodeString(string(req.CsvFileBase64))
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, fmt.Errorf("decode csv file failed, err: %v", err))
+	}
+	rates, err := ratelogic.NewCSVProvider(content).Fetch(cts.Kit, req.Year, req.Month)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return s.rate.Save(cts.Kit, enumor.ExchangeRateSourceImport, req.Year, req.Month, rates)
+}
+
+// SyncExchangeRate 立即从汇率服务同步指定月份的汇率
+func (s *service) SyncExchangeRate(cts *rest.Contexts) (any, error) {
+	req := new(asbill.ExchangeRateMonthReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.rate.Provider == nil {
+		return nil, errf.New(errf.InvalidParameter, "exchange rate provider is not configured")
+	}
+	return s.rate.Sync(cts.Kit, s.rate.Provider, req.Year, req.Month)
+}
+
+// LockExchangeRate 锁定指定月份的汇率，锁定后不允许修改
+func (s *service) LockExchangeRate(cts *rest.Contexts) (any, error) {
+	req := new(asbill.ExchangeRateMonthReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	err := s.authorizer.AuthorizeWithPerm(cts.Kit,
+		meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.AccountBill, Action: meta.Update}})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.rate.Lock(cts.Kit, req.Year, req.Month)
+}
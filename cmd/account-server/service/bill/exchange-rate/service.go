@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/account-server/logics/audit"
+	ratelogic "hcm/cmd/account-server/logics/bill/exchangerate"
 	"hcm/cmd/account-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -35,12 +36,18 @@ func InitService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		rate:       c.ExchangeRate,
 	}
 
 	h := rest.NewHandler()
 
 	// register handler
 	h.Add("ListExchangeRate", http.MethodPost, "/bills/exchange_rates/list", svc.ListExchangeRate)
+	h.Add("CreateExchangeRate", http.MethodPost, "/bills/exchange_rates/create", svc.CreateExchangeRate)
+	h.Add("UpdateExchangeRate", http.MethodPatch, "/bills/exchange_rates/{id}", svc.UpdateExchangeRate)
+	h.Add("ImportExchangeRate", http.MethodPost, "/bills/exchange_rates/import", svc.ImportExchangeRate)
+	h.Add("SyncExchangeRate", http.MethodPost, "/bills/exchange_rates/sync", svc.SyncExchangeRate)
+	h.Add("LockExchangeRate", http.MethodPost, "/bills/exchange_rates/lock", svc.LockExchangeRate)
 
 	h.Load(c.WebService)
 }
@@ -49,4 +56,5 @@ type service struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	rate       *ratelogic.Manager
 }
@@ -22,7 +22,11 @@ package capability
 
 import (
 	"hcm/cmd/account-server/logics/audit"
+	"hcm/cmd/account-server/logics/bill/exchangerate"
+	"hcm/cmd/account-server/logics/bill/report"
+	"hcm/cmd/account-server/logics/bill/retention"
 	"hcm/pkg/client"
+	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/thirdparty/esb"
 
@@ -36,4 +40,12 @@ type Capability struct {
 	Authorizer auth.Authorizer
 	Audit      audit.Interface
 	EsbClient  esb.Client
+	// ExchangeRate 汇率管理
+	ExchangeRate *exchangerate.Manager
+	// Report 账单订阅报表调度
+	Report *report.Scheduler
+	// ObjectStore 对象存储，未配置时为空
+	ObjectStore objectstore.Storage
+	// Retention 账单数据保留及归档管理
+	Retention *retention.Manager
 }
@@ -30,10 +30,21 @@ import (
 
 	logicaudit "hcm/cmd/account-server/logics/audit"
 	"hcm/cmd/account-server/logics/bill"
+	"hcm/cmd/account-server/logics/bill/budget"
+	ratelogic "hcm/cmd/account-server/logics/bill/exchangerate"
+	"hcm/cmd/account-server/logics/bill/report"
+	"hcm/cmd/account-server/logics/bill/retention"
 	mainaccount "hcm/cmd/account-server/service/account-set/main-account"
 	rootaccount "hcm/cmd/account-server/service/account-set/root-account"
 	"hcm/cmd/account-server/service/bill/billadjustment"
+	"hcm/cmd/account-server/service/bill/billallocation"
+	"hcm/cmd/account-server/service/bill/billarchive"
+	"hcm/cmd/account-server/service/bill/billbudget"
+	"hcm/cmd/account-server/service/bill/billcommitment"
+	"hcm/cmd/account-server/service/bill/billforecast"
 	"hcm/cmd/account-server/service/bill/billitem"
+	"hcm/cmd/account-server/service/bill/billreconciliation"
+	"hcm/cmd/account-server/service/bill/billreport"
 	"hcm/cmd/account-server/service/bill/billsummarybiz"
 	"hcm/cmd/account-server/service/bill/billsummarymain"
 	"hcm/cmd/account-server/service/bill/billsummaryroot"
@@ -44,6 +55,7 @@ import (
 	"hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/handler"
 	"hcm/pkg/iam/auth"
 	"hcm/pkg/logs"
@@ -52,6 +64,7 @@ import (
 	restcli "hcm/pkg/rest/client"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
 	"hcm/pkg/thirdparty/esb"
 	"hcm/pkg/tools/ssl"
 
@@ -66,6 +79,11 @@ type Service struct {
 	audit       logicaudit.Interface
 	billManager *bill.BillManager
 	esbClient   esb.Client
+	budget      *budget.Evaluator
+	rate        *ratelogic.Manager
+	report      *report.Scheduler
+	retention   *retention.Manager
+	objectStore objectstore.Storage
 }
 
 // NewService create a service instance.
@@ -114,12 +132,42 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		CurrentRootControllers: make(map[string]*bill.RootAccountController),
 	}
 
+	// 预算告警、订阅报表邮件通知，未配置cmsi时只记录告警、不发送报表
+	budgetEvaluator := &budget.Evaluator{Sd: sd, Client: apiClientSet}
+	reportScheduler := &report.Scheduler{Sd: sd, Client: apiClientSet}
+	if cmsiCfg := cc.AccountServer().Cmsi; len(cmsiCfg.Endpoints) != 0 {
+		cmsiCli, err := cmsi.NewClient(&cmsiCfg, metrics.Register())
+		if err != nil {
+			return nil, err
+		}
+		budgetEvaluator.CmsiCli = cmsiCli
+		reportScheduler.CmsiCli = cmsiCli
+	}
+
+	// 订阅报表及大批量账单导出文件存储
+	objectStore, err := objectstore.GetObjectStore(cc.AccountServer().Objectstore)
+	if err != nil {
+		return nil, err
+	}
+	reportScheduler.Storage = objectStore
+
+	// 汇率管理，配置了汇率服务地址时定时自动拉取汇率
+	rateManager := &ratelogic.Manager{Sd: sd, Client: apiClientSet}
+	if rateOpt := cc.AccountServer().ExchangeRate.Provider; len(rateOpt.Endpoint) != 0 {
+		rateManager.Provider = ratelogic.NewHTTPProvider(rateOpt)
+	}
+
 	svr := &Service{
 		clientSet:   apiClientSet,
 		authorizer:  authorizer,
 		audit:       logicaudit.NewAudit(apiClientSet.DataService()),
 		billManager: newBillManager,
 		esbClient:   esbClient,
+		budget:      budgetEvaluator,
+		rate:        rateManager,
+		report:      reportScheduler,
+		retention:   &retention.Manager{Sd: sd, Client: apiClientSet},
+		objectStore: objectStore,
 	}
 
 	return svr, nil
@@ -159,6 +207,18 @@ func (s *Service) ListenAndServeRest() error {
 	logs.Infof("start bill manager")
 	go s.billManager.Run(context.Background())
 
+	logs.Infof("start bill budget evaluator")
+	go s.budget.Run(context.Background())
+
+	logs.Infof("start bill exchange rate manager")
+	go s.rate.Run(context.Background())
+
+	logs.Infof("start bill report scheduler")
+	go s.report.Run(context.Background())
+
+	logs.Infof("start bill retention manager")
+	go s.retention.Run(context.Background())
+
 	logs.Infof("listen restful server on %s with secure(%v) now.", server.Addr, network.TLS.Enable())
 
 	go func() {
@@ -197,11 +257,15 @@ func (s *Service) apiSet() *restful.Container {
 	ws.Produces(restful.MIME_JSON)
 
 	c := &capability.Capability{
-		WebService: ws,
-		ApiClient:  s.clientSet,
-		Authorizer: s.authorizer,
-		Audit:      s.audit,
-		EsbClient:  s.esbClient,
+		WebService:   ws,
+		ApiClient:    s.clientSet,
+		Authorizer:   s.authorizer,
+		Audit:        s.audit,
+		EsbClient:    s.esbClient,
+		ExchangeRate: s.rate,
+		Report:       s.report,
+		ObjectStore:  s.objectStore,
+		Retention:    s.retention,
 	}
 
 	mainaccount.InitService(c)
@@ -211,8 +275,15 @@ func (s *Service) apiSet() *restful.Container {
 	billitem.InitBillItemService(c)
 	billsummarybiz.InitService(c)
 	billadjustment.InitBillAdjustmentService(c)
+	billbudget.InitService(c)
 	billsyncrecord.InitService(c)
 	exchangerate.InitService(c)
+	billreport.InitService(c)
+	billallocation.InitService(c)
+	billreconciliation.InitService(c)
+	billforecast.InitService(c)
+	billcommitment.InitService(c)
+	billarchive.InitService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
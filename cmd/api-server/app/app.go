@@ -21,6 +21,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -35,6 +36,7 @@ import (
 	"hcm/pkg/runtime/gwparser"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the api server
@@ -61,6 +63,8 @@ func Run(opt *options.Option) error {
 type apiService struct {
 	svc *service.Service
 	dis serviced.Discover
+	// shutdownTracing 进程退出前导出剩余的链路数据
+	shutdownTracing func(ctx context.Context) error
 }
 
 // prepare do prepare jobs before run api server.
@@ -83,8 +87,17 @@ func (as *apiService) prepare(opt *options.Option) error {
 	network := cc.ApiServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	shutdownTracing, err := tracing.Init(string(cc.APIServerName), cc.ApiServer().Tracing.Config())
+	if err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+	as.shutdownTracing = shutdownTracing
+
 	// new api server discovery client.
-	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName, cc.AccountServerName}}
+	discOpt := serviced.DiscoveryOption{
+		Services: []cc.Name{cc.CloudServerName, cc.AccountServerName, cc.DataServiceName},
+	}
 	dis, err := serviced.NewDiscovery(cc.ApiServer().Service, discOpt)
 	if err != nil {
 		return fmt.Errorf("new service discovery faield, err: %v", err)
@@ -94,7 +107,7 @@ func (as *apiService) prepare(opt *options.Option) error {
 	logs.Infof("create discovery success.")
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(cmd.WithLog()); err != nil {
+	if err := ctl.LoadCtl(append(cmd.WithCircuitBreaker(), cmd.WithLog())...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
@@ -102,5 +115,7 @@ func (as *apiService) prepare(opt *options.Option) error {
 }
 
 func (as *apiService) finalizer() {
+	defer tracing.Shutdown(as.shutdownTracing)
+
 	return
 }
@@ -26,11 +26,15 @@ import (
 	"net/http"
 	"regexp"
 
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/gwparser"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
 // restFilter returns api server's restful request filter, we filter all requests base on URL.
@@ -38,13 +42,25 @@ func (p *proxy) restFilter() restful.FilterFunction {
 	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 		r, w := req.Request, resp.ResponseWriter
 
-		// parse request
-		kt, err := gwparser.Parse(r.Context(), r.Header)
+		// parse request, 携带API令牌的请求由令牌确定用户及权限范围，不再经过网关解析
+		var kt *kit.Kit
+		var err error
+		if len(r.Header.Get(constant.APITokenKey)) != 0 {
+			kt, err = p.tokenValidator.Parse(r.Context(), r.Header)
+		} else {
+			kt, err = gwparser.Parse(r.Context(), r.Header)
+		}
 		if err != nil {
 			w.WriteHeader(http.StatusForbidden)
 			fmt.Fprintf(w, errf.Error(err).Error())
 			return
 		}
+		// 提取网关传入的链路上下文，proxy 转发时注入到请求头，api-server 到后端服务的调用在同一条链路中
+		ctx, span := tracing.StartServer(r.Context(), "proxy "+r.Method, r.Header, kt.Rid)
+		defer span.End()
+		span.SetAttributes(semconv.URLPath(r.URL.Path))
+		req.Request = r.WithContext(ctx)
+		r = req.Request
 		req.Request.Header = kt.Header()
 
 		body, err := peekRequest(r)
@@ -27,23 +27,29 @@ import (
 	"time"
 
 	"hcm/pkg/cc"
+	apiclient "hcm/pkg/client"
 	"hcm/pkg/client/discovery"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/logs"
+	"hcm/pkg/rest/ratelimit"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
 )
 
 // proxy all server's rest proxy.
 type proxy struct {
-	discovery map[cc.Name]*discovery.APIDiscovery
-	cli       *http.Client
+	discovery      map[cc.Name]*discovery.APIDiscovery
+	cli            *http.Client
+	tokenValidator *tokenValidator
+	// limiter 接口限流器，未开启限流时为nil
+	limiter *ratelimit.Limiter
 }
 
 // newProxy create new rest proxy.
-func newProxy(dis serviced.Discover, cli *http.Client) (*proxy, error) {
+func newProxy(dis serviced.Discover, cli *http.Client, apiClient *apiclient.ClientSet) (*proxy, error) {
 	apiDiscovery := make(map[cc.Name]*discovery.APIDiscovery)
 
 	discoverServices := []cc.Name{cc.CloudServerName, cc.AccountServerName}
@@ -51,9 +57,16 @@ func newProxy(dis serviced.Discover, cli *http.Client) (*proxy, error) {
 		apiDiscovery[service] = discovery.NewAPIDiscovery(service, dis)
 	}
 
+	limiter, err := ratelimit.New(cc.ApiServer().RateLimit, cc.ApiServer().Service)
+	if err != nil {
+		return nil, fmt.Errorf("new rate limiter failed, err: %v", err)
+	}
+
 	p := &proxy{
-		discovery: apiDiscovery,
-		cli:       cli,
+		discovery:      apiDiscovery,
+		cli:            cli,
+		tokenValidator: newTokenValidator(apiClient),
+		limiter:        limiter,
 	}
 
 	return p, nil
@@ -64,6 +77,10 @@ func (p *proxy) apiSet() *restful.Container {
 
 	ws.Path("/api/v1")
 	ws.Filter(p.restFilter())
+	// 限流在请求解析之后，按解析出的应用、用户及业务限流
+	if p.limiter != nil {
+		ws.Filter(p.limiter.RestFilter())
+	}
 	ws.Produces(restful.MIME_JSON)
 
 	ws.Route(ws.GET("{.*}").To(p.Do))
@@ -82,7 +99,8 @@ func (p *proxy) Do(req *restful.Request, resp *restful.Response) {
 	rid := r.Header.Get(constant.RidKey)
 	start := time.Now()
 
-	if err := p.prepareRequest(req); err != nil {
+	ds, host, err := p.prepareRequest(req)
+	if err != nil {
 		_, _ = fmt.Fprintf(w, errf.NewFromErr(http.StatusNotFound, err).Error())
 		logs.Errorf("prepare request to proxy failed, err: %v, rid: %s", err, rid)
 		return
@@ -101,14 +119,23 @@ func (p *proxy) Do(req *restful.Request, resp *restful.Response) {
 			proxyReq.Header.Set(k, v[0])
 		}
 	}
+	// 请求已通过api-server限流时告知后端服务不再重复计数，否则清除外部传入的标记，由后端服务限流
+	proxyReq.Header.Del(constant.RateLimitedByKey)
+	if p.limiter != nil {
+		proxyReq.Header.Set(constant.RateLimitedByKey, string(cc.APIServerName))
+	}
+	tracing.Inject(proxyReq.Context(), proxyReq.Header)
 
+	proxyStart := time.Now()
 	response, err := p.cli.Do(proxyReq)
 	if err != nil {
+		ds.Report(host, time.Since(proxyStart), 0, err)
 		_, _ = fmt.Fprintf(w, err.Error())
 		logs.Errorf("do request[%s url: %s] failed, err: %v, rid: %s", r.Method, url, err, rid)
 		return
 	}
 	defer response.Body.Close()
+	ds.Report(host, time.Since(proxyStart), response.StatusCode, nil)
 
 	for k, v := range response.Header {
 		if len(v) > 0 {
@@ -133,14 +160,15 @@ func (p *proxy) Do(req *restful.Request, resp *restful.Response) {
 	return
 }
 
-// prepareRequest get request service by url, discover service and proxy request to target server
-func (p *proxy) prepareRequest(req *restful.Request) error {
+// prepareRequest get request service by url, discover service and proxy request to target server, returns the
+// discovery and the target server host.
+func (p *proxy) prepareRequest(req *restful.Request) (*discovery.APIDiscovery, string, error) {
 	var service cc.Name
 
 	// path format: /api/{api_version}/{service}/other
 	paths := strings.Split(req.Request.URL.Path, "/")
 	if len(paths) <= 3 {
-		return fmt.Errorf("received invalid url path: %s", req.Request.URL.Path)
+		return nil, "", fmt.Errorf("received invalid url path: %s", req.Request.URL.Path)
 	}
 
 	servicePath := paths[3]
@@ -150,17 +178,19 @@ func (p *proxy) prepareRequest(req *restful.Request) error {
 	case "account":
 		service = cc.AccountServerName
 	default:
-		return fmt.Errorf("received unknown url path: %s", req.Request.URL.Path)
+		return nil, "", fmt.Errorf("received unknown url path: %s", req.Request.URL.Path)
 	}
 
 	ds, exists := p.discovery[service]
 	if !exists {
-		return fmt.Errorf("received request service %s is not supported, path: %s", service, req.Request.URL.Path)
+		return nil, "", fmt.Errorf("received request service %s is not supported, path: %s", service,
+			req.Request.URL.Path)
 	}
 
 	servers, err := ds.GetServers()
 	if err != nil {
-		return fmt.Errorf("received request to service %s has no servers, path: %s", service, req.Request.URL.Path)
+		return nil, "", fmt.Errorf("received request to service %s has no servers, path: %s", service,
+			req.Request.URL.Path)
 	}
 
 	if strings.HasPrefix(servers[0], "https://") {
@@ -171,5 +201,5 @@ func (p *proxy) prepareRequest(req *restful.Request) error {
 		req.Request.URL.Scheme = "http"
 	}
 
-	return nil
+	return ds, servers[0], nil
 }
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"hcm/pkg/cc"
+	apiclient "hcm/pkg/client"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/handler"
 	"hcm/pkg/logs"
@@ -64,7 +65,7 @@ func NewService(dis serviced.Discover) (*Service, error) {
 		return nil, err
 	}
 
-	p, err := newProxy(dis, cli)
+	p, err := newProxy(dis, cli, apiclient.NewClientSet(cli, dis))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreapitoken "hcm/pkg/api/core/api-token"
+	dsapitoken "hcm/pkg/api/data-service/api-token"
+	apiclient "hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/uuid"
+)
+
+const (
+	// tokenCacheTTL 令牌校验结果的缓存时间，令牌吊销最迟在该时间后生效
+	tokenCacheTTL = time.Minute
+	// tokenCacheCleanThreshold 缓存数量超过该值时清理过期缓存
+	tokenCacheCleanThreshold = 1000
+	// tokenAppCode 通过API令牌访问时使用的AppCode
+	tokenAppCode = "hcm-api-token"
+)
+
+type tokenCacheEntry struct {
+	token    *coreapitoken.Token
+	cachedAt time.Time
+}
+
+// validateTokenFunc validate api token by token hash.
+type validateTokenFunc func(kt *kit.Kit, req *dsapitoken.TokenValidateReq) (*coreapitoken.Token, error)
+
+// tokenValidator validate api token by data-service and cache valid tokens for a short while.
+type tokenValidator struct {
+	validateToken validateTokenFunc
+	now           func() time.Time
+	lock          sync.RWMutex
+	cache         map[string]tokenCacheEntry
+}
+
+func newTokenValidator(client *apiclient.ClientSet) *tokenValidator {
+	return &tokenValidator{
+		validateToken: client.DataService().Global.APIToken.ValidateToken,
+		now:           time.Now,
+		cache:         make(map[string]tokenCacheEntry),
+	}
+}
+
+// Parse api token in request header to context kit, the kit carries token scope to backend services.
+func (v *tokenValidator) Parse(ctx context.Context, header http.Header) (*kit.Kit, error) {
+	plain := header.Get(constant.APITokenKey)
+
+	rid := header.Get(constant.RidKey)
+	if len(rid) < 16 || len(rid) > 50 {
+		rid = uuid.UUID()
+	}
+
+	token, err := v.validate(ctx, rid, coreapitoken.HashToken(plain))
+	if err != nil {
+		return nil, err
+	}
+
+	// 令牌主体即鉴权用户，再次校验服务账号主体在保留命名空间内，防止服务账号令牌冒充真实用户
+	if err = coreapitoken.ValidatePrincipal(token.PrincipalType, token.Principal); err != nil {
+		logs.Errorf("api token principal is invalid, err: %v, id: %s, rid: %s", err, token.ID, rid)
+		return nil, errf.New(errf.PermissionDenied, "invalid api token")
+	}
+
+	kt := &kit.Kit{
+		Ctx:     ctx,
+		User:    token.Principal,
+		Rid:     rid,
+		AppCode: tokenAppCode,
+		TokenScope: &kit.TokenScope{
+			TokenID:  token.ID,
+			BkBizIDs: token.BkBizIDs,
+			Actions:  token.Actions,
+		},
+	}
+
+	if err = kt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return kt, nil
+}
+
+func (v *tokenValidator) validate(ctx context.Context, rid, hash string) (*coreapitoken.Token, error) {
+	now := v.now()
+
+	v.lock.RLock()
+	entry, exists := v.cache[hash]
+	v.lock.RUnlock()
+	if exists && now.Sub(entry.cachedAt) < tokenCacheTTL {
+		return entry.token, nil
+	}
+
+	kt := core.NewBackendKit()
+	kt.Ctx = ctx
+	kt.Rid = rid
+	token, err := v.validateToken(kt, &dsapitoken.TokenValidateReq{
+		TokenHash: hash,
+	})
+	if err != nil {
+		logs.Errorf("validate api token failed, err: %v, rid: %s", err, rid)
+		v.lock.Lock()
+		delete(v.cache, hash)
+		v.lock.Unlock()
+		return nil, err
+	}
+
+	v.lock.Lock()
+	if len(v.cache) >= tokenCacheCleanThreshold {
+		for key, one := range v.cache {
+			if now.Sub(one.cachedAt) >= tokenCacheTTL {
+				delete(v.cache, key)
+			}
+		}
+	}
+	v.cache[hash] = tokenCacheEntry{token: token, cachedAt: now}
+	v.lock.Unlock()
+
+	return token, nil
+}
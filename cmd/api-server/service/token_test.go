@@ -0,0 +1,152 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	coreapitoken "hcm/pkg/api/core/api-token"
+	dsapitoken "hcm/pkg/api/data-service/api-token"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+)
+
+// testTokenStore simulates data-service token validation, returns the token or the error of the token hash.
+type testTokenStore struct {
+	tokens map[string]*coreapitoken.Token
+	errs   map[string]error
+	calls  int
+}
+
+func (s *testTokenStore) validate(_ *kit.Kit, req *dsapitoken.TokenValidateReq) (*coreapitoken.Token, error) {
+	s.calls++
+	if err, exists := s.errs[req.TokenHash]; exists {
+		return nil, err
+	}
+	return s.tokens[req.TokenHash], nil
+}
+
+func newTestTokenValidator(store *testTokenStore, now *time.Time) *tokenValidator {
+	return &tokenValidator{
+		validateToken: store.validate,
+		now:           func() time.Time { return *now },
+		cache:         make(map[string]tokenCacheEntry),
+	}
+}
+
+func tokenHeader(plain string) http.Header {
+	header := http.Header{}
+	header.Set(constant.APITokenKey, plain)
+	header.Set(constant.RidKey, "test-api-token-rid")
+	return header
+}
+
+func TestTokenValidatorParse(t *testing.T) {
+	store := &testTokenStore{
+		tokens: map[string]*coreapitoken.Token{
+			coreapitoken.HashToken("hcm_sa"): {
+				ID:            "00000001",
+				PrincipalType: enumor.APITokenPrincipalServiceAccount,
+				Principal:     "sa:deployer",
+				BkBizIDs:      []int64{1},
+				Actions:       []string{"find"},
+			},
+			coreapitoken.HashToken("hcm_fake_admin"): {
+				ID:            "00000002",
+				PrincipalType: enumor.APITokenPrincipalServiceAccount,
+				Principal:     "admin",
+			},
+		},
+		errs: map[string]error{
+			coreapitoken.HashToken("hcm_revoked"): errf.New(errf.PermissionDenied, "api token has been revoked"),
+			coreapitoken.HashToken("hcm_expired"): errf.New(errf.PermissionDenied, "api token has expired"),
+		},
+	}
+	now := time.Now()
+	validator := newTestTokenValidator(store, &now)
+
+	kt, err := validator.Parse(context.Background(), tokenHeader("hcm_sa"))
+	if err != nil {
+		t.Fatalf("parse valid token failed, err: %v", err)
+	}
+	if kt.User != "sa:deployer" || kt.AppCode != tokenAppCode || kt.TokenScope == nil ||
+		kt.TokenScope.TokenID != "00000001" || len(kt.TokenScope.BkBizIDs) != 1 {
+		t.Errorf("unexpected kit of valid token: %+v, scope: %+v", kt, kt.TokenScope)
+	}
+
+	for _, plain := range []string{"hcm_revoked", "hcm_expired", "hcm_fake_admin"} {
+		if _, err = validator.Parse(context.Background(), tokenHeader(plain)); err == nil {
+			t.Errorf("token %s should be rejected", plain)
+			continue
+		}
+		if ef := errf.Error(err); ef.Code != errf.PermissionDenied {
+			t.Errorf("token %s should be rejected with permission denied, got: %v", plain, err)
+		}
+	}
+
+	// 失败的校验结果不缓存，吊销和过期的令牌每次都要回源校验
+	calls := store.calls
+	if _, err = validator.Parse(context.Background(), tokenHeader("hcm_revoked")); err == nil {
+		t.Errorf("revoked token should be rejected again")
+	}
+	if store.calls != calls+1 {
+		t.Errorf("revoked token should not be cached")
+	}
+}
+
+func TestTokenValidatorCacheRevoke(t *testing.T) {
+	hash := coreapitoken.HashToken("hcm_user")
+	store := &testTokenStore{
+		tokens: map[string]*coreapitoken.Token{
+			hash: {ID: "00000003", PrincipalType: enumor.APITokenPrincipalUser, Principal: "tom"},
+		},
+		errs: map[string]error{},
+	}
+	now := time.Now()
+	validator := newTestTokenValidator(store, &now)
+
+	if _, err := validator.Parse(context.Background(), tokenHeader("hcm_user")); err != nil {
+		t.Fatalf("parse valid token failed, err: %v", err)
+	}
+
+	// 令牌在缓存有效期内被吊销，仍使用缓存结果
+	store.errs[hash] = errf.New(errf.PermissionDenied, "api token has been revoked")
+	now = now.Add(tokenCacheTTL - time.Second)
+	if _, err := validator.Parse(context.Background(), tokenHeader("hcm_user")); err != nil {
+		t.Errorf("token should be served from cache before ttl, err: %v", err)
+	}
+	if store.calls != 1 {
+		t.Errorf("token should be validated once before ttl, got %d calls", store.calls)
+	}
+
+	// 缓存过期后重新校验，吊销生效并清除缓存
+	now = now.Add(time.Second)
+	if _, err := validator.Parse(context.Background(), tokenHeader("hcm_user")); err == nil {
+		t.Errorf("revoked token should be rejected after cache ttl")
+	}
+	if _, exists := validator.cache[hash]; exists {
+		t.Errorf("revoked token should be removed from cache")
+	}
+}
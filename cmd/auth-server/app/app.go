@@ -44,7 +44,8 @@ func Run(opt *options.Option) error {
 		return err
 	}
 
-	svc, err := service.NewService(as.sd, cc.AuthServer().IAM, cc.AuthServer().Esb, as.disableAuth, as.disableWriteOpt)
+	svc, err := service.NewService(as.sd, cc.AuthServer().IAM, cc.AuthServer().Esb, cc.AuthServer().Authorizer,
+		as.disableAuth, as.disableWriteOpt)
 	if err != nil {
 		return fmt.Errorf("initialize service failed, err: %v", err)
 	}
@@ -110,6 +110,14 @@ func AdaptAuthOptions(a *meta.ResourceAttribute) (client.ActionID, []client.Reso
 		return genApplicationResources(a)
 	case meta.AccountBillThirdParty:
 		return genAccountBillThirdPartyResource(a)
+	case meta.Webhook:
+		return genWebhookResource(a)
+	case meta.AuditExport:
+		return genAuditExportResource(a)
+	case meta.ServiceAccount:
+		return genServiceAccountResource(a)
+	case meta.RBAC:
+		return genRBACResource(a)
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm auth type: %s", a.Basic.Type)
 	}
@@ -36,6 +36,7 @@ import (
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/iam/client"
 	"hcm/pkg/iam/meta"
+	"hcm/pkg/iam/rbac"
 	"hcm/pkg/iam/sdk/auth"
 	"hcm/pkg/iam/sys"
 	"hcm/pkg/kit"
@@ -49,6 +50,8 @@ import (
 type Auth struct {
 	// auth related operate.
 	auth auth.Authorizer
+	// local is the local rbac authorizer, when it is set, authorize is done by it instead of iam.
+	local *rbac.Authorizer
 	// ds data service's auth related api.
 	ds *dataservice.Client
 	// disableAuth defines whether iam authorization is disabled
@@ -86,6 +89,33 @@ func NewAuth(auth auth.Authorizer, ds *dataservice.Client, disableAuth bool, esb
 	return i, nil
 }
 
+// NewLocalAuth new auth which authorize by local rbac authorizer.
+func NewLocalAuth(local *rbac.Authorizer, ds *dataservice.Client, disableAuth bool, esbCli esb.Client,
+	disableWriteOpt *options.DisableWriteOption) (*Auth, error) {
+
+	if local == nil {
+		return nil, errf.New(errf.InvalidParameter, "local rbac authorizer is nil")
+	}
+
+	if ds == nil {
+		return nil, errf.New(errf.InvalidParameter, "data client is nil")
+	}
+
+	if disableWriteOpt == nil {
+		return nil, errf.New(errf.InvalidParameter, "disable write operation is nil")
+	}
+
+	i := &Auth{
+		local:           local,
+		ds:              ds,
+		disableAuth:     disableAuth,
+		disableWriteOpt: disableWriteOpt,
+		esbCli:          esbCli,
+	}
+
+	return i, nil
+}
+
 // InitAuthService initialize the iam authorize service
 func (a *Auth) InitAuthService(c *capability.Capability) {
 	h := rest.NewHandler()
@@ -140,6 +170,10 @@ func (a *Auth) authorizeBatch(kt *kit.Kit, req *authserver.AuthorizeBatchReq, ex
 		return decisions, nil
 	}
 
+	if a.local != nil {
+		return a.authorizeBatchByLocal(kt, req, exact)
+	}
+
 	// parse hcm resource to iam resource
 	opts, decisions, err := parseAttributesToBatchOptions(kt, req.User, req.Resources...)
 	if err != nil {
@@ -186,6 +220,26 @@ func (a *Auth) authorizeBatch(kt *kit.Kit, req *authserver.AuthorizeBatchReq, ex
 	return decisions, nil
 }
 
+// authorizeBatchByLocal authorize resource batch by local rbac authorizer.
+func (a *Auth) authorizeBatchByLocal(kt *kit.Kit, req *authserver.AuthorizeBatchReq, exact bool) (
+	[]meta.Decision, error) {
+
+	var decisions []meta.Decision
+	var err error
+	if exact {
+		decisions, err = a.local.Authorize(kt, req.User.UserName, req.Resources...)
+	} else {
+		decisions, err = a.local.AuthorizeAny(kt, req.User.UserName, req.Resources...)
+	}
+	if err != nil {
+		logs.Errorf("authorize batch by local rbac failed, err: %v, exact: %v, req: %#v, rid: %s", err, exact, req,
+			kt.Rid)
+		return nil, err
+	}
+
+	return decisions, nil
+}
+
 func (a *Auth) isWriteOperationDisabled(kt *kit.Kit, resources []meta.ResourceAttribute) error {
 	if !a.disableWriteOpt.IsDisabled {
 		return nil
@@ -516,6 +570,17 @@ func (a *Auth) ListAuthorizedInstances(cts *rest.Contexts) (interface{}, error)
 		return nil, err
 	}
 
+	if a.local != nil {
+		input := &meta.ListAuthResInput{Type: req.Type, Action: req.Action}
+		authorized, err := a.local.ListAuthorizedInstances(cts.Kit, req.User.UserName, input)
+		if err != nil {
+			logs.Errorf("list authorized instances by local rbac failed, err: %v, req: %+v, rid: %s", err, req,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		return client.AuthorizeList{Ids: authorized.IDs, IsAny: authorized.IsAny}, nil
+	}
+
 	res := &meta.ResourceAttribute{
 		Basic: &meta.Basic{
 			Type:   req.Type,
@@ -561,6 +626,11 @@ func (a *Auth) RegisterResourceCreatorAction(cts *rest.Contexts) (interface{}, e
 		return nil, err
 	}
 
+	// 本地RBAC鉴权不存在创建者权限，资源权限统一由角色授权
+	if a.local != nil {
+		return make([]client.CreatorActionPolicy, 0), nil
+	}
+
 	opts := &client.InstanceWithCreator{
 		System:  sys.SystemIDHCM,
 		Type:    req.Instance.Type,
@@ -593,6 +663,11 @@ func (a *Auth) GetApplyPermUrl(cts *rest.Contexts) (interface{}, error) {
 		return nil, err
 	}
 
+	// 本地RBAC鉴权没有权限申请页面，需要联系管理员授权
+	if a.local != nil {
+		return "", nil
+	}
+
 	url, err := a.auth.GetApplyPermUrl(cts.Kit.Ctx, req)
 	if err != nil {
 		logs.Errorf("get iam apply permission url failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
@@ -278,7 +278,8 @@ func genRecycleBinResource(a *meta.ResourceAttribute) (client.ActionID, []client
 			return sys.BizAccess, []client.Resource{bizRes}, nil
 		}
 		return sys.RecycleBinAccess, []client.Resource{res}, nil
-	case meta.Recycle, meta.Recover:
+	case meta.Recycle, meta.Recover, meta.Create, meta.Update, meta.Delete:
+		// 回收站保留策略的增删改与回收站操作使用相同的权限
 		if a.BizID > 0 {
 			return sys.BizRecycleBinOperate, []client.Resource{bizRes}, nil
 		}
@@ -606,9 +607,11 @@ func genLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []clie
 	case meta.Update:
 		// update resource is related to hcm account resource
 		return sys.CLBResOperate, []client.Resource{res}, nil
-	case meta.Delete:
+	case meta.Delete, meta.Recycle:
 		// delete resource is related to hcm account resource
 		return sys.CLBResDelete, []client.Resource{res}, nil
+	case meta.Destroy, meta.Recover:
+		return sys.RecycleBinOperate, []client.Resource{res}, nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
@@ -632,8 +635,10 @@ func genBizLoadBalancerResource(a *meta.ResourceAttribute) (client.ActionID, []c
 		return sys.BizCLBResCreate, []client.Resource{res}, nil
 	case meta.Update:
 		return sys.BizCLBResOperate, []client.Resource{res}, nil
-	case meta.Delete:
+	case meta.Delete, meta.Recycle:
 		return sys.BizCLBResDelete, []client.Resource{res}, nil
+	case meta.Destroy, meta.Recover:
+		return sys.BizRecycleBinOperate, []client.Resource{res}, nil
 	default:
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
@@ -736,3 +741,43 @@ func genAccountBillThirdPartyResource(a *meta.ResourceAttribute) (client.ActionI
 		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
 	}
 }
+
+// genWebhookResource webhook订阅属于平台全局配置
+func genWebhookResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genAuditExportResource 审计导出涉及全部业务的审计，使用平台全局配置权限
+func genAuditExportResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genServiceAccountResource 服务账号及其API令牌可代表服务账号访问全部资源，使用平台全局配置权限
+func genServiceAccountResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
+
+// genRBACResource 本地鉴权的角色及授权管理，使用平台全局配置权限
+func genRBACResource(a *meta.ResourceAttribute) (client.ActionID, []client.Resource, error) {
+	switch a.Basic.Action {
+	case meta.Find, meta.Create, meta.Update, meta.Delete:
+		return sys.GlobalConfiguration, make([]client.Resource, 0), nil
+	default:
+		return "", nil, errf.Newf(errf.InvalidParameter, "unsupported hcm action: %s", a.Basic.Action)
+	}
+}
@@ -108,8 +108,13 @@ func (s *Service) apiSet() *restful.Container {
 		WebService: ws,
 	}
 
-	s.initial.InitInitialService(c)
-	s.iam.InitIAMService(c)
+	// 使用本地RBAC鉴权时不注册IAM初始化及IAM回调相关接口
+	if s.initial != nil {
+		s.initial.InitInitialService(c)
+	}
+	if s.iam != nil {
+		s.iam.InitIAMService(c)
+	}
 	s.auth.InitAuthService(c)
 
 	return restful.NewContainer().Add(c.WebService)
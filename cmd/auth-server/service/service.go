@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"hcm/cmd/auth-server/options"
 	"hcm/cmd/auth-server/service/auth"
@@ -33,6 +34,7 @@ import (
 	apicli "hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/iam/client"
+	"hcm/pkg/iam/rbac"
 	pkgauth "hcm/pkg/iam/sdk/auth"
 	"hcm/pkg/iam/sys"
 	"hcm/pkg/logs"
@@ -63,10 +65,10 @@ type Service struct {
 }
 
 // NewService create a service instance.
-func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, disableAuth bool,
-	disableWriteOpt *options.DisableWriteOption) (*Service, error) {
+func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, authorizer cc.Authorizer,
+	disableAuth bool, disableWriteOpt *options.DisableWriteOption) (*Service, error) {
 
-	cli, err := newClientSet(sd, iamSettings, esbSettings, disableAuth)
+	cli, err := newClientSet(sd, iamSettings, esbSettings, authorizer, disableAuth)
 	if err != nil {
 		return nil, fmt.Errorf("new client set failed, err: %v", err)
 	}
@@ -90,7 +92,8 @@ func NewService(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, di
 	return s, nil
 }
 
-func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, disableAuth bool) (*ClientSet, error) {
+func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb, authorizer cc.Authorizer,
+	disableAuth bool) (*ClientSet, error) {
 
 	logs.Infof("start initialize the client set.")
 
@@ -114,6 +117,24 @@ func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb,
 
 	logs.Infof("initialize system api client set success.")
 
+	esbClient, err := esb.NewClient(&esbSettings, metrics.Register())
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &ClientSet{
+		ds:     apiClientSet.DataService(),
+		esbCli: esbClient,
+	}
+
+	// 使用本地RBAC鉴权时不依赖IAM，不需要初始化IAM相关的客户端
+	if authorizer.Backend == cc.LocalAuthorizerBackend {
+		refreshInterval := time.Duration(authorizer.Local.RefreshIntervalSec) * time.Second
+		cs.rbac = rbac.NewAuthorizer(cs.ds, authorizer.Local.Admins, refreshInterval)
+		logs.Infof("initialize the client set with local rbac authorizer success.")
+		return cs, nil
+	}
+
 	cfg := &client.Config{
 		Address:   iamSettings.Endpoints,
 		AppCode:   iamSettings.AppCode,
@@ -138,23 +159,14 @@ func newClientSet(sd serviced.Discover, iamSettings cc.IAM, esbSettings cc.Esb,
 		return nil, fmt.Errorf("new iam logics failed, err: %v", err)
 	}
 
-	esbClient, err := esb.NewClient(&esbSettings, metrics.Register())
-	if err != nil {
-		return nil, err
-	}
-
 	authSdk, err := pkgauth.NewAuth(iamCli, iamLgc, esbClient)
 	if err != nil {
 		return nil, fmt.Errorf("new iam auth sdk failed, err: %v", err)
 	}
 	logs.Infof("initialize iam auth sdk success.")
 
-	cs := &ClientSet{
-		ds:     apiClientSet.DataService(),
-		sys:    iamSys,
-		auth:   authSdk,
-		esbCli: esbClient,
-	}
+	cs.sys = iamSys
+	cs.auth = authSdk
 	logs.Infof("initialize the client set success.")
 	return cs, nil
 }
@@ -167,6 +179,8 @@ type ClientSet struct {
 	sys *sys.Sys
 	// auth related operate.
 	auth pkgauth.Authorizer
+	// rbac local rbac authorizer, only set when authorize backend is local.
+	rbac *rbac.Authorizer
 	// esb client.
 	esbCli esb.Client
 }
@@ -175,6 +189,12 @@ type ClientSet struct {
 func (s *Service) initLogicModule() error {
 	var err error
 
+	if s.client.rbac != nil {
+		s.auth, err = auth.NewLocalAuth(s.client.rbac, s.client.ds, s.disableAuth, s.client.esbCli,
+			s.disableWriteOpt)
+		return err
+	}
+
 	s.initial, err = initial.NewInitial(s.client.sys, s.disableAuth)
 	if err != nil {
 		return err
@@ -21,6 +21,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -34,6 +35,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the cloud server.
@@ -59,6 +61,8 @@ func Run(opt *options.Option) error {
 type cloudServer struct {
 	svc *service.Service
 	sd  serviced.Service
+	// shutdownTracing 进程退出前导出剩余的链路数据
+	shutdownTracing func(ctx context.Context) error
 }
 
 // prepare do prepare jobs before run api discover.
@@ -76,6 +80,18 @@ func (ds *cloudServer) prepare(opt *options.Option) error {
 	network := cc.CloudServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	shutdownTracing, err := tracing.Init(string(cc.CloudServerName), cc.CloudServer().Tracing.Config())
+	if err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+	ds.shutdownTracing = shutdownTracing
+
+	// load dynamic config overrides from etcd and keep watching the changes.
+	if err := serviced.WatchConfig(cc.CloudServer().Service); err != nil {
+		return fmt.Errorf("watch dynamic config failed, err: %v", err)
+	}
+
 	// init service discovery.
 	svcOpt := serviced.NewServiceOption(cc.CloudServerName, cc.CloudServer().Network)
 	discOpt := serviced.DiscoveryOption{
@@ -96,7 +112,7 @@ func (ds *cloudServer) prepare(opt *options.Option) error {
 	ds.svc = svc
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
+	if err := ctl.LoadCtl(append(ctl.WithBasics(sd), svc.CtlCommands()...)...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
@@ -114,6 +130,8 @@ func (ds *cloudServer) register() error {
 }
 
 func (ds *cloudServer) finalizer() {
+	defer tracing.Shutdown(ds.shutdownTracing)
+
 	lock.Manager.Close()
 
 	if err := ds.sd.Deregister(); err != nil {
@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dependency
+
+import (
+	actioncvm "hcm/cmd/task-server/logics/action/cvm"
+	actioneip "hcm/cmd/task-server/logics/action/eip"
+	actionlb "hcm/cmd/task-server/logics/action/load-balancer"
+	actionsg "hcm/cmd/task-server/logics/action/security-group"
+	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
+	actionvpc "hcm/cmd/task-server/logics/action/vpc"
+	depproto "hcm/pkg/api/cloud-server/dependency"
+	hcproto "hcm/pkg/api/hc-service/load-balancer"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/counter"
+	"hcm/pkg/tools/slice"
+)
+
+// deleteActions 支持级联删除的资源类型及其删除任务
+var deleteActions = map[enumor.CloudResourceType]enumor.ActionName{
+	enumor.CvmCloudResType:           enumor.ActionDeleteCvm,
+	enumor.EipCloudResType:           enumor.ActionDeleteEIP,
+	enumor.SecurityGroupCloudResType: enumor.ActionDeleteSecurityGroup,
+	enumor.LoadBalancerCloudResType:  enumor.ActionDeleteLoadBalancer,
+	enumor.ListenerCloudResType:      enumor.ActionLoadBalancerDeleteListener,
+	enumor.SubnetCloudResType:        enumor.ActionDeleteSubnet,
+	enumor.VpcCloudResType:           enumor.ActionDeleteVpc,
+}
+
+// CascadeDelete 按依赖树创建级联删除任务流，依赖资源的删除任务先于父资源执行。
+func (d *dependency) CascadeDelete(kt *kit.Kit, items []depproto.DeleteImpactItem) (string, error) {
+	tasks, err := buildCascadeTasks(items)
+	if err != nil {
+		return "", err
+	}
+
+	req := &ts.AddCustomFlowReq{
+		Name:  enumor.FlowCascadeDelete,
+		Tasks: tasks,
+	}
+	result, err := d.client.TaskServer().CreateCustomFlow(kt, req)
+	if err != nil {
+		logs.Errorf("create cascade delete flow failed, err: %v, rid: %s", err, kt.Rid)
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+func buildCascadeTasks(items []depproto.DeleteImpactItem) ([]ts.CustomFlowTask, error) {
+	builder := &cascadeTaskBuilder{
+		getActionID: counter.NewNumStringCounter(1, 10),
+		added:       make(map[string]action.ActIDType),
+		tasks:       make([]ts.CustomFlowTask, 0),
+	}
+
+	for _, item := range items {
+		if !item.Cascadable {
+			return nil, errf.Newf(errf.InvalidParameter, "%s: %s has dependencies that can not be cascade deleted",
+				item.Root.ResType, item.Root.ID)
+		}
+
+		if _, err := builder.add(item.Root); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.tasks, nil
+}
+
+type cascadeTaskBuilder struct {
+	getActionID func() string
+	added       map[string]action.ActIDType
+	tasks       []ts.CustomFlowTask
+}
+
+// add 后序遍历依赖树添加删除任务，返回该节点删除任务的ID
+func (b *cascadeTaskBuilder) add(node *depproto.DependencyNode) (action.ActIDType, error) {
+	if actionID, exists := b.added[nodeKey(node)]; exists {
+		return actionID, nil
+	}
+
+	var dependOn []action.ActIDType
+	listenerIDs := make([]string, 0)
+	for _, child := range node.Children {
+		if !child.Cascade {
+			continue
+		}
+
+		// 监听器需要指定所属负载均衡，按负载均衡批量删除
+		if child.ResType == enumor.ListenerCloudResType {
+			listenerIDs = append(listenerIDs, child.ID)
+			continue
+		}
+
+		actionID, err := b.add(child)
+		if err != nil {
+			return "", err
+		}
+		dependOn = append(dependOn, actionID)
+	}
+
+	for _, part := range slice.Split(listenerIDs, constant.BatchDeleteListenerCloudMaxLimit) {
+		actionID := action.ActIDType(b.getActionID())
+		b.tasks = append(b.tasks, ts.CustomFlowTask{
+			ActionID:   actionID,
+			ActionName: enumor.ActionLoadBalancerDeleteListener,
+			Params: actionlb.DeleteListenerOption{
+				Vendor:      node.Vendor,
+				LbID:        node.ID,
+				ListenerIDs: part,
+			},
+			Retry: tableasync.NewRetryWithPolicy(3, 100, 200),
+		})
+		dependOn = append(dependOn, actionID)
+	}
+
+	params, err := deleteParams(node)
+	if err != nil {
+		return "", err
+	}
+
+	actionID := action.ActIDType(b.getActionID())
+	b.tasks = append(b.tasks, ts.CustomFlowTask{
+		ActionID:   actionID,
+		ActionName: deleteActions[node.ResType],
+		Params:     params,
+		DependOn:   dependOn,
+		Retry:      tableasync.NewRetryWithPolicy(3, 1000, 5000),
+	})
+	b.added[nodeKey(node)] = actionID
+
+	return actionID, nil
+}
+
+func deleteParams(node *depproto.DependencyNode) (interface{}, error) {
+	switch node.ResType {
+	case enumor.CvmCloudResType:
+		return &actioncvm.CvmOperationOption{
+			Vendor:    node.Vendor,
+			AccountID: node.AccountID,
+			Region:    node.Region,
+			IDs:       []string{node.ID},
+		}, nil
+	case enumor.EipCloudResType:
+		return actioneip.DeleteEIPOption{Vendor: node.Vendor, ID: node.ID}, nil
+	case enumor.SecurityGroupCloudResType:
+		return actionsg.DeleteSGOption{Vendor: node.Vendor, ID: node.ID}, nil
+	case enumor.LoadBalancerCloudResType:
+		return actionlb.DeleteLoadBalancerOption{
+			Vendor: node.Vendor,
+			TCloudBatchDeleteLoadbalancerReq: hcproto.TCloudBatchDeleteLoadbalancerReq{
+				AccountID: node.AccountID,
+				Region:    node.Region,
+				IDs:       []string{node.ID},
+			},
+		}, nil
+	case enumor.SubnetCloudResType:
+		return &actionsubnet.DeleteSubnetOption{Vendor: node.Vendor, ID: node.ID}, nil
+	case enumor.VpcCloudResType:
+		return &actionvpc.DeleteVpcOption{Vendor: node.Vendor, ID: node.ID}, nil
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "%s not support cascade delete", node.ResType)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dependency
+
+import (
+	"testing"
+
+	depproto "hcm/pkg/api/cloud-server/dependency"
+	"hcm/pkg/async/action"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestBuildCascadeTasks(t *testing.T) {
+	lb := &depproto.DependencyNode{ResType: enumor.LoadBalancerCloudResType, ID: "lb-1", Vendor: enumor.TCloud,
+		Blocking: true, Children: []*depproto.DependencyNode{
+			{ResType: enumor.ListenerCloudResType, ID: "lis-1", Blocking: true, Cascade: true},
+			{ResType: enumor.ListenerCloudResType, ID: "lis-2", Blocking: true, Cascade: true},
+		}}
+	subnet := &depproto.DependencyNode{ResType: enumor.SubnetCloudResType, ID: "subnet-1", Vendor: enumor.TCloud,
+		Blocking: true, Cascade: true}
+	vpc := &depproto.DependencyNode{ResType: enumor.VpcCloudResType, ID: "vpc-1", Vendor: enumor.TCloud,
+		Children: []*depproto.DependencyNode{
+			subnet,
+			{ResType: enumor.RouteTableCloudResType, ID: "rt-1"},
+		}}
+
+	items := []depproto.DeleteImpactItem{
+		{Root: lb, Cascadable: isCascadable(lb)},
+		{Root: vpc, Cascadable: isCascadable(vpc)},
+	}
+	tasks, err := buildCascadeTasks(items)
+	if err != nil {
+		t.Fatalf("build cascade tasks failed, err: %v", err)
+	}
+
+	expects := []enumor.ActionName{
+		enumor.ActionLoadBalancerDeleteListener,
+		enumor.ActionDeleteLoadBalancer,
+		enumor.ActionDeleteSubnet,
+		enumor.ActionDeleteVpc,
+	}
+	if len(tasks) != len(expects) {
+		t.Fatalf("got %d tasks, expect %d, tasks: %+v", len(tasks), len(expects), tasks)
+	}
+
+	executed := make(map[action.ActIDType]bool)
+	for i, task := range tasks {
+		if task.ActionName != expects[i] {
+			t.Errorf("task %d got action %s, expect %s", i, task.ActionName, expects[i])
+		}
+		for _, dep := range task.DependOn {
+			if !executed[dep] {
+				t.Errorf("task %s depends on %s which is not created before", task.ActionID, dep)
+			}
+		}
+		executed[task.ActionID] = true
+	}
+
+	// 子网上仍有主机时不可级联删除
+	subnet.Children = []*depproto.DependencyNode{{ResType: enumor.CvmCloudResType, ID: "cvm-1", Blocking: true}}
+	if isCascadable(vpc) {
+		t.Errorf("vpc with blocking cvm in subnet should not be cascadable")
+	}
+}
@@ -0,0 +1,250 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dependency 资源依赖分析及级联删除
+package dependency
+
+import (
+	"fmt"
+
+	depproto "hcm/pkg/api/cloud-server/dependency"
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// maxDepth 依赖树最大深度
+const maxDepth = 5
+
+// basicInfoFields 依赖树节点查询的基础字段
+var basicInfoFields = []string{"id", "vendor", "account_id", "bk_biz_id"}
+
+// regionBasicInfoFields 带地域的资源查询的基础字段
+var regionBasicInfoFields = []string{"id", "vendor", "account_id", "bk_biz_id", "region"}
+
+// regionResTypes 带地域字段的资源类型
+var regionResTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:              {},
+	enumor.DiskCloudResType:             {},
+	enumor.EipCloudResType:              {},
+	enumor.SecurityGroupCloudResType:    {},
+	enumor.LoadBalancerCloudResType:     {},
+	enumor.TargetGroupCloudResType:      {},
+	enumor.SubnetCloudResType:           {},
+	enumor.VpcCloudResType:              {},
+	enumor.RouteTableCloudResType:       {},
+	enumor.NetworkInterfaceCloudResType: {},
+}
+
+// Interface define dependency logics interface.
+type Interface interface {
+	DeleteImpact(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) ([]depproto.DeleteImpactItem, error)
+	CascadeDelete(kt *kit.Kit, items []depproto.DeleteImpactItem) (string, error)
+}
+
+type dependency struct {
+	client *client.ClientSet
+}
+
+// NewDependency new dependency logics.
+func NewDependency(client *client.ClientSet) Interface {
+	return &dependency{
+		client: client,
+	}
+}
+
+// DeleteImpact 分析资源删除影响，返回每个资源的依赖树。仅沿可级联删除的依赖继续向下分析。
+func (d *dependency) DeleteImpact(kt *kit.Kit, resType enumor.CloudResourceType, ids []string) (
+	[]depproto.DeleteImpactItem, error) {
+
+	ids = slice.Unique(ids)
+	roots := make([]*depproto.DependencyNode, 0, len(ids))
+	for _, id := range ids {
+		roots = append(roots, &depproto.DependencyNode{ResType: resType, ID: id})
+	}
+
+	if err := d.fillBasicInfo(kt, roots); err != nil {
+		return nil, err
+	}
+
+	for _, root := range roots {
+		if len(root.Vendor) == 0 {
+			return nil, errf.Newf(errf.RecordNotFound, "%s: %s not found", resType, root.ID)
+		}
+	}
+
+	if err := d.buildTree(kt, roots, 1); err != nil {
+		return nil, err
+	}
+
+	items := make([]depproto.DeleteImpactItem, 0, len(roots))
+	for _, root := range roots {
+		items = append(items, depproto.DeleteImpactItem{
+			Root:       root,
+			Deletable:  isDeletable(root),
+			Cascadable: isCascadable(root),
+		})
+	}
+
+	return items, nil
+}
+
+// buildTree 按层查询依赖资源，同一层同类型资源批量查询
+func (d *dependency) buildTree(kt *kit.Kit, nodes []*depproto.DependencyNode, depth int) error {
+	if len(nodes) == 0 || depth > maxDepth {
+		return nil
+	}
+
+	typeNodes := groupByResType(nodes)
+	children := make([]*depproto.DependencyNode, 0)
+	next := make([]*depproto.DependencyNode, 0)
+	for resType, list := range typeNodes {
+		ids := make([]string, 0, len(list))
+		for _, node := range list {
+			ids = append(ids, node.ID)
+		}
+
+		for _, e := range d.edges(resType) {
+			rel := make(relation)
+			for _, part := range slice.Split(ids, int(core.DefaultMaxPageLimit)) {
+				partRel, err := e.find(kt, part)
+				if err != nil {
+					return err
+				}
+				for parentID, refs := range partRel {
+					rel[parentID] = append(rel[parentID], refs...)
+				}
+			}
+
+			for _, node := range list {
+				for _, ref := range rel[node.ID] {
+					child := &depproto.DependencyNode{
+						ResType:  ref.ResType,
+						ID:       ref.ID,
+						Blocking: e.blocking,
+						Cascade:  e.cascade,
+					}
+					node.Children = append(node.Children, child)
+					children = append(children, child)
+					if e.cascade {
+						next = append(next, child)
+					}
+				}
+			}
+		}
+	}
+
+	if err := d.fillBasicInfo(kt, children); err != nil {
+		return err
+	}
+
+	return d.buildTree(kt, next, depth+1)
+}
+
+// fillBasicInfo 填充节点的基础信息
+func (d *dependency) fillBasicInfo(kt *kit.Kit, nodes []*depproto.DependencyNode) error {
+	for resType, list := range groupByResType(nodes) {
+		fields := basicInfoFields
+		if _, exists := regionResTypes[resType]; exists {
+			fields = regionBasicInfoFields
+		}
+
+		ids := make([]string, 0, len(list))
+		for _, node := range list {
+			ids = append(ids, node.ID)
+		}
+
+		for _, part := range slice.Split(slice.Unique(ids), int(core.DefaultMaxPageLimit)) {
+			req := dataproto.ListResourceBasicInfoReq{ResourceType: resType, IDs: part, Fields: fields}
+			infoMap, err := d.client.DataService().Global.Cloud.ListResBasicInfo(kt, req)
+			if err != nil {
+				logs.Errorf("list %s basic info failed, err: %v, ids: %v, rid: %s", resType, err, part, kt.Rid)
+				return err
+			}
+
+			for _, node := range list {
+				info, exists := infoMap[node.ID]
+				if !exists {
+					continue
+				}
+				node.Vendor = info.Vendor
+				node.AccountID = info.AccountID
+				node.BkBizID = info.BkBizID
+				node.Region = info.Region
+			}
+		}
+	}
+
+	return nil
+}
+
+func groupByResType(nodes []*depproto.DependencyNode) map[enumor.CloudResourceType][]*depproto.DependencyNode {
+	result := make(map[enumor.CloudResourceType][]*depproto.DependencyNode)
+	for _, node := range nodes {
+		result[node.ResType] = append(result[node.ResType], node)
+	}
+	return result
+}
+
+// isDeletable 没有阻塞删除的依赖资源
+func isDeletable(node *depproto.DependencyNode) bool {
+	for _, child := range node.Children {
+		if child.Blocking {
+			return false
+		}
+	}
+	return true
+}
+
+// isCascadable 资源支持删除，且阻塞删除的依赖资源均可级联删除
+func isCascadable(node *depproto.DependencyNode) bool {
+	if _, exists := deleteActions[node.ResType]; !exists {
+		return false
+	}
+
+	for _, child := range node.Children {
+		if !child.Blocking {
+			continue
+		}
+		if !child.Cascade || !isCascadable(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// Nodes 展开依赖树中将被删除的资源，包括根资源及其级联删除的依赖资源
+func Nodes(root *depproto.DependencyNode) []*depproto.DependencyNode {
+	result := []*depproto.DependencyNode{root}
+	for _, child := range root.Children {
+		if child.Cascade {
+			result = append(result, Nodes(child)...)
+		}
+	}
+	return result
+}
+
+func nodeKey(node *depproto.DependencyNode) string {
+	return fmt.Sprintf("%s/%s", node.ResType, node.ID)
+}
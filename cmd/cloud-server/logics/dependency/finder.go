@@ -0,0 +1,456 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dependency
+
+import (
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	coreni "hcm/pkg/api/core/cloud/network-interface"
+	routetable "hcm/pkg/api/core/cloud/route-table"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+)
+
+// resRef 依赖资源引用
+type resRef struct {
+	ResType enumor.CloudResourceType
+	ID      string
+}
+
+// relation 父资源ID到依赖资源的映射
+type relation map[string][]resRef
+
+func (r relation) add(parentID string, resType enumor.CloudResourceType, id string) {
+	r[parentID] = append(r[parentID], resRef{ResType: resType, ID: id})
+}
+
+type finder func(kt *kit.Kit, ids []string) (relation, error)
+
+// edge 描述一类依赖关系
+type edge struct {
+	// blocking 依赖资源存在时父资源无法删除
+	blocking bool
+	// cascade 依赖资源可以随父资源级联删除
+	cascade bool
+	find    finder
+}
+
+// edges 返回资源类型的所有依赖关系
+func (d *dependency) edges(resType enumor.CloudResourceType) []edge {
+	switch resType {
+	case enumor.CvmCloudResType:
+		// 主机删除时云上会自动解绑硬盘、EIP、安全组等，不阻塞删除
+		return []edge{
+			{find: d.cvmDisks},
+			{find: d.cvmEips},
+			{find: d.cvmNetworkInterfaces},
+			{find: d.cvmSecurityGroups},
+			{find: d.cvmTargetGroups},
+		}
+	case enumor.DiskCloudResType:
+		return []edge{{blocking: true, find: d.diskCvms}}
+	case enumor.EipCloudResType:
+		return []edge{{blocking: true, find: d.eipCvms}}
+	case enumor.SecurityGroupCloudResType:
+		return []edge{{blocking: true, find: d.securityGroupBoundRes}}
+	case enumor.LoadBalancerCloudResType:
+		return []edge{{blocking: true, cascade: true, find: d.loadBalancerListeners}}
+	case enumor.SubnetCloudResType:
+		return []edge{
+			{blocking: true, find: d.subnetCvms},
+			{blocking: true, find: d.subnetNetworkInterfaces},
+			{blocking: true, find: d.subnetLoadBalancers},
+		}
+	case enumor.VpcCloudResType:
+		// 默认路由表随VPC一起删除，不阻塞删除
+		return []edge{
+			{blocking: true, cascade: true, find: d.vpcSubnets},
+			{blocking: true, find: d.vpcLoadBalancers},
+			{find: d.vpcRouteTables},
+		}
+	case enumor.RouteTableCloudResType:
+		return []edge{{blocking: true, find: d.routeTableSubnets}}
+	default:
+		return nil
+	}
+}
+
+// listAll 分页查询满足条件的全部数据
+func listAll[T any](expr *filter.Expression, fields []string, list func(req *core.ListReq) ([]T, error)) ([]T,
+	error) {
+
+	req := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+		Fields: fields,
+	}
+
+	all := make([]T, 0)
+	for {
+		details, err := list(req)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, details...)
+
+		if len(details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		req.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return all, nil
+}
+
+func (d *dependency) cvmDisks(kt *kit.Kit, ids []string) (relation, error) {
+	return d.listDiskCvmRel(kt, "cvm_id", ids, func(r relation, rel *dataproto.DiskCvmRelResult) {
+		r.add(rel.CvmID, enumor.DiskCloudResType, rel.DiskID)
+	})
+}
+
+func (d *dependency) diskCvms(kt *kit.Kit, ids []string) (relation, error) {
+	return d.listDiskCvmRel(kt, "disk_id", ids, func(r relation, rel *dataproto.DiskCvmRelResult) {
+		r.add(rel.DiskID, enumor.CvmCloudResType, rel.CvmID)
+	})
+}
+
+func (d *dependency) listDiskCvmRel(kt *kit.Kit, field string, ids []string,
+	add func(r relation, rel *dataproto.DiskCvmRelResult)) (relation, error) {
+
+	rels, err := listAll(tools.ContainersExpression(field, ids), nil,
+		func(req *core.ListReq) ([]*dataproto.DiskCvmRelResult, error) {
+			result, err := d.client.DataService().Global.ListDiskCvmRel(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list disk cvm rel failed, err: %v, %s: %v, rid: %s", err, field, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, rel := range rels {
+		add(r, rel)
+	}
+	return r, nil
+}
+
+func (d *dependency) cvmEips(kt *kit.Kit, ids []string) (relation, error) {
+	return d.listEipCvmRel(kt, "cvm_id", ids, func(r relation, rel *dataproto.EipCvmRelResult) {
+		r.add(rel.CvmID, enumor.EipCloudResType, rel.EipID)
+	})
+}
+
+func (d *dependency) eipCvms(kt *kit.Kit, ids []string) (relation, error) {
+	return d.listEipCvmRel(kt, "eip_id", ids, func(r relation, rel *dataproto.EipCvmRelResult) {
+		r.add(rel.EipID, enumor.CvmCloudResType, rel.CvmID)
+	})
+}
+
+func (d *dependency) listEipCvmRel(kt *kit.Kit, field string, ids []string,
+	add func(r relation, rel *dataproto.EipCvmRelResult)) (relation, error) {
+
+	rels, err := listAll(tools.ContainersExpression(field, ids), nil,
+		func(req *core.ListReq) ([]*dataproto.EipCvmRelResult, error) {
+			result, err := d.client.DataService().Global.ListEipCvmRel(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list eip cvm rel failed, err: %v, %s: %v, rid: %s", err, field, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, rel := range rels {
+		add(r, rel)
+	}
+	return r, nil
+}
+
+func (d *dependency) cvmNetworkInterfaces(kt *kit.Kit, ids []string) (relation, error) {
+	rels, err := listAll(tools.ContainersExpression("cvm_id", ids), nil,
+		func(req *core.ListReq) ([]*dataproto.NetworkInterfaceCvmRelResult, error) {
+			result, err := d.client.DataService().Global.NetworkInterfaceCvmRel.List(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list network interface cvm rel failed, err: %v, cvm ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, rel := range rels {
+		r.add(rel.CvmID, enumor.NetworkInterfaceCloudResType, rel.NetworkInterfaceID)
+	}
+	return r, nil
+}
+
+func (d *dependency) cvmSecurityGroups(kt *kit.Kit, ids []string) (relation, error) {
+	expr := tools.ExpressionAnd(
+		tools.RuleEqual("res_type", enumor.CvmCloudResType),
+		tools.RuleIn("res_id", ids),
+	)
+	rels, err := d.listSGCommonRel(kt, expr)
+	if err != nil {
+		logs.Errorf("list cvm security group rel failed, err: %v, cvm ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, rel := range rels {
+		r.add(rel.ResID, enumor.SecurityGroupCloudResType, rel.SecurityGroupID)
+	}
+	return r, nil
+}
+
+func (d *dependency) securityGroupBoundRes(kt *kit.Kit, ids []string) (relation, error) {
+	rels, err := d.listSGCommonRel(kt, tools.ContainersExpression("security_group_id", ids))
+	if err != nil {
+		logs.Errorf("list security group bound res failed, err: %v, sg ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, rel := range rels {
+		r.add(rel.SecurityGroupID, rel.ResType, rel.ResID)
+	}
+	return r, nil
+}
+
+func (d *dependency) listSGCommonRel(kt *kit.Kit, expr *filter.Expression) ([]corecloud.SecurityGroupCommonRel,
+	error) {
+
+	return listAll(expr, nil, func(req *core.ListReq) ([]corecloud.SecurityGroupCommonRel, error) {
+		result, err := d.client.DataService().Global.SGCommonRel.List(kt, req)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	})
+}
+
+func (d *dependency) cvmTargetGroups(kt *kit.Kit, ids []string) (relation, error) {
+	targets, err := listAll(tools.ContainersExpression("inst_id", ids), []string{"inst_id", "target_group_id"},
+		func(req *core.ListReq) ([]corelb.BaseTarget, error) {
+			result, err := d.client.DataService().Global.LoadBalancer.ListTarget(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list cvm target failed, err: %v, cvm ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, target := range targets {
+		r.add(target.InstID, enumor.TargetGroupCloudResType, target.TargetGroupID)
+	}
+	return r, nil
+}
+
+func (d *dependency) loadBalancerListeners(kt *kit.Kit, ids []string) (relation, error) {
+	listeners, err := listAll(tools.ContainersExpression("lb_id", ids), []string{"id", "lb_id"},
+		func(req *core.ListReq) ([]corelb.BaseListener, error) {
+			result, err := d.client.DataService().Global.LoadBalancer.ListListener(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list load balancer listener failed, err: %v, lb ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, listener := range listeners {
+		r.add(listener.LbID, enumor.ListenerCloudResType, listener.ID)
+	}
+	return r, nil
+}
+
+// subnetCvms 主机的子网ID以json数组存储，需要逐个子网查询
+func (d *dependency) subnetCvms(kt *kit.Kit, ids []string) (relation, error) {
+	r := make(relation)
+	for _, id := range ids {
+		cvms, err := listAll(tools.ExpressionAnd(tools.RuleJSONContains("subnet_ids", id)), []string{"id"},
+			func(req *core.ListReq) ([]corecvm.BaseCvm, error) {
+				result, err := d.client.DataService().Global.Cvm.ListCvm(kt, req)
+				if err != nil {
+					return nil, err
+				}
+				return result.Details, nil
+			})
+		if err != nil {
+			logs.Errorf("list subnet cvm failed, err: %v, subnet id: %s, rid: %s", err, id, kt.Rid)
+			return nil, err
+		}
+
+		for _, cvm := range cvms {
+			r.add(id, enumor.CvmCloudResType, cvm.ID)
+		}
+	}
+	return r, nil
+}
+
+func (d *dependency) subnetNetworkInterfaces(kt *kit.Kit, ids []string) (relation, error) {
+	nis, err := listAll(tools.ContainersExpression("subnet_id", ids), []string{"id", "subnet_id"},
+		func(req *core.ListReq) ([]coreni.BaseNetworkInterface, error) {
+			result, err := d.client.DataService().Global.NetworkInterface.List(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list subnet network interface failed, err: %v, subnet ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, ni := range nis {
+		r.add(ni.SubnetID, enumor.NetworkInterfaceCloudResType, ni.ID)
+	}
+	return r, nil
+}
+
+func (d *dependency) subnetLoadBalancers(kt *kit.Kit, ids []string) (relation, error) {
+	lbs, err := d.listLoadBalancer(kt, "subnet_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, lb := range lbs {
+		r.add(lb.SubnetID, enumor.LoadBalancerCloudResType, lb.ID)
+	}
+	return r, nil
+}
+
+func (d *dependency) vpcLoadBalancers(kt *kit.Kit, ids []string) (relation, error) {
+	lbs, err := d.listLoadBalancer(kt, "vpc_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, lb := range lbs {
+		r.add(lb.VpcID, enumor.LoadBalancerCloudResType, lb.ID)
+	}
+	return r, nil
+}
+
+func (d *dependency) listLoadBalancer(kt *kit.Kit, field string, ids []string) ([]corelb.BaseLoadBalancer,
+	error) {
+
+	lbs, err := listAll(tools.ContainersExpression(field, ids), []string{"id", "vpc_id", "subnet_id"},
+		func(req *core.ListReq) ([]corelb.BaseLoadBalancer, error) {
+			result, err := d.client.DataService().Global.LoadBalancer.ListLoadBalancer(kt, req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, %s: %v, rid: %s", err, field, ids, kt.Rid)
+		return nil, err
+	}
+	return lbs, nil
+}
+
+func (d *dependency) vpcSubnets(kt *kit.Kit, ids []string) (relation, error) {
+	subnets, err := d.listSubnet(kt, "vpc_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, subnet := range subnets {
+		r.add(subnet.VpcID, enumor.SubnetCloudResType, subnet.ID)
+	}
+	return r, nil
+}
+
+func (d *dependency) routeTableSubnets(kt *kit.Kit, ids []string) (relation, error) {
+	subnets, err := d.listSubnet(kt, "route_table_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, subnet := range subnets {
+		r.add(subnet.RouteTableID, enumor.SubnetCloudResType, subnet.ID)
+	}
+	return r, nil
+}
+
+func (d *dependency) listSubnet(kt *kit.Kit, field string, ids []string) ([]corecloud.BaseSubnet, error) {
+	subnets, err := listAll(tools.ContainersExpression(field, ids), []string{"id", "vpc_id", "route_table_id"},
+		func(req *core.ListReq) ([]corecloud.BaseSubnet, error) {
+			result, err := d.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list subnet failed, err: %v, %s: %v, rid: %s", err, field, ids, kt.Rid)
+		return nil, err
+	}
+	return subnets, nil
+}
+
+func (d *dependency) vpcRouteTables(kt *kit.Kit, ids []string) (relation, error) {
+	routeTables, err := listAll(tools.ContainersExpression("vpc_id", ids), []string{"id", "vpc_id"},
+		func(req *core.ListReq) ([]routetable.BaseRouteTable, error) {
+			result, err := d.client.DataService().Global.RouteTable.List(kt.Ctx, kt.Header(), req)
+			if err != nil {
+				return nil, err
+			}
+			return result.Details, nil
+		})
+	if err != nil {
+		logs.Errorf("list vpc route table failed, err: %v, vpc ids: %v, rid: %s", err, ids, kt.Rid)
+		return nil, err
+	}
+
+	r := make(relation)
+	for _, routeTable := range routeTables {
+		r.add(routeTable.VpcID, enumor.RouteTableCloudResType, routeTable.ID)
+	}
+	return r, nil
+}
@@ -23,8 +23,10 @@ package logics
 import (
 	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/cvm"
+	"hcm/cmd/cloud-server/logics/dependency"
 	"hcm/cmd/cloud-server/logics/disk"
 	"hcm/cmd/cloud-server/logics/eip"
+	securitygroup "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/pkg/client"
 	"hcm/pkg/thirdparty/esb"
 )
@@ -35,11 +37,13 @@ type Logics struct {
 	Disk  disk.Interface
 	Cvm   cvm.Interface
 	Eip   eip.Interface
+
+	SecurityGroup securitygroup.Interface
+	Dependency    dependency.Interface
 }
 
 // NewLogics create a new cloud server logics.
-func NewLogics(c *client.ClientSet, esbClient esb.Client) *Logics {
-	auditLogics := audit.NewAudit(c.DataService())
+func NewLogics(c *client.ClientSet, esbClient esb.Client, auditLogics audit.Interface) *Logics {
 	eipLogics := eip.NewEip(c, auditLogics)
 	diskLogics := disk.NewDisk(c, auditLogics)
 	return &Logics{
@@ -47,5 +51,8 @@ func NewLogics(c *client.ClientSet, esbClient esb.Client) *Logics {
 		Disk:  disk.NewDisk(c, auditLogics),
 		Cvm:   cvm.NewCvm(c, auditLogics, eipLogics, diskLogics, esbClient),
 		Eip:   eip.NewEip(c, auditLogics),
+
+		SecurityGroup: securitygroup.NewSecurityGroup(c),
+		Dependency:    dependency.NewDependency(c),
 	}
 }
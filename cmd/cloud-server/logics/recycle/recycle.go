@@ -20,6 +20,8 @@
 package logicsrecycle
 
 import (
+	"fmt"
+
 	corerr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/api/data-service/recycle-record"
 	"hcm/pkg/client/data-service"
@@ -63,3 +65,29 @@ func MarkRecordSuccess(kt *kit.Kit, ds *dataservice.Client, recordIDs []string)
 			constant.RecycleUpdateRecordFailed, recordIDs, err, kt.Rid)
 	}
 }
+
+// ValidateRecycleRecord 只能批量处理处于同一个回收任务的且是等待回收的记录。
+func ValidateRecycleRecord(records []corerr.RecycleRecord, resType enumor.CloudResourceType) error {
+	taskID := ""
+	for _, one := range records {
+		if len(taskID) == 0 {
+			taskID = one.TaskID
+		} else if taskID != one.TaskID {
+			return fmt.Errorf("only %s in one task can be reclaimed at the same time", resType)
+		}
+
+		if one.Status != enumor.WaitingRecycleRecordStatus {
+			return fmt.Errorf("record: %s not is wait_recycle status", one.ID)
+		}
+
+		if one.ResType != resType {
+			return fmt.Errorf("record: %s not is %s recycle record", one.ID, resType)
+		}
+
+		if one.RecycleType == enumor.RecycleTypeRelated {
+			return fmt.Errorf("related recycled %s(%s) can not be operated", resType, one.ResID)
+		}
+	}
+
+	return nil
+}
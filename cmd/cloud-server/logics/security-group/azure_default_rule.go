@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+// AzureDefaultSGRule define azure default security group rule.
+type AzureDefaultSGRule struct {
+	Name                                string                       `json:"name"`
+	Memo                                *string                      `json:"memo"`
+	DestinationAddressPrefix            *string                      `json:"destination_address_prefix"`
+	DestinationAddressPrefixes          []*string                    `json:"destination_address_prefixes"`
+	CloudDestinationAppSecurityGroupIDs []*string                    `json:"cloud_destination_app_security_group_ids"`
+	DestinationPortRange                *string                      `json:"destination_port_range"`
+	DestinationPortRanges               []*string                    `json:"destination_port_ranges"`
+	Protocol                            string                       `json:"protocol"`
+	ProvisioningState                   string                       `json:"provisioning_state"`
+	SourceAddressPrefix                 *string                      `json:"source_address_prefix"`
+	SourceAddressPrefixes               []*string                    `json:"source_address_prefixes"`
+	CloudSourceAppSecurityGroupIDs      []*string                    `json:"cloud_source_app_security_group_ids"`
+	SourcePortRange                     *string                      `json:"source_port_range"`
+	SourcePortRanges                    []*string                    `json:"source_port_ranges"`
+	Priority                            int32                        `json:"priority"`
+	Type                                enumor.SecurityGroupRuleType `json:"type"`
+	Access                              string                       `json:"access"`
+}
+
+// AzureDefaultSGRuleMap azure 安全组默认规则，按规则方向区分。
+// TODO: 之后考虑是否通过同步的方式将这几条默认安全组规则同步进来，而不是写死。
+// reference:
+// https://learn.microsoft.com/zh-cn/azure/virtual-network/network-security-groups-overview#default-security-rules
+var AzureDefaultSGRuleMap = map[enumor.SecurityGroupRuleType][]AzureDefaultSGRule{
+	enumor.Egress: {
+		{
+			Name:                     "AllowVnetOutBound",
+			Memo:                     converter.ValToPtr("Allow outbound traffic from all VMs to all VMs in VNET"),
+			Protocol:                 "*",
+			SourcePortRange:          converter.ValToPtr("*"),
+			DestinationPortRange:     converter.ValToPtr("*"),
+			SourceAddressPrefix:      converter.ValToPtr("VirtualNetwork"),
+			DestinationAddressPrefix: converter.ValToPtr("VirtualNetwork"),
+			Access:                   "Allow",
+			Priority:                 65000,
+			Type:                     enumor.Egress,
+		},
+		{
+			Name:                     "AllowInternetOutBound",
+			Memo:                     converter.ValToPtr("Allow outbound traffic from all VMs to Internet"),
+			Protocol:                 "*",
+			SourcePortRange:          converter.ValToPtr("*"),
+			DestinationPortRange:     converter.ValToPtr("*"),
+			SourceAddressPrefix:      converter.ValToPtr("*"),
+			DestinationAddressPrefix: converter.ValToPtr("Internet"),
+			Access:                   "Allow",
+			Priority:                 65001,
+			Type:                     enumor.Egress,
+		},
+		{
+			Name:                     "DenyAllOutBound",
+			Memo:                     converter.ValToPtr("Deny all outbound traffic"),
+			Protocol:                 "*",
+			SourcePortRange:          converter.ValToPtr("*"),
+			DestinationPortRange:     converter.ValToPtr("*"),
+			SourceAddressPrefix:      converter.ValToPtr("*"),
+			DestinationAddressPrefix: converter.ValToPtr("*"),
+			Access:                   "Deny",
+			Priority:                 65500,
+			Type:                     enumor.Egress,
+		},
+	},
+	enumor.Ingress: {
+		{
+			Name:                     "AllowVnetInBound",
+			Memo:                     converter.ValToPtr("Allow inbound traffic from all VMs in VNET"),
+			Protocol:                 "*",
+			SourcePortRange:          converter.ValToPtr("*"),
+			DestinationPortRange:     converter.ValToPtr("*"),
+			SourceAddressPrefix:      converter.ValToPtr("VirtualNetwork"),
+			DestinationAddressPrefix: converter.ValToPtr("VirtualNetwork"),
+			Access:                   "Allow",
+			Priority:                 65000,
+			Type:                     enumor.Ingress,
+		},
+		{
+			Name:                     "AllowAzureLoadBalancerInBound",
+			Memo:                     converter.ValToPtr("Allow inbound traffic from azure load balancer"),
+			Protocol:                 "*",
+			SourcePortRange:          converter.ValToPtr("*"),
+			DestinationPortRange:     converter.ValToPtr("*"),
+			SourceAddressPrefix:      converter.ValToPtr("AzureLoadBalancer"),
+			DestinationAddressPrefix: converter.ValToPtr("*"),
+			Access:                   "Allow",
+			Priority:                 65001,
+			Type:                     enumor.Ingress,
+		},
+		{
+			Name:                     "DenyAllInBound",
+			Memo:                     converter.ValToPtr("Deny all inbound traffic"),
+			Protocol:                 "*",
+			SourcePortRange:          converter.ValToPtr("*"),
+			DestinationPortRange:     converter.ValToPtr("*"),
+			SourceAddressPrefix:      converter.ValToPtr("*"),
+			DestinationAddressPrefix: converter.ValToPtr("*"),
+			Access:                   "Deny",
+			Priority:                 65500,
+			Type:                     enumor.Ingress,
+		},
+	},
+}
@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"sort"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/slice"
+)
+
+// GroupRules 参与评估的单个安全组及其规则，gcp 的防火墙规则按 vpc 聚合为一组。
+type GroupRules struct {
+	SecurityGroupID string
+	// Scope azure 安全组的绑定位置，取值为 subnet 或 network_interface
+	Scope enumor.CloudResourceType
+	Rules []Rule
+}
+
+// EvaluateOption define evaluate option.
+type EvaluateOption struct {
+	MatchOption
+	// NetworkTags gcp 实例的网络标记，用于匹配防火墙规则的 target tags
+	NetworkTags []string
+}
+
+// Evaluate 按照各云厂商安全组的生效规则评估访问是否放行，groups 需要按照云上绑定的优先级排列。
+func Evaluate(vendor enumor.Vendor, groups []GroupRules, opt *EvaluateOption) (
+	*cloudserver.SGPolicyEvaluateResult, error) {
+
+	result := &cloudserver.SGPolicyEvaluateResult{
+		SecurityGroupIDs: make([]string, 0, len(groups)),
+		SkippedRules:     make([]cloudserver.SGPolicyRule, 0),
+	}
+	for _, group := range groups {
+		result.SecurityGroupIDs = append(result.SecurityGroupIDs, group.SecurityGroupID)
+		for _, rule := range group.Rules {
+			if len(rule.Unresolved) != 0 && rule.Type == opt.Type {
+				result.SkippedRules = append(result.SkippedRules, rule.SGPolicyRule)
+			}
+		}
+	}
+
+	switch vendor {
+	case enumor.TCloud:
+		evaluateTCloud(result, groups, opt)
+	case enumor.Aws:
+		evaluateAws(result, groups, opt)
+	case enumor.HuaWei:
+		evaluateHuaWei(result, groups, opt)
+	case enumor.Azure:
+		evaluateAzure(result, groups, opt)
+	case enumor.Gcp:
+		evaluateGcp(result, groups, opt)
+	default:
+		return nil, fmt.Errorf("vendor: %s not support", vendor)
+	}
+
+	return result, nil
+}
+
+// evaluateTCloud 腾讯云按实例绑定安全组的先后顺序、组内按规则索引顺序依次匹配，首条命中的规则生效，均未命中时拒绝。
+func evaluateTCloud(result *cloudserver.SGPolicyEvaluateResult, groups []GroupRules, opt *EvaluateOption) {
+	for _, group := range groups {
+		rules := sortRules(group.Rules, false)
+		if rule := firstMatch(rules, opt); rule != nil {
+			decide(result, rule)
+			return
+		}
+	}
+
+	result.Allowed = false
+	result.Reason = "no rule matched, denied by default"
+}
+
+// evaluateAws aws 安全组规则只有放行，所有安全组规则取并集，任一规则命中即放行，均未命中时拒绝。
+func evaluateAws(result *cloudserver.SGPolicyEvaluateResult, groups []GroupRules, opt *EvaluateOption) {
+	for _, group := range groups {
+		if rule := firstMatch(group.Rules, opt); rule != nil {
+			decide(result, rule)
+			return
+		}
+	}
+
+	result.Allowed = false
+	result.Reason = "no allow rule matched, denied by default"
+}
+
+// evaluateHuaWei 华为云将所有安全组规则合并后按优先级匹配，优先级相同时拒绝规则优先，均未命中时拒绝。
+func evaluateHuaWei(result *cloudserver.SGPolicyEvaluateResult, groups []GroupRules, opt *EvaluateOption) {
+	all := make([]Rule, 0)
+	for _, group := range groups {
+		all = append(all, group.Rules...)
+	}
+
+	if rule := firstMatch(sortRules(all, true), opt); rule != nil {
+		decide(result, rule)
+		return
+	}
+
+	result.Allowed = false
+	result.Reason = "no rule matched, denied by default"
+}
+
+// evaluateAzure azure 子网和网络接口上的安全组需要分别放行，入站先评估子网安全组再评估网络接口安全组，出站相反。
+// 每个安全组内按优先级匹配，未命中自定义规则时由默认规则兜底。
+func evaluateAzure(result *cloudserver.SGPolicyEvaluateResult, groups []GroupRules, opt *EvaluateOption) {
+	first, second := enumor.SubnetCloudResType, enumor.NetworkInterfaceCloudResType
+	if opt.Type == enumor.Egress {
+		first, second = second, first
+	}
+
+	ordered := make([]GroupRules, 0, len(groups))
+	for _, scope := range []enumor.CloudResourceType{first, second} {
+		for _, group := range groups {
+			if group.Scope == scope {
+				ordered = append(ordered, group)
+			}
+		}
+	}
+
+	if len(ordered) == 0 {
+		result.Allowed = true
+		result.Reason = "no network security group associated, allowed by default"
+		return
+	}
+
+	for _, group := range ordered {
+		rules := append(make([]Rule, 0, len(group.Rules)), group.Rules...)
+		for _, one := range AzureDefaultSGRuleMap[opt.Type] {
+			rules = append(rules, ConvAzureDefaultRule(group.SecurityGroupID, one))
+		}
+
+		rule := firstMatch(sortRules(rules, false), opt)
+		if rule == nil {
+			continue
+		}
+
+		decide(result, rule)
+		if !result.Allowed {
+			return
+		}
+	}
+}
+
+// evaluateGcp gcp 防火墙规则作用于 vpc，按 target tags 筛选出对实例生效的规则后按优先级匹配，优先级相同时拒绝规则优先。
+// 均未命中时由隐含规则决定：入站拒绝，出站放行。
+func evaluateGcp(result *cloudserver.SGPolicyEvaluateResult, groups []GroupRules, opt *EvaluateOption) {
+	effective := make([]Rule, 0)
+	for _, group := range groups {
+		for _, rule := range group.Rules {
+			if len(rule.TargetTags) != 0 && len(slice.Intersection(rule.TargetTags, opt.NetworkTags)) == 0 {
+				continue
+			}
+			effective = append(effective, rule)
+		}
+	}
+
+	if rule := firstMatch(sortRules(effective, true), opt); rule != nil {
+		decide(result, rule)
+		return
+	}
+
+	if opt.Type == enumor.Egress {
+		result.Allowed = true
+		result.Reason = "no firewall rule matched, allowed by implied egress rule"
+		return
+	}
+
+	result.Allowed = false
+	result.Reason = "no firewall rule matched, denied by implied ingress rule"
+}
+
+// sortRules sort rules by priority asc, deny rule goes first with same priority if denyFirst is true.
+func sortRules(rules []Rule, denyFirst bool) []Rule {
+	sorted := append(make([]Rule, 0, len(rules)), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority < sorted[j].Priority
+		}
+
+		if denyFirst {
+			return sorted[i].Action == enumor.SGPolicyDeny && sorted[j].Action != enumor.SGPolicyDeny
+		}
+
+		return false
+	})
+
+	return sorted
+}
+
+func firstMatch(rules []Rule, opt *EvaluateOption) *Rule {
+	for idx := range rules {
+		if rules[idx].Match(&opt.MatchOption) {
+			return &rules[idx]
+		}
+	}
+
+	return nil
+}
+
+func decide(result *cloudserver.SGPolicyEvaluateResult, rule *Rule) {
+	decided := rule.SGPolicyRule
+	result.DecidedBy = &decided
+	result.Allowed = rule.Action == enumor.SGPolicyAllow
+	result.Reason = fmt.Sprintf("%s by rule(id: %s, name: %s, priority: %d) of %s", rule.Action, rule.ID,
+		rule.Name, rule.Priority, rule.SecurityGroupID)
+}
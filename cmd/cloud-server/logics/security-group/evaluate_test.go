@@ -0,0 +1,251 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"net"
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func newOption(ruleType enumor.SecurityGroupRuleType, protocol string, port int64, ip string) *EvaluateOption {
+	return &EvaluateOption{MatchOption: MatchOption{Type: ruleType, Protocol: protocol, Port: port,
+		IP: net.ParseIP(ip)}}
+}
+
+func TestEvaluateTCloud(t *testing.T) {
+	groups := []GroupRules{
+		{
+			SecurityGroupID: "sg-1",
+			Rules: []Rule{
+				ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: "r2", CloudPolicyIndex: 1, Type: enumor.Ingress,
+					Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("ALL"),
+					IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "ACCEPT", SecurityGroupID: "sg-1"}),
+				ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: "r1", CloudPolicyIndex: 0, Type: enumor.Ingress,
+					Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr("22,3389"),
+					IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "DROP", SecurityGroupID: "sg-1"}),
+			},
+		},
+	}
+
+	cases := []struct {
+		opt     *EvaluateOption
+		allowed bool
+		ruleID  string
+	}{
+		{newOption(enumor.Ingress, "tcp", 22, "1.1.1.1"), false, "r1"},
+		{newOption(enumor.Ingress, "tcp", 443, "1.1.1.1"), true, "r2"},
+		{newOption(enumor.Ingress, "udp", 53, "1.1.1.1"), false, ""},
+		{newOption(enumor.Egress, "tcp", 443, "1.1.1.1"), false, ""},
+	}
+
+	for idx, c := range cases {
+		result, err := Evaluate(enumor.TCloud, groups, c.opt)
+		if err != nil {
+			t.Fatalf("case %d evaluate failed, err: %v", idx, err)
+		}
+
+		if result.Allowed != c.allowed {
+			t.Errorf("case %d got allowed %v, expect %v", idx, result.Allowed, c.allowed)
+		}
+
+		ruleID := ""
+		if result.DecidedBy != nil {
+			ruleID = result.DecidedBy.ID
+		}
+		if ruleID != c.ruleID {
+			t.Errorf("case %d got rule %s, expect %s", idx, ruleID, c.ruleID)
+		}
+	}
+}
+
+func TestEvaluateHuaWeiDenyFirst(t *testing.T) {
+	groups := []GroupRules{
+		{SecurityGroupID: "sg-1", Rules: []Rule{ConvHuaWeiRule(corecloud.HuaWeiSecurityGroupRule{ID: "allow",
+			Priority: 1, Type: enumor.Ingress, Protocol: "tcp", Port: "443", RemoteIPPrefix: "10.0.0.0/8",
+			Action: "allow"})}},
+		{SecurityGroupID: "sg-2", Rules: []Rule{ConvHuaWeiRule(corecloud.HuaWeiSecurityGroupRule{ID: "deny",
+			Priority: 1, Type: enumor.Ingress, Protocol: "", Action: "deny"})}},
+	}
+
+	result, err := Evaluate(enumor.HuaWei, groups, newOption(enumor.Ingress, "tcp", 443, "10.1.1.1"))
+	if err != nil {
+		t.Fatalf("evaluate failed, err: %v", err)
+	}
+
+	if result.Allowed || result.DecidedBy == nil || result.DecidedBy.ID != "deny" {
+		t.Errorf("deny rule should take effect first with same priority, got: %+v", result)
+	}
+}
+
+func TestEvaluateAzure(t *testing.T) {
+	groups := []GroupRules{
+		{SecurityGroupID: "subnet-sg", Scope: enumor.SubnetCloudResType, Rules: []Rule{
+			ConvAzureRule(corecloud.AzureSecurityGroupRule{ID: "allow-https", Priority: 100, Type: enumor.Ingress,
+				Protocol: "Tcp", DestinationPortRange: converter.ValToPtr("443"),
+				SourceAddressPrefix: converter.ValToPtr("Internet"), Access: "Allow"}),
+		}},
+		{SecurityGroupID: "nic-sg", Scope: enumor.NetworkInterfaceCloudResType},
+	}
+
+	// 网络接口安全组只有默认规则，公网访问会被 DenyAllInBound 拒绝
+	result, err := Evaluate(enumor.Azure, groups, newOption(enumor.Ingress, "tcp", 443, "1.1.1.1"))
+	if err != nil {
+		t.Fatalf("evaluate failed, err: %v", err)
+	}
+
+	if result.Allowed || result.DecidedBy == nil || result.DecidedBy.Name != "DenyAllInBound" ||
+		result.DecidedBy.SecurityGroupID != "nic-sg" {
+		t.Errorf("traffic should be denied by nic default rule, got: %+v", result)
+	}
+}
+
+func TestEvaluateGcp(t *testing.T) {
+	groups := []GroupRules{
+		{SecurityGroupID: "vpc-1", Rules: ConvGcpFirewallRule(corecloud.GcpFirewallRule{ID: "fw-1", Priority: 1000,
+			Type: "INGRESS", SourceRanges: []string{"0.0.0.0/0"}, TargetTags: []string{"web"},
+			Allowed: []corecloud.GcpProtocolSet{{Protocol: "tcp", Port: []string{"80", "8000-9000"}}}})},
+	}
+
+	opt := newOption(enumor.Ingress, "tcp", 8080, "1.1.1.1")
+	result, err := Evaluate(enumor.Gcp, groups, opt)
+	if err != nil {
+		t.Fatalf("evaluate failed, err: %v", err)
+	}
+	if result.Allowed {
+		t.Errorf("instance without target tag should be denied by implied rule, got: %+v", result)
+	}
+
+	opt.NetworkTags = []string{"web"}
+	result, err = Evaluate(enumor.Gcp, groups, opt)
+	if err != nil {
+		t.Fatalf("evaluate failed, err: %v", err)
+	}
+	if !result.Allowed || result.DecidedBy == nil || result.DecidedBy.ID != "fw-1" {
+		t.Errorf("instance with target tag should be allowed by fw-1, got: %+v", result)
+	}
+}
+
+func TestAddressMatchedCrossFamily(t *testing.T) {
+	cases := []struct {
+		addresses []string
+		ip        string
+		matched   bool
+	}{
+		{[]string{"0.0.0.0/0"}, "1.1.1.1", true},
+		{[]string{"0.0.0.0/0"}, "2001:db8::1", false},
+		{[]string{"::/0"}, "2001:db8::1", true},
+		{[]string{"::/0"}, "1.1.1.1", false},
+		{[]string{"::/0", "0.0.0.0/0"}, "1.1.1.1", true},
+		{[]string{"*"}, "2001:db8::1", true},
+		{[]string{"any"}, "1.1.1.1", true},
+		{[]string{"2001:db8::/32"}, "1.1.1.1", false},
+		{[]string{"10.0.0.0/8"}, "::ffff:10.1.1.1", true},
+	}
+
+	for idx, c := range cases {
+		if got := addressMatched(c.addresses, net.ParseIP(c.ip)); got != c.matched {
+			t.Errorf("case %d addresses %v ip %s got matched %v, expect %v", idx, c.addresses, c.ip, got, c.matched)
+		}
+	}
+}
+
+func TestEvaluateCrossFamily(t *testing.T) {
+	cases := []struct {
+		vendor  enumor.Vendor
+		groups  []GroupRules
+		ip      string
+		allowed bool
+		ruleID  string
+	}{
+		{
+			// ipv6 的拒绝规则优先级更高，但不影响 ipv4 流量
+			vendor: enumor.TCloud,
+			groups: []GroupRules{{SecurityGroupID: "sg-1", Rules: []Rule{
+				ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: "deny-v6", CloudPolicyIndex: 0,
+					Type: enumor.Ingress, Protocol: converter.ValToPtr("ALL"), Port: converter.ValToPtr("ALL"),
+					IPv6Cidr: converter.ValToPtr("::/0"), Action: "DROP", SecurityGroupID: "sg-1"}),
+				ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: "allow-v4", CloudPolicyIndex: 1,
+					Type: enumor.Ingress, Protocol: converter.ValToPtr("ALL"), Port: converter.ValToPtr("ALL"),
+					IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "ACCEPT", SecurityGroupID: "sg-1"}),
+			}}},
+			ip:      "1.1.1.1",
+			allowed: true,
+			ruleID:  "allow-v4",
+		},
+		{
+			vendor: enumor.TCloud,
+			groups: []GroupRules{{SecurityGroupID: "sg-1", Rules: []Rule{
+				ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: "deny-v6", CloudPolicyIndex: 0,
+					Type: enumor.Ingress, Protocol: converter.ValToPtr("ALL"), Port: converter.ValToPtr("ALL"),
+					IPv6Cidr: converter.ValToPtr("::/0"), Action: "DROP", SecurityGroupID: "sg-1"}),
+				ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: "allow-v4", CloudPolicyIndex: 1,
+					Type: enumor.Ingress, Protocol: converter.ValToPtr("ALL"), Port: converter.ValToPtr("ALL"),
+					IPv4Cidr: converter.ValToPtr("0.0.0.0/0"), Action: "ACCEPT", SecurityGroupID: "sg-1"}),
+			}}},
+			ip:      "2001:db8::1",
+			allowed: false,
+			ruleID:  "deny-v6",
+		},
+		{
+			// 华为云未指定远端的 ipv6 放通规则不会放通 ipv4 流量
+			vendor: enumor.HuaWei,
+			groups: []GroupRules{{SecurityGroupID: "sg-1", Rules: []Rule{
+				ConvHuaWeiRule(corecloud.HuaWeiSecurityGroupRule{ID: "allow-v6", Priority: 1, Type: enumor.Ingress,
+					Ethertype: "IPv6", Action: "allow"}),
+			}}},
+			ip:      "1.1.1.1",
+			allowed: false,
+			ruleID:  "",
+		},
+		{
+			vendor: enumor.HuaWei,
+			groups: []GroupRules{{SecurityGroupID: "sg-1", Rules: []Rule{
+				ConvHuaWeiRule(corecloud.HuaWeiSecurityGroupRule{ID: "allow-v6", Priority: 1, Type: enumor.Ingress,
+					Ethertype: "IPv6", Action: "allow"}),
+			}}},
+			ip:      "2001:db8::1",
+			allowed: true,
+			ruleID:  "allow-v6",
+		},
+	}
+
+	for idx, c := range cases {
+		result, err := Evaluate(c.vendor, c.groups, newOption(enumor.Ingress, "tcp", 443, c.ip))
+		if err != nil {
+			t.Fatalf("case %d evaluate failed, err: %v", idx, err)
+		}
+
+		if result.Allowed != c.allowed {
+			t.Errorf("case %d got allowed %v, expect %v", idx, result.Allowed, c.allowed)
+		}
+
+		ruleID := ""
+		if result.DecidedBy != nil {
+			ruleID = result.DecidedBy.ID
+		}
+		if ruleID != c.ruleID {
+			t.Errorf("case %d got rule %s, expect %s", idx, ruleID, c.ruleID)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+)
+
+// AdminPorts 管理端口，对全网开放时视为高风险。
+var AdminPorts = []int64{22, 3389, 3306}
+
+// lintRisk 检查项对应的风险等级及风险分。
+var lintRisk = map[enumor.SGLintType]struct {
+	level enumor.RiskLevel
+	score uint64
+}{
+	enumor.SGLintOpenAdminPort: {level: enumor.RiskLevelHigh, score: 10},
+	enumor.SGLintShadowedRule:  {level: enumor.RiskLevelMedium, score: 3},
+	enumor.SGLintUnusedGroup:   {level: enumor.RiskLevelLow, score: 2},
+	enumor.SGLintDuplicateRule: {level: enumor.RiskLevelLow, score: 1},
+}
+
+// LintRiskScore return risk level and score of lint type.
+func LintRiskScore(lintType enumor.SGLintType) (enumor.RiskLevel, uint64) {
+	risk, exists := lintRisk[lintType]
+	if !exists {
+		return enumor.RiskLevelLow, 0
+	}
+
+	return risk.level, risk.score
+}
+
+// LintFinding 规则检查结果，安全组级别的检查项 Rule 为空。
+type LintFinding struct {
+	Rule      *Rule
+	LintType  enumor.SGLintType
+	RiskLevel enumor.RiskLevel
+	RiskScore uint64
+	Detail    corecloud.SGLintDetail
+}
+
+func newLintFinding(rule *Rule, lintType enumor.SGLintType, detail corecloud.SGLintDetail) LintFinding {
+	level, score := LintRiskScore(lintType)
+	return LintFinding{
+		Rule:      rule,
+		LintType:  lintType,
+		RiskLevel: level,
+		RiskScore: score,
+		Detail:    detail,
+	}
+}
+
+func ruleDetail(rule *Rule, message string) corecloud.SGLintDetail {
+	return corecloud.SGLintDetail{
+		Message:   message,
+		Protocol:  rule.Protocol,
+		Ports:     rule.Ports,
+		Addresses: rule.Addresses,
+	}
+}
+
+// LintRules 检查单个安全组内的规则，包括管理端口对全网开放、规则被覆盖以及规则重复。
+// 规则需按照云厂商的生效顺序评估，aws 安全组规则只有放行规则，与顺序无关。
+func LintRules(vendor enumor.Vendor, rules []Rule) []LintFinding {
+	findings := make([]LintFinding, 0)
+	for idx := range rules {
+		if ports := openAdminPorts(&rules[idx]); len(ports) != 0 {
+			findings = append(findings, newLintFinding(&rules[idx], enumor.SGLintOpenAdminPort,
+				ruleDetail(&rules[idx], fmt.Sprintf("admin port %s is open to all addresses", joinPorts(ports)))))
+		}
+	}
+
+	ordered := lintOrder(vendor, rules)
+	seen := make(map[string]*Rule)
+	for idx, rule := range ordered {
+		if len(rule.Unresolved) != 0 {
+			continue
+		}
+
+		key := ruleKey(rule)
+		if first, exists := seen[key]; exists {
+			detail := ruleDetail(rule, fmt.Sprintf("rule is duplicate with rule %s", first.ID))
+			detail.RelatedRuleID = first.ID
+			findings = append(findings, newLintFinding(rule, enumor.SGLintDuplicateRule, detail))
+			continue
+		}
+		seen[key] = rule
+
+		candidates := ordered[:idx]
+		if vendor == enumor.Aws {
+			candidates = ordered
+		}
+		for _, prev := range candidates {
+			if prev == rule || len(prev.Unresolved) != 0 || ruleKey(prev) == key || !ruleCovers(prev, rule) {
+				continue
+			}
+
+			detail := ruleDetail(rule, fmt.Sprintf("rule is shadowed by rule %s and never takes effect", prev.ID))
+			detail.RelatedRuleID = prev.ID
+			findings = append(findings, newLintFinding(rule, enumor.SGLintShadowedRule, detail))
+			break
+		}
+	}
+
+	return findings
+}
+
+// lintOrder return rules in the order they take effect, huawei and gcp evaluate deny rule first with same priority.
+func lintOrder(vendor enumor.Vendor, rules []Rule) []*Rule {
+	ordered := make([]*Rule, 0, len(rules))
+	for idx := range rules {
+		ordered = append(ordered, &rules[idx])
+	}
+
+	denyFirst := vendor == enumor.HuaWei || vendor == enumor.Gcp
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+
+		if denyFirst {
+			return ordered[i].Action == enumor.SGPolicyDeny && ordered[j].Action != enumor.SGPolicyDeny
+		}
+
+		return false
+	})
+
+	return ordered
+}
+
+// openAdminPorts return admin ports which the ingress allow rule opens to all addresses.
+func openAdminPorts(rule *Rule) []int64 {
+	if len(rule.Unresolved) != 0 || rule.Type != enumor.Ingress || rule.Action != enumor.SGPolicyAllow {
+		return nil
+	}
+
+	anyAddress := false
+	for _, address := range rule.Addresses {
+		if isAnyAddress(address) || address == "Internet" {
+			anyAddress = true
+			break
+		}
+	}
+	if !anyAddress || !protocolMatched(rule.Protocol, "tcp") {
+		return nil
+	}
+
+	ports := make([]int64, 0)
+	for _, port := range AdminPorts {
+		if portMatched(rule.Ports, port) {
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+func joinPorts(ports []int64) string {
+	parts := make([]string, 0, len(ports))
+	for _, port := range ports {
+		parts = append(parts, fmt.Sprintf("%d", port))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ruleKey 规则的归一化标识，协议、端口、地址、动作及生效实例相同的规则视为重复规则。
+func ruleKey(rule *Rule) string {
+	protocol := normalizeProtocol(rule.Protocol)
+	ports := "all"
+	if isPortProtocol(protocol) && !portsAll(rule.Ports) {
+		ports = strings.Join(sortedCopy(splitPorts(rule.Ports)), ",")
+	}
+
+	return strings.Join([]string{string(rule.Type), protocol, ports,
+		strings.Join(sortedCopy(normalizeAddresses(rule.Addresses)), ","), string(rule.Action),
+		strings.Join(sortedCopy(rule.TargetTags), ",")}, "|")
+}
+
+func sortedCopy(values []string) []string {
+	sorted := append(make([]string, 0, len(values)), values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func splitPorts(ports []string) []string {
+	result := make([]string, 0, len(ports))
+	for _, one := range ports {
+		for _, part := range strings.Split(one, ",") {
+			result = append(result, strings.TrimSpace(part))
+		}
+	}
+	return result
+}
+
+func portsAll(ports []string) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, port := range splitPorts(ports) {
+		if isAllPort(port) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeAddresses(addresses []string) []string {
+	result := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if isAnyAddress(address) {
+			result = append(result, "any")
+			continue
+		}
+
+		if ipNet, ok := parseAddress(address); ok {
+			result = append(result, ipNet.String())
+			continue
+		}
+		result = append(result, address)
+	}
+	return result
+}
+
+// ruleCovers judge whether all traffic matched by rule is also matched by prev.
+func ruleCovers(prev, rule *Rule) bool {
+	if prev.Type != rule.Type {
+		return false
+	}
+
+	protocol := normalizeProtocol(rule.Protocol)
+	if !protocolMatched(prev.Protocol, protocol) {
+		return false
+	}
+
+	if isPortProtocol(protocol) && !portsCovered(prev.Ports, rule.Ports) {
+		return false
+	}
+
+	if !addressesCovered(prev.Addresses, rule.Addresses) {
+		return false
+	}
+
+	return tagsCovered(prev.TargetTags, rule.TargetTags)
+}
+
+// portsCovered judge whether every port range of ports is contained by some range of prevPorts.
+func portsCovered(prevPorts, ports []string) bool {
+	if portsAll(prevPorts) {
+		return true
+	}
+
+	if portsAll(ports) {
+		return false
+	}
+
+	for _, port := range splitPorts(ports) {
+		from, to, ok := parsePortRange(port)
+		if !ok {
+			return false
+		}
+
+		covered := false
+		for _, prev := range splitPorts(prevPorts) {
+			prevFrom, prevTo, ok := parsePortRange(prev)
+			if ok && prevFrom <= from && to <= prevTo {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addressesCovered judge whether every address is contained by some address of prevAddresses.
+func addressesCovered(prevAddresses, addresses []string) bool {
+	if len(addresses) == 0 {
+		return false
+	}
+
+	for _, address := range addresses {
+		if !addressCovered(prevAddresses, address) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func addressCovered(prevAddresses []string, address string) bool {
+	ipNet, ok := parseAddress(address)
+	for _, prev := range prevAddresses {
+		if isAnyAddress(prev) || prev == address {
+			return true
+		}
+
+		if !ok || isAnyAddress(address) {
+			continue
+		}
+
+		prevNet, prevOk := parseAddress(prev)
+		if !prevOk || !prevNet.Contains(ipNet.IP) {
+			continue
+		}
+
+		prevOnes, prevBits := prevNet.Mask.Size()
+		ones, bits := ipNet.Mask.Size()
+		if prevBits == bits && prevOnes <= ones {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tagsCovered gcp 防火墙规则未指定目标标记时对 vpc 下所有实例生效。
+func tagsCovered(prevTags, tags []string) bool {
+	if len(prevTags) == 0 {
+		return true
+	}
+
+	if len(tags) == 0 {
+		return false
+	}
+
+	for _, tag := range tags {
+		found := false
+		for _, prev := range prevTags {
+			if prev == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,262 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"fmt"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataservice "hcm/pkg/api/data-service"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/slice"
+)
+
+// LintAccount 检查账号下所有安全组的规则风险，并使用本次检查结果替换该账号已有的检查结果，返回检查结果数量。
+func (sg *securityGroup) LintAccount(kt *kit.Kit, vendor enumor.Vendor, accountID string) (int, error) {
+	switch vendor {
+	case enumor.TCloud, enumor.Aws, enumor.HuaWei, enumor.Azure:
+	default:
+		return 0, errf.Newf(errf.InvalidParameter, "vendor: %s not support security group lint", vendor)
+	}
+
+	findings := make([]dataproto.SGLintFindingCreate, 0)
+	listReq := &dataproto.SecurityGroupListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("vendor", vendor),
+			tools.RuleEqual("account_id", accountID),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := sg.client.DataService().Global.SecurityGroup.ListSecurityGroup(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list security group failed, err: %v, account: %s, rid: %s", err, accountID, kt.Rid)
+			return 0, err
+		}
+
+		for _, group := range result.Details {
+			groupFindings, err := sg.lintSecurityGroup(kt, group)
+			if err != nil {
+				return 0, err
+			}
+			findings = append(findings, groupFindings...)
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	delReq := &dataservice.BatchDeleteReq{Filter: tools.EqualExpression("account_id", accountID)}
+	if err := sg.client.DataService().Global.SGLintFinding.BatchDelete(kt, delReq); err != nil {
+		logs.Errorf("delete security group lint findings failed, err: %v, account: %s, rid: %s", err, accountID,
+			kt.Rid)
+		return 0, err
+	}
+
+	for _, batch := range slice.Split(findings, constant.BatchOperationMaxLimit) {
+		createReq := &dataproto.SGLintFindingBatchCreateReq{Findings: batch}
+		if _, err := sg.client.DataService().Global.SGLintFinding.BatchCreate(kt, createReq); err != nil {
+			logs.Errorf("create security group lint findings failed, err: %v, account: %s, rid: %s", err,
+				accountID, kt.Rid)
+			return 0, err
+		}
+	}
+
+	return len(findings), nil
+}
+
+func (sg *securityGroup) lintSecurityGroup(kt *kit.Kit, group corecloud.BaseSecurityGroup) (
+	[]dataproto.SGLintFindingCreate, error) {
+
+	rules, err := sg.ListSGRules(kt, group.Vendor, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lintFindings := LintRules(group.Vendor, rules)
+
+	used, err := sg.IsSecurityGroupUsed(kt, group.Vendor, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		lintFindings = append(lintFindings, newLintFinding(nil, enumor.SGLintUnusedGroup, corecloud.SGLintDetail{
+			Message: "security group is not associated with any resource",
+		}))
+	}
+
+	findings := make([]dataproto.SGLintFindingCreate, 0, len(lintFindings))
+	for idx := range lintFindings {
+		one := lintFindings[idx]
+		finding := dataproto.SGLintFindingCreate{
+			Vendor:               group.Vendor,
+			AccountID:            group.AccountID,
+			BkBizID:              group.BkBizID,
+			Region:               group.Region,
+			SecurityGroupID:      group.ID,
+			CloudSecurityGroupID: group.CloudID,
+			LintType:             one.LintType,
+			RiskLevel:            one.RiskLevel,
+			RiskScore:            one.RiskScore,
+			Detail:               &one.Detail,
+		}
+		if one.Rule != nil {
+			finding.RuleID = one.Rule.ID
+			finding.RuleType = string(one.Rule.Type)
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings, nil
+}
+
+// IsSecurityGroupUsed 安全组关联了主机、负载均衡等资源，azure 安全组还可能绑定到子网及网络接口。
+func (sg *securityGroup) IsSecurityGroupUsed(kt *kit.Kit, vendor enumor.Vendor, sgID string) (bool, error) {
+	countReq := &core.ListReq{
+		Filter: tools.EqualExpression("security_group_id", sgID),
+		Page:   core.NewCountPage(),
+	}
+	cvmRel, err := sg.client.DataService().Global.SGCvmRel.List(kt.Ctx, kt.Header(), countReq)
+	if err != nil {
+		logs.Errorf("count security group cvm rel failed, err: %v, sg: %s, rid: %s", err, sgID, kt.Rid)
+		return false, err
+	}
+	if cvmRel.Count != 0 {
+		return true, nil
+	}
+
+	commonRel, err := sg.client.DataService().Global.SGCommonRel.List(kt, countReq)
+	if err != nil {
+		logs.Errorf("count security group common rel failed, err: %v, sg: %s, rid: %s", err, sgID, kt.Rid)
+		return false, err
+	}
+	if commonRel.Count != 0 {
+		return true, nil
+	}
+
+	if vendor != enumor.Azure {
+		return false, nil
+	}
+
+	extReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				&filter.AtomRule{Field: "extension.security_group_id", Op: filter.JSONEqual.Factory(),
+					Value: sgID},
+			},
+		},
+		Page: core.NewCountPage(),
+	}
+	subnet, err := sg.client.DataService().Global.Subnet.List(kt.Ctx, kt.Header(), extReq)
+	if err != nil {
+		logs.Errorf("count azure subnet failed, err: %v, sg: %s, rid: %s", err, sgID, kt.Rid)
+		return false, err
+	}
+	if subnet.Count != 0 {
+		return true, nil
+	}
+
+	ni, err := sg.client.DataService().Global.NetworkInterface.List(kt, extReq)
+	if err != nil {
+		logs.Errorf("count azure network interface failed, err: %v, sg: %s, rid: %s", err, sgID, kt.Rid)
+		return false, err
+	}
+
+	return ni.Count != 0, nil
+}
+
+// LintSummary 汇总业务下安全组规则检查结果，生成业务风险报告。
+func (sg *securityGroup) LintSummary(kt *kit.Kit, bizID int64) (*cloudserver.SGLintSummary, error) {
+	summary := &cloudserver.SGLintSummary{
+		BkBizID:    bizID,
+		LintCount:  make(map[enumor.SGLintType]uint64),
+		LevelCount: make(map[enumor.RiskLevel]uint64),
+	}
+
+	for _, lintType := range []enumor.SGLintType{enumor.SGLintOpenAdminPort, enumor.SGLintShadowedRule,
+		enumor.SGLintDuplicateRule, enumor.SGLintUnusedGroup} {
+
+		listReq := &core.ListReq{
+			Filter: tools.ExpressionAnd(
+				tools.RuleEqual("bk_biz_id", bizID),
+				tools.RuleEqual("lint_type", lintType),
+			),
+			Page: core.NewCountPage(),
+		}
+		result, err := sg.client.DataService().Global.SGLintFinding.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("count security group lint finding failed, err: %v, biz: %d, type: %s, rid: %s", err,
+				bizID, lintType, kt.Rid)
+			return nil, err
+		}
+
+		level, score := LintRiskScore(lintType)
+		summary.LintCount[lintType] = result.Count
+		summary.LevelCount[level] += result.Count
+		summary.RiskScore += result.Count * score
+	}
+
+	sgIDs, err := sg.listLintSecurityGroupIDs(kt, bizID)
+	if err != nil {
+		return nil, err
+	}
+	summary.LintSGCount = uint64(len(sgIDs))
+
+	return summary, nil
+}
+
+func (sg *securityGroup) listLintSecurityGroupIDs(kt *kit.Kit, bizID int64) ([]string, error) {
+	sgIDs := make([]string, 0)
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("bk_biz_id", bizID),
+		Fields: []string{"security_group_id"},
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		result, err := sg.client.DataService().Global.SGLintFinding.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list security group lint finding failed, err: %v, biz: %d, rid: %s", err, bizID, kt.Rid)
+			return nil, fmt.Errorf("list security group lint finding failed, err: %v", err)
+		}
+
+		for _, one := range result.Details {
+			sgIDs = append(sgIDs, one.SecurityGroupID)
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return slice.Unique(sgIDs), nil
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+func newTCloudRule(id string, index int64, port, cidr, action string) Rule {
+	return ConvTCloudRule(corecloud.TCloudSecurityGroupRule{ID: id, CloudPolicyIndex: index, Type: enumor.Ingress,
+		Protocol: converter.ValToPtr("TCP"), Port: converter.ValToPtr(port), IPv4Cidr: converter.ValToPtr(cidr),
+		Action: action, SecurityGroupID: "sg-1"})
+}
+
+func TestLintRules(t *testing.T) {
+	rules := []Rule{
+		newTCloudRule("open", 0, "22,8080", "0.0.0.0/0", "ACCEPT"),
+		newTCloudRule("wide", 1, "8000-9000", "10.0.0.0/8", "ACCEPT"),
+		newTCloudRule("shadowed", 2, "8080", "10.1.0.0/16", "DROP"),
+		newTCloudRule("duplicate", 3, "8000-9000", "10.0.0.0/8", "ACCEPT"),
+		newTCloudRule("private", 4, "3306", "192.168.0.0/16", "ACCEPT"),
+	}
+
+	expects := map[string]enumor.SGLintType{
+		"open":      enumor.SGLintOpenAdminPort,
+		"shadowed":  enumor.SGLintShadowedRule,
+		"duplicate": enumor.SGLintDuplicateRule,
+	}
+
+	findings := LintRules(enumor.TCloud, rules)
+	if len(findings) != len(expects) {
+		t.Fatalf("got %d findings, expect %d, findings: %+v", len(findings), len(expects), findings)
+	}
+
+	for _, one := range findings {
+		if expects[one.Rule.ID] != one.LintType {
+			t.Errorf("rule %s got lint type %s, expect %s", one.Rule.ID, one.LintType, expects[one.Rule.ID])
+		}
+	}
+
+	if findings[0].RiskLevel != enumor.RiskLevelHigh || findings[0].Detail.Message == "" {
+		t.Errorf("open admin port should be high risk, got: %+v", findings[0])
+	}
+}
+
+func TestLintRulesAwsOrderIndependent(t *testing.T) {
+	rules := []Rule{
+		ConvAwsRule(corecloud.AwsSecurityGroupRule{ID: "narrow", Type: enumor.Ingress, Protocol: converter.ValToPtr("tcp"),
+			FromPort: converter.ValToPtr(int64(443)), ToPort: converter.ValToPtr(int64(443)),
+			IPv4Cidr: converter.ValToPtr("10.0.0.0/24")}),
+		ConvAwsRule(corecloud.AwsSecurityGroupRule{ID: "wide", Type: enumor.Ingress, Protocol: converter.ValToPtr("-1"),
+			IPv4Cidr: converter.ValToPtr("10.0.0.0/8")}),
+	}
+
+	findings := LintRules(enumor.Aws, rules)
+	if len(findings) != 1 || findings[0].Rule.ID != "narrow" || findings[0].Detail.RelatedRuleID != "wide" {
+		t.Errorf("narrow rule should be shadowed by wide rule, got: %+v", findings)
+	}
+}
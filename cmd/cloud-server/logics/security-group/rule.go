@@ -0,0 +1,547 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tools/converter"
+)
+
+// Rule 归一化后的安全组规则，屏蔽各云厂商规则字段差异，用于规则评估及分析。
+type Rule struct {
+	cloudserver.SGPolicyRule
+	// TargetTags gcp 防火墙规则生效的实例网络标记，为空时对 vpc 下所有实例生效
+	TargetTags []string
+	// Unresolved 规则引用了安全组、地址组、参数模版等无法在本地展开的对象时，记录其原因
+	Unresolved string
+}
+
+// MatchOption define traffic to match with rule.
+type MatchOption struct {
+	Type     enumor.SecurityGroupRuleType
+	Protocol string
+	Port     int64
+	IP       net.IP
+}
+
+// Match judge whether the traffic hit the rule.
+func (r *Rule) Match(opt *MatchOption) bool {
+	if len(r.Unresolved) != 0 || r.Type != opt.Type {
+		return false
+	}
+
+	if !protocolMatched(r.Protocol, normalizeProtocol(opt.Protocol)) {
+		return false
+	}
+
+	if isPortProtocol(opt.Protocol) && !portMatched(r.Ports, opt.Port) {
+		return false
+	}
+
+	return addressMatched(r.Addresses, opt.IP)
+}
+
+const protocolAll = "all"
+
+// normalizeProtocol 各云厂商协议的表示方式不同，如 aws 使用 -1、azure 使用 * 表示全部协议，统一转换为小写协议名。
+func normalizeProtocol(protocol string) string {
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	switch protocol {
+	case "", "-1", "*", "any", protocolAll:
+		return protocolAll
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "1":
+		return "icmp"
+	case "58", "ipv6-icmp":
+		return "icmpv6"
+	default:
+		return protocol
+	}
+}
+
+func isPortProtocol(protocol string) bool {
+	protocol = normalizeProtocol(protocol)
+	return protocol == "tcp" || protocol == "udp"
+}
+
+func protocolMatched(ruleProtocol, protocol string) bool {
+	ruleProtocol = normalizeProtocol(ruleProtocol)
+	if ruleProtocol == protocolAll {
+		return true
+	}
+
+	return ruleProtocol == protocol
+}
+
+// isAllPort judge whether the port expression means all ports.
+func isAllPort(port string) bool {
+	switch strings.ToLower(strings.TrimSpace(port)) {
+	case "", "all", "*", "-1", "0-65535", "1-65535", "-1--1":
+		return true
+	default:
+		return false
+	}
+}
+
+// portMatched port expression supports single port(80), range(80-90) and list(80,443).
+func portMatched(ports []string, port int64) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, one := range ports {
+		for _, part := range strings.Split(one, ",") {
+			if isAllPort(part) {
+				return true
+			}
+
+			from, to, ok := parsePortRange(part)
+			if ok && port >= from && port <= to {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func parsePortRange(port string) (int64, int64, bool) {
+	port = strings.TrimSpace(port)
+	bounds := strings.SplitN(port, "-", 2)
+
+	from, err := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if len(bounds) == 1 {
+		return from, from, true
+	}
+
+	to, err := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return from, to, true
+}
+
+// azureLoadBalancerIP azure 负载均衡健康探测使用的虚拟 ip
+var azureLoadBalancerIP = net.ParseIP("168.63.129.16")
+
+// isAnyAddress judge whether the address means all addresses.
+func isAnyAddress(address string) bool {
+	switch strings.ToLower(strings.TrimSpace(address)) {
+	case "*", "any", "0.0.0.0/0", "::/0":
+		return true
+	default:
+		return false
+	}
+}
+
+// anyAddressMatched judge whether the any address covers the ip, "0.0.0.0/0" only covers ipv4
+// and "::/0" only covers ipv6.
+func anyAddressMatched(address string, ip net.IP) bool {
+	switch strings.ToLower(strings.TrimSpace(address)) {
+	case "*", "any":
+		return true
+	case "0.0.0.0/0":
+		return ip.To4() != nil
+	case "::/0":
+		return ip.To4() == nil
+	default:
+		return false
+	}
+}
+
+// isAddressResolvable judge whether the address can be evaluated locally.
+func isAddressResolvable(address string) bool {
+	if isAnyAddress(address) {
+		return true
+	}
+
+	switch address {
+	case "Internet", "VirtualNetwork", "AzureLoadBalancer":
+		return true
+	}
+
+	_, ok := parseAddress(address)
+	return ok
+}
+
+func parseAddress(address string) (*net.IPNet, bool) {
+	address = strings.TrimSpace(address)
+	if !strings.Contains(address, "/") {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			return nil, false
+		}
+
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, true
+	}
+
+	_, ipNet, err := net.ParseCIDR(address)
+	if err != nil {
+		return nil, false
+	}
+
+	return ipNet, true
+}
+
+// addressMatched 地址支持 ip、cidr 以及 azure 的服务标记。
+// azure 的 VirtualNetwork、Internet 服务标记按私有地址、公网地址近似计算。
+func addressMatched(addresses []string, ip net.IP) bool {
+	for _, one := range addresses {
+		if isAnyAddress(one) {
+			if anyAddressMatched(one, ip) {
+				return true
+			}
+			continue
+		}
+
+		switch one {
+		case "Internet":
+			if !ip.IsPrivate() && !ip.IsLoopback() {
+				return true
+			}
+			continue
+		case "VirtualNetwork":
+			if ip.IsPrivate() {
+				return true
+			}
+			continue
+		case "AzureLoadBalancer":
+			if ip.Equal(azureLoadBalancerIP) {
+				return true
+			}
+			continue
+		}
+
+		ipNet, ok := parseAddress(one)
+		if ok && ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConvTCloudRule convert tcloud security group rule to Rule.
+func ConvTCloudRule(one corecloud.TCloudSecurityGroupRule) Rule {
+	rule := Rule{
+		SGPolicyRule: cloudserver.SGPolicyRule{
+			Vendor:               enumor.TCloud,
+			ID:                   one.ID,
+			CloudID:              strconv.FormatInt(one.CloudPolicyIndex, 10),
+			SecurityGroupID:      one.SecurityGroupID,
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			Type:                 one.Type,
+			Priority:             one.CloudPolicyIndex,
+			Protocol:             converter.PtrToVal(one.Protocol),
+			Ports:                []string{converter.PtrToVal(one.Port)},
+			Addresses:            make([]string, 0),
+			Action:               enumor.SGPolicyDeny,
+			Memo:                 converter.PtrToVal(one.Memo),
+		},
+	}
+
+	if strings.ToUpper(one.Action) == "ACCEPT" {
+		rule.Action = enumor.SGPolicyAllow
+	}
+
+	if len(converter.PtrToVal(one.IPv4Cidr)) != 0 {
+		rule.Addresses = append(rule.Addresses, *one.IPv4Cidr)
+	}
+	if len(converter.PtrToVal(one.IPv6Cidr)) != 0 {
+		rule.Addresses = append(rule.Addresses, *one.IPv6Cidr)
+	}
+
+	switch {
+	case len(converter.PtrToVal(one.CloudServiceID)) != 0 || len(converter.PtrToVal(one.CloudServiceGroupID)) != 0:
+		rule.Unresolved = "rule references protocol port template"
+	case len(converter.PtrToVal(one.CloudTargetSecurityGroupID)) != 0:
+		rule.Unresolved = "rule references security group"
+	case len(converter.PtrToVal(one.CloudAddressID)) != 0 || len(converter.PtrToVal(one.CloudAddressGroupID)) != 0:
+		rule.Unresolved = "rule references address template"
+	}
+
+	return rule
+}
+
+// ConvAwsRule convert aws security group rule to Rule, aws security group rules only support allow.
+func ConvAwsRule(one corecloud.AwsSecurityGroupRule) Rule {
+	rule := Rule{
+		SGPolicyRule: cloudserver.SGPolicyRule{
+			Vendor:               enumor.Aws,
+			ID:                   one.ID,
+			CloudID:              one.CloudID,
+			SecurityGroupID:      one.SecurityGroupID,
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			Type:                 one.Type,
+			Protocol:             converter.PtrToVal(one.Protocol),
+			Ports:                []string{},
+			Addresses:            make([]string, 0),
+			Action:               enumor.SGPolicyAllow,
+			Memo:                 converter.PtrToVal(one.Memo),
+		},
+	}
+
+	if isPortProtocol(rule.Protocol) && one.FromPort != nil && one.ToPort != nil && *one.FromPort != -1 {
+		rule.Ports = []string{strconv.FormatInt(*one.FromPort, 10) + "-" + strconv.FormatInt(*one.ToPort, 10)}
+	}
+
+	if len(converter.PtrToVal(one.IPv4Cidr)) != 0 {
+		rule.Addresses = append(rule.Addresses, *one.IPv4Cidr)
+	}
+	if len(converter.PtrToVal(one.IPv6Cidr)) != 0 {
+		rule.Addresses = append(rule.Addresses, *one.IPv6Cidr)
+	}
+
+	switch {
+	case len(converter.PtrToVal(one.CloudTargetSecurityGroupID)) != 0:
+		rule.Unresolved = "rule references security group"
+	case len(converter.PtrToVal(one.CloudPrefixListID)) != 0:
+		rule.Unresolved = "rule references prefix list"
+	}
+
+	return rule
+}
+
+// ConvHuaWeiRule convert huawei security group rule to Rule.
+func ConvHuaWeiRule(one corecloud.HuaWeiSecurityGroupRule) Rule {
+	rule := Rule{
+		SGPolicyRule: cloudserver.SGPolicyRule{
+			Vendor:               enumor.HuaWei,
+			ID:                   one.ID,
+			CloudID:              one.CloudID,
+			SecurityGroupID:      one.SecurityGroupID,
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			Type:                 one.Type,
+			Priority:             one.Priority,
+			Protocol:             one.Protocol,
+			Ports:                []string{one.Port},
+			Addresses:            make([]string, 0),
+			Action:               enumor.SGPolicyDeny,
+			Memo:                 converter.PtrToVal(one.Memo),
+		},
+	}
+
+	if strings.ToLower(one.Action) == "allow" {
+		rule.Action = enumor.SGPolicyAllow
+	}
+
+	switch {
+	case len(one.RemoteIPPrefix) != 0:
+		rule.Addresses = append(rule.Addresses, one.RemoteIPPrefix)
+	case len(one.CloudRemoteGroupID) != 0:
+		rule.Unresolved = "rule references security group"
+	case len(one.CloudRemoteAddressGroupID) != 0:
+		rule.Unresolved = "rule references address group"
+	default:
+		// 华为云未指定远端时表示所有地址
+		if strings.ToLower(one.Ethertype) == "ipv6" {
+			rule.Addresses = append(rule.Addresses, "::/0")
+		} else {
+			rule.Addresses = append(rule.Addresses, "0.0.0.0/0")
+		}
+	}
+
+	return rule
+}
+
+// ConvAzureRule convert azure security group rule to Rule.
+func ConvAzureRule(one corecloud.AzureSecurityGroupRule) Rule {
+	rule := Rule{
+		SGPolicyRule: cloudserver.SGPolicyRule{
+			Vendor:               enumor.Azure,
+			ID:                   one.ID,
+			CloudID:              one.CloudID,
+			Name:                 one.Name,
+			SecurityGroupID:      one.SecurityGroupID,
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			Type:                 one.Type,
+			Priority:             int64(one.Priority),
+			Protocol:             one.Protocol,
+			Ports:                azurePorts(one.DestinationPortRange, one.DestinationPortRanges),
+			Action:               enumor.SGPolicyDeny,
+			Memo:                 converter.PtrToVal(one.Memo),
+		},
+	}
+
+	if strings.ToLower(one.Access) == "allow" {
+		rule.Action = enumor.SGPolicyAllow
+	}
+
+	// 入站规则评估访问来源，出站规则评估访问目的
+	peerAsgIDs := one.CloudSourceAppSecurityGroupIDs
+	rule.Addresses = azureAddresses(one.SourceAddressPrefix, one.SourceAddressPrefixes)
+	if one.Type == enumor.Egress {
+		peerAsgIDs = one.CloudDestinationAppSecurityGroupIDs
+		rule.Addresses = azureAddresses(one.DestinationAddressPrefix, one.DestinationAddressPrefixes)
+	}
+
+	if len(rule.Addresses) == 0 && len(peerAsgIDs) != 0 {
+		rule.Unresolved = "rule references application security group"
+	}
+	markUnresolvedAddress(&rule)
+
+	return rule
+}
+
+// ConvAzureDefaultRule convert azure default security group rule to Rule.
+func ConvAzureDefaultRule(sgID string, one AzureDefaultSGRule) Rule {
+	rule := Rule{
+		SGPolicyRule: cloudserver.SGPolicyRule{
+			Vendor:          enumor.Azure,
+			Name:            one.Name,
+			SecurityGroupID: sgID,
+			Type:            one.Type,
+			Priority:        int64(one.Priority),
+			Protocol:        one.Protocol,
+			Ports:           azurePorts(one.DestinationPortRange, one.DestinationPortRanges),
+			Addresses:       azureAddresses(one.SourceAddressPrefix, one.SourceAddressPrefixes),
+			Action:          enumor.SGPolicyDeny,
+			Memo:            converter.PtrToVal(one.Memo),
+		},
+	}
+
+	if one.Type == enumor.Egress {
+		rule.Addresses = azureAddresses(one.DestinationAddressPrefix, one.DestinationAddressPrefixes)
+	}
+
+	if strings.ToLower(one.Access) == "allow" {
+		rule.Action = enumor.SGPolicyAllow
+	}
+
+	return rule
+}
+
+func azurePorts(port *string, ports []*string) []string {
+	result := make([]string, 0, len(ports)+1)
+	if len(converter.PtrToVal(port)) != 0 {
+		result = append(result, *port)
+	}
+
+	for _, one := range ports {
+		if len(converter.PtrToVal(one)) != 0 {
+			result = append(result, *one)
+		}
+	}
+
+	return result
+}
+
+func azureAddresses(address *string, addresses []*string) []string {
+	result := make([]string, 0, len(addresses)+1)
+	if len(converter.PtrToVal(address)) != 0 {
+		result = append(result, *address)
+	}
+
+	for _, one := range addresses {
+		if len(converter.PtrToVal(one)) != 0 {
+			result = append(result, *one)
+		}
+	}
+
+	return result
+}
+
+// ConvGcpFirewallRule convert gcp firewall rule to Rules, every protocol set of firewall rule is converted to one
+// Rule. disabled firewall rule is not effective, so nil is returned.
+func ConvGcpFirewallRule(one corecloud.GcpFirewallRule) []Rule {
+	if one.Disabled {
+		return nil
+	}
+
+	ruleType := enumor.Ingress
+	addresses := one.SourceRanges
+	if strings.ToUpper(one.Type) == "EGRESS" {
+		ruleType = enumor.Egress
+		addresses = one.DestinationRanges
+	}
+
+	unresolved := ""
+	switch {
+	case len(one.TargetServiceAccounts) != 0:
+		unresolved = "rule targets service account"
+	case len(addresses) == 0 && (len(one.SourceTags) != 0 || len(one.SourceServiceAccounts) != 0):
+		unresolved = "rule references source tag or service account"
+	}
+
+	base := cloudserver.SGPolicyRule{
+		Vendor:          enumor.Gcp,
+		ID:              one.ID,
+		CloudID:         one.CloudID,
+		Name:            one.Name,
+		SecurityGroupID: one.VpcId,
+		Type:            ruleType,
+		Priority:        one.Priority,
+		Addresses:       addresses,
+		Memo:            one.Memo,
+	}
+
+	rules := make([]Rule, 0, len(one.Allowed)+len(one.Denied))
+	for _, set := range one.Allowed {
+		rule := Rule{SGPolicyRule: base, TargetTags: one.TargetTags, Unresolved: unresolved}
+		rule.Protocol = set.Protocol
+		rule.Ports = set.Port
+		rule.Action = enumor.SGPolicyAllow
+		markUnresolvedAddress(&rule)
+		rules = append(rules, rule)
+	}
+
+	for _, set := range one.Denied {
+		rule := Rule{SGPolicyRule: base, TargetTags: one.TargetTags, Unresolved: unresolved}
+		rule.Protocol = set.Protocol
+		rule.Ports = set.Port
+		rule.Action = enumor.SGPolicyDeny
+		markUnresolvedAddress(&rule)
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func markUnresolvedAddress(rule *Rule) {
+	if len(rule.Unresolved) != 0 {
+		return
+	}
+
+	for _, address := range rule.Addresses {
+		if !isAddressResolvable(address) {
+			rule.Unresolved = "rule references unsupported address: " + address
+			return
+		}
+	}
+}
@@ -0,0 +1,331 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package securitygroup ...
+package securitygroup
+
+import (
+	"fmt"
+	"net"
+
+	cloudserver "hcm/pkg/api/cloud-server"
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// Interface define security group logics interface.
+type Interface interface {
+	EvaluateCvmPolicy(kt *kit.Kit, vendor enumor.Vendor, cvmID string, req *cloudserver.SGPolicyEvaluateReq) (
+		*cloudserver.SGPolicyEvaluateResult, error)
+	ListSGRules(kt *kit.Kit, vendor enumor.Vendor, sgID string) ([]Rule, error)
+	LintAccount(kt *kit.Kit, vendor enumor.Vendor, accountID string) (int, error)
+	LintSummary(kt *kit.Kit, bizID int64) (*cloudserver.SGLintSummary, error)
+	IsSecurityGroupUsed(kt *kit.Kit, vendor enumor.Vendor, sgID string) (bool, error)
+}
+
+type securityGroup struct {
+	client *client.ClientSet
+}
+
+// NewSecurityGroup new security group logics.
+func NewSecurityGroup(client *client.ClientSet) Interface {
+	return &securityGroup{
+		client: client,
+	}
+}
+
+// EvaluateCvmPolicy 评估 cvm 上生效的安全组（gcp 为防火墙规则）是否放行指定流量，并返回决定结果的规则。
+func (sg *securityGroup) EvaluateCvmPolicy(kt *kit.Kit, vendor enumor.Vendor, cvmID string,
+	req *cloudserver.SGPolicyEvaluateReq) (*cloudserver.SGPolicyEvaluateResult, error) {
+
+	opt := &EvaluateOption{
+		MatchOption: MatchOption{
+			Type:     req.Type,
+			Protocol: req.Protocol,
+			Port:     req.Port,
+			IP:       net.ParseIP(req.Address),
+		},
+	}
+
+	var groups []GroupRules
+	var err error
+	switch vendor {
+	case enumor.TCloud, enumor.Aws, enumor.HuaWei:
+		groups, err = sg.listCvmGroupRules(kt, vendor, cvmID)
+	case enumor.Azure:
+		groups, err = sg.listAzureCvmGroupRules(kt, cvmID)
+	case enumor.Gcp:
+		groups, opt.NetworkTags, err = sg.listGcpCvmFirewallRules(kt, cvmID)
+	default:
+		return nil, errf.Newf(errf.InvalidParameter, "vendor: %s not support", vendor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return Evaluate(vendor, groups, opt)
+}
+
+// listCvmGroupRules list security groups bound to cvm and their rules, tcloud security groups are sorted by the
+// order of cvm bound.
+func (sg *securityGroup) listCvmGroupRules(kt *kit.Kit, vendor enumor.Vendor, cvmID string) ([]GroupRules, error) {
+	listReq := &dataproto.SGCvmRelWithSecurityGroupListReq{CvmIDs: []string{cvmID}}
+	rels, err := sg.client.DataService().Global.SGCvmRel.ListWithSecurityGroup(kt.Ctx, kt.Header(), listReq)
+	if err != nil {
+		logs.Errorf("list security group by cvm failed, err: %v, cvmID: %s, rid: %s", err, cvmID, kt.Rid)
+		return nil, err
+	}
+
+	if vendor == enumor.TCloud {
+		cvm, err := sg.client.DataService().TCloud.Cvm.GetCvm(kt.Ctx, kt.Header(), cvmID)
+		if err != nil {
+			logs.Errorf("get tcloud cvm failed, err: %v, cvmID: %s, rid: %s", err, cvmID, kt.Rid)
+			return nil, err
+		}
+
+		rels = sortByCloudIDs(rels, cvm.Extension.CloudSecurityGroupIDs)
+	}
+
+	groups := make([]GroupRules, 0, len(rels))
+	for _, rel := range rels {
+		rules, err := sg.ListSGRules(kt, vendor, rel.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, GroupRules{SecurityGroupID: rel.ID, Rules: rules})
+	}
+
+	return groups, nil
+}
+
+// sortByCloudIDs sort security groups by cloud ids, security groups not in cloud ids are put at the end.
+func sortByCloudIDs(rels []corecloud.SGCvmRelWithBaseSecurityGroup,
+	cloudIDs []string) []corecloud.SGCvmRelWithBaseSecurityGroup {
+
+	relMap := make(map[string]corecloud.SGCvmRelWithBaseSecurityGroup, len(rels))
+	for _, rel := range rels {
+		relMap[rel.CloudID] = rel
+	}
+
+	sorted := make([]corecloud.SGCvmRelWithBaseSecurityGroup, 0, len(rels))
+	for _, cloudID := range cloudIDs {
+		if rel, exist := relMap[cloudID]; exist {
+			sorted = append(sorted, rel)
+			delete(relMap, cloudID)
+		}
+	}
+
+	for _, rel := range rels {
+		if _, exist := relMap[rel.CloudID]; exist {
+			sorted = append(sorted, rel)
+		}
+	}
+
+	return sorted
+}
+
+// listAzureCvmGroupRules azure 安全组绑定在子网和网络接口上，分别查询并记录绑定位置。
+func (sg *securityGroup) listAzureCvmGroupRules(kt *kit.Kit, cvmID string) ([]GroupRules, error) {
+	cvm, err := sg.client.DataService().Azure.Cvm.GetCvm(kt.Ctx, kt.Header(), cvmID)
+	if err != nil {
+		logs.Errorf("get azure cvm failed, err: %v, cvmID: %s, rid: %s", err, cvmID, kt.Rid)
+		return nil, err
+	}
+
+	groups := make([]GroupRules, 0)
+	if len(cvm.SubnetIDs) != 0 {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("id", cvm.SubnetIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		subnets, err := sg.client.DataService().Azure.Subnet.ListSubnetExt(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list subnet failed, err: %v, subnetIDs: %v, rid: %s", err, cvm.SubnetIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range subnets.Details {
+			if one.Extension == nil || len(one.Extension.SecurityGroupID) == 0 {
+				continue
+			}
+			groups = append(groups, GroupRules{SecurityGroupID: one.Extension.SecurityGroupID,
+				Scope: enumor.SubnetCloudResType})
+		}
+	}
+
+	if cvm.Extension != nil && len(cvm.Extension.CloudNetworkInterfaceIDs) != 0 {
+		listReq := &core.ListReq{
+			Filter: tools.ContainersExpression("cloud_id", cvm.Extension.CloudNetworkInterfaceIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		nis, err := sg.client.DataService().Azure.NetworkInterface.ListNetworkInterfaceExt(kt.Ctx, kt.Header(),
+			listReq)
+		if err != nil {
+			logs.Errorf("list network interface failed, err: %v, cloudIDs: %v, rid: %s", err,
+				cvm.Extension.CloudNetworkInterfaceIDs, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range nis.Details {
+			if one.Extension == nil || len(converter.PtrToVal(one.Extension.SecurityGroupID)) == 0 {
+				continue
+			}
+			groups = append(groups, GroupRules{SecurityGroupID: *one.Extension.SecurityGroupID,
+				Scope: enumor.NetworkInterfaceCloudResType})
+		}
+	}
+
+	for idx := range groups {
+		rules, err := sg.ListSGRules(kt, enumor.Azure, groups[idx].SecurityGroupID)
+		if err != nil {
+			return nil, err
+		}
+		groups[idx].Rules = rules
+	}
+
+	return groups, nil
+}
+
+// listGcpCvmFirewallRules gcp 防火墙规则作用于 vpc，查询 cvm 所在 vpc 下的所有防火墙规则，并返回 cvm 的网络标记。
+func (sg *securityGroup) listGcpCvmFirewallRules(kt *kit.Kit, cvmID string) ([]GroupRules, []string, error) {
+	cvm, err := sg.client.DataService().Gcp.Cvm.GetCvm(kt.Ctx, kt.Header(), cvmID)
+	if err != nil {
+		logs.Errorf("get gcp cvm failed, err: %v, cvmID: %s, rid: %s", err, cvmID, kt.Rid)
+		return nil, nil, err
+	}
+
+	networkTags := make([]string, 0)
+	if cvm.Extension != nil {
+		networkTags = cvm.Extension.NetworkTags
+	}
+
+	groups := make([]GroupRules, 0, len(cvm.VpcIDs))
+	for _, vpcID := range slice.Unique(cvm.VpcIDs) {
+		rules, err := sg.listGcpFirewallRules(kt, vpcID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		groups = append(groups, GroupRules{SecurityGroupID: vpcID, Rules: rules})
+	}
+
+	return groups, networkTags, nil
+}
+
+func (sg *securityGroup) listGcpFirewallRules(kt *kit.Kit, vpcID string) ([]Rule, error) {
+	listReq := &dataproto.GcpFirewallRuleListReq{
+		Filter: tools.EqualExpression("vpc_id", vpcID),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	rules := make([]Rule, 0)
+	for {
+		result, err := sg.client.DataService().Gcp.Firewall.ListFirewallRule(kt.Ctx, kt.Header(), listReq)
+		if err != nil {
+			logs.Errorf("list gcp firewall rule failed, err: %v, vpcID: %s, rid: %s", err, vpcID, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range result.Details {
+			rules = append(rules, ConvGcpFirewallRule(one)...)
+		}
+
+		if len(result.Details) < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return rules, nil
+}
+
+// ListSGRules list all rules of security group and convert them to Rule.
+func (sg *securityGroup) ListSGRules(kt *kit.Kit, vendor enumor.Vendor, sgID string) ([]Rule, error) {
+	rules := make([]Rule, 0)
+	page := core.NewDefaultBasePage()
+	for {
+		var count int
+		var err error
+		filter := tools.EqualExpression("security_group_id", sgID)
+
+		switch vendor {
+		case enumor.TCloud:
+			var result *dataproto.TCloudSGRuleListResult
+			result, err = sg.client.DataService().TCloud.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&dataproto.TCloudSGRuleListReq{Filter: filter, Page: page}, sgID)
+			if err == nil {
+				count = len(result.Details)
+				rules = append(rules, slice.Map(result.Details, ConvTCloudRule)...)
+			}
+
+		case enumor.Aws:
+			var result *dataproto.AwsSGRuleListResult
+			result, err = sg.client.DataService().Aws.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&dataproto.AwsSGRuleListReq{Filter: filter, Page: page}, sgID)
+			if err == nil {
+				count = len(result.Details)
+				rules = append(rules, slice.Map(result.Details, ConvAwsRule)...)
+			}
+
+		case enumor.HuaWei:
+			var result *dataproto.HuaWeiSGRuleListResult
+			result, err = sg.client.DataService().HuaWei.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&dataproto.HuaWeiSGRuleListReq{Filter: filter, Page: page}, sgID)
+			if err == nil {
+				count = len(result.Details)
+				rules = append(rules, slice.Map(result.Details, ConvHuaWeiRule)...)
+			}
+
+		case enumor.Azure:
+			var result *dataproto.AzureSGRuleListResult
+			result, err = sg.client.DataService().Azure.SecurityGroup.ListSecurityGroupRule(kt.Ctx, kt.Header(),
+				&dataproto.AzureSGRuleListReq{Filter: filter, Page: page}, sgID)
+			if err == nil {
+				count = len(result.Details)
+				rules = append(rules, slice.Map(result.Details, ConvAzureRule)...)
+			}
+
+		default:
+			return nil, fmt.Errorf("vendor: %s not support list security group rule", vendor)
+		}
+		if err != nil {
+			logs.Errorf("list %s security group rule failed, err: %v, sgID: %s, rid: %s", vendor, err, sgID, kt.Rid)
+			return nil, err
+		}
+
+		if count < int(core.DefaultMaxPageLimit) {
+			break
+		}
+
+		page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	return rules, nil
+}
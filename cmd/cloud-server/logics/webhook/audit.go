@@ -0,0 +1,197 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"hcm/cmd/cloud-server/logics/audit"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+)
+
+// AuditEventData audit webhook event data.
+type AuditEventData struct {
+	ResType           enumor.AuditResourceType `json:"res_type"`
+	Action            enumor.AuditAction       `json:"action"`
+	ResIDs            []string                 `json:"res_ids"`
+	ParentID          string                   `json:"parent_id,omitempty"`
+	BkBizID           int64                    `json:"bk_biz_id,omitempty"`
+	UpdateFields      map[string]interface{}   `json:"update_fields,omitempty"`
+	AssociatedResType enumor.AuditResourceType `json:"associated_res_type,omitempty"`
+	AssociatedResID   string                   `json:"associated_res_id,omitempty"`
+}
+
+var _ audit.Interface = new(auditPublisher)
+
+// NewAudit wrap audit interface, publish audit webhook event after audit is recorded successfully.
+func NewAudit(a audit.Interface, p Publisher) audit.Interface {
+	return &auditPublisher{
+		Interface: a,
+		publisher: p,
+	}
+}
+
+type auditPublisher struct {
+	audit.Interface
+	publisher Publisher
+}
+
+func (a *auditPublisher) publish(kt *kit.Kit, data *AuditEventData) {
+	a.publisher.Publish(kt, enumor.NewAuditWebhookEventType(data.ResType, data.Action), data)
+}
+
+// ResDeleteAudit resource delete audit.
+func (a *auditPublisher) ResDeleteAudit(kt *kit.Kit, resType enumor.AuditResourceType, ids []string) error {
+	if err := a.Interface.ResDeleteAudit(kt, resType, ids); err != nil {
+		return err
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Delete, ResIDs: ids})
+	return nil
+}
+
+// ChildResDeleteAudit child resource delete audit.
+func (a *auditPublisher) ChildResDeleteAudit(kt *kit.Kit, resType enumor.AuditResourceType, parentID string,
+	ids []string) error {
+
+	if err := a.Interface.ChildResDeleteAudit(kt, resType, parentID, ids); err != nil {
+		return err
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Delete, ResIDs: ids, ParentID: parentID})
+	return nil
+}
+
+// ResUpdateAudit resource update audit.
+func (a *auditPublisher) ResUpdateAudit(kt *kit.Kit, resType enumor.AuditResourceType, id string,
+	updateFields map[string]interface{}) error {
+
+	if err := a.Interface.ResUpdateAudit(kt, resType, id, updateFields); err != nil {
+		return err
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Update, ResIDs: []string{id},
+		UpdateFields: updateFields})
+	return nil
+}
+
+// ChildResUpdateAudit child resource update audit.
+func (a *auditPublisher) ChildResUpdateAudit(kt *kit.Kit, resType enumor.AuditResourceType, parentID, id string,
+	updateFields map[string]interface{}) error {
+
+	if err := a.Interface.ChildResUpdateAudit(kt, resType, parentID, id, updateFields); err != nil {
+		return err
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Update, ResIDs: []string{id},
+		ParentID: parentID, UpdateFields: updateFields})
+	return nil
+}
+
+// ResBizAssignAudit resource assign to biz audit.
+func (a *auditPublisher) ResBizAssignAudit(kt *kit.Kit, resType enumor.AuditResourceType, resIDs []string,
+	bizID int64) error {
+
+	if err := a.Interface.ResBizAssignAudit(kt, resType, resIDs, bizID); err != nil {
+		return err
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Assign, ResIDs: resIDs, BkBizID: bizID})
+	return nil
+}
+
+// ResDeliverAudit resource deliver to biz audit.
+func (a *auditPublisher) ResDeliverAudit(kt *kit.Kit, resType enumor.AuditResourceType, resIDs []string,
+	bizID int64) error {
+
+	if err := a.Interface.ResDeliverAudit(kt, resType, resIDs, bizID); err != nil {
+		return err
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Deliver, ResIDs: resIDs, BkBizID: bizID})
+	return nil
+}
+
+// ResCloudAreaBindAudit resource bind cloud area audit.
+func (a *auditPublisher) ResCloudAreaBindAudit(kt *kit.Kit, resType enumor.AuditResourceType,
+	opt []audit.ResCloudAreaBindOption) error {
+
+	if err := a.Interface.ResCloudAreaBindAudit(kt, resType, opt); err != nil {
+		return err
+	}
+
+	ids := slice.Map(opt, func(one audit.ResCloudAreaBindOption) string { return one.ResID })
+	a.publish(kt, &AuditEventData{ResType: resType, Action: enumor.Assign, ResIDs: ids})
+	return nil
+}
+
+// ResBaseOperationAudit resource base operation audit.
+func (a *auditPublisher) ResBaseOperationAudit(kt *kit.Kit, resType enumor.AuditResourceType,
+	action protoaudit.OperationAction, ids []string) error {
+
+	if err := a.Interface.ResBaseOperationAudit(kt, resType, action, ids); err != nil {
+		return err
+	}
+
+	auditAction, err := action.ConvAuditAction()
+	if err != nil {
+		logs.Errorf("convert operation action to audit action failed, err: %v, rid: %s", err, kt.Rid)
+		return nil
+	}
+
+	a.publish(kt, &AuditEventData{ResType: resType, Action: auditAction, ResIDs: ids})
+	return nil
+}
+
+// ResOperationAudit resource operation audit.
+func (a *auditPublisher) ResOperationAudit(kt *kit.Kit, info protoaudit.CloudResourceOperationInfo) error {
+	if err := a.Interface.ResOperationAudit(kt, info); err != nil {
+		return err
+	}
+
+	auditAction, err := info.Action.ConvAuditAction()
+	if err != nil {
+		logs.Errorf("convert operation action to audit action failed, err: %v, rid: %s", err, kt.Rid)
+		return nil
+	}
+
+	a.publish(kt, &AuditEventData{ResType: info.ResType, Action: auditAction, ResIDs: []string{info.ResID},
+		AssociatedResType: info.AssociatedResType, AssociatedResID: info.AssociatedResID})
+	return nil
+}
+
+// ResRecycleAudit resource recycle/recover audit.
+func (a *auditPublisher) ResRecycleAudit(kt *kit.Kit, req *protoaudit.CloudResourceRecycleAuditReq) error {
+	if err := a.Interface.ResRecycleAudit(kt, req); err != nil {
+		return err
+	}
+
+	auditAction, err := req.Action.ConvAuditAction()
+	if err != nil {
+		logs.Errorf("convert recycle action to audit action failed, err: %v, rid: %s", err, kt.Rid)
+		return nil
+	}
+
+	ids := slice.Map(req.Infos, func(one protoaudit.CloudResRecycleAuditInfo) string { return one.ResID })
+	a.publish(kt, &AuditEventData{ResType: req.ResType, Action: auditAction, ResIDs: ids})
+	return nil
+}
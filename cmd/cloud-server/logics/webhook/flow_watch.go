@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"time"
+
+	"hcm/pkg/api/core"
+	coreasync "hcm/pkg/api/core/async"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/times"
+)
+
+// flowWatchInterval 扫描执行结束的异步任务流的间隔
+const flowWatchInterval = 30 * time.Second
+
+// FlowCompleteEventData async flow complete webhook event data.
+type FlowCompleteEventData struct {
+	ID        string             `json:"id"`
+	Name      enumor.FlowName    `json:"name"`
+	State     enumor.FlowState   `json:"state"`
+	Reason    *tableasync.Reason `json:"reason,omitempty"`
+	Memo      string             `json:"memo"`
+	Creator   string             `json:"creator"`
+	UpdatedAt string             `json:"updated_at"`
+}
+
+// WatchFlowComplete 定时扫描执行结束的异步任务流，发布任务流执行结束事件，仅主节点执行。
+// 投递webhook的任务流本身不发布事件，避免事件循环。
+func WatchFlowComplete(c *client.ClientSet, state serviced.State, p Publisher) {
+	w := &flowWatcher{client: c, publisher: p}
+	for {
+		time.Sleep(flowWatchInterval)
+
+		if !state.IsMaster() {
+			// 切换为主节点后从当前时间开始扫描，不补发历史事件
+			w.reset()
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		if err := w.watchOnce(kt); err != nil {
+			logs.Errorf("watch async flow complete failed, err: %v, rid: %s", err, kt.Rid)
+		}
+	}
+}
+
+type flowWatcher struct {
+	client    *client.ClientSet
+	publisher Publisher
+	// checkpoint 已发布事件的任务流最大更新时间
+	checkpoint string
+	// published checkpoint 时刻已发布事件的任务流，避免同一秒内的任务流重复发布
+	published map[string]struct{}
+}
+
+func (w *flowWatcher) reset() {
+	w.checkpoint = ""
+	w.published = nil
+}
+
+func (w *flowWatcher) watchOnce(kt *kit.Kit) error {
+	if len(w.checkpoint) == 0 {
+		w.checkpoint = times.ConvStdTimeFormat(time.Now())
+		w.published = make(map[string]struct{})
+		return nil
+	}
+
+	listReq := &core.ListReq{
+		Filter: &filter.Expression{
+			Op: filter.And,
+			Rules: []filter.RuleFactory{
+				filter.AtomRule{Field: "state", Op: filter.In.Factory(),
+					Value: []enumor.FlowState{enumor.FlowSuccess, enumor.FlowFailed, enumor.FlowCancel}},
+				filter.AtomRule{Field: "name", Op: filter.NotEqual.Factory(), Value: enumor.FlowWebhookDeliver},
+				filter.AtomRule{Field: "updated_at", Op: filter.GreaterThanEqual.Factory(), Value: w.checkpoint},
+			},
+		},
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "updated_at", Order: core.Ascending},
+	}
+
+	for {
+		result, err := w.client.TaskServer().ListFlow(kt, listReq)
+		if err != nil {
+			logs.Errorf("list complete async flow failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, flow := range result.Details {
+			w.publish(kt, flow)
+		}
+
+		if uint(len(result.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return nil
+}
+
+func (w *flowWatcher) publish(kt *kit.Kit, flow coreasync.AsyncFlow) {
+	if _, exists := w.published[flow.ID]; exists && flow.UpdatedAt == w.checkpoint {
+		return
+	}
+
+	if flow.UpdatedAt > w.checkpoint {
+		w.checkpoint = flow.UpdatedAt
+		w.published = make(map[string]struct{})
+	}
+	w.published[flow.ID] = struct{}{}
+
+	w.publisher.Publish(kt, enumor.WebhookEventAsyncFlowComplete, &FlowCompleteEventData{
+		ID:        flow.ID,
+		Name:      flow.Name,
+		State:     flow.State,
+		Reason:    flow.Reason,
+		Memo:      flow.Memo,
+		Creator:   flow.Creator,
+		UpdatedAt: flow.UpdatedAt,
+	})
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// HeaderEvent 事件类型请求头
+	HeaderEvent = "X-HCM-Event"
+	// HeaderEventID 事件ID请求头
+	HeaderEventID = "X-HCM-Event-ID"
+	// HeaderDelivery 投递ID请求头，重试时保持不变，接收方可用于去重
+	HeaderDelivery = "X-HCM-Delivery"
+	// HeaderTimestamp 签名时间戳请求头，单位: 秒
+	HeaderTimestamp = "X-HCM-Timestamp"
+	// HeaderSignature 签名请求头，格式为 sha256={hex}
+	HeaderSignature = "X-HCM-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// BuildHeaders build webhook deliver request headers, signature is only set when secret is not empty.
+func BuildHeaders(event *Event, deliveryID, timestamp, secret, payload string) map[string]string {
+	headers := map[string]string{
+		HeaderEvent:     string(event.Type),
+		HeaderEventID:   event.ID,
+		HeaderDelivery:  deliveryID,
+		HeaderTimestamp: timestamp,
+	}
+
+	if len(secret) != 0 {
+		headers[HeaderSignature] = Sign(secret, timestamp, payload)
+	}
+
+	return headers
+}
+
+// Sign 使用订阅密钥对 "{timestamp}.{payload}" 计算 HMAC-SHA256 签名，接收方使用相同方式校验
+func Sign(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
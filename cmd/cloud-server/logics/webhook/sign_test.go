@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestSign(t *testing.T) {
+	got := Sign("secret", "1700000000", `{"id":"1"}`)
+	expect := "sha256=086f6aff7bd084c98679825129c5a64dbad88c760016d6d2c0fb123f27951d54"
+	if got != expect {
+		t.Errorf("got signature %s, expect %s", got, expect)
+	}
+
+	event := &Event{ID: "event-1", Type: enumor.WebhookEventRecycleExpiry}
+	headers := BuildHeaders(event, "delivery-1", "1700000000", "", `{"id":"1"}`)
+	if _, exists := headers[HeaderSignature]; exists {
+		t.Errorf("signature header should not be set without secret")
+	}
+}
+
+func TestEventTypeMatch(t *testing.T) {
+	cvmDelete := enumor.NewAuditWebhookEventType(enumor.CvmAuditResType, enumor.Delete)
+
+	cases := []struct {
+		subscribed enumor.WebhookEventType
+		event      enumor.WebhookEventType
+		expect     bool
+	}{
+		{subscribed: enumor.WebhookEventAll, event: cvmDelete, expect: true},
+		{subscribed: "audit.*", event: cvmDelete, expect: true},
+		{subscribed: "audit.cvm.*", event: cvmDelete, expect: true},
+		{subscribed: "audit.disk.*", event: cvmDelete, expect: false},
+		{subscribed: cvmDelete, event: cvmDelete, expect: true},
+		{subscribed: "audit.*", event: enumor.WebhookEventApplicationStatus, expect: false},
+	}
+
+	for _, c := range cases {
+		if got := c.subscribed.Match(c.event); got != c.expect {
+			t.Errorf("%s match %s got %v, expect %v", c.subscribed, c.event, got, c.expect)
+		}
+	}
+}
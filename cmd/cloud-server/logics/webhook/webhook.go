@@ -0,0 +1,290 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook 提供webhook事件发布能力，将资源审计、申请单、异步任务、回收站等事件投递到订阅方
+package webhook
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	actionwebhook "hcm/cmd/task-server/logics/action/webhook"
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	protowebhook "hcm/pkg/api/data-service/webhook"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	tableasync "hcm/pkg/dal/table/async"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/json"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+	"hcm/pkg/tools/uuid"
+)
+
+const (
+	// eventQueueSize 待投递事件队列长度，队列满时丢弃事件
+	eventQueueSize = 1000
+	// subscriptionCacheTTL 订阅列表缓存时长
+	subscriptionCacheTTL = 30 * time.Second
+	// deliverAttempts 单个投递的最大投递次数
+	deliverAttempts = 5
+)
+
+// Event webhook event.
+type Event struct {
+	// ID 事件ID，同一事件投递到多个订阅时相同
+	ID   string                  `json:"id"`
+	Type enumor.WebhookEventType `json:"type"`
+	// OccurredAt 事件发生时间
+	OccurredAt string `json:"occurred_at"`
+	// Operator 触发事件的操作人
+	Operator string `json:"operator"`
+	// Rid 触发事件的请求ID
+	Rid  string      `json:"rid"`
+	Data interface{} `json:"data"`
+}
+
+// Publisher define webhook event publisher.
+type Publisher interface {
+	// Publish 异步发布事件，不阻塞调用方，投递结果记录在投递记录中
+	Publish(kt *kit.Kit, eventType enumor.WebhookEventType, data interface{})
+}
+
+var _ Publisher = new(publisher)
+
+// NewPublisher new webhook publisher, cipher is used to decrypt subscription secret.
+func NewPublisher(c *client.ClientSet, cipher cryptography.Crypto) Publisher {
+	p := &publisher{
+		client: c,
+		cipher: cipher,
+		events: make(chan *Event, eventQueueSize),
+	}
+	go p.run()
+
+	return p
+}
+
+type publisher struct {
+	client *client.ClientSet
+	cipher cryptography.Crypto
+	events chan *Event
+
+	cacheLock     sync.Mutex
+	subscriptions []corewebhook.Subscription
+	cachedAt      time.Time
+}
+
+// Publish event.
+func (p *publisher) Publish(kt *kit.Kit, eventType enumor.WebhookEventType, data interface{}) {
+	event := &Event{
+		ID:         uuid.UUID(),
+		Type:       eventType,
+		OccurredAt: times.ConvStdTimeFormat(time.Now()),
+		Operator:   kt.User,
+		Rid:        kt.Rid,
+		Data:       data,
+	}
+
+	select {
+	case p.events <- event:
+	default:
+		logs.Errorf("webhook event queue is full, drop event: %s, type: %s, rid: %s", event.ID, eventType, kt.Rid)
+	}
+}
+
+func (p *publisher) run() {
+	for event := range p.events {
+		kt := core.NewBackendKit()
+		if err := p.dispatch(kt, event); err != nil {
+			logs.Errorf("dispatch webhook event failed, err: %v, event: %s, type: %s, event rid: %s, rid: %s", err,
+				event.ID, event.Type, event.Rid, kt.Rid)
+		}
+	}
+}
+
+// dispatch 为匹配的订阅创建投递记录，并创建异步任务流进行投递
+func (p *publisher) dispatch(kt *kit.Kit, event *Event) error {
+	subs, err := p.listMatchedSubscriptions(kt, event.Type)
+	if err != nil {
+		return err
+	}
+
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, batch := range slice.Split(subs, constant.BatchOperationMaxLimit) {
+		if err = p.deliver(kt, event, string(payload), batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *publisher) deliver(kt *kit.Kit, event *Event, payload string, subs []corewebhook.Subscription) error {
+	createReq := &protowebhook.DeliveryBatchCreateReq{
+		Deliveries: slice.Map(subs, func(sub corewebhook.Subscription) protowebhook.DeliveryCreate {
+			return protowebhook.DeliveryCreate{
+				SubscriptionID: sub.ID,
+				EventType:      event.Type,
+				EventID:        event.ID,
+				Payload:        types.JsonField(payload),
+				State:          enumor.WebhookDeliveryPending,
+			}
+		}),
+	}
+	result, err := p.client.DataService().Global.Webhook.BatchCreateDelivery(kt, createReq)
+	if err != nil {
+		logs.Errorf("create webhook delivery failed, err: %v, event: %s, rid: %s", err, event.ID, kt.Rid)
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	tasks := make([]ts.CustomFlowTask, 0, len(subs))
+	for idx, sub := range subs {
+		secret, err := p.decryptSecret(sub.Secret)
+		if err != nil {
+			logs.Errorf("decrypt webhook subscription secret failed, err: %v, id: %s, rid: %s", err, sub.ID, kt.Rid)
+			return err
+		}
+
+		tasks = append(tasks, ts.CustomFlowTask{
+			ActionID:   action.ActIDType(strconv.Itoa(idx + 1)),
+			ActionName: enumor.ActionWebhookDeliver,
+			Params: &actionwebhook.DeliverOption{
+				DeliveryID:  result.IDs[idx],
+				URL:         sub.URL,
+				Headers:     BuildHeaders(event, result.IDs[idx], timestamp, secret, payload),
+				Payload:     payload,
+				MaxAttempts: deliverAttempts,
+			},
+			Retry: tableasync.NewRetryWithPolicy(deliverAttempts, 2000, 5000),
+		})
+	}
+
+	flowReq := &ts.AddCustomFlowReq{
+		Name:  enumor.FlowWebhookDeliver,
+		Memo:  string(event.Type),
+		Tasks: tasks,
+	}
+	flowResult, flowErr := p.client.TaskServer().CreateCustomFlow(kt, flowReq)
+
+	updateReq := &protowebhook.DeliveryBatchUpdateReq{
+		Deliveries: make([]protowebhook.DeliveryUpdate, 0, len(result.IDs)),
+	}
+	for _, id := range result.IDs {
+		update := protowebhook.DeliveryUpdate{ID: id}
+		if flowErr != nil {
+			update.State = enumor.WebhookDeliveryFailed
+			update.Response = converter.ValToPtr("create deliver flow failed, err: " + flowErr.Error())
+		} else {
+			update.FlowID = flowResult.ID
+		}
+		updateReq.Deliveries = append(updateReq.Deliveries, update)
+	}
+	if err = p.client.DataService().Global.Webhook.BatchUpdateDelivery(kt, updateReq); err != nil {
+		logs.Errorf("update webhook delivery flow failed, err: %v, event: %s, rid: %s", err, event.ID, kt.Rid)
+		return err
+	}
+
+	if flowErr != nil {
+		logs.Errorf("create webhook deliver flow failed, err: %v, event: %s, rid: %s", flowErr, event.ID, kt.Rid)
+		return flowErr
+	}
+
+	return nil
+}
+
+func (p *publisher) decryptSecret(secret string) (string, error) {
+	if len(secret) == 0 {
+		return "", nil
+	}
+
+	return p.cipher.DecryptFromBase64(secret)
+}
+
+// listMatchedSubscriptions 获取订阅了该事件的已启用订阅，订阅列表缓存一段时间避免频繁查询
+func (p *publisher) listMatchedSubscriptions(kt *kit.Kit, eventType enumor.WebhookEventType) (
+	[]corewebhook.Subscription, error) {
+
+	p.cacheLock.Lock()
+	defer p.cacheLock.Unlock()
+
+	if time.Since(p.cachedAt) > subscriptionCacheTTL {
+		subs, err := p.listEnabledSubscriptions(kt)
+		if err != nil {
+			return nil, err
+		}
+		p.subscriptions = subs
+		p.cachedAt = time.Now()
+	}
+
+	matched := make([]corewebhook.Subscription, 0)
+	for _, sub := range p.subscriptions {
+		for _, one := range sub.EventTypes {
+			if one.Match(eventType) {
+				matched = append(matched, sub)
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+func (p *publisher) listEnabledSubscriptions(kt *kit.Kit) ([]corewebhook.Subscription, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("enabled", true),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	result := make([]corewebhook.Subscription, 0)
+	for {
+		listResp, err := p.client.DataService().Global.Webhook.ListSubscription(kt, listReq)
+		if err != nil {
+			logs.Errorf("list webhook subscription failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		result = append(result, listResp.Details...)
+
+		if uint(len(listResp.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apitoken 服务账号及API令牌管理
+package apitoken
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the service account and api token service.
+func InitService(c *capability.Capability) {
+	svc := &svc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+	h.Add("CreateServiceAccount", http.MethodPost, "/service_accounts/create", svc.CreateServiceAccount)
+	h.Add("UpdateServiceAccount", http.MethodPatch, "/service_accounts/{id}", svc.UpdateServiceAccount)
+	h.Add("BatchDeleteServiceAccount", http.MethodDelete, "/service_accounts/batch", svc.BatchDeleteServiceAccount)
+	h.Add("ListServiceAccount", http.MethodPost, "/service_accounts/list", svc.ListServiceAccount)
+
+	h.Add("CreateAPIToken", http.MethodPost, "/api_tokens/create", svc.CreateToken)
+	h.Add("ListAPIToken", http.MethodPost, "/api_tokens/list", svc.ListToken)
+	h.Add("RevokeAPIToken", http.MethodPost, "/api_tokens/revoke", svc.RevokeToken)
+
+	h.Load(c.WebService)
+}
+
+type svc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *svc) authServiceAccount(kt *kit.Kit, action meta.Action) error {
+	return svc.authorizer.AuthorizeWithPerm(kt, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.ServiceAccount, Action: action},
+	})
+}
+
+// rejectTokenRequest 通过API令牌发起的请求不允许管理服务账号和令牌，避免令牌自我续期或越权签发
+func rejectTokenRequest(kt *kit.Kit) error {
+	if kt.TokenScope != nil {
+		return errf.New(errf.PermissionDenied, "service account and api token can not be managed by api token")
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apitoken
+
+import (
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server/api-token"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsapitoken "hcm/pkg/api/data-service/api-token"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateServiceAccount create service account.
+func (svc *svc) CreateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ServiceAccountCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := rejectTokenRequest(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	if err := svc.authServiceAccount(cts.Kit, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &dsapitoken.ServiceAccountCreateReq{Name: req.Name, Memo: req.Memo}
+	result, err := svc.client.DataService().Global.APIToken.CreateServiceAccount(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create service account failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateServiceAccount update service account.
+func (svc *svc) UpdateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.ServiceAccountUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := rejectTokenRequest(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	if err := svc.authServiceAccount(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsapitoken.ServiceAccountUpdateReq{ID: id, Memo: req.Memo}
+	if err := svc.client.DataService().Global.APIToken.UpdateServiceAccount(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update service account failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteServiceAccount batch delete service account, api tokens of the service accounts are deleted too.
+func (svc *svc) BatchDeleteServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ServiceAccountDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := rejectTokenRequest(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	if err := svc.authServiceAccount(cts.Kit, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", req.IDs)}
+	if err := svc.client.DataService().Global.APIToken.BatchDeleteServiceAccount(cts.Kit, delReq); err != nil {
+		logs.Errorf("delete service account failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListServiceAccount list service account.
+func (svc *svc) ListServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authServiceAccount(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.APIToken.ListServiceAccount(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &proto.ServiceAccountListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// ensureServiceAccountExists check service account exists by name.
+func (svc *svc) ensureServiceAccountExists(cts *rest.Contexts, name string) error {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("name", name),
+		Page:   core.NewCountPage(),
+	}
+	result, err := svc.client.DataService().Global.APIToken.ListServiceAccount(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("count service account failed, err: %v, name: %s, rid: %s", err, name, cts.Kit.Rid)
+		return err
+	}
+
+	if result.Count == 0 {
+		return errf.New(errf.RecordNotFound, fmt.Sprintf("service account %s not exists", name))
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apitoken
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server/api-token"
+	"hcm/pkg/api/core"
+	coreapitoken "hcm/pkg/api/core/api-token"
+	dsapitoken "hcm/pkg/api/data-service/api-token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/times"
+)
+
+// tokenRandomBytes API令牌随机部分的字节数
+const tokenRandomBytes = 32
+
+// CreateToken create api token, token plaintext is only returned in this response.
+func (svc *svc) CreateToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.TokenCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := rejectTokenRequest(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	principal := cts.Kit.User
+	if req.PrincipalType == enumor.APITokenPrincipalServiceAccount {
+		// 为服务账号签发令牌需要服务账号的管理权限，用户令牌只能代表用户本人，无需额外鉴权
+		if err := svc.authServiceAccount(cts.Kit, meta.Create); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ensureServiceAccountExists(cts, req.Principal); err != nil {
+			return nil, err
+		}
+		principal = coreapitoken.ServiceAccountPrincipal(req.Principal)
+	}
+
+	// 服务账号令牌主体使用保留前缀，用户名不可占用该前缀，避免令牌冒充其他用户
+	if err := coreapitoken.ValidatePrincipal(req.PrincipalType, principal); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	token, err := genToken()
+	if err != nil {
+		logs.Errorf("generate api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	expireAt := times.ConvStdTimeNow().AddDate(0, 0, int(req.ExpireDays))
+	createReq := &dsapitoken.TokenCreateReq{
+		Name:          req.Name,
+		TokenHash:     coreapitoken.HashToken(token),
+		TokenPrefix:   token[:coreapitoken.TokenDisplayPrefixLen],
+		PrincipalType: req.PrincipalType,
+		Principal:     principal,
+		BkBizIDs:      req.BkBizIDs,
+		Actions:       req.Actions,
+		ExpireAt:      times.ConvStdTimeFormat(expireAt),
+		Memo:          req.Memo,
+	}
+	result, err := svc.client.DataService().Global.APIToken.CreateToken(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create api token failed, err: %v, name: %s, principal: %s, rid: %s", err, req.Name, principal,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &proto.TokenCreateResult{ID: result.ID, Token: token}, nil
+}
+
+// ListToken list api token, user without service account permission can only list tokens of himself.
+func (svc *svc) ListToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	_, authorized, err := svc.authorizer.Authorize(cts.Kit, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.ServiceAccount, Action: meta.Find},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !authorized {
+		req.Filter, err = tools.And(req.Filter,
+			tools.RuleEqual("principal_type", string(enumor.APITokenPrincipalUser)),
+			tools.RuleEqual("principal", cts.Kit.User))
+		if err != nil {
+			logs.Errorf("merge api token list filter failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	result, err := svc.client.DataService().Global.APIToken.ListToken(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &proto.TokenListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// RevokeToken revoke api token, revoked token can not be used any more.
+func (svc *svc) RevokeToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.TokenRevokeReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := rejectTokenRequest(cts.Kit); err != nil {
+		return nil, err
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", req.IDs),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "principal_type", "principal"},
+	}
+	result, err := svc.client.DataService().Global.APIToken.ListToken(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list api token failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != len(req.IDs) {
+		return nil, errf.New(errf.RecordNotFound, "some api tokens not exists")
+	}
+
+	// 吊销他人或服务账号的令牌需要服务账号的管理权限
+	for _, one := range result.Details {
+		if one.PrincipalType == enumor.APITokenPrincipalUser && one.Principal == cts.Kit.User {
+			continue
+		}
+
+		if err = svc.authServiceAccount(cts.Kit, meta.Update); err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	revoked := true
+	for _, id := range req.IDs {
+		updateReq := &dsapitoken.TokenUpdateReq{ID: id, Revoked: &revoked}
+		if err = svc.client.DataService().Global.APIToken.UpdateToken(cts.Kit, updateReq); err != nil {
+			logs.Errorf("revoke api token failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// genToken generate api token plaintext.
+func genToken() (string, error) {
+	buf := make([]byte, tokenRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("read random bytes failed, err: %v", err)
+	}
+
+	return coreapitoken.TokenPlainPrefix + hex.EncodeToString(buf), nil
+}
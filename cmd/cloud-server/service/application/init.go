@@ -27,6 +27,7 @@ import (
 	"github.com/tidwall/gjson"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/webhook"
 	"hcm/cmd/cloud-server/service/application/handlers"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/api/core"
@@ -56,6 +57,7 @@ func InitApplicationService(c *capability.Capability, bkHcmUrl string) {
 		esbCli:     c.EsbClient,
 		bkHcmUrl:   bkHcmUrl,
 		cmsiCli:    c.CmsiCli,
+		webhook:    c.Webhook,
 	}
 	h := rest.NewHandler()
 	h.Add("ListApplications", "POST", "/applications/list", svc.ListApplications)
@@ -99,6 +101,7 @@ type applicationSvc struct {
 	esbCli     esb.Client
 	bkHcmUrl   string
 	cmsiCli    cmsi.Client
+	webhook    webhook.Publisher
 }
 
 func (a *applicationSvc) getCallbackUrl() string {
@@ -161,8 +164,23 @@ func (a *applicationSvc) updateStatusWithDetail(
 	if deliveryDetail != "" {
 		req.DeliveryDetail = &deliveryDetail
 	}
-	_, err := a.client.DataService().Global.Application.Update(cts.Kit, applicationID, req)
-	return err
+	if _, err := a.client.DataService().Global.Application.Update(cts.Kit, applicationID, req); err != nil {
+		return err
+	}
+
+	a.webhook.Publish(cts.Kit, enumor.WebhookEventApplicationStatus, &applicationStatusEventData{
+		ID:             applicationID,
+		Status:         status,
+		DeliveryDetail: deliveryDetail,
+	})
+	return nil
+}
+
+// applicationStatusEventData 申请单状态变更的webhook事件内容
+type applicationStatusEventData struct {
+	ID             string                   `json:"id"`
+	Status         enumor.ApplicationStatus `json:"status"`
+	DeliveryDetail string                   `json:"delivery_detail,omitempty"`
 }
 
 func (a *applicationSvc) getApplicationBySN(cts *rest.Contexts, sn string) (*dataproto.ApplicationResp, error) {
@@ -38,6 +38,7 @@ import (
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
 )
 
 // InitService initialize the audit service.
@@ -53,6 +54,10 @@ func InitService(c *capability.Capability) {
 	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
 	h.Add("ListAuditAsyncFlow", http.MethodPost, "/audits/async_flow/list", svc.ListAuditAsyncFlow)
 	h.Add("ListAuditAsyncTask", http.MethodPost, "/audits/async_task/list", svc.ListAuditAsyncTask)
+	h.Add("ListAuditFieldChange", http.MethodPost, "/audits/field_changes/list", svc.ListAuditFieldChange)
+	h.Add("CreateAuditExport", http.MethodPost, "/audits/exports/create", svc.CreateAuditExport)
+	h.Add("ListAuditExport", http.MethodPost, "/audits/exports/list", svc.ListAuditExport)
+	h.Add("GetAuditCloudSyncReport", http.MethodPost, "/audits/cloud_sync/report", svc.GetAuditCloudSyncReport)
 
 	// biz audit apis
 	h.Add("GetBizAudit", http.MethodGet, "/bizs/{bk_biz_id}/audits/{id}", svc.GetBizAudit)
@@ -61,6 +66,8 @@ func InitService(c *capability.Capability) {
 		svc.ListBizAuditAsyncFlow)
 	h.Add("ListBizAuditAsyncTask", http.MethodPost, "/bizs/{bk_biz_id}/audits/async_task/list",
 		svc.ListBizAuditAsyncTask)
+	h.Add("ListBizAuditFieldChange", http.MethodPost, "/bizs/{bk_biz_id}/audits/field_changes/list",
+		svc.ListBizAuditFieldChange)
 
 	h.Load(c.WebService)
 }
@@ -135,11 +142,124 @@ func (svc svc) listAudit(cts *rest.Contexts, authHandler handler.ListAuthResHand
 	}
 	req.Filter = expr
 
+	listReq := &audit.AuditListReq{
+		ListReq: &core.ListReq{
+			Filter: req.Filter,
+			Page:   req.Page,
+		},
+		Keyword: req.Keyword,
+	}
+	return svc.client.DataService().Global.Audit.ListAudit(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+}
+
+// ListAuditFieldChange 查询资源下审计的字段变更记录.
+func (svc svc) ListAuditFieldChange(cts *rest.Contexts) (interface{}, error) {
+	return svc.listAuditFieldChange(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizAuditFieldChange 查询业务下审计的字段变更记录.
+func (svc svc) ListBizAuditFieldChange(cts *rest.Contexts) (interface{}, error) {
+	return svc.listAuditFieldChange(cts, handler.ListBizAuthRes)
+}
+
+func (svc svc) listAuditFieldChange(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{},
+	error) {
+
+	req := new(proto.AuditFieldChangeListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// authorize
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.Audit, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &audit.FieldChangeListResult{Count: 0, Details: make([]coreaudit.FieldChange, 0)}, nil
+	}
+
 	listReq := &core.ListReq{
-		Filter: req.Filter,
+		Filter: expr,
 		Page:   req.Page,
 	}
-	return svc.client.DataService().Global.Audit.ListAudit(cts.Kit.Ctx, cts.Kit.Header(), listReq)
+	return svc.client.DataService().Global.Audit.ListAuditFieldChange(cts.Kit, listReq)
+}
+
+// CreateAuditExport 将上次导出之后的审计以哈希链的形式导出到对象存储.
+func (svc svc) CreateAuditExport(cts *rest.Contexts) (interface{}, error) {
+	req := new(audit.AuditExportCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.AuditExport, Action: meta.Create}}); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.CreateAuditExport(cts.Kit, req)
+}
+
+// ListAuditExport 查询审计导出记录.
+func (svc svc) ListAuditExport(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.AuditExport, Action: meta.Find}}); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.ListAuditExport(cts.Kit, req)
+}
+
+// GetAuditCloudSyncReport 查询账号下资源同步发现的云上变更（HCM之外的变更）统计报表.
+func (svc svc) GetAuditCloudSyncReport(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AuditCloudSyncReportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	dsReq := &audit.CloudSyncReportReq{
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		AccountIDs: slice.Unique(req.AccountIDs),
+	}
+	if err := dsReq.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	authRes := make([]meta.ResourceAttribute, 0, len(dsReq.AccountIDs))
+	for _, accountID := range dsReq.AccountIDs {
+		authRes = append(authRes, meta.ResourceAttribute{
+			Basic: &meta.Basic{Type: meta.Audit, Action: meta.Find, ResourceID: accountID}})
+	}
+	if err := svc.authorizer.AuthorizeWithPerm(cts.Kit, authRes...); err != nil {
+		return nil, err
+	}
+
+	return svc.client.DataService().Global.Audit.GetCloudSyncReport(cts.Kit, dsReq)
 }
 
 // ListAuditAsyncFlow 查询资源下异步任务的操作记录详情.
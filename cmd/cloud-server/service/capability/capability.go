@@ -23,6 +23,7 @@ package capability
 import (
 	"hcm/cmd/cloud-server/logics"
 	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/cmd/cloud-server/logics/webhook"
 	"hcm/pkg/client"
 	"hcm/pkg/cryptography"
 	"hcm/pkg/iam/auth"
@@ -46,4 +47,5 @@ type Capability struct {
 	ItsmCli    itsm.Client
 	BKBaseCli  bkbase.Client
 	CmsiCli    cmsi.Client
+	Webhook    webhook.Publisher
 }
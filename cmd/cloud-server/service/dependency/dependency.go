@@ -0,0 +1,240 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dependency 资源删除影响分析及级联删除
+package dependency
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/logics/audit"
+	logicsdep "hcm/cmd/cloud-server/logics/dependency"
+	"hcm/cmd/cloud-server/service/capability"
+	depproto "hcm/pkg/api/cloud-server/dependency"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// InitService initialize the resource dependency service.
+func InitService(c *capability.Capability) {
+	svc := &svc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		audit:      c.Audit,
+		dependency: c.Logics.Dependency,
+	}
+
+	h := rest.NewHandler()
+
+	h.Add("DeleteImpact", http.MethodPost, "/resources/delete_impact", svc.DeleteImpact)
+	h.Add("CascadeDelete", http.MethodPost, "/resources/cascade_delete", svc.CascadeDelete)
+
+	h.Add("BizDeleteImpact", http.MethodPost, "/bizs/{bk_biz_id}/resources/delete_impact", svc.BizDeleteImpact)
+	h.Add("BizCascadeDelete", http.MethodPost, "/bizs/{bk_biz_id}/resources/cascade_delete", svc.BizCascadeDelete)
+
+	h.Load(c.WebService)
+}
+
+type svc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	audit      audit.Interface
+	dependency logicsdep.Interface
+}
+
+// resAuthTypes 资源类型对应的鉴权资源类型
+var resAuthTypes = map[enumor.CloudResourceType]meta.ResourceType{
+	enumor.CvmCloudResType:           meta.Cvm,
+	enumor.DiskCloudResType:          meta.Disk,
+	enumor.EipCloudResType:           meta.Eip,
+	enumor.SecurityGroupCloudResType: meta.SecurityGroup,
+	enumor.LoadBalancerCloudResType:  meta.LoadBalancer,
+	enumor.ListenerCloudResType:      meta.Listener,
+	enumor.SubnetCloudResType:        meta.Subnet,
+	enumor.VpcCloudResType:           meta.Vpc,
+	enumor.RouteTableCloudResType:    meta.RouteTable,
+}
+
+// resAuditTypes 资源类型对应的审计资源类型
+var resAuditTypes = map[enumor.CloudResourceType]enumor.AuditResourceType{
+	enumor.CvmCloudResType:           enumor.CvmAuditResType,
+	enumor.EipCloudResType:           enumor.EipAuditResType,
+	enumor.SecurityGroupCloudResType: enumor.SecurityGroupAuditResType,
+	enumor.LoadBalancerCloudResType:  enumor.LoadBalancerAuditResType,
+	enumor.ListenerCloudResType:      enumor.ListenerAuditResType,
+	enumor.SubnetCloudResType:        enumor.SubnetAuditResType,
+	enumor.VpcCloudResType:           enumor.VpcCloudAuditResType,
+}
+
+// DeleteImpact analyze delete impact of resources.
+func (svc *svc) DeleteImpact(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteImpact(cts, handler.ResOperateAuth)
+}
+
+// BizDeleteImpact analyze delete impact of biz resources.
+func (svc *svc) BizDeleteImpact(cts *rest.Contexts) (interface{}, error) {
+	return svc.deleteImpact(cts, handler.BizOperateAuth)
+}
+
+func (svc *svc) deleteImpact(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(depproto.DeleteImpactReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRoots(cts, validHandler, req.ResType, req.IDs, meta.Find); err != nil {
+		return nil, err
+	}
+
+	items, err := svc.dependency.DeleteImpact(cts.Kit, req.ResType, req.IDs)
+	if err != nil {
+		logs.Errorf("analyze delete impact failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &depproto.DeleteImpactResult{Details: items}, nil
+}
+
+// CascadeDelete delete resources with their cascadable dependencies.
+func (svc *svc) CascadeDelete(cts *rest.Contexts) (interface{}, error) {
+	return svc.cascadeDelete(cts, handler.ResOperateAuth)
+}
+
+// BizCascadeDelete delete biz resources with their cascadable dependencies.
+func (svc *svc) BizCascadeDelete(cts *rest.Contexts) (interface{}, error) {
+	return svc.cascadeDelete(cts, handler.BizOperateAuth)
+}
+
+func (svc *svc) cascadeDelete(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (interface{}, error) {
+	req := new(depproto.CascadeDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRoots(cts, validHandler, req.ResType, req.IDs, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	items, err := svc.dependency.DeleteImpact(cts.Kit, req.ResType, req.IDs)
+	if err != nil {
+		logs.Errorf("analyze delete impact failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	typeInfos := make(map[enumor.CloudResourceType]map[string]types.CloudResourceBasicInfo)
+	for _, item := range items {
+		if !item.Cascadable {
+			return nil, errf.Newf(errf.InvalidParameter, "%s: %s has dependencies that can not be cascade deleted",
+				item.Root.ResType, item.Root.ID)
+		}
+
+		for _, node := range logicsdep.Nodes(item.Root) {
+			if typeInfos[node.ResType] == nil {
+				typeInfos[node.ResType] = make(map[string]types.CloudResourceBasicInfo)
+			}
+			typeInfos[node.ResType][node.ID] = types.CloudResourceBasicInfo{
+				ResType:   node.ResType,
+				ID:        node.ID,
+				Vendor:    node.Vendor,
+				AccountID: node.AccountID,
+				BkBizID:   node.BkBizID,
+				Region:    node.Region,
+			}
+		}
+	}
+
+	// 级联删除的依赖资源同样需要删除权限
+	for resType, infos := range typeInfos {
+		err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: resAuthTypes[resType],
+			Action: meta.Delete, BasicInfos: infos})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = svc.deleteAudit(cts.Kit, typeInfos); err != nil {
+		return nil, err
+	}
+
+	flowID, err := svc.dependency.CascadeDelete(cts.Kit, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &depproto.CascadeDeleteResult{FlowID: flowID}, nil
+}
+
+// authRoots 校验待分析资源的权限
+func (svc *svc) authRoots(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler,
+	resType enumor.CloudResourceType, ids []string, action meta.Action) error {
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: resType,
+		IDs:          ids,
+		Fields:       types.CommonBasicInfoFields,
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		logs.Errorf("list %s basic info failed, err: %v, ids: %v, rid: %s", resType, err, ids, cts.Kit.Rid)
+		return err
+	}
+
+	return validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: resAuthTypes[resType],
+		Action: action, BasicInfos: basicInfoMap})
+}
+
+func (svc *svc) deleteAudit(kt *kit.Kit,
+	typeInfos map[enumor.CloudResourceType]map[string]types.CloudResourceBasicInfo) error {
+
+	for resType, infos := range typeInfos {
+		auditType, exists := resAuditTypes[resType]
+		if !exists {
+			continue
+		}
+
+		ids := make([]string, 0, len(infos))
+		for id := range infos {
+			ids = append(ids, id)
+		}
+
+		if err := svc.audit.ResDeleteAudit(kt, auditType, ids); err != nil {
+			logs.Errorf("create %s delete audit failed, err: %v, ids: %v, rid: %s", resType, err, ids, kt.Rid)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -54,6 +54,8 @@ func InitService(c *capability.Capability) {
 	h.Add("TCloudDescribeResources", http.MethodPost,
 		"/vendors/tcloud/load_balancers/resources/describe", svc.TCloudDescribeResources)
 	h.Add("BatchDeleteLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteLoadBalancer)
+	h.Add("RecycleLoadBalancer", http.MethodPost, "/load_balancers/recycle", svc.RecycleLoadBalancer)
+	h.Add("RecoverLoadBalancer", http.MethodPost, "/load_balancers/recover", svc.RecoverLoadBalancer)
 	h.Add("ListListenerCountByLbIDs", http.MethodPost, "/load_balancers/listeners/count", svc.ListListenerCountByLbIDs)
 	h.Add("GetLoadBalancerLockStatus", http.MethodGet,
 		"/load_balancers/{id}/lock/status", svc.GetLoadBalancerLockStatus)
@@ -78,6 +80,8 @@ func bizService(h *rest.Handler, svc *lbSvc) {
 		"/load_balancers/with/delete_protection/list", svc.ListBizLoadBalancerWithDeleteProtect)
 	h.Add("GetBizLoadBalancer", http.MethodGet, "/load_balancers/{id}", svc.GetBizLoadBalancer)
 	h.Add("BatchDeleteBizLoadBalancer", http.MethodDelete, "/load_balancers/batch", svc.BatchDeleteBizLoadBalancer)
+	h.Add("RecycleBizLoadBalancer", http.MethodPost, "/load_balancers/recycle", svc.RecycleBizLoadBalancer)
+	h.Add("RecoverBizLoadBalancer", http.MethodPost, "/load_balancers/recover", svc.RecoverBizLoadBalancer)
 
 	h.Add("ListBizListener", http.MethodPost, "/load_balancers/{lb_id}/listeners/list", svc.ListBizListener)
 	h.Add("GetBizListener", http.MethodGet, "/listeners/{id}", svc.GetBizListener)
@@ -0,0 +1,304 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"fmt"
+
+	logicsrecycle "hcm/cmd/cloud-server/logics/recycle"
+	csrecycle "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	corerr "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// RecycleLoadBalancer recycle load balancer.
+func (svc *lbSvc) RecycleLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizLoadBalancer recycle biz load balancer.
+func (svc *lbSvc) RecycleBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) recycleLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrecycle.ResRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          req.IDs,
+		Fields:       append(types.CommonBasicInfoFields, "region", "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range req.IDs {
+		info, exists := basicInfoMap[id]
+		if !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "load balancer(%s) not found", id)
+		}
+		if info.Vendor != enumor.TCloud {
+			return nil, errf.Newf(errf.InvalidParameter, "load balancer(%s) recycle only supports tcloud", id)
+		}
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Recycle, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = svc.checkLoadBalancerDeleteProtect(cts.Kit, req.IDs); err != nil {
+		return nil, err
+	}
+
+	// 回收期间监听器仍然保留，这里记录监听器及规则配置快照，便于销毁后追溯或重建
+	detailMap, err := svc.snapshotLoadBalancerListeners(cts.Kit, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(req.IDs))
+	opt := &dsrr.BatchRecycleReq{
+		ResType:            enumor.LoadBalancerCloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos:              make([]dsrr.RecycleReq, 0, len(req.IDs)),
+	}
+	for _, id := range req.IDs {
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: id, Data: detailMap[id]})
+		opt.Infos = append(opt.Infos, dsrr.RecycleReq{ID: id, Detail: detailMap[id]})
+	}
+
+	// create recycle audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.LoadBalancerAuditResType,
+		Action:  protoaudit.Recycle,
+		Infos:   auditInfos,
+	}
+	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	taskID, err := svc.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("recycle load balancer failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csrecycle.RecycleResult{TaskID: taskID}, nil
+}
+
+// checkLoadBalancerDeleteProtect 开启删除保护的负载均衡到期后无法被销毁，不允许回收
+func (svc *lbSvc) checkLoadBalancerDeleteProtect(kt *kit.Kit, lbIDs []string) error {
+	lbReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", lbIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	lbResp, err := svc.client.DataService().TCloud.LoadBalancer.ListLoadBalancer(kt, lbReq)
+	if err != nil {
+		logs.Errorf("list load balancer failed, err: %v, ids: %v, rid: %s", err, lbIDs, kt.Rid)
+		return err
+	}
+
+	for _, lb := range lbResp.Details {
+		if cvt.PtrToVal(lb.Extension.DeleteProtect) {
+			return errf.Newf(errf.InvalidParameter, "%s(%s) is protected for deletion", lb.Name, lb.CloudID)
+		}
+	}
+	return nil
+}
+
+// snapshotLoadBalancerListeners 获取负载均衡下的监听器及url规则，按负载均衡id分组返回
+func (svc *lbSvc) snapshotLoadBalancerListeners(kt *kit.Kit, lbIDs []string) (
+	map[string]*corerr.LoadBalancerRecycleDetail, error) {
+
+	detailMap := make(map[string]*corerr.LoadBalancerRecycleDetail, len(lbIDs))
+	for _, id := range lbIDs {
+		detailMap[id] = &corerr.LoadBalancerRecycleDetail{Listeners: make([]corerr.LoadBalancerListenerSnapshot, 0)}
+	}
+
+	listeners := make([]corelb.TCloudListener, 0)
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("lb_id", lbIDs),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		lblResp, err := svc.client.DataService().TCloud.LoadBalancer.ListListener(kt, listReq)
+		if err != nil {
+			logs.Errorf("list listener failed, err: %v, lb ids: %v, rid: %s", err, lbIDs, kt.Rid)
+			return nil, err
+		}
+		listeners = append(listeners, lblResp.Details...)
+		if uint(len(lblResp.Details)) < core.DefaultMaxPageLimit {
+			break
+		}
+		listReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+	}
+
+	ruleMap := make(map[string][]corelb.TCloudLbUrlRule)
+	for _, batch := range slice.Split(listeners, constant.BatchOperationMaxLimit) {
+		lblIDs := slice.Map(batch, func(one corelb.TCloudListener) string { return one.ID })
+		ruleReq := &core.ListReq{
+			Filter: tools.ContainersExpression("lbl_id", lblIDs),
+			Page:   core.NewDefaultBasePage(),
+		}
+		for {
+			ruleResp, err := svc.client.DataService().TCloud.LoadBalancer.ListUrlRule(kt, ruleReq)
+			if err != nil {
+				logs.Errorf("list url rule failed, err: %v, lbl ids: %v, rid: %s", err, lblIDs, kt.Rid)
+				return nil, err
+			}
+			for _, rule := range ruleResp.Details {
+				ruleMap[rule.LblID] = append(ruleMap[rule.LblID], rule)
+			}
+			if uint(len(ruleResp.Details)) < core.DefaultMaxPageLimit {
+				break
+			}
+			ruleReq.Page.Start += uint32(core.DefaultMaxPageLimit)
+		}
+	}
+
+	for _, lbl := range listeners {
+		detail, exists := detailMap[lbl.LbID]
+		if !exists {
+			return nil, fmt.Errorf("listener(%s) load balancer(%s) is not in recycle list", lbl.ID, lbl.LbID)
+		}
+		detail.Listeners = append(detail.Listeners, corerr.LoadBalancerListenerSnapshot{
+			TCloudListener: lbl,
+			Rules:          ruleMap[lbl.ID],
+		})
+	}
+
+	return detailMap, nil
+}
+
+// RecoverLoadBalancer recover load balancer.
+func (svc *lbSvc) RecoverLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverLoadBalancer(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizLoadBalancer recover biz load balancer.
+func (svc *lbSvc) RecoverBizLoadBalancer(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverLoadBalancer(cts, handler.BizOperateAuth)
+}
+
+func (svc *lbSvc) recoverLoadBalancer(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrecycle.ResRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", req.RecordIDs),
+		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
+	}
+	records, err := svc.client.DataService().Global.RecycleRecord.ListRecycleRecord(cts.Kit, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records.Details) != len(req.RecordIDs) {
+		return nil, errf.New(errf.InvalidParameter, "some record_ids are not in recycle bin")
+	}
+	err = logicsrecycle.ValidateRecycleRecord(records.Details, enumor.LoadBalancerCloudResType)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(records.Details))
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(records.Details))
+	for _, record := range records.Details {
+		ids = append(ids, record.ResID)
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: record.ResID})
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.LoadBalancerCloudResType,
+		IDs:          ids,
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.LoadBalancer,
+		Action: meta.Recover, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create recover audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.LoadBalancerAuditResType,
+		Action:  protoaudit.Recover,
+		Infos:   auditInfos,
+	}
+	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create recover audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &dsrr.BatchRecoverReq{
+		ResType:   enumor.LoadBalancerCloudResType,
+		RecordIDs: req.RecordIDs,
+	}
+	if err = svc.client.DataService().Global.RecycleRecord.BatchRecoverCloudResource(cts.Kit, opt); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package loadbalancer
+
+import (
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/ctl/cmd"
+)
+
+var _ cmd.ResFlowLockReleaser = new(ResFlowLockReleaser)
+
+// ResFlowLockReleaser 强制释放资源锁，用于处理异常任务流遗留的资源锁
+type ResFlowLockReleaser struct {
+	client *client.ClientSet
+}
+
+// NewResFlowLockReleaser new resource flow lock releaser.
+func NewResFlowLockReleaser(client *client.ClientSet) *ResFlowLockReleaser {
+	return &ResFlowLockReleaser{client: client}
+}
+
+// ReleaseResFlowLock 强制释放资源锁，资源跟Flow的状态按超时处理，持有锁的任务流不会被取消
+func (r *ResFlowLockReleaser) ReleaseResFlowLock(kt *kit.Kit, resType enumor.CloudResourceType, resID string) (
+	string, error) {
+
+	lockReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("res_id", resID),
+			tools.RuleEqual("res_type", resType),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	lockRet, err := r.client.DataService().Global.LoadBalancer.ListResFlowLock(kt, lockReq)
+	if err != nil {
+		logs.Errorf("list res flow lock failed, err: %v, resID: %s, resType: %s, rid: %s", err, resID, resType,
+			kt.Rid)
+		return "", err
+	}
+	if len(lockRet.Details) == 0 {
+		return "", errf.Newf(errf.RecordNotFound, "%s(%s) is not locked", resType, resID)
+	}
+
+	lock := lockRet.Details[0]
+	unlockReq := &dataproto.ResFlowLockReq{
+		ResID:   lock.ResID,
+		ResType: lock.ResType,
+		FlowID:  lock.Owner,
+		Status:  enumor.TimeoutResFlowStatus,
+	}
+	if err = r.client.DataService().Global.LoadBalancer.ResFlowUnLock(kt, unlockReq); err != nil {
+		logs.Errorf("call dataservice to unlock res flow failed, err: %v, req: %+v, rid: %s", err, unlockReq,
+			kt.Rid)
+		return "", err
+	}
+
+	return lock.Owner, nil
+}
@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	proto "hcm/pkg/api/cloud-server/rbac"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateGroup create rbac group.
+func (svc *svc) CreateGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.GroupCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &protorbac.GroupCreateReq{
+		Name:    req.Name,
+		Members: req.Members,
+		Memo:    req.Memo,
+	}
+	result, err := svc.client.DataService().Global.RBAC.CreateGroup(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create rbac group failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateGroup update rbac group.
+func (svc *svc) UpdateGroup(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.GroupUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protorbac.GroupUpdateReq{
+		ID:      id,
+		Members: req.Members,
+		Memo:    req.Memo,
+	}
+	if err := svc.client.DataService().Global.RBAC.UpdateGroup(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update rbac group failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListGroup list rbac group.
+func (svc *svc) ListGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.RBAC.ListGroup(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list rbac group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &proto.GroupListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// BatchDeleteGroup batch delete rbac group.
+func (svc *svc) BatchDeleteGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", req.IDs)}
+	if err := svc.client.DataService().Global.RBAC.BatchDeleteGroup(cts.Kit, delReq); err != nil {
+		logs.Errorf("delete rbac group failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
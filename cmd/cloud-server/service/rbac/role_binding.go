@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	proto "hcm/pkg/api/cloud-server/rbac"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	protorbac "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CreateRoleBinding create rbac role binding.
+func (svc *svc) CreateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.RoleBindingCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Create); err != nil {
+		return nil, err
+	}
+
+	createReq := &protorbac.RoleBindingCreateReq{
+		RoleID:      req.RoleID,
+		SubjectType: req.SubjectType,
+		Subject:     req.Subject,
+		BkBizIDs:    req.BkBizIDs,
+	}
+	result, err := svc.client.DataService().Global.RBAC.CreateRoleBinding(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create rbac role binding failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// UpdateRoleBinding update rbac role binding.
+func (svc *svc) UpdateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.RoleBindingUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protorbac.RoleBindingUpdateReq{
+		ID:       id,
+		BkBizIDs: req.BkBizIDs,
+	}
+	if err := svc.client.DataService().Global.RBAC.UpdateRoleBinding(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update rbac role binding failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRoleBinding list rbac role binding.
+func (svc *svc) ListRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.RBAC.ListRoleBinding(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &proto.RoleBindingListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// BatchDeleteRoleBinding batch delete rbac role binding.
+func (svc *svc) BatchDeleteRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRBAC(cts.Kit, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", req.IDs)}
+	if err := svc.client.DataService().Global.RBAC.BatchDeleteRoleBinding(cts.Kit, delReq); err != nil {
+		logs.Errorf("delete rbac role binding failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
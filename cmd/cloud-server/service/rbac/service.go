@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地鉴权的角色、用户组及角色绑定管理，仅在auth-server使用本地鉴权时生效
+package rbac
+
+import (
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	"hcm/pkg/client"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the local rbac service.
+func InitService(c *capability.Capability) {
+	svc := &svc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+	}
+
+	h := rest.NewHandler()
+	h.Add("CreateRBACRole", http.MethodPost, "/rbac/roles/create", svc.CreateRole)
+	h.Add("UpdateRBACRole", http.MethodPatch, "/rbac/roles/{id}", svc.UpdateRole)
+	h.Add("ListRBACRole", http.MethodPost, "/rbac/roles/list", svc.ListRole)
+	h.Add("BatchDeleteRBACRole", http.MethodDelete, "/rbac/roles/batch", svc.BatchDeleteRole)
+
+	h.Add("CreateRBACGroup", http.MethodPost, "/rbac/groups/create", svc.CreateGroup)
+	h.Add("UpdateRBACGroup", http.MethodPatch, "/rbac/groups/{id}", svc.UpdateGroup)
+	h.Add("ListRBACGroup", http.MethodPost, "/rbac/groups/list", svc.ListGroup)
+	h.Add("BatchDeleteRBACGroup", http.MethodDelete, "/rbac/groups/batch", svc.BatchDeleteGroup)
+
+	h.Add("CreateRBACRoleBinding", http.MethodPost, "/rbac/role_bindings/create", svc.CreateRoleBinding)
+	h.Add("UpdateRBACRoleBinding", http.MethodPatch, "/rbac/role_bindings/{id}", svc.UpdateRoleBinding)
+	h.Add("ListRBACRoleBinding", http.MethodPost, "/rbac/role_bindings/list", svc.ListRoleBinding)
+	h.Add("BatchDeleteRBACRoleBinding", http.MethodDelete, "/rbac/role_bindings/batch", svc.BatchDeleteRoleBinding)
+
+	h.Load(c.WebService)
+}
+
+type svc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+}
+
+func (svc *svc) authRBAC(kt *kit.Kit, action meta.Action) error {
+	return svc.authorizer.AuthorizeWithPerm(kt, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.RBAC, Action: action},
+	})
+}
@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"hcm/pkg/cc"
+	"hcm/pkg/runtime/ctl/cmd"
+	"hcm/pkg/serviced"
+)
+
+// pausedConfigKey 回收定时任务暂停状态的动态配置项，暂停后不再销毁到期的回收资源，也不再发送到期通知
+const pausedConfigKey = "recycle.paused"
+
+// isPaused 回收定时任务是否暂停，暂停状态持久化在动态配置中，对全部节点生效且重启后保持
+func isPaused() bool {
+	return cc.CloudServer().Recycle.Paused
+}
+
+var _ cmd.RecycleController = new(Controller)
+
+// Controller 回收定时任务运行时控制器
+type Controller struct {
+	// putConfig 持久化动态配置并立即生效
+	putConfig func(key string, value interface{}) error
+	// paused 获取当前生效的暂停状态
+	paused func() bool
+}
+
+// NewController new recycle timer controller.
+func NewController() *Controller {
+	return &Controller{putConfig: serviced.PutConfig, paused: isPaused}
+}
+
+// Pause 暂停回收定时任务，正在销毁的资源处理完后生效
+func (c *Controller) Pause() error {
+	return c.putConfig(pausedConfigKey, true)
+}
+
+// Resume 恢复回收定时任务
+func (c *Controller) Resume() error {
+	return c.putConfig(pausedConfigKey, false)
+}
+
+// IsPaused 回收定时任务是否暂停
+func (c *Controller) IsPaused() bool {
+	return c.paused()
+}
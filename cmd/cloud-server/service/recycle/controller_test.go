@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"testing"
+)
+
+func TestControllerPauseResume(t *testing.T) {
+	config := make(map[string]interface{})
+	c := &Controller{
+		putConfig: func(key string, value interface{}) error {
+			config[key] = value
+			return nil
+		},
+		paused: func() bool {
+			paused, _ := config[pausedConfigKey].(bool)
+			return paused
+		},
+	}
+
+	if c.IsPaused() {
+		t.Fatalf("recycle timer should not be paused by default")
+	}
+	if err := c.Pause(); err != nil {
+		t.Fatalf("pause recycle timer failed, err: %v", err)
+	}
+	if !c.IsPaused() || config[pausedConfigKey] != true {
+		t.Fatalf("paused state should be persisted to %s, config: %v", pausedConfigKey, config)
+	}
+	if err := c.Resume(); err != nil {
+		t.Fatalf("resume recycle timer failed, err: %v", err)
+	}
+	if c.IsPaused() || config[pausedConfigKey] != false {
+		t.Fatalf("resumed state should be persisted to %s, config: %v", pausedConfigKey, config)
+	}
+}
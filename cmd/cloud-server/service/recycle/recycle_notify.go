@@ -0,0 +1,247 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"hcm/pkg/api/core"
+	recyclerecord "hcm/pkg/api/core/recycle-record"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
+	"hcm/pkg/tools/slice"
+	"hcm/pkg/tools/times"
+)
+
+const (
+	expireNotifyTitle   = "【海垓】回收站资源即将被销毁通知"
+	expireNotifyContent = `<p>您好，您回收的以下资源即将到期，到期后将被自动销毁，如需保留请尽快在回收站中恢复：</p>
+<table border="1" cellspacing="0" cellpadding="4">
+<tr><th>资源类型</th><th>资源ID</th><th>云资源ID</th><th>资源名称</th><th>业务ID</th><th>销毁时间</th></tr>
+%s
+</table>`
+	expireNotifyRow = "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%d</td><td>%s</td></tr>"
+)
+
+// expireNotifyTiming 定时扫描即将到期的回收记录，通过邮件通知回收人，回收配置每轮重新读取，支持动态调整
+func (r *recycle) expireNotifyTiming() {
+	for {
+		time.Sleep(time.Minute * 10)
+
+		if !r.state.IsMaster() || isPaused() {
+			continue
+		}
+
+		kt := core.NewBackendKit()
+		if err := r.notifyExpiringRecords(kt, cc.CloudServer().Recycle); err != nil {
+			logs.Errorf("notify expiring recycle records failed, err: %v, rid: %s", err, kt.Rid)
+		}
+	}
+}
+
+func (r *recycle) notifyExpiringRecords(kt *kit.Kit, conf cc.Recycle) error {
+	policyNotifyMap, err := r.listPolicyNotifyBefore(kt)
+	if err != nil {
+		return err
+	}
+
+	// 取全局配置与回收策略中最大的提前通知时长作为查询窗口
+	maxNotifyBefore := int(conf.NotifyBeforeTime)
+	for _, notifyBefore := range policyNotifyMap {
+		maxNotifyBefore = max(maxNotifyBefore, notifyBefore)
+	}
+	if maxNotifyBefore == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	expr, err := tools.And(
+		tools.EqualExpression("status", enumor.WaitingRecycleRecordStatus),
+		&filter.AtomRule{Field: "recycled_at", Op: filter.GreaterThan.Factory(),
+			Value: times.ConvStdTimeFormat(now)},
+		&filter.AtomRule{Field: "recycled_at", Op: filter.LessThanEqual.Factory(),
+			Value: times.ConvStdTimeFormat(now.Add(time.Hour * time.Duration(maxNotifyBefore)))},
+		// 关联资源随主资源一起回收，不单独通知
+		&filter.AtomRule{Field: "recycle_type", Op: filter.NotEqual.Factory(), Value: enumor.RecycleTypeRelated},
+	)
+	if err != nil {
+		return err
+	}
+
+	listReq := &core.ListReq{
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "res_type", "res_id", "cloud_res_id", "res_name", "bk_biz_id", "detail", "creator",
+			"recycled_at"},
+	}
+	userRecords := make(map[string][]recyclerecord.RecycleRecord)
+	for {
+		recordRes, err := r.client.DataService().Global.RecycleRecord.ListRecycleRecord(kt, listReq)
+		if err != nil {
+			logs.Errorf("list expiring recycle record failed, err: %v, rid: %s", err, kt.Rid)
+			return err
+		}
+
+		for _, record := range recordRes.Details {
+			notifyBefore := chooseNotifyBefore(policyNotifyMap, record, int(conf.NotifyBeforeTime))
+			if !needExpireNotify(record, notifyBefore, now) {
+				continue
+			}
+			userRecords[record.Creator] = append(userRecords[record.Creator], record)
+		}
+
+		if uint(len(recordRes.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	for user, records := range userRecords {
+		// 邮件发送失败时记录不会标记为已通知，下一轮会再次发布事件，订阅方可按回收记录ID去重
+		r.webhook.Publish(kt, enumor.WebhookEventRecycleExpiry, &expiryEventData{Recycler: user, Records: records})
+
+		if err = r.sendExpireNotify(kt, user, records); err != nil {
+			logs.Errorf("send recycle expire notify to %s failed, err: %v, rid: %s", user, err, kt.Rid)
+			continue
+		}
+
+		recordIDs := slice.Map(records, func(r recyclerecord.RecycleRecord) string { return r.ID })
+		for _, ids := range slice.Split(recordIDs, constant.BatchOperationMaxLimit) {
+			updateReq := &dsrr.BatchUpdateReq{Data: slice.Map(ids, func(id string) dsrr.UpdateReq {
+				return dsrr.UpdateReq{ID: id, Detail: recyclerecord.ExpireNotifyDetail{ExpireNotified: true}}
+			})}
+			if err = r.client.DataService().Global.RecycleRecord.BatchUpdateRecycleRecord(kt, updateReq); err != nil {
+				logs.Errorf("mark recycle record expire notified failed, err: %v, ids: %v, rid: %s", err, ids,
+					kt.Rid)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expiryEventData 回收资源即将到期的webhook事件内容
+type expiryEventData struct {
+	Recycler string                        `json:"recycler"`
+	Records  []recyclerecord.RecycleRecord `json:"records"`
+}
+
+// listPolicyNotifyBefore 获取回收策略中配置的提前通知时长，key为业务ID与资源类型
+func (r *recycle) listPolicyNotifyBefore(kt *kit.Kit) (map[string]int, error) {
+	listReq := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"bk_biz_id", "res_type", "notify_before"},
+	}
+
+	result := make(map[string]int)
+	for {
+		listResp, err := r.client.DataService().Global.RecyclePolicy.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("list recycle policy failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range listResp.Details {
+			result[policyKey(one.BkBizID, one.ResType)] = one.NotifyBefore
+		}
+
+		if uint(len(listResp.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+
+	return result, nil
+}
+
+// sendExpireNotify 通过 cmsi 邮件通知回收人。notice 客户端对接的是平台公告服务，只支持查询当前公告和注册应用，
+// 没有面向指定用户的消息接口，因此到期通知不经过 notice 发送。
+func (r *recycle) sendExpireNotify(kt *kit.Kit, user string, records []recyclerecord.RecycleRecord) error {
+	if r.cmsiCli == nil {
+		return fmt.Errorf("cmsi client is not set")
+	}
+
+	mail := &cmsi.CmsiMail{
+		ReceiverUserName: user,
+		Title:            expireNotifyTitle,
+		Content:          buildExpireNotifyContent(records),
+	}
+	return r.cmsiCli.SendMail(kt, mail)
+}
+
+// buildExpireNotifyContent 生成到期通知邮件内容，资源名称等字段可能由用户或云上自定义，需转义后再拼入html
+func buildExpireNotifyContent(records []recyclerecord.RecycleRecord) string {
+	rows := make([]string, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, fmt.Sprintf(expireNotifyRow, html.EscapeString(string(record.ResType)),
+			html.EscapeString(record.ResID), html.EscapeString(record.CloudResID), html.EscapeString(record.ResName),
+			record.BkBizID, html.EscapeString(record.RecycledAt)))
+	}
+
+	return fmt.Sprintf(expireNotifyContent, strings.Join(rows, "\n"))
+}
+
+// needExpireNotify 判断回收记录是否进入提前通知时间窗口且尚未通知
+func needExpireNotify(record recyclerecord.RecycleRecord, notifyBefore int, now time.Time) bool {
+	if notifyBefore <= 0 || len(record.Creator) == 0 {
+		return false
+	}
+
+	recycledAt, err := time.ParseInLocation(constant.TimeStdFormat, record.RecycledAt, time.Local)
+	if err != nil {
+		logs.Errorf("parse recycle record recycled_at failed, err: %v, id: %s", err, record.ID)
+		return false
+	}
+
+	if recycledAt.Sub(now) > time.Hour*time.Duration(notifyBefore) {
+		return false
+	}
+
+	detail, ok := record.Detail.(map[string]interface{})
+	if !ok {
+		return true
+	}
+	notified, _ := detail["expire_notified"].(bool)
+	return !notified
+}
+
+// chooseNotifyBefore 回收策略中配置了大于0的提前通知时长时优先使用，否则使用全局配置
+func chooseNotifyBefore(policyNotifyMap map[string]int, record recyclerecord.RecycleRecord, defaultNotify int) int {
+	if policyNotify, exists := policyNotifyMap[policyKey(record.BkBizID, record.ResType)]; exists &&
+		policyNotify > 0 {
+		return policyNotify
+	}
+	return defaultNotify
+}
+
+func policyKey(bizID int64, resType enumor.CloudResourceType) string {
+	return fmt.Sprintf("%d/%s", bizID, resType)
+}
@@ -0,0 +1,123 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	recyclerecord "hcm/pkg/api/core/recycle-record"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestChooseNotifyBefore(t *testing.T) {
+	policyNotifyMap := map[string]int{
+		policyKey(100, enumor.CvmCloudResType):  48,
+		policyKey(100, enumor.DiskCloudResType): 0,
+	}
+
+	cases := []struct {
+		name    string
+		bizID   int64
+		resType enumor.CloudResourceType
+		expect  int
+	}{
+		{name: "business policy first", bizID: 100, resType: enumor.CvmCloudResType, expect: 48},
+		{name: "policy without notify falls back to global", bizID: 100, resType: enumor.DiskCloudResType,
+			expect: 12},
+		{name: "other resource type uses global", bizID: 100, resType: enumor.EipCloudResType, expect: 12},
+		{name: "other business uses global", bizID: 200, resType: enumor.CvmCloudResType, expect: 12},
+	}
+
+	for _, c := range cases {
+		record := recyclerecord.RecycleRecord{}
+		record.BkBizID, record.ResType = c.bizID, c.resType
+		if got := chooseNotifyBefore(policyNotifyMap, record, 12); got != c.expect {
+			t.Errorf("%s: expect notify before %d, got %d", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestPolicyKey(t *testing.T) {
+	if policyKey(1, enumor.CvmCloudResType) == policyKey(1, enumor.DiskCloudResType) {
+		t.Errorf("policy key should differ by resource type")
+	}
+	if policyKey(1, enumor.CvmCloudResType) == policyKey(11, enumor.CvmCloudResType) {
+		t.Errorf("policy key should differ by business")
+	}
+}
+
+func TestNeedExpireNotify(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) string { return now.Add(d).Format(constant.TimeStdFormat) }
+	newRecord := func(creator, recycledAt string, detail any) recyclerecord.RecycleRecord {
+		record := recyclerecord.RecycleRecord{Detail: detail}
+		record.Creator, record.RecycledAt = creator, recycledAt
+		return record
+	}
+
+	cases := []struct {
+		name         string
+		record       recyclerecord.RecycleRecord
+		notifyBefore int
+		expect       bool
+	}{
+		{name: "inside window", record: newRecord("u", at(time.Hour), nil), notifyBefore: 2, expect: true},
+		{name: "on window edge", record: newRecord("u", at(2*time.Hour), nil), notifyBefore: 2, expect: true},
+		{name: "outside window", record: newRecord("u", at(3*time.Hour), nil), notifyBefore: 2, expect: false},
+		{name: "notify disabled", record: newRecord("u", at(time.Hour), nil), notifyBefore: 0, expect: false},
+		{name: "no recycler", record: newRecord("", at(time.Hour), nil), notifyBefore: 2, expect: false},
+		{name: "invalid recycled at", record: newRecord("u", "bad", nil), notifyBefore: 2, expect: false},
+		{name: "already notified", record: newRecord("u", at(time.Hour),
+			map[string]interface{}{"expire_notified": true}), notifyBefore: 2, expect: false},
+		{name: "not notified yet", record: newRecord("u", at(time.Hour),
+			map[string]interface{}{"expire_notified": false}), notifyBefore: 2, expect: true},
+	}
+
+	for _, c := range cases {
+		if got := needExpireNotify(c.record, c.notifyBefore, now); got != c.expect {
+			t.Errorf("%s: expect %v, got %v", c.name, c.expect, got)
+		}
+	}
+}
+
+func TestBuildExpireNotifyContent(t *testing.T) {
+	records := []recyclerecord.RecycleRecord{{BaseRecycleRecord: recyclerecord.BaseRecycleRecord{
+		ResType:    enumor.CvmCloudResType,
+		ResID:      "00000001",
+		CloudResID: "ins-1",
+		ResName:    "<script>alert(1)</script>",
+		BkBizID:    100,
+		RecycledAt: "2024-11-01 10:00:00",
+	}}}
+
+	content := buildExpireNotifyContent(records)
+	if strings.Contains(content, "<script>") {
+		t.Errorf("resource name is not escaped, content: %s", content)
+	}
+	if !strings.Contains(content, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Errorf("escaped resource name not found, content: %s", content)
+	}
+	if !strings.Contains(content, "<td>ins-1</td>") {
+		t.Errorf("cloud resource id not found, content: %s", content)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"fmt"
+
+	proto "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// CreateRecyclePolicy create recycle policy.
+func (svc *svc) CreateRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.createRecyclePolicy(cts, 0)
+}
+
+// CreateBizRecyclePolicy create biz recycle policy.
+func (svc *svc) CreateBizRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := parseBizID(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.createRecyclePolicy(cts, bizID)
+}
+
+func (svc *svc) createRecyclePolicy(cts *rest.Contexts, bizID int64) (interface{}, error) {
+	req := new(proto.RecyclePolicyCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if bizID != 0 {
+		req.BkBizID = bizID
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRecyclePolicy(cts.Kit, bizID, meta.Create); err != nil {
+		return nil, err
+	}
+
+	// 同一业务下同一资源类型只能有一条回收策略
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("bk_biz_id", req.BkBizID),
+			tools.RuleEqual("res_type", req.ResType),
+		),
+		Page: core.NewCountPage(),
+	}
+	listResp, err := svc.client.DataService().Global.RecyclePolicy.List(cts.Kit, listReq)
+	if err != nil {
+		logs.Errorf("list recycle policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	if listResp.Count > 0 {
+		return nil, errf.Newf(errf.RecordDuplicated, "recycle policy of biz: %d, res_type: %s already exists",
+			req.BkBizID, req.ResType)
+	}
+
+	createReq := &dsrr.RecyclePolicyBatchCreateReq{
+		Policies: []dsrr.RecyclePolicyCreate{{
+			BkBizID:      req.BkBizID,
+			ResType:      req.ResType,
+			ReserveTime:  req.ReserveTime,
+			NotifyBefore: req.NotifyBefore,
+			Memo:         req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.RecyclePolicy.BatchCreate(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create recycle policy failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, fmt.Errorf("create recycle policy but return ids: %v is invalid", result.IDs)
+	}
+
+	return core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateRecyclePolicy update recycle policy.
+func (svc *svc) UpdateRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.updateRecyclePolicy(cts, 0)
+}
+
+// UpdateBizRecyclePolicy update biz recycle policy.
+func (svc *svc) UpdateBizRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := parseBizID(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.updateRecyclePolicy(cts, bizID)
+}
+
+func (svc *svc) updateRecyclePolicy(cts *rest.Contexts, bizID int64) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.RecyclePolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRecyclePolicy(cts.Kit, bizID, meta.Update); err != nil {
+		return nil, err
+	}
+
+	if err := svc.checkRecyclePolicyInBiz(cts.Kit, bizID, []string{id}); err != nil {
+		return nil, err
+	}
+
+	updateReq := &dsrr.RecyclePolicyUpdateReq{
+		ID:           id,
+		ReserveTime:  req.ReserveTime,
+		NotifyBefore: req.NotifyBefore,
+		Memo:         req.Memo,
+	}
+	if err := svc.client.DataService().Global.RecyclePolicy.Update(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update recycle policy failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteRecyclePolicy batch delete recycle policy.
+func (svc *svc) BatchDeleteRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.batchDeleteRecyclePolicy(cts, 0)
+}
+
+// BatchDeleteBizRecyclePolicy batch delete biz recycle policy.
+func (svc *svc) BatchDeleteBizRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := parseBizID(cts)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.batchDeleteRecyclePolicy(cts, bizID)
+}
+
+func (svc *svc) batchDeleteRecyclePolicy(cts *rest.Contexts, bizID int64) (interface{}, error) {
+	req := new(proto.RecyclePolicyDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authRecyclePolicy(cts.Kit, bizID, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	if err := svc.checkRecyclePolicyInBiz(cts.Kit, bizID, req.IDs); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", req.IDs)}
+	if err := svc.client.DataService().Global.RecyclePolicy.BatchDelete(cts.Kit, delReq); err != nil {
+		logs.Errorf("delete recycle policy failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRecyclePolicy list recycle policy.
+func (svc *svc) ListRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.listRecyclePolicy(cts, handler.ListResourceRecycleAuthRes)
+}
+
+// ListBizRecyclePolicy list biz recycle policy.
+func (svc *svc) ListBizRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.listRecyclePolicy(cts, handler.ListBizRecycleAuthRes)
+}
+
+func (svc *svc) listRecyclePolicy(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.RecycleBin, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return new(proto.RecyclePolicyListResult), nil
+	}
+	req.Filter = expr
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return svc.client.DataService().Global.RecyclePolicy.List(cts.Kit, req)
+}
+
+// authRecyclePolicy 回收策略的增删改使用回收站操作权限，bizID为0时校验资源下的权限
+func (svc *svc) authRecyclePolicy(kt *kit.Kit, bizID int64, action meta.Action) error {
+	return svc.authorizer.AuthorizeWithPerm(kt, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.RecycleBin, Action: action}, BizID: bizID,
+	})
+}
+
+// checkRecyclePolicyInBiz 校验回收策略存在，且业务下的接口只能操作本业务的回收策略
+func (svc *svc) checkRecyclePolicyInBiz(kt *kit.Kit, bizID int64, ids []string) error {
+	rules := []*filter.AtomRule{tools.RuleIn("id", ids)}
+	if bizID != 0 {
+		rules = append(rules, tools.RuleEqual("bk_biz_id", bizID))
+	}
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(rules...),
+		Page:   core.NewCountPage(),
+	}
+	listResp, err := svc.client.DataService().Global.RecyclePolicy.List(kt, listReq)
+	if err != nil {
+		logs.Errorf("list recycle policy failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+		return err
+	}
+
+	if int(listResp.Count) != len(ids) {
+		return errf.Newf(errf.InvalidParameter, "recycle policy: %v not all found", ids)
+	}
+
+	return nil
+}
+
+func parseBizID(cts *rest.Contexts) (int64, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return 0, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return 0, errf.New(errf.InvalidParameter, "biz id is invalid")
+	}
+
+	return bizID, nil
+}
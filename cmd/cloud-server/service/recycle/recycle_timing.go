@@ -23,12 +23,20 @@ import (
 	"time"
 
 	"hcm/cmd/cloud-server/logics"
+	"hcm/cmd/cloud-server/logics/async"
+	"hcm/cmd/cloud-server/logics/audit"
 	"hcm/cmd/cloud-server/logics/recycle"
+	"hcm/cmd/cloud-server/logics/webhook"
+	actionlb "hcm/cmd/task-server/logics/action/load-balancer"
+	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	"hcm/pkg/api/core"
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
 	recyclerecord "hcm/pkg/api/core/recycle-record"
 	dataproto "hcm/pkg/api/data-service/cloud"
-	"hcm/pkg/cc"
+	hclb "hcm/pkg/api/hc-service/load-balancer"
+	ts "hcm/pkg/api/task-server"
 	"hcm/pkg/client"
+	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/tools"
@@ -37,33 +45,45 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/serviced"
+	"hcm/pkg/thirdparty/api-gateway/cmsi"
 	"hcm/pkg/thirdparty/esb"
+	"hcm/pkg/tools/converter"
 	"hcm/pkg/tools/retry"
 	"hcm/pkg/tools/slice"
 	"hcm/pkg/tools/times"
 )
 
 type recycle struct {
-	client *client.ClientSet
-	logics *logics.Logics
-	state  serviced.State
+	client  *client.ClientSet
+	logics  *logics.Logics
+	state   serviced.State
+	cmsiCli cmsi.Client
+	webhook webhook.Publisher
 }
 
 // RecycleTiming timing recycle all resource.
-func RecycleTiming(c *client.ClientSet, state serviced.State, conf cc.Recycle, esbClient esb.Client) {
+func RecycleTiming(c *client.ClientSet, state serviced.State, esbClient esb.Client,
+	cmsiCli cmsi.Client, auditLogics audit.Interface, publisher webhook.Publisher) {
+
 	r := &recycle{
-		client: c,
-		state:  state,
-		logics: logics.NewLogics(c, esbClient),
+		client:  c,
+		state:   state,
+		logics:  logics.NewLogics(c, esbClient, auditLogics),
+		cmsiCli: cmsiCli,
+		webhook: publisher,
 	}
 
-	go r.recycleTiming(enumor.DiskCloudResType, r.recycleDiskWorker, conf)
-	go r.recycleTiming(enumor.CvmCloudResType, r.recycleCvmWorker, conf)
+	go r.recycleTiming(enumor.DiskCloudResType, r.recycleDiskWorker)
+	go r.recycleTiming(enumor.CvmCloudResType, r.recycleCvmWorker)
+	go r.recycleTiming(enumor.EipCloudResType, r.recycleEipWorker)
+	go r.recycleTiming(enumor.LoadBalancerCloudResType, r.recycleLoadBalancerWorker)
+	go r.recycleTiming(enumor.SecurityGroupCloudResType, r.recycleSecurityGroupWorker)
+	go r.expireNotifyTiming()
 }
 
 type recycleWorker func(kt *kit.Kit, info *types.CloudResourceBasicInfo) error
 
-func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycleWorker, conf cc.Recycle) {
+func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycleWorker) {
 	for {
 		kt := core.NewBackendKit()
 
@@ -73,6 +93,12 @@ func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycle
 			continue
 		}
 
+		if isPaused() {
+			logs.Infof("recycle %s, but recycle timer is paused, skip", resType)
+			time.Sleep(time.Minute)
+			continue
+		}
+
 		logs.Infof("start recycle %s, rid: %s", resType, kt.Rid)
 		// get need recycled resource records
 		expr, err := tools.And(tools.EqualWithOpExpression(filter.And,
@@ -136,6 +162,11 @@ func (r *recycle) recycleTiming(resType enumor.CloudResourceType, worker recycle
 				time.Sleep(time.Minute)
 				break
 			}
+			if isPaused() {
+				logs.Infof("recycle %s res(id: %s), but recycle timer is paused, skip, rid: %s", resType,
+					record.ResID, kt.Rid)
+				break
+			}
 			r.execWorker(kt, worker, record, basicInfoMap)
 		}
 
@@ -195,3 +226,135 @@ func (r *recycle) recycleCvmWorker(kt *kit.Kit, info *types.CloudResourceBasicIn
 	}
 	return nil
 }
+
+func (r *recycle) recycleEipWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	relReq := &core.ListReq{
+		Filter: tools.EqualExpression("eip_id", info.ID),
+		Page:   core.NewCountPage(),
+	}
+	relRes, err := r.client.DataService().Global.ListEipCvmRel(kt, relReq)
+	if err != nil {
+		logs.Errorf("count eip cvm rel failed, err: %v, eip: %s, rid: %s", err, info.ID, kt.Rid)
+		return err
+	}
+	if converter.PtrToVal(relRes.Count) > 0 {
+		return errf.Newf(errf.InvalidParameter, "recycled eip(%s) is associated, cannot be deleted", info.ID)
+	}
+
+	if err = r.logics.Audit.ResDeleteAudit(kt, enumor.EipAuditResType, []string{info.ID}); err != nil {
+		logs.Errorf("create eip delete audit failed, err: %v, eip: %s, rid: %s", err, info.ID, kt.Rid)
+		return err
+	}
+
+	if err = r.logics.Eip.DeleteEip(kt, info.Vendor, info.ID); err != nil {
+		logs.Errorf("delete eip failed, err: %v, eip: %s, rid: %s", err, info.ID, kt.Rid)
+		return err
+	}
+	return nil
+}
+
+// checkLoadBalancerRecycleVendor 负载均衡回收目前只支持腾讯云，其他云厂商的负载均衡无法加入回收站，
+// 出现时直接报错，由调用方将回收记录标记为失败
+func checkLoadBalancerRecycleVendor(vendor enumor.Vendor) error {
+	if vendor != enumor.TCloud {
+		return errf.Newf(errf.InvalidParameter, "load balancer recycle does not support vendor: %s", vendor)
+	}
+
+	return nil
+}
+
+func (r *recycle) recycleLoadBalancerWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	if err := checkLoadBalancerRecycleVendor(info.Vendor); err != nil {
+		return err
+	}
+
+	// 负载均衡下存在监听器时无法删除，需要先删除监听器，监听器配置已在回收时记录到回收记录中
+	if err := r.deleteLoadBalancerListeners(kt, info.ID); err != nil {
+		return err
+	}
+
+	if err := r.logics.Audit.ResDeleteAudit(kt, enumor.LoadBalancerAuditResType, []string{info.ID}); err != nil {
+		logs.Errorf("create load balancer delete audit failed, err: %v, lb: %s, rid: %s", err, info.ID, kt.Rid)
+		return err
+	}
+
+	flowReq := &ts.AddCustomFlowReq{
+		Name: enumor.FlowDeleteLoadBalancer,
+		Tasks: []ts.CustomFlowTask{{
+			ActionID:   "1",
+			ActionName: enumor.ActionDeleteLoadBalancer,
+			Params: actionlb.DeleteLoadBalancerOption{
+				Vendor: info.Vendor,
+				TCloudBatchDeleteLoadbalancerReq: hclb.TCloudBatchDeleteLoadbalancerReq{
+					AccountID: info.AccountID,
+					Region:    info.Region,
+					IDs:       []string{info.ID},
+				},
+			},
+		}},
+	}
+	return r.createFlowAndWait(kt, flowReq)
+}
+
+func (r *recycle) deleteLoadBalancerListeners(kt *kit.Kit, lbID string) error {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("lb_id", lbID),
+		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
+		Fields: []string{"id"},
+	}
+	for {
+		lblResp, err := r.client.DataService().Global.LoadBalancer.ListListener(kt, listReq)
+		if err != nil {
+			logs.Errorf("list listener failed, err: %v, lb: %s, rid: %s", err, lbID, kt.Rid)
+			return err
+		}
+		if len(lblResp.Details) == 0 {
+			return nil
+		}
+
+		ids := slice.Map(lblResp.Details, func(one corelb.BaseListener) string { return one.ID })
+		if err = r.logics.Audit.ResDeleteAudit(kt, enumor.ListenerAuditResType, ids); err != nil {
+			logs.Errorf("create listener delete audit failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return err
+		}
+		if err = r.client.HCService().TCloud.Clb.DeleteListener(kt, &core.BatchDeleteReq{IDs: ids}); err != nil {
+			logs.Errorf("delete listener failed, err: %v, ids: %v, rid: %s", err, ids, kt.Rid)
+			return err
+		}
+	}
+}
+
+func (r *recycle) recycleSecurityGroupWorker(kt *kit.Kit, info *types.CloudResourceBasicInfo) error {
+	used, err := r.logics.SecurityGroup.IsSecurityGroupUsed(kt, info.Vendor, info.ID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return errf.Newf(errf.InvalidParameter, "recycled security group(%s) is in use, cannot be deleted", info.ID)
+	}
+
+	if err = r.logics.Audit.ResDeleteAudit(kt, enumor.SecurityGroupAuditResType, []string{info.ID}); err != nil {
+		logs.Errorf("create security group delete audit failed, err: %v, sg: %s, rid: %s", err, info.ID, kt.Rid)
+		return err
+	}
+
+	flowReq := &ts.AddCustomFlowReq{
+		Name: enumor.FlowDeleteSecurityGroup,
+		Tasks: []ts.CustomFlowTask{{
+			ActionID:   "1",
+			ActionName: enumor.ActionDeleteSecurityGroup,
+			Params:     actionsg.DeleteSGOption{Vendor: info.Vendor, ID: info.ID},
+		}},
+	}
+	return r.createFlowAndWait(kt, flowReq)
+}
+
+func (r *recycle) createFlowAndWait(kt *kit.Kit, flowReq *ts.AddCustomFlowReq) error {
+	result, err := r.client.TaskServer().CreateCustomFlow(kt, flowReq)
+	if err != nil {
+		logs.Errorf("create %s flow failed, err: %v, rid: %s", flowReq.Name, err, kt.Rid)
+		return err
+	}
+
+	return async.WaitTaskToEnd(kt, r.client.TaskServer(), result.ID)
+}
@@ -0,0 +1,219 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"hcm/cmd/cloud-server/logics"
+	"hcm/cmd/cloud-server/logics/audit"
+	"hcm/pkg/api/core"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+)
+
+// lbRecycleStub 模拟data-service、hc-service、task-server，记录负载均衡回收过程中的下游调用
+type lbRecycleStub struct {
+	listCalls      int
+	deleteLblPath  string
+	deleteLblIDs   []string
+	flowName       enumor.FlowName
+	flowActionName enumor.ActionName
+	flowParams     lbDeleteFlowParams
+	auditResTypes  []enumor.AuditResourceType
+}
+
+type lbDeleteFlowParams struct {
+	Vendor    enumor.Vendor `json:"vendor"`
+	AccountID string        `json:"account_id"`
+	Region    string        `json:"region"`
+	IDs       []string      `json:"ids"`
+}
+
+// Do implements client.HTTPClient.
+func (s *lbRecycleStub) Do(req *http.Request) (*http.Response, error) {
+	body := make([]byte, 0)
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	var data interface{}
+	path := req.URL.Path
+	switch {
+	case strings.HasSuffix(path, "/load_balancers/listeners/list"):
+		// 第一次查询返回监听器，删除后再次查询为空
+		s.listCalls++
+		details := make([]map[string]string, 0)
+		if s.listCalls == 1 {
+			details = append(details, map[string]string{"id": "lbl-1"}, map[string]string{"id": "lbl-2"})
+		}
+		data = map[string]interface{}{"details": details}
+
+	case strings.HasSuffix(path, "/listeners/batch") && req.Method == http.MethodDelete:
+		delReq := new(core.BatchDeleteReq)
+		if err := json.Unmarshal(body, delReq); err != nil {
+			return nil, err
+		}
+		s.deleteLblPath = path
+		s.deleteLblIDs = delReq.IDs
+
+	case strings.HasSuffix(path, "/custom_flows/create"):
+		flowReq := new(struct {
+			Name  enumor.FlowName `json:"name"`
+			Tasks []struct {
+				ActionName enumor.ActionName  `json:"action_name"`
+				Params     lbDeleteFlowParams `json:"params"`
+			} `json:"tasks"`
+		})
+		if err := json.Unmarshal(body, flowReq); err != nil {
+			return nil, err
+		}
+		if len(flowReq.Tasks) != 1 {
+			return nil, fmt.Errorf("unexpected flow tasks: %s", body)
+		}
+		s.flowName = flowReq.Name
+		s.flowActionName = flowReq.Tasks[0].ActionName
+		s.flowParams = flowReq.Tasks[0].Params
+		data = map[string]string{"id": "flow-1"}
+
+	case strings.HasSuffix(path, "/flows/flow-1"):
+		data = map[string]interface{}{"id": "flow-1", "state": enumor.FlowSuccess}
+
+	default:
+		return nil, fmt.Errorf("unexpected request: %s %s", req.Method, path)
+	}
+
+	respBody, err := json.Marshal(map[string]interface{}{"code": errf.OK, "message": "ok", "data": data})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(respBody)),
+		Request:    req,
+	}, nil
+}
+
+// Discover implements serviced.Discover.
+func (s *lbRecycleStub) Discover(cc.Name) ([]string, error) {
+	return []string{"http://127.0.0.1:1"}, nil
+}
+
+// Services implements serviced.Discover.
+func (s *lbRecycleStub) Services() []cc.Name {
+	return []cc.Name{cc.DataServiceName, cc.HCServiceName, cc.TaskServerName}
+}
+
+// GetServiceAllNodeKeys implements serviced.Discover.
+func (s *lbRecycleStub) GetServiceAllNodeKeys(cc.Name) ([]string, error) {
+	return nil, nil
+}
+
+// lbRecycleAuditStub 记录资源删除审计，其余审计方法不应被调用
+type lbRecycleAuditStub struct {
+	audit.Interface
+	stub *lbRecycleStub
+}
+
+// ResDeleteAudit ...
+func (a *lbRecycleAuditStub) ResDeleteAudit(_ *kit.Kit, resType enumor.AuditResourceType, _ []string) error {
+	a.stub.auditResTypes = append(a.stub.auditResTypes, resType)
+	return nil
+}
+
+func TestRecycleLoadBalancerWorker(t *testing.T) {
+	stub := new(lbRecycleStub)
+	r := &recycle{
+		client: client.NewClientSet(stub, stub),
+		logics: &logics.Logics{Audit: &lbRecycleAuditStub{stub: stub}},
+	}
+
+	info := &types.CloudResourceBasicInfo{ID: "lb-1", Vendor: enumor.TCloud, AccountID: "account-1",
+		Region: "ap-guangzhou"}
+	if err := r.recycleLoadBalancerWorker(kit.New(), info); err != nil {
+		t.Fatalf("recycle tcloud load balancer failed, err: %v", err)
+	}
+
+	// 先通过tcloud的hc-service接口删除负载均衡下的监听器
+	if !strings.HasSuffix(stub.deleteLblPath, "/hc/vendors/tcloud/listeners/batch") {
+		t.Errorf("listeners should be deleted by tcloud hc-service api, got path: %s", stub.deleteLblPath)
+	}
+	if !reflect.DeepEqual(stub.deleteLblIDs, []string{"lbl-1", "lbl-2"}) {
+		t.Errorf("unexpected deleted listeners: %v", stub.deleteLblIDs)
+	}
+	if stub.listCalls != 2 {
+		t.Errorf("listeners should be listed until empty, list calls: %d", stub.listCalls)
+	}
+
+	// 再通过异步任务删除负载均衡
+	if stub.flowName != enumor.FlowDeleteLoadBalancer || stub.flowActionName != enumor.ActionDeleteLoadBalancer {
+		t.Errorf("unexpected flow: %s, action: %s", stub.flowName, stub.flowActionName)
+	}
+	expectParams := lbDeleteFlowParams{Vendor: enumor.TCloud, AccountID: "account-1", Region: "ap-guangzhou",
+		IDs: []string{"lb-1"}}
+	if !reflect.DeepEqual(stub.flowParams, expectParams) {
+		t.Errorf("unexpected delete load balancer params: %+v", stub.flowParams)
+	}
+
+	expectAudits := []enumor.AuditResourceType{enumor.ListenerAuditResType, enumor.LoadBalancerAuditResType}
+	if !reflect.DeepEqual(stub.auditResTypes, expectAudits) {
+		t.Errorf("unexpected delete audits: %v", stub.auditResTypes)
+	}
+}
+
+func TestRecycleLoadBalancerWorkerUnsupportedVendor(t *testing.T) {
+	// 不支持的云厂商在调用任何下游服务前即返回错误，回收记录被标记为失败
+	stub := new(lbRecycleStub)
+	r := &recycle{
+		client: client.NewClientSet(stub, stub),
+		logics: &logics.Logics{Audit: &lbRecycleAuditStub{stub: stub}},
+	}
+	for _, vendor := range []enumor.Vendor{enumor.Aws, enumor.Gcp, enumor.Azure, enumor.HuaWei, ""} {
+		info := &types.CloudResourceBasicInfo{ID: "lb-1", Vendor: vendor}
+		err := r.recycleLoadBalancerWorker(kit.New(), info)
+		if err == nil {
+			t.Errorf("load balancer of vendor %q should not be recycled", vendor)
+			continue
+		}
+		if ef := errf.Error(err); ef.Code != errf.InvalidParameter {
+			t.Errorf("load balancer of vendor %q should be rejected as invalid parameter, got: %v", vendor, err)
+		}
+	}
+
+	if stub.listCalls != 0 || len(stub.deleteLblIDs) != 0 || stub.flowName != "" || len(stub.auditResTypes) != 0 {
+		t.Errorf("unsupported vendor should not call any downstream service, stub: %+v", stub)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"hcm/cmd/cloud-server/logics/audit"
+	logicssg "hcm/cmd/cloud-server/logics/security-group"
 	"hcm/cmd/cloud-server/service/capability"
 	"hcm/pkg/client"
 	"hcm/pkg/iam/auth"
@@ -35,6 +36,7 @@ func InitSecurityGroupService(c *capability.Capability) {
 		client:     c.ApiClient,
 		authorizer: c.Authorizer,
 		audit:      c.Audit,
+		sgLogic:    c.Logics.SecurityGroup,
 	}
 
 	h := rest.NewHandler()
@@ -44,8 +46,13 @@ func InitSecurityGroupService(c *capability.Capability) {
 	h.Add("GetSecurityGroup", http.MethodGet, "/security_groups/{id}", svc.GetSecurityGroup)
 	h.Add("BatchUpdateSecurityGroup", http.MethodPatch, "/security_groups/{id}", svc.UpdateSecurityGroup)
 	h.Add("BatchDeleteSecurityGroup", http.MethodDelete, "/security_groups/batch", svc.BatchDeleteSecurityGroup)
+	h.Add("RecycleSecurityGroup", http.MethodPost, "/security_groups/recycle", svc.RecycleSecurityGroup)
+	h.Add("RecoverSecurityGroup", http.MethodPost, "/security_groups/recover", svc.RecoverSecurityGroup)
 	h.Add("ListSecurityGroup", http.MethodPost, "/security_groups/list", svc.ListSecurityGroup)
 	h.Add("ListSecurityGroupsByCvmID", http.MethodGet, "/security_groups/cvms/{cvm_id}", svc.ListSecurityGroupsByCvmID)
+	h.Add("EvaluateCvmSGPolicy", http.MethodPost, "/security_groups/cvms/{cvm_id}/policy/evaluate",
+		svc.EvaluateCvmSGPolicy)
+	h.Add("ListSGLintFinding", http.MethodPost, "/security_groups/lint_findings/list", svc.ListSGLintFinding)
 	h.Add("AssignSecurityGroupToBiz", http.MethodPost, "/security_groups/assign/bizs", svc.AssignSecurityGroupToBiz)
 	h.Add("AssociateCvm", http.MethodPost, "/security_groups/associate/cvms", svc.AssociateCvm)
 	h.Add("DisassociateCvm", http.MethodPost, "/security_groups/disassociate/cvms", svc.DisassociateCvm)
@@ -86,9 +93,19 @@ func bizService(h *rest.Handler, svc *securityGroupSvc) {
 		svc.UpdateBizSecurityGroup)
 	h.Add("BatchDeleteBizSecurityGroup", http.MethodDelete, "/bizs/{bk_biz_id}/security_groups/batch",
 		svc.BatchDeleteBizSecurityGroup)
+	h.Add("RecycleBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/recycle",
+		svc.RecycleBizSecurityGroup)
+	h.Add("RecoverBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/recover",
+		svc.RecoverBizSecurityGroup)
 	h.Add("ListBizSecurityGroup", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/list", svc.ListBizSecurityGroup)
 	h.Add("ListBizSecurityGroupsByCvmID", http.MethodGet, "/bizs/{bk_biz_id}/security_groups/cvms/{cvm_id}",
 		svc.ListBizSecurityGroupsByCvmID)
+	h.Add("EvaluateBizCvmSGPolicy", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/cvms/{cvm_id}/policy/evaluate",
+		svc.EvaluateBizCvmSGPolicy)
+	h.Add("ListBizSGLintFinding", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/lint_findings/list",
+		svc.ListBizSGLintFinding)
+	h.Add("GetBizSGLintSummary", http.MethodGet, "/bizs/{bk_biz_id}/security_groups/lint_findings/summary",
+		svc.GetBizSGLintSummary)
 	h.Add("AssociateBizCvm", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/associate/cvms", svc.AssociateBizCvm)
 	h.Add("DisassociateCvm", http.MethodPost, "/bizs/{bk_biz_id}/security_groups/disassociate/cvms",
 		svc.DisassociateBizCvm)
@@ -126,4 +143,5 @@ type securityGroupSvc struct {
 	client     *client.ClientSet
 	authorizer auth.Authorizer
 	audit      audit.Interface
+	sgLogic    logicssg.Interface
 }
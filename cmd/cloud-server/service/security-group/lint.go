@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	"hcm/pkg/api/core"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// ListSGLintFinding list security group lint finding.
+func (svc *securityGroupSvc) ListSGLintFinding(cts *rest.Contexts) (interface{}, error) {
+	return svc.listSGLintFinding(cts, handler.ListResourceAuthRes)
+}
+
+// ListBizSGLintFinding list biz security group lint finding.
+func (svc *securityGroupSvc) ListBizSGLintFinding(cts *rest.Contexts) (interface{}, error) {
+	return svc.listSGLintFinding(cts, handler.ListBizAuthRes)
+}
+
+func (svc *securityGroupSvc) listSGLintFinding(cts *rest.Contexts, authHandler handler.ListAuthResHandler) (
+	interface{}, error) {
+
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	expr, noPermFlag, err := authHandler(cts, &handler.ListAuthResOption{Authorizer: svc.authorizer,
+		ResType: meta.SecurityGroup, Action: meta.Find, Filter: req.Filter})
+	if err != nil {
+		return nil, err
+	}
+
+	if noPermFlag {
+		return &dataproto.SGLintFindingListResult{Count: 0, Details: nil}, nil
+	}
+	req.Filter = expr
+
+	return svc.client.DataService().Global.SGLintFinding.List(cts.Kit, req)
+}
+
+// GetBizSGLintSummary get biz security group lint risk report.
+func (svc *securityGroupSvc) GetBizSGLintSummary(cts *rest.Contexts) (interface{}, error) {
+	bizID, err := cts.PathParameter("bk_biz_id").Int64()
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if bizID <= 0 {
+		return nil, errf.New(errf.InvalidParameter, "biz id is invalid")
+	}
+
+	err = svc.authorizer.AuthorizeWithPerm(cts.Kit, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.SecurityGroup, Action: meta.Find}, BizID: bizID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := svc.sgLogic.LintSummary(cts.Kit, bizID)
+	if err != nil {
+		logs.Errorf("get biz security group lint summary failed, err: %v, biz: %d, rid: %s", err, bizID,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return summary, nil
+}
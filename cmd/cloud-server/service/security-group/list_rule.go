@@ -20,13 +20,13 @@
 package securitygroup
 
 import (
+	logicssg "hcm/cmd/cloud-server/logics/security-group"
 	proto "hcm/pkg/api/cloud-server"
 	dataproto "hcm/pkg/api/data-service/cloud"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/iam/meta"
 	"hcm/pkg/rest"
-	"hcm/pkg/tools/converter"
 	"hcm/pkg/tools/hooks/handler"
 )
 
@@ -117,113 +117,10 @@ func (svc *securityGroupSvc) listSGRule(cts *rest.Contexts, validHandler handler
 func (svc *securityGroupSvc) GetAzureDefaultSGRule(cts *rest.Contexts) (interface{}, error) {
 	ruleType := enumor.SecurityGroupRuleType(cts.PathParameter("type").String())
 
-	rules, exist := azureDefaultSGRuleMap[ruleType]
+	rules, exist := logicssg.AzureDefaultSGRuleMap[ruleType]
 	if !exist {
 		return nil, errf.Newf(errf.InvalidParameter, "rule type: %s not support", ruleType)
 	}
 
 	return rules, nil
 }
-
-// AzureDefaultSGRule define azure default security group rule.
-type AzureDefaultSGRule struct {
-	Name                                string                       `json:"name"`
-	Memo                                *string                      `json:"memo"`
-	DestinationAddressPrefix            *string                      `json:"destination_address_prefix"`
-	DestinationAddressPrefixes          []*string                    `json:"destination_address_prefixes"`
-	CloudDestinationAppSecurityGroupIDs []*string                    `json:"cloud_destination_app_security_group_ids"`
-	DestinationPortRange                *string                      `json:"destination_port_range"`
-	DestinationPortRanges               []*string                    `json:"destination_port_ranges"`
-	Protocol                            string                       `json:"protocol"`
-	ProvisioningState                   string                       `json:"provisioning_state"`
-	SourceAddressPrefix                 *string                      `json:"source_address_prefix"`
-	SourceAddressPrefixes               []*string                    `json:"source_address_prefixes"`
-	CloudSourceAppSecurityGroupIDs      []*string                    `json:"cloud_source_app_security_group_ids"`
-	SourcePortRange                     *string                      `json:"source_port_range"`
-	SourcePortRanges                    []*string                    `json:"source_port_ranges"`
-	Priority                            int32                        `json:"priority"`
-	Type                                enumor.SecurityGroupRuleType `json:"type"`
-	Access                              string                       `json:"access"`
-}
-
-// TODO: 之后考虑是否通过同步的方式将这几条默认安全组规则同步进来，而不是写死。
-// reference:
-// https://learn.microsoft.com/zh-cn/azure/virtual-network/network-security-groups-overview#default-security-rules
-var azureDefaultSGRuleMap = map[enumor.SecurityGroupRuleType][]AzureDefaultSGRule{
-	enumor.Egress: {
-		{
-			Name:                     "AllowVnetOutBound",
-			Memo:                     converter.ValToPtr("Allow outbound traffic from all VMs to all VMs in VNET"),
-			Protocol:                 "*",
-			SourcePortRange:          converter.ValToPtr("*"),
-			DestinationPortRange:     converter.ValToPtr("*"),
-			SourceAddressPrefix:      converter.ValToPtr("VirtualNetwork"),
-			DestinationAddressPrefix: converter.ValToPtr("VirtualNetwork"),
-			Access:                   "Allow",
-			Priority:                 65000,
-			Type:                     enumor.Egress,
-		},
-		{
-			Name:                     "AllowInternetOutBound",
-			Memo:                     converter.ValToPtr("Allow outbound traffic from all VMs to Internet"),
-			Protocol:                 "*",
-			SourcePortRange:          converter.ValToPtr("*"),
-			DestinationPortRange:     converter.ValToPtr("*"),
-			SourceAddressPrefix:      converter.ValToPtr("*"),
-			DestinationAddressPrefix: converter.ValToPtr("Internet"),
-			Access:                   "Allow",
-			Priority:                 65001,
-			Type:                     enumor.Egress,
-		},
-		{
-			Name:                     "DenyAllOutBound",
-			Memo:                     converter.ValToPtr("Deny all outbound traffic"),
-			Protocol:                 "*",
-			SourcePortRange:          converter.ValToPtr("*"),
-			DestinationPortRange:     converter.ValToPtr("*"),
-			SourceAddressPrefix:      converter.ValToPtr("*"),
-			DestinationAddressPrefix: converter.ValToPtr("*"),
-			Access:                   "Deny",
-			Priority:                 65500,
-			Type:                     enumor.Egress,
-		},
-	},
-	enumor.Ingress: {
-		{
-			Name:                     "AllowVnetInBound",
-			Memo:                     converter.ValToPtr("Allow inbound traffic from all VMs in VNET"),
-			Protocol:                 "*",
-			SourcePortRange:          converter.ValToPtr("*"),
-			DestinationPortRange:     converter.ValToPtr("*"),
-			SourceAddressPrefix:      converter.ValToPtr("VirtualNetwork"),
-			DestinationAddressPrefix: converter.ValToPtr("VirtualNetwork"),
-			Access:                   "Allow",
-			Priority:                 65000,
-			Type:                     enumor.Ingress,
-		},
-		{
-			Name:                     "AllowAzureLoadBalancerInBound",
-			Memo:                     converter.ValToPtr("Allow inbound traffic from azure load balancer"),
-			Protocol:                 "*",
-			SourcePortRange:          converter.ValToPtr("*"),
-			DestinationPortRange:     converter.ValToPtr("*"),
-			SourceAddressPrefix:      converter.ValToPtr("AzureLoadBalancer"),
-			DestinationAddressPrefix: converter.ValToPtr("*"),
-			Access:                   "Allow",
-			Priority:                 65001,
-			Type:                     enumor.Ingress,
-		},
-		{
-			Name:                     "DenyAllInBound",
-			Memo:                     converter.ValToPtr("Deny all inbound traffic"),
-			Protocol:                 "*",
-			SourcePortRange:          converter.ValToPtr("*"),
-			DestinationPortRange:     converter.ValToPtr("*"),
-			SourceAddressPrefix:      converter.ValToPtr("*"),
-			DestinationAddressPrefix: converter.ValToPtr("*"),
-			Access:                   "Deny",
-			Priority:                 65500,
-			Type:                     enumor.Ingress,
-		},
-	},
-}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	proto "hcm/pkg/api/cloud-server"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+)
+
+// EvaluateCvmSGPolicy evaluate effective security group policy of cvm.
+func (svc *securityGroupSvc) EvaluateCvmSGPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.evaluateCvmSGPolicy(cts, handler.ResOperateAuth)
+}
+
+// EvaluateBizCvmSGPolicy evaluate effective security group policy of biz cvm.
+func (svc *securityGroupSvc) EvaluateBizCvmSGPolicy(cts *rest.Contexts) (interface{}, error) {
+	return svc.evaluateCvmSGPolicy(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) evaluateCvmSGPolicy(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	cvmID := cts.PathParameter("cvm_id").String()
+	if len(cvmID) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "cvm_id is required")
+	}
+
+	req := new(proto.SGPolicyEvaluateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	baseInfo, err := svc.client.DataService().Global.Cloud.GetResBasicInfo(cts.Kit, enumor.CvmCloudResType, cvmID)
+	if err != nil {
+		logs.Errorf("get resource vendor failed, err: %v, cvmID: %s, rid: %s", err, cvmID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Find, BasicInfo: baseInfo})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := svc.sgLogic.EvaluateCvmPolicy(cts.Kit, baseInfo.Vendor, cvmID, req)
+	if err != nil {
+		logs.Errorf("evaluate cvm security group policy failed, err: %v, cvmID: %s, req: %+v, rid: %s", err, cvmID,
+			req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
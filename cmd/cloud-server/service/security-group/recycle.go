@@ -0,0 +1,240 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package securitygroup
+
+import (
+	logicsrecycle "hcm/cmd/cloud-server/logics/recycle"
+	logicssg "hcm/cmd/cloud-server/logics/security-group"
+	csrecycle "hcm/pkg/api/cloud-server/recycle"
+	"hcm/pkg/api/core"
+	corerr "hcm/pkg/api/core/recycle-record"
+	protoaudit "hcm/pkg/api/data-service/audit"
+	dataproto "hcm/pkg/api/data-service/cloud"
+	dsrr "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hooks/handler"
+	"hcm/pkg/tools/slice"
+)
+
+// RecycleSecurityGroup recycle security group.
+func (svc *securityGroupSvc) RecycleSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleSecurityGroup(cts, handler.ResOperateAuth)
+}
+
+// RecycleBizSecurityGroup recycle biz security group.
+func (svc *securityGroupSvc) RecycleBizSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recycleSecurityGroup(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) recycleSecurityGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrecycle.ResRecycleReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SecurityGroupCloudResType,
+		IDs:          req.IDs,
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Recycle, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(req.IDs))
+	opt := &dsrr.BatchRecycleReq{
+		ResType:            enumor.SecurityGroupCloudResType,
+		DefaultRecycleTime: cc.CloudServer().Recycle.AutoDeleteTime,
+		Infos:              make([]dsrr.RecycleReq, 0, len(req.IDs)),
+	}
+	for _, id := range req.IDs {
+		info, exists := basicInfoMap[id]
+		if !exists {
+			return nil, errf.Newf(errf.RecordNotFound, "security group(%s) not found", id)
+		}
+
+		detail, err := svc.buildSGRecycleDetail(cts.Kit, info.Vendor, id)
+		if err != nil {
+			return nil, err
+		}
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: id, Data: detail})
+		opt.Infos = append(opt.Infos, dsrr.RecycleReq{ID: id, Detail: detail})
+	}
+
+	// create recycle audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.SecurityGroupAuditResType,
+		Action:  protoaudit.Recycle,
+		Infos:   auditInfos,
+	}
+	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create recycle audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	taskID, err := svc.client.DataService().Global.RecycleRecord.BatchRecycleCloudRes(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("recycle security group failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &csrecycle.RecycleResult{TaskID: taskID}, nil
+}
+
+// buildSGRecycleDetail 使用中的安全组不允许回收，未使用的安全组记录其规则快照
+func (svc *securityGroupSvc) buildSGRecycleDetail(kt *kit.Kit, vendor enumor.Vendor, sgID string) (
+	*corerr.SecurityGroupRecycleDetail, error) {
+
+	used, err := svc.sgLogic.IsSecurityGroupUsed(kt, vendor, sgID)
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		return nil, errf.Newf(errf.InvalidParameter, "security group(%s) is in use, can not be recycled", sgID)
+	}
+
+	rules, err := svc.sgLogic.ListSGRules(kt, vendor, sgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corerr.SecurityGroupRecycleDetail{
+		Rules: slice.Map(rules, func(one logicssg.Rule) corerr.SGRuleSnapshot {
+			return corerr.SGRuleSnapshot{
+				CloudID:   one.CloudID,
+				Type:      one.Type,
+				Priority:  one.Priority,
+				Protocol:  one.Protocol,
+				Ports:     one.Ports,
+				Addresses: one.Addresses,
+				Action:    one.Action,
+				Memo:      one.Memo,
+			}
+		}),
+	}, nil
+}
+
+// RecoverSecurityGroup recover security group.
+func (svc *securityGroupSvc) RecoverSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverSecurityGroup(cts, handler.ResOperateAuth)
+}
+
+// RecoverBizSecurityGroup recover biz security group.
+func (svc *securityGroupSvc) RecoverBizSecurityGroup(cts *rest.Contexts) (interface{}, error) {
+	return svc.recoverSecurityGroup(cts, handler.BizOperateAuth)
+}
+
+func (svc *securityGroupSvc) recoverSecurityGroup(cts *rest.Contexts, validHandler handler.ValidWithAuthHandler) (
+	interface{}, error) {
+
+	req := new(csrecycle.ResRecoverReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	listReq := &core.ListReq{
+		Filter: tools.ContainersExpression("id", req.RecordIDs),
+		Page:   &core.BasePage{Limit: constant.BatchOperationMaxLimit},
+	}
+	records, err := svc.client.DataService().Global.RecycleRecord.ListRecycleRecord(cts.Kit, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records.Details) != len(req.RecordIDs) {
+		return nil, errf.New(errf.InvalidParameter, "some record_ids are not in recycle bin")
+	}
+	err = logicsrecycle.ValidateRecycleRecord(records.Details, enumor.SecurityGroupCloudResType)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	ids := make([]string, 0, len(records.Details))
+	auditInfos := make([]protoaudit.CloudResRecycleAuditInfo, 0, len(records.Details))
+	for _, record := range records.Details {
+		ids = append(ids, record.ResID)
+		auditInfos = append(auditInfos, protoaudit.CloudResRecycleAuditInfo{ResID: record.ResID})
+	}
+
+	basicInfoReq := dataproto.ListResourceBasicInfoReq{
+		ResourceType: enumor.SecurityGroupCloudResType,
+		IDs:          ids,
+		Fields:       append(types.CommonBasicInfoFields, "recycle_status"),
+	}
+	basicInfoMap, err := svc.client.DataService().Global.Cloud.ListResBasicInfo(cts.Kit, basicInfoReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate biz and authorize
+	err = validHandler(cts, &handler.ValidWithAuthOption{Authorizer: svc.authorizer, ResType: meta.SecurityGroup,
+		Action: meta.Recover, BasicInfos: basicInfoMap})
+	if err != nil {
+		return nil, err
+	}
+
+	// create recover audit
+	auditReq := &protoaudit.CloudResourceRecycleAuditReq{
+		ResType: enumor.SecurityGroupAuditResType,
+		Action:  protoaudit.Recover,
+		Infos:   auditInfos,
+	}
+	if err = svc.audit.ResRecycleAudit(cts.Kit, auditReq); err != nil {
+		logs.Errorf("create recover audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &dsrr.BatchRecoverReq{
+		ResType:   enumor.SecurityGroupCloudResType,
+		RecordIDs: req.RecordIDs,
+	}
+	if err = svc.client.DataService().Global.RecycleRecord.BatchRecoverCloudResource(cts.Kit, opt); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -30,7 +30,9 @@ import (
 
 	"hcm/cmd/cloud-server/logics"
 	logicaudit "hcm/cmd/cloud-server/logics/audit"
+	logicwebhook "hcm/cmd/cloud-server/logics/webhook"
 	"hcm/cmd/cloud-server/service/account"
+	apitoken "hcm/cmd/cloud-server/service/api-token"
 	"hcm/cmd/cloud-server/service/application"
 	appcvm "hcm/cmd/cloud-server/service/application/handlers/cvm"
 	approvalprocess "hcm/cmd/cloud-server/service/approval_process"
@@ -44,6 +46,7 @@ import (
 	"hcm/cmd/cloud-server/service/cert"
 	cloudselection "hcm/cmd/cloud-server/service/cloud-selection"
 	"hcm/cmd/cloud-server/service/cvm"
+	"hcm/cmd/cloud-server/service/dependency"
 	"hcm/cmd/cloud-server/service/disk"
 	"hcm/cmd/cloud-server/service/eip"
 	"hcm/cmd/cloud-server/service/firewall"
@@ -51,6 +54,7 @@ import (
 	instancetype "hcm/cmd/cloud-server/service/instance-type"
 	loadbalancer "hcm/cmd/cloud-server/service/load-balancer"
 	networkinterface "hcm/cmd/cloud-server/service/network-interface"
+	"hcm/cmd/cloud-server/service/rbac"
 	"hcm/cmd/cloud-server/service/recycle"
 	"hcm/cmd/cloud-server/service/region"
 	resourcegroup "hcm/cmd/cloud-server/service/resource-group"
@@ -62,6 +66,7 @@ import (
 	"hcm/cmd/cloud-server/service/sync/lock"
 	"hcm/cmd/cloud-server/service/user"
 	"hcm/cmd/cloud-server/service/vpc"
+	"hcm/cmd/cloud-server/service/webhook"
 	"hcm/cmd/cloud-server/service/zone"
 	"hcm/pkg/cc"
 	"hcm/pkg/client"
@@ -73,6 +78,8 @@ import (
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
+	"hcm/pkg/rest/ratelimit"
+	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/thirdparty/api-gateway/bkbase"
@@ -97,6 +104,9 @@ type Service struct {
 	itsmCli   itsm.Client
 	bkBaseCli bkbase.Client
 	cmsiCli   cmsi.Client
+	webhook   logicwebhook.Publisher
+	// limiter 接口限流器，未开启限流时为nil
+	limiter *ratelimit.Limiter
 }
 
 // NewService create a service instance.
@@ -115,20 +125,19 @@ func NewService(sd serviced.ServiceDiscover) (*Service, error) {
 		return nil, err
 	}
 
-	if cc.CloudServer().CloudResource.Sync.Enable {
-		interval := time.Duration(cc.CloudServer().CloudResource.Sync.SyncIntervalMin) * time.Minute
-		go sync.CloudResourceSync(interval, sd, apiClientSet)
-	}
+	go sync.CloudResourceSync(sd, apiClientSet)
 
 	if cc.CloudServer().BillConfig.Enable {
 		interval := time.Duration(cc.CloudServer().BillConfig.SyncIntervalMin) * time.Minute
 		go bill.CloudBillConfigCreate(interval, sd, apiClientSet)
 	}
 
-	recycle.RecycleTiming(apiClientSet, sd, cc.CloudServer().Recycle, esbClient)
+	recycle.RecycleTiming(apiClientSet, sd, esbClient, svr.cmsiCli, svr.audit, svr.webhook)
 
 	go appcvm.TimingHandleDeliverApplication(svr.client, 2*time.Second)
 
+	go logicwebhook.WatchFlowComplete(apiClientSet, sd, svr.webhook)
+
 	return svr, nil
 }
 
@@ -190,15 +199,25 @@ func getCloudClientSvr(sd serviced.ServiceDiscover) (*client.ClientSet, esb.Clie
 		return nil, nil, nil, err
 	}
 
+	limiter, err := ratelimit.New(cc.CloudServer().RateLimit, cc.CloudServer().Service)
+	if err != nil {
+		logs.Errorf("failed to create rate limiter, err: %v", err)
+		return nil, nil, nil, err
+	}
+
+	// 审计记录成功后发布审计事件到webhook订阅方
+	publisher := logicwebhook.NewPublisher(apiClientSet, cipher)
 	svr := &Service{
 		client:     apiClientSet,
 		authorizer: authorizer,
-		audit:      logicaudit.NewAudit(apiClientSet.DataService()),
+		audit:      logicwebhook.NewAudit(logicaudit.NewAudit(apiClientSet.DataService()), publisher),
 		cipher:     cipher,
 		esbClient:  esbClient,
 		itsmCli:    itsmCli,
 		bkBaseCli:  bkbaseCli,
 		cmsiCli:    cmsiCli,
+		webhook:    publisher,
+		limiter:    limiter,
 	}
 
 	return apiClientSet, esbClient, svr, nil
@@ -272,6 +291,10 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	ws := new(restful.WebService)
 	ws.Path("/api/v1/cloud")
 	ws.Produces(restful.MIME_JSON)
+	if s.limiter != nil {
+		// api-server转发的请求已在api-server限流，不重复计数
+		ws.Filter(s.limiter.RestFilter(cc.APIServerName))
+	}
 
 	c := &capability.Capability{
 		WebService: ws,
@@ -280,10 +303,11 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 		Audit:      s.audit,
 		Cipher:     s.cipher,
 		EsbClient:  s.esbClient,
-		Logics:     logics.NewLogics(s.client, s.esbClient),
+		Logics:     logics.NewLogics(s.client, s.esbClient, s.audit),
 		ItsmCli:    s.itsmCli,
 		BKBaseCli:  s.bkBaseCli,
 		CmsiCli:    s.cmsiCli,
+		Webhook:    s.webhook,
 	}
 
 	account.InitAccountService(c)
@@ -307,6 +331,7 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	audit.InitService(c)
 	assign.InitService(c)
 	recycle.InitService(c)
+	dependency.InitService(c)
 	bill.InitBillService(c)
 
 	user.InitService(c)
@@ -319,6 +344,9 @@ func (s *Service) apiSet(bkHcmUrl string) *restful.Container {
 	asynctask.InitService(c)
 
 	bandwidthpackage.InitService(c)
+	webhook.InitService(c)
+	rbac.InitService(c)
+	apitoken.InitService(c)
 
 	return restful.NewContainer().Add(c.WebService)
 }
@@ -341,3 +369,9 @@ func (s *Service) Healthz(w http.ResponseWriter, _ *http.Request) {
 	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
 	return
 }
+
+// CtlCommands returns the cloud server specific control tool commands.
+func (s *Service) CtlCommands() []cmd.Cmd {
+	cmds := append(cmd.WithResSync(sync.NewController(s.client)), cmd.WithRecycle(recycle.NewController())...)
+	return append(cmds, cmd.WithReleaseResFlowLock(loadbalancer.NewResFlowLockReleaser(s.client)))
+}
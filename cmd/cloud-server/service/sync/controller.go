@@ -0,0 +1,111 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"sort"
+
+	"hcm/cmd/cloud-server/logics/account"
+	"hcm/pkg/cc"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/ctl/cmd"
+	"hcm/pkg/serviced"
+	"hcm/pkg/tools/slice"
+)
+
+// pausedVendorsConfigKey 暂停定时同步的云厂商的动态配置项
+const pausedVendorsConfigKey = "cloudResource.sync.pausedVendors"
+
+// currentPausedVendors 获取暂停定时同步的云厂商，暂停状态持久化在动态配置中，对全部节点生效且重启后保持
+func currentPausedVendors() []enumor.Vendor {
+	return cc.CloudServer().CloudResource.Sync.PausedVendors
+}
+
+func isVendorPaused(vendor enumor.Vendor) bool {
+	return slice.IsItemInSlice(currentPausedVendors(), vendor)
+}
+
+var _ cmd.ResSyncController = new(Controller)
+
+// Controller 云资源定时同步运行时控制器
+type Controller struct {
+	cliSet *client.ClientSet
+	// putConfig 持久化动态配置并立即生效
+	putConfig func(key string, value interface{}) error
+	// pausedVendors 获取当前生效的暂停定时同步的云厂商
+	pausedVendors func() []enumor.Vendor
+}
+
+// NewController new cloud resource sync controller.
+func NewController(cliSet *client.ClientSet) *Controller {
+	return &Controller{cliSet: cliSet, putConfig: serviced.PutConfig, pausedVendors: currentPausedVendors}
+}
+
+// PauseVendor 暂停云厂商的定时同步，不影响正在进行中的同步
+func (c *Controller) PauseVendor(vendor enumor.Vendor) error {
+	vendors := c.PausedVendors()
+	if slice.IsItemInSlice(vendors, vendor) {
+		return nil
+	}
+
+	vendors = append(vendors, vendor)
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i] < vendors[j] })
+	return c.putConfig(pausedVendorsConfigKey, vendors)
+}
+
+// ResumeVendor 恢复云厂商的定时同步
+func (c *Controller) ResumeVendor(vendor enumor.Vendor) error {
+	vendors := c.PausedVendors()
+	if !slice.IsItemInSlice(vendors, vendor) {
+		return nil
+	}
+
+	remained := make([]enumor.Vendor, 0, len(vendors))
+	for _, one := range vendors {
+		if one != vendor {
+			remained = append(remained, one)
+		}
+	}
+	return c.putConfig(pausedVendorsConfigKey, remained)
+}
+
+// PausedVendors 获取暂停定时同步的云厂商
+func (c *Controller) PausedVendors() []enumor.Vendor {
+	vendors := append([]enumor.Vendor{}, c.pausedVendors()...)
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i] < vendors[j] })
+
+	return vendors
+}
+
+// SyncAccount 立即同步账号下的全部云资源，同步异步执行，同一账号不可并行同步
+func (c *Controller) SyncAccount(kt *kit.Kit, accountID string) error {
+	baseInfo, err := c.cliSet.DataService().Global.Cloud.GetResBasicInfo(kt, enumor.AccountCloudResType, accountID)
+	if err != nil {
+		logs.Errorf("get account basic info failed, err: %v, accountID: %s, rid: %s", err, accountID, kt.Rid)
+		return err
+	}
+
+	// 与周期同步一致，作为后台同步执行
+	kt.RequestSource = enumor.BackgroundSync
+	return account.Sync(kt, c.cliSet, baseInfo.Vendor, accountID)
+}
@@ -0,0 +1,75 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sync
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// newTestController 使用内存模拟动态配置，写入的配置立即作为当前生效的配置
+func newTestController(config map[string]interface{}) *Controller {
+	return &Controller{
+		putConfig: func(key string, value interface{}) error {
+			config[key] = value
+			return nil
+		},
+		pausedVendors: func() []enumor.Vendor {
+			vendors, _ := config[pausedVendorsConfigKey].([]enumor.Vendor)
+			return vendors
+		},
+	}
+}
+
+func TestControllerPauseResumeVendor(t *testing.T) {
+	config := make(map[string]interface{})
+	c := newTestController(config)
+
+	for _, vendor := range []enumor.Vendor{enumor.TCloud, enumor.Aws, enumor.TCloud} {
+		if err := c.PauseVendor(vendor); err != nil {
+			t.Fatalf("pause %s failed, err: %v", vendor, err)
+		}
+	}
+	want := []enumor.Vendor{enumor.Aws, enumor.TCloud}
+	if got := config[pausedVendorsConfigKey]; !reflect.DeepEqual(got, want) {
+		t.Fatalf("paused vendors should be persisted sorted and deduplicated, got: %v", got)
+	}
+	if got := c.PausedVendors(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected paused vendors: %v", got)
+	}
+
+	if err := c.ResumeVendor(enumor.Aws); err != nil {
+		t.Fatalf("resume aws failed, err: %v", err)
+	}
+	if got := config[pausedVendorsConfigKey]; !reflect.DeepEqual(got, []enumor.Vendor{enumor.TCloud}) {
+		t.Fatalf("resumed vendor should be removed from persisted config, got: %v", got)
+	}
+
+	// 恢复未暂停的云厂商不写入配置
+	c.putConfig = func(key string, value interface{}) error {
+		t.Fatalf("config should not be written when vendor is not paused, key: %s", key)
+		return nil
+	}
+	if err := c.ResumeVendor(enumor.Gcp); err != nil {
+		t.Fatalf("resume gcp failed, err: %v", err)
+	}
+}
@@ -26,9 +26,13 @@ import (
 
 	"hcm/cmd/cloud-server/logics/account"
 	"hcm/cmd/cloud-server/service/sync/detail"
+	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
 	protocloud "hcm/pkg/api/data-service/cloud"
+	ts "hcm/pkg/api/task-server"
+	"hcm/pkg/async/action"
+	"hcm/pkg/cc"
 	"hcm/pkg/client"
 	dataservice "hcm/pkg/client/data-service"
 	"hcm/pkg/criteria/enumor"
@@ -36,17 +40,24 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/runtime/filter"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tools/counter"
 	"hcm/pkg/tools/retry"
 )
 
-// CloudResourceSync 定时同步云资源
-func CloudResourceSync(intervalMin time.Duration, sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
-	logs.Infof("cloud resource sync enable, syncIntervalMin: %v", intervalMin)
+// CloudResourceSync 定时同步云资源，是否开启及同步间隔每轮重新读取，支持动态调整
+func CloudResourceSync(sd serviced.ServiceDiscover, cliSet *client.ClientSet) {
+	logs.Infof("cloud resource sync start, config: %+v", cc.CloudServer().CloudResource.Sync)
 
 	for {
-		time.Sleep(intervalMin)
+		conf := cc.CloudServer().CloudResource.Sync
+		if !conf.Enable || conf.SyncIntervalMin == 0 {
+			time.Sleep(time.Minute)
+			continue
+		}
 
-		if !sd.IsMaster() {
+		time.Sleep(time.Duration(conf.SyncIntervalMin) * time.Minute)
+
+		if !cc.CloudServer().CloudResource.Sync.Enable || !sd.IsMaster() {
 			continue
 		}
 
@@ -54,12 +65,22 @@ func CloudResourceSync(intervalMin time.Duration, sd serviced.ServiceDiscover, c
 		logs.Infof("cloud resource all sync start, time: %v", start)
 
 		waitGroup := new(sync.WaitGroup)
-		syncers := account.GetAvailableVendorSyncers()
+		syncers := make([]account.VendorSyncer, 0)
+		for _, syncer := range account.GetAvailableVendorSyncers() {
+			if isVendorPaused(syncer.Vendor()) {
+				logs.Infof("%s periodic resource sync is paused, skip", syncer.Vendor())
+				continue
+			}
+			syncers = append(syncers, syncer)
+		}
 
 		waitGroup.Add(len(syncers))
 		for _, vendorSyncer := range syncers {
 			go func(vendor account.VendorSyncer) {
-				allAccountSync(core.NewBackendKit(), cliSet, vendor)
+				kt := core.NewBackendKit()
+				// 周期同步为后台同步，资源同步服务据此将发现的变更记为云上变更
+				kt.RequestSource = enumor.BackgroundSync
+				allAccountSync(kt, cliSet, vendor)
 				waitGroup.Done()
 			}(vendorSyncer)
 		}
@@ -120,6 +141,12 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 
 			// 公共资源仅需要同步一次即可
 			syncPublicResource = false
+
+			// 同步完成后异步检查安全组规则风险，失败不影响后续账号同步
+			if err = createSGLintFlow(kt, cliSet, acc); err != nil {
+				logs.Errorf("create %s security group lint flow failed, err: %v, accountID: %s, rid: %s",
+					syncer.Vendor(), err, acc.ID, kt.Rid)
+			}
 		}
 		if len(accounts) < int(core.DefaultMaxPageLimit) {
 			break
@@ -128,6 +155,31 @@ func allAccountSync(kt *kit.Kit, cliSet *client.ClientSet, syncer account.Vendor
 	}
 }
 
+// createSGLintFlow create async flow to lint security groups of account, gcp has no security group.
+func createSGLintFlow(kt *kit.Kit, cliSet *client.ClientSet, acc *corecloud.BaseAccount) error {
+	if acc.Vendor == enumor.Gcp {
+		return nil
+	}
+
+	nextID := counter.NewNumStringCounter(1, 10)
+	flowReq := &ts.AddCustomFlowReq{
+		Name: enumor.FlowLintSecurityGroup,
+		Tasks: []ts.CustomFlowTask{
+			{
+				ActionID:   action.ActIDType(nextID()),
+				ActionName: enumor.ActionLintSecurityGroup,
+				Params: actionsg.LintSGOption{
+					Vendor:    acc.Vendor,
+					AccountID: acc.ID,
+				},
+			},
+		},
+	}
+
+	_, err := cliSet.TaskServer().CreateCustomFlow(kt, flowReq)
+	return err
+}
+
 const maxRetryCount = 3
 
 // listAccountWithRetry 查询账号列表，最多重试3次，每次等待
@@ -0,0 +1,237 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook webhook订阅管理及投递记录查询
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/cloud-server/service/capability"
+	proto "hcm/pkg/api/cloud-server/webhook"
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	protowebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/client"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/cryptography"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/auth"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+)
+
+// InitService initialize the webhook service.
+func InitService(c *capability.Capability) {
+	svc := &svc{
+		client:     c.ApiClient,
+		authorizer: c.Authorizer,
+		cipher:     c.Cipher,
+	}
+
+	h := rest.NewHandler()
+	h.Add("CreateWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/create", svc.CreateSubscription)
+	h.Add("UpdateWebhookSubscription", http.MethodPatch, "/webhooks/subscriptions/{id}", svc.UpdateSubscription)
+	h.Add("BatchDeleteWebhookSubscription", http.MethodDelete, "/webhooks/subscriptions/batch",
+		svc.BatchDeleteSubscription)
+	h.Add("ListWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/list", svc.ListSubscription)
+	h.Add("ListWebhookDelivery", http.MethodPost, "/webhooks/deliveries/list", svc.ListDelivery)
+
+	h.Load(c.WebService)
+}
+
+type svc struct {
+	client     *client.ClientSet
+	authorizer auth.Authorizer
+	cipher     cryptography.Crypto
+}
+
+// CreateSubscription create webhook subscription.
+func (svc *svc) CreateSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.SubscriptionCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authWebhook(cts.Kit, meta.Create); err != nil {
+		return nil, err
+	}
+
+	enabled := req.Enabled
+	if enabled == nil {
+		enabled = converter.ValToPtr(true)
+	}
+	createReq := &protowebhook.SubscriptionBatchCreateReq{
+		Subscriptions: []protowebhook.SubscriptionCreate{{
+			Name:       req.Name,
+			URL:        req.URL,
+			Secret:     svc.encryptSecret(req.Secret),
+			EventTypes: req.EventTypes,
+			Enabled:    enabled,
+			Memo:       req.Memo,
+		}},
+	}
+	result, err := svc.client.DataService().Global.Webhook.BatchCreateSubscription(cts.Kit, createReq)
+	if err != nil {
+		logs.Errorf("create webhook subscription failed, err: %v, name: %s, rid: %s", err, req.Name, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.IDs) != 1 {
+		return nil, fmt.Errorf("create webhook subscription but return ids: %v is invalid", result.IDs)
+	}
+
+	return core.CreateResult{ID: result.IDs[0]}, nil
+}
+
+// UpdateSubscription update webhook subscription.
+func (svc *svc) UpdateSubscription(cts *rest.Contexts) (interface{}, error) {
+	id := cts.PathParameter("id").String()
+	if len(id) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "id is required")
+	}
+
+	req := new(proto.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authWebhook(cts.Kit, meta.Update); err != nil {
+		return nil, err
+	}
+
+	updateReq := &protowebhook.SubscriptionUpdateReq{
+		ID:         id,
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     svc.encryptSecret(req.Secret),
+		EventTypes: req.EventTypes,
+		Enabled:    req.Enabled,
+		Memo:       req.Memo,
+	}
+	if err := svc.client.DataService().Global.Webhook.UpdateSubscription(cts.Kit, updateReq); err != nil {
+		logs.Errorf("update webhook subscription failed, err: %v, id: %s, rid: %s", err, id, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// BatchDeleteSubscription batch delete webhook subscription.
+func (svc *svc) BatchDeleteSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.SubscriptionDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authWebhook(cts.Kit, meta.Delete); err != nil {
+		return nil, err
+	}
+
+	delReq := &dataservice.BatchDeleteReq{Filter: tools.ContainersExpression("id", req.IDs)}
+	if err := svc.client.DataService().Global.Webhook.BatchDeleteSubscription(cts.Kit, delReq); err != nil {
+		logs.Errorf("delete webhook subscription failed, err: %v, ids: %v, rid: %s", err, req.IDs, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSubscription list webhook subscription, secret is not returned.
+func (svc *svc) ListSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authWebhook(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Webhook.ListSubscription(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	for idx := range result.Details {
+		result.Details[idx].Secret = ""
+	}
+
+	return &proto.SubscriptionListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+// ListDelivery list webhook delivery.
+func (svc *svc) ListDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.authWebhook(cts.Kit, meta.Find); err != nil {
+		return nil, err
+	}
+
+	result, err := svc.client.DataService().Global.Webhook.ListDelivery(cts.Kit, req)
+	if err != nil {
+		logs.Errorf("list webhook delivery failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &proto.DeliveryListResult{Count: result.Count, Details: result.Details}, nil
+}
+
+func (svc *svc) authWebhook(kt *kit.Kit, action meta.Action) error {
+	return svc.authorizer.AuthorizeWithPerm(kt, meta.ResourceAttribute{
+		Basic: &meta.Basic{Type: meta.Webhook, Action: action},
+	})
+}
+
+// encryptSecret 签名密钥加密后存储，为空时不加密
+func (svc *svc) encryptSecret(secret string) string {
+	if len(secret) == 0 {
+		return ""
+	}
+
+	return svc.cipher.EncryptToBase64(secret)
+}
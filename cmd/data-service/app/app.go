@@ -21,6 +21,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -33,6 +34,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the data service.
@@ -58,6 +60,8 @@ func Run(opt *options.Option) error {
 type dataService struct {
 	svc *service.Service
 	sd  serviced.Service
+	// shutdownTracing 进程退出前导出剩余的链路数据
+	shutdownTracing func(ctx context.Context) error
 }
 
 // prepare do prepare jobs before run api discover.
@@ -75,6 +79,18 @@ func (ds *dataService) prepare(opt *options.Option) error {
 	network := cc.DataService().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	shutdownTracing, err := tracing.Init(string(cc.DataServiceName), cc.DataService().Tracing.Config())
+	if err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+	ds.shutdownTracing = shutdownTracing
+
+	// load dynamic config overrides from etcd and keep watching the changes.
+	if err := serviced.WatchConfig(cc.DataService().Service); err != nil {
+		return fmt.Errorf("watch dynamic config failed, err: %v", err)
+	}
+
 	svc, err := service.NewService()
 	if err != nil {
 		return fmt.Errorf("initialize service failed, err: %v", err)
@@ -109,6 +125,8 @@ func (ds *dataService) register() error {
 }
 
 func (ds *dataService) finalizer() {
+	defer tracing.Shutdown(ds.shutdownTracing)
+
 	if err := ds.sd.Deregister(); err != nil {
 		logs.Errorf("process service shutdown, but deregister failed, err: %v", err)
 		return
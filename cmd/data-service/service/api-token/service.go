@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apitoken ...
+package apitoken
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the service account and api token service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+	h := rest.NewHandler()
+	h.Add("CreateServiceAccount", http.MethodPost, "/service_accounts/create", svc.CreateServiceAccount)
+	h.Add("UpdateServiceAccount", http.MethodPatch, "/service_accounts", svc.UpdateServiceAccount)
+	h.Add("ListServiceAccount", http.MethodPost, "/service_accounts/list", svc.ListServiceAccount)
+	h.Add("BatchDeleteServiceAccount", http.MethodDelete, "/service_accounts/batch", svc.BatchDeleteServiceAccount)
+
+	h.Add("CreateAPIToken", http.MethodPost, "/api_tokens/create", svc.CreateToken)
+	h.Add("UpdateAPIToken", http.MethodPatch, "/api_tokens", svc.UpdateToken)
+	h.Add("ListAPIToken", http.MethodPost, "/api_tokens/list", svc.ListToken)
+	h.Add("ValidateAPIToken", http.MethodPost, "/api_tokens/validate", svc.ValidateToken)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
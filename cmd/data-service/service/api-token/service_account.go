@@ -0,0 +1,214 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apitoken
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	coreapitoken "hcm/pkg/api/core/api-token"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/api-token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableapitoken "hcm/pkg/dal/table/api-token"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateServiceAccount create service account.
+func (svc *service) CreateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ServiceAccountCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := tableapitoken.ServiceAccountTable{
+		Name:    req.Name,
+		Memo:    req.Memo,
+		Creator: cts.Kit.User,
+		Reviser: cts.Kit.User,
+	}
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.ServiceAccount().BatchCreateWithTx(cts.Kit, txn,
+			[]tableapitoken.ServiceAccountTable{model})
+		if err != nil {
+			return nil, fmt.Errorf("create service account failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("create service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok || len(idList) != 1 {
+		return nil, fmt.Errorf("create service account but return ids is invalid, ids: %v", ids)
+	}
+
+	return &core.CreateResult{ID: idList[0]}, nil
+}
+
+// UpdateServiceAccount update service account.
+func (svc *service) UpdateServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.ServiceAccountUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableapitoken.ServiceAccountTable{
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.ServiceAccount().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update service account failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListServiceAccount list service account.
+func (svc *service) ListServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list service account failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &proto.ServiceAccountListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreapitoken.ServiceAccount, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreapitoken.ServiceAccount{
+			ID:   one.ID,
+			Name: one.Name,
+			Memo: converter.PtrToVal(one.Memo),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &proto.ServiceAccountListResult{Details: details}, nil
+}
+
+// BatchDeleteServiceAccount batch delete service account, api tokens of the service accounts are deleted too.
+func (svc *service) BatchDeleteServiceAccount(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id", "name"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	delIDs, delNames := make([]string, 0), make([]string, 0)
+	for {
+		listResp, err := svc.dao.ServiceAccount().List(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("list service account failed, err: %v", err)
+		}
+
+		for _, one := range listResp.Details {
+			delIDs = append(delIDs, one.ID)
+			delNames = append(delNames, coreapitoken.ServiceAccountPrincipal(one.Name))
+		}
+
+		if uint(len(listResp.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	if len(delIDs) == 0 {
+		return nil, nil
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, names := range slice.Split(delNames, int(core.DefaultMaxPageLimit)) {
+			tokenExpr := tools.ExpressionAnd(
+				tools.RuleEqual("principal_type", string(enumor.APITokenPrincipalServiceAccount)),
+				tools.RuleIn("principal", names),
+			)
+			if err := svc.dao.APIToken().DeleteWithTx(cts.Kit, txn, tokenExpr); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, ids := range slice.Split(delIDs, int(core.DefaultMaxPageLimit)) {
+			if err := svc.dao.ServiceAccount().DeleteWithTx(cts.Kit, txn,
+				tools.ContainersExpression("id", ids)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete service account failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
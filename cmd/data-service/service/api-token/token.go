@@ -0,0 +1,270 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apitoken
+
+import (
+	"fmt"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreapitoken "hcm/pkg/api/core/api-token"
+	proto "hcm/pkg/api/data-service/api-token"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableapitoken "hcm/pkg/dal/table/api-token"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// lastUsedRefreshInterval 令牌最近使用时间的刷新间隔，避免每次校验都写库
+const lastUsedRefreshInterval = time.Minute
+
+// CreateToken create api token.
+func (svc *service) CreateToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.TokenCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := tableapitoken.TokenTable{
+		Name:          req.Name,
+		TokenHash:     req.TokenHash,
+		TokenPrefix:   req.TokenPrefix,
+		PrincipalType: req.PrincipalType,
+		Principal:     req.Principal,
+		BkBizIDs:      req.BkBizIDs,
+		Actions:       req.Actions,
+		ExpireAt:      req.ExpireAt,
+		Revoked:       converter.ValToPtr(false),
+		Memo:          req.Memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.APIToken().BatchCreateWithTx(cts.Kit, txn, []tableapitoken.TokenTable{model})
+		if err != nil {
+			return nil, fmt.Errorf("create api token failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("create api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok || len(idList) != 1 {
+		return nil, fmt.Errorf("create api token but return ids is invalid, ids: %v", ids)
+	}
+
+	return &core.CreateResult{ID: idList[0]}, nil
+}
+
+// UpdateToken update api token, only name, memo and revoked status can be updated.
+func (svc *service) UpdateToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.TokenUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tableapitoken.TokenTable{
+		Name:    req.Name,
+		Revoked: req.Revoked,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if err := svc.dao.APIToken().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update api token failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListToken list api token.
+func (svc *service) ListToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.APIToken().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list api token failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list api token failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &proto.TokenListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreapitoken.Token, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, convTokenTable(one))
+	}
+
+	return &proto.TokenListResult{Details: details}, nil
+}
+
+// ValidateToken validate api token by token hash, returns the token if it is usable.
+func (svc *service) ValidateToken(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.TokenValidateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Filter: tools.EqualExpression("token_hash", req.TokenHash),
+		Page:   core.NewDefaultBasePage(),
+	}
+	result, err := svc.dao.APIToken().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list api token by hash failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) != 1 {
+		return nil, errf.New(errf.PermissionDenied, "invalid api token")
+	}
+	token := result.Details[0]
+
+	now := times.ConvStdTimeNow()
+	if err = checkTokenAvailable(token, now); err != nil {
+		logs.Errorf("api token is unavailable, err: %v, id: %s, rid: %s", err, token.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	if token.PrincipalType == enumor.APITokenPrincipalServiceAccount {
+		saName, _ := coreapitoken.ServiceAccountName(token.Principal)
+		saOpt := &types.ListOption{
+			Filter: tools.EqualExpression("name", saName),
+			Page:   core.NewCountPage(),
+		}
+		saResult, err := svc.dao.ServiceAccount().List(cts.Kit, saOpt)
+		if err != nil {
+			logs.Errorf("count service account failed, err: %v, name: %s, rid: %s", err, token.Principal,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		if saResult.Count == 0 {
+			return nil, errf.New(errf.PermissionDenied, "service account of api token not exists")
+		}
+	}
+
+	if svc.needRefreshLastUsed(token.LastUsedAt, now) {
+		lastUsedAt := times.ConvStdTimeFormat(now)
+		// 最近使用时间仅用于展示，刷新失败不影响令牌校验结果
+		if err = svc.dao.APIToken().UpdateLastUsed(cts.Kit, token.ID, lastUsedAt); err != nil {
+			logs.Errorf("update api token last used time failed, err: %v, id: %s, rid: %s", err, token.ID,
+				cts.Kit.Rid)
+		} else {
+			token.LastUsedAt = lastUsedAt
+		}
+	}
+
+	validToken := convTokenTable(token)
+	return &validToken, nil
+}
+
+// checkTokenAvailable check api token is not revoked, not expired and its principal is valid at now.
+func checkTokenAvailable(token tableapitoken.TokenTable, now time.Time) error {
+	if converter.PtrToVal(token.Revoked) {
+		return errf.New(errf.PermissionDenied, "api token has been revoked")
+	}
+
+	expireAt, err := time.Parse(constant.TimeStdFormat, token.ExpireAt)
+	if err != nil {
+		return errf.New(errf.PermissionDenied, "invalid api token")
+	}
+	if !now.Before(expireAt) {
+		return errf.New(errf.PermissionDenied, "api token has expired")
+	}
+
+	if err = coreapitoken.ValidatePrincipal(token.PrincipalType, token.Principal); err != nil {
+		return errf.New(errf.PermissionDenied, "invalid api token")
+	}
+
+	return nil
+}
+
+func (svc *service) needRefreshLastUsed(lastUsedAt string, now time.Time) bool {
+	if len(lastUsedAt) == 0 {
+		return true
+	}
+
+	last, err := time.Parse(constant.TimeStdFormat, lastUsedAt)
+	if err != nil {
+		return true
+	}
+
+	return now.Sub(last) >= lastUsedRefreshInterval
+}
+
+func convTokenTable(one tableapitoken.TokenTable) coreapitoken.Token {
+	return coreapitoken.Token{
+		ID:            one.ID,
+		Name:          one.Name,
+		TokenPrefix:   one.TokenPrefix,
+		PrincipalType: one.PrincipalType,
+		Principal:     one.Principal,
+		BkBizIDs:      one.BkBizIDs,
+		Actions:       one.Actions,
+		ExpireAt:      one.ExpireAt,
+		Revoked:       converter.PtrToVal(one.Revoked),
+		LastUsedAt:    one.LastUsedAt,
+		Memo:          converter.PtrToVal(one.Memo),
+		Revision: core.Revision{
+			Creator:   one.Creator,
+			Reviser:   one.Reviser,
+			CreatedAt: one.CreatedAt.String(),
+			UpdatedAt: one.UpdatedAt.String(),
+		},
+	}
+}
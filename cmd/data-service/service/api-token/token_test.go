@@ -0,0 +1,103 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apitoken
+
+import (
+	"testing"
+	"time"
+
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableapitoken "hcm/pkg/dal/table/api-token"
+	"hcm/pkg/tools/converter"
+)
+
+func TestCheckTokenAvailable(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	valid := tableapitoken.TokenTable{
+		ID:            "00000001",
+		PrincipalType: enumor.APITokenPrincipalServiceAccount,
+		Principal:     "sa:deployer",
+		ExpireAt:      now.Add(time.Hour).Format(constant.TimeStdFormat),
+		Revoked:       converter.ValToPtr(false),
+	}
+
+	cases := []struct {
+		name   string
+		modify func(token *tableapitoken.TokenTable)
+		errMsg string
+	}{
+		{name: "valid", modify: func(token *tableapitoken.TokenTable) {}},
+		{
+			name:   "revoked",
+			modify: func(token *tableapitoken.TokenTable) { token.Revoked = converter.ValToPtr(true) },
+			errMsg: "api token has been revoked",
+		},
+		{
+			name: "revoked and expired",
+			modify: func(token *tableapitoken.TokenTable) {
+				token.Revoked = converter.ValToPtr(true)
+				token.ExpireAt = now.Add(-time.Hour).Format(constant.TimeStdFormat)
+			},
+			errMsg: "api token has been revoked",
+		},
+		{
+			name:   "expired",
+			modify: func(token *tableapitoken.TokenTable) { token.ExpireAt = now.Format(constant.TimeStdFormat) },
+			errMsg: "api token has expired",
+		},
+		{
+			name:   "invalid expire time",
+			modify: func(token *tableapitoken.TokenTable) { token.ExpireAt = "2024-06-01" },
+			errMsg: "invalid api token",
+		},
+		{
+			name:   "service account without prefix",
+			modify: func(token *tableapitoken.TokenTable) { token.Principal = "admin" },
+			errMsg: "invalid api token",
+		},
+		{
+			name: "user in service account namespace",
+			modify: func(token *tableapitoken.TokenTable) {
+				token.PrincipalType = enumor.APITokenPrincipalUser
+			},
+			errMsg: "invalid api token",
+		},
+	}
+
+	for _, c := range cases {
+		token := valid
+		c.modify(&token)
+
+		err := checkTokenAvailable(token, now)
+		if len(c.errMsg) == 0 {
+			if err != nil {
+				t.Errorf("%s: expect available, got err: %v", c.name, err)
+			}
+			continue
+		}
+
+		ef := errf.Error(err)
+		if err == nil || ef.Code != errf.PermissionDenied || ef.Message != c.errMsg {
+			t.Errorf("%s: expect permission denied %q, got err: %v", c.name, c.errMsg, err)
+		}
+	}
+}
@@ -33,6 +33,8 @@ import (
 	"hcm/pkg/dal/dao"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/objectstore"
+	tableaudit "hcm/pkg/dal/table/audit"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
 )
@@ -40,14 +42,17 @@ import (
 // InitAuditService initial the Audit service
 func InitAuditService(cap *capability.Capability) {
 	svc := &svc{
-		cloudAudit: cloud.NewCloudAudit(cap.Dao),
-		dao:        cap.Dao,
+		cloudAudit:  cloud.NewCloudAudit(cap.Dao),
+		dao:         cap.Dao,
+		objectStore: cap.ObjectStore,
 	}
 
 	h := rest.NewHandler()
 
 	h.Add("CloudResourceUpdateAudit", http.MethodPost, "/cloud/resources/update_audits/create",
 		svc.cloudAudit.CloudResourceUpdateAudit)
+	h.Add("CloudResourceCreateAudit", http.MethodPost, "/cloud/resources/create_audits/create",
+		svc.cloudAudit.CloudResourceCreateAudit)
 	h.Add("CloudResourceDeleteAudit", http.MethodPost, "/cloud/resources/delete_audits/create",
 		svc.cloudAudit.CloudResourceDeleteAudit)
 	h.Add("CloudResourceAssignAudit", http.MethodPost, "/cloud/resources/assign_audits/create",
@@ -58,19 +63,24 @@ func InitAuditService(cap *capability.Capability) {
 		svc.cloudAudit.CloudResourceRecycleAudit)
 	h.Add("ListAudit", http.MethodPost, "/audits/list", svc.ListAudit)
 	h.Add("GetAudit", http.MethodGet, "/audits/{id}", svc.GetAudit)
+	h.Add("ListAuditFieldChange", http.MethodPost, "/audits/field_changes/list", svc.ListAuditFieldChange)
+	h.Add("CreateAuditExport", http.MethodPost, "/audits/exports/create", svc.CreateAuditExport)
+	h.Add("ListAuditExport", http.MethodPost, "/audits/exports/list", svc.ListAuditExport)
+	h.Add("GetCloudSyncReport", http.MethodPost, "/audits/cloud_sync/report", svc.GetCloudSyncReport)
 
 	h.Load(cap.WebService)
 }
 
 // Audit define audit service.
 type svc struct {
-	cloudAudit *cloud.Audit
-	dao        dao.Set
+	cloudAudit  *cloud.Audit
+	dao         dao.Set
+	objectStore objectstore.Storage
 }
 
 // ListAudit list audits.
 func (svc *svc) ListAudit(cts *rest.Contexts) (interface{}, error) {
-	req := new(core.ListReq)
+	req := new(proto.AuditListReq)
 	if err := cts.DecodeInto(req); err != nil {
 		return nil, err
 	}
@@ -84,7 +94,13 @@ func (svc *svc) ListAudit(cts *rest.Contexts) (interface{}, error) {
 		Page:   req.Page,
 		Fields: req.Fields,
 	}
-	result, err := svc.dao.Audit().List(cts.Kit, opt)
+	var result *types.ListAuditDetails
+	var err error
+	if len(req.Keyword) != 0 {
+		result, err = svc.dao.Audit().Search(cts.Kit, opt, req.Keyword)
+	} else {
+		result, err = svc.dao.Audit().List(cts.Kit, opt)
+	}
 	if err != nil {
 		logs.Errorf("list audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
 		return nil, fmt.Errorf("list audit failed, err: %v", err)
@@ -95,28 +111,78 @@ func (svc *svc) ListAudit(cts *rest.Contexts) (interface{}, error) {
 
 	details := make([]coreaudit.Audit, 0, len(result.Details))
 	for _, one := range result.Details {
-		details = append(details, coreaudit.Audit{
-			ID:         one.ID,
-			ResID:      one.ResID,
-			CloudResID: one.CloudResID,
-			ResName:    one.ResName,
-			ResType:    one.ResType,
-			Action:     one.Action,
-			BkBizID:    one.BkBizID,
-			Vendor:     one.Vendor,
-			AccountID:  one.AccountID,
-			Operator:   one.Operator,
-			Detail:     one.Detail,
-			Source:     one.Source,
-			Rid:        one.Rid,
-			AppCode:    one.AppCode,
-			CreatedAt:  one.CreatedAt.String(),
-		})
+		details = append(details, convAudit(one))
 	}
 
 	return &proto.ListResult{Details: details}, nil
 }
 
+func convAudit(one tableaudit.AuditTable) coreaudit.Audit {
+	return coreaudit.Audit{
+		ID:         one.ID,
+		ResID:      one.ResID,
+		CloudResID: one.CloudResID,
+		ResName:    one.ResName,
+		ResType:    one.ResType,
+		Action:     one.Action,
+		BkBizID:    one.BkBizID,
+		Vendor:     one.Vendor,
+		AccountID:  one.AccountID,
+		Operator:   one.Operator,
+		Detail:     one.Detail,
+		Source:     one.Source,
+		Rid:        one.Rid,
+		AppCode:    one.AppCode,
+		CreatedAt:  one.CreatedAt.String(),
+	}
+}
+
+// ListAuditFieldChange list audit field changes.
+func (svc *svc) ListAuditFieldChange(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{Filter: req.Filter, Page: req.Page, Fields: req.Fields}
+	result, err := svc.dao.Audit().ListFieldChange(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list audit field change failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list audit field change failed, err: %v", err)
+	}
+	if req.Page.Count {
+		return &proto.FieldChangeListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreaudit.FieldChange, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreaudit.FieldChange{
+			ID:           one.ID,
+			AuditID:      one.AuditID,
+			ResID:        one.ResID,
+			CloudResID:   one.CloudResID,
+			ResName:      one.ResName,
+			ResType:      one.ResType,
+			ChildResType: one.ChildResType,
+			Field:        one.Field,
+			BeforeValue:  one.BeforeValue,
+			AfterValue:   one.AfterValue,
+			BkBizID:      one.BkBizID,
+			Vendor:       one.Vendor,
+			AccountID:    one.AccountID,
+			Operator:     one.Operator,
+			Rid:          one.Rid,
+			CreatedAt:    one.CreatedAt.String(),
+		})
+	}
+
+	return &proto.FieldChangeListResult{Details: details}, nil
+}
+
 // GetAudit get audits.
 func (svc *svc) GetAudit(cts *rest.Contexts) (interface{}, error) {
 	id, err := cts.PathParameter("id").Uint64()
@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	protoaudit "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// CloudResourceCreateAudit cloud resource create audit, used when resource sync finds resources created outside hcm.
+// create audit should be recorded after the resource is created in db.
+func (ad Audit) CloudResourceCreateAudit(cts *rest.Contexts) (interface{}, error) {
+	req := new(protoaudit.CloudResourceCreateAuditReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 按云资源类型进行分类
+	createMap := make(map[enumor.AuditResourceType][]protoaudit.CloudResourceDeleteInfo)
+	for _, one := range req.Creates {
+		createMap[one.ResType] = append(createMap[one.ResType],
+			protoaudit.CloudResourceDeleteInfo{ResType: one.ResType, ResID: one.ResID})
+	}
+
+	auditAll := make([]*tableaudit.AuditTable, 0, len(req.Creates))
+	for resType, creates := range createMap {
+		audits, err := ad.buildCreateAuditInfo(cts.Kit, resType, creates)
+		if err != nil {
+			logs.Errorf("query create audit info failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, err
+		}
+
+		auditAll = append(auditAll, audits...)
+	}
+
+	if err := ad.dao.Audit().BatchCreate(cts.Kit, auditAll); err != nil {
+		logs.Errorf("batch create audit failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// buildCreateAuditInfo 新增审计与删除审计一样记录资源的完整数据，所以复用删除审计的构建逻辑。
+// 安全组、负载均衡等资源在DB创建时已记录新增审计，不在此支持，避免重复记录。
+func (ad Audit) buildCreateAuditInfo(kt *kit.Kit, resType enumor.AuditResourceType,
+	creates []protoaudit.CloudResourceDeleteInfo) ([]*tableaudit.AuditTable, error) {
+
+	switch resType {
+	case enumor.VpcCloudAuditResType, enumor.SubnetAuditResType, enumor.CvmAuditResType, enumor.EipAuditResType,
+		enumor.DiskAuditResType, enumor.GcpFirewallRuleAuditResType:
+	default:
+		return nil, fmt.Errorf("build create audit cloud resource type: %s not support", resType)
+	}
+
+	audits, err := ad.buildDeleteAuditInfo(kt, resType, "", creates)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, one := range audits {
+		one.Action = enumor.Create
+	}
+
+	return audits, nil
+}
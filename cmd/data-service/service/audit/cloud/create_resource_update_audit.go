@@ -97,6 +97,10 @@ func (ad Audit) buildUpdateAuditInfo(kt *kit.Kit, resType enumor.AuditResourceTy
 		audits, err = ad.loadBalancer.LoadBalancerUpdateAuditBuild(kt, updates)
 	case enumor.UrlRuleAuditResType:
 		audits, err = ad.loadBalancer.UrlRuleUpdateAuditBuild(kt, parentID, updates)
+	case enumor.ListenerAuditResType:
+		audits, err = ad.listenerUpdateAuditBuild(kt, updates)
+	case enumor.TargetGroupAuditResType:
+		audits, err = ad.targetGroupUpdateAuditBuild(kt, updates)
 
 	default:
 		return nil, fmt.Errorf("cloud resource type: %s not support", resType)
@@ -31,6 +31,49 @@ import (
 	"hcm/pkg/logs"
 )
 
+func (ad Audit) listenerUpdateAuditBuild(kt *kit.Kit, updates []protoaudit.CloudResourceUpdateInfo) (
+	[]*tableaudit.AuditTable, error) {
+
+	ids := make([]string, 0, len(updates))
+	for _, one := range updates {
+		ids = append(ids, one.ResID)
+	}
+
+	idMap, err := ad.listListener(kt, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	audits := make([]*tableaudit.AuditTable, 0, len(updates))
+	for _, one := range updates {
+		resData, exist := idMap[one.ResID]
+		if !exist {
+			continue
+		}
+
+		audits = append(audits, &tableaudit.AuditTable{
+			ResID:      one.ResID,
+			CloudResID: resData.CloudID,
+			ResName:    resData.Name,
+			ResType:    enumor.ListenerAuditResType,
+			Action:     enumor.Update,
+			BkBizID:    resData.BkBizID,
+			Vendor:     resData.Vendor,
+			AccountID:  resData.AccountID,
+			Operator:   kt.User,
+			Source:     kt.GetRequestSource(),
+			Rid:        kt.Rid,
+			AppCode:    kt.AppCode,
+			Detail: &tableaudit.BasicDetail{
+				Data:    resData,
+				Changed: one.UpdateFields,
+			},
+		})
+	}
+
+	return audits, nil
+}
+
 func (ad Audit) listenerDeleteAuditBuild(kt *kit.Kit, deletes []protoaudit.CloudResourceDeleteInfo) (
 	[]*tableaudit.AuditTable, error) {
 
@@ -85,8 +128,8 @@ func (ad Audit) listListener(kt *kit.Kit, ids []string) (map[string]*tablelb.Loa
 	}
 
 	result := make(map[string]*tablelb.LoadBalancerListenerTable, len(list.Details))
-	for _, one := range list.Details {
-		result[one.ID] = &one
+	for idx := range list.Details {
+		result[list.Details[idx].ID] = &list.Details[idx]
 	}
 
 	return result, nil
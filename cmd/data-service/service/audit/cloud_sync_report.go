@@ -0,0 +1,73 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	coreaudit "hcm/pkg/api/core/audit"
+	proto "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/times"
+)
+
+// GetCloudSyncReport 云上变更报表，统计时间范围内资源同步发现的HCM之外的云上变更，按账号、资源类型、操作类型分组。
+func (svc *svc) GetCloudSyncReport(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.CloudSyncReportReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 云上变更包括同步发现的资源新增、变更和删除
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("source", string(enumor.CloudSync)),
+		tools.RuleIn("action", []string{string(enumor.Create), string(enumor.Update), string(enumor.Delete)}),
+		tools.RuleGreaterThanEqual("created_at", times.ConvStdTimeFormat(req.StartTime)),
+		tools.RuleLessThan("created_at", times.ConvStdTimeFormat(req.EndTime)),
+	}
+	if len(req.AccountIDs) != 0 {
+		rules = append(rules, tools.RuleIn("account_id", req.AccountIDs))
+	}
+
+	counts, err := svc.dao.Audit().CountChange(cts.Kit, tools.ExpressionAnd(rules...))
+	if err != nil {
+		logs.Errorf("count cloud sync audit failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	details := make([]coreaudit.ChangeCount, 0, len(counts))
+	for _, one := range counts {
+		details = append(details, coreaudit.ChangeCount{
+			AccountID: one.AccountID,
+			Vendor:    one.Vendor,
+			ResType:   one.ResType,
+			Action:    one.Action,
+			Count:     one.Count,
+		})
+	}
+
+	return &proto.CloudSyncReportResult{Details: details}, nil
+}
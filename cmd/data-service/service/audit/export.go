@@ -0,0 +1,227 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"hcm/pkg/api/core"
+	coreaudit "hcm/pkg/api/core/audit"
+	proto "hcm/pkg/api/data-service/audit"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tableaudit "hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/hash"
+	"hcm/pkg/tools/times"
+)
+
+const (
+	defaultExportLimit uint64 = 50000
+	exportPageLimit    uint64 = 500
+	// exportDelay 只导出创建时间早于该时长的审计，避免未提交事务中ID较小的审计在导出之后才提交而被遗漏
+	exportDelay = time.Minute
+)
+
+// CreateAuditExport 将上次导出之后的审计按ID顺序以哈希链的形式导出到对象存储，
+// 本次导出的起始哈希为上次导出的最后一条记录的哈希，首次导出时为 hash.ChainGenesisHash
+func (svc *svc) CreateAuditExport(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.AuditExportCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultExportLimit
+	}
+
+	if svc.objectStore == nil {
+		return nil, errf.New(errf.Aborted, "object store of data service is not configured")
+	}
+
+	export, err := svc.lastAuditExport(cts.Kit)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "audit_export_*.jsonl.gz")
+	if err != nil {
+		return nil, fmt.Errorf("create tmp file failed, err: %v", err)
+	}
+	defer func() {
+		_ = file.Close()
+		if err := os.Remove(file.Name()); err != nil {
+			logs.Warnf("remove tmp file %s failed, err: %v, rid: %s", file.Name(), err, cts.Kit.Rid)
+		}
+	}()
+
+	gw := gzip.NewWriter(file)
+	writer := hash.NewChainWriter(gw, export.PrevHash)
+	if err = svc.dumpAudits(cts.Kit, export, req.Limit, writer); err != nil {
+		return nil, err
+	}
+	if err = gw.Close(); err != nil {
+		return nil, err
+	}
+
+	if writer.Count() == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "no audit to export after id: %d", export.StartID-1)
+	}
+	export.RecordCount = writer.Count()
+	export.LastHash = writer.LastHash()
+	export.ObjectPath = fmt.Sprintf("audit/export/%020d-%020d.jsonl.gz", export.StartID, export.EndID)
+	export.Creator = cts.Kit.User
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err = svc.objectStore.Upload(cts.Kit, export.ObjectPath, file); err != nil {
+		logs.Errorf("upload audit export to %s failed, err: %v, rid: %s", export.ObjectPath, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// start_id 唯一，并发导出时只有一个能成功记录，保证导出记录之间的哈希链不分叉
+	id, err := svc.dao.Audit().CreateExport(cts.Kit, export)
+	if err != nil {
+		logs.Errorf("create audit export failed, err: %v, export: %+v, rid: %s", err, export, cts.Kit.Rid)
+		return nil, err
+	}
+
+	logs.Infof("audits [%d, %d] exported to %s, count: %d, last hash: %s, rid: %s", export.StartID, export.EndID,
+		export.ObjectPath, export.RecordCount, export.LastHash, cts.Kit.Rid)
+
+	return &coreaudit.Export{
+		ID:          id,
+		StartID:     export.StartID,
+		EndID:       export.EndID,
+		RecordCount: export.RecordCount,
+		PrevHash:    export.PrevHash,
+		LastHash:    export.LastHash,
+		ObjectPath:  export.ObjectPath,
+		Creator:     export.Creator,
+	}, nil
+}
+
+// lastAuditExport 根据最后一次导出记录生成本次导出的起始ID及起始哈希
+func (svc *svc) lastAuditExport(kt *kit.Kit) (*tableaudit.ExportTable, error) {
+	opt := &types.ListOption{
+		Filter: tools.AllExpression(),
+		Page:   &core.BasePage{Start: 0, Limit: 1, Sort: "end_id", Order: core.Descending},
+	}
+	result, err := svc.dao.Audit().ListExport(kt, opt)
+	if err != nil {
+		logs.Errorf("list last audit export failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	if len(result.Details) == 0 {
+		return &tableaudit.ExportTable{StartID: 1, PrevHash: hash.ChainGenesisHash}, nil
+	}
+
+	last := result.Details[0]
+	return &tableaudit.ExportTable{StartID: last.EndID + 1, PrevHash: last.LastHash}, nil
+}
+
+// dumpAudits 从export.StartID开始按ID顺序分页写入最多limit条审计，并更新export的起止ID
+func (svc *svc) dumpAudits(kt *kit.Kit, export *tableaudit.ExportTable, limit uint64,
+	writer *hash.ChainWriter) error {
+
+	before := times.ConvStdTimeFormat(time.Now().Add(-exportDelay))
+	cursor := export.StartID - 1
+	for writer.Count() < limit {
+		pageLimit := min(exportPageLimit, limit-writer.Count())
+		opt := &types.ListOption{
+			Filter: tools.ExpressionAnd(
+				tools.RuleGreaterThan("id", cursor),
+				tools.RuleLessThan("created_at", before),
+			),
+			Page: &core.BasePage{Start: 0, Limit: uint(pageLimit), Sort: "id", Order: core.Ascending},
+		}
+		result, err := svc.dao.Audit().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list audit after id %d failed, err: %v, rid: %s", cursor, err, kt.Rid)
+			return err
+		}
+
+		for _, one := range result.Details {
+			if err = writer.Write(convAudit(one)); err != nil {
+				return err
+			}
+		}
+
+		if len(result.Details) == 0 {
+			break
+		}
+		cursor = result.Details[len(result.Details)-1].ID
+		export.EndID = cursor
+		if uint64(len(result.Details)) < pageLimit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// ListAuditExport list audit export.
+func (svc *svc) ListAuditExport(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{Filter: req.Filter, Page: req.Page, Fields: req.Fields}
+	result, err := svc.dao.Audit().ListExport(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list audit export failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+	if req.Page.Count {
+		return &proto.AuditExportListResult{Count: result.Count}, nil
+	}
+
+	details := make([]coreaudit.Export, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, coreaudit.Export{
+			ID:          one.ID,
+			StartID:     one.StartID,
+			EndID:       one.EndID,
+			RecordCount: one.RecordCount,
+			PrevHash:    one.PrevHash,
+			LastHash:    one.LastHash,
+			ObjectPath:  one.ObjectPath,
+			Creator:     one.Creator,
+			CreatedAt:   one.CreatedAt.String(),
+		})
+	}
+
+	return &proto.AuditExportListResult{Details: details}, nil
+}
@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billallocationrule
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateBillAllocationRule create bill allocation rule
+func (svc *service) CreateBillAllocationRule(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillAllocationRuleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	targets, err := types.NewJsonField(req.Targets)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	rule := tablebill.AccountBillAllocationRule{
+		Name:                  req.Name,
+		Vendor:                req.Vendor,
+		RootAccountID:         req.RootAccountID,
+		RuleType:              req.RuleType,
+		SourceType:            req.SourceType,
+		SourceMainAccountIDs:  req.SourceMainAccountIDs,
+		SourceHcProductCodes:  req.SourceHcProductCodes,
+		ExcludeMainAccountIDs: req.ExcludeMainAccountIDs,
+		Targets:               targets,
+		SpArnPrefix:           cvt.ValToPtr(req.SpArnPrefix),
+		CreditIDs:             req.CreditIDs,
+		Enabled:               cvt.ValToPtr(true),
+		Memo:                  req.Memo,
+		Creator:               cts.Kit.User,
+		Reviser:               cts.Kit.User,
+	}
+	if req.Enabled != nil {
+		rule.Enabled = req.Enabled
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.AccountBillAllocationRule().CreateWithTx(cts.Kit, txn,
+			[]tablebill.AccountBillAllocationRule{rule})
+		if err != nil {
+			logs.Errorf("fail to create bill allocation rule, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("create bill allocation rule failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok || len(ids) != 1 {
+		return nil, fmt.Errorf("create bill allocation rule but return ids is invalid, ids: %v", result)
+	}
+
+	return &core.CreateResult{ID: ids[0]}, nil
+}
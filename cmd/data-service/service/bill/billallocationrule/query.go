@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billallocationrule
+
+import (
+	"encoding/json"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+)
+
+// ListBillAllocationRule list bill allocation rule with options
+func (svc *service) ListBillAllocationRule(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+
+	data, err := svc.dao.AccountBillAllocationRule().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]bill.AllocationRule, 0, len(data.Details))
+	for _, one := range data.Details {
+		rule, err := convAllocationRule(one)
+		if err != nil {
+			logs.Errorf("convert bill allocation rule failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, rule)
+	}
+
+	return &dsbill.BillAllocationRuleListResult{Details: details, Count: data.Count}, nil
+}
+
+func convAllocationRule(r tablebill.AccountBillAllocationRule) (bill.AllocationRule, error) {
+	rule := bill.AllocationRule{
+		ID:                    r.ID,
+		Name:                  r.Name,
+		Vendor:                r.Vendor,
+		RootAccountID:         r.RootAccountID,
+		RuleType:              r.RuleType,
+		SourceType:            r.SourceType,
+		SourceMainAccountIDs:  r.SourceMainAccountIDs,
+		SourceHcProductCodes:  r.SourceHcProductCodes,
+		ExcludeMainAccountIDs: r.ExcludeMainAccountIDs,
+		SpArnPrefix:           cvt.PtrToVal(r.SpArnPrefix),
+		CreditIDs:             r.CreditIDs,
+		Enabled:               cvt.PtrToVal(r.Enabled),
+		Memo:                  r.Memo,
+		Revision: &core.Revision{
+			Creator:   r.Creator,
+			Reviser:   r.Reviser,
+			CreatedAt: r.CreatedAt.String(),
+			UpdatedAt: r.UpdatedAt.String(),
+		},
+	}
+	if !r.Targets.IsEmpty() && r.Targets != "null" {
+		if err := json.Unmarshal([]byte(r.Targets), &rule.Targets); err != nil {
+			return rule, err
+		}
+	}
+	return rule, nil
+}
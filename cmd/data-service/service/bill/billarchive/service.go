@@ -0,0 +1,181 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package billarchive ...
+package billarchive
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// InitService initialize the bill archive service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+	h := rest.NewHandler()
+	h.Add("CreateBillArchive", http.MethodPost, "/bills/archives/create", svc.CreateBillArchive)
+	h.Add("UpdateBillArchive", http.MethodPatch, "/bills/archives", svc.UpdateBillArchive)
+	h.Add("ListBillArchive", http.MethodPost, "/bills/archives/list", svc.ListBillArchive)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+// CreateBillArchive create bill archive
+func (svc *service) CreateBillArchive(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillArchiveCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	archive := tablebill.AccountBillArchive{
+		Vendor:    req.Vendor,
+		BillYear:  req.BillYear,
+		BillMonth: req.BillMonth,
+		State:     req.State,
+		ItemCount: cvt.ValToPtr(uint64(0)),
+		FileSize:  cvt.ValToPtr(uint64(0)),
+		Reason:    cvt.ValToPtr(""),
+		Creator:   cts.Kit.User,
+		Reviser:   cts.Kit.User,
+	}
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.AccountBillArchive().CreateWithTx(cts.Kit, txn, []tablebill.AccountBillArchive{archive})
+		if err != nil {
+			logs.Errorf("fail to create bill archive, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("create bill archive failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok || len(ids) != 1 {
+		return nil, fmt.Errorf("create bill archive but return ids is invalid, ids: %v", result)
+	}
+
+	return &core.CreateResult{ID: ids[0]}, nil
+}
+
+// UpdateBillArchive update bill archive
+func (svc *service) UpdateBillArchive(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillArchiveUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	archive := &tablebill.AccountBillArchive{
+		ID:         req.ID,
+		State:      req.State,
+		ObjectPath: req.ObjectPath,
+		ItemCount:  req.ItemCount,
+		FileSize:   req.FileSize,
+		FlowID:     req.FlowID,
+		Reason:     req.Reason,
+		RestoredAt: req.RestoredAt,
+		Reviser:    cts.Kit.User,
+	}
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.AccountBillArchive().UpdateByIDWithTx(cts.Kit, txn, req.ID, archive); err != nil {
+			logs.Errorf("update bill archive failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+			return nil, fmt.Errorf("update bill archive failed, err: %v", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListBillArchive list bill archive with options
+func (svc *service) ListBillArchive(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+
+	data, err := svc.dao.AccountBillArchive().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]bill.Archive, 0, len(data.Details))
+	for _, one := range data.Details {
+		details = append(details, bill.Archive{
+			ID:         one.ID,
+			Vendor:     one.Vendor,
+			BillYear:   one.BillYear,
+			BillMonth:  one.BillMonth,
+			State:      one.State,
+			ObjectPath: one.ObjectPath,
+			ItemCount:  cvt.PtrToVal(one.ItemCount),
+			FileSize:   cvt.PtrToVal(one.FileSize),
+			FlowID:     one.FlowID,
+			Reason:     cvt.PtrToVal(one.Reason),
+			RestoredAt: one.RestoredAt,
+			Revision: &core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &dsbill.BillArchiveListResult{Details: details, Count: data.Count}, nil
+}
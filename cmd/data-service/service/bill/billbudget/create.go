@@ -0,0 +1,133 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billbudget
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateBillBudget create bill budget
+func (svc *service) CreateBillBudget(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillBudgetCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	budget := tablebill.AccountBillBudget{
+		Name:          req.Name,
+		Scope:         req.Scope,
+		BkBizID:       req.BkBizID,
+		MainAccountID: req.MainAccountID,
+		ProductID:     req.ProductID,
+		Currency:      req.Currency,
+		Amount:        &types.Decimal{Decimal: req.Amount},
+		Thresholds:    req.Thresholds,
+		ForecastAlert: cvt.ValToPtr(req.ForecastAlert),
+		AnomalyRatio:  cvt.ValToPtr(req.AnomalyRatio),
+		Receivers:     req.Receivers,
+		Memo:          req.Memo,
+		Creator:       cts.Kit.User,
+		Reviser:       cts.Kit.User,
+	}
+	if budget.Thresholds == nil {
+		budget.Thresholds = make(types.Int64Array, 0)
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.AccountBillBudget().CreateWithTx(cts.Kit, txn, []tablebill.AccountBillBudget{budget})
+		if err != nil {
+			logs.Errorf("fail to create bill budget, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("create bill budget failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok || len(ids) != 1 {
+		return nil, fmt.Errorf("create bill budget but return ids is invalid, ids: %v", result)
+	}
+
+	return &core.CreateResult{ID: ids[0]}, nil
+}
+
+// BatchCreateBillBudgetAlert create bill budget alerts
+func (svc *service) BatchCreateBillBudgetAlert(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BatchCreateBillBudgetAlertReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	alerts := make([]tablebill.AccountBillBudgetAlert, 0, len(req.Alerts))
+	for _, one := range req.Alerts {
+		alerts = append(alerts, tablebill.AccountBillBudgetAlert{
+			BudgetID:     one.BudgetID,
+			BillYear:     one.BillYear,
+			BillMonth:    one.BillMonth,
+			BillDay:      one.BillDay,
+			AlertType:    one.AlertType,
+			Threshold:    one.Threshold,
+			Currency:     one.Currency,
+			BudgetAmount: &types.Decimal{Decimal: one.BudgetAmount},
+			Cost:         &types.Decimal{Decimal: one.Cost},
+			ForecastCost: &types.Decimal{Decimal: one.ForecastCost},
+			Message:      one.Message,
+		})
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.AccountBillBudget().CreateAlertWithTx(cts.Kit, txn, alerts)
+		if err != nil {
+			logs.Errorf("fail to create bill budget alert, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("create bill budget alert failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok {
+		return nil, fmt.Errorf("create bill budget alert but return ids type not []string, ids: %v", result)
+	}
+
+	return &core.BatchCreateResult{IDs: ids}, nil
+}
@@ -25,12 +25,14 @@ import (
 
 	"hcm/pkg/api/core"
 	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao/orm"
 	tablebill "hcm/pkg/dal/table/bill"
 	"hcm/pkg/dal/table/types"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest"
+	cvt "hcm/pkg/tools/converter"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -48,12 +50,20 @@ func (svc *service) CreateBillExchangeRate(cts *rest.Contexts) (any, error) {
 	idList, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		var rateList []tablebill.AccountBillExchangeRate
 		for _, rate := range req.ExchangeRates {
+			if len(rate.Source) == 0 {
+				rate.Source = enumor.ExchangeRateSourceManual
+			}
+			if rate.Locked == nil {
+				rate.Locked = cvt.ValToPtr(false)
+			}
 			dbRate := tablebill.AccountBillExchangeRate{
 				Year:         rate.Year,
 				Month:        rate.Month,
 				FromCurrency: rate.FromCurrency,
 				ToCurrency:   rate.ToCurrency,
 				ExchangeRate: &types.Decimal{Decimal: *rate.ExchangeRate},
+				Source:       rate.Source,
+				Locked:       rate.Locked,
 				Creator:      cts.Kit.User,
 				Reviser:      cts.Kit.User,
 			}
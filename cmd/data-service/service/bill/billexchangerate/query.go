@@ -64,6 +64,8 @@ func convExchangeRate(r tablebill.AccountBillExchangeRate) bill.ExchangeRate {
 		FromCurrency: r.FromCurrency,
 		ToCurrency:   r.ToCurrency,
 		ExchangeRate: cvt.ValToPtr(r.ExchangeRate.Decimal),
+		Source:       r.Source,
+		Locked:       r.Locked,
 		Revision: &core.Revision{
 			Creator:   r.Creator,
 			Reviser:   r.Reviser,
@@ -52,6 +52,8 @@ func (svc *service) UpdateBillExchangeRate(cts *rest.Contexts) (any, error) {
 		Month:        req.Month,
 		FromCurrency: req.FromCurrency,
 		ToCurrency:   req.ToCurrency,
+		Source:       req.Source,
+		Locked:       req.Locked,
 		Reviser:      cts.Kit.User,
 	}
 	if req.ExchangeRate != nil {
@@ -38,12 +38,17 @@ func InitService(cap *capability.Capability) {
 	h.Add("ListBillItemExt", http.MethodPost, "/vendors/{vendor}/bills/items/list", svc.ListBillItemExt)
 	h.Add("ListBillItem", http.MethodPost, "/bills/items/list", svc.ListBillItem)
 	h.Add("ListBillItemRaw", http.MethodPost, "/bills/items/list_with_extension", svc.ListBillItemRaw)
+	h.Add("ListBillItemResCost", http.MethodPost, "/bills/items/res_costs/list", svc.ListBillItemResCost)
 
 	h.Add("CreateBillItem", http.MethodPost, "/vendors/{vendor}/bills/items/create", svc.CreateBillItem)
 	h.Add("CreateBillItemRaw", http.MethodPost, "/vendors/{vendor}/bills/rawitems/create", svc.CreateBillItemRaw)
 	h.Add("DeleteBillItem", http.MethodDelete, "/bills/items", svc.DeleteBillItem)
 	h.Add("UpdateBillItem", http.MethodPut, "/vendors/{vendor}/bills/items/update", svc.UpdateBillItem)
 
+	h.Add("ListBillItemPartition", http.MethodPost, "/bills/items/partitions/list", svc.ListBillItemPartition)
+	h.Add("CreateBillItemPartition", http.MethodPost, "/bills/items/partitions/create", svc.CreateBillItemPartition)
+	h.Add("DropBillItemPartition", http.MethodDelete, "/bills/items/partitions", svc.DropBillItemPartition)
+
 	h.Load(cap.WebService)
 }
 
@@ -56,6 +56,8 @@ func (svc *service) CreateBillItem(cts *rest.Contexts) (interface{}, error) {
 	}
 
 	switch vendor {
+	case enumor.TCloud:
+		return createBillItem[bill.TCloudBillItemExtension](cts, svc, vendor)
 	case enumor.Aws:
 		return createBillItem[bill.AwsBillItemExtension](cts, svc, vendor)
 	case enumor.HuaWei:
@@ -107,6 +109,7 @@ func createBillItem[E bill.BillItemExtension](cts *rest.Contexts, svc *service,
 				HcProductName: item.HcProductName,
 				ResAmount:     &types.Decimal{Decimal: item.ResAmount},
 				ResAmountUnit: item.ResAmountUnit,
+				CloudResID:    item.CloudResID,
 				Extension:     types.JsonField(extJson),
 				Creator:       cts.Kit.User,
 				Reviser:       cts.Kit.User,
@@ -0,0 +1,72 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billitem
+
+import (
+	dataproto "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListBillItemPartition list existing bill item partition tables
+func (svc *service) ListBillItemPartition(cts *rest.Contexts) (interface{}, error) {
+	partitions, err := svc.dao.AccountBillItem().ListPartition(cts.Kit)
+	if err != nil {
+		logs.Errorf("list bill item partition failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &dataproto.BillItemPartitionListResult{Count: uint64(len(partitions)), Details: partitions}, nil
+}
+
+// CreateBillItemPartition create bill item partition table from template table
+func (svc *service) CreateBillItemPartition(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataproto.BillItemPartitionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.AccountBillItem().CreatePartition(cts.Kit, req); err != nil {
+		logs.Errorf("create bill item partition failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// DropBillItemPartition drop bill item partition table
+func (svc *service) DropBillItemPartition(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataproto.BillItemPartitionReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := svc.dao.AccountBillItem().DropPartition(cts.Kit, req); err != nil {
+		logs.Errorf("drop bill item partition failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+	return nil, nil
+}
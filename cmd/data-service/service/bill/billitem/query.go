@@ -96,6 +96,38 @@ func (svc *service) ListBillItem(cts *rest.Contexts) (interface{}, error) {
 	return &dataproto.BillItemBaseListResult{Details: details, Count: data.Count}, nil
 }
 
+// ListBillItemResCost list bill item cost group by cloud resource id and currency
+func (svc *service) ListBillItemResCost(cts *rest.Contexts) (any, error) {
+	req := new(dataproto.BillItemListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+
+	data, err := svc.dao.AccountBillItem().ListResCost(cts.Kit, req.ItemCommonOpt, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*bill.ResourceCost, 0, len(data.Details))
+	for _, d := range data.Details {
+		one := &bill.ResourceCost{CloudResID: d.CloudResID, Currency: d.Currency}
+		if d.Cost != nil {
+			one.Cost = d.Cost.Decimal
+		}
+		details = append(details, one)
+	}
+
+	return &dataproto.BillItemResCostListResult{Details: details, Count: data.Count}, nil
+}
+
 // ListBillItemExt ...
 func (svc *service) ListBillItemExt(cts *rest.Contexts) (any, error) {
 
@@ -105,6 +137,8 @@ func (svc *service) ListBillItemExt(cts *rest.Contexts) (any, error) {
 	}
 
 	switch vendor {
+	case enumor.TCloud:
+		return listBillItemExt[bill.TCloudBillItemExtension](cts, svc, vendor)
 	case enumor.Aws:
 		return listBillItemExt[bill.AwsBillItemExtension](cts, svc, vendor)
 	case enumor.HuaWei:
@@ -187,6 +221,7 @@ func convBillItem(m *tablebill.AccountBillItem) *bill.BaseBillItem {
 		HcProductName: m.HcProductName,
 		ResAmount:     m.ResAmount.Decimal,
 		ResAmountUnit: m.ResAmountUnit,
+		CloudResID:    m.CloudResID,
 		Revision: &core.Revision{
 			CreatedAt: m.CreatedAt.String(),
 			UpdatedAt: m.UpdatedAt.String(),
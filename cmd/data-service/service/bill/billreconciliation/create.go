@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billreconciliation
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateBillReconciliation create bill reconciliation
+func (svc *service) CreateBillReconciliation(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillReconciliationCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	details, err := types.NewJsonField(req.Details)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	record := tablebill.AccountBillReconciliation{
+		RootAccountID:  req.RootAccountID,
+		Vendor:         req.Vendor,
+		BillYear:       req.BillYear,
+		BillMonth:      req.BillMonth,
+		SummaryVersion: req.SummaryVersion,
+		State:          req.State,
+		Details:        details,
+		Creator:        cts.Kit.User,
+	}
+
+	result, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.AccountBillReconciliation().CreateWithTx(cts.Kit, txn,
+			[]tablebill.AccountBillReconciliation{record})
+		if err != nil {
+			logs.Errorf("fail to create bill reconciliation, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("create bill reconciliation failed, err: %v", err)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids, ok := result.([]string)
+	if !ok || len(ids) != 1 {
+		return nil, fmt.Errorf("create bill reconciliation but return ids is invalid, ids: %v", result)
+	}
+
+	return &core.CreateResult{ID: ids[0]}, nil
+}
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billreconciliation
+
+import (
+	"encoding/json"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListBillReconciliation list bill reconciliation with options
+func (svc *service) ListBillReconciliation(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+
+	data, err := svc.dao.AccountBillReconciliation().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]bill.Reconciliation, 0, len(data.Details))
+	for _, one := range data.Details {
+		record, err := convReconciliation(one)
+		if err != nil {
+			logs.Errorf("convert bill reconciliation failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, record)
+	}
+
+	return &dsbill.BillReconciliationListResult{Details: details, Count: data.Count}, nil
+}
+
+func convReconciliation(r tablebill.AccountBillReconciliation) (bill.Reconciliation, error) {
+	record := bill.Reconciliation{
+		ID:             r.ID,
+		RootAccountID:  r.RootAccountID,
+		Vendor:         r.Vendor,
+		BillYear:       r.BillYear,
+		BillMonth:      r.BillMonth,
+		SummaryVersion: r.SummaryVersion,
+		State:          r.State,
+		Creator:        r.Creator,
+		CreatedAt:      r.CreatedAt.String(),
+	}
+	if !r.Details.IsEmpty() && r.Details != "null" {
+		if err := json.Unmarshal([]byte(r.Details), &record.Details); err != nil {
+			return record, err
+		}
+	}
+	return record, nil
+}
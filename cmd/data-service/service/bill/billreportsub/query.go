@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billreportsub
+
+import (
+	"encoding/json"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	cvt "hcm/pkg/tools/converter"
+)
+
+// ListBillReportSub list bill report subscription with options
+func (svc *service) ListBillReportSub(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+	opt := &types.ListOption{
+		Filter: req.Filter,
+		Page:   req.Page,
+		Fields: req.Fields,
+	}
+
+	data, err := svc.dao.AccountBillReportSub().List(cts.Kit, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]bill.ReportSubscription, 0, len(data.Details))
+	for _, one := range data.Details {
+		sub, err := convReportSub(one)
+		if err != nil {
+			logs.Errorf("convert bill report subscription failed, err: %v, id: %s, rid: %s", err, one.ID,
+				cts.Kit.Rid)
+			return nil, err
+		}
+		details = append(details, sub)
+	}
+
+	return &dsbill.BillReportSubListResult{Details: details, Count: data.Count}, nil
+}
+
+func convReportSub(s tablebill.AccountBillReportSub) (bill.ReportSubscription, error) {
+	sub := bill.ReportSubscription{
+		ID:            s.ID,
+		Name:          s.Name,
+		ReportType:    s.ReportType,
+		Vendor:        s.Vendor,
+		Format:        s.Format,
+		Cadence:       s.Cadence,
+		SendDay:       s.SendDay,
+		Receivers:     s.Receivers,
+		Enabled:       cvt.PtrToVal(s.Enabled),
+		LastBillYear:  s.LastBillYear,
+		LastBillMonth: s.LastBillMonth,
+		Memo:          s.Memo,
+		Revision: &core.Revision{
+			Creator:   s.Creator,
+			Reviser:   s.Reviser,
+			CreatedAt: s.CreatedAt.String(),
+			UpdatedAt: s.UpdatedAt.String(),
+		},
+	}
+	if !s.Filter.IsEmpty() && s.Filter != "null" {
+		sub.Filter = new(filter.Expression)
+		if err := json.Unmarshal([]byte(s.Filter), sub.Filter); err != nil {
+			return sub, err
+		}
+	}
+	return sub, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package billreportsub
+
+import (
+	"fmt"
+
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// UpdateBillReportSub update bill report subscription
+func (svc *service) UpdateBillReportSub(cts *rest.Contexts) (any, error) {
+	req := new(dsbill.BillReportSubUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sub := &tablebill.AccountBillReportSub{
+		ID:            req.ID,
+		Name:          req.Name,
+		Format:        req.Format,
+		Cadence:       req.Cadence,
+		SendDay:       req.SendDay,
+		Receivers:     req.Receivers,
+		Enabled:       req.Enabled,
+		LastBillYear:  req.LastBillYear,
+		LastBillMonth: req.LastBillMonth,
+		Memo:          req.Memo,
+		Reviser:       cts.Kit.User,
+	}
+	if req.Filter != nil {
+		filterJson, err := types.NewJsonField(req.Filter)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		sub.Filter = filterJson
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		if err := svc.dao.AccountBillReportSub().UpdateByIDWithTx(cts.Kit, txn, req.ID, sub); err != nil {
+			logs.Errorf("update bill report subscription failed, err: %v, id: %s, rid: %s", err, req.ID,
+				cts.Kit.Rid)
+			return nil, fmt.Errorf("update bill report subscription failed, err: %v", err)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
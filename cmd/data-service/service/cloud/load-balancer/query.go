@@ -138,6 +138,7 @@ func convTableToBaseLB(one *tablelb.LoadBalancerTable) *corelb.BaseLoadBalancer
 		CloudCreatedTime:     one.CloudCreatedTime,
 		CloudStatusTime:      one.CloudStatusTime,
 		CloudExpiredTime:     one.CloudExpiredTime,
+		RecycleStatus:        one.RecycleStatus,
 		Memo:                 one.Memo,
 		Revision: &core.Revision{
 			Creator:   one.Creator,
@@ -0,0 +1,94 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sglint
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	tablecloud "hcm/pkg/dal/table/cloud"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreate security group lint findings.
+func (svc *sgLintSvc) BatchCreate(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocloud.SGLintFindingBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablecloud.SecurityGroupLintFindingTable, 0, len(req.Findings))
+	for _, one := range req.Findings {
+		detail, err := json.MarshalToString(one.Detail)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+
+		models = append(models, tablecloud.SecurityGroupLintFindingTable{
+			Vendor:               one.Vendor,
+			AccountID:            one.AccountID,
+			BkBizID:              one.BkBizID,
+			Region:               one.Region,
+			SecurityGroupID:      one.SecurityGroupID,
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			RuleID:               one.RuleID,
+			RuleType:             one.RuleType,
+			LintType:             one.LintType,
+			RiskLevel:            one.RiskLevel,
+			RiskScore:            one.RiskScore,
+			Detail:               types.JsonField(detail),
+			Creator:              cts.Kit.User,
+			Reviser:              cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.SGLintFinding().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create security group lint findings failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create security group lint findings failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create security group lint findings but return id type is not []string, "+
+			"id type: %T", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package sglint
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corecloud "hcm/pkg/api/core/cloud"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/json"
+)
+
+// List security group lint findings.
+func (svc *sgLintSvc) List(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.SGLintFinding().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list security group lint findings failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list security group lint findings failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protocloud.SGLintFindingListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corecloud.SGLintFinding, 0, len(result.Details))
+	for _, one := range result.Details {
+		var detail *corecloud.SGLintDetail
+		if !one.Detail.IsEmpty() {
+			detail = new(corecloud.SGLintDetail)
+			if err = json.UnmarshalFromString(string(one.Detail), detail); err != nil {
+				logs.Errorf("unmarshal security group lint finding detail failed, err: %v, id: %s, rid: %s", err,
+					one.ID, cts.Kit.Rid)
+				return nil, fmt.Errorf("unmarshal security group lint finding detail failed, err: %v", err)
+			}
+		}
+
+		details = append(details, corecloud.SGLintFinding{
+			ID:                   one.ID,
+			Vendor:               one.Vendor,
+			AccountID:            one.AccountID,
+			BkBizID:              one.BkBizID,
+			Region:               one.Region,
+			SecurityGroupID:      one.SecurityGroupID,
+			CloudSecurityGroupID: one.CloudSecurityGroupID,
+			RuleID:               one.RuleID,
+			RuleType:             one.RuleType,
+			LintType:             one.LintType,
+			RiskLevel:            one.RiskLevel,
+			RiskScore:            one.RiskScore,
+			Detail:               detail,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protocloud.SGLintFindingListResult{Details: details}, nil
+}
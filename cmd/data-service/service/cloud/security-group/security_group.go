@@ -153,18 +153,19 @@ func (svc *securityGroupSvc) ListSecurityGroup(cts *rest.Contexts) (interface{},
 	details := make([]corecloud.BaseSecurityGroup, 0, len(result.Details))
 	for _, one := range result.Details {
 		details = append(details, corecloud.BaseSecurityGroup{
-			ID:        one.ID,
-			Vendor:    one.Vendor,
-			CloudID:   one.CloudID,
-			BkBizID:   one.BkBizID,
-			Region:    one.Region,
-			Name:      one.Name,
-			Memo:      one.Memo,
-			AccountID: one.AccountID,
-			Creator:   one.Creator,
-			Reviser:   one.Reviser,
-			CreatedAt: one.CreatedAt.String(),
-			UpdatedAt: one.UpdatedAt.String(),
+			ID:            one.ID,
+			Vendor:        one.Vendor,
+			CloudID:       one.CloudID,
+			BkBizID:       one.BkBizID,
+			Region:        one.Region,
+			Name:          one.Name,
+			Memo:          one.Memo,
+			AccountID:     one.AccountID,
+			RecycleStatus: one.RecycleStatus,
+			Creator:       one.Creator,
+			Reviser:       one.Reviser,
+			CreatedAt:     one.CreatedAt.String(),
+			UpdatedAt:     one.UpdatedAt.String(),
 		})
 	}
 
@@ -290,18 +291,19 @@ func (svc *securityGroupSvc) GetSecurityGroup(cts *rest.Contexts) (interface{},
 
 func convTableToBaseSG(sgTable *tablecloud.SecurityGroupTable) *corecloud.BaseSecurityGroup {
 	return &corecloud.BaseSecurityGroup{
-		ID:        sgTable.ID,
-		Vendor:    sgTable.Vendor,
-		CloudID:   sgTable.CloudID,
-		BkBizID:   sgTable.BkBizID,
-		Region:    sgTable.Region,
-		Name:      sgTable.Name,
-		Memo:      sgTable.Memo,
-		AccountID: sgTable.AccountID,
-		Creator:   sgTable.Creator,
-		Reviser:   sgTable.Reviser,
-		CreatedAt: sgTable.CreatedAt.String(),
-		UpdatedAt: sgTable.UpdatedAt.String(),
+		ID:            sgTable.ID,
+		Vendor:        sgTable.Vendor,
+		CloudID:       sgTable.CloudID,
+		BkBizID:       sgTable.BkBizID,
+		Region:        sgTable.Region,
+		Name:          sgTable.Name,
+		Memo:          sgTable.Memo,
+		AccountID:     sgTable.AccountID,
+		RecycleStatus: sgTable.RecycleStatus,
+		Creator:       sgTable.Creator,
+		Reviser:       sgTable.Reviser,
+		CreatedAt:     sgTable.CreatedAt.String(),
+		UpdatedAt:     sgTable.UpdatedAt.String(),
 	}
 }
 
@@ -0,0 +1,200 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateGroup create rbac group.
+func (svc *service) CreateGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.GroupCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := tablerbac.GroupTable{
+		Name:    req.Name,
+		Members: slice.Unique(req.Members),
+		Memo:    req.Memo,
+		Creator: cts.Kit.User,
+		Reviser: cts.Kit.User,
+	}
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.RBACGroup().BatchCreateWithTx(cts.Kit, txn, []tablerbac.GroupTable{model})
+		if err != nil {
+			return nil, fmt.Errorf("create rbac group failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("create rbac group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok || len(idList) != 1 {
+		return nil, fmt.Errorf("create rbac group but return ids is invalid, ids: %v", ids)
+	}
+
+	return &core.CreateResult{ID: idList[0]}, nil
+}
+
+// UpdateGroup update rbac group, members are replaced when set.
+func (svc *service) UpdateGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.GroupUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablerbac.GroupTable{
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if req.Members != nil {
+		model.Members = slice.Unique(req.Members)
+	}
+
+	if err := svc.dao.RBACGroup().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update rbac group failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListGroup list rbac group.
+func (svc *service) ListGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.RBACGroup().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list rbac group failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &proto.GroupListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corerbac.Group, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corerbac.Group{
+			ID:      one.ID,
+			Name:    one.Name,
+			Members: one.Members,
+			Memo:    converter.PtrToVal(one.Memo),
+			Revision: convRevision(one.Creator, one.Reviser, one.CreatedAt.String(),
+				one.UpdatedAt.String()),
+		})
+	}
+
+	return &proto.GroupListResult{Details: details}, nil
+}
+
+// BatchDeleteGroup batch delete rbac group, role bindings of the groups are deleted too.
+func (svc *service) BatchDeleteGroup(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	groups, err := listAll(cts.Kit, svc.dao.RBACGroup().List, req.Filter, []string{"id", "name"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, batch := range slice.Split(groups, int(core.DefaultMaxPageLimit)) {
+			ids, names := make([]string, 0, len(batch)), make([]string, 0, len(batch))
+			for _, one := range batch {
+				ids = append(ids, one.ID)
+				names = append(names, one.Name)
+			}
+
+			bindingExpr := tools.ExpressionAnd(
+				tools.RuleEqual("subject_type", string(enumor.RBACSubjectGroup)),
+				tools.RuleIn("subject", names),
+			)
+			if err := svc.dao.RBACRoleBinding().DeleteWithTx(cts.Kit, txn, bindingExpr); err != nil {
+				return nil, err
+			}
+
+			delFilter := tools.ContainersExpression("id", ids)
+			if err := svc.dao.RBACGroup().DeleteWithTx(cts.Kit, txn, delFilter); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete rbac group failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
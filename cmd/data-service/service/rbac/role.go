@@ -0,0 +1,215 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	tabletypes "hcm/pkg/dal/table/types"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateRole create rbac role.
+func (svc *service) CreateRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.RoleCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	permissions, err := tabletypes.NewJsonField(req.Permissions)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := tablerbac.RoleTable{
+		Name:        req.Name,
+		Permissions: permissions,
+		Memo:        req.Memo,
+		Creator:     cts.Kit.User,
+		Reviser:     cts.Kit.User,
+	}
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.RBACRole().BatchCreateWithTx(cts.Kit, txn, []tablerbac.RoleTable{model})
+		if err != nil {
+			return nil, fmt.Errorf("create rbac role failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("create rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok || len(idList) != 1 {
+		return nil, fmt.Errorf("create rbac role but return ids is invalid, ids: %v", ids)
+	}
+
+	return &core.CreateResult{ID: idList[0]}, nil
+}
+
+// UpdateRole update rbac role.
+func (svc *service) UpdateRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.RoleUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablerbac.RoleTable{
+		Name:    req.Name,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if req.Permissions != nil {
+		permissions, err := tabletypes.NewJsonField(req.Permissions)
+		if err != nil {
+			return nil, errf.NewFromErr(errf.InvalidParameter, err)
+		}
+		model.Permissions = permissions
+	}
+
+	if err := svc.dao.RBACRole().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update rbac role failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRole list rbac role.
+func (svc *service) ListRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.RBACRole().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list rbac role failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &proto.RoleListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corerbac.Role, 0, len(result.Details))
+	for _, one := range result.Details {
+		permissions := make([]corerbac.Permission, 0)
+		if !one.Permissions.IsEmpty() {
+			if err = json.Unmarshal([]byte(one.Permissions), &permissions); err != nil {
+				logs.Errorf("unmarshal rbac role permissions failed, err: %v, id: %s, rid: %s", err, one.ID,
+					cts.Kit.Rid)
+				return nil, err
+			}
+		}
+
+		details = append(details, corerbac.Role{
+			ID:          one.ID,
+			Name:        one.Name,
+			Permissions: permissions,
+			Memo:        converter.PtrToVal(one.Memo),
+			Revision: convRevision(one.Creator, one.Reviser, one.CreatedAt.String(),
+				one.UpdatedAt.String()),
+		})
+	}
+
+	return &proto.RoleListResult{Details: details}, nil
+}
+
+// BatchDeleteRole batch delete rbac role, role bindings of the roles are deleted too.
+func (svc *service) BatchDeleteRole(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	roles, err := listAll(cts.Kit, svc.dao.RBACRole().List, req.Filter, []string{"id"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, 0, len(roles))
+	for _, one := range roles {
+		delIDs = append(delIDs, one.ID)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, ids := range slice.Split(delIDs, int(core.DefaultMaxPageLimit)) {
+			if err := svc.dao.RBACRoleBinding().DeleteWithTx(cts.Kit, txn,
+				tools.ContainersExpression("role_id", ids)); err != nil {
+				return nil, err
+			}
+
+			if err := svc.dao.RBACRole().DeleteWithTx(cts.Kit, txn, tools.ContainersExpression("id", ids)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete rbac role failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
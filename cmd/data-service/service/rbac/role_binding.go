@@ -0,0 +1,200 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablerbac "hcm/pkg/dal/table/rbac"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateRoleBinding create rbac role binding.
+func (svc *service) CreateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.RoleBindingCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	roleOpt := &types.ListOption{
+		Filter: tools.EqualExpression("id", req.RoleID),
+		Page:   core.NewCountPage(),
+	}
+	roleResult, err := svc.dao.RBACRole().List(cts.Kit, roleOpt)
+	if err != nil {
+		logs.Errorf("count rbac role failed, err: %v, id: %s, rid: %s", err, req.RoleID, cts.Kit.Rid)
+		return nil, err
+	}
+	if roleResult.Count == 0 {
+		return nil, errf.Newf(errf.RecordNotFound, "rbac role %s not exists", req.RoleID)
+	}
+
+	model := tablerbac.RoleBindingTable{
+		RoleID:      req.RoleID,
+		SubjectType: req.SubjectType,
+		Subject:     req.Subject,
+		BkBizIDs:    slice.Unique(req.BkBizIDs),
+		Creator:     cts.Kit.User,
+		Reviser:     cts.Kit.User,
+	}
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.RBACRoleBinding().BatchCreateWithTx(cts.Kit, txn, []tablerbac.RoleBindingTable{model})
+		if err != nil {
+			return nil, fmt.Errorf("create rbac role binding failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("create rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok || len(idList) != 1 {
+		return nil, fmt.Errorf("create rbac role binding but return ids is invalid, ids: %v", ids)
+	}
+
+	return &core.CreateResult{ID: idList[0]}, nil
+}
+
+// UpdateRoleBinding update business scope of rbac role binding.
+func (svc *service) UpdateRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(proto.RoleBindingUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablerbac.RoleBindingTable{
+		BkBizIDs: slice.Unique(req.BkBizIDs),
+		Reviser:  cts.Kit.User,
+	}
+	if err := svc.dao.RBACRoleBinding().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update rbac role binding failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRoleBinding list rbac role binding.
+func (svc *service) ListRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.RBACRoleBinding().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list rbac role binding failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &proto.RoleBindingListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corerbac.RoleBinding, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corerbac.RoleBinding{
+			ID:          one.ID,
+			RoleID:      one.RoleID,
+			SubjectType: one.SubjectType,
+			Subject:     one.Subject,
+			BkBizIDs:    one.BkBizIDs,
+			Revision: convRevision(one.Creator, one.Reviser, one.CreatedAt.String(),
+				one.UpdatedAt.String()),
+		})
+	}
+
+	return &proto.RoleBindingListResult{Details: details}, nil
+}
+
+// BatchDeleteRoleBinding batch delete rbac role binding.
+func (svc *service) BatchDeleteRoleBinding(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bindings, err := listAll(cts.Kit, svc.dao.RBACRoleBinding().List, req.Filter, []string{"id"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+
+	delIDs := make([]string, 0, len(bindings))
+	for _, one := range bindings {
+		delIDs = append(delIDs, one.ID)
+	}
+
+	_, err = svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, ids := range slice.Split(delIDs, int(core.DefaultMaxPageLimit)) {
+			if err := svc.dao.RBACRoleBinding().DeleteWithTx(cts.Kit, txn,
+				tools.ContainersExpression("id", ids)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete rbac role binding failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
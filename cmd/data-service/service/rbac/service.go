@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地RBAC角色、用户组及角色绑定管理
+package rbac
+
+import (
+	"fmt"
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/api/core"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+)
+
+// InitService initialize the local rbac service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+	h := rest.NewHandler()
+	h.Add("CreateRBACRole", http.MethodPost, "/rbac/roles/create", svc.CreateRole)
+	h.Add("UpdateRBACRole", http.MethodPatch, "/rbac/roles", svc.UpdateRole)
+	h.Add("ListRBACRole", http.MethodPost, "/rbac/roles/list", svc.ListRole)
+	h.Add("BatchDeleteRBACRole", http.MethodDelete, "/rbac/roles/batch", svc.BatchDeleteRole)
+
+	h.Add("CreateRBACGroup", http.MethodPost, "/rbac/groups/create", svc.CreateGroup)
+	h.Add("UpdateRBACGroup", http.MethodPatch, "/rbac/groups", svc.UpdateGroup)
+	h.Add("ListRBACGroup", http.MethodPost, "/rbac/groups/list", svc.ListGroup)
+	h.Add("BatchDeleteRBACGroup", http.MethodDelete, "/rbac/groups/batch", svc.BatchDeleteGroup)
+
+	h.Add("CreateRBACRoleBinding", http.MethodPost, "/rbac/role_bindings/create", svc.CreateRoleBinding)
+	h.Add("UpdateRBACRoleBinding", http.MethodPatch, "/rbac/role_bindings", svc.UpdateRoleBinding)
+	h.Add("ListRBACRoleBinding", http.MethodPost, "/rbac/role_bindings/list", svc.ListRoleBinding)
+	h.Add("BatchDeleteRBACRoleBinding", http.MethodDelete, "/rbac/role_bindings/batch", svc.BatchDeleteRoleBinding)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
+
+type lister[T any] func(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[T], error)
+
+// listAll list all records matched with the filter.
+func listAll[T any](kt *kit.Kit, list lister[T], expr *filter.Expression, fields []string) ([]T, error) {
+	opt := &types.ListOption{
+		Fields: fields,
+		Filter: expr,
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	all := make([]T, 0)
+	for {
+		result, err := list(kt, opt)
+		if err != nil {
+			logs.Errorf("list records failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, fmt.Errorf("list records failed, err: %v", err)
+		}
+
+		all = append(all, result.Details...)
+		if uint(len(result.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	return all, nil
+}
+
+func convRevision(creator, reviser, createdAt, updatedAt string) core.Revision {
+	return core.Revision{
+		Creator:   creator,
+		Reviser:   reviser,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
@@ -0,0 +1,260 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recyclerecord
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/recycle-record"
+	dataservice "hcm/pkg/api/data-service"
+	protodata "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	prototable "hcm/pkg/dal/table/recycle-record"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/runtime/filter"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateRecyclePolicy batch create recycle policy.
+func (svc *recycleRecordSvc) BatchCreateRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(protodata.RecyclePolicyBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]prototable.RecyclePolicyTable, 0, len(req.Policies))
+	for _, one := range req.Policies {
+		models = append(models, prototable.RecyclePolicyTable{
+			BkBizID:      one.BkBizID,
+			ResType:      one.ResType,
+			ReserveTime:  one.ReserveTime,
+			NotifyBefore: one.NotifyBefore,
+			Memo:         one.Memo,
+			Creator:      cts.Kit.User,
+			Reviser:      cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.RecyclePolicy().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create recycle policy failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create recycle policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create recycle policy but return id type is not []string, id type: %T", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
+
+// UpdateRecyclePolicy update recycle policy.
+func (svc *recycleRecordSvc) UpdateRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(protodata.RecyclePolicyUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &prototable.RecyclePolicyTable{
+		ReserveTime:  req.ReserveTime,
+		NotifyBefore: req.NotifyBefore,
+		Memo:         req.Memo,
+		Reviser:      cts.Kit.User,
+	}
+	if err := svc.dao.RecyclePolicy().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update recycle policy failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListRecyclePolicy list recycle policy.
+func (svc *recycleRecordSvc) ListRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.RecyclePolicy().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list recycle policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list recycle policy failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protodata.RecyclePolicyListResult{Count: result.Count}, nil
+	}
+
+	details := make([]protocore.RecyclePolicy, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, protocore.RecyclePolicy{
+			ID:           one.ID,
+			BkBizID:      one.BkBizID,
+			ResType:      one.ResType,
+			ReserveTime:  one.ReserveTime,
+			NotifyBefore: one.NotifyBefore,
+			Memo:         converter.PtrToVal(one.Memo),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protodata.RecyclePolicyListResult{Details: details}, nil
+}
+
+// BatchDeleteRecyclePolicy batch delete recycle policy.
+func (svc *recycleRecordSvc) BatchDeleteRecyclePolicy(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	delIDs := make([]string, 0)
+	for {
+		listResp, err := svc.dao.RecyclePolicy().List(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list recycle policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("list recycle policy failed, err: %v", err)
+		}
+
+		for _, one := range listResp.Details {
+			delIDs = append(delIDs, one.ID)
+		}
+
+		if uint(len(listResp.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	if len(delIDs) == 0 {
+		return nil, nil
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, ids := range slice.Split(delIDs, int(core.DefaultMaxPageLimit)) {
+			if err := svc.dao.RecyclePolicy().DeleteWithTx(cts.Kit, txn,
+				tools.ContainersExpression("id", ids)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete recycle policy failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getRecyclePolicyReserveTime 获取业务下对应资源类型的回收站保留时长，key为业务ID，value为保留时长(小时)
+func (svc *recycleRecordSvc) getRecyclePolicyReserveTime(kt *kit.Kit, resType enumor.CloudResourceType,
+	bizIDs []int64) (map[int64]int, error) {
+
+	result := make(map[int64]int)
+	for _, ids := range slice.Split(slice.Unique(bizIDs), int(core.DefaultMaxPageLimit)) {
+		opt := &types.ListOption{
+			Filter: &filter.Expression{
+				Op: filter.And,
+				Rules: []filter.RuleFactory{
+					filter.AtomRule{Field: "res_type", Op: filter.Equal.Factory(), Value: resType},
+					filter.AtomRule{Field: "bk_biz_id", Op: filter.In.Factory(), Value: ids},
+				},
+			},
+			Page:   core.NewDefaultBasePage(),
+			Fields: []string{"bk_biz_id", "reserve_time"},
+		}
+		listResp, err := svc.dao.RecyclePolicy().List(kt, opt)
+		if err != nil {
+			logs.Errorf("list recycle policy failed, err: %v, res_type: %s, rid: %s", err, resType, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range listResp.Details {
+			result[one.BkBizID] = one.ReserveTime
+		}
+	}
+
+	return result, nil
+}
+
+// chooseRecycleReserveTime 保留时长优先级: 业务资源类型回收策略 > 账号回收站保留时长 > 全局默认时长
+func chooseRecycleReserveTime(policyReserveTime map[int64]int, bizID int64, accountReserveTime int,
+	defaultReserveTime uint) uint {
+
+	if reserveTime, exists := policyReserveTime[bizID]; exists {
+		return uint(reserveTime)
+	}
+	if accountReserveTime > -1 {
+		return uint(accountReserveTime)
+	}
+	return defaultReserveTime
+}
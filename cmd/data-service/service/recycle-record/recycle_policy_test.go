@@ -0,0 +1,51 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recyclerecord
+
+import "testing"
+
+func TestChooseRecycleReserveTime(t *testing.T) {
+	policyReserveTime := map[int64]int{100: 72, 200: 0}
+
+	cases := []struct {
+		name        string
+		bizID       int64
+		accountTime int
+		defaultTime uint
+		expect      uint
+	}{
+		{name: "business policy first", bizID: 100, accountTime: 24, defaultTime: 48, expect: 72},
+		{name: "business policy zero reserve time", bizID: 200, accountTime: 24, defaultTime: 48, expect: 0},
+		{name: "account reserve time without policy", bizID: 300, accountTime: 24, defaultTime: 48, expect: 24},
+		{name: "account reserve time zero", bizID: 300, accountTime: 0, defaultTime: 48, expect: 0},
+		{name: "global default", bizID: 300, accountTime: -1, defaultTime: 48, expect: 48},
+	}
+
+	for _, c := range cases {
+		got := chooseRecycleReserveTime(policyReserveTime, c.bizID, c.accountTime, c.defaultTime)
+		if got != c.expect {
+			t.Errorf("%s: expect reserve time %d, got %d", c.name, c.expect, got)
+		}
+	}
+
+	if got := chooseRecycleReserveTime(nil, 100, -1, 48); got != 48 {
+		t.Errorf("nil policy: expect reserve time 48, got %d", got)
+	}
+}
@@ -60,6 +60,11 @@ func InitRecycleRecordService(cap *capability.Capability) {
 	h.Add("BatchUpdateRecycleStatus", "PATCH", "/recycle_records/recycle_status/batch",
 		svc.BatchUpdateRecycleStatus)
 
+	h.Add("BatchCreateRecyclePolicy", "POST", "/recycle_policies/batch/create", svc.BatchCreateRecyclePolicy)
+	h.Add("UpdateRecyclePolicy", "PATCH", "/recycle_policies", svc.UpdateRecyclePolicy)
+	h.Add("ListRecyclePolicy", "POST", "/recycle_policies/list", svc.ListRecyclePolicy)
+	h.Add("BatchDeleteRecyclePolicy", "DELETE", "/recycle_policies/batch", svc.BatchDeleteRecyclePolicy)
+
 	h.Load(cap.WebService)
 }
 
@@ -91,24 +96,31 @@ func (svc *recycleRecordSvc) BatchRecycleCloudResource(cts *rest.Contexts) (inte
 		return nil, errf.Newf(errf.InvalidParameter, "recycle resource count is invalid")
 	}
 
+	recycleDetails, err := getRecycleDetails(req.Infos, resourceInfo)
+	if err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	bizIDs := make([]int64, 0, len(resourceInfo))
+	for _, info := range resourceInfo {
+		bizIDs = append(bizIDs, info.BkBizID)
+	}
+	policyReserveTime, err := svc.getRecyclePolicyReserveTime(cts.Kit, req.ResType, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	taskID, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
 		recycleRecords := make([]prototable.RecycleRecordTable, 0, len(resourceInfo))
-		for idx, info := range resourceInfo {
+		for _, info := range resourceInfo {
 			accountInfo, err := svc.checkAndGetAccount(cts.Kit, info)
 			if err != nil {
 				return nil, err
 			}
 
-			recycleDetail, err := tabletype.NewJsonField(req.Infos[idx].Detail)
-			if err != nil {
-				return nil, errf.NewFromErr(errf.InvalidParameter, err)
-			}
-
-			recycleReserveTime := req.DefaultRecycleTime
 			// TODO: 将默认时间修改放到cloud-server中去做
-			if accountInfo.Details[0].RecycleReserveTime > -1 {
-				recycleReserveTime = uint(accountInfo.Details[0].RecycleReserveTime)
-			}
+			recycleReserveTime := chooseRecycleReserveTime(policyReserveTime, info.BkBizID,
+				accountInfo.Details[0].RecycleReserveTime, req.DefaultRecycleTime)
 			recycleRecords = append(recycleRecords, prototable.RecycleRecordTable{
 				RecycleType: req.RecycleType,
 				Vendor:      info.Vendor,
@@ -119,7 +131,7 @@ func (svc *recycleRecordSvc) BatchRecycleCloudResource(cts *rest.Contexts) (inte
 				BkBizID:     info.BkBizID,
 				AccountID:   info.AccountID,
 				Region:      info.Region,
-				Detail:      recycleDetail,
+				Detail:      recycleDetails[info.ID],
 				Status:      enumor.WaitingRecycleRecordStatus,
 				Creator:     cts.Kit.User,
 				Reviser:     cts.Kit.User,
@@ -145,6 +157,32 @@ func (svc *recycleRecordSvc) BatchRecycleCloudResource(cts *rest.Contexts) (inte
 	return taskID, nil
 }
 
+// getRecycleDetails 按资源ID获取回收详情，查询到的资源顺序与请求顺序不一定相同，需按ID匹配而不能按下标匹配
+func getRecycleDetails(infos []protodata.RecycleReq, resources []protodao.RecycleResourceInfo) (
+	map[string]tabletype.JsonField, error) {
+
+	detailMap := make(map[string]tabletype.JsonField, len(infos))
+	for _, one := range infos {
+		if _, exists := detailMap[one.ID]; exists {
+			return nil, fmt.Errorf("recycle resource: %s is duplicated", one.ID)
+		}
+
+		detail, err := tabletype.NewJsonField(one.Detail)
+		if err != nil {
+			return nil, err
+		}
+		detailMap[one.ID] = detail
+	}
+
+	for _, one := range resources {
+		if _, exists := detailMap[one.ID]; !exists {
+			return nil, fmt.Errorf("recycle detail of resource: %s not found", one.ID)
+		}
+	}
+
+	return detailMap, nil
+}
+
 func (svc *recycleRecordSvc) checkAndGetAccount(kt *kit.Kit, info protodao.RecycleResourceInfo) (
 	*types.ListAccountDetails, error) {
 
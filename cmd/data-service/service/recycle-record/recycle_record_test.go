@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recyclerecord
+
+import (
+	"testing"
+
+	protodata "hcm/pkg/api/data-service/recycle-record"
+	protodao "hcm/pkg/dal/dao/types/recycle-record"
+	tabletype "hcm/pkg/dal/table/types"
+)
+
+func TestGetRecycleDetails(t *testing.T) {
+	infos := []protodata.RecycleReq{
+		{ID: "lb-1", Detail: map[string]string{"listener": "lbl-1"}},
+		{ID: "lb-2", Detail: map[string]string{"listener": "lbl-2"}},
+		{ID: "lb-3", Detail: map[string]string{"listener": "lbl-3"}},
+	}
+	// 数据库返回的资源顺序与请求顺序不同
+	resources := []protodao.RecycleResourceInfo{{ID: "lb-3"}, {ID: "lb-1"}, {ID: "lb-2"}}
+
+	details, err := getRecycleDetails(infos, resources)
+	if err != nil {
+		t.Fatalf("get recycle details failed, err: %v", err)
+	}
+
+	expects := map[string]tabletype.JsonField{
+		"lb-1": `{"listener":"lbl-1"}`,
+		"lb-2": `{"listener":"lbl-2"}`,
+		"lb-3": `{"listener":"lbl-3"}`,
+	}
+	for _, res := range resources {
+		if details[res.ID] != expects[res.ID] {
+			t.Errorf("detail of %s should be %s, got: %s", res.ID, expects[res.ID], details[res.ID])
+		}
+	}
+
+	if _, err = getRecycleDetails(append(infos, infos[0]), resources); err == nil {
+		t.Errorf("duplicated recycle resource should be rejected")
+	}
+
+	unknown := []protodao.RecycleResourceInfo{{ID: "lb-1"}, {ID: "lb-2"}, {ID: "lb-4"}}
+	if _, err = getRecycleDetails(infos, unknown); err == nil {
+		t.Errorf("resource without recycle detail should be rejected")
+	}
+}
@@ -30,14 +30,20 @@ import (
 
 	mainaccount "hcm/cmd/data-service/service/account-set/main-account"
 	rootaccount "hcm/cmd/data-service/service/account-set/root-account"
+	apitoken "hcm/cmd/data-service/service/api-token"
 	"hcm/cmd/data-service/service/application"
 	"hcm/cmd/data-service/service/audit"
 	"hcm/cmd/data-service/service/auth"
 	"hcm/cmd/data-service/service/bill/billadjustmentitem"
+	"hcm/cmd/data-service/service/bill/billallocationrule"
+	"hcm/cmd/data-service/service/bill/billarchive"
+	"hcm/cmd/data-service/service/bill/billbudget"
 	"hcm/cmd/data-service/service/bill/billdailytask"
 	"hcm/cmd/data-service/service/bill/billexchangerate"
 	"hcm/cmd/data-service/service/bill/billitem"
 	"hcm/cmd/data-service/service/bill/billmonthtask"
+	"hcm/cmd/data-service/service/bill/billreconciliation"
+	"hcm/cmd/data-service/service/bill/billreportsub"
 	"hcm/cmd/data-service/service/bill/billsummarydaily"
 	"hcm/cmd/data-service/service/bill/billsummarymain"
 	"hcm/cmd/data-service/service/bill/billsummaryroot"
@@ -68,12 +74,15 @@ import (
 	securitygroup "hcm/cmd/data-service/service/cloud/security-group"
 	sgcomrel "hcm/cmd/data-service/service/cloud/security-group-common-rel"
 	sgcvmrel "hcm/cmd/data-service/service/cloud/security-group-cvm-rel"
+	sglint "hcm/cmd/data-service/service/cloud/security-group-lint"
 	subaccount "hcm/cmd/data-service/service/cloud/sub-account"
 	sync "hcm/cmd/data-service/service/cloud/sync"
 	"hcm/cmd/data-service/service/cloud/zone"
 	"hcm/cmd/data-service/service/cos"
+	"hcm/cmd/data-service/service/rbac"
 	recyclerecord "hcm/cmd/data-service/service/recycle-record"
 	"hcm/cmd/data-service/service/user"
+	"hcm/cmd/data-service/service/webhook"
 	"hcm/pkg/cc"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/cryptography"
@@ -107,6 +116,13 @@ func NewService() (*Service, error) {
 		return nil, err
 	}
 
+	// db请求限流配置支持动态调整
+	cc.OnChange("database.limiter", func() {
+		limiter := cc.DataService().Database.Limiter
+		dao.SetIngressLimit(limiter.QPS, limiter.Burst)
+		logs.Infof("db ingress limiter changed to qps: %d, burst: %d", limiter.QPS, limiter.Burst)
+	})
+
 	// 加解密器
 	cipher, err := newCipherFromConfig(cc.DataService().Crypto)
 	if err != nil {
@@ -247,6 +263,7 @@ func (s *Service) apiSet() *restful.Container {
 	cert.InitService(capability)
 	loadbalancer.InitService(capability)
 	sgcomrel.InitService(capability)
+	sglint.InitService(capability)
 	mainaccount.InitService(capability)
 	rootaccount.InitService(capability)
 
@@ -268,7 +285,15 @@ func (s *Service) apiSet() *restful.Container {
 	sgcomrel.InitService(capability)
 
 	billexchangerate.InitService(capability)
+	billbudget.InitService(capability)
+	billreportsub.InitService(capability)
+	billallocationrule.InitService(capability)
+	billreconciliation.InitService(capability)
+	billarchive.InitService(capability)
 	billsyncrecord.InitService(capability)
+	webhook.InitService(capability)
+	apitoken.InitService(capability)
+	rbac.InitService(capability)
 
 	return restful.NewContainer().Add(capability.WebService)
 }
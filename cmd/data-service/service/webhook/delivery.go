@@ -0,0 +1,163 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	protowebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablewebhook "hcm/pkg/dal/table/webhook"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateDelivery batch create webhook delivery.
+func (svc *service) BatchCreateDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(protowebhook.DeliveryBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablewebhook.DeliveryTable, 0, len(req.Deliveries))
+	for _, one := range req.Deliveries {
+		models = append(models, tablewebhook.DeliveryTable{
+			SubscriptionID: one.SubscriptionID,
+			EventType:      one.EventType,
+			EventID:        one.EventID,
+			Payload:        one.Payload,
+			State:          one.State,
+			Creator:        cts.Kit.User,
+			Reviser:        cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.WebhookDelivery().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create webhook delivery failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create webhook delivery failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create webhook delivery but return id type is not []string, id type: %T", ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
+
+// BatchUpdateDelivery batch update webhook delivery.
+func (svc *service) BatchUpdateDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(protowebhook.DeliveryBatchUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	for _, one := range req.Deliveries {
+		model := &tablewebhook.DeliveryTable{
+			State:      one.State,
+			Attempts:   one.Attempts,
+			StatusCode: one.StatusCode,
+			Response:   one.Response,
+			FlowID:     one.FlowID,
+			Reviser:    cts.Kit.User,
+		}
+		if err := svc.dao.WebhookDelivery().Update(cts.Kit, tools.EqualExpression("id", one.ID), model); err != nil {
+			logs.Errorf("update webhook delivery failed, err: %v, id: %s, rid: %s", err, one.ID, cts.Kit.Rid)
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ListDelivery list webhook delivery.
+func (svc *service) ListDelivery(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.WebhookDelivery().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list webhook delivery failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list webhook delivery failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protowebhook.DeliveryListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corewebhook.Delivery, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corewebhook.Delivery{
+			ID:             one.ID,
+			SubscriptionID: one.SubscriptionID,
+			EventType:      one.EventType,
+			EventID:        one.EventID,
+			Payload:        one.Payload,
+			State:          one.State,
+			Attempts:       one.Attempts,
+			StatusCode:     one.StatusCode,
+			Response:       converter.PtrToVal(one.Response),
+			FlowID:         one.FlowID,
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protowebhook.DeliveryListResult{Details: details}, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook ...
+package webhook
+
+import (
+	"net/http"
+
+	"hcm/cmd/data-service/service/capability"
+	"hcm/pkg/dal/dao"
+	"hcm/pkg/rest"
+)
+
+// InitService initialize the webhook service
+func InitService(cap *capability.Capability) {
+	svc := &service{
+		dao: cap.Dao,
+	}
+	h := rest.NewHandler()
+	h.Add("BatchCreateWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/batch/create",
+		svc.BatchCreateSubscription)
+	h.Add("UpdateWebhookSubscription", http.MethodPatch, "/webhooks/subscriptions", svc.UpdateSubscription)
+	h.Add("ListWebhookSubscription", http.MethodPost, "/webhooks/subscriptions/list", svc.ListSubscription)
+	h.Add("BatchDeleteWebhookSubscription", http.MethodDelete, "/webhooks/subscriptions/batch",
+		svc.BatchDeleteSubscription)
+
+	h.Add("BatchCreateWebhookDelivery", http.MethodPost, "/webhooks/deliveries/batch/create",
+		svc.BatchCreateDelivery)
+	h.Add("BatchUpdateWebhookDelivery", http.MethodPatch, "/webhooks/deliveries/batch", svc.BatchUpdateDelivery)
+	h.Add("ListWebhookDelivery", http.MethodPost, "/webhooks/deliveries/list", svc.ListDelivery)
+
+	h.Load(cap.WebService)
+}
+
+type service struct {
+	dao dao.Set
+}
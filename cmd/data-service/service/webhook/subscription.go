@@ -0,0 +1,228 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	dataservice "hcm/pkg/api/data-service"
+	protowebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/orm"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	tablewebhook "hcm/pkg/dal/table/webhook"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BatchCreateSubscription batch create webhook subscription.
+func (svc *service) BatchCreateSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(protowebhook.SubscriptionBatchCreateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	models := make([]tablewebhook.SubscriptionTable, 0, len(req.Subscriptions))
+	for _, one := range req.Subscriptions {
+		models = append(models, tablewebhook.SubscriptionTable{
+			Name:       one.Name,
+			URL:        one.URL,
+			Secret:     one.Secret,
+			EventTypes: eventTypesToStringArray(one.EventTypes),
+			Enabled:    one.Enabled,
+			Memo:       one.Memo,
+			Creator:    cts.Kit.User,
+			Reviser:    cts.Kit.User,
+		})
+	}
+
+	ids, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		ids, err := svc.dao.WebhookSubscription().BatchCreateWithTx(cts.Kit, txn, models)
+		if err != nil {
+			return nil, fmt.Errorf("batch create webhook subscription failed, err: %v", err)
+		}
+
+		return ids, nil
+	})
+	if err != nil {
+		logs.Errorf("batch create webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	idList, ok := ids.([]string)
+	if !ok {
+		return nil, fmt.Errorf("batch create webhook subscription but return id type is not []string, id type: %T",
+			ids)
+	}
+
+	return &core.BatchCreateResult{IDs: idList}, nil
+}
+
+// UpdateSubscription update webhook subscription.
+func (svc *service) UpdateSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(protowebhook.SubscriptionUpdateReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	model := &tablewebhook.SubscriptionTable{
+		Name:    req.Name,
+		URL:     req.URL,
+		Secret:  req.Secret,
+		Enabled: req.Enabled,
+		Memo:    req.Memo,
+		Reviser: cts.Kit.User,
+	}
+	if len(req.EventTypes) != 0 {
+		model.EventTypes = eventTypesToStringArray(req.EventTypes)
+	}
+	if err := svc.dao.WebhookSubscription().Update(cts.Kit, tools.EqualExpression("id", req.ID), model); err != nil {
+		logs.Errorf("update webhook subscription failed, err: %v, id: %s, rid: %s", err, req.ID, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ListSubscription list webhook subscription.
+func (svc *service) ListSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(core.ListReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: req.Fields,
+		Filter: req.Filter,
+		Page:   req.Page,
+	}
+	result, err := svc.dao.WebhookSubscription().List(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, fmt.Errorf("list webhook subscription failed, err: %v", err)
+	}
+
+	if req.Page.Count {
+		return &protowebhook.SubscriptionListResult{Count: result.Count}, nil
+	}
+
+	details := make([]corewebhook.Subscription, 0, len(result.Details))
+	for _, one := range result.Details {
+		details = append(details, corewebhook.Subscription{
+			ID:     one.ID,
+			Name:   one.Name,
+			URL:    one.URL,
+			Secret: one.Secret,
+			EventTypes: slice.Map(one.EventTypes, func(e string) enumor.WebhookEventType {
+				return enumor.WebhookEventType(e)
+			}),
+			Enabled: converter.PtrToVal(one.Enabled),
+			Memo:    converter.PtrToVal(one.Memo),
+			Revision: core.Revision{
+				Creator:   one.Creator,
+				Reviser:   one.Reviser,
+				CreatedAt: one.CreatedAt.String(),
+				UpdatedAt: one.UpdatedAt.String(),
+			},
+		})
+	}
+
+	return &protowebhook.SubscriptionListResult{Details: details}, nil
+}
+
+// BatchDeleteSubscription batch delete webhook subscription.
+func (svc *service) BatchDeleteSubscription(cts *rest.Contexts) (interface{}, error) {
+	req := new(dataservice.BatchDeleteReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	opt := &types.ListOption{
+		Fields: []string{"id"},
+		Filter: req.Filter,
+		Page:   core.NewDefaultBasePage(),
+	}
+	delIDs := make([]string, 0)
+	for {
+		listResp, err := svc.dao.WebhookSubscription().List(cts.Kit, opt)
+		if err != nil {
+			logs.Errorf("list webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+			return nil, fmt.Errorf("list webhook subscription failed, err: %v", err)
+		}
+
+		for _, one := range listResp.Details {
+			delIDs = append(delIDs, one.ID)
+		}
+
+		if uint(len(listResp.Details)) < opt.Page.Limit {
+			break
+		}
+		opt.Page.Start += uint32(opt.Page.Limit)
+	}
+
+	if len(delIDs) == 0 {
+		return nil, nil
+	}
+
+	_, err := svc.dao.Txn().AutoTxn(cts.Kit, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		for _, ids := range slice.Split(delIDs, int(core.DefaultMaxPageLimit)) {
+			if err := svc.dao.WebhookSubscription().DeleteWithTx(cts.Kit, txn,
+				tools.ContainersExpression("id", ids)); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
+	})
+	if err != nil {
+		logs.Errorf("delete webhook subscription failed, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func eventTypesToStringArray(eventTypes []enumor.WebhookEventType) []string {
+	return slice.Map(eventTypes, func(e enumor.WebhookEventType) string { return string(e) })
+}
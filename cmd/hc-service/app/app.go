@@ -21,6 +21,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -34,6 +35,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 // Run start the hc service.
@@ -59,6 +61,8 @@ func Run(opt *options.Option) error {
 type hcService struct {
 	svc *service.Service
 	sd  serviced.Service
+	// shutdownTracing 进程退出前导出剩余的链路数据
+	shutdownTracing func(ctx context.Context) error
 }
 
 // prepare do prepare jobs before run api discover.
@@ -76,6 +80,13 @@ func (ds *hcService) prepare(opt *options.Option) error {
 	network := cc.HCService().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	shutdownTracing, err := tracing.Init(string(cc.HCServiceName), cc.HCService().Tracing.Config())
+	if err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+	ds.shutdownTracing = shutdownTracing
+
 	// register hc service.
 	svcOpt := serviced.NewServiceOption(cc.HCServiceName, cc.HCService().Network)
 	disOpt := serviced.DiscoveryOption{
@@ -114,6 +125,8 @@ func (ds *hcService) register() error {
 }
 
 func (ds *hcService) finalizer() {
+	defer tracing.Shutdown(ds.shutdownTracing)
+
 	if err := ds.sd.Deregister(); err != nil {
 		logs.Errorf("process service shutdown, but deregister failed, err: %v", err)
 		return
@@ -227,13 +227,15 @@ func (cli *client) createCvm(kt *kit.Kit, accountID string, region string,
 		Cvms: lists,
 	}
 
-	_, err = cli.dbCli.Aws.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.Aws.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create aws cvm failed, err: %v, rid: %s", enumor.Aws,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.CvmAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.Aws,
 		accountID, len(addSlice), kt.Rid)
 
@@ -360,6 +362,8 @@ func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string,
 		lists = append(lists, cvm)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.CvmAuditResType, lists)
+
 	updateReq := dataproto.CvmBatchUpdateReq[corecvm.AwsCvmExtension]{
 		Cvms: lists,
 	}
@@ -505,6 +509,14 @@ func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate cvm not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listCvmFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.CvmAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataproto.CvmBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -159,6 +159,8 @@ func (cli *client) updateSG(kt *kit.Kit, accountID string, region string,
 		securityGroups = append(securityGroups, securityGroup)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SecurityGroupAuditResType, securityGroups)
+
 	updateReq := &protocloud.SecurityGroupBatchUpdateReq[cloudcore.AwsSecurityGroupExtension]{
 		SecurityGroups: securityGroups,
 	}
@@ -254,6 +256,14 @@ func (cli *client) deleteSG(kt *kit.Kit, accountID string, region string, delClo
 		return fmt.Errorf("validate sg not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSGFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SecurityGroupAuditResType, delFromDB)
+	}
+
 	deleteReq := &protocloud.SecurityGroupBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
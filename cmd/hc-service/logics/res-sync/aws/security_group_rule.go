@@ -238,6 +238,9 @@ func (cli *client) updateSGRule(kt *kit.Kit, opt *syncSGRuleOption,
 		return err
 	}
 
+	common.AuditCloudSyncChildUpdate(kt, cli.dbCli, enumor.SecurityGroupRuleAuditResType, opt.SGMap[opt.CloudSGID],
+		list)
+
 	updateReq := &protocloud.AwsSGRuleBatchUpdateReq{
 		Rules: list,
 	}
@@ -328,6 +331,15 @@ func (cli *client) deleteSGRule(kt *kit.Kit, opt *syncSGRuleOption, delCloudIDs
 		return fmt.Errorf("validate sgRule not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		rulesFromDB, err := cli.listSGRuleFromDB(kt, opt)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncChildDeleteByCloudIDs(kt, cli.dbCli, enumor.SecurityGroupRuleAuditResType,
+			opt.SGMap[opt.CloudSGID], rulesFromDB, delCloudIDs)
+	}
+
 	deleteReq := &protocloud.AwsSGRuleBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -117,6 +117,14 @@ func (cli *client) deleteSubnet(kt *kit.Kit, accountID string, region string, de
 		return fmt.Errorf("validate subnet not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSubnetFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SubnetAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -161,6 +169,8 @@ func (cli *client) updateSubnet(kt *kit.Kit, accountID string, updateMap map[str
 		subnets = append(subnets, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SubnetAuditResType, subnets)
+
 	updateReq := &cloud.SubnetBatchUpdateReq[cloud.AwsSubnetUpdateExt]{
 		Subnets: subnets,
 	}
@@ -238,11 +248,14 @@ func (cli *client) createSubnet(kt *kit.Kit, accountID, region string, addSubnet
 	createReq := &cloud.SubnetBatchCreateReq[cloud.AwsSubnetCreateExt]{
 		Subnets: subnets,
 	}
-	if _, err := cli.dbCli.Aws.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.Aws.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create subnet failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.SubnetAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync subnet to create subnet success, accountID: %s, count: %d, rid: %s", enumor.Aws,
 		accountID, len(addSubnets), kt.Rid)
 
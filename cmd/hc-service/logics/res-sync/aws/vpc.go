@@ -117,6 +117,14 @@ func (cli *client) deleteVpc(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate vpc not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listVpcFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.VpcCloudAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -169,6 +177,8 @@ func (cli *client) updateVpc(kt *kit.Kit, accountID string, updateMap map[string
 		vpcs = append(vpcs, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.VpcCloudAuditResType, vpcs)
+
 	updateReq := &cloud.VpcBatchUpdateReq[cloud.AwsVpcUpdateExt]{
 		Vpcs: vpcs,
 	}
@@ -228,11 +238,14 @@ func (cli *client) createVpc(kt *kit.Kit, accountID string, addVpcs []types.AwsV
 	createReq := &cloud.VpcBatchCreateReq[cloud.AwsVpcCreateExt]{
 		Vpcs: vpcs,
 	}
-	if _, err := cli.dbCli.Aws.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.Aws.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create vpc failed, err: %v, rid: %s", enumor.Aws, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.VpcCloudAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync vpc to create vpc success, accountID: %s, count: %d, rid: %s", enumor.Aws,
 		accountID, len(addVpcs), kt.Rid)
 
@@ -277,13 +277,15 @@ func (cli *client) createCvm(kt *kit.Kit, accountID string, resGroupName string,
 	createReq := dataproto.CvmBatchCreateReq[corecvm.AzureCvmExtension]{
 		Cvms: lists,
 	}
-	_, err = cli.dbCli.Azure.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.Azure.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create azure cvm failed, err: %v, rid: %s", enumor.Azure,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.CvmAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.Azure,
 		accountID, len(addSlice), kt.Rid)
 
@@ -396,6 +398,8 @@ func (cli *client) updateCvm(kt *kit.Kit, accountID string, resGroupName string,
 		lists = append(lists, cvm)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.CvmAuditResType, lists)
+
 	updateReq := dataproto.CvmBatchUpdateReq[corecvm.AzureCvmExtension]{
 		Cvms: lists,
 	}
@@ -590,6 +594,14 @@ func (cli *client) deleteCvm(kt *kit.Kit, accountID string, resGroupName string,
 		return fmt.Errorf("validate cvm not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listCvmFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.CvmAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataproto.CvmBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -186,6 +186,14 @@ func (cli *client) deleteEip(kt *kit.Kit, accountID string, resGroupName string,
 		return fmt.Errorf("validate eip not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listEipFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.EipAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataeip.EipDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -267,11 +275,14 @@ func (cli *client) createEip(kt *kit.Kit, accountID string, bizID int64, addEip
 		request = append(request, tmpRes)
 	}
 
-	if _, err := cli.dbCli.Azure.BatchCreateEip(kt.Ctx, kt.Header(), &request); err != nil {
+	createResult, err := cli.dbCli.Azure.BatchCreateEip(kt.Ctx, kt.Header(), &request)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create eip failed, err: %v, rid: %s", enumor.Azure, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.EipAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync eip to create eip success, accountID: %s, count: %d, rid: %s", enumor.Azure,
 		accountID, len(addEip), kt.Rid)
 
@@ -270,6 +270,9 @@ func (cli *client) updateSGRule(kt *kit.Kit, opt *syncSGRuleOption,
 		return err
 	}
 
+	common.AuditCloudSyncChildUpdate(kt, cli.dbCli, enumor.SecurityGroupRuleAuditResType,
+		opt.SGMap[opt.CloudSGID].ID, list)
+
 	updateReq := &protocloud.AzureSGRuleBatchUpdateReq{
 		Rules: list,
 	}
@@ -382,6 +385,15 @@ func (cli *client) deleteSGRule(kt *kit.Kit, opt *syncSGRuleOption, delCloudIDs
 		return fmt.Errorf("validate sgRule not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		rulesFromDB, err := cli.listSGRuleFromDB(kt, opt)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncChildDeleteByCloudIDs(kt, cli.dbCli, enumor.SecurityGroupRuleAuditResType,
+			opt.SGMap[opt.CloudSGID].ID, rulesFromDB, delCloudIDs)
+	}
+
 	deleteReq := &protocloud.AzureSGRuleBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
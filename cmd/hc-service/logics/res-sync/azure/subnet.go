@@ -191,6 +191,14 @@ func (cli *client) deleteSubnet(kt *kit.Kit, accountID, resGroupName, cloudVpcID
 		return fmt.Errorf("validate subnet not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSubnetFromDB(kt, checkParams, cloudVpcID)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SubnetAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -233,6 +241,8 @@ func (cli *client) updateSubnet(kt *kit.Kit, accountID string, updateMap map[str
 		subnets = append(subnets, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SubnetAuditResType, subnets)
+
 	updateReq := &cloud.SubnetBatchUpdateReq[cloud.AzureSubnetUpdateExt]{
 		Subnets: subnets,
 	}
@@ -298,12 +308,15 @@ func (cli *client) createSubnet(kt *kit.Kit, accountID, resGroupName, cloudVpcID
 	createReq := &cloud.SubnetBatchCreateReq[cloud.AzureSubnetCreateExt]{
 		Subnets: subnets,
 	}
-	if _, err := cli.dbCli.Azure.Subnet.BatchCreate(kt, createReq); err != nil {
+	createResult, err := cli.dbCli.Azure.Subnet.BatchCreate(kt, createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create subnet failed, err: %v, rid: %s", enumor.Azure, err,
 			kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.SubnetAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync subnet to create subnet success, accountID: %s, count: %d, rid: %s", enumor.Azure,
 		accountID, len(addSubnet), kt.Rid)
 
@@ -187,6 +187,14 @@ func (cli *client) deleteVpc(kt *kit.Kit, accountID string, resGroupName string,
 		return fmt.Errorf("validate vpc not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listVpcFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.VpcCloudAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -233,6 +241,8 @@ func (cli *client) updateVpc(kt *kit.Kit, accountID string, updateMap map[string
 		vpcs = append(vpcs, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.VpcCloudAuditResType, vpcs)
+
 	updateReq := &cloud.VpcBatchUpdateReq[cloud.AzureVpcUpdateExt]{
 		Vpcs: vpcs,
 	}
@@ -288,11 +298,14 @@ func (cli *client) createVpc(kt *kit.Kit, accountID string, addVpc []types.Azure
 	createReq := &cloud.VpcBatchCreateReq[cloud.AzureVpcCreateExt]{
 		Vpcs: vpcs,
 	}
-	if _, err := cli.dbCli.Azure.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.Azure.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create vpc failed, err: %v, rid: %s", enumor.Azure, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.VpcCloudAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync vpc to create vpc success, accountID: %s, count: %d, rid: %s", enumor.Azure,
 		accountID, len(addVpc), kt.Rid)
 
@@ -0,0 +1,177 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"reflect"
+
+	protoaudit "hcm/pkg/api/data-service/audit"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+)
+
+// IsCloudSync 判断当前请求是否由云上资源同步发起，只有该场景下才需要记录云上变更审计。
+func IsCloudSync(kt *kit.Kit) bool {
+	return kt.GetRequestSource() == enumor.CloudSync
+}
+
+// AuditCloudSyncCreate 同步发现资源在云上被新增（非HCM操作）时，记录新增审计。
+// 审计需要读取DB中新增的资源信息，所以必须在DB数据创建后调用，审计失败只打印日志，不影响同步。
+// 安全组在DB创建时已按请求来源记录新增审计，无需调用。
+func AuditCloudSyncCreate(kt *kit.Kit, dbCli *dataservice.Client, resType enumor.AuditResourceType,
+	createdIDs []string) {
+
+	if !IsCloudSync(kt) || len(createdIDs) == 0 {
+		return
+	}
+
+	creates := make([]protoaudit.CloudResourceCreateInfo, 0, len(createdIDs))
+	for _, id := range createdIDs {
+		creates = append(creates, protoaudit.CloudResourceCreateInfo{ResType: resType, ResID: id})
+	}
+
+	for _, batch := range slice.Split(creates, constant.BatchOperationMaxLimit) {
+		req := &protoaudit.CloudResourceCreateAuditReq{Creates: batch}
+		if err := dbCli.Global.Audit.CloudResourceCreateAudit(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("create cloud sync create audit failed, res_type: %s, count: %d, err: %v, rid: %s",
+				resType, len(batch), err, kt.Rid)
+		}
+	}
+}
+
+// AuditCloudSyncDelete 同步发现资源在云上已被删除（非HCM操作）时，记录删除审计。
+// 审计需要读取DB中的资源信息，所以必须在删除DB数据前调用，审计失败只打印日志，不影响同步。
+func AuditCloudSyncDelete[T interface{ GetID() string }](kt *kit.Kit, dbCli *dataservice.Client,
+	resType enumor.AuditResourceType, delFromDB []T) {
+
+	AuditCloudSyncChildDelete(kt, dbCli, resType, "", delFromDB)
+}
+
+// AuditCloudSyncChildDelete 同步发现子资源（如安全组规则）在云上已被删除时，记录删除审计，parentID 为所属父资源ID。
+func AuditCloudSyncChildDelete[T interface{ GetID() string }](kt *kit.Kit, dbCli *dataservice.Client,
+	resType enumor.AuditResourceType, parentID string, delFromDB []T) {
+
+	if !IsCloudSync(kt) || len(delFromDB) == 0 {
+		return
+	}
+
+	deletes := make([]protoaudit.CloudResourceDeleteInfo, 0, len(delFromDB))
+	for _, one := range delFromDB {
+		deletes = append(deletes, protoaudit.CloudResourceDeleteInfo{ResType: resType, ResID: one.GetID()})
+	}
+
+	for _, batch := range slice.Split(deletes, constant.BatchOperationMaxLimit) {
+		req := &protoaudit.CloudResourceDeleteAuditReq{ParentID: parentID, Deletes: batch}
+		if err := dbCli.Global.Audit.CloudResourceDeleteAudit(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("create cloud sync delete audit failed, res_type: %s, count: %d, err: %v, rid: %s",
+				resType, len(batch), err, kt.Rid)
+		}
+	}
+}
+
+// AuditCloudSyncChildDeleteByCloudIDs 与 AuditCloudSyncChildDelete 相同，dataFromDB 为父资源下DB中的子资源，
+// 按 delCloudIDs 过滤出被删除的子资源后记录删除审计。
+func AuditCloudSyncChildDeleteByCloudIDs[T interface {
+	GetID() string
+	GetCloudID() string
+}](kt *kit.Kit, dbCli *dataservice.Client, resType enumor.AuditResourceType, parentID string, dataFromDB []T,
+	delCloudIDs []string) {
+
+	AuditCloudSyncChildDelete(kt, dbCli, resType, parentID, filterByCloudIDs(dataFromDB, delCloudIDs))
+}
+
+func filterByCloudIDs[T interface{ GetCloudID() string }](dataFromDB []T, cloudIDs []string) []T {
+	cloudIDMap := converter.StringSliceToMap(cloudIDs)
+	return slice.Filter(dataFromDB, func(one T) bool {
+		_, exist := cloudIDMap[one.GetCloudID()]
+		return exist
+	})
+}
+
+// AuditCloudSyncUpdate 同步发现资源在云上被修改（非HCM操作）时，记录变更审计。
+// updates 为 data-service 批量更新请求中的更新项，需包含 id 字段，零值字段不会更新DB，所以也不计入变更字段。
+// 审计需要读取DB中变更前的数据，所以必须在更新DB数据前调用，审计失败只打印日志，不影响同步。
+func AuditCloudSyncUpdate[T any](kt *kit.Kit, dbCli *dataservice.Client, resType enumor.AuditResourceType,
+	updates []T) {
+
+	AuditCloudSyncChildUpdate(kt, dbCli, resType, "", updates)
+}
+
+// AuditCloudSyncChildUpdate 同步发现子资源（如安全组规则）在云上被修改时，记录变更审计，parentID 为所属父资源ID。
+func AuditCloudSyncChildUpdate[T any](kt *kit.Kit, dbCli *dataservice.Client, resType enumor.AuditResourceType,
+	parentID string, updates []T) {
+
+	if !IsCloudSync(kt) || len(updates) == 0 {
+		return
+	}
+
+	infos := make([]protoaudit.CloudResourceUpdateInfo, 0, len(updates))
+	for _, one := range updates {
+		fields, err := converter.StructToMap(one)
+		if err != nil {
+			logs.Errorf("convert cloud sync update fields failed, res_type: %s, err: %v, rid: %s", resType, err,
+				kt.Rid)
+			continue
+		}
+
+		id, _ := fields["id"].(string)
+		delete(fields, "id")
+		fields = pruneZeroFields(fields)
+		if len(id) == 0 || len(fields) == 0 {
+			continue
+		}
+
+		infos = append(infos, protoaudit.CloudResourceUpdateInfo{ResType: resType, ResID: id, UpdateFields: fields})
+	}
+
+	for _, batch := range slice.Split(infos, constant.BatchOperationMaxLimit) {
+		req := &protoaudit.CloudResourceUpdateAuditReq{ParentID: parentID, Updates: batch}
+		if err := dbCli.Global.Audit.CloudResourceUpdateAudit(kt.Ctx, kt.Header(), req); err != nil {
+			logs.Errorf("create cloud sync update audit failed, res_type: %s, count: %d, err: %v, rid: %s",
+				resType, len(batch), err, kt.Rid)
+		}
+	}
+}
+
+// pruneZeroFields 递归去除零值字段，与DB更新时忽略零值字段的行为保持一致。
+func pruneZeroFields(fields map[string]interface{}) map[string]interface{} {
+	for key, value := range fields {
+		if sub, ok := value.(map[string]interface{}); ok {
+			value = pruneZeroFields(sub)
+			fields[key] = value
+		}
+
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			delete(fields, key)
+			continue
+		}
+
+		if sub, ok := value.(map[string]interface{}); ok && len(sub) == 0 {
+			delete(fields, key)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,43 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package common
+
+import (
+	"testing"
+
+	corecloud "hcm/pkg/api/core/cloud"
+)
+
+func TestFilterByCloudIDs(t *testing.T) {
+	rules := []corecloud.AwsSecurityGroupRule{
+		{ID: "1", CloudID: "sgr-1"},
+		{ID: "2", CloudID: "sgr-2"},
+		{ID: "3", CloudID: "sgr-3"},
+	}
+
+	got := filterByCloudIDs(rules, []string{"sgr-3", "sgr-1", "sgr-4"})
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "3" {
+		t.Errorf("unexpected filter result: %+v", got)
+	}
+
+	if got = filterByCloudIDs(rules, nil); len(got) != 0 {
+		t.Errorf("expect empty result, got: %+v", got)
+	}
+}
@@ -213,6 +213,7 @@ func (cli *client) createCvm(kt *kit.Kit, accountID string, region string, zone
 				SelfLink:                 one.SelfLink,
 				CpuPlatform:              one.CpuPlatform,
 				Labels:                   one.Labels,
+				NetworkTags:              getNetworkTags(one),
 				MinCpuPlatform:           one.MinCpuPlatform,
 				StartRestricted:          one.StartRestricted,
 				ResourcePolicies:         one.ResourcePolicies,
@@ -246,13 +247,15 @@ func (cli *client) createCvm(kt *kit.Kit, accountID string, region string, zone
 		Cvms: lists,
 	}
 
-	_, err = cli.dbCli.Gcp.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.Gcp.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create gcp cvm failed, err: %v, rid: %s", enumor.Gcp,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.CvmAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
 		accountID, len(addSlice), kt.Rid)
 
@@ -363,6 +366,7 @@ func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string, zone
 				SelfLink:                 one.SelfLink,
 				CpuPlatform:              one.CpuPlatform,
 				Labels:                   one.Labels,
+				NetworkTags:              getNetworkTags(one),
 				MinCpuPlatform:           one.MinCpuPlatform,
 				StartRestricted:          one.StartRestricted,
 				ResourcePolicies:         one.ResourcePolicies,
@@ -392,6 +396,8 @@ func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string, zone
 		lists = append(lists, cvm)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.CvmAuditResType, lists)
+
 	updateReq := dataproto.CvmBatchUpdateReq[corecvm.GcpCvmExtension]{
 		Cvms: lists,
 	}
@@ -578,6 +584,14 @@ func (cli *client) deleteCvm(kt *kit.Kit, accountID string, zone string, delClou
 		return fmt.Errorf("validate cvm not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listCvmFromDB(kt, checkParams, zone)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.CvmAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataproto.CvmBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -758,6 +772,10 @@ func isCvmChange(cloud typescvm.GcpCvm, db corecvm.Cvm[cvm.GcpCvmExtension]) boo
 		return true
 	}
 
+	if !assert.IsStringSliceEqual(db.Extension.NetworkTags, getNetworkTags(cloud)) {
+		return true
+	}
+
 	if db.Status != cloud.Status {
 		return true
 	}
@@ -902,3 +920,12 @@ func isCvmChange(cloud typescvm.GcpCvm, db corecvm.Cvm[cvm.GcpCvmExtension]) boo
 
 	return false
 }
+
+// getNetworkTags get network tags of gcp cvm.
+func getNetworkTags(cvm typescvm.GcpCvm) []string {
+	if cvm.Tags == nil {
+		return make([]string, 0)
+	}
+
+	return cvm.Tags.Items
+}
@@ -278,13 +278,15 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, zone string, addSli
 		createReq = append(createReq, disk)
 	}
 
-	_, err := cli.dbCli.Gcp.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.Gcp.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create gcp disk failed, err: %v, rid: %s", enumor.Gcp,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.DiskAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
 		accountID, len(addSlice), kt.Rid)
 
@@ -311,6 +313,14 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, zone string, delClo
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listDiskFromDB(kt, checkParams, &SyncDiskOption{Zone: zone})
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.DiskAuditResType, delFromDB)
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -168,11 +168,13 @@ func (cli *client) createFirewall(kt *kit.Kit, accountID string,
 	batchCreateReq := &cloud.GcpFirewallRuleBatchCreateReq{
 		FirewallRules: rulesCreate,
 	}
-	_, err = cli.dbCli.Gcp.Firewall.BatchCreateFirewallRule(kt.Ctx, kt.Header(), batchCreateReq)
+	createResult, err := cli.dbCli.Gcp.Firewall.BatchCreateFirewallRule(kt.Ctx, kt.Header(), batchCreateReq)
 	if err != nil {
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.GcpFirewallRuleAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync firewall to create firewall success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
 		accountID, len(addSlice), kt.Rid)
 
@@ -253,6 +255,8 @@ func (cli *client) updateFirewall(kt *kit.Kit, accountID string,
 		rulesUpdate = append(rulesUpdate, rule)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.GcpFirewallRuleAuditResType, rulesUpdate)
+
 	batchCreateReq := &cloud.GcpFirewallRuleBatchUpdateReq{
 		FirewallRules: rulesUpdate,
 	}
@@ -287,6 +291,14 @@ func (cli *client) deleteFirewall(kt *kit.Kit, accountID string, delCloudIDs []s
 		return fmt.Errorf("validate firewall not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listFirewallFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.GcpFirewallRuleAuditResType, delFromDB)
+	}
+
 	deleteReq := &cloud.GcpFirewallRuleBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -184,6 +184,14 @@ func (cli *client) deleteSubnet(kt *kit.Kit, accountID, region string, delCloudI
 		return fmt.Errorf("validate subnet not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSubnetFromDB(kt, checkParams, region)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SubnetAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -226,6 +234,8 @@ func (cli *client) updateSubnet(kt *kit.Kit, accountID string, updateMap map[str
 		subnets = append(subnets, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SubnetAuditResType, subnets)
+
 	updateReq := &cloud.SubnetBatchUpdateReq[cloud.GcpSubnetUpdateExt]{
 		Subnets: subnets,
 	}
@@ -303,11 +313,14 @@ func (cli *client) createSubnet(kt *kit.Kit, accountID string, addSubnet []adtys
 	createReq := &cloud.SubnetBatchCreateReq[cloud.GcpSubnetCreateExt]{
 		Subnets: subnets,
 	}
-	if _, err := cli.dbCli.Gcp.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.Gcp.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create subnet failed, err: %v, rid: %s", enumor.Gcp, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.SubnetAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync subnet to create subnet success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
 		accountID, len(addSubnet), kt.Rid)
 
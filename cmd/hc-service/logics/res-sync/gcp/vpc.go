@@ -182,6 +182,14 @@ func (cli *client) deleteVpc(kt *kit.Kit, accountID string, delCloudIDs []string
 		return fmt.Errorf("validate vpc not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listVpcFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.VpcCloudAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -223,6 +231,8 @@ func (cli *client) updateVpc(kt *kit.Kit, accountID string, updateMap map[string
 		vpcs = append(vpcs, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.VpcCloudAuditResType, vpcs)
+
 	updateReq := &cloud.VpcBatchUpdateReq[cloud.GcpVpcUpdateExt]{
 		Vpcs: vpcs,
 	}
@@ -270,11 +280,14 @@ func (cli *client) createVpc(kt *kit.Kit, accountID string, addVpc []types.GcpVp
 	createReq := &cloud.VpcBatchCreateReq[cloud.GcpVpcCreateExt]{
 		Vpcs: vpcs,
 	}
-	if _, err := cli.dbCli.Gcp.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.Gcp.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create vpc failed, err: %v, rid: %s", enumor.Gcp, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.VpcCloudAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync vpc to create vpc success, accountID: %s, count: %d, rid: %s", enumor.Gcp,
 		accountID, len(addVpc), kt.Rid)
 
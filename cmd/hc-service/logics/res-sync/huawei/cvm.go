@@ -243,6 +243,8 @@ func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string,
 		lists = append(lists, cvm)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.CvmAuditResType, lists)
+
 	updateReq := dataproto.CvmBatchUpdateReq[corecvm.HuaWeiCvmExtension]{
 		Cvms: lists,
 	}
@@ -409,13 +411,15 @@ func (cli *client) createCvm(kt *kit.Kit, accountID string, region string,
 		Cvms: lists,
 	}
 
-	_, err = cli.dbCli.HuaWei.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.HuaWei.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create huawei cvm failed, err: %v, rid: %s", enumor.HuaWei,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.CvmAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
 		accountID, len(addSlice), kt.Rid)
 
@@ -569,6 +573,14 @@ func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate cvm not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listCvmFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.CvmAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataproto.CvmBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
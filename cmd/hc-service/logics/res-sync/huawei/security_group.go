@@ -141,6 +141,8 @@ func (cli *client) updateSG(kt *kit.Kit, accountID string,
 		securityGroups = append(securityGroups, securityGroup)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SecurityGroupAuditResType, securityGroups)
+
 	updateReq := &protocloud.SecurityGroupBatchUpdateReq[cloudcore.HuaWeiSecurityGroupExtension]{
 		SecurityGroups: securityGroups,
 	}
@@ -218,6 +220,14 @@ func (cli *client) deleteSG(kt *kit.Kit, accountID string, region string, delClo
 		return fmt.Errorf("validate sg not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSGFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SecurityGroupAuditResType, delFromDB)
+	}
+
 	deleteReq := &protocloud.SecurityGroupBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
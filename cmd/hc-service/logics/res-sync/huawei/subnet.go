@@ -186,6 +186,14 @@ func (cli *client) deleteSubnet(kt *kit.Kit, accountID, region, cloudVpcID strin
 		return fmt.Errorf("validate subnet not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSubnetFromDB(kt, checkParams, cloudVpcID)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SubnetAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -229,6 +237,8 @@ func (cli *client) updateSubnet(kt *kit.Kit, accountID string, updateMap map[str
 		subnets = append(subnets, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SubnetAuditResType, subnets)
+
 	updateReq := &cloud.SubnetBatchUpdateReq[cloud.HuaWeiSubnetUpdateExt]{
 		Subnets: subnets,
 	}
@@ -293,12 +303,15 @@ func (cli *client) createSubnet(kt *kit.Kit, accountID, region, cloudVpcID strin
 	createReq := &cloud.SubnetBatchCreateReq[cloud.HuaWeiSubnetCreateExt]{
 		Subnets: subnets,
 	}
-	if _, err := cli.dbCli.HuaWei.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.HuaWei.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create subnet failed, err: %v, rid: %s", enumor.HuaWei, err,
 			kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.SubnetAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync subnet to create subnet success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
 		accountID, len(addSubnet), kt.Rid)
 
@@ -185,6 +185,14 @@ func (cli *client) deleteVpc(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate vpc not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listVpcFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.VpcCloudAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -232,6 +240,8 @@ func (cli *client) updateVpc(kt *kit.Kit, accountID string, updateMap map[string
 		vpcs = append(vpcs, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.VpcCloudAuditResType, vpcs)
+
 	updateReq := &cloud.VpcBatchUpdateReq[cloud.HuaWeiVpcUpdateExt]{
 		Vpcs: vpcs,
 	}
@@ -286,11 +296,14 @@ func (cli *client) createVpc(kt *kit.Kit, accountID string, addVpc []types.HuaWe
 	createReq := &cloud.VpcBatchCreateReq[cloud.HuaWeiVpcCreateExt]{
 		Vpcs: vpcs,
 	}
-	if _, err := cli.dbCli.HuaWei.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.HuaWei.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create vpc failed, err: %v, rid: %s", enumor.HuaWei, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.VpcCloudAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync vpc to create vpc success, accountID: %s, count: %d, rid: %s", enumor.HuaWei,
 		accountID, len(addVpc), kt.Rid)
 
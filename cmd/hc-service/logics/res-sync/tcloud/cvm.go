@@ -205,6 +205,8 @@ func (cli *client) updateCvm(kt *kit.Kit, accountID string, region string,
 		lists = append(lists, updateOne)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.CvmAuditResType, lists)
+
 	updateReq := dataproto.CvmBatchUpdateReq[corecvm.TCloudCvmExtension]{
 		Cvms: lists,
 	}
@@ -339,13 +341,15 @@ func (cli *client) createCvm(kt *kit.Kit, accountID string, region string,
 		Cvms: lists,
 	}
 
-	_, err = cli.dbCli.TCloud.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.TCloud.Cvm.BatchCreateCvm(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create tcloud cvm failed, err: %v, rid: %s", enumor.TCloud,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.CvmAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync cvm to create cvm success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
 		accountID, len(addSlice), kt.Rid)
 
@@ -451,6 +455,14 @@ func (cli *client) deleteCvm(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate cvm not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listCvmFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.CvmAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataproto.CvmBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -114,6 +114,14 @@ func (cli *client) deleteDisk(kt *kit.Kit, accountID string, region string, delC
 		return fmt.Errorf("validate disk not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listDiskFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.DiskAuditResType, delFromDB)
+	}
+
 	deleteReq := &disk.DiskDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -222,13 +230,15 @@ func (cli *client) createDisk(kt *kit.Kit, accountID string, region string,
 		createReq = append(createReq, disk)
 	}
 
-	_, err := cli.dbCli.TCloud.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
+	createResult, err := cli.dbCli.TCloud.BatchCreateDisk(kt.Ctx, kt.Header(), &createReq)
 	if err != nil {
 		logs.Errorf("[%s] request dataservice to create tcloud disk failed, err: %v, rid: %s", enumor.TCloud,
 			err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.DiskAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync disk to create disk success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
 		accountID, len(addSlice), kt.Rid)
 
@@ -185,6 +185,14 @@ func (cli *client) deleteEip(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate eip not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listEipFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.EipAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataeip.EipDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -257,11 +265,14 @@ func (cli *client) createEip(kt *kit.Kit, accountID string, addEip []*typeseip.T
 		createReq = append(createReq, tmpRes)
 	}
 
-	if _, err := cli.dbCli.TCloud.BatchCreateEip(kt.Ctx, kt.Header(), &createReq); err != nil {
+	createResult, err := cli.dbCli.TCloud.BatchCreateEip(kt.Ctx, kt.Header(), &createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create eip failed, err: %v, rid: %s", enumor.TCloud, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.EipAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync eip to create eip success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
 		accountID, len(addEip), kt.Rid)
 
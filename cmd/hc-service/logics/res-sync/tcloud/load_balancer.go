@@ -285,6 +285,8 @@ func (cli *client) updateLoadBalancer(kt *kit.Kit, accountID string, region stri
 	for id, clb := range updateMap {
 		updateReq.Lbs = append(updateReq.Lbs, convCloudToDBUpdate(id, clb, vpcMap, subnetMap, region))
 	}
+
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.LoadBalancerAuditResType, updateReq.Lbs)
 	if err := cli.dbCli.TCloud.LoadBalancer.BatchUpdate(kt, &updateReq); err != nil {
 		logs.Errorf("[%s] call data service to update tcloud load balancer failed, err: %v, rid: %s",
 			enumor.TCloud, err, kt.Rid)
@@ -316,6 +318,14 @@ func (cli *client) deleteLoadBalancer(kt *kit.Kit, accountID string, region stri
 		return fmt.Errorf("lb not exist before sync deletion")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listLBFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.LoadBalancerAuditResType, delFromDB)
+	}
+
 	deleteReq := &protocloud.LoadBalancerBatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -178,7 +178,7 @@ func (cli *client) deleteRemovedListener(kt *kit.Kit, lbID string, cloudLblList
 	}
 
 	for _, cloudIds := range slice.Split(removedLblCloudIds, constant.BatchOperationMaxLimit) {
-		if err := cli.deleteListener(kt, cloudIds); err != nil {
+		if err := cli.deleteListener(kt, lbID, cloudIds); err != nil {
 			logs.Errorf("fail to delete removed listener for sync, err: %v, listener_cloud_ids: %v, lbID: %s, rid: %s",
 				err, cloudIds, lbID, kt.Rid)
 			return err
@@ -209,7 +209,7 @@ func (cli *client) listener(kt *kit.Kit, params *SyncBaseParams, opt *SyncListen
 		cloudListeners, dbListeners, isListenerChange)
 
 	// 删除云上已经删除的监听器实例
-	if err = cli.deleteListener(kt, delCloudIDs); err != nil {
+	if err = cli.deleteListener(kt, opt.LBID, delCloudIDs); err != nil {
 		return err
 	}
 
@@ -285,10 +285,19 @@ func (cli *client) listListenerFromDB(kt *kit.Kit, lbID string, cloudIds []strin
 	return lblResp.Details, nil
 }
 
-func (cli *client) deleteListener(kt *kit.Kit, cloudIds []string) error {
+func (cli *client) deleteListener(kt *kit.Kit, lbID string, cloudIds []string) error {
 	if len(cloudIds) == 0 {
 		return nil
 	}
+
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listListenerFromDB(kt, lbID, cloudIds, core.NewDefaultBasePage())
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.ListenerAuditResType, delFromDB)
+	}
+
 	delReq := &dataproto.LoadBalancerBatchDeleteReq{Filter: tools.ContainersExpression("cloud_id", cloudIds)}
 	err := cli.dbCli.Global.LoadBalancer.DeleteListener(kt, delReq)
 	if err != nil {
@@ -417,6 +426,8 @@ func (cli *client) updateListener(kt *kit.Kit, bizID int64, updateMap map[string
 		})
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.ListenerAuditResType, updates)
+
 	err := cli.dbCli.TCloud.LoadBalancer.BatchUpdateTCloudListener(kt,
 		&dataproto.TCloudListenerUpdateReq{Listeners: updates})
 	if err != nil {
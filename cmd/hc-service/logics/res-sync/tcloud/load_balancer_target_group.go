@@ -84,6 +84,8 @@ func (cli *client) LocalTargetGroup(kt *kit.Kit, param *SyncBaseParams, opt *Syn
 			IDs:         []string{tg.ID},
 			HealthCheck: convHealthCheck(tgCloudHealthMap[tg.CloudID]),
 		}
+		common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.TargetGroupAuditResType,
+			[]map[string]interface{}{{"id": tg.ID, "health_check": updateReq.HealthCheck}})
 		err = cli.dbCli.TCloud.LoadBalancer.BatchUpdateTCloudTargetGroup(kt, updateReq)
 		if err != nil {
 			logs.Errorf("fail to update target group health check during sync, err: %v, rid: %s", err, kt.Rid)
@@ -20,6 +20,7 @@
 package tcloud
 
 import (
+	"hcm/cmd/hc-service/logics/res-sync/common"
 	securitygrouprule "hcm/pkg/adaptor/types/security-group-rule"
 	"hcm/pkg/api/core"
 	corecloud "hcm/pkg/api/core/cloud"
@@ -116,7 +117,7 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 	}
 
 	updateRules := make(map[string]*corecloud.TCloudSecurityGroupRule)
-	deleteRuleIDs := make([]string, 0)
+	deleteRules := make([]corecloud.TCloudSecurityGroupRule, 0)
 	for _, one := range rulesFromDB {
 		var ruleMap map[int64]*vpc.SecurityGroupPolicy
 		switch one.Type {
@@ -131,7 +132,7 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		}
 		policy, exist := ruleMap[one.CloudPolicyIndex]
 		if !exist {
-			deleteRuleIDs = append(deleteRuleIDs, one.ID)
+			deleteRules = append(deleteRules, one)
 			continue
 		}
 		delete(ruleMap, one.CloudPolicyIndex)
@@ -150,7 +151,9 @@ func (cli *client) securityGroupRule(kt *kit.Kit, opt *syncSGRuleOption) (*SyncR
 		createRules = append(createRules, *rule)
 	}
 
-	if len(deleteRuleIDs) != 0 {
+	if len(deleteRules) != 0 {
+		common.AuditCloudSyncChildDelete(kt, cli.dbCli, enumor.SecurityGroupRuleAuditResType, sg.ID, deleteRules)
+		deleteRuleIDs := slice.Map(deleteRules, corecloud.TCloudSecurityGroupRule.GetID)
 		if err = cli.deleteSGRule(kt, sg.ID, deleteRuleIDs); err != nil {
 			return nil, err
 		}
@@ -307,6 +310,8 @@ func (cli *client) updateSGRule(kt *kit.Kit, sgID string, updateRules map[string
 			AccountID:                  rule.AccountID,
 		})
 	}
+	common.AuditCloudSyncChildUpdate(kt, cli.dbCli, enumor.SecurityGroupRuleAuditResType, sgID, ruleSlice)
+
 	// split rules into batches to avoid reaching batch operation limit
 	ruleBatches := slice.Split(ruleSlice, constant.BatchOperationMaxLimit)
 	for batchIdx, updateRuleBatch := range ruleBatches {
@@ -115,6 +115,14 @@ func (cli *client) deleteSubnet(kt *kit.Kit, accountID string, region string, de
 		return fmt.Errorf("validate subnet not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listSubnetFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.SubnetAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -157,6 +165,8 @@ func (cli *client) updateSubnet(kt *kit.Kit, accountID string, updateMap map[str
 		subnets = append(subnets, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.SubnetAuditResType, subnets)
+
 	updateReq := &cloud.SubnetBatchUpdateReq[cloud.TCloudSubnetUpdateExt]{
 		Subnets: subnets,
 	}
@@ -231,12 +241,15 @@ func (cli *client) createSubnet(kt *kit.Kit, accountID string, region string,
 	createReq := &cloud.SubnetBatchCreateReq[cloud.TCloudSubnetCreateExt]{
 		Subnets: subnets,
 	}
-	if _, err := cli.dbCli.TCloud.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.TCloud.Subnet.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create subnet failed, err: %v, rid: %s", enumor.TCloud, err,
 			kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.SubnetAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync subnet to create subnet success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
 		accountID, len(addSubnet), kt.Rid)
 
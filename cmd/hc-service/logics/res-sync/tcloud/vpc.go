@@ -185,6 +185,14 @@ func (cli *client) deleteVpc(kt *kit.Kit, accountID string, region string, delCl
 		return fmt.Errorf("validate vpc not exist failed, before delete")
 	}
 
+	if common.IsCloudSync(kt) {
+		delFromDB, err := cli.listVpcFromDB(kt, checkParams)
+		if err != nil {
+			return err
+		}
+		common.AuditCloudSyncDelete(kt, cli.dbCli, enumor.VpcCloudAuditResType, delFromDB)
+	}
+
 	deleteReq := &dataservice.BatchDeleteReq{
 		Filter: tools.ContainersExpression("cloud_id", delCloudIDs),
 	}
@@ -235,6 +243,8 @@ func (cli *client) updateVpc(kt *kit.Kit, accountID string, updateMap map[string
 		vpcs = append(vpcs, tmpRes)
 	}
 
+	common.AuditCloudSyncUpdate(kt, cli.dbCli, enumor.VpcCloudAuditResType, vpcs)
+
 	updateReq := &cloud.VpcBatchUpdateReq[cloud.TCloudVpcUpdateExt]{
 		Vpcs: vpcs,
 	}
@@ -292,11 +302,14 @@ func (cli *client) createVpc(kt *kit.Kit, accountID string, addVpc []types.TClou
 	createReq := &cloud.VpcBatchCreateReq[cloud.TCloudVpcCreateExt]{
 		Vpcs: vpcs,
 	}
-	if _, err := cli.dbCli.TCloud.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq); err != nil {
+	createResult, err := cli.dbCli.TCloud.Vpc.BatchCreate(kt.Ctx, kt.Header(), createReq)
+	if err != nil {
 		logs.Errorf("[%s] request dataservice to batch create vpc failed, err: %v, rid: %s", enumor.TCloud, err, kt.Rid)
 		return err
 	}
 
+	common.AuditCloudSyncCreate(kt, cli.dbCli, enumor.VpcCloudAuditResType, createResult.IDs)
+
 	logs.Infof("[%s] sync vpc to create vpc success, accountID: %s, count: %d, rid: %s", enumor.TCloud,
 		accountID, len(addVpc), kt.Rid)
 
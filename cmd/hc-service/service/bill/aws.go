@@ -881,3 +881,104 @@ func (b bill) AwsGetRootAccountSpTotalUsage(cts *rest.Contexts) (any, error) {
 	}
 	return result, nil
 }
+
+// AwsGetRootAccountMonthTotal get aws root account month bill total group by currency
+func (b bill) AwsGetRootAccountMonthTotal(cts *rest.Contexts) (any, error) {
+
+	req := new(hcbill.RootAccountMonthTotalReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rootAccount, err := b.cs.DataService().Global.RootAccount.GetBasicInfo(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("fait to find root account, err: %+v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 查询aws账单基础表
+	billInfo, err := getRootAccountBillConfigInfo[billcore.AwsBillConfigExtension](
+		cts.Kit, req.RootAccountID, b.cs.DataService())
+	if err != nil {
+		logs.Errorf("aws get root account(id: %s) bill config for month total failed, err: %+v, rid: %s",
+			req.RootAccountID, err, cts.Kit.Rid)
+		return nil, err
+	}
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "bill config for root account: %s is not found",
+			req.RootAccountID)
+	}
+
+	cli, err := b.ad.AwsRoot(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("aws request adaptor client err, req: %+v, err: %+v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+	opt := &typesBill.AwsRootMonthTotalOption{
+		PayerCloudID: rootAccount.CloudID,
+		Year:         req.BillYear,
+		Month:        req.BillMonth,
+	}
+	totals, err := cli.GetRootAccountMonthTotal(cts.Kit, billInfo, opt)
+	if err != nil {
+		logs.Errorf("fail to get aws root account month total, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &hcbill.RootAccountMonthTotalResult{Details: totals}, nil
+}
+
+// AwsGetRootAccountSpAnalysis get aws root account savings plans utilization and ec2 coverage
+func (b bill) AwsGetRootAccountSpAnalysis(cts *rest.Contexts) (any, error) {
+
+	req := new(hcbill.AwsRootSpAnalysisReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	rootAccount, err := b.cs.DataService().Global.RootAccount.GetBasicInfo(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("fait to find root account, err: %+v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	// 查询aws账单基础表
+	billInfo, err := getRootAccountBillConfigInfo[billcore.AwsBillConfigExtension](
+		cts.Kit, req.RootAccountID, b.cs.DataService())
+	if err != nil {
+		logs.Errorf("aws get root account(id: %s) bill config for sp analysis failed, err: %+v, rid: %s",
+			req.RootAccountID, err, cts.Kit.Rid)
+		return nil, err
+	}
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "bill config for root account: %s is not found",
+			req.RootAccountID)
+	}
+
+	cli, err := b.ad.AwsRoot(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("aws request adaptor client err, req: %+v, err: %+v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+	opt := &typesBill.AwsRootSpAnalysisOption{
+		PayerCloudID: rootAccount.CloudID,
+		Year:         req.BillYear,
+		Month:        req.BillMonth,
+		SpArnPrefix:  req.SpArnPrefix,
+	}
+	result, err := cli.GetRootAccountSpAnalysis(cts.Kit, billInfo, opt)
+	if err != nil {
+		logs.Errorf("fail to get aws root account sp analysis, err: %v, rid: %s", err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -61,6 +61,12 @@ func InitBillService(cap *capability.Capability) {
 		"/vendors/azure/root_account_bills/list", v.AzureGetRootAccountBillList)
 	h.Add("AwsGetRootAccountSpTotalUsage", "GET",
 		"/vendors/aws/root_account_bills/sp_usage_total", v.AwsGetRootAccountSpTotalUsage)
+	h.Add("AwsGetRootAccountMonthTotal", "POST",
+		"/vendors/aws/root_account_bills/month_total", v.AwsGetRootAccountMonthTotal)
+	h.Add("AwsGetRootAccountSpAnalysis", "POST",
+		"/vendors/aws/root_account_bills/savings_plans/analysis", v.AwsGetRootAccountSpAnalysis)
+	h.Add("GcpGetRootAccountMonthTotal", "POST",
+		"/vendors/gcp/root_account_bills/month_total", v.GcpGetRootAccountMonthTotal)
 
 	h.Load(cap.WebService)
 }
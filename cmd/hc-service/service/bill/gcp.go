@@ -234,3 +234,43 @@ func (b bill) GcpQueryCreditList(cts *rest.Contexts) (interface{}, error) {
 		Details: resp,
 	}, nil
 }
+
+// GcpGetRootAccountMonthTotal get gcp root account month bill total group by currency.
+func (b bill) GcpGetRootAccountMonthTotal(cts *rest.Contexts) (any, error) {
+	req := new(hcbillservice.RootAccountMonthTotalReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	// 查询gcp账单配置表
+	billInfo, err := getRootAccountBillConfigInfo[billcore.GcpBillConfigExtension](
+		cts.Kit, req.RootAccountID, b.cs.DataService())
+	if err != nil {
+		logs.Errorf("gcp root account bill config get base info db failed, root account id: %s, err: %+v, rid: %s",
+			req.RootAccountID, err, cts.Kit.Rid)
+		return nil, err
+	}
+	if billInfo == nil {
+		return nil, errf.Newf(
+			errf.RecordNotFound, "bill config for root_account_id: %s is not found", req.RootAccountID)
+	}
+
+	cli, err := b.ad.GcpRoot(cts.Kit, req.RootAccountID)
+	if err != nil {
+		logs.Errorf("gcp request adaptor client err, req: %+v, err: %+v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	opt := &typesBill.GcpRootMonthTotalOption{Month: fmt.Sprintf("%d%02d", req.BillYear, req.BillMonth)}
+	totals, err := cli.GetRootAccountMonthTotal(cts.Kit, opt, billInfo)
+	if err != nil {
+		logs.Errorf("fail to get gcp root account month total, req: %+v, err: %v, rid: %s", req, err, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return &hcbillservice.RootAccountMonthTotalResult{Details: totals}, nil
+}
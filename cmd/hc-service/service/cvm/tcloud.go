@@ -44,6 +44,10 @@ func (svc *cvmSvc) initTCloudCvmService(cap *capability.Capability) {
 	h.Add("BatchRebootTCloudCvm", http.MethodPost, "/vendors/tcloud/cvms/batch/reboot", svc.BatchRebootTCloudCvm)
 	h.Add("BatchDeleteTCloudCvm", http.MethodDelete, "/vendors/tcloud/cvms/batch", svc.BatchDeleteTCloudCvm)
 	h.Add("BatchResetTCloudCvmPwd", http.MethodPost, "/vendors/tcloud/cvms/batch/reset/pwd", svc.BatchResetTCloudCvmPwd)
+	h.Add("ListTCloudReservedInstance", http.MethodPost, "/vendors/tcloud/reserved_instances/list",
+		svc.ListTCloudReservedInstance)
+	h.Add("ListTCloudReservedInstanceOffering", http.MethodPost, "/vendors/tcloud/reserved_instances/offerings/list",
+		svc.ListTCloudReservedInstanceOffering)
 
 	h.Load(cap.WebService)
 }
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	protocvm "hcm/pkg/api/hc-service/cvm"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+)
+
+// ListTCloudReservedInstance list tcloud reserved instances of region.
+func (svc *cvmSvc) ListTCloudReservedInstance(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.TCloudListReservedInstanceReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tcloud, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := &typecvm.TCloudListReservedInstanceOption{Region: req.Region, States: req.States}
+	result, err := tcloud.ListReservedInstance(cts.Kit, opt)
+	if err != nil {
+		logs.Errorf("list tcloud reserved instance failed, err: %v, req: %+v, rid: %s", err, req, cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListTCloudReservedInstanceOffering list tcloud reserved instance offerings.
+func (svc *cvmSvc) ListTCloudReservedInstanceOffering(cts *rest.Contexts) (interface{}, error) {
+	req := new(protocvm.TCloudListReservedInstanceOfferingReq)
+	if err := cts.DecodeInto(req); err != nil {
+		return nil, errf.NewFromErr(errf.DecodeRequestFailed, err)
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	tcloud, err := svc.ad.TCloud(cts.Kit, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tcloud.ListReservedInstanceOffering(cts.Kit, &req.TCloudListReservedInstanceOfferingOption)
+	if err != nil {
+		logs.Errorf("list tcloud reserved instance offering failed, err: %v, req: %+v, rid: %s", err, req,
+			cts.Kit.Rid)
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -45,6 +45,11 @@ type Handler interface {
 // ResourceSync 资源同步流程。
 func ResourceSync(cts *rest.Contexts, handler Handler) error {
 	kt := cts.Kit
+	// 后台周期同步发现的资源新增、变更、删除都发生在HCM之外，审计来源标记为云上同步。
+	// HCM自身操作后触发的同步为ApiCall请求，其发现的变更由HCM发起，不能记为云上变更
+	if kt.GetRequestSource() == enumor.BackgroundSync {
+		kt.RequestSource = enumor.CloudSync
+	}
 
 	// 解析请求参数到handler实现中，构建同步需要的客户端
 	if err := handler.Prepare(cts); err != nil {
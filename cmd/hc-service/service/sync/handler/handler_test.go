@@ -0,0 +1,88 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package handler
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// testHandler records request source of each sync step.
+type testHandler struct {
+	sources []enumor.RequestSourceType
+}
+
+func (h *testHandler) Prepare(cts *rest.Contexts) error {
+	h.sources = append(h.sources, cts.Kit.GetRequestSource())
+	return nil
+}
+
+func (h *testHandler) Next(kt *kit.Kit) ([]string, error) {
+	h.sources = append(h.sources, kt.GetRequestSource())
+	return nil, nil
+}
+
+func (h *testHandler) Sync(kt *kit.Kit, cloudIDs []string) error {
+	h.sources = append(h.sources, kt.GetRequestSource())
+	return nil
+}
+
+func (h *testHandler) RemoveDeleteFromCloud(kt *kit.Kit) error {
+	h.sources = append(h.sources, kt.GetRequestSource())
+	return nil
+}
+
+func (h *testHandler) Name() enumor.CloudResourceType {
+	return enumor.VpcCloudResType
+}
+
+func TestResourceSyncRequestSource(t *testing.T) {
+	cases := []struct {
+		source enumor.RequestSourceType
+		expect enumor.RequestSourceType
+	}{
+		// 后台周期同步发现的变更记为云上变更
+		{source: enumor.BackgroundSync, expect: enumor.CloudSync},
+		// HCM自身操作触发的同步保持原来源
+		{source: "", expect: enumor.ApiCall},
+		{source: enumor.ApiCall, expect: enumor.ApiCall},
+	}
+
+	for _, c := range cases {
+		kt := kit.New()
+		kt.RequestSource = c.source
+		h := new(testHandler)
+		if err := ResourceSync(&rest.Contexts{Kit: kt}, h); err != nil {
+			t.Fatalf("resource sync failed, err: %v", err)
+		}
+
+		if len(h.sources) == 0 {
+			t.Fatalf("sync handler is not called")
+		}
+		for _, one := range h.sources {
+			if one != c.expect {
+				t.Errorf("source: %q, expect sync with source %s, got: %s", c.source, c.expect, one)
+			}
+		}
+	}
+}
@@ -21,6 +21,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -33,6 +34,7 @@ import (
 	"hcm/pkg/runtime/ctl"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
+	"hcm/pkg/tracing"
 )
 
 const shutdownWaitTimeSec = 60
@@ -60,6 +62,8 @@ func Run(opt *options.Option) error {
 type taskServer struct {
 	svc *service.Service
 	sd  serviced.Service
+	// shutdownTracing 进程退出前导出剩余的链路数据
+	shutdownTracing func(ctx context.Context) error
 }
 
 // prepare do prepare jobs before run api discover.
@@ -77,6 +81,13 @@ func (ds *taskServer) prepare(opt *options.Option) error {
 	network := cc.TaskServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// init tracing
+	shutdownTracing, err := tracing.Init(string(cc.TaskServerName), cc.TaskServer().Tracing.Config())
+	if err != nil {
+		return fmt.Errorf("init tracing failed, err: %v", err)
+	}
+	ds.shutdownTracing = shutdownTracing
+
 	// init service discovery.
 	svcOpt := serviced.NewServiceOption(cc.TaskServerName, cc.TaskServer().Network)
 	discOpt := serviced.DiscoveryOption{
@@ -96,7 +107,7 @@ func (ds *taskServer) prepare(opt *options.Option) error {
 	ds.svc = svc
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(ctl.WithBasics(sd)...); err != nil {
+	if err := ctl.LoadCtl(append(ctl.WithBasics(sd), svc.CtlCommands()...)...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
@@ -115,6 +126,8 @@ func (ds *taskServer) register() error {
 
 // finalizer ...
 func (ds *taskServer) finalizer() {
+	defer tracing.Shutdown(ds.shutdownTracing)
+
 	if err := ds.sd.Deregister(); err != nil {
 		logs.Errorf("process service shutdown, but deregister failed, err: %v", err)
 		return
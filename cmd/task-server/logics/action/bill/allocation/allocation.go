@@ -0,0 +1,353 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package allocation 共享费用分摊规则计算，月度任务分账与规则预览共用
+package allocation
+
+import (
+	"fmt"
+	"sort"
+
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/shopspring/decimal"
+)
+
+// Share 分摊目标二级账号及分摊比例
+type Share struct {
+	MainAccount *protocore.BaseMainAccount
+	Ratio       decimal.Decimal
+}
+
+// SourceCost 待分摊的共享费用
+type SourceCost struct {
+	// MainAccountID 共享费用所在二级账号，来源为公共费用时为空
+	MainAccountID string
+	Currency      enumor.CurrencyCode
+	Cost          decimal.Decimal
+}
+
+// Allocator 根据分摊规则计算分摊目标及比例
+type Allocator struct {
+	ds *dataservice.Client
+}
+
+// NewAllocator ...
+func NewAllocator(ds *dataservice.Client) *Allocator {
+	return &Allocator{ds: ds}
+}
+
+// ListRules 查询一级账号下指定来源的已启用分摊规则，按ID排序
+func (a *Allocator) ListRules(kt *kit.Kit, rootAccountID string, sourceType enumor.BillAllocationSourceType) (
+	[]billcore.AllocationRule, error) {
+
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("root_account_id", rootAccountID),
+			tools.RuleEqual("source_type", sourceType),
+			tools.RuleEqual("enabled", true),
+		),
+		Page: &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending},
+	}
+	resp, err := a.ds.Global.Bill.ListBillAllocationRule(kt, listReq)
+	if err != nil {
+		logs.Errorf("fail to list %s allocation rule of root account %s, err: %v, rid: %s",
+			sourceType, rootAccountID, err, kt.Rid)
+		return nil, err
+	}
+	return resp.Details, nil
+}
+
+// ListMainAccounts 查询一级账号下的全部二级账号
+func (a *Allocator) ListMainAccounts(kt *kit.Kit, rootAccountID string) (map[string]*protocore.BaseMainAccount,
+	error) {
+
+	mainAccountMap := make(map[string]*protocore.BaseMainAccount)
+	listReq := &core.ListReq{
+		Filter: tools.ExpressionAnd(tools.RuleEqual("parent_account_id", rootAccountID)),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		resp, err := a.ds.Global.MainAccount.List(kt, listReq)
+		if err != nil {
+			logs.Errorf("fail to list main account of root account %s for allocation, err: %v, rid: %s",
+				rootAccountID, err, kt.Rid)
+			return nil, err
+		}
+		for _, account := range resp.Details {
+			mainAccountMap[account.ID] = account
+		}
+		if uint(len(resp.Details)) < listReq.Page.Limit {
+			break
+		}
+		listReq.Page.Start += uint32(listReq.Page.Limit)
+	}
+	return mainAccountMap, nil
+}
+
+// ListShares 计算规则的分摊目标及比例，权重全部为0时返回空
+func (a *Allocator) ListShares(kt *kit.Kit, rule *billcore.AllocationRule, billYear, billMonth int,
+	mainAccountMap map[string]*protocore.BaseMainAccount) ([]Share, error) {
+
+	if rule.RuleType == enumor.BillAllocationFixed {
+		shares := make([]Share, 0, len(rule.Targets))
+		for _, target := range rule.Targets {
+			account, ok := mainAccountMap[target.MainAccountID]
+			if !ok {
+				return nil, fmt.Errorf("target main account %s of allocation rule %s not found under root account",
+					target.MainAccountID, rule.ID)
+			}
+			shares = append(shares, Share{MainAccount: account, Ratio: target.Ratio})
+		}
+		return shares, nil
+	}
+
+	candidates := listCandidates(rule, mainAccountMap)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var weights map[string]decimal.Decimal
+	var err error
+	switch rule.RuleType {
+	case enumor.BillAllocationUsage:
+		weights, err = a.getUsageWeights(kt, rule.RootAccountID, billYear, billMonth)
+	case enumor.BillAllocationCpu:
+		weights, err = a.getCpuWeights(kt, rule.Vendor, candidates)
+	default:
+		return nil, fmt.Errorf("unsupported bill allocation rule type: %s", rule.RuleType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateShares(candidates, weights), nil
+}
+
+// listCandidates 按比例分摊时参与分摊的二级账号，不包括排除账号及共享费用所在账号，按ID排序保证结果稳定
+func listCandidates(rule *billcore.AllocationRule,
+	mainAccountMap map[string]*protocore.BaseMainAccount) []*protocore.BaseMainAccount {
+
+	excluded := cvt.StringSliceToMap(rule.ExcludeMainAccountIDs)
+	if rule.SourceType == enumor.BillAllocationSourceBillItem {
+		for _, id := range rule.SourceMainAccountIDs {
+			excluded[id] = struct{}{}
+		}
+	}
+	candidates := make([]*protocore.BaseMainAccount, 0, len(mainAccountMap))
+	for id, account := range mainAccountMap {
+		if _, exist := excluded[id]; exist {
+			continue
+		}
+		candidates = append(candidates, account)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	return candidates
+}
+
+func calculateShares(candidates []*protocore.BaseMainAccount, weights map[string]decimal.Decimal) []Share {
+	total := decimal.Zero
+	for _, account := range candidates {
+		total = total.Add(weights[account.ID])
+	}
+	if !total.IsPositive() {
+		return nil
+	}
+
+	shares := make([]Share, 0, len(candidates))
+	for _, account := range candidates {
+		weight := weights[account.ID]
+		if !weight.IsPositive() {
+			continue
+		}
+		shares = append(shares, Share{MainAccount: account, Ratio: weight.Div(total)})
+	}
+	return shares
+}
+
+// SplitCost 按比例拆分费用，由最后一个目标承担舍入误差，保证拆分后总额不变
+func SplitCost(total decimal.Decimal, shares []Share) []decimal.Decimal {
+	costs := make([]decimal.Decimal, len(shares))
+	allocated := decimal.Zero
+	for i, share := range shares {
+		if i == len(shares)-1 {
+			costs[i] = total.Sub(allocated)
+			break
+		}
+		costs[i] = total.Mul(share.Ratio)
+		allocated = allocated.Add(costs[i])
+	}
+	return costs
+}
+
+// getUsageWeights 以二级账号当月费用作为权重
+func (a *Allocator) getUsageWeights(kt *kit.Kit, rootAccountID string, billYear, billMonth int) (
+	map[string]decimal.Decimal, error) {
+
+	req := &dsbill.BillSummaryMainListReq{
+		Filter: tools.ExpressionAnd(
+			tools.RuleEqual("root_account_id", rootAccountID),
+			tools.RuleEqual("bill_year", billYear),
+			tools.RuleEqual("bill_month", billMonth),
+		),
+		Page: core.NewDefaultBasePage(),
+	}
+	weights := make(map[string]decimal.Decimal)
+	for {
+		resp, err := a.ds.Global.Bill.ListBillSummaryMain(kt, req)
+		if err != nil {
+			logs.Errorf("fail to list main account summary for allocation, root account: %s, %d-%02d, err: %v, "+
+				"rid: %s", rootAccountID, billYear, billMonth, err, kt.Rid)
+			return nil, err
+		}
+		for _, summary := range resp.Details {
+			weights[summary.MainAccountID] = summary.CurrentMonthCost
+		}
+		if uint(len(resp.Details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+	return weights, nil
+}
+
+// SumBillItemSource 汇总来源为账单明细的共享费用，按来源二级账号及币种聚合，不包括月度任务生成的明细
+func (a *Allocator) SumBillItemSource(kt *kit.Kit, rule *billcore.AllocationRule, billYear, billMonth int) (
+	[]SourceCost, error) {
+
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("root_account_id", rule.RootAccountID),
+		tools.RuleEqual("vendor", rule.Vendor),
+		tools.RuleEqual("bill_year", billYear),
+		tools.RuleEqual("bill_month", billMonth),
+		tools.RuleGreaterThan("bill_day", enumor.MonthTaskSpecialBillDay),
+	}
+	if len(rule.SourceMainAccountIDs) > 0 {
+		rules = append(rules, tools.RuleIn("main_account_id", rule.SourceMainAccountIDs))
+	}
+	if len(rule.SourceHcProductCodes) > 0 {
+		rules = append(rules, tools.RuleIn("hc_product_code", rule.SourceHcProductCodes))
+	}
+	return a.sumBillItems(kt, rule.Vendor, billYear, billMonth, tools.ExpressionAnd(rules...), true)
+}
+
+// SumCommonExpense 汇总月度任务已分摊的公共费用，用于预览公共费用分摊规则
+func (a *Allocator) SumCommonExpense(kt *kit.Kit, rule *billcore.AllocationRule, billYear, billMonth int) (
+	[]SourceCost, error) {
+
+	return a.sumMonthTaskItems(kt, rule, billYear, billMonth, constant.BillCommonExpenseName, nil)
+}
+
+// SumReturnedCost 汇总月度任务已归还给目标账号的savings plans、credit费用，用于预览对应来源的分摊规则
+func (a *Allocator) SumReturnedCost(kt *kit.Kit, rule *billcore.AllocationRule, billYear, billMonth int) (
+	[]SourceCost, error) {
+
+	var productCode string
+	switch rule.SourceType {
+	case enumor.BillAllocationSourceSavingsPlans:
+		productCode = constant.AwsSavingsPlansCostCodeReverse
+	case enumor.BillAllocationSourceCredits:
+		productCode = constant.GcpCreditReturnCost
+	default:
+		return nil, fmt.Errorf("allocation source type %s has no returned cost", rule.SourceType)
+	}
+
+	targetIDs := make([]string, 0, len(rule.Targets))
+	for _, target := range rule.Targets {
+		targetIDs = append(targetIDs, target.MainAccountID)
+	}
+	return a.sumMonthTaskItems(kt, rule, billYear, billMonth, productCode,
+		[]*filter.AtomRule{tools.RuleIn("main_account_id", targetIDs)})
+}
+
+func (a *Allocator) sumMonthTaskItems(kt *kit.Kit, rule *billcore.AllocationRule, billYear, billMonth int,
+	productCode string, extra []*filter.AtomRule) ([]SourceCost, error) {
+
+	rules := []*filter.AtomRule{
+		tools.RuleEqual("root_account_id", rule.RootAccountID),
+		tools.RuleEqual("vendor", rule.Vendor),
+		tools.RuleEqual("bill_year", billYear),
+		tools.RuleEqual("bill_month", billMonth),
+		tools.RuleEqual("bill_day", enumor.MonthTaskSpecialBillDay),
+		tools.RuleEqual("hc_product_code", productCode),
+	}
+	rules = append(rules, extra...)
+	return a.sumBillItems(kt, rule.Vendor, billYear, billMonth, tools.ExpressionAnd(rules...), false)
+}
+
+func (a *Allocator) sumBillItems(kt *kit.Kit, vendor enumor.Vendor, billYear, billMonth int,
+	expr *filter.Expression, byMainAccount bool) ([]SourceCost, error) {
+
+	type sourceKey struct {
+		mainAccountID string
+		currency      enumor.CurrencyCode
+	}
+	costMap := make(map[sourceKey]decimal.Decimal)
+	keys := make([]sourceKey, 0)
+
+	commonOpt := &dsbill.ItemCommonOpt{Vendor: vendor, Year: billYear, Month: billMonth}
+	page := &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit}
+	for {
+		listReq := &dsbill.BillItemListReq{
+			ItemCommonOpt: commonOpt,
+			ListReq: &core.ListReq{
+				Filter: expr,
+				Page:   page,
+				Fields: []string{"main_account_id", "currency", "cost"},
+			},
+		}
+		resp, err := a.ds.Global.Bill.ListBillItem(kt, listReq)
+		if err != nil {
+			logs.Errorf("fail to list bill item for allocation source, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		for _, item := range resp.Details {
+			key := sourceKey{currency: item.Currency}
+			if byMainAccount {
+				key.mainAccountID = item.MainAccountID
+			}
+			if _, exist := costMap[key]; !exist {
+				keys = append(keys, key)
+			}
+			costMap[key] = costMap[key].Add(item.Cost)
+		}
+		if uint(len(resp.Details)) < page.Limit {
+			break
+		}
+		page.Start += uint32(page.Limit)
+	}
+
+	sources := make([]SourceCost, 0, len(keys))
+	for _, key := range keys {
+		sources = append(sources, SourceCost{MainAccountID: key.mainAccountID, Currency: key.currency,
+			Cost: costMap[key]})
+	}
+	return sources, nil
+}
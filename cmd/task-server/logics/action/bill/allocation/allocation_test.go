@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package allocation
+
+import (
+	"testing"
+
+	protocore "hcm/pkg/api/core/account-set"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSplitCost(t *testing.T) {
+	accounts := []*protocore.BaseMainAccount{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	weights := map[string]decimal.Decimal{
+		"a": decimal.NewFromInt(1),
+		"b": decimal.NewFromInt(1),
+		"c": decimal.NewFromInt(1),
+	}
+	shares := calculateShares(accounts, weights)
+	if len(shares) != 3 {
+		t.Fatalf("account without weight should be skipped, got %d shares", len(shares))
+	}
+
+	total := decimal.NewFromInt(100)
+	sum := decimal.Zero
+	for _, cost := range SplitCost(total, shares) {
+		sum = sum.Add(cost)
+	}
+	if !sum.Equal(total) {
+		t.Errorf("sum of split cost should be %s, got: %s", total, sum)
+	}
+
+	if shares := calculateShares(accounts, map[string]decimal.Decimal{}); len(shares) != 0 {
+		t.Errorf("zero weights should return no share, got: %d", len(shares))
+	}
+}
+
+func TestParseMachineTypeCpu(t *testing.T) {
+	cases := map[string]int64{
+		"n2-standard-8":     8,
+		"custom-4-16384":    4,
+		"n2-custom-6-24576": 6,
+		"e2-micro":          0,
+		"Standard_D4s_v3":   4,
+		"S5.MEDIUM4":        0,
+		"":                  0,
+	}
+	for machineType, expect := range cases {
+		if got := parseMachineTypeCpu(machineType); got != expect {
+			t.Errorf("machine type: %s, got: %d, expect: %d", machineType, got, expect)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package allocation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hcm/pkg/api/core"
+	protocore "hcm/pkg/api/core/account-set"
+	corecvm "hcm/pkg/api/core/cloud/cvm"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/slice"
+
+	"github.com/shopspring/decimal"
+)
+
+// getCpuWeights 以二级账号所属业务下已同步主机的CPU核数作为权重，同一业务下有多个二级账号时平均分配
+func (a *Allocator) getCpuWeights(kt *kit.Kit, vendor enumor.Vendor, candidates []*protocore.BaseMainAccount) (
+	map[string]decimal.Decimal, error) {
+
+	bizAccountCount := make(map[int64]int64)
+	for _, account := range candidates {
+		bizAccountCount[account.BkBizID]++
+	}
+	bizIDs := make([]int64, 0, len(bizAccountCount))
+	for bizID := range bizAccountCount {
+		bizIDs = append(bizIDs, bizID)
+	}
+
+	bizCpu, err := a.countCpuByBiz(kt, vendor, bizIDs)
+	if err != nil {
+		logs.Errorf("fail to count cpu by biz for allocation, vendor: %s, err: %v, rid: %s", vendor, err, kt.Rid)
+		return nil, err
+	}
+
+	weights := make(map[string]decimal.Decimal, len(candidates))
+	for _, account := range candidates {
+		weights[account.ID] = decimal.NewFromInt(bizCpu[account.BkBizID]).
+			Div(decimal.NewFromInt(bizAccountCount[account.BkBizID]))
+	}
+	return weights, nil
+}
+
+// countCpuByBiz 统计各业务下该厂商已同步主机的CPU核数
+func (a *Allocator) countCpuByBiz(kt *kit.Kit, vendor enumor.Vendor, bizIDs []int64) (map[int64]int64, error) {
+	result := make(map[int64]int64)
+	for _, batch := range slice.Split(bizIDs, int(core.DefaultMaxPageLimit)) {
+		var err error
+		switch vendor {
+		case enumor.TCloud:
+			err = sumCvmCpu(kt, a.ds.TCloud.Cvm.ListCvmExt, batch, result,
+				func(ext *corecvm.TCloudCvmExtension) int64 { return cvt.PtrToVal(ext.Cpu) })
+		case enumor.Aws:
+			err = sumCvmCpu(kt, a.ds.Aws.Cvm.ListCvmExt, batch, result, awsCpu)
+		case enumor.HuaWei:
+			err = sumCvmCpu(kt, a.ds.HuaWei.Cvm.ListCvmExt, batch, result, huaweiCpu)
+		case enumor.Azure:
+			err = sumCvmCpu(kt, a.ds.Azure.Cvm.ListCvmExt, batch, result, azureCpu)
+		case enumor.Gcp:
+			err = sumCvmCpu(kt, a.ds.Gcp.Cvm.ListCvmExt, batch, result, nil)
+		default:
+			return nil, fmt.Errorf("cpu allocation of vendor %s is not supported", vendor)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type listCvmFunc[T corecvm.Extension] func(ctx context.Context, h http.Header, req *protocloud.CvmListReq) (
+	*protocloud.CvmExtListResult[T], error)
+
+// sumCvmCpu 分页查询主机并按业务累加CPU核数，cpuOf为空或返回0时根据机型推算
+func sumCvmCpu[T corecvm.Extension](kt *kit.Kit, list listCvmFunc[T], bizIDs []int64, result map[int64]int64,
+	cpuOf func(ext *T) int64) error {
+
+	req := &protocloud.CvmListReq{
+		Filter: tools.ExpressionAnd(tools.RuleIn("bk_biz_id", bizIDs)),
+		Page:   core.NewDefaultBasePage(),
+	}
+	for {
+		resp, err := list(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			return err
+		}
+		for _, one := range resp.Details {
+			var cpu int64
+			if cpuOf != nil && one.Extension != nil {
+				cpu = cpuOf(one.Extension)
+			}
+			if cpu == 0 {
+				cpu = parseMachineTypeCpu(one.MachineType)
+			}
+			result[one.BkBizID] += cpu
+		}
+		if uint(len(resp.Details)) < req.Page.Limit {
+			return nil
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+}
+
+func awsCpu(ext *corecvm.AwsCvmExtension) int64 {
+	if ext.CpuOptions == nil {
+		return 0
+	}
+	threads := cvt.PtrToVal(ext.CpuOptions.ThreadsPerCore)
+	if threads == 0 {
+		threads = 1
+	}
+	return cvt.PtrToVal(ext.CpuOptions.CoreCount) * threads
+}
+
+func huaweiCpu(ext *corecvm.HuaWeiCvmExtension) int64 {
+	if ext.Flavor == nil {
+		return 0
+	}
+	cpu, err := strconv.ParseInt(ext.Flavor.VCpus, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return cpu
+}
+
+func azureCpu(ext *corecvm.AzureCvmExtension) int64 {
+	if ext.HardwareProfile == nil || ext.HardwareProfile.VmSizeProperties == nil {
+		return 0
+	}
+	return int64(cvt.PtrToVal(ext.HardwareProfile.VmSizeProperties.VCPUsAvailable))
+}
+
+// parseMachineTypeCpu 从机型名称推算CPU核数，无法推算时返回0
+func parseMachineTypeCpu(machineType string) int64 {
+	parts := strings.FieldsFunc(machineType, func(r rune) bool { return r == '-' || r == '_' })
+	for i, part := range parts {
+		// gcp 自定义机型，如 custom-4-16384、n2-custom-4-16384
+		if part == "custom" && i+1 < len(parts) {
+			return parseLeadingInt(parts[i+1])
+		}
+	}
+	if len(parts) < 2 {
+		return 0
+	}
+	// gcp 预定义机型，如 n2-standard-8
+	if cpu, err := strconv.ParseInt(parts[len(parts)-1], 10, 64); err == nil {
+		return cpu
+	}
+	// azure 机型，如 Standard_D4s_v3
+	return parseLeadingInt(strings.TrimLeftFunc(parts[1], isNotDigit))
+}
+
+func parseLeadingInt(s string) int64 {
+	end := strings.IndexFunc(s, isNotDigit)
+	if end < 0 {
+		end = len(s)
+	}
+	val, err := strconv.ParseInt(s[:end], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return val
+}
+
+func isNotDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
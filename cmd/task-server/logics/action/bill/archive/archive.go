@@ -0,0 +1,216 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package archive 账单明细分表归档到对象存储及从归档恢复
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+)
+
+// ArchiveOption option for bill item partition archive and restore
+type ArchiveOption struct {
+	ArchiveID string        `json:"archive_id" validate:"required"`
+	Vendor    enumor.Vendor `json:"vendor" validate:"required"`
+	BillYear  int           `json:"bill_year" validate:"required"`
+	BillMonth int           `json:"bill_month" validate:"required,min=1,max=12"`
+}
+
+// Validate ...
+func (opt *ArchiveOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+func (opt *ArchiveOption) partition() *dsbill.BillItemPartitionReq {
+	return &dsbill.BillItemPartitionReq{Vendor: opt.Vendor, Year: opt.BillYear, Month: opt.BillMonth}
+}
+
+// ObjectPath 归档文件在对象存储中的路径
+func (opt *ArchiveOption) ObjectPath() string {
+	return fmt.Sprintf("bill/archive/%s/%d%02d/%s.jsonl.gz", opt.Vendor, opt.BillYear, opt.BillMonth,
+		opt.ArchiveID)
+}
+
+var _ action.Action = new(ArchiveAction)
+var _ action.ParameterAction = new(ArchiveAction)
+
+// ArchiveAction 将账单明细分表导出为gzip压缩的json lines文件，上传到对象存储后删除分表
+type ArchiveAction struct{}
+
+// ParameterNew return request params.
+func (act ArchiveAction) ParameterNew() interface{} {
+	return new(ArchiveOption)
+}
+
+// Name return action name
+func (act ArchiveAction) Name() enumor.ActionName {
+	return enumor.ActionBillArchive
+}
+
+// Run archive bill item partition
+func (act ArchiveAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*ArchiveOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := act.archive(kt.Kit(), opt); err != nil {
+		logs.Errorf("fail to archive bill item of %s %d-%02d, err: %v, rid: %s",
+			opt.Vendor, opt.BillYear, opt.BillMonth, err, kt.Kit().Rid)
+		markFailed(kt.Kit(), opt.ArchiveID, enumor.BillArchiveFailed, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (act ArchiveAction) archive(kt *kit.Kit, opt *ArchiveOption) error {
+	storage := actcli.GetObjectStore()
+	if storage == nil {
+		return errors.New("object store of task server is not configured")
+	}
+
+	file, err := createTmpFile()
+	if err != nil {
+		return err
+	}
+	defer removeTmpFile(file)
+
+	count, err := dumpBillItems(kt, opt, file)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	objectPath := opt.ObjectPath()
+	if err = storage.Upload(kt, objectPath, file); err != nil {
+		return fmt.Errorf("upload archive file to %s failed, err: %v", objectPath, err)
+	}
+
+	// 先更新归档记录再删除分表，删除失败时由账单保留检查重新删除已归档的分表
+	updateReq := &dsbill.BillArchiveUpdateReq{
+		ID:         opt.ArchiveID,
+		State:      enumor.BillArchiveArchived,
+		ObjectPath: objectPath,
+		ItemCount:  cvt.ValToPtr(count),
+		FileSize:   cvt.ValToPtr(uint64(info.Size())),
+		Reason:     cvt.ValToPtr(""),
+	}
+	if err = actcli.GetDataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+		return fmt.Errorf("update bill archive %s to archived failed, err: %v", opt.ArchiveID, err)
+	}
+	if err = actcli.GetDataService().Global.Bill.DropBillItemPartition(kt, opt.partition()); err != nil {
+		return fmt.Errorf("drop bill item partition failed, err: %v", err)
+	}
+
+	logs.Infof("bill item of %s %d-%02d archived to %s, count: %d, size: %d, rid: %s", opt.Vendor, opt.BillYear,
+		opt.BillMonth, objectPath, count, info.Size(), kt.Rid)
+	return nil
+}
+
+// dumpBillItems 按ID顺序分页导出分表中的全部账单明细，每行一条json
+func dumpBillItems(kt *kit.Kit, opt *ArchiveOption, w io.Writer) (uint64, error) {
+	gw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(gw)
+
+	count := uint64(0)
+	lastID := ""
+	for {
+		listReq := &dsbill.BillItemListReq{
+			ItemCommonOpt: opt.partition(),
+			ListReq: &core.ListReq{
+				Filter: tools.ExpressionAnd(tools.RuleIDGreaterThan(lastID)),
+				Page:   &core.BasePage{Start: 0, Limit: core.DefaultMaxPageLimit, Sort: "id", Order: core.Ascending},
+			},
+		}
+		resp, err := actcli.GetDataService().Global.Bill.ListBillItemRaw(kt, listReq)
+		if err != nil {
+			return 0, fmt.Errorf("list bill item after %s failed, err: %v", lastID, err)
+		}
+		for _, item := range resp.Details {
+			if err = encoder.Encode(item); err != nil {
+				return 0, err
+			}
+		}
+		count += uint64(len(resp.Details))
+		if uint(len(resp.Details)) < core.DefaultMaxPageLimit {
+			break
+		}
+		lastID = resp.Details[len(resp.Details)-1].ID
+	}
+
+	if err := gw.Close(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func createTmpFile() (*os.File, error) {
+	if err := os.MkdirAll(cc.TaskServer().TmpFileDir, 0700); err != nil {
+		return nil, fmt.Errorf("create tmp file dir failed, err: %v", err)
+	}
+	return os.CreateTemp(cc.TaskServer().TmpFileDir, "bill_archive_*.jsonl.gz")
+}
+
+func removeTmpFile(file *os.File) {
+	if err := file.Close(); err != nil {
+		logs.Warnf("close tmp file %s failed, err: %v", file.Name(), err)
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		logs.Warnf("remove tmp file %s failed, err: %v", file.Name(), err)
+	}
+}
+
+// markFailed 记录失败原因，归档失败时状态置为失败，恢复失败时状态回到已归档
+func markFailed(kt *kit.Kit, archiveID string, state enumor.BillArchiveState, cause error) {
+	reason := []rune(cause.Error())
+	if len(reason) > 1024 {
+		reason = reason[:1024]
+	}
+	updateReq := &dsbill.BillArchiveUpdateReq{ID: archiveID, State: state, Reason: cvt.ValToPtr(string(reason))}
+	if err := actcli.GetDataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+		logs.Errorf("fail to update bill archive %s to %s, err: %v, rid: %s", archiveID, state, err, kt.Rid)
+	}
+}
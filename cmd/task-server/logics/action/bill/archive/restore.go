@@ -0,0 +1,197 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	rawjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core/bill"
+	dsbill "hcm/pkg/api/data-service/bill"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+	"hcm/pkg/tools/times"
+)
+
+var _ action.Action = new(RestoreAction)
+var _ action.ParameterAction = new(RestoreAction)
+
+// RestoreAction 从对象存储下载归档文件，重建账单明细分表并导入，用于重新导出已归档月份的账单
+type RestoreAction struct{}
+
+// ParameterNew return request params.
+func (act RestoreAction) ParameterNew() interface{} {
+	return new(ArchiveOption)
+}
+
+// Name return action name
+func (act RestoreAction) Name() enumor.ActionName {
+	return enumor.ActionBillArchiveRestore
+}
+
+// Run restore archived bill item partition
+func (act RestoreAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*ArchiveOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	if err := act.restore(kt.Kit(), opt); err != nil {
+		logs.Errorf("fail to restore bill item of %s %d-%02d, err: %v, rid: %s",
+			opt.Vendor, opt.BillYear, opt.BillMonth, err, kt.Kit().Rid)
+		// 删除导入了部分数据的分表，归档文件仍然有效，状态回到已归档
+		if dropErr := actcli.GetDataService().Global.Bill.DropBillItemPartition(kt.Kit(),
+			opt.partition()); dropErr != nil {
+			logs.Errorf("fail to drop partially restored bill item partition, err: %v, rid: %s", dropErr,
+				kt.Kit().Rid)
+		}
+		markFailed(kt.Kit(), opt.ArchiveID, enumor.BillArchiveArchived, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (act RestoreAction) restore(kt *kit.Kit, opt *ArchiveOption) error {
+	storage := actcli.GetObjectStore()
+	if storage == nil {
+		return errors.New("object store of task server is not configured")
+	}
+
+	file, err := createTmpFile()
+	if err != nil {
+		return err
+	}
+	defer removeTmpFile(file)
+
+	objectPath := opt.ObjectPath()
+	if err = storage.Download(kt, objectPath, file); err != nil {
+		return fmt.Errorf("download archive file %s failed, err: %v", objectPath, err)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err = actcli.GetDataService().Global.Bill.CreateBillItemPartition(kt, opt.partition()); err != nil {
+		return fmt.Errorf("create bill item partition failed, err: %v", err)
+	}
+	count, err := loadBillItems(kt, opt, file)
+	if err != nil {
+		return err
+	}
+
+	updateReq := &dsbill.BillArchiveUpdateReq{
+		ID:         opt.ArchiveID,
+		State:      enumor.BillArchiveRestored,
+		Reason:     cvt.ValToPtr(""),
+		RestoredAt: times.ConvStdTimeFormat(times.ConvStdTimeNow()),
+	}
+	if err = actcli.GetDataService().Global.Bill.UpdateBillArchive(kt, updateReq); err != nil {
+		return fmt.Errorf("update bill archive %s to restored failed, err: %v", opt.ArchiveID, err)
+	}
+
+	logs.Infof("bill item of %s %d-%02d restored from %s, count: %d, rid: %s", opt.Vendor, opt.BillYear,
+		opt.BillMonth, objectPath, count, kt.Rid)
+	return nil
+}
+
+// loadBillItems 逐行读取归档文件并分批写入分表
+func loadBillItems(kt *kit.Kit, opt *ArchiveOption, r io.Reader) (uint64, error) {
+	gr, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return 0, fmt.Errorf("open archive file failed, err: %v", err)
+	}
+	defer gr.Close()
+
+	count := uint64(0)
+	batch := make([]dsbill.BillItemCreateReq[rawjson.RawMessage], 0, constant.BatchOperationMaxLimit)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		createReq := &dsbill.BatchRawBillItemCreateReq{ItemCommonOpt: opt.partition(), Items: batch}
+		if _, err := actcli.GetDataService().Global.Bill.BatchCreateBillItem(kt, createReq); err != nil {
+			return fmt.Errorf("create restored bill item failed, err: %v", err)
+		}
+		count += uint64(len(batch))
+		batch = make([]dsbill.BillItemCreateReq[rawjson.RawMessage], 0, constant.BatchOperationMaxLimit)
+		return nil
+	}
+
+	decoder := rawjson.NewDecoder(gr)
+	for {
+		item := new(bill.BillItemRaw)
+		if err = decoder.Decode(item); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, fmt.Errorf("decode archived bill item failed, err: %v", err)
+		}
+		batch = append(batch, convRestoreReq(item))
+		if len(batch) < constant.BatchOperationMaxLimit {
+			continue
+		}
+		if err = flush(); err != nil {
+			return 0, err
+		}
+	}
+	if err = flush(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func convRestoreReq(item *bill.BillItemRaw) dsbill.BillItemCreateReq[rawjson.RawMessage] {
+	req := dsbill.BillItemCreateReq[rawjson.RawMessage]{
+		RootAccountID: item.RootAccountID,
+		MainAccountID: item.MainAccountID,
+		Vendor:        item.Vendor,
+		ProductID:     item.ProductID,
+		BkBizID:       item.BkBizID,
+		BillYear:      item.BillYear,
+		BillMonth:     item.BillMonth,
+		BillDay:       item.BillDay,
+		VersionID:     item.VersionID,
+		Currency:      item.Currency,
+		Cost:          item.Cost,
+		HcProductCode: item.HcProductCode,
+		HcProductName: item.HcProductName,
+		ResAmount:     item.ResAmount,
+		ResAmountUnit: item.ResAmountUnit,
+		CloudResID:    item.CloudResID,
+	}
+	if len(item.Extension) > 0 {
+		req.Extension = cvt.ValToPtr(item.Extension)
+	}
+	return req
+}
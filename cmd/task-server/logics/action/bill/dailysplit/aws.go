@@ -61,6 +61,7 @@ func (ds *AwsSplitter) DoSplit(kt *kit.Kit, opt *DailyAccountSplitActionOption,
 		HcProductName: item.HcProductName,
 		ResAmount:     item.ResAmount,
 		ResAmountUnit: item.ResAmountUnit,
+		CloudResID:    ExtractCloudResID(opt.Vendor, string(item.Extension)),
 		Extension:     cvt.ValToPtr(rawjson.RawMessage(item.Extension)),
 	}
 	billItems = append(billItems, usageBillItem)
@@ -123,6 +124,7 @@ func (ds *AwsSplitter) extractSpCostItem(kt *kit.Kit, opt *DailyAccountSplitActi
 		Cost:          spNetCost,
 		HcProductCode: constant.AwsSavingsPlansCostCode,
 		HcProductName: constant.AwsSavingsPlansCostCode,
+		CloudResID:    ExtractCloudResID(opt.Vendor, string(item.Extension)),
 		Extension:     cvt.ValToPtr(rawjson.RawMessage(item.Extension)),
 	}
 
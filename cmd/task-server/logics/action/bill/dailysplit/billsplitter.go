@@ -86,6 +86,7 @@ func (ds *DefaultSplitter) DoSplit(kt *kit.Kit, opt *DailyAccountSplitActionOpti
 		HcProductName: item.HcProductName,
 		ResAmount:     item.ResAmount,
 		ResAmountUnit: item.ResAmountUnit,
+		CloudResID:    ExtractCloudResID(opt.Vendor, string(item.Extension)),
 		Extension:     &ext,
 	}
 	return []bill.BillItemCreateReq[rawjson.RawMessage]{
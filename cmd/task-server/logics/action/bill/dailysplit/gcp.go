@@ -55,6 +55,7 @@ func (ds *GcpSplitter) DoSplit(kt *kit.Kit, opt *DailyAccountSplitActionOption,
 		logs.Errorf("fail to marshal gcp raw bill item extension for split, err: %v, rid: %s", err, kt.Rid)
 		return nil, err
 	}
+	cloudResID := ExtractCloudResID(opt.Vendor, string(item.Extension))
 	usageBillItem := bill.BillItemCreateReq[rawjson.RawMessage]{
 		RootAccountID: opt.RootAccountID,
 		MainAccountID: opt.MainAccountID,
@@ -71,6 +72,7 @@ func (ds *GcpSplitter) DoSplit(kt *kit.Kit, opt *DailyAccountSplitActionOption,
 		HcProductName: item.HcProductName,
 		ResAmount:     item.ResAmount,
 		ResAmountUnit: item.ResAmountUnit,
+		CloudResID:    cloudResID,
 		Extension:     cvt.ValToPtr[rawjson.RawMessage](rawExt),
 	}
 
@@ -100,6 +102,7 @@ func (ds *GcpSplitter) DoSplit(kt *kit.Kit, opt *DailyAccountSplitActionOption,
 			Cost:          cvt.PtrToVal(credit.Amount),
 			HcProductCode: constant.GcpCreditReturnCost,
 			HcProductName: credit.ID,
+			CloudResID:    cloudResID,
 			Extension:     cvt.ValToPtr[rawjson.RawMessage](rawExt),
 		}
 		billItems = append(billItems, creditBillItem)
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dailysplit
+
+import (
+	rawjson "encoding/json"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+// maxCloudResIDLen 账单明细云资源ID字段最大长度
+const maxCloudResIDLen = 255
+
+// vendorCloudResIDKeys 各云厂商原始账单中云资源ID对应的字段
+var vendorCloudResIDKeys = map[enumor.Vendor]string{
+	enumor.TCloud: "ResourceId",
+	enumor.Aws:    "line_item_resource_id",
+	enumor.Gcp:    "resource_name",
+	enumor.HuaWei: "resource_id",
+}
+
+// ExtractCloudResID 从原始账单中提取云资源ID，用于关联已同步资源的cloud_id，提取不到时返回空字符串
+func ExtractCloudResID(vendor enumor.Vendor, extension string) string {
+	key, exists := vendorCloudResIDKeys[vendor]
+	if !exists || len(extension) == 0 {
+		return ""
+	}
+
+	fields := make(map[string]rawjson.RawMessage)
+	if err := rawjson.Unmarshal([]byte(extension), &fields); err != nil {
+		return ""
+	}
+
+	var cloudResID string
+	if err := rawjson.Unmarshal(fields[key], &cloudResID); err != nil {
+		return ""
+	}
+
+	if len(cloudResID) > maxCloudResIDLen {
+		return ""
+	}
+
+	return cloudResID
+}
@@ -0,0 +1,48 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package dailysplit
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestExtractCloudResID(t *testing.T) {
+	cases := []struct {
+		vendor    enumor.Vendor
+		extension string
+		expect    string
+	}{
+		{enumor.Aws, `{"line_item_resource_id":"i-0abc","line_item_line_item_type":"Usage"}`, "i-0abc"},
+		{enumor.HuaWei, `{"resource_id":"6f3d1c2a","resource_name":"ecs-1"}`, "6f3d1c2a"},
+		{enumor.Gcp, `{"resource_name":"instance-1","resource_global_name":null}`, "instance-1"},
+		{enumor.Gcp, `{"resource_name":null}`, ""},
+		{enumor.TCloud, `{"ResourceId":"ins-1"}`, "ins-1"},
+		{enumor.Azure, `{"resource_id":"vm-1"}`, ""},
+		{enumor.Aws, `invalid`, ""},
+	}
+
+	for _, c := range cases {
+		if got := ExtractCloudResID(c.vendor, c.extension); got != c.expect {
+			t.Errorf("vendor: %s, extension: %s, got: %s, expect: %s", c.vendor, c.extension, got, c.expect)
+		}
+	}
+}
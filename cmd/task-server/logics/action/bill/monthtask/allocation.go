@@ -0,0 +1,253 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package monthtask
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/cmd/task-server/logics/action/bill/allocation"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	protocore "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
+	"hcm/pkg/api/data-service/bill"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	cvt "hcm/pkg/tools/converter"
+
+	"github.com/shopspring/decimal"
+)
+
+// AllocationMonthTask 按分摊规则将账单明细中的共享费用分摊给其他二级账号，各厂商通用
+type AllocationMonthTask struct{}
+
+// allocationRawExtension 分摊任务原始账单扩展字段，记录共享费用来源
+type allocationRawExtension struct {
+	RuleID              string `json:"rule_id"`
+	SourceMainAccountID string `json:"source_main_account_id"`
+}
+
+// allocationItemExtension 分摊生成的账单明细扩展字段
+type allocationItemExtension struct {
+	RuleID              string          `json:"allocation_rule_id"`
+	RuleName            string          `json:"allocation_rule_name"`
+	RuleType            string          `json:"allocation_rule_type"`
+	SourceMainAccountID string          `json:"source_main_account_id"`
+	Ratio               decimal.Decimal `json:"ratio"`
+}
+
+// GetBatchSize 每条规则的每个来源账号及币种对应一条原始账单，数量较少
+func (t AllocationMonthTask) GetBatchSize(kt *kit.Kit) uint64 {
+	return 100
+}
+
+// Pull 按规则汇总当月共享费用，规则数量有限，一次拉取完成
+func (t AllocationMonthTask) Pull(kt *kit.Kit, opt *MonthTaskActionOption, index uint64) (
+	itemList []bill.RawBillItem, isFinished bool, err error) {
+
+	if index > 0 {
+		return nil, true, nil
+	}
+
+	allocator := allocation.NewAllocator(actcli.GetDataService())
+	rules, err := allocator.ListRules(kt, opt.RootAccountID, enumor.BillAllocationSourceBillItem)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range rules {
+		sources, err := allocator.SumBillItemSource(kt, &rules[i], opt.BillYear, opt.BillMonth)
+		if err != nil {
+			logs.Errorf("fail to sum allocation source of rule %s, opt: %s, err: %v, rid: %s",
+				rules[i].ID, opt.String(), err, kt.Rid)
+			return nil, false, err
+		}
+		for _, source := range sources {
+			if source.Cost.IsZero() {
+				continue
+			}
+			extBytes, err := json.Marshal(allocationRawExtension{RuleID: rules[i].ID,
+				SourceMainAccountID: source.MainAccountID})
+			if err != nil {
+				return nil, false, fmt.Errorf("marshal allocation raw extension failed, err: %v", err)
+			}
+			itemList = append(itemList, bill.RawBillItem{
+				HcProductCode: constant.BillAllocationName,
+				HcProductName: rules[i].Name,
+				BillCurrency:  source.Currency,
+				BillCost:      source.Cost,
+				Extension:     types.JsonField(extBytes),
+			})
+		}
+	}
+	return itemList, true, nil
+}
+
+// Split 将共享费用按规则分摊给目标二级账号，并冲平来源二级账号的支出
+func (t AllocationMonthTask) Split(kt *kit.Kit, opt *MonthTaskActionOption, rawItemList []*bill.RawBillItem) (
+	[]bill.BillItemCreateReq[json.RawMessage], error) {
+
+	if len(rawItemList) == 0 {
+		return nil, nil
+	}
+
+	task, err := getMonthTask(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+	allocator := allocation.NewAllocator(actcli.GetDataService())
+	rules, err := allocator.ListRules(kt, opt.RootAccountID, enumor.BillAllocationSourceBillItem)
+	if err != nil {
+		return nil, err
+	}
+	ruleMap := make(map[string]*billcore.AllocationRule, len(rules))
+	for i := range rules {
+		ruleMap[rules[i].ID] = &rules[i]
+	}
+	mainAccountMap, err := allocator.ListMainAccounts(kt, opt.RootAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	shareCache := make(map[string][]allocation.Share)
+	billItems := make([]bill.BillItemCreateReq[json.RawMessage], 0)
+	for _, raw := range rawItemList {
+		rawExt := new(allocationRawExtension)
+		if err := json.Unmarshal([]byte(raw.Extension), rawExt); err != nil {
+			return nil, fmt.Errorf("unmarshal allocation raw extension failed, err: %v", err)
+		}
+		rule, ok := ruleMap[rawExt.RuleID]
+		if !ok {
+			// 拉取后规则被删除或停用，费用保留在来源账号
+			logs.Warnf("allocation rule %s not found or disabled, skip, opt: %s, rid: %s", rawExt.RuleID,
+				opt.String(), kt.Rid)
+			continue
+		}
+		source, ok := mainAccountMap[rawExt.SourceMainAccountID]
+		if !ok {
+			return nil, fmt.Errorf("source main account %s of allocation rule %s not found",
+				rawExt.SourceMainAccountID, rule.ID)
+		}
+		shares, ok := shareCache[rule.ID]
+		if !ok {
+			shares, err = allocator.ListShares(kt, rule, opt.BillYear, opt.BillMonth, mainAccountMap)
+			if err != nil {
+				logs.Errorf("fail to list shares of allocation rule %s, err: %v, rid: %s", rule.ID, err, kt.Rid)
+				return nil, err
+			}
+			shareCache[rule.ID] = shares
+		}
+		if len(shares) == 0 {
+			logs.Warnf("no allocation target of rule %s, skip, opt: %s, rid: %s", rule.ID, opt.String(), kt.Rid)
+			continue
+		}
+
+		costs := allocation.SplitCost(raw.BillCost, shares)
+		for i, share := range shares {
+			ext := allocationItemExtension{RuleID: rule.ID, RuleName: rule.Name, RuleType: string(rule.RuleType),
+				SourceMainAccountID: source.ID, Ratio: share.Ratio}
+			item, err := newAllocationBillItem(share.MainAccount, opt, task.VersionID, raw.BillCurrency, costs[i],
+				constant.BillAllocationName, ext)
+			if err != nil {
+				return nil, err
+			}
+			billItems = append(billItems, item)
+		}
+		reverseExt := allocationItemExtension{RuleID: rule.ID, RuleName: rule.Name,
+			RuleType: string(rule.RuleType), SourceMainAccountID: source.ID, Ratio: decimal.NewFromInt(1)}
+		reverse, err := newAllocationBillItem(source, opt, task.VersionID, raw.BillCurrency, raw.BillCost.Neg(),
+			constant.BillAllocationReverseName, reverseExt)
+		if err != nil {
+			return nil, err
+		}
+		billItems = append(billItems, reverse)
+	}
+	return billItems, nil
+}
+
+// GetHcProductCodes hc product code ranges
+func (t AllocationMonthTask) GetHcProductCodes() []string {
+	return []string{constant.BillAllocationName, constant.BillAllocationReverseName}
+}
+
+func newAllocationBillItem(account *protocore.BaseMainAccount, opt *MonthTaskActionOption, versionID int,
+	currency enumor.CurrencyCode, cost decimal.Decimal, productCode string, ext any) (
+	bill.BillItemCreateReq[json.RawMessage], error) {
+
+	extBytes, err := json.Marshal(ext)
+	if err != nil {
+		return bill.BillItemCreateReq[json.RawMessage]{}, fmt.Errorf("marshal allocation extension failed, err: %v",
+			err)
+	}
+	return newMonthBillItem(account, opt, versionID, currency, cost, productCode, extBytes), nil
+}
+
+// newMonthBillItem 生成指定二级账号的月度账单明细
+func newMonthBillItem(account *protocore.BaseMainAccount, opt *MonthTaskActionOption, versionID int,
+	currency enumor.CurrencyCode, cost decimal.Decimal, productCode string,
+	extension []byte) bill.BillItemCreateReq[json.RawMessage] {
+
+	return bill.BillItemCreateReq[json.RawMessage]{
+		RootAccountID: opt.RootAccountID,
+		MainAccountID: account.ID,
+		Vendor:        opt.Vendor,
+		ProductID:     account.OpProductID,
+		BkBizID:       account.BkBizID,
+		BillYear:      opt.BillYear,
+		BillMonth:     opt.BillMonth,
+		BillDay:       enumor.MonthTaskSpecialBillDay,
+		VersionID:     versionID,
+		Currency:      currency,
+		Cost:          cost,
+		HcProductCode: productCode,
+		HcProductName: productCode,
+		Extension:     cvt.ValToPtr[json.RawMessage](extension),
+	}
+}
+
+// getCommonExpenseRule 公共费用分摊规则，未配置时返回nil，按原有费用占比方式分摊
+func (o MonthTaskActionOption) getCommonExpenseRule() (*billcore.AllocationRule, error) {
+	ruleJson := o.Extension[constant.BillCommonExpenseAllocationRuleKey]
+	if len(ruleJson) == 0 {
+		return nil, nil
+	}
+	rule := new(billcore.AllocationRule)
+	if err := json.Unmarshal([]byte(ruleJson), rule); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal common expense allocation rule: %w", err)
+	}
+	return rule, nil
+}
+
+// allocateByRule 按分摊规则计算公共费用的分摊目标及金额
+func allocateByRule(kt *kit.Kit, opt *MonthTaskActionOption, rule *billcore.AllocationRule,
+	mainAccountMap map[string]*protocore.BaseMainAccount, cost decimal.Decimal) (
+	[]allocation.Share, []decimal.Decimal, error) {
+
+	allocator := allocation.NewAllocator(actcli.GetDataService())
+	shares, err := allocator.ListShares(kt, rule, opt.BillYear, opt.BillMonth, mainAccountMap)
+	if err != nil {
+		logs.Errorf("fail to list shares of common expense allocation rule %s, err: %v, rid: %s", rule.ID, err,
+			kt.Rid)
+		return nil, nil, err
+	}
+	return shares, allocation.SplitCost(cost, shares), nil
+}
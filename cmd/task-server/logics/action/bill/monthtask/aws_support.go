@@ -26,6 +26,7 @@ import (
 	actcli "hcm/cmd/task-server/logics/action/cli"
 	"hcm/pkg/api/core"
 	protocore "hcm/pkg/api/core/account-set"
+	billcore "hcm/pkg/api/core/bill"
 	"hcm/pkg/api/data-service/bill"
 	hcbill "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/criteria/constant"
@@ -129,6 +130,16 @@ func (a AwsSupportMonthTask) Split(kt *kit.Kit, opt *MonthTaskActionOption,
 		return nil, err
 	}
 
+	rule, err := opt.getCommonExpenseRule()
+	if err != nil {
+		logs.Errorf("fail to get common expense allocation rule, err: %v, opt: %#v, rid: %s", err, opt, kt.Rid)
+		return nil, err
+	}
+	if rule != nil {
+		return a.splitCommonExpenseByRule(kt, opt, rule, rootAccount.CloudID, mainAccountMap, rootAsMainAccount,
+			rawItemList)
+	}
+
 	commonItems, err := a.splitCommonExpense(kt, opt, mainAccountMap, rootAsMainAccount, rawItemList)
 	if err != nil {
 		logs.Errorf("fail to split common expense for aws month task split step, err: %v, opt: %#v, rid: %s",
@@ -203,6 +214,71 @@ func (a AwsSupportMonthTask) splitCommonExpense(kt *kit.Kit, opt *MonthTaskActio
 	return billItems, nil
 }
 
+// splitCommonExpenseByRule 按公共费用分摊规则分摊，作为二级账号录入的根账号不参与分摊，并冲平其支出
+func (a AwsSupportMonthTask) splitCommonExpenseByRule(kt *kit.Kit, opt *MonthTaskActionOption,
+	rule *billcore.AllocationRule, rootCloudID string, mainAccountMap map[string]*protocore.BaseMainAccount,
+	rootAsMainAccount *protocore.BaseMainAccount, rawItemList []*bill.RawBillItem) (
+	[]bill.BillItemCreateReq[json.RawMessage], error) {
+
+	batchSum := decimal.Zero
+	currency := enumor.CurrencyUSD
+	for _, item := range rawItemList {
+		batchSum = batchSum.Add(item.BillCost)
+		if len(item.BillCurrency) != 0 {
+			currency = item.BillCurrency
+		}
+	}
+
+	candidates := mainAccountMap
+	if rootAsMainAccount != nil {
+		candidates = make(map[string]*protocore.BaseMainAccount, len(mainAccountMap))
+		for id, account := range mainAccountMap {
+			if id != rootAsMainAccount.ID {
+				candidates[id] = account
+			}
+		}
+	}
+	task, err := getMonthTask(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+	shares, costs, err := allocateByRule(kt, opt, rule, candidates, batchSum)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) == 0 {
+		logs.Warnf("no main account for aws common expense allocation rule %s, opt: %#v, rid: %s", rule.ID, opt,
+			kt.Rid)
+		return nil, nil
+	}
+
+	billItems := make([]bill.BillItemCreateReq[json.RawMessage], 0, len(shares)*2)
+	for i, share := range shares {
+		extJson, err := convAwsBillItemExtension(constant.BillCommonExpenseName, opt, rootCloudID,
+			share.MainAccount.CloudID, currency, costs[i])
+		if err != nil {
+			logs.Errorf("fail to marshal aws common expense extension to json, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		billItems = append(billItems, newMonthBillItem(share.MainAccount, opt, task.VersionID, currency, costs[i],
+			constant.BillCommonExpenseName, extJson))
+
+		if rootAsMainAccount == nil {
+			continue
+		}
+		reverseCost := costs[i].Neg()
+		reverseExtJson, err := convAwsBillItemExtension(constant.BillCommonExpenseReverseName, opt, rootCloudID,
+			share.MainAccount.CloudID, currency, reverseCost)
+		if err != nil {
+			logs.Errorf("fail to marshal aws common expense reverse extension to json, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		billItems = append(billItems, newMonthBillItem(rootAsMainAccount, opt, task.VersionID, currency,
+			reverseCost, constant.BillCommonExpenseReverseName, reverseExtJson))
+	}
+	return billItems, nil
+}
+
 // 不包含根账号自身以及用户设定的排除账号的 二级账号汇总信息
 func (a AwsSupportMonthTask) listSummaryMainForSupport(kt *kit.Kit, opt *MonthTaskActionOption,
 	mainAccountMap map[string]*protocore.BaseMainAccount, rootCloudID string) ([]*bill.BillSummaryMain, error) {
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"hcm/cmd/task-server/logics/action/bill/allocation"
 	actcli "hcm/cmd/task-server/logics/action/cli"
 	typesbill "hcm/pkg/adaptor/types/bill"
 	"hcm/pkg/api/core"
@@ -168,15 +169,13 @@ func (gcp *GcpSupportMonthTask) Split(kt *kit.Kit, opt *MonthTaskActionOption,
 	if len(rawItemList) <= 0 {
 		return nil, nil
 	}
-	summaryMainList, err := gcp.getSummaryMainListExcluded(kt, opt.RootAccountID, opt.BillYear, opt.BillMonth)
-	if err != nil {
-		return nil, err
-	}
-
-	billItems := make([]dsbill.BillItemCreateReq[json.RawMessage], 0, len(summaryMainList))
 	// 聚合本批次 账单总额，并分摊给每个主账号
 	batchCost := decimal.Zero
+	currency := enumor.CurrencyUSD
 	for _, item := range rawItemList {
+		if len(item.BillCurrency) != 0 {
+			currency = item.BillCurrency
+		}
 		// 不计算赠金的支出
 		cost := item.BillCost
 		gcpRaw := billcore.GcpRawBillItem{}
@@ -193,6 +192,21 @@ func (gcp *GcpSupportMonthTask) Split(kt *kit.Kit, opt *MonthTaskActionOption,
 		}
 		batchCost = batchCost.Add(cost)
 	}
+
+	rule, err := opt.getCommonExpenseRule()
+	if err != nil {
+		logs.Errorf("fail to get common expense allocation rule, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	if rule != nil {
+		return gcp.splitByRule(kt, opt, rule, currency, batchCost)
+	}
+
+	summaryMainList, err := gcp.getSummaryMainListExcluded(kt, opt.RootAccountID, opt.BillYear, opt.BillMonth)
+	if err != nil {
+		return nil, err
+	}
+	billItems := make([]dsbill.BillItemCreateReq[json.RawMessage], 0, len(summaryMainList))
 	// 按比例分摊给各个二级账号
 	summaryTotal := decimal.Zero
 	for _, summaryMain := range summaryMainList {
@@ -222,6 +236,36 @@ func (gcp *GcpSupportMonthTask) Split(kt *kit.Kit, opt *MonthTaskActionOption,
 	return billItems, nil
 }
 
+// splitByRule 按公共费用分摊规则分摊
+func (gcp *GcpSupportMonthTask) splitByRule(kt *kit.Kit, opt *MonthTaskActionOption, rule *billcore.AllocationRule,
+	currency enumor.CurrencyCode, batchCost decimal.Decimal) ([]dsbill.BillItemCreateReq[json.RawMessage], error) {
+
+	mainAccountMap, err := allocation.NewAllocator(actcli.GetDataService()).ListMainAccounts(kt, opt.RootAccountID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := getMonthTask(kt, opt)
+	if err != nil {
+		return nil, err
+	}
+	shares, costs, err := allocateByRule(kt, opt, rule, mainAccountMap, batchCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) == 0 {
+		logs.Warnf("no main account for gcp common expense allocation rule %s, opt: %s, rid: %s", rule.ID,
+			opt.String(), kt.Rid)
+		return nil, nil
+	}
+
+	billItems := make([]dsbill.BillItemCreateReq[json.RawMessage], 0, len(shares))
+	for i, share := range shares {
+		billItems = append(billItems, newMonthBillItem(share.MainAccount, opt, task.VersionID, currency, costs[i],
+			constant.BillCommonExpenseName, []byte("{}")))
+	}
+	return billItems, nil
+}
+
 func (gcp *GcpSupportMonthTask) getSummaryMainListExcluded(kt *kit.Kit, rootAccountID string, billYear, billMonth int) (
 	[]*dsbill.BillSummaryMain, error) {
 
@@ -30,6 +30,9 @@ import (
 
 // GetRunner return month task vendor runner
 func GetRunner(vendor enumor.Vendor, taskType enumor.MonthTaskType) (MonthTaskRunner, error) {
+	if taskType == enumor.BillAllocationMonthTask {
+		return &AllocationMonthTask{}, nil
+	}
 	switch vendor {
 	case enumor.Gcp:
 		return newGcpRunner(taskType)
@@ -24,11 +24,13 @@ import (
 	dataservice "hcm/pkg/client/data-service"
 	hcservice "hcm/pkg/client/hc-service"
 	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/objectstore"
 )
 
 var (
-	cliSet *client.ClientSet
-	daoSet dao.Set
+	cliSet  *client.ClientSet
+	daoSet  dao.Set
+	storage objectstore.Storage
 )
 
 // SetClientSet set client set.
@@ -60,3 +62,13 @@ func SetDaoSet(cli dao.Set) {
 func GetDaoSet() dao.Set {
 	return daoSet
 }
+
+// SetObjectStore set object store.
+func SetObjectStore(s objectstore.Storage) {
+	storage = s
+}
+
+// GetObjectStore get object store, return nil if object store is not configured.
+func GetObjectStore() objectstore.Storage {
+	return storage
+}
@@ -20,6 +20,7 @@
 package logicsaction
 
 import (
+	actionbillarchive "hcm/cmd/task-server/logics/action/bill/archive"
 	actionbilldailypull "hcm/cmd/task-server/logics/action/bill/dailypull"
 	actionbillsplit "hcm/cmd/task-server/logics/action/bill/dailysplit"
 	actiondailysummary "hcm/cmd/task-server/logics/action/bill/dailysummary"
@@ -33,16 +34,20 @@ import (
 	actionlb "hcm/cmd/task-server/logics/action/load-balancer"
 	actionsg "hcm/cmd/task-server/logics/action/security-group"
 	actionsubnet "hcm/cmd/task-server/logics/action/subnet"
+	actionvpc "hcm/cmd/task-server/logics/action/vpc"
+	actionwebhook "hcm/cmd/task-server/logics/action/webhook"
 	actionflow "hcm/cmd/task-server/logics/flow"
 	"hcm/pkg/async/action"
 	"hcm/pkg/client"
 	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/objectstore"
 )
 
 // Init init action.
-func Init(cli *client.ClientSet, dao dao.Set) {
+func Init(cli *client.ClientSet, dao dao.Set, storage objectstore.Storage) {
 	actcli.SetClientSet(cli)
 	actcli.SetDaoSet(dao)
+	actcli.SetObjectStore(storage)
 
 	register()
 }
@@ -58,8 +63,10 @@ func register() {
 	action.RegisterAction(actionfirewall.DeleteAction{})
 
 	action.RegisterAction(actionsubnet.DeleteAction{})
+	action.RegisterAction(actionvpc.DeleteAction{})
 	action.RegisterAction(actionsg.DeleteSgAction{})
 	action.RegisterAction(actionsg.CreateHuaweiSGRuleAction{})
+	action.RegisterAction(actionsg.LintSGAction{})
 	action.RegisterAction(actioneip.DeleteEIPAction{})
 
 	action.RegisterAction(actionlb.AddTargetToGroupAction{})
@@ -78,7 +85,11 @@ func register() {
 	action.RegisterAction(actionmainsummary.MainAccountSummaryAction{})
 	action.RegisterAction(actionrootsummary.RootAccountSummaryAction{})
 	action.RegisterAction(actionmonthtask.MonthTaskAction{})
+	action.RegisterAction(actionbillarchive.ArchiveAction{})
+	action.RegisterAction(actionbillarchive.RestoreAction{})
 
 	action.RegisterAction(actionlb.DeleteURLRuleAction{})
 	action.RegisterAction(actionlb.DeleteListenerAction{})
+
+	action.RegisterAction(actionwebhook.DeliverAction{})
 }
@@ -0,0 +1,80 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionsg
+
+import (
+	logicssg "hcm/cmd/cloud-server/logics/security-group"
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+// LintSGAction security group lint action
+type LintSGAction struct {
+}
+
+// LintSGOption ...
+type LintSGOption struct {
+	Vendor    enumor.Vendor `json:"vendor" validate:"required"`
+	AccountID string        `json:"account_id" validate:"required"`
+}
+
+// Validate ...
+func (opt *LintSGOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// LintSGResult ...
+type LintSGResult struct {
+	FindingCount int `json:"finding_count"`
+}
+
+// ParameterNew returns parameter of
+func (s LintSGAction) ParameterNew() (params any) {
+	return new(LintSGOption)
+}
+
+// Name ActionLintSecurityGroup
+func (s LintSGAction) Name() enumor.ActionName {
+	return enumor.ActionLintSecurityGroup
+}
+
+// Run 检查账号下安全组规则风险，并替换该账号的检查结果
+func (s LintSGAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	opt, ok := params.(*LintSGOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	count, err := logicssg.NewSecurityGroup(actcli.GetClientSet()).LintAccount(kt.Kit(), opt.Vendor, opt.AccountID)
+	if err != nil {
+		logs.Errorf("lint security group failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return &LintSGResult{FindingCount: count}, nil
+}
@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionvpc vpc action
+package actionvpc
+
+import (
+	"fmt"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/logs"
+)
+
+var _ action.Action = new(DeleteAction)
+var _ action.ParameterAction = new(DeleteAction)
+
+// DeleteAction define delete vpc action.
+type DeleteAction struct{}
+
+// DeleteVpcOption 删除VPC选项
+type DeleteVpcOption struct {
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	ID     string        `json:"id" validate:"required"`
+}
+
+// Validate DeleteVpcOption.
+func (opt DeleteVpcOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// ParameterNew return delete params.
+func (act DeleteAction) ParameterNew() (params interface{}) {
+	return new(DeleteVpcOption)
+}
+
+// Name ...
+func (act DeleteAction) Name() enumor.ActionName {
+	return enumor.ActionDeleteVpc
+}
+
+// Run ...
+func (act DeleteAction) Run(kt run.ExecuteKit, params interface{}) (interface{}, error) {
+	opt, ok := params.(*DeleteVpcOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	ctx, header := kt.Kit().Ctx, kt.Kit().Header()
+	switch opt.Vendor {
+	case enumor.TCloud:
+		err = actcli.GetHCService().TCloud.Vpc.Delete(ctx, header, opt.ID)
+	case enumor.Aws:
+		err = actcli.GetHCService().Aws.Vpc.Delete(ctx, header, opt.ID)
+	case enumor.Gcp:
+		err = actcli.GetHCService().Gcp.Vpc.Delete(ctx, header, opt.ID)
+	case enumor.Azure:
+		err = actcli.GetHCService().Azure.Vpc.Delete(ctx, header, opt.ID)
+	case enumor.HuaWei:
+		err = actcli.GetHCService().HuaWei.Vpc.Delete(ctx, header, opt.ID)
+	default:
+		return nil, fmt.Errorf("vendor: %s not support", opt.Vendor)
+	}
+	if err != nil {
+		logs.Errorf("delete vpc failed, err: %v, opt: %+v, rid: %s", err, opt, kt.Kit().Rid)
+		return nil, err
+	}
+
+	return nil, nil
+}
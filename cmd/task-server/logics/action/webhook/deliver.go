@@ -0,0 +1,248 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package actionwebhook webhook事件投递任务
+package actionwebhook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	actcli "hcm/cmd/task-server/logics/action/cli"
+	"hcm/pkg/api/core"
+	corewebhook "hcm/pkg/api/core/webhook"
+	protowebhook "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/async/action"
+	"hcm/pkg/async/action/run"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/converter"
+)
+
+const (
+	// deliverTimeout 单次投递的超时时间
+	deliverTimeout = 10 * time.Second
+	// maxResponseLen 投递记录中保存的响应摘要最大长度
+	maxResponseLen = 1024
+	// maxDiscardLen 投递响应读取丢弃的最大长度，用于复用连接
+	maxDiscardLen = 4096
+)
+
+// deliverClient 投递使用的http客户端，不使用代理并在建立连接时校验解析后的地址，拒绝投递到内部地址，
+// 避免DNS重绑定绕过订阅时的地址校验；不跟随重定向，防止通过重定向访问内部地址
+var deliverClient = &http.Client{
+	Timeout: deliverTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: deliverTimeout,
+			Control: denyInternalAddress,
+		}).DialContext,
+		TLSHandshakeTimeout: deliverTimeout,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// denyInternalAddress 拒绝连接内部地址，address 为DNS解析后实际连接的地址
+func denyInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("webhook receiver address %s is invalid", address)
+	}
+
+	if corewebhook.IsInternalIP(ip) {
+		return fmt.Errorf("webhook receiver address %s is an internal address, network: %s", host, network)
+	}
+
+	return nil
+}
+
+var _ action.Action = new(DeliverAction)
+var _ action.ParameterAction = new(DeliverAction)
+var _ action.RollbackAction = new(DeliverAction)
+
+// DeliverAction webhook deliver action
+type DeliverAction struct{}
+
+// DeliverOption webhook deliver option.
+type DeliverOption struct {
+	DeliveryID string `json:"delivery_id" validate:"required"`
+	URL        string `json:"url" validate:"required"`
+	// Headers 投递请求头，包含事件类型及签名，由发布方计算，任务中不保存签名密钥
+	Headers map[string]string `json:"headers" validate:"omitempty"`
+	Payload string            `json:"payload" validate:"required"`
+	// MaxAttempts 最大投递次数，达到后投递记录置为失败
+	MaxAttempts int `json:"max_attempts" validate:"min=1"`
+}
+
+// Validate ...
+func (opt *DeliverOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// DeliverResult ...
+type DeliverResult struct {
+	StatusCode int `json:"status_code"`
+}
+
+// ParameterNew return webhook deliver option.
+func (act DeliverAction) ParameterNew() (params any) {
+	return new(DeliverOption)
+}
+
+// Name return action name
+func (act DeliverAction) Name() enumor.ActionName {
+	return enumor.ActionWebhookDeliver
+}
+
+// Run 投递webhook事件，投递失败时返回错误，由异步任务框架进行重试
+func (act DeliverAction) Run(kt run.ExecuteKit, params any) (any, error) {
+	opt, ok := params.(*DeliverOption)
+	if !ok {
+		return nil, errf.New(errf.InvalidParameter, "params type mismatch")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	attempts, err := getDeliveryAttempts(kt.Kit(), opt.DeliveryID)
+	if err != nil {
+		return nil, err
+	}
+	attempts++
+
+	statusCode, response, deliverErr := post(kt.Kit(), opt)
+
+	state := enumor.WebhookDeliverySuccess
+	if deliverErr != nil {
+		state = enumor.WebhookDeliveryPending
+		if attempts >= opt.MaxAttempts {
+			state = enumor.WebhookDeliveryFailed
+		}
+		response = deliverErr.Error()
+	}
+
+	updateReq := &protowebhook.DeliveryBatchUpdateReq{Deliveries: []protowebhook.DeliveryUpdate{{
+		ID:         opt.DeliveryID,
+		State:      state,
+		Attempts:   attempts,
+		StatusCode: statusCode,
+		Response:   converter.ValToPtr(truncate(response, maxResponseLen)),
+	}}}
+	if err = actcli.GetDataService().Global.Webhook.BatchUpdateDelivery(kt.Kit(), updateReq); err != nil {
+		logs.Errorf("update webhook delivery failed, err: %v, id: %s, rid: %s", err, opt.DeliveryID, kt.Kit().Rid)
+		return nil, err
+	}
+
+	if deliverErr != nil {
+		logs.Errorf("deliver webhook failed, err: %v, delivery: %s, attempts: %d, rid: %s", deliverErr,
+			opt.DeliveryID, attempts, kt.Kit().Rid)
+		return nil, deliverErr
+	}
+
+	return &DeliverResult{StatusCode: statusCode}, nil
+}
+
+// Rollback 投递请求携带投递ID，接收方可据此去重，无需回滚
+func (act DeliverAction) Rollback(kt run.ExecuteKit, params any) error {
+	return nil
+}
+
+func getDeliveryAttempts(kt *kit.Kit, id string) (int, error) {
+	listReq := &core.ListReq{
+		Filter: tools.EqualExpression("id", id),
+		Page:   core.NewDefaultBasePage(),
+		Fields: []string{"id", "attempts"},
+	}
+	result, err := actcli.GetDataService().Global.Webhook.ListDelivery(kt, listReq)
+	if err != nil {
+		logs.Errorf("list webhook delivery failed, err: %v, id: %s, rid: %s", err, id, kt.Rid)
+		return 0, err
+	}
+
+	if len(result.Details) == 0 {
+		return 0, errf.Newf(errf.RecordNotFound, "webhook delivery: %s not found", id)
+	}
+
+	return result.Details[0].Attempts, nil
+}
+
+// post 投递webhook事件，返回状态码及响应摘要，响应内容不返回也不保存，避免通过投递记录读取接收方的响应
+func post(kt *kit.Kit, opt *DeliverOption) (int, string, error) {
+	if err := corewebhook.ValidateURL(opt.URL); err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequestWithContext(kt.Ctx, http.MethodPost, opt.URL, bytes.NewBufferString(opt.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range opt.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := deliverClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDiscardLen))
+
+	summary := statusSummary(resp.StatusCode)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, summary, fmt.Errorf("webhook receiver responded with %s", summary)
+	}
+
+	return resp.StatusCode, summary, nil
+}
+
+// statusSummary 响应状态摘要，如 "200 OK"
+func statusSummary(statusCode int) string {
+	return truncate(fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)), maxResponseLen)
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	return string(runes[:max])
+}
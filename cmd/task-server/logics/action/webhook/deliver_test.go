@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package actionwebhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDenyInternalAddress(t *testing.T) {
+	cases := []struct {
+		address string
+		allowed bool
+	}{
+		{address: "8.8.8.8:443", allowed: true},
+		{address: "[2001:4860:4860::8888]:443", allowed: true},
+		{address: "127.0.0.1:9600", allowed: false},
+		{address: "169.254.169.254:80", allowed: false},
+		{address: "10.0.0.1:2379", allowed: false},
+		{address: "[::1]:80", allowed: false},
+		{address: "invalid", allowed: false},
+	}
+
+	for _, c := range cases {
+		err := denyInternalAddress("tcp4", c.address, nil)
+		if (err == nil) != c.allowed {
+			t.Errorf("address: %s, expect allowed: %v, got err: %v", c.address, c.allowed, err)
+		}
+	}
+}
+
+func TestDeliverClientDenyInternal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// 投递客户端在连接时校验地址，即使绕过了url校验也不能访问内部地址
+	resp, err := deliverClient.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Errorf("deliver client should not connect to internal address: %s", server.URL)
+	}
+}
+
+func TestStatusSummary(t *testing.T) {
+	if summary := statusSummary(http.StatusInternalServerError); summary != "500 Internal Server Error" {
+		t.Errorf("unexpected status summary: %s", summary)
+	}
+}
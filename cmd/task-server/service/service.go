@@ -42,11 +42,13 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/dal/dao"
+	"hcm/pkg/dal/objectstore"
 	"hcm/pkg/handler"
 	"hcm/pkg/logs"
 	"hcm/pkg/metrics"
 	"hcm/pkg/rest"
 	restcli "hcm/pkg/rest/client"
+	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/runtime/shutdown"
 	"hcm/pkg/serviced"
 	"hcm/pkg/tools/ssl"
@@ -90,7 +92,13 @@ func NewService(sd serviced.ServiceDiscover, shutdownWaitTimeSec int) (*Service,
 		return nil, err
 	}
 
-	logicsaction.Init(apiClientSet, dao)
+	// init object store, bill item archive is disabled if not configured
+	objectStore, err := objectstore.GetObjectStore(cc.TaskServer().Objectstore)
+	if err != nil {
+		return nil, err
+	}
+
+	logicsaction.Init(apiClientSet, dao, objectStore)
 	async, err := createAndStartAsync(sd, dao, shutdownWaitTimeSec)
 	if err != nil {
 		return nil, err
@@ -251,3 +259,12 @@ func (s *Service) Healthz(w http.ResponseWriter, _ *http.Request) {
 	rest.WriteResp(w, rest.NewBaseResp(errf.OK, "healthy"))
 	return
 }
+
+// CtlCommands returns the task server specific control tool commands.
+func (s *Service) CtlCommands() []cmd.Cmd {
+	return []cmd.Cmd{
+		cmd.WithGetAsyncStatus(func() interface{} {
+			return s.async.GetConsumer().Status()
+		}),
+	}
+}
@@ -72,6 +72,11 @@ func (s *webService) prepare(opt *options.Option) error {
 	network := cc.WebServer().Network
 	metrics.InitMetrics(net.JoinHostPort(network.BindIP, strconv.Itoa(int(network.Port))))
 
+	// load dynamic config overrides from etcd and keep watching the changes.
+	if err := serviced.WatchConfig(cc.WebServer().Service); err != nil {
+		return fmt.Errorf("watch dynamic config failed, err: %v", err)
+	}
+
 	// new api server discovery client.
 	discOpt := serviced.DiscoveryOption{Services: []cc.Name{cc.CloudServerName, cc.AuthServerName, cc.AccountServerName}}
 	dis, err := serviced.NewDiscovery(cc.WebServer().Service, discOpt)
@@ -89,7 +94,7 @@ func (s *webService) prepare(opt *options.Option) error {
 	s.svc = svc
 
 	// init hcm control tool
-	if err := ctl.LoadCtl(cmd.WithLog()); err != nil {
+	if err := ctl.LoadCtl(append(cmd.WithCircuitBreaker(), cmd.WithLog(), cmd.WithGetConfig())...); err != nil {
 		return fmt.Errorf("load control tool failed, err: %v", err)
 	}
 
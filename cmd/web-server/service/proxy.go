@@ -63,11 +63,16 @@ func newProxy(dis serviced.Discover, cli *http.Client) (*proxy, error) {
 func (p *proxy) Do(req *restful.Request, resp *restful.Response) {
 	r, w := req.Request, resp.ResponseWriter
 
-	p.proxyRequest(req, w)
-
 	rid := r.Header.Get(constant.RidKey)
 	start := time.Now()
 
+	ds, host, err := p.prepareRequest(req)
+	if err != nil {
+		logs.Errorf("prepare request to proxy failed, err: %v, rid: %s", err, rid)
+		fmt.Fprintf(w, errf.NewFromErr(http.StatusNotFound, err).Error())
+		return
+	}
+
 	url := r.URL.Scheme + "://" + r.URL.Host + r.RequestURI
 	proxyReq, err := http.NewRequestWithContext(r.Context(), r.Method, url, r.Body)
 	if err != nil {
@@ -81,14 +86,19 @@ func (p *proxy) Do(req *restful.Request, resp *restful.Response) {
 			proxyReq.Header.Set(k, v[0])
 		}
 	}
+	// 页面请求未经过api-server限流，清除外部传入的限流标记，由后端服务限流
+	proxyReq.Header.Del(constant.RateLimitedByKey)
 
+	proxyStart := time.Now()
 	response, err := p.cli.Do(proxyReq)
 	if err != nil {
+		ds.Report(host, time.Since(proxyStart), 0, err)
 		logs.Errorf("do request[%s url: %s] failed, err: %v, rid: %s", r.Method, url, err, rid)
 		fmt.Fprintf(w, err.Error())
 		return
 	}
 	defer response.Body.Close()
+	ds.Report(host, time.Since(proxyStart), response.StatusCode, nil)
 
 	for k, v := range response.Header {
 		if len(v) > 0 {
@@ -109,38 +119,35 @@ func (p *proxy) Do(req *restful.Request, resp *restful.Response) {
 	return
 }
 
-// proxyRequest get request service by url, discover service and proxy request to target server
-func (p *proxy) proxyRequest(req *restful.Request, w http.ResponseWriter) {
+// prepareRequest get request service by url, discover service and set the target server to request url, returns
+// the discovery and the target server host.
+func (p *proxy) prepareRequest(req *restful.Request) (*discovery.APIDiscovery, string, error) {
 	var service cc.Name
 
 	// path format: /api/{api_version}/{service}/other
 	paths := strings.Split(req.Request.URL.Path, "/")
-	if len(paths) > 3 {
-		servicePath := paths[3]
-		switch servicePath {
-		case "cloud":
-			service = cc.CloudServerName
-		case "account":
-			service = cc.AccountServerName
-		}
-	} else {
-		logs.Errorf("received url path length not conform to the regulations, path: %s", req.Request.URL.Path)
-		fmt.Fprintf(w, errf.New(http.StatusNotFound, "Not Found").Error())
-		return
+	if len(paths) <= 3 {
+		return nil, "", fmt.Errorf("received url path length not conform to the regulations, path: %s",
+			req.Request.URL.Path)
+	}
+
+	switch paths[3] {
+	case "cloud":
+		service = cc.CloudServerName
+	case "account":
+		service = cc.AccountServerName
 	}
 
-	discovery, exists := p.discovery[service]
+	ds, exists := p.discovery[service]
 	if !exists {
-		logs.Errorf("received request service %s is not supported, path: %s", service, req.Request.URL.Path)
-		fmt.Fprintf(w, errf.New(http.StatusNotFound, "Service Not Supported").Error())
-		return
+		return nil, "", fmt.Errorf("received request service %s is not supported, path: %s", service,
+			req.Request.URL.Path)
 	}
 
-	servers, err := discovery.GetServers()
+	servers, err := ds.GetServers()
 	if err != nil {
-		logs.Errorf("received request service %s has no servers, path: %s", service, req.Request.URL.Path)
-		fmt.Fprintf(w, errf.New(http.StatusNotFound, "Servers Not Found").Error())
-		return
+		return nil, "", fmt.Errorf("received request service %s has no servers, path: %s", service,
+			req.Request.URL.Path)
 	}
 
 	if strings.HasPrefix(servers[0], "https://") {
@@ -150,4 +157,6 @@ func (p *proxy) proxyRequest(req *restful.Request, w http.ResponseWriter) {
 		req.Request.URL.Host = servers[0][7:]
 		req.Request.URL.Scheme = "http"
 	}
+
+	return ds, servers[0], nil
 }
@@ -792,3 +792,186 @@ func (a *Aws) GetRootSpTotalUsage(kt *kit.Kit, billInfo *billcore.AwsRootBillCon
 
 	return ret, nil
 }
+
+const (
+	// AwsRootMonthTotalSQL 按币种汇总一级账号月度账单，与账单拉取一致使用net unblended cost
+	AwsRootMonthTotalSQL = `SELECT
+			line_item_currency_code AS currency,
+			sum(line_item_net_unblended_cost) AS cost
+			FROM %s.%s
+			WHERE bill_payer_account_id = '%s' AND year = '%d' AND month = '%d'
+			GROUP BY line_item_currency_code`
+)
+
+// GetRootAccountMonthTotal get month bill total of root account group by currency
+func (a *Aws) GetRootAccountMonthTotal(kt *kit.Kit, billInfo *billcore.AwsRootBillConfig,
+	opt *typesBill.AwsRootMonthTotalOption) ([]typesBill.CurrencyTotal, error) {
+
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "bill info is required")
+	}
+	if opt == nil {
+		return nil, errf.Newf(errf.InvalidParameter, "opt for get month total is required")
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	sql := fmt.Sprintf(AwsRootMonthTotalSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.PayerCloudID,
+		opt.Year, opt.Month)
+	cloudList, err := a.GetRootAccountAwsAthenaQuery(kt, sql, billInfo)
+	if err != nil {
+		logs.Errorf("fail to call aws athena query for get root month total, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	totals := make([]typesBill.CurrencyTotal, 0, len(cloudList))
+	for _, record := range cloudList {
+		cost, err := decimal.NewFromString(record["cost"])
+		if err != nil {
+			logs.Errorf("fail to parse month total cost %s, err: %v, rid: %s", record["cost"], err, kt.Rid)
+			return nil, err
+		}
+		totals = append(totals, typesBill.CurrencyTotal{
+			Currency: enumor.CurrencyCode(record["currency"]),
+			Cost:     cost,
+		})
+	}
+	return totals, nil
+}
+
+const (
+	// AwsSpUtilizationSQL 按 savings plan 汇总当月承诺金额及已使用的承诺金额
+	AwsSpUtilizationSQL = `SELECT
+			savings_plan_savings_plan_a_r_n AS sp_arn,
+			sum(savings_plan_recurring_commitment_for_billing_period) +
+				sum(savings_plan_amortized_upfront_commitment_for_billing_period) AS commitment,
+			sum(savings_plan_used_commitment) AS used_commitment
+			FROM %s.%s
+			WHERE line_item_line_item_type = 'SavingsPlanRecurringFee'
+			AND bill_payer_account_id = '%s' AND year = '%d' AND month = '%d'`
+	// AwsSpCoverageSQL 按使用账号、地域、实例规格汇总EC2实例的覆盖费用及按需费用
+	AwsSpCoverageSQL = `SELECT
+			line_item_usage_account_id AS usage_account_id,
+			product_region AS region,
+			product_instance_type AS instance_type,
+			sum(CASE WHEN line_item_line_item_type = 'SavingsPlanCoveredUsage'
+				THEN line_item_unblended_cost ELSE 0 END) AS covered_cost,
+			sum(CASE WHEN line_item_line_item_type = 'SavingsPlanCoveredUsage'
+				THEN savings_plan_savings_plan_effective_cost ELSE 0 END) AS sp_effective_cost,
+			sum(CASE WHEN line_item_line_item_type = 'Usage' THEN line_item_unblended_cost ELSE 0 END) AS on_demand_cost
+			FROM %s.%s
+			WHERE line_item_product_code = 'AmazonEC2' AND line_item_usage_type LIKE '%%BoxUsage%%'
+			AND line_item_line_item_type IN ('Usage', 'SavingsPlanCoveredUsage')
+			AND bill_payer_account_id = '%s' AND year = '%d' AND month = '%d'
+			GROUP BY line_item_usage_account_id, product_region, product_instance_type`
+	// AwsSpDailyOnDemandSQL 按天汇总未被覆盖的EC2实例按需费用
+	AwsSpDailyOnDemandSQL = `SELECT
+			date_format(line_item_usage_start_date, '%%Y-%%m-%%d') AS usage_date,
+			sum(line_item_unblended_cost) AS on_demand_cost
+			FROM %s.%s
+			WHERE line_item_product_code = 'AmazonEC2' AND line_item_usage_type LIKE '%%BoxUsage%%'
+			AND line_item_line_item_type = 'Usage'
+			AND bill_payer_account_id = '%s' AND year = '%d' AND month = '%d'
+			GROUP BY date_format(line_item_usage_start_date, '%%Y-%%m-%%d')`
+)
+
+// GetRootAccountSpAnalysis get savings plans utilization and ec2 coverage of root account
+func (a *Aws) GetRootAccountSpAnalysis(kt *kit.Kit, billInfo *billcore.AwsRootBillConfig,
+	opt *typesBill.AwsRootSpAnalysisOption) (*typesBill.AwsSpAnalysisResult, error) {
+
+	if billInfo == nil {
+		return nil, errf.Newf(errf.RecordNotFound, "bill info is required")
+	}
+	if opt == nil {
+		return nil, errf.Newf(errf.InvalidParameter, "opt for get sp analysis is required")
+	}
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	result := &typesBill.AwsSpAnalysisResult{}
+
+	sql := fmt.Sprintf(AwsSpUtilizationSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.PayerCloudID,
+		opt.Year, opt.Month)
+	if len(opt.SpArnPrefix) > 0 {
+		sql += fmt.Sprintf(" AND savings_plan_savings_plan_a_r_n LIKE '%s%%'", opt.SpArnPrefix)
+	}
+	sql += " GROUP BY savings_plan_savings_plan_a_r_n"
+	records, err := a.GetRootAccountAwsAthenaQuery(kt, sql, billInfo)
+	if err != nil {
+		logs.Errorf("fail to call aws athena query for sp utilization, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	for _, record := range records {
+		costs, err := parseAthenaDecimals(record, "commitment", "used_commitment")
+		if err != nil {
+			logs.Errorf("fail to parse sp utilization, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		result.Utilizations = append(result.Utilizations, typesBill.AwsSpUtilization{
+			SpArn:          record["sp_arn"],
+			Commitment:     costs[0],
+			UsedCommitment: costs[1],
+		})
+	}
+
+	sql = fmt.Sprintf(AwsSpCoverageSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.PayerCloudID,
+		opt.Year, opt.Month)
+	if records, err = a.GetRootAccountAwsAthenaQuery(kt, sql, billInfo); err != nil {
+		logs.Errorf("fail to call aws athena query for sp coverage, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	for _, record := range records {
+		costs, err := parseAthenaDecimals(record, "covered_cost", "sp_effective_cost", "on_demand_cost")
+		if err != nil {
+			logs.Errorf("fail to parse sp coverage, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		result.Coverages = append(result.Coverages, typesBill.AwsSpCoverage{
+			UsageAccountID:  record["usage_account_id"],
+			Region:          record["region"],
+			InstanceType:    record["instance_type"],
+			CoveredCost:     costs[0],
+			SpEffectiveCost: costs[1],
+			OnDemandCost:    costs[2],
+		})
+	}
+
+	sql = fmt.Sprintf(AwsSpDailyOnDemandSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.PayerCloudID,
+		opt.Year, opt.Month)
+	if records, err = a.GetRootAccountAwsAthenaQuery(kt, sql, billInfo); err != nil {
+		logs.Errorf("fail to call aws athena query for daily on demand cost, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	for _, record := range records {
+		costs, err := parseAthenaDecimals(record, "on_demand_cost")
+		if err != nil {
+			logs.Errorf("fail to parse daily on demand cost, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+		result.DailyOnDemand = append(result.DailyOnDemand, typesBill.AwsSpDailyOnDemand{
+			Date:         record["usage_date"],
+			OnDemandCost: costs[0],
+		})
+	}
+
+	return result, nil
+}
+
+// parseAthenaDecimals 解析athena查询结果中的金额字段，空值视为0
+func parseAthenaDecimals(record map[string]string, keys ...string) ([]decimal.Decimal, error) {
+	values := make([]decimal.Decimal, 0, len(keys))
+	for _, key := range keys {
+		if len(record[key]) == 0 {
+			values = append(values, decimal.Zero)
+			continue
+		}
+		value, err := decimal.NewFromString(record[key])
+		if err != nil {
+			return nil, fmt.Errorf("parse %s value %s failed, err: %v", key, record[key], err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -20,7 +20,11 @@
 package aws
 
 import (
+	"net/http"
+
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/tracing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -48,17 +52,22 @@ const (
 
 type clientSet struct {
 	credentials *credentials.Credentials
+	// httpClient 记录接口调用 span 的 http client
+	httpClient *http.Client
 }
 
 func newClientSet(secret *types.BaseSecret) *clientSet {
-	return &clientSet{credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, "")}
+	return &clientSet{
+		credentials: credentials.NewStaticCredentials(secret.CloudSecretID, secret.CloudSecretKey, ""),
+		httpClient:  tracing.NewHTTPClient(enumor.Aws),
+	}
 }
 
 func (c *clientSet) ec2Client(region string) (*ec2.EC2, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -82,7 +91,7 @@ func (c *clientSet) stsClient() (*sts.STS, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -102,7 +111,7 @@ func (c *clientSet) athenaClient(region string) (*athena.Athena, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -126,7 +135,7 @@ func (c *clientSet) organizations() (*organizations.Organizations, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -146,7 +155,7 @@ func (c *clientSet) s3Client(region string) (*s3.S3, error) {
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -170,7 +179,7 @@ func (c *clientSet) costAndUsageReportClient(region string) (*curservice.Costand
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
@@ -194,7 +203,7 @@ func (c *clientSet) cloudFormationClient(region string) (*cloudformation.CloudFo
 	cfg := &aws.Config{
 		Credentials: c.credentials,
 		DisableSSL:  nil,
-		HTTPClient:  nil,
+		HTTPClient:  c.httpClient,
 		LogLevel:    nil,
 		Logger:      nil,
 		MaxRetries:  nil,
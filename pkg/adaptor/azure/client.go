@@ -23,9 +23,13 @@ import (
 	"fmt"
 
 	"hcm/pkg/adaptor/types"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tracing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
@@ -37,10 +41,17 @@ import (
 
 type clientSet struct {
 	credential *types.AzureCredential
+	// armOption 记录接口调用 span 的 arm 客户端配置
+	armOption *arm.ClientOptions
 }
 
 func newClientSet(credential *types.AzureCredential) *clientSet {
-	return &clientSet{credential}
+	return &clientSet{
+		credential: credential,
+		armOption: &arm.ClientOptions{
+			ClientOptions: policy.ClientOptions{Transport: tracing.NewHTTPClient(enumor.Azure)},
+		},
+	}
 }
 
 // graphServiceClient ...
@@ -65,7 +76,7 @@ func (c *clientSet) subscriptionClient() (*armsubscription.SubscriptionsClient,
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscription.NewSubscriptionsClient(credential, nil)
+	client, err := armsubscription.NewSubscriptionsClient(credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure subscription client failed, err: %v", err)
 	}
@@ -83,7 +94,7 @@ func (c *clientSet) vpcClient() (*armnetwork.VirtualNetworksClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewVirtualNetworksClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -100,7 +111,7 @@ func (c *clientSet) usageClient() (*armnetwork.UsagesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewUsagesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure usage client failed, err: %v", err)
 	}
@@ -117,7 +128,7 @@ func (c *clientSet) subnetClient() (*armnetwork.SubnetsClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSubnetsClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -130,7 +141,7 @@ func (c *clientSet) diskClient() (*armcompute.DisksClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewDisksClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 }
 
 // imageClient ...
@@ -140,7 +151,7 @@ func (c *clientSet) imageClient() (*armcompute.VirtualMachineImagesClient, error
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, nil)
+	return armcompute.NewVirtualMachineImagesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 }
 
 // newClientSecretCredential ...
@@ -158,7 +169,7 @@ func (c *clientSet) securityGroupClient() (*armnetwork.SecurityGroupsClient, err
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewSecurityGroupsClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure security group client failed, err: %v", err)
 	}
@@ -173,7 +184,7 @@ func (c *clientSet) virtualMachineClient() (*armcompute.VirtualMachinesClient, e
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachinesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machines client failed, err: %v", err)
 	}
@@ -188,7 +199,7 @@ func (c *clientSet) virtualMachineSizeClient() (*armcompute.VirtualMachineSizesC
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewVirtualMachineSizesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure virtual machine sizes client failed, err: %v", err)
 	}
@@ -203,7 +214,7 @@ func (c *clientSet) clientFactory() (*armcompute.ClientFactory, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armcompute.NewClientFactory(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armcompute.NewClientFactory(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure client factory failed, err: %v", err)
 	}
@@ -218,7 +229,7 @@ func (c *clientSet) resourceGroupsClient() (*armresources.ResourceGroupsClient,
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armresources.NewResourceGroupsClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init resourceGroups client failed, err: %v", err)
 	}
@@ -233,7 +244,7 @@ func (c *clientSet) regionClient() (*armsubscriptions.Client, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armsubscriptions.NewClient(credential, nil)
+	client, err := armsubscriptions.NewClient(credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init region client failed, err: %v", err)
 	}
@@ -251,7 +262,7 @@ func (c *clientSet) routeTableClient() (*armnetwork.RouteTablesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRouteTablesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -268,7 +279,7 @@ func (c *clientSet) routeClient() (*armnetwork.RoutesClient, error) {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewRoutesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure vpc client failed, err: %v", err)
 	}
@@ -281,7 +292,7 @@ func (c *clientSet) publicIPAddressesClient() (*armnetwork.PublicIPAddressesClie
 	if err != nil {
 		return nil, fmt.Errorf("init azure credential failed, err: %v", err)
 	}
-	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewPublicIPAddressesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init azure public ip addresses client failed, err: %v", err)
 	}
@@ -295,7 +306,7 @@ func (c *clientSet) networkInterfaceClient() (*armnetwork.InterfacesClient, erro
 		return nil, fmt.Errorf("init network interface credential failed, err: %v", err)
 	}
 
-	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, nil)
+	client, err := armnetwork.NewInterfacesClient(c.credential.CloudSubscriptionID, credential, c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init network interface client failed, err: %v", err)
 	}
@@ -311,7 +322,7 @@ func (c *clientSet) networkInterfaceIPConfigClient() (*armnetwork.InterfaceIPCon
 	}
 
 	client, err := armnetwork.NewInterfaceIPConfigurationsClient(c.credential.CloudSubscriptionID, credential,
-		nil)
+		c.armOption)
 	if err != nil {
 		return nil, fmt.Errorf("init network interface ipconfig client failed, err: %v", err)
 	}
@@ -28,11 +28,13 @@ import (
 	billcore "hcm/pkg/api/core/bill"
 	"hcm/pkg/api/core/cloud"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/math"
 
 	"cloud.google.com/go/bigquery"
+	"github.com/shopspring/decimal"
 	"google.golang.org/api/iterator"
 )
 
@@ -400,3 +402,43 @@ func (g *Gcp) parseRootCreditCondition(opt *typesBill.GcpBillListOption) (string
 
 	return "", nil
 }
+
+// RootAccountMonthTotalSQL 按币种汇总一级账号月度账单，费用包含全部赠金抵扣，金额单位为百万分之一。
+// 与本平台汇总口径一致：日账单拉取时非PROMOTION赠金计入total_cost，PROMOTION赠金在分账时拆为独立的赠金明细
+
+const RootAccountMonthTotalSQL = `SELECT currency,
+  SUM(CAST(cost * 1000000 AS int64)) +
+  SUM(IFNULL((SELECT SUM(CAST(c.amount * 1000000 AS int64)) FROM UNNEST(credits) c), 0)) AS total_micros
+  FROM %s.%s
+  WHERE invoice.month = '%s'
+  GROUP BY currency`
+
+// GetRootAccountMonthTotal get month bill total of root account group by currency
+func (g *Gcp) GetRootAccountMonthTotal(kt *kit.Kit, opt *typesBill.GcpRootMonthTotalOption,
+	billInfo *billcore.RootAccountBillConfig[billcore.GcpBillConfigExtension]) ([]typesBill.CurrencyTotal, error) {
+
+	if err := opt.Validate(); err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(RootAccountMonthTotalSQL, billInfo.CloudDatabaseName, billInfo.CloudTableName, opt.Month)
+	list, _, err := g.GetBigQuery(kt, sql)
+	if err != nil {
+		logs.Errorf("gcp get root account month total failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	totals := make([]typesBill.CurrencyTotal, 0, len(list))
+	for _, row := range list {
+		currency, _ := row["currency"].(string)
+		micros, ok := row["total_micros"].(int64)
+		if !ok {
+			return nil, fmt.Errorf("invalid gcp month total value: %v", row["total_micros"])
+		}
+		totals = append(totals, typesBill.CurrencyTotal{
+			Currency: enumor.CurrencyCode(currency),
+			Cost:     decimal.New(micros, -6),
+		})
+	}
+	return totals, nil
+}
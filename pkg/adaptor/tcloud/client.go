@@ -20,11 +20,14 @@
 package tcloud
 
 import (
+	"net/http"
 	"time"
 
 	"hcm/pkg/adaptor/types"
 	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/tools/rand"
+	"hcm/pkg/tracing"
 
 	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
 	cam "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cam/v20190116"
@@ -58,12 +61,15 @@ type ClientSet interface {
 type clientSet struct {
 	credential *common.Credential
 	profile    *profile.ClientProfile
+	// transport 记录接口调用 span 的 http transport
+	transport http.RoundTripper
 }
 
 func newClientSet(s *types.BaseSecret, profile *profile.ClientProfile) ClientSet {
 	return &clientSet{
 		credential: common.NewCredential(s.CloudSecretID, s.CloudSecretKey),
 		profile:    profile,
+		transport:  tracing.NewTransport(enumor.TCloud),
 	}
 }
 
@@ -89,6 +95,8 @@ func (c *clientSet) CamServiceClient(region string) (*cam.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
 
@@ -99,6 +107,8 @@ func (c *clientSet) CvmClient(region string) (*cvm.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
 
@@ -109,6 +119,8 @@ func (c *clientSet) CbsClient(region string) (*cbs.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
 
@@ -119,6 +131,8 @@ func (c *clientSet) VpcClient(region string) (*vpc.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
 
@@ -129,6 +143,8 @@ func (c *clientSet) BillClient() (*billing.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
 
@@ -139,6 +155,8 @@ func (c *clientSet) ClbClient(region string) (*clb.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
 
@@ -149,5 +167,7 @@ func (c *clientSet) CertClient() (*ssl.Client, error) {
 		return nil, err
 	}
 
+	client.WithHttpTransport(c.transport)
+
 	return client, nil
 }
@@ -124,6 +124,10 @@ type TCloud interface {
 	CreateCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (*poller.BaseDoneResult, error)
 	InquiryPriceCvm(kt *kit.Kit, opt *cvm.TCloudCreateOption) (
 		*cvm.InquiryPriceResult, error)
+	ListReservedInstance(kt *kit.Kit, opt *cvm.TCloudListReservedInstanceOption) (
+		[]cvm.TCloudReservedInstance, error)
+	ListReservedInstanceOffering(kt *kit.Kit, opt *cvm.TCloudListReservedInstanceOfferingOption) (
+		[]cvm.TCloudReservedInstanceOffering, error)
 	ListPoliciesGrantingServiceAccess(kt *kit.Kit, opt *account.TCloudListPolicyOption) (
 		[]*v20190116.ListGrantServiceAccessNode, error)
 	ListArgsTplAddress(kt *kit.Kit, opt *typeargstpl.TCloudListOption) (
@@ -0,0 +1,135 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tcloud
+
+import (
+	"fmt"
+	"strconv"
+
+	"hcm/pkg/adaptor/types/core"
+	typecvm "hcm/pkg/adaptor/types/cvm"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	cvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// ListReservedInstance list all reserved instances of region.
+// reference: https://cloud.tencent.com/document/api/213/47532
+func (t *TCloudImpl) ListReservedInstance(kt *kit.Kit, opt *typecvm.TCloudListReservedInstanceOption) (
+	[]typecvm.TCloudReservedInstance, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDescribeReservedInstancesRequest()
+	if len(opt.States) != 0 {
+		req.Filters = []*cvm.Filter{{Name: common.StringPtr("state"), Values: common.StringPtrs(opt.States)}}
+	}
+	req.Limit = common.Int64Ptr(int64(core.TCloudQueryLimit))
+
+	instances := make([]typecvm.TCloudReservedInstance, 0)
+	for offset := int64(0); ; offset += core.TCloudQueryLimit {
+		req.Offset = common.Int64Ptr(offset)
+		resp, err := client.DescribeReservedInstancesWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list tcloud reserved instance failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Response.ReservedInstancesSet {
+			instances = append(instances, typecvm.TCloudReservedInstance{ReservedInstances: one})
+		}
+		if len(resp.Response.ReservedInstancesSet) < core.TCloudQueryLimit {
+			break
+		}
+	}
+
+	return instances, nil
+}
+
+// ListReservedInstanceOffering list reserved instance offerings of zone and instance type.
+// reference: https://cloud.tencent.com/document/api/213/47530
+func (t *TCloudImpl) ListReservedInstanceOffering(kt *kit.Kit, opt *typecvm.TCloudListReservedInstanceOfferingOption) (
+	[]typecvm.TCloudReservedInstanceOffering, error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list option is required")
+	}
+
+	if err := opt.Validate(); err != nil {
+		return nil, errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	client, err := t.clientSet.CvmClient(opt.Region)
+	if err != nil {
+		return nil, fmt.Errorf("new tcloud cvm client failed, err: %v", err)
+	}
+
+	req := cvm.NewDescribeReservedInstancesOfferingsRequest()
+	req.Filters = []*cvm.Filter{
+		{Name: common.StringPtr("zone"), Values: common.StringPtrs([]string{opt.Zone})},
+		{Name: common.StringPtr("instance-type"), Values: common.StringPtrs([]string{opt.InstanceType})},
+	}
+	if opt.Duration != 0 {
+		req.Filters = append(req.Filters, &cvm.Filter{Name: common.StringPtr("duration"),
+			Values: common.StringPtrs([]string{strconv.FormatInt(opt.Duration, 10)})})
+	}
+	if len(opt.OfferingType) != 0 {
+		req.Filters = append(req.Filters, &cvm.Filter{Name: common.StringPtr("offering-type"),
+			Values: common.StringPtrs([]string{opt.OfferingType})})
+	}
+	if len(opt.ProductDescription) != 0 {
+		req.Filters = append(req.Filters, &cvm.Filter{Name: common.StringPtr("product-description"),
+			Values: common.StringPtrs([]string{opt.ProductDescription})})
+	}
+	req.Limit = common.Int64Ptr(int64(core.TCloudQueryLimit))
+
+	offerings := make([]typecvm.TCloudReservedInstanceOffering, 0)
+	for offset := int64(0); ; offset += core.TCloudQueryLimit {
+		req.Offset = common.Int64Ptr(offset)
+		resp, err := client.DescribeReservedInstancesOfferingsWithContext(kt.Ctx, req)
+		if err != nil {
+			logs.Errorf("list tcloud reserved instance offering failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		for _, one := range resp.Response.ReservedInstancesOfferingsSet {
+			offerings = append(offerings, typecvm.TCloudReservedInstanceOffering{ReservedInstancesOffering: one})
+		}
+		if len(resp.Response.ReservedInstancesOfferingsSet) < core.TCloudQueryLimit {
+			break
+		}
+	}
+
+	return offerings, nil
+}
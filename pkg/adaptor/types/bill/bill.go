@@ -597,3 +597,78 @@ type AwsSpUsageTotalResult struct {
 	SpCost        *decimal.Decimal    `json:"sp_cost"`
 	SpNetCost     *decimal.Decimal    `json:"sp_net_cost"`
 }
+
+// AwsRootMonthTotalOption define aws root account month total get option.
+type AwsRootMonthTotalOption struct {
+	PayerCloudID string `json:"payer_cloud_id" validate:"required"`
+	Year         uint   `json:"year" validate:"required"`
+	Month        uint   `json:"month" validate:"required,min=1,max=12"`
+}
+
+// Validate aws root account month total option.
+func (opt AwsRootMonthTotalOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// GcpRootMonthTotalOption define gcp root account month total get option.
+type GcpRootMonthTotalOption struct {
+	// Month 账单月份，格式为yyyymm
+	Month string `json:"month" validate:"required,len=6"`
+}
+
+// Validate gcp root account month total option.
+func (opt GcpRootMonthTotalOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// CurrencyTotal 按币种汇总的云厂商月度账单金额
+type CurrencyTotal struct {
+	Currency enumor.CurrencyCode `json:"currency"`
+	Cost     decimal.Decimal     `json:"cost"`
+}
+
+// AwsRootSpAnalysisOption define aws root account savings plans analysis option.
+type AwsRootSpAnalysisOption struct {
+	PayerCloudID string `json:"payer_cloud_id" validate:"required"`
+	Year         uint   `json:"year" validate:"required"`
+	Month        uint   `json:"month" validate:"required,min=1,max=12"`
+	SpArnPrefix  string `json:"sp_arn_prefix" validate:"omitempty"`
+}
+
+// Validate aws root account savings plans analysis option.
+func (opt AwsRootSpAnalysisOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// AwsSpAnalysisResult 一级账号 savings plans 使用情况，金额单位为USD
+type AwsSpAnalysisResult struct {
+	Utilizations  []AwsSpUtilization   `json:"utilizations"`
+	Coverages     []AwsSpCoverage      `json:"coverages"`
+	DailyOnDemand []AwsSpDailyOnDemand `json:"daily_on_demand"`
+}
+
+// AwsSpUtilization 单个 savings plan 当月承诺金额及已使用金额
+type AwsSpUtilization struct {
+	SpArn          string          `json:"sp_arn"`
+	Commitment     decimal.Decimal `json:"commitment"`
+	UsedCommitment decimal.Decimal `json:"used_commitment"`
+}
+
+// AwsSpCoverage 按使用账号、地域、实例规格汇总的EC2实例费用
+type AwsSpCoverage struct {
+	UsageAccountID string `json:"usage_account_id"`
+	Region         string `json:"region"`
+	InstanceType   string `json:"instance_type"`
+	// CoveredCost savings plans 覆盖部分按需价格计算的费用
+	CoveredCost decimal.Decimal `json:"covered_cost"`
+	// SpEffectiveCost savings plans 覆盖部分实际分摊的费用
+	SpEffectiveCost decimal.Decimal `json:"sp_effective_cost"`
+	// OnDemandCost 未被覆盖的按需费用
+	OnDemandCost decimal.Decimal `json:"on_demand_cost"`
+}
+
+// AwsSpDailyOnDemand 每日未被覆盖的EC2实例按需费用
+type AwsSpDailyOnDemand struct {
+	Date         string          `json:"date"`
+	OnDemandCost decimal.Decimal `json:"on_demand_cost"`
+}
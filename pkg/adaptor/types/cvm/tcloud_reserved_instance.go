@@ -0,0 +1,64 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cvm
+
+import (
+	"hcm/pkg/criteria/validator"
+
+	tcvm "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/cvm/v20170312"
+)
+
+// TCloudListReservedInstanceOption defines options to list tcloud reserved instances.
+type TCloudListReservedInstanceOption struct {
+	Region string `json:"region" validate:"required"`
+	// States 预留实例状态（active、pending、retired），为空时查询所有状态
+	States []string `json:"states" validate:"omitempty,max=5"`
+}
+
+// Validate tcloud list reserved instance option.
+func (opt TCloudListReservedInstanceOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudReservedInstance tcloud reserved instance.
+type TCloudReservedInstance struct {
+	*tcvm.ReservedInstances
+}
+
+// TCloudListReservedInstanceOfferingOption defines options to list tcloud reserved instance offerings.
+type TCloudListReservedInstanceOfferingOption struct {
+	Region       string `json:"region" validate:"required"`
+	Zone         string `json:"zone" validate:"required"`
+	InstanceType string `json:"instance_type" validate:"required"`
+	// Duration 购买时长，单位为秒，31536000 (1年) | 94608000（3年）
+	Duration           int64  `json:"duration" validate:"omitempty"`
+	OfferingType       string `json:"offering_type" validate:"omitempty"`
+	ProductDescription string `json:"product_description" validate:"omitempty"`
+}
+
+// Validate tcloud list reserved instance offering option.
+func (opt TCloudListReservedInstanceOfferingOption) Validate() error {
+	return validator.Validate.Struct(opt)
+}
+
+// TCloudReservedInstanceOffering tcloud reserved instance offering.
+type TCloudReservedInstanceOffering struct {
+	*tcvm.ReservedInstancesOffering
+}
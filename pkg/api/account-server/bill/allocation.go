@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// UpdateBillAllocationRuleReq 修改分摊规则，云厂商、一级账号及费用来源不允许修改，列表字段传空数组表示清空
+type UpdateBillAllocationRuleReq struct {
+	Name                  string                        `json:"name" validate:"omitempty,max=64"`
+	RuleType              enumor.BillAllocationRuleType `json:"rule_type" validate:"omitempty"`
+	SourceMainAccountIDs  []string                      `json:"source_main_account_ids" validate:"omitempty,max=100"`
+	SourceHcProductCodes  []string                      `json:"source_hc_product_codes" validate:"omitempty,max=100"`
+	ExcludeMainAccountIDs []string                      `json:"exclude_main_account_ids" validate:"omitempty,max=500"`
+	Targets               []bill.AllocationTarget       `json:"targets" validate:"omitempty,max=500,dive"`
+	SpArnPrefix           *string                       `json:"sp_arn_prefix" validate:"omitempty,max=255"`
+	CreditIDs             []string                      `json:"credit_ids" validate:"omitempty,max=100"`
+	Enabled               *bool                         `json:"enabled" validate:"omitempty"`
+	Memo                  *string                       `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *UpdateBillAllocationRuleReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// PreviewBillAllocationRuleReq 预览分摊规则在指定账单月份的分摊结果
+type PreviewBillAllocationRuleReq struct {
+	BillYear  int `json:"bill_year" validate:"required"`
+	BillMonth int `json:"bill_month" validate:"required"`
+}
+
+// Validate ...
+func (r *PreviewBillAllocationRuleReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if r.BillMonth > 12 || r.BillMonth < 1 {
+		return errors.New("month must between 1 and 12")
+	}
+	return nil
+}
+
+// PreviewBillAllocationRuleResult 分摊规则预览结果
+type PreviewBillAllocationRuleResult struct {
+	RuleID  string                  `json:"rule_id"`
+	Sources []AllocationSourceCost  `json:"sources"`
+	Details []AllocationPreviewItem `json:"details"`
+}
+
+// AllocationSourceCost 待分摊的共享费用
+type AllocationSourceCost struct {
+	// MainAccountID 共享费用所在二级账号，来源为公共费用时为空
+	MainAccountID string              `json:"main_account_id"`
+	Currency      enumor.CurrencyCode `json:"currency"`
+	Cost          decimal.Decimal     `json:"cost"`
+}
+
+// AllocationPreviewItem 分摊到二级账号的费用
+type AllocationPreviewItem struct {
+	MainAccountID       string              `json:"main_account_id"`
+	MainAccountCloudID  string              `json:"main_account_cloud_id"`
+	BkBizID             int64               `json:"bk_biz_id"`
+	SourceMainAccountID string              `json:"source_main_account_id"`
+	Currency            enumor.CurrencyCode `json:"currency"`
+	Ratio               decimal.Decimal     `json:"ratio"`
+	Cost                decimal.Decimal     `json:"cost"`
+}
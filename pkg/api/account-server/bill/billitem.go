@@ -40,11 +40,17 @@ type ExportBillItemReq struct {
 	BillMonth   int                `json:"bill_month" validate:"required"`
 	ExportLimit uint64             `json:"export_limit" validate:"required"`
 	Filter      *filter.Expression `json:"filter" validate:"omitempty"`
+	// ObjectStore 为true时导出数据流式上传至对象存储并返回下载链接，支持更大的导出数量
+	ObjectStore bool `json:"object_store" validate:"omitempty"`
 }
 
 // Validate ListBillItemReq
 func (r *ExportBillItemReq) Validate() error {
-	if r.ExportLimit > constant.ExcelExportLimit {
+	limit := uint64(constant.ExcelExportLimit)
+	if r.ObjectStore {
+		limit = constant.ObjectStoreExportLimit
+	}
+	if r.ExportLimit > limit {
 		return errors.New("export limit exceed")
 	}
 	if r.Filter != nil {
@@ -209,6 +215,12 @@ type AdjustmentItemSumResult struct {
 	CostMap map[enumor.BillAdjustmentType]map[enumor.CurrencyCode]*bill.CostWithCurrency `json:"cost_map"`
 }
 
+// ObjectStoreExportResult 导出至对象存储的结果
+type ObjectStoreExportResult struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
+
 // FileDownloadResp define file download resp.
 type FileDownloadResp struct {
 	ContentTypeStr        string
@@ -50,10 +50,17 @@ type MainAccountSummarySumReq struct {
 	BillYear  int                `json:"bill_year" validate:"required"`
 	BillMonth int                `json:"bill_month" validate:"required"`
 	Filter    *filter.Expression `json:"filter" validate:"omitempty"`
+	// Currency 汇总换算的目标币种，为空时不换算
+	Currency enumor.CurrencyCode `json:"currency" validate:"omitempty"`
 }
 
 // Validate ...
 func (req *MainAccountSummarySumReq) Validate() error {
+	if len(req.Currency) != 0 {
+		if err := req.Currency.Validate(); err != nil {
+			return err
+		}
+	}
 	return validator.Validate.Struct(req)
 }
 
@@ -61,6 +68,8 @@ func (req *MainAccountSummarySumReq) Validate() error {
 type MainAccountSummarySumResult struct {
 	Count   uint64                                             `json:"count"`
 	CostMap map[enumor.CurrencyCode]*billcore.CostWithCurrency `json:"cost_map"`
+	// ConvertedCost 按请求币种换算后的总费用，请求未指定币种时为空
+	ConvertedCost *billcore.ConvertedCost `json:"converted_cost,omitempty"`
 }
 
 // MainAccountSummaryListResult main account summary list result
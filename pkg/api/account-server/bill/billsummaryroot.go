@@ -73,10 +73,17 @@ type RootAccountSummarySumReq struct {
 	BillYear  int                `json:"bill_year" validate:"required"`
 	BillMonth int                `json:"bill_month" validate:"required"`
 	Filter    *filter.Expression `json:"filter" validate:"omitempty"`
+	// Currency 汇总换算的目标币种，为空时不换算
+	Currency enumor.CurrencyCode `json:"currency" validate:"omitempty"`
 }
 
 // Validate ...
 func (req *RootAccountSummarySumReq) Validate() error {
+	if len(req.Currency) != 0 {
+		if err := req.Currency.Validate(); err != nil {
+			return err
+		}
+	}
 	return validator.Validate.Struct(req)
 }
 
@@ -84,6 +91,8 @@ func (req *RootAccountSummarySumReq) Validate() error {
 type RootAccountSummarySumResult struct {
 	Count   uint64                                             `json:"count"`
 	CostMap map[enumor.CurrencyCode]*billcore.CostWithCurrency `json:"cost_map"`
+	// ConvertedCost 按请求币种换算后的总费用，请求未指定币种时为空
+	ConvertedCost *billcore.ConvertedCost `json:"converted_cost,omitempty"`
 }
 
 // BillSummaryRootResult ...
@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// AwsSavingsPlanAnalysisReq 分析一级账号下 savings plans 的使用率及EC2实例覆盖率
+type AwsSavingsPlanAnalysisReq struct {
+	RootAccountID string `json:"root_account_id" validate:"required"`
+	BillYear      uint   `json:"bill_year" validate:"required"`
+	BillMonth     uint   `json:"bill_month" validate:"required,min=1,max=12"`
+	// SpArnPrefix 仅分析 ARN 以此为前缀的 savings plans
+	SpArnPrefix string `json:"sp_arn_prefix" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *AwsSavingsPlanAnalysisReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// AwsSavingsPlanAnalysisResult savings plans 分析结果，比率均为百分比
+type AwsSavingsPlanAnalysisResult struct {
+	Currency enumor.CurrencyCode `json:"currency"`
+	// Commitment 当月承诺金额
+	Commitment       decimal.Decimal `json:"commitment"`
+	UsedCommitment   decimal.Decimal `json:"used_commitment"`
+	UnusedCommitment decimal.Decimal `json:"unused_commitment"`
+	UtilizationRate  decimal.Decimal `json:"utilization_rate"`
+	// CoveredCost 被覆盖的EC2实例按需价格计算的费用
+	CoveredCost decimal.Decimal `json:"covered_cost"`
+	// OnDemandCost 未被覆盖的EC2实例按需费用
+	OnDemandCost   decimal.Decimal               `json:"on_demand_cost"`
+	CoverageRate   decimal.Decimal               `json:"coverage_rate"`
+	SavingsPlans   []AwsSavingsPlanUtilization   `json:"savings_plans"`
+	Coverages      []AwsSavingsPlanCoverage      `json:"coverages"`
+	Recommendation *AwsSavingsPlanRecommendation `json:"recommendation"`
+}
+
+// AwsSavingsPlanUtilization 单个 savings plan 的使用率
+type AwsSavingsPlanUtilization struct {
+	SpArn            string          `json:"sp_arn"`
+	Commitment       decimal.Decimal `json:"commitment"`
+	UsedCommitment   decimal.Decimal `json:"used_commitment"`
+	UnusedCommitment decimal.Decimal `json:"unused_commitment"`
+	UtilizationRate  decimal.Decimal `json:"utilization_rate"`
+}
+
+// AwsSavingsPlanCoverage 按使用账号、地域、实例规格统计的覆盖率
+type AwsSavingsPlanCoverage struct {
+	UsageAccountID string `json:"usage_account_id"`
+	Region         string `json:"region"`
+	InstanceType   string `json:"instance_type"`
+	// RunningCount 已同步的运行中实例数量
+	RunningCount    int             `json:"running_count"`
+	CoveredCost     decimal.Decimal `json:"covered_cost"`
+	SpEffectiveCost decimal.Decimal `json:"sp_effective_cost"`
+	OnDemandCost    decimal.Decimal `json:"on_demand_cost"`
+	CoverageRate    decimal.Decimal `json:"coverage_rate"`
+}
+
+// AwsSavingsPlanRecommendation 按当月每日未覆盖按需费用的最小值推荐追加的每小时承诺金额
+type AwsSavingsPlanRecommendation struct {
+	// DiscountRate 当月 savings plans 实际折扣率
+	DiscountRate     decimal.Decimal `json:"discount_rate"`
+	HourlyCommitment decimal.Decimal `json:"hourly_commitment"`
+	// MonthlyCommitment 按当月天数折算的承诺金额
+	MonthlyCommitment       decimal.Decimal `json:"monthly_commitment"`
+	EstimatedMonthlySavings decimal.Decimal `json:"estimated_monthly_savings"`
+}
+
+// TCloudReservedInstanceAnalysisReq 分析腾讯云账号指定地域下预留实例的使用率及按量计费实例覆盖率
+type TCloudReservedInstanceAnalysisReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+}
+
+// Validate ...
+func (r *TCloudReservedInstanceAnalysisReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// TCloudReservedInstanceAnalysisResult 预留实例分析结果，比率均为百分比
+type TCloudReservedInstanceAnalysisResult struct {
+	ReservedCount int `json:"reserved_count"`
+	CoveredCount  int `json:"covered_count"`
+	// PostpaidCount 运行中的按量计费实例数量
+	PostpaidCount   int                                    `json:"postpaid_count"`
+	CoverageRate    decimal.Decimal                        `json:"coverage_rate"`
+	UtilizationRate decimal.Decimal                        `json:"utilization_rate"`
+	Items           []TCloudReservedInstanceCoverage       `json:"items"`
+	Recommendations []TCloudReservedInstanceRecommendation `json:"recommendations"`
+}
+
+// TCloudReservedInstanceCoverage 按可用区、实例规格统计的预留实例覆盖情况
+type TCloudReservedInstanceCoverage struct {
+	Zone            string          `json:"zone"`
+	InstanceType    string          `json:"instance_type"`
+	ReservedCount   int             `json:"reserved_count"`
+	PostpaidCount   int             `json:"postpaid_count"`
+	CoveredCount    int             `json:"covered_count"`
+	UnusedCount     int             `json:"unused_count"`
+	CoverageRate    decimal.Decimal `json:"coverage_rate"`
+	UtilizationRate decimal.Decimal `json:"utilization_rate"`
+	Currency        string          `json:"currency"`
+	// UnusedMonthlyCost 未使用的预留实例按月均摊的费用
+	UnusedMonthlyCost decimal.Decimal `json:"unused_monthly_cost"`
+}
+
+// TCloudReservedInstanceRecommendation 对持续运行的未覆盖按量计费实例推荐购买一年期预留实例
+type TCloudReservedInstanceRecommendation struct {
+	Zone         string `json:"zone"`
+	InstanceType string `json:"instance_type"`
+	Count        int    `json:"count"`
+	OfferingID   string `json:"offering_id"`
+	OfferingType string `json:"offering_type"`
+	Currency     string `json:"currency"`
+	// ReservedMonthlyCost 推荐购买的预留实例按月均摊的费用
+	ReservedMonthlyCost decimal.Decimal `json:"reserved_monthly_cost"`
+	// OnDemandMonthlyCost 对应实例上月账单费用
+	OnDemandMonthlyCost     decimal.Decimal `json:"on_demand_monthly_cost"`
+	EstimatedMonthlySavings decimal.Decimal `json:"estimated_monthly_savings"`
+}
@@ -0,0 +1,109 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// CostResTypes 支持按资源查询费用的资源类型
+var CostResTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:  {},
+	enumor.DiskCloudResType: {},
+	enumor.EipCloudResType:  {},
+}
+
+// ListResourceCostReq 查询资源费用请求
+type ListResourceCostReq struct {
+	BillYear  int                      `json:"bill_year" validate:"required"`
+	BillMonth int                      `json:"bill_month" validate:"required,min=1,max=12"`
+	ResType   enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs       []string                 `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate ListResourceCostReq
+func (r *ListResourceCostReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if _, exists := CostResTypes[r.ResType]; !exists {
+		return fmt.Errorf("res_type: %s not support cost query", r.ResType)
+	}
+
+	return nil
+}
+
+// ResourceCostResult 资源费用结果
+type ResourceCostResult struct {
+	Details []ResourceCostItem `json:"details"`
+}
+
+// ResourceCostItem 单个资源的费用，按币种分别汇总
+type ResourceCostItem struct {
+	ResType enumor.CloudResourceType `json:"res_type"`
+	ID      string                   `json:"id"`
+	CloudID string                   `json:"cloud_id"`
+	Name    string                   `json:"name"`
+	BkBizID int64                    `json:"bk_biz_id"`
+	Costs   []CurrencyCost           `json:"costs"`
+}
+
+// CurrencyCost 币种费用
+type CurrencyCost struct {
+	Currency enumor.CurrencyCode `json:"currency"`
+	Cost     decimal.Decimal     `json:"cost"`
+}
+
+// ListBizResourceCostReq 查询业务下按资源聚合的费用请求
+type ListBizResourceCostReq struct {
+	BillYear  int            `json:"bill_year" validate:"required"`
+	BillMonth int            `json:"bill_month" validate:"required,min=1,max=12"`
+	BkBizID   int64          `json:"bk_biz_id" validate:"required,min=1"`
+	Page      *core.BasePage `json:"page" validate:"required"`
+}
+
+// Validate ListBizResourceCostReq
+func (r *ListBizResourceCostReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	return r.Page.Validate()
+}
+
+// BizResourceCostResult 业务下按资源聚合的费用结果
+type BizResourceCostResult = core.ListResultT[BizResourceCostItem]
+
+// BizResourceCostItem 业务下单个云资源单个币种的费用，资源已同步时返回对应的资源信息
+type BizResourceCostItem struct {
+	CloudResID string                   `json:"cloud_res_id"`
+	Currency   enumor.CurrencyCode      `json:"currency"`
+	Cost       decimal.Decimal          `json:"cost"`
+	ResType    enumor.CloudResourceType `json:"res_type,omitempty"`
+	ID         string                   `json:"id,omitempty"`
+	Name       string                   `json:"name,omitempty"`
+}
@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// CreateExchangeRateReq 手动录入汇率
+type CreateExchangeRateReq struct {
+	Year         int                 `json:"year" validate:"required,gt=0"`
+	Month        int                 `json:"month" validate:"required,gte=1,lte=12"`
+	FromCurrency enumor.CurrencyCode `json:"from_currency" validate:"required"`
+	ToCurrency   enumor.CurrencyCode `json:"to_currency" validate:"required"`
+	ExchangeRate *decimal.Decimal    `json:"exchange_rate" validate:"required"`
+}
+
+// Validate ...
+func (r *CreateExchangeRateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if err := r.FromCurrency.Validate(); err != nil {
+		return err
+	}
+	if err := r.ToCurrency.Validate(); err != nil {
+		return err
+	}
+	if r.FromCurrency == r.ToCurrency {
+		return errors.New("from_currency and to_currency can not be the same")
+	}
+	if !r.ExchangeRate.IsPositive() {
+		return errors.New("exchange_rate must be positive")
+	}
+	return nil
+}
+
+// UpdateExchangeRateReq 修改汇率，已锁定的汇率不允许修改
+type UpdateExchangeRateReq struct {
+	ExchangeRate *decimal.Decimal `json:"exchange_rate" validate:"required"`
+}
+
+// Validate ...
+func (r *UpdateExchangeRateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if !r.ExchangeRate.IsPositive() {
+		return errors.New("exchange_rate must be positive")
+	}
+	return nil
+}
+
+// ImportExchangeRateReq 通过CSV文件导入汇率，文件每行格式为: from_currency,to_currency,exchange_rate
+type ImportExchangeRateReq struct {
+	Year          int          `json:"year" validate:"required,gt=0"`
+	Month         int          `json:"month" validate:"required,gte=1,lte=12"`
+	CsvFileBase64 Base64String `json:"csv_file_base64" validate:"required"`
+}
+
+// Validate ...
+func (r *ImportExchangeRateReq) Validate() error {
+	if err := r.CsvFileBase64.checkSize(importFileSizeMaxLimit1MB); err != nil {
+		return err
+	}
+	return validator.Validate.Struct(r)
+}
+
+// ExchangeRateMonthReq 按月操作汇率，用于锁定、同步汇率
+type ExchangeRateMonthReq struct {
+	Year  int `json:"year" validate:"required,gt=0"`
+	Month int `json:"month" validate:"required,gte=1,lte=12"`
+}
+
+// Validate ...
+func (r *ExchangeRateMonthReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// ExchangeRateSaveResult 汇率导入、同步结果
+type ExchangeRateSaveResult struct {
+	// Created 新增的汇率数量
+	Created int `json:"created"`
+	// Updated 更新的汇率数量
+	Updated int `json:"updated"`
+	// SkippedLocked 因已锁定而跳过的汇率数量
+	SkippedLocked int `json:"skipped_locked"`
+}
+
+// ExchangeRateLockResult 汇率锁定结果
+type ExchangeRateLockResult struct {
+	Locked int `json:"locked"`
+}
@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// DefaultForecastHistoryDays 默认用于拟合的历史天数
+	DefaultForecastHistoryDays = 90
+	// MaxForecastHistoryDays 最大用于拟合的历史天数
+	MaxForecastHistoryDays = 365
+)
+
+// BillForecastReq 按业务或二级账号预测月末及下季度费用，业务与二级账号需且只能指定一个
+type BillForecastReq struct {
+	BkBizID       int64  `json:"bk_biz_id" validate:"omitempty,min=1"`
+	MainAccountID string `json:"main_account_id" validate:"omitempty"`
+	// HistoryDays 用于拟合的历史天数，默认90天
+	HistoryDays int `json:"history_days" validate:"omitempty,min=7,max=365"`
+}
+
+// Validate ...
+func (r *BillForecastReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if (r.BkBizID == 0) == (len(r.MainAccountID) == 0) {
+		return errors.New("one of bk_biz_id and main_account_id is required")
+	}
+	if r.HistoryDays == 0 {
+		r.HistoryDays = DefaultForecastHistoryDays
+	}
+	return nil
+}
+
+// BillForecastResult 费用预测结果
+type BillForecastResult struct {
+	BillYear  int `json:"bill_year"`
+	BillMonth int `json:"bill_month"`
+	// LastBillDate 参与拟合的最后一个出账日期，格式为 2006-01-02，无历史账单时为空
+	LastBillDate string `json:"last_bill_date"`
+	// ConfidenceLevel 置信区间的置信水平
+	ConfidenceLevel float64               `json:"confidence_level"`
+	Items           []BillForecastItem    `json:"items"`
+	Planned         []BillForecastPlanned `json:"planned"`
+}
+
+// BillForecastItem 单个币种的费用预测
+type BillForecastItem struct {
+	Currency enumor.CurrencyCode      `json:"currency"`
+	Model    enumor.BillForecastModel `json:"model"`
+	// MonthToDateCost 当月已出账费用
+	MonthToDateCost decimal.Decimal `json:"month_to_date_cost"`
+	// MonthEnd 当月月末费用，包含当月已出账费用
+	MonthEnd BillForecastValue `json:"month_end"`
+	// NextQuarter 当月之后三个自然月的费用合计
+	NextQuarter BillForecastValue `json:"next_quarter"`
+	// Months 当月之后三个自然月各月费用
+	Months []BillForecastMonth `json:"months"`
+}
+
+// BillForecastValue 预测值及置信区间，区间已包含计划费用
+type BillForecastValue struct {
+	Point decimal.Decimal `json:"point"`
+	Lower decimal.Decimal `json:"lower"`
+	Upper decimal.Decimal `json:"upper"`
+	// PlannedCost 待审批的购买申请带来的已知费用
+	PlannedCost decimal.Decimal `json:"planned_cost"`
+}
+
+// BillForecastMonth 单月费用预测
+type BillForecastMonth struct {
+	BillYear          int `json:"bill_year"`
+	BillMonth         int `json:"bill_month"`
+	BillForecastValue `json:",inline"`
+}
+
+// BillForecastPlanned 待审批的购买申请按询价结果折算的计划费用
+type BillForecastPlanned struct {
+	ApplicationID string              `json:"application_id"`
+	SN            string              `json:"sn"`
+	Vendor        enumor.Vendor       `json:"vendor"`
+	AccountID     string              `json:"account_id"`
+	BkBizID       int64               `json:"bk_biz_id"`
+	ChargeType    string              `json:"charge_type"`
+	Currency      enumor.CurrencyCode `json:"currency"`
+	// DailyCost 按需计费时为每日费用
+	DailyCost decimal.Decimal `json:"daily_cost"`
+	// MonthlyCost 包年包月时为按购买时长均摊的每月费用
+	MonthlyCost decimal.Decimal `json:"monthly_cost"`
+}
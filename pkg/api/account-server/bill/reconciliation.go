@@ -0,0 +1,84 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// RunBillReconciliationReq 对一级账号指定月份账单进行对账
+type RunBillReconciliationReq struct {
+	RootAccountID string `json:"root_account_id" validate:"required"`
+	BillYear      int    `json:"bill_year" validate:"required"`
+	BillMonth     int    `json:"bill_month" validate:"required"`
+}
+
+// Validate ...
+func (r *RunBillReconciliationReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if r.BillMonth > 12 || r.BillMonth < 1 {
+		return errors.New("month must between 1 and 12")
+	}
+	return nil
+}
+
+// BillReconciliationDrillDownReq 查看对账记录对应的每日账单汇总
+type BillReconciliationDrillDownReq struct {
+	// MainAccountID 只查看指定二级账号的每日账单汇总，为空时汇总所有二级账号
+	MainAccountID string `json:"main_account_id" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *BillReconciliationDrillDownReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// BillReconciliationDrillDownResult 对账记录下钻结果
+type BillReconciliationDrillDownResult struct {
+	ReconciliationID string                          `json:"reconciliation_id"`
+	MainAccounts     []ReconciliationMainAccountCost `json:"main_accounts"`
+	Days             []ReconciliationDailyCost       `json:"days"`
+}
+
+// ReconciliationMainAccountCost 二级账号当月账单汇总
+type ReconciliationMainAccountCost struct {
+	MainAccountID      string `json:"main_account_id"`
+	MainAccountCloudID string `json:"main_account_cloud_id"`
+	BkBizID            int64  `json:"bk_biz_id"`
+	// VersionID 二级账号当前账单版本，每日账单汇总按该版本统计
+	VersionID int                 `json:"version_id"`
+	Currency  enumor.CurrencyCode `json:"currency"`
+	Cost      decimal.Decimal     `json:"cost"`
+}
+
+// ReconciliationDailyCost 每日账单汇总
+type ReconciliationDailyCost struct {
+	BillDay  int                 `json:"bill_day"`
+	Currency enumor.CurrencyCode `json:"currency"`
+	Cost     decimal.Decimal     `json:"cost"`
+	Count    int64               `json:"count"`
+}
@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// UpdateBillReportSubReq 修改账单订阅报表，报表类型及云厂商不允许修改
+type UpdateBillReportSubReq struct {
+	Name      string                   `json:"name" validate:"omitempty,max=64"`
+	Filter    *filter.Expression       `json:"filter" validate:"omitempty"`
+	Format    enumor.BillReportFormat  `json:"format" validate:"omitempty"`
+	Cadence   enumor.BillReportCadence `json:"cadence" validate:"omitempty"`
+	SendDay   int                      `json:"send_day" validate:"omitempty,min=1,max=28"`
+	Receivers []string                 `json:"receivers" validate:"omitempty,max=20"`
+	Enabled   *bool                    `json:"enabled" validate:"omitempty"`
+	Memo      *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *UpdateBillReportSubReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// DeliverBillReportReq 立即发送指定账单月份的订阅报表
+type DeliverBillReportReq struct {
+	BillYear  int `json:"bill_year" validate:"required"`
+	BillMonth int `json:"bill_month" validate:"required"`
+}
+
+// Validate ...
+func (r *DeliverBillReportReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if r.BillMonth > 12 || r.BillMonth < 1 {
+		return errors.New("month must between 1 and 12")
+	}
+	return nil
+}
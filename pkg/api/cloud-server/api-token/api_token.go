@@ -0,0 +1,164 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apitoken ...
+package apitoken
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	coreapitoken "hcm/pkg/api/core/api-token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/iam/meta"
+)
+
+// serviceAccountNameRegexp 服务账号名称只能包含小写字母、数字、中划线和下划线，且以小写字母开头，
+// 长度限制需保证加上令牌主体前缀后不超过用户名的最大长度64
+var serviceAccountNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]{2,59}$`)
+
+// TokenMaxExpireDays API令牌的最长有效期（天）
+const TokenMaxExpireDays = 365
+
+// ------------------------ Service Account ------------------------
+
+// ServiceAccountCreateReq create service account request.
+type ServiceAccountCreateReq struct {
+	Name string  `json:"name" validate:"required,max=60"`
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ServiceAccountCreateReq
+func (req *ServiceAccountCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if !serviceAccountNameRegexp.MatchString(req.Name) {
+		return errors.New("name should start with a lowercase letter and only contain lowercase letters, " +
+			"digits, '-' and '_', length 3~60")
+	}
+
+	return nil
+}
+
+// ServiceAccountUpdateReq update service account request.
+type ServiceAccountUpdateReq struct {
+	Memo *string `json:"memo" validate:"required,max=255"`
+}
+
+// Validate ServiceAccountUpdateReq
+func (req *ServiceAccountUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ServiceAccountDeleteReq delete service account request, tokens of the service accounts are deleted too.
+type ServiceAccountDeleteReq struct {
+	IDs []string `json:"ids" validate:"min=1,max=100"`
+}
+
+// Validate ServiceAccountDeleteReq
+func (req *ServiceAccountDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ServiceAccountListResult defines list service account result.
+type ServiceAccountListResult struct {
+	Count   uint64                        `json:"count"`
+	Details []coreapitoken.ServiceAccount `json:"details"`
+}
+
+// ------------------------ Token ------------------------
+
+// TokenCreateReq create api token request.
+type TokenCreateReq struct {
+	Name          string                       `json:"name" validate:"required,max=64"`
+	PrincipalType enumor.APITokenPrincipalType `json:"principal_type" validate:"required"`
+	// Principal 服务账号名称，主体类型为用户时不需要传，令牌主体为当前用户
+	Principal string `json:"principal" validate:"omitempty,max=60"`
+	// BkBizIDs 令牌可访问的业务，为空表示不限制业务
+	BkBizIDs   []int64  `json:"bk_biz_ids" validate:"omitempty,max=100"`
+	Actions    []string `json:"actions" validate:"required,min=1"`
+	ExpireDays uint     `json:"expire_days" validate:"required,min=1"`
+	Memo       *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TokenCreateReq
+func (req *TokenCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.PrincipalType.Validate(); err != nil {
+		return err
+	}
+
+	switch req.PrincipalType {
+	case enumor.APITokenPrincipalUser:
+		if len(req.Principal) != 0 {
+			return errors.New("principal should be empty when principal type is user")
+		}
+	case enumor.APITokenPrincipalServiceAccount:
+		if len(req.Principal) == 0 {
+			return errors.New("principal is required when principal type is service_account")
+		}
+	}
+
+	for _, action := range req.Actions {
+		if _, exists := meta.TokenActions[meta.Action(action)]; !exists {
+			return fmt.Errorf("action %s can not be granted to api token", action)
+		}
+	}
+
+	for _, bizID := range req.BkBizIDs {
+		if bizID <= 0 {
+			return fmt.Errorf("invalid bk_biz_id: %d", bizID)
+		}
+	}
+
+	if req.ExpireDays > TokenMaxExpireDays {
+		return fmt.Errorf("expire_days should <= %d", TokenMaxExpireDays)
+	}
+
+	return nil
+}
+
+// TokenCreateResult create api token result, token plaintext is only returned once.
+type TokenCreateResult struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+}
+
+// TokenRevokeReq revoke api token request.
+type TokenRevokeReq struct {
+	IDs []string `json:"ids" validate:"min=1,max=100"`
+}
+
+// Validate TokenRevokeReq
+func (req *TokenRevokeReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TokenListResult defines list api token result.
+type TokenListResult struct {
+	Count   uint64               `json:"count"`
+	Details []coreapitoken.Token `json:"details"`
+}
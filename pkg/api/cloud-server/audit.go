@@ -20,6 +20,8 @@
 package cloudserver
 
 import (
+	"time"
+
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/validator"
 	"hcm/pkg/runtime/filter"
@@ -31,6 +33,8 @@ import (
 type AuditListReq struct {
 	Filter *filter.Expression `json:"filter" validate:"required"`
 	Page   *core.BasePage     `json:"page" validate:"required"`
+	// Keyword 对资源名称、云资源ID及审计详情进行全文检索
+	Keyword string `json:"keyword" validate:"omitempty,max=128"`
 }
 
 // Validate audit list req.
@@ -38,6 +42,33 @@ func (req *AuditListReq) Validate() error {
 	return validator.Validate.Struct(req)
 }
 
+// -------------------------- List Audit Field Change --------------------------
+
+// AuditFieldChangeListReq define audit field change list req.
+type AuditFieldChangeListReq struct {
+	Filter *filter.Expression `json:"filter" validate:"required"`
+	Page   *core.BasePage     `json:"page" validate:"required"`
+}
+
+// Validate audit field change list req.
+func (req *AuditFieldChangeListReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Cloud Sync Report --------------------------
+
+// AuditCloudSyncReportReq define out-of-band change report req.
+type AuditCloudSyncReportReq struct {
+	StartTime  time.Time `json:"start_time" validate:"required"`
+	EndTime    time.Time `json:"end_time" validate:"required"`
+	AccountIDs []string  `json:"account_ids" validate:"required,min=1,max=100"`
+}
+
+// Validate audit cloud sync report req.
+func (req *AuditCloudSyncReportReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // -------------------------- List Audit Async Flow --------------------------
 
 // AuditAsyncFlowListReq define audit async flow list req.
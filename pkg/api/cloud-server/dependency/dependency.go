@@ -0,0 +1,112 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package dependency defines resource dependency api.
+package dependency
+
+import (
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// DeleteImpactResTypes 支持删除影响分析的资源类型
+var DeleteImpactResTypes = map[enumor.CloudResourceType]struct{}{
+	enumor.CvmCloudResType:           {},
+	enumor.DiskCloudResType:          {},
+	enumor.EipCloudResType:           {},
+	enumor.SecurityGroupCloudResType: {},
+	enumor.LoadBalancerCloudResType:  {},
+	enumor.SubnetCloudResType:        {},
+	enumor.VpcCloudResType:           {},
+	enumor.RouteTableCloudResType:    {},
+}
+
+// DeleteImpactReq delete impact request.
+type DeleteImpactReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=100"`
+}
+
+// Validate DeleteImpactReq.
+func (req *DeleteImpactReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, exists := DeleteImpactResTypes[req.ResType]; !exists {
+		return fmt.Errorf("res_type: %s not support delete impact analysis", req.ResType)
+	}
+
+	return nil
+}
+
+// DeleteImpactResult delete impact result.
+type DeleteImpactResult struct {
+	Details []DeleteImpactItem `json:"details"`
+}
+
+// DeleteImpactItem 单个资源的删除影响
+type DeleteImpactItem struct {
+	Root *DependencyNode `json:"root"`
+	// Deletable 没有阻塞删除的依赖资源，可以直接删除
+	Deletable bool `json:"deletable"`
+	// Cascadable 所有阻塞删除的依赖资源均可级联删除
+	Cascadable bool `json:"cascadable"`
+}
+
+// DependencyNode 依赖树节点
+type DependencyNode struct {
+	ResType   enumor.CloudResourceType `json:"res_type"`
+	ID        string                   `json:"id"`
+	Vendor    enumor.Vendor            `json:"vendor"`
+	AccountID string                   `json:"account_id"`
+	BkBizID   int64                    `json:"bk_biz_id"`
+	Region    string                   `json:"region"`
+	// Blocking 该资源存在时父资源无法删除
+	Blocking bool `json:"blocking"`
+	// Cascade 该资源可以随父资源一起级联删除
+	Cascade  bool              `json:"cascade"`
+	Children []*DependencyNode `json:"children"`
+}
+
+// CascadeDeleteReq cascade delete request.
+type CascadeDeleteReq struct {
+	ResType enumor.CloudResourceType `json:"res_type" validate:"required"`
+	IDs     []string                 `json:"ids" validate:"required,min=1,max=20"`
+}
+
+// Validate CascadeDeleteReq.
+func (req *CascadeDeleteReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if _, exists := DeleteImpactResTypes[req.ResType]; !exists {
+		return fmt.Errorf("res_type: %s not support cascade delete", req.ResType)
+	}
+
+	return nil
+}
+
+// CascadeDeleteResult cascade delete result.
+type CascadeDeleteResult struct {
+	FlowID string `json:"flow_id"`
+}
@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac ...
+package rbac
+
+import (
+	"errors"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Role --------------------------
+
+// RoleCreateReq create rbac role request.
+type RoleCreateReq struct {
+	Name        string                `json:"name" validate:"required,max=64"`
+	Permissions []corerbac.Permission `json:"permissions" validate:"required,min=1,max=100"`
+	Memo        *string               `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RoleCreateReq
+func (req *RoleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return corerbac.ValidatePermissions(req.Permissions)
+}
+
+// RoleUpdateReq update rbac role request, permissions are replaced when set.
+type RoleUpdateReq struct {
+	Name        string                `json:"name" validate:"omitempty,max=64"`
+	Permissions []corerbac.Permission `json:"permissions" validate:"omitempty,max=100"`
+	Memo        *string               `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RoleUpdateReq
+func (req *RoleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.Permissions) == 0 && req.Memo == nil {
+		return errors.New("one of the update fields must be set")
+	}
+
+	if len(req.Permissions) != 0 {
+		return corerbac.ValidatePermissions(req.Permissions)
+	}
+
+	return nil
+}
+
+// RoleListResult defines list rbac role result.
+type RoleListResult struct {
+	Count   uint64          `json:"count"`
+	Details []corerbac.Role `json:"details"`
+}
+
+// -------------------------- Group --------------------------
+
+// GroupCreateReq create rbac group request.
+type GroupCreateReq struct {
+	Name    string   `json:"name" validate:"required,max=64"`
+	Members []string `json:"members" validate:"omitempty,max=1000"`
+	Memo    *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate GroupCreateReq
+func (req *GroupCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// GroupUpdateReq update rbac group request, members are replaced when set.
+type GroupUpdateReq struct {
+	Members []string `json:"members" validate:"omitempty,max=1000"`
+	Memo    *string  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate GroupUpdateReq
+func (req *GroupUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Members == nil && req.Memo == nil {
+		return errors.New("one of the update fields must be set")
+	}
+
+	return nil
+}
+
+// GroupListResult defines list rbac group result.
+type GroupListResult struct {
+	Count   uint64           `json:"count"`
+	Details []corerbac.Group `json:"details"`
+}
+
+// -------------------------- Role Binding --------------------------
+
+// RoleBindingCreateReq create rbac role binding request.
+type RoleBindingCreateReq struct {
+	RoleID      string                 `json:"role_id" validate:"required"`
+	SubjectType enumor.RBACSubjectType `json:"subject_type" validate:"required"`
+	Subject     string                 `json:"subject" validate:"required,max=64"`
+	// BkBizIDs 授权的业务范围，为空时表示全部业务
+	BkBizIDs []int64 `json:"bk_biz_ids" validate:"omitempty,max=100"`
+}
+
+// Validate RoleBindingCreateReq
+func (req *RoleBindingCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	return req.SubjectType.Validate()
+}
+
+// RoleBindingUpdateReq update rbac role binding request, only business scope can be updated.
+type RoleBindingUpdateReq struct {
+	// BkBizIDs 授权的业务范围，为空时表示全部业务
+	BkBizIDs []int64 `json:"bk_biz_ids" validate:"omitempty,max=100"`
+}
+
+// Validate RoleBindingUpdateReq
+func (req *RoleBindingUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// RoleBindingListResult defines list rbac role binding result.
+type RoleBindingListResult struct {
+	Count   uint64                 `json:"count"`
+	Details []corerbac.RoleBinding `json:"details"`
+}
+
+// -------------------------- Delete --------------------------
+
+// BatchDeleteReq batch delete rbac role, group or role binding request.
+type BatchDeleteReq struct {
+	IDs []string `json:"ids" validate:"min=1,max=100"`
+}
+
+// Validate BatchDeleteReq
+func (req *BatchDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
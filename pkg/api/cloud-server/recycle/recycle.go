@@ -23,10 +23,31 @@ package recycle
 import (
 	rr "hcm/pkg/api/core/recycle-record"
 	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
 )
 
 // ------------------------ Recycle ------------------------
 
+// ResRecycleReq recycle eip, load balancer or security group request.
+type ResRecycleReq struct {
+	IDs []string `json:"ids" validate:"min=1,max=100"`
+}
+
+// Validate ResRecycleReq
+func (req *ResRecycleReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ResRecoverReq recover eip, load balancer or security group request.
+type ResRecoverReq struct {
+	RecordIDs []string `json:"record_ids" validate:"min=1,max=100"`
+}
+
+// Validate ResRecoverReq
+func (req *ResRecoverReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
 // RecycleResult defines recycle resource result.
 type RecycleResult struct {
 	TaskID string `json:"task_id"`
@@ -0,0 +1,107 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recycle
+
+import (
+	"fmt"
+
+	rr "hcm/pkg/api/core/recycle-record"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ------------------------ Create ------------------------
+
+// RecyclePolicyCreateReq create recycle policy request.
+type RecyclePolicyCreateReq struct {
+	// BkBizID 业务ID，业务下的接口以路径中的业务ID为准
+	BkBizID      int64                    `json:"bk_biz_id" validate:"omitempty,min=-1"`
+	ResType      enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ReserveTime  int                      `json:"reserve_time" validate:"required,min=1"`
+	NotifyBefore int                      `json:"notify_before" validate:"omitempty,min=0"`
+	Memo         *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RecyclePolicyCreateReq
+func (req *RecyclePolicyCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.BkBizID == 0 {
+		return fmt.Errorf("bk_biz_id is required")
+	}
+
+	if _, exists := enumor.RecyclableResTypeMap[req.ResType]; !exists {
+		return fmt.Errorf("res_type: %s not support recycle", req.ResType)
+	}
+
+	if req.NotifyBefore >= req.ReserveTime {
+		return fmt.Errorf("notify_before should < reserve_time")
+	}
+
+	return nil
+}
+
+// ------------------------ Update ------------------------
+
+// RecyclePolicyUpdateReq update recycle policy request.
+type RecyclePolicyUpdateReq struct {
+	ReserveTime  int     `json:"reserve_time" validate:"omitempty,min=1"`
+	NotifyBefore int     `json:"notify_before" validate:"omitempty,min=0"`
+	Memo         *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RecyclePolicyUpdateReq
+func (req *RecyclePolicyUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.ReserveTime == 0 && req.NotifyBefore == 0 && req.Memo == nil {
+		return fmt.Errorf("one of the update fields must be set")
+	}
+
+	if req.ReserveTime != 0 && req.NotifyBefore >= req.ReserveTime {
+		return fmt.Errorf("notify_before should < reserve_time")
+	}
+
+	return nil
+}
+
+// ------------------------ Delete ------------------------
+
+// RecyclePolicyDeleteReq delete recycle policy request.
+type RecyclePolicyDeleteReq struct {
+	IDs []string `json:"ids" validate:"min=1,max=100"`
+}
+
+// Validate RecyclePolicyDeleteReq
+func (req *RecyclePolicyDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// RecyclePolicyListResult defines list recycle policy result.
+type RecyclePolicyListResult struct {
+	Count   uint64             `json:"count"`
+	Details []rr.RecyclePolicy `json:"details"`
+}
@@ -0,0 +1,35 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import "hcm/pkg/criteria/enumor"
+
+// SGLintSummary define business security group lint risk report.
+type SGLintSummary struct {
+	BkBizID int64 `json:"bk_biz_id"`
+	// RiskScore 业务下所有检查结果的风险分之和
+	RiskScore uint64 `json:"risk_score"`
+	// LintSGCount 存在风险的安全组数量
+	LintSGCount uint64 `json:"lint_sg_count"`
+	// LintCount 各检查项的检查结果数量
+	LintCount map[enumor.SGLintType]uint64 `json:"lint_count"`
+	// LevelCount 各风险等级的检查结果数量
+	LevelCount map[enumor.RiskLevel]uint64 `json:"level_count"`
+}
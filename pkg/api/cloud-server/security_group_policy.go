@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloudserver
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Evaluate --------------------------
+
+// SGPolicyEvaluateReq define security group effective policy evaluate request.
+type SGPolicyEvaluateReq struct {
+	// Type 流量方向，ingress 时 Address 为访问来源，egress 时 Address 为访问目的
+	Type     enumor.SecurityGroupRuleType `json:"type" validate:"required"`
+	Protocol string                       `json:"protocol" validate:"required"`
+	Port     int64                        `json:"port" validate:"omitempty,min=0,max=65535"`
+	Address  string                       `json:"address" validate:"required"`
+}
+
+// Validate security group effective policy evaluate request.
+func (req *SGPolicyEvaluateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if req.Type != enumor.Ingress && req.Type != enumor.Egress {
+		return fmt.Errorf("type: %s not support", req.Type)
+	}
+
+	switch strings.ToLower(req.Protocol) {
+	case "tcp", "udp":
+		if req.Port <= 0 {
+			return errors.New("port is required when protocol is tcp or udp")
+		}
+	case "icmp", "icmpv6", "all":
+	default:
+		return fmt.Errorf("protocol: %s not support", req.Protocol)
+	}
+
+	if net.ParseIP(req.Address) == nil {
+		return fmt.Errorf("address: %s is not a valid ip", req.Address)
+	}
+
+	return nil
+}
+
+// SGPolicyEvaluateResult define security group effective policy evaluate result.
+type SGPolicyEvaluateResult struct {
+	Allowed bool `json:"allowed"`
+	// DecidedBy 决定访问结果的规则，为空时表示没有规则命中，由云上默认策略决定
+	DecidedBy *SGPolicyRule `json:"decided_by"`
+	Reason    string        `json:"reason"`
+	// SecurityGroupIDs 按评估顺序排列的生效安全组/防火墙规则所属资源ID
+	SecurityGroupIDs []string `json:"security_group_ids"`
+	// SkippedRules 引用了安全组、地址组、参数模版等无法在本地展开的规则，未参与评估
+	SkippedRules []SGPolicyRule `json:"skipped_rules"`
+}
+
+// SGPolicyRule define security group rule normalized for evaluation.
+type SGPolicyRule struct {
+	Vendor               enumor.Vendor                `json:"vendor"`
+	ID                   string                       `json:"id"`
+	CloudID              string                       `json:"cloud_id"`
+	Name                 string                       `json:"name"`
+	SecurityGroupID      string                       `json:"security_group_id"`
+	CloudSecurityGroupID string                       `json:"cloud_security_group_id"`
+	Type                 enumor.SecurityGroupRuleType `json:"type"`
+	Priority             int64                        `json:"priority"`
+	Protocol             string                       `json:"protocol"`
+	Ports                []string                     `json:"ports"`
+	Addresses            []string                     `json:"addresses"`
+	Action               enumor.SGPolicyAction        `json:"action"`
+	Memo                 string                       `json:"memo"`
+}
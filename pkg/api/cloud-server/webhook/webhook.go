@@ -0,0 +1,124 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook ...
+package webhook
+
+import (
+	"fmt"
+
+	corewebhook "hcm/pkg/api/core/webhook"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// ------------------------ Create ------------------------
+
+// SubscriptionCreateReq create webhook subscription request.
+type SubscriptionCreateReq struct {
+	Name string `json:"name" validate:"required,max=64"`
+	URL  string `json:"url" validate:"required,max=1024,url"`
+	// Secret 签名密钥，为空时投递请求不携带签名
+	Secret     string                    `json:"secret" validate:"omitempty,max=128"`
+	EventTypes []enumor.WebhookEventType `json:"event_types" validate:"required,min=1,max=100"`
+	Enabled    *bool                     `json:"enabled" validate:"omitempty"`
+	Memo       *string                   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionCreateReq
+func (req *SubscriptionCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := corewebhook.ValidateURL(req.URL); err != nil {
+		return err
+	}
+
+	return validateEventTypes(req.EventTypes)
+}
+
+// ------------------------ Update ------------------------
+
+// SubscriptionUpdateReq update webhook subscription request.
+type SubscriptionUpdateReq struct {
+	Name string `json:"name" validate:"omitempty,max=64"`
+	URL  string `json:"url" validate:"omitempty,max=1024,url"`
+	// Secret 签名密钥，不传时保持不变
+	Secret     string                    `json:"secret" validate:"omitempty,max=128"`
+	EventTypes []enumor.WebhookEventType `json:"event_types" validate:"omitempty,max=100"`
+	Enabled    *bool                     `json:"enabled" validate:"omitempty"`
+	Memo       *string                   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionUpdateReq
+func (req *SubscriptionUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Name) == 0 && len(req.URL) == 0 && len(req.Secret) == 0 && len(req.EventTypes) == 0 &&
+		req.Enabled == nil && req.Memo == nil {
+		return fmt.Errorf("one of the update fields must be set")
+	}
+
+	if len(req.URL) != 0 {
+		if err := corewebhook.ValidateURL(req.URL); err != nil {
+			return err
+		}
+	}
+
+	return validateEventTypes(req.EventTypes)
+}
+
+func validateEventTypes(eventTypes []enumor.WebhookEventType) error {
+	for _, one := range eventTypes {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ------------------------ Delete ------------------------
+
+// SubscriptionDeleteReq delete webhook subscription request.
+type SubscriptionDeleteReq struct {
+	IDs []string `json:"ids" validate:"min=1,max=100"`
+}
+
+// Validate SubscriptionDeleteReq
+func (req *SubscriptionDeleteReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// SubscriptionListResult defines list webhook subscription result.
+type SubscriptionListResult struct {
+	Count   uint64                     `json:"count"`
+	Details []corewebhook.Subscription `json:"details"`
+}
+
+// DeliveryListResult defines list webhook delivery result.
+type DeliveryListResult struct {
+	Count   uint64                 `json:"count"`
+	Details []corewebhook.Delivery `json:"details"`
+}
@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apitoken ...
+package apitoken
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+const (
+	// TokenPlainPrefix API令牌明文的固定前缀，便于识别和密钥扫描
+	TokenPlainPrefix = "hcm_"
+	// TokenDisplayPrefixLen 用于展示的令牌前缀长度
+	TokenDisplayPrefixLen = 12
+	// ServiceAccountPrincipalPrefix 服务账号令牌主体的保留前缀，真实用户名不含冒号，
+	// 服务账号令牌以该前缀加服务账号名称作为用户名鉴权，避免服务账号冒充真实用户
+	ServiceAccountPrincipalPrefix = "sa:"
+)
+
+// HashToken returns the sha256 hex digest of api token plaintext, only the digest is stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ServiceAccountPrincipal returns the api token principal of the service account.
+func ServiceAccountPrincipal(name string) string {
+	return ServiceAccountPrincipalPrefix + name
+}
+
+// ServiceAccountName returns the service account name of the api token principal, returns false if the principal
+// is not a service account principal.
+func ServiceAccountName(principal string) (string, bool) {
+	if !strings.HasPrefix(principal, ServiceAccountPrincipalPrefix) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(principal, ServiceAccountPrincipalPrefix)
+	if len(name) == 0 {
+		return "", false
+	}
+
+	return name, true
+}
+
+// ValidatePrincipal validate api token principal, service account principal must be in the reserved namespace, and
+// user principal must not be in it.
+func ValidatePrincipal(principalType enumor.APITokenPrincipalType, principal string) error {
+	if len(principal) == 0 {
+		return errors.New("api token principal is empty")
+	}
+
+	_, isServiceAccount := ServiceAccountName(principal)
+	switch principalType {
+	case enumor.APITokenPrincipalServiceAccount:
+		if !isServiceAccount {
+			return errors.New("service account principal should start with " + ServiceAccountPrincipalPrefix)
+		}
+	case enumor.APITokenPrincipalUser:
+		if strings.HasPrefix(principal, ServiceAccountPrincipalPrefix) {
+			return errors.New("user principal should not start with " + ServiceAccountPrincipalPrefix)
+		}
+	default:
+		return errors.New("unsupported api token principal type: " + string(principalType))
+	}
+
+	return nil
+}
+
+// ServiceAccount defines service account.
+type ServiceAccount struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Memo          string `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Token defines api token, token plaintext and hash are never returned.
+type Token struct {
+	ID            string                       `json:"id"`
+	Name          string                       `json:"name"`
+	TokenPrefix   string                       `json:"token_prefix"`
+	PrincipalType enumor.APITokenPrincipalType `json:"principal_type"`
+	Principal     string                       `json:"principal"`
+	BkBizIDs      []int64                      `json:"bk_biz_ids"`
+	Actions       []string                     `json:"actions"`
+	ExpireAt      string                       `json:"expire_at"`
+	Revoked       bool                         `json:"revoked"`
+	LastUsedAt    string                       `json:"last_used_at"`
+	Memo          string                       `json:"memo"`
+	core.Revision `json:",inline"`
+}
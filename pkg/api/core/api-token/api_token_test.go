@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package apitoken
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+func TestValidatePrincipal(t *testing.T) {
+	cases := []struct {
+		principalType enumor.APITokenPrincipalType
+		principal     string
+		valid         bool
+	}{
+		{principalType: enumor.APITokenPrincipalServiceAccount, principal: ServiceAccountPrincipal("ci"), valid: true},
+		{principalType: enumor.APITokenPrincipalServiceAccount, principal: "admin", valid: false},
+		{principalType: enumor.APITokenPrincipalServiceAccount, principal: ServiceAccountPrincipalPrefix, valid: false},
+		{principalType: enumor.APITokenPrincipalUser, principal: "admin", valid: true},
+		{principalType: enumor.APITokenPrincipalUser, principal: "sa:ci", valid: false},
+		{principalType: enumor.APITokenPrincipalUser, principal: "", valid: false},
+		{principalType: "unknown", principal: "admin", valid: false},
+	}
+
+	for _, c := range cases {
+		err := ValidatePrincipal(c.principalType, c.principal)
+		if (err == nil) != c.valid {
+			t.Errorf("type: %s, principal: %q, expect valid: %v, got err: %v", c.principalType, c.principal,
+				c.valid, err)
+		}
+	}
+
+	if name, ok := ServiceAccountName(ServiceAccountPrincipal("ci")); !ok || name != "ci" {
+		t.Errorf("service account name of principal should be ci, got: %q, %v", name, ok)
+	}
+}
@@ -78,3 +78,45 @@ type TargetGroupAsyncAuditDetail struct {
 	LoadBalancer tablelb.LoadBalancerTable `json:"load_balancer"`
 	ResFlow      *cloud.ResFlowLockReq     `json:"res_flow"`
 }
+
+// FieldChange define audit field change.
+type FieldChange struct {
+	ID           uint64                   `json:"id"`
+	AuditID      uint64                   `json:"audit_id"`
+	ResID        string                   `json:"res_id"`
+	CloudResID   string                   `json:"cloud_res_id"`
+	ResName      string                   `json:"res_name"`
+	ResType      enumor.AuditResourceType `json:"res_type"`
+	ChildResType enumor.AuditResourceType `json:"child_res_type"`
+	Field        string                   `json:"field"`
+	BeforeValue  string                   `json:"before_value"`
+	AfterValue   string                   `json:"after_value"`
+	BkBizID      int64                    `json:"bk_biz_id"`
+	Vendor       enumor.Vendor            `json:"vendor"`
+	AccountID    string                   `json:"account_id"`
+	Operator     string                   `json:"operator"`
+	Rid          string                   `json:"rid"`
+	CreatedAt    string                   `json:"created_at"`
+}
+
+// Export define audit export.
+type Export struct {
+	ID          string `json:"id"`
+	StartID     uint64 `json:"start_id"`
+	EndID       uint64 `json:"end_id"`
+	RecordCount uint64 `json:"record_count"`
+	PrevHash    string `json:"prev_hash"`
+	LastHash    string `json:"last_hash"`
+	ObjectPath  string `json:"object_path"`
+	Creator     string `json:"creator"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ChangeCount define audit count grouped by account, resource type and action.
+type ChangeCount struct {
+	AccountID string                   `json:"account_id"`
+	Vendor    enumor.Vendor            `json:"vendor"`
+	ResType   enumor.AuditResourceType `json:"res_type"`
+	Action    enumor.AuditAction       `json:"action"`
+	Count     uint64                   `json:"count"`
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+// AllocationRule 共享费用分摊规则
+type AllocationRule struct {
+	ID string `json:"id"`
+	// Name 规则名称
+	Name string `json:"name"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `json:"vendor"`
+	// RootAccountID 一级账号ID
+	RootAccountID string `json:"root_account_id"`
+	// RuleType 分摊方式
+	RuleType enumor.BillAllocationRuleType `json:"rule_type"`
+	// SourceType 共享费用来源
+	SourceType enumor.BillAllocationSourceType `json:"source_type"`
+	// SourceMainAccountIDs 共享费用所在二级账号，来源为账单明细时有效
+	SourceMainAccountIDs []string `json:"source_main_account_ids"`
+	// SourceHcProductCodes 共享费用的产品编码，来源为账单明细时有效
+	SourceHcProductCodes []string `json:"source_hc_product_codes"`
+	// ExcludeMainAccountIDs 不参与分摊的二级账号，按比例分摊时有效
+	ExcludeMainAccountIDs []string `json:"exclude_main_account_ids"`
+	// Targets 固定比例分摊的目标二级账号及比例
+	Targets []AllocationTarget `json:"targets"`
+	// SpArnPrefix 匹配savings plans的ARN前缀，来源为savings_plans时有效，为空时不过滤
+	SpArnPrefix string `json:"sp_arn_prefix"`
+	// CreditIDs 归还给目标账号的credit ID，来源为credits时有效
+	CreditIDs []string `json:"credit_ids"`
+	// Enabled 是否启用
+	Enabled bool `json:"enabled"`
+	// Memo 备注
+	Memo *string `json:"memo"`
+
+	*core.Revision `json:",inline"`
+}
+
+// AllocationTarget 固定比例分摊目标
+type AllocationTarget struct {
+	// MainAccountID 二级账号ID
+	MainAccountID string `json:"main_account_id" validate:"required"`
+	// Ratio 分摊比例，所有目标比例之和必须为1
+	Ratio decimal.Decimal `json:"ratio"`
+}
@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// Archive 账单明细归档记录
+type Archive struct {
+	ID        string                  `json:"id"`
+	Vendor    enumor.Vendor           `json:"vendor"`
+	BillYear  int                     `json:"bill_year"`
+	BillMonth int                     `json:"bill_month"`
+	State     enumor.BillArchiveState `json:"state"`
+	// ObjectPath 归档文件在对象存储中的路径
+	ObjectPath string `json:"object_path"`
+	ItemCount  uint64 `json:"item_count"`
+	// FileSize 归档文件大小，单位为字节
+	FileSize uint64 `json:"file_size"`
+	// FlowID 最近一次归档或恢复的异步任务ID
+	FlowID string `json:"flow_id"`
+	// Reason 最近一次归档或恢复失败的原因
+	Reason     string `json:"reason"`
+	RestoredAt string `json:"restored_at"`
+
+	*core.Revision `json:",inline"`
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/bssintl/v2/model"
 	"github.com/shopspring/decimal"
+	billing "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/billing/v20180709"
 )
 
 // BaseBillItem 存储分账后的明细
@@ -48,6 +49,7 @@ type BaseBillItem struct {
 	HcProductName  string              `json:"hc_product_name,omitempty"`
 	ResAmount      decimal.Decimal     `json:"res_amount,omitempty"`
 	ResAmountUnit  string              `json:"res_amount_unit,omitempty"`
+	CloudResID     string              `json:"cloud_res_id,omitempty"`
 	*core.Revision `json:",inline"`
 }
 
@@ -98,6 +100,7 @@ type BillItemExtension interface {
 
 // TCloudBillItemExtension ...
 type TCloudBillItemExtension struct {
+	*billing.BillDetail `json:",inline"`
 }
 
 // AwsBillItemExtension ...
@@ -163,9 +166,55 @@ type GcpBillItemExtension struct {
 
 // AzureBillItemExtension ...
 type AzureBillItemExtension struct {
+	*AzureRawBillItem `json:",inline"`
 }
 
-// KaopuBillItemExtension ...
+// AzureRawBillItem azure legacy usage detail, 字段与 armconsumption.LegacyUsageDetail 序列化结果一致
+type AzureRawBillItem struct {
+	ID         *string                     `json:"id"`
+	Name       *string                     `json:"name"`
+	Kind       *string                     `json:"kind"`
+	Properties *AzureRawBillItemProperties `json:"properties"`
+}
+
+// AzureRawBillItemProperties azure legacy usage detail properties
+type AzureRawBillItemProperties struct {
+	BillingAccountID       *string               `json:"billingAccountId"`
+	BillingPeriodStartDate *string               `json:"billingPeriodStartDate"`
+	BillingPeriodEndDate   *string               `json:"billingPeriodEndDate"`
+	BillingCurrency        *string               `json:"billingCurrency"`
+	SubscriptionID         *string               `json:"subscriptionId"`
+	SubscriptionName       *string               `json:"subscriptionName"`
+	Date                   *string               `json:"date"`
+	ResourceGroup          *string               `json:"resourceGroup"`
+	ResourceID             *string               `json:"resourceId"`
+	ResourceName           *string               `json:"resourceName"`
+	ResourceLocation       *string               `json:"resourceLocation"`
+	ConsumedService        *string               `json:"consumedService"`
+	Product                *string               `json:"product"`
+	MeterID                *string               `json:"meterId"`
+	MeterDetails           *AzureRawMeterDetails `json:"meterDetails"`
+	ChargeType             *string               `json:"chargeType"`
+	PricingModel           *string               `json:"pricingModel"`
+	Frequency              *string               `json:"frequency"`
+	PublisherType          *string               `json:"publisherType"`
+	ReservationName        *string               `json:"reservationName"`
+	Quantity               *decimal.Decimal      `json:"quantity"`
+	EffectivePrice         *decimal.Decimal      `json:"effectivePrice"`
+	UnitPrice              *decimal.Decimal      `json:"unitPrice"`
+	Cost                   *decimal.Decimal      `json:"cost"`
+}
+
+// AzureRawMeterDetails azure meter details
+type AzureRawMeterDetails struct {
+	MeterName        *string `json:"meterName"`
+	MeterCategory    *string `json:"meterCategory"`
+	MeterSubCategory *string `json:"meterSubCategory"`
+	ServiceFamily    *string `json:"serviceFamily"`
+	UnitOfMeasure    *string `json:"unitOfMeasure"`
+}
+
+// KaopuBillItemExtension 靠谱云暂无原始账单字段，账单明细只包含通用字段
 type KaopuBillItemExtension struct {
 }
 
@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+// Budget 账单预算
+type Budget struct {
+	ID string `json:"id"`
+	// Name 预算名称
+	Name string `json:"name"`
+	// Scope 预算范围
+	Scope enumor.BillBudgetScope `json:"scope"`
+	// BkBizID 业务ID
+	BkBizID int64 `json:"bk_biz_id"`
+	// MainAccountID 二级账号ID
+	MainAccountID string `json:"main_account_id"`
+	// ProductID 运营产品ID
+	ProductID int64 `json:"product_id"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `json:"currency"`
+	// Amount 月度预算金额
+	Amount decimal.Decimal `json:"amount"`
+	// Thresholds 告警阈值，预算金额的百分比
+	Thresholds []int64 `json:"thresholds"`
+	// ForecastAlert 预测月末费用超出预算时是否告警
+	ForecastAlert bool `json:"forecast_alert"`
+	// AnomalyRatio 日环比增长百分比告警阈值，为0时不检测
+	AnomalyRatio int64 `json:"anomaly_ratio"`
+	// Receivers 告警接收人
+	Receivers []string `json:"receivers"`
+	// Memo 备注
+	Memo *string `json:"memo"`
+
+	*core.Revision `json:",inline"`
+}
+
+// BudgetAlert 预算告警记录
+type BudgetAlert struct {
+	ID string `json:"id"`
+	// BudgetID 预算ID
+	BudgetID string `json:"budget_id"`
+	// BillYear 账单年份
+	BillYear int `json:"bill_year"`
+	// BillMonth 账单月份
+	BillMonth int `json:"bill_month"`
+	// BillDay 账单日期
+	BillDay int `json:"bill_day"`
+	// AlertType 告警类型
+	AlertType enumor.BillBudgetAlertType `json:"alert_type"`
+	// Threshold 触发的告警阈值
+	Threshold int64 `json:"threshold"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `json:"currency"`
+	// BudgetAmount 预算金额
+	BudgetAmount decimal.Decimal `json:"budget_amount"`
+	// Cost 当月累计费用，日环比告警时为当日费用
+	Cost decimal.Decimal `json:"cost"`
+	// ForecastCost 预测月末费用
+	ForecastCost decimal.Decimal `json:"forecast_cost"`
+	// Message 告警信息
+	Message string `json:"message"`
+	// CreatedAt 创建时间
+	CreatedAt string `json:"created_at"`
+}
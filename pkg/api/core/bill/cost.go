@@ -31,3 +31,16 @@ type CostWithCurrency struct {
 	RMBCost  decimal.Decimal
 	Currency enumor.CurrencyCode
 }
+
+// ConvertedCost 按指定币种换算后的费用
+type ConvertedCost struct {
+	Currency enumor.CurrencyCode `json:"currency"`
+	Cost     decimal.Decimal     `json:"cost"`
+}
+
+// ResourceCost 按云资源ID聚合的费用，CloudResID 为空表示无法归属到具体资源的费用
+type ResourceCost struct {
+	CloudResID string              `json:"cloud_res_id"`
+	Currency   enumor.CurrencyCode `json:"currency"`
+	Cost       decimal.Decimal     `json:"cost"`
+}
@@ -40,6 +40,10 @@ type ExchangeRate struct {
 	ToCurrency enumor.CurrencyCode `json:"to_currency"`
 	// ExchangeRate 汇率
 	ExchangeRate *decimal.Decimal `json:"exchange_rate"`
+	// Source 汇率来源
+	Source enumor.ExchangeRateSource `json:"source"`
+	// Locked 是否已锁定
+	Locked *bool `json:"locked"`
 
 	*core.Revision `json:",inline"`
 }
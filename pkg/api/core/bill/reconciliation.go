@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+// Reconciliation 一级账号账单对账记录
+type Reconciliation struct {
+	ID            string        `json:"id"`
+	RootAccountID string        `json:"root_account_id"`
+	Vendor        enumor.Vendor `json:"vendor"`
+	BillYear      int           `json:"bill_year"`
+	BillMonth     int           `json:"bill_month"`
+	// SummaryVersion 对账时一级账号账单汇总的版本
+	SummaryVersion int                            `json:"summary_version"`
+	State          enumor.BillReconciliationState `json:"state"`
+	Details        []ReconciliationDetail         `json:"details"`
+	Creator        string                         `json:"creator"`
+	CreatedAt      string                         `json:"created_at"`
+}
+
+// ReconciliationDetail 单个币种的对账结果
+type ReconciliationDetail struct {
+	Currency enumor.CurrencyCode `json:"currency"`
+	// VendorCost 云厂商账单金额
+	VendorCost decimal.Decimal `json:"vendor_cost"`
+	// HcmCost 一级账号账单汇总金额
+	HcmCost decimal.Decimal `json:"hcm_cost"`
+	// DiffCost 差额，为一级账号账单汇总金额减去云厂商账单金额
+	DiffCost decimal.Decimal `json:"diff_cost"`
+	// Tolerance 允许的最大差额
+	Tolerance decimal.Decimal `json:"tolerance"`
+	Matched   bool            `json:"matched"`
+}
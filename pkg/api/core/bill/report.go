@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/runtime/filter"
+)
+
+// ReportSubscription 账单报表订阅
+type ReportSubscription struct {
+	ID string `json:"id"`
+	// Name 订阅名称
+	Name string `json:"name"`
+	// ReportType 报表类型
+	ReportType enumor.BillReportType `json:"report_type"`
+	// Vendor 云厂商，报表类型为账单明细时有效
+	Vendor enumor.Vendor `json:"vendor"`
+	// Filter 报表过滤条件
+	Filter *filter.Expression `json:"filter"`
+	// Format 文件格式
+	Format enumor.BillReportFormat `json:"format"`
+	// Cadence 发送周期
+	Cadence enumor.BillReportCadence `json:"cadence"`
+	// SendDay 每月发送日期，发送周期为每月时有效
+	SendDay int `json:"send_day"`
+	// Receivers 接收人
+	Receivers []string `json:"receivers"`
+	// Enabled 是否启用
+	Enabled bool `json:"enabled"`
+	// LastBillYear 最近一次发送的账单年份
+	LastBillYear int `json:"last_bill_year"`
+	// LastBillMonth 最近一次发送的账单月份
+	LastBillMonth int `json:"last_bill_month"`
+	// Memo 备注
+	Memo *string `json:"memo"`
+
+	*core.Revision `json:",inline"`
+}
@@ -39,6 +39,8 @@ type GcpCvmExtension struct {
 	// Labels: Labels to apply to this instance. These can be later modified
 	// by the setLabels method.
 	Labels map[string]string `json:"labels,omitempty"`
+	// NetworkTags: Tags of the instance, used to identify valid sources or targets for network firewalls.
+	NetworkTags []string `json:"network_tags,omitempty"`
 	// MinCpuPlatform: Specifies a minimum CPU platform for the VM instance.
 	// Applicable values are the friendly names of CPU platforms, such as
 	// minCpuPlatform: "Intel Haswell" or minCpuPlatform: "Intel Sandy
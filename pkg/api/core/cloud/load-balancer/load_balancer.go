@@ -54,6 +54,7 @@ type BaseLoadBalancer struct {
 	CloudCreatedTime     string   `json:"cloud_created_time"`
 	CloudStatusTime      string   `json:"cloud_status_time"`
 	CloudExpiredTime     string   `json:"cloud_expired_time"`
+	RecycleStatus        string   `json:"recycle_status,omitempty"`
 
 	Memo           *string `json:"memo"`
 	*core.Revision `json:",inline"`
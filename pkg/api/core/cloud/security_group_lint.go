@@ -0,0 +1,57 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+)
+
+// SGLintFinding define security group lint finding.
+type SGLintFinding struct {
+	ID                   string            `json:"id"`
+	Vendor               enumor.Vendor     `json:"vendor"`
+	AccountID            string            `json:"account_id"`
+	BkBizID              int64             `json:"bk_biz_id"`
+	Region               string            `json:"region"`
+	SecurityGroupID      string            `json:"security_group_id"`
+	CloudSecurityGroupID string            `json:"cloud_security_group_id"`
+	RuleID               string            `json:"rule_id"`
+	RuleType             string            `json:"rule_type"`
+	LintType             enumor.SGLintType `json:"lint_type"`
+	RiskLevel            enumor.RiskLevel  `json:"risk_level"`
+	RiskScore            uint64            `json:"risk_score"`
+	Detail               *SGLintDetail     `json:"detail"`
+	core.Revision        `json:",inline"`
+}
+
+// SGLintDetail define security group lint finding detail.
+type SGLintDetail struct {
+	// Message 检查结果描述
+	Message string `json:"message"`
+	// Protocol 命中规则的协议
+	Protocol string `json:"protocol,omitempty"`
+	// Ports 命中规则的端口
+	Ports []string `json:"ports,omitempty"`
+	// Addresses 命中规则的源/目的地址
+	Addresses []string `json:"addresses,omitempty"`
+	// RelatedRuleID 覆盖或重复的规则ID
+	RelatedRuleID string `json:"related_rule_id,omitempty"`
+}
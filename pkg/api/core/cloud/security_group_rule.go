@@ -59,6 +59,11 @@ type TCloudSecurityGroupRule struct {
 	UpdatedAt                  string                       `json:"updated_at"`
 }
 
+// GetID ...
+func (sgr TCloudSecurityGroupRule) GetID() string {
+	return sgr.ID
+}
+
 // AwsSecurityGroupRule define aws security group rule.
 type AwsSecurityGroupRule struct {
 	ID                         string                       `json:"id"`
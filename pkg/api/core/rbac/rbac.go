@@ -0,0 +1,117 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地RBAC鉴权相关的通用类型
+package rbac
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/meta"
+)
+
+// Any 通配符，用于角色权限中表示所有资源类型或所有操作
+const Any = "*"
+
+// Permission defines rbac role permission, resource type and actions can be Any.
+type Permission struct {
+	ResType meta.ResourceType `json:"res_type"`
+	Actions []meta.Action     `json:"actions"`
+}
+
+// Validate Permission.
+func (p Permission) Validate() error {
+	if len(p.ResType) == 0 {
+		return errors.New("permission res_type is required")
+	}
+
+	if len(p.Actions) == 0 {
+		return fmt.Errorf("permission actions of res_type %s are required", p.ResType)
+	}
+
+	for _, action := range p.Actions {
+		if len(action) == 0 {
+			return fmt.Errorf("permission action of res_type %s can not be empty", p.ResType)
+		}
+	}
+
+	return nil
+}
+
+// Match returns if the permission contains the action of resource type.
+func (p Permission) Match(resType meta.ResourceType, action meta.Action) bool {
+	if p.ResType != Any && p.ResType != resType {
+		return false
+	}
+
+	for _, one := range p.Actions {
+		if one == Any || one == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidatePermissions validate permissions.
+func ValidatePermissions(permissions []Permission) error {
+	if len(permissions) == 0 {
+		return errors.New("permissions are required")
+	}
+
+	for _, one := range permissions {
+		if err := one.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Role defines rbac role.
+type Role struct {
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	Permissions   []Permission `json:"permissions"`
+	Memo          string       `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Group defines rbac user group.
+type Group struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Members       []string `json:"members"`
+	Memo          string   `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// RoleBinding defines rbac role binding, binds role to user or group in businesses.
+type RoleBinding struct {
+	ID          string                 `json:"id"`
+	RoleID      string                 `json:"role_id"`
+	SubjectType enumor.RBACSubjectType `json:"subject_type"`
+	Subject     string                 `json:"subject"`
+	// BkBizIDs 角色生效的业务，为空表示对所有业务及平台资源生效
+	BkBizIDs      []int64 `json:"bk_biz_ids"`
+	core.Revision `json:",inline"`
+}
@@ -20,6 +20,8 @@
 package recyclerecord
 
 import (
+	corelb "hcm/pkg/api/core/cloud/load-balancer"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/tools/json"
 )
 
@@ -28,6 +30,11 @@ type BaseRecycleDetail struct {
 	ErrorMessage string `json:"error_message,omitempty"`
 }
 
+// ExpireNotifyDetail 回收到期前通知标记，合并写入回收记录详情中，避免重复通知
+type ExpireNotifyDetail struct {
+	ExpireNotified bool `json:"expire_notified"`
+}
+
 // CvmRecycleDetail 包含回收选项、disk，eip 挂载选项
 type CvmRecycleDetail struct {
 	CvmRecycleOptions `json:",inline"`
@@ -100,3 +107,36 @@ type DiskRecycleOptions struct{}
 type DiskRelatedRecycleOpt struct {
 	CvmID string `json:"cvm_id"`
 }
+
+// EipRecycleOptions eip recycle record options.
+type EipRecycleOptions struct{}
+
+// LoadBalancerRecycleDetail 负载均衡回收时保存的监听器配置快照，回收期间监听器仍然保留，销毁时随负载均衡一并删除
+type LoadBalancerRecycleDetail struct {
+	Listeners    []LoadBalancerListenerSnapshot `json:"listeners"`
+	ErrorMessage string                         `json:"error_message,omitempty"`
+}
+
+// LoadBalancerListenerSnapshot 监听器及其下的url规则
+type LoadBalancerListenerSnapshot struct {
+	corelb.TCloudListener `json:",inline"`
+	Rules                 []corelb.TCloudLbUrlRule `json:"rules"`
+}
+
+// SecurityGroupRecycleDetail 安全组回收时保存的规则快照
+type SecurityGroupRecycleDetail struct {
+	Rules        []SGRuleSnapshot `json:"rules"`
+	ErrorMessage string           `json:"error_message,omitempty"`
+}
+
+// SGRuleSnapshot 归一化后的安全组规则
+type SGRuleSnapshot struct {
+	CloudID   string                       `json:"cloud_id"`
+	Type      enumor.SecurityGroupRuleType `json:"type"`
+	Priority  int64                        `json:"priority"`
+	Protocol  string                       `json:"protocol"`
+	Ports     []string                     `json:"ports"`
+	Addresses []string                     `json:"addresses"`
+	Action    enumor.SGPolicyAction        `json:"action"`
+	Memo      string                       `json:"memo"`
+}
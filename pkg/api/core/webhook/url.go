@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ValidateURL validate webhook url, only http and https url is allowed, and url with loopback, link-local or private
+// ip address host is rejected. domain host is checked again after dns resolution when delivering.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url, err: %v", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url scheme %s is not supported, only http and https are allowed", u.Scheme)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if len(host) == 0 {
+		return errors.New("webhook url host is required")
+	}
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("webhook url host should not be localhost")
+	}
+
+	if ip := net.ParseIP(host); ip != nil && IsInternalIP(ip) {
+		return fmt.Errorf("webhook url host %s is an internal address", host)
+	}
+
+	return nil
+}
+
+// IsInternalIP 判断是否为内部地址，包括回环、链路本地、私有、未指定及组播地址，webhook不允许投递到这些地址，
+// 防止通过webhook访问内网服务或云厂商元数据服务
+func IsInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast()
+}
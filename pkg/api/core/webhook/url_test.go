@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package webhook
+
+import (
+	"net"
+	"testing"
+)
+
+func TestValidateURL(t *testing.T) {
+	cases := []struct {
+		url   string
+		valid bool
+	}{
+		{url: "https://cmdb.example.com/hcm/events", valid: true},
+		{url: "http://8.8.8.8:8080/events", valid: true},
+		{url: "ftp://cmdb.example.com/events", valid: false},
+		{url: "file:///etc/passwd", valid: false},
+		{url: "gopher://127.0.0.1:6379/_", valid: false},
+		{url: "http:///events", valid: false},
+		{url: "http://localhost:9600/api/v1/data", valid: false},
+		{url: "http://LOCALHOST./events", valid: false},
+		{url: "http://127.0.0.1:2379/v3/kv/range", valid: false},
+		{url: "http://169.254.169.254/latest/meta-data", valid: false},
+		{url: "http://10.0.0.8/events", valid: false},
+		{url: "http://192.168.1.1/events", valid: false},
+		{url: "http://[::1]:9600/events", valid: false},
+		{url: "http://[fe80::1]/events", valid: false},
+		{url: "http://0.0.0.0/events", valid: false},
+	}
+
+	for _, c := range cases {
+		err := ValidateURL(c.url)
+		if (err == nil) != c.valid {
+			t.Errorf("url: %s, expect valid: %v, got err: %v", c.url, c.valid, err)
+		}
+	}
+}
+
+func TestIsInternalIP(t *testing.T) {
+	internal := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.0.1", "169.254.169.254", "::1", "fe80::1",
+		"fc00::1", "0.0.0.0", "::ffff:127.0.0.1", "224.0.0.1"}
+	for _, one := range internal {
+		if !IsInternalIP(net.ParseIP(one)) {
+			t.Errorf("%s should be internal ip", one)
+		}
+	}
+
+	for _, one := range []string{"8.8.8.8", "172.32.0.1", "2001:4860:4860::8888"} {
+		if IsInternalIP(net.ParseIP(one)) {
+			t.Errorf("%s should not be internal ip", one)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook ...
+package webhook
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/types"
+)
+
+// Subscription defines webhook subscription.
+type Subscription struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Secret 加密后的签名密钥，仅用于服务间传递，不对外返回
+	Secret        string                    `json:"secret,omitempty"`
+	EventTypes    []enumor.WebhookEventType `json:"event_types"`
+	Enabled       bool                      `json:"enabled"`
+	Memo          string                    `json:"memo"`
+	core.Revision `json:",inline"`
+}
+
+// Delivery defines webhook delivery log.
+type Delivery struct {
+	ID             string                      `json:"id"`
+	SubscriptionID string                      `json:"subscription_id"`
+	EventType      enumor.WebhookEventType     `json:"event_type"`
+	EventID        string                      `json:"event_id"`
+	Payload        types.JsonField             `json:"payload"`
+	State          enumor.WebhookDeliveryState `json:"state"`
+	// Attempts 已投递次数
+	Attempts int `json:"attempts"`
+	// StatusCode 最近一次投递的HTTP状态码
+	StatusCode int `json:"status_code"`
+	// Response 最近一次投递的响应状态摘要或错误信息
+	Response      string `json:"response"`
+	FlowID        string `json:"flow_id"`
+	core.Revision `json:",inline"`
+}
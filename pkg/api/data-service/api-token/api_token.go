@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apitoken ...
+package apitoken
+
+import (
+	coreapitoken "hcm/pkg/api/core/api-token"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Service Account --------------------------
+
+// ServiceAccountCreateReq defines create service account request.
+type ServiceAccountCreateReq struct {
+	Name string  `json:"name" validate:"required,max=64"`
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ServiceAccountCreateReq.
+func (req *ServiceAccountCreateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ServiceAccountUpdateReq defines update service account request.
+type ServiceAccountUpdateReq struct {
+	ID   string  `json:"id" validate:"required"`
+	Memo *string `json:"memo" validate:"required,max=255"`
+}
+
+// Validate ServiceAccountUpdateReq.
+func (req *ServiceAccountUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// ServiceAccountListResult defines list service account result.
+type ServiceAccountListResult struct {
+	Count   uint64                        `json:"count,omitempty"`
+	Details []coreapitoken.ServiceAccount `json:"details,omitempty"`
+}
+
+// -------------------------- Token --------------------------
+
+// TokenCreateReq defines create api token request.
+type TokenCreateReq struct {
+	Name string `json:"name" validate:"required,max=64"`
+	// TokenHash 令牌的sha256哈希值，令牌明文不会传递到 data-service
+	TokenHash     string                       `json:"token_hash" validate:"required,len=64"`
+	TokenPrefix   string                       `json:"token_prefix" validate:"required,max=16"`
+	PrincipalType enumor.APITokenPrincipalType `json:"principal_type" validate:"required"`
+	Principal     string                       `json:"principal" validate:"required,max=64"`
+	BkBizIDs      []int64                      `json:"bk_biz_ids" validate:"omitempty"`
+	Actions       []string                     `json:"actions" validate:"required,min=1"`
+	ExpireAt      string                       `json:"expire_at" validate:"required"`
+	Memo          *string                      `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TokenCreateReq.
+func (req *TokenCreateReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if err := req.PrincipalType.Validate(); err != nil {
+		return err
+	}
+
+	return coreapitoken.ValidatePrincipal(req.PrincipalType, req.Principal)
+}
+
+// TokenUpdateReq defines update api token request.
+type TokenUpdateReq struct {
+	ID      string  `json:"id" validate:"required"`
+	Name    string  `json:"name" validate:"omitempty,max=64"`
+	Revoked *bool   `json:"revoked" validate:"omitempty"`
+	Memo    *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate TokenUpdateReq.
+func (req *TokenUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TokenListResult defines list api token result.
+type TokenListResult struct {
+	Count   uint64               `json:"count,omitempty"`
+	Details []coreapitoken.Token `json:"details,omitempty"`
+}
+
+// TokenValidateReq defines validate api token request.
+type TokenValidateReq struct {
+	TokenHash string `json:"token_hash" validate:"required,len=64"`
+}
+
+// Validate TokenValidateReq.
+func (req *TokenValidateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
@@ -21,8 +21,11 @@
 package audit
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"hcm/pkg/api/core"
 	coreasync "hcm/pkg/api/core/async"
 	"hcm/pkg/api/core/audit"
 	"hcm/pkg/criteria/constant"
@@ -59,6 +62,32 @@ type CloudResourceUpdateInfo struct {
 	UpdateFields map[string]interface{}   `json:"update_fields" validate:"required"`
 }
 
+// -------------------------- Create Audit --------------------------
+
+// CloudResourceCreateAuditReq define cloud create audit request when cloud resource is created outside hcm.
+type CloudResourceCreateAuditReq struct {
+	Creates []CloudResourceCreateInfo `json:"creates" validate:"required"`
+}
+
+// Validate CloudResourceCreateAuditReq.
+func (req *CloudResourceCreateAuditReq) Validate() error {
+	if err := validator.Validate.Struct(req); err != nil {
+		return err
+	}
+
+	if len(req.Creates) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("creates shuold <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return nil
+}
+
+// CloudResourceCreateInfo defines cloud resource creates info for audit.
+type CloudResourceCreateInfo struct {
+	ResType enumor.AuditResourceType `json:"res_type" validate:"required"`
+	ResID   string                   `json:"res_id" validate:"required"`
+}
+
 // -------------------------- Delete Audit --------------------------
 
 // CloudResourceDeleteAuditReq define cloud create audit request when cloud resource delete.
@@ -220,6 +249,26 @@ const (
 
 // -------------------------- List --------------------------
 
+// AuditListReq defines list audit request.
+type AuditListReq struct {
+	*core.ListReq `json:",inline"`
+	// Keyword 对资源名称、云资源ID及审计详情进行全文检索
+	Keyword string `json:"keyword" validate:"omitempty,max=128"`
+}
+
+// Validate AuditListReq.
+func (r *AuditListReq) Validate() error {
+	if r.ListReq == nil {
+		return errors.New("list request is required")
+	}
+
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	return r.ListReq.Validate()
+}
+
 // ListResp defines list audit response.
 type ListResp struct {
 	rest.BaseResp `json:",inline"`
@@ -232,6 +281,9 @@ type ListResult struct {
 	Details []audit.Audit `json:"details"`
 }
 
+// FieldChangeListResult defines list audit field change result.
+type FieldChangeListResult = core.ListResultT[audit.FieldChange]
+
 // -------------------------- Get --------------------------
 
 // GetResp defines get audit response.
@@ -247,3 +299,51 @@ type GetAsyncTaskResp struct {
 	Flow  *coreasync.AsyncFlow      `json:"flow"`
 	Tasks []coreasync.AsyncFlowTask `json:"tasks"`
 }
+
+// -------------------------- Export --------------------------
+
+// AuditExportCreateReq defines create audit export request.
+type AuditExportCreateReq struct {
+	// Limit 本次导出的最大记录数
+	Limit uint64 `json:"limit" validate:"omitempty,max=100000"`
+}
+
+// Validate AuditExportCreateReq.
+func (r *AuditExportCreateReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// AuditExportListResult defines list audit export result.
+type AuditExportListResult = core.ListResultT[audit.Export]
+
+// CloudSyncReportMaxDays 云上变更报表单次查询的最大时间跨度（天）
+const CloudSyncReportMaxDays = 92
+
+// CloudSyncReportReq defines query out-of-band change report request.
+type CloudSyncReportReq struct {
+	StartTime  time.Time `json:"start_time" validate:"required"`
+	EndTime    time.Time `json:"end_time" validate:"required"`
+	AccountIDs []string  `json:"account_ids" validate:"omitempty,max=100"`
+}
+
+// Validate CloudSyncReportReq.
+func (r *CloudSyncReportReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if !r.EndTime.After(r.StartTime) {
+		return errors.New("end_time should be after start_time")
+	}
+
+	if r.EndTime.Sub(r.StartTime) > CloudSyncReportMaxDays*24*time.Hour {
+		return fmt.Errorf("time range should <= %d days", CloudSyncReportMaxDays)
+	}
+
+	return nil
+}
+
+// CloudSyncReportResult defines out-of-band change report result.
+type CloudSyncReportResult struct {
+	Details []audit.ChangeCount `json:"details"`
+}
@@ -0,0 +1,179 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// BillAllocationRuleCreateReq ...
+type BillAllocationRuleCreateReq struct {
+	// Name 规则名称
+	Name string `json:"name" validate:"required,max=64"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `json:"vendor" validate:"required"`
+	// RootAccountID 一级账号ID
+	RootAccountID string `json:"root_account_id" validate:"required"`
+	// RuleType 分摊方式
+	RuleType enumor.BillAllocationRuleType `json:"rule_type" validate:"required"`
+	// SourceType 共享费用来源
+	SourceType enumor.BillAllocationSourceType `json:"source_type" validate:"required"`
+	// SourceMainAccountIDs 共享费用所在二级账号，来源为账单明细时与产品编码至少填写一项
+	SourceMainAccountIDs []string `json:"source_main_account_ids" validate:"omitempty,max=100"`
+	// SourceHcProductCodes 共享费用的产品编码，来源为账单明细时与二级账号至少填写一项
+	SourceHcProductCodes []string `json:"source_hc_product_codes" validate:"omitempty,max=100"`
+	// ExcludeMainAccountIDs 不参与分摊的二级账号
+	ExcludeMainAccountIDs []string `json:"exclude_main_account_ids" validate:"omitempty,max=500"`
+	// Targets 固定比例分摊的目标二级账号及比例，分摊方式为固定比例时必填
+	Targets []bill.AllocationTarget `json:"targets" validate:"omitempty,max=500,dive"`
+	// SpArnPrefix 匹配savings plans的ARN前缀，仅来源为savings_plans时允许填写
+	SpArnPrefix string `json:"sp_arn_prefix" validate:"omitempty,max=255"`
+	// CreditIDs 归还给目标账号的credit ID，来源为credits时必填
+	CreditIDs []string `json:"credit_ids" validate:"omitempty,max=100"`
+	// Enabled 是否启用，默认启用
+	Enabled *bool `json:"enabled" validate:"omitempty"`
+	// Memo 备注
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BillAllocationRuleCreateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if err := r.Vendor.Validate(); err != nil {
+		return err
+	}
+	return ValidateAllocationRule(&bill.AllocationRule{
+		Vendor:               r.Vendor,
+		RuleType:             r.RuleType,
+		SourceType:           r.SourceType,
+		SourceMainAccountIDs: r.SourceMainAccountIDs,
+		SourceHcProductCodes: r.SourceHcProductCodes,
+		Targets:              r.Targets,
+		SpArnPrefix:          r.SpArnPrefix,
+		CreditIDs:            r.CreditIDs,
+	})
+}
+
+// ValidateAllocationRule 校验分摊方式与费用来源、分摊目标的组合是否合法
+func ValidateAllocationRule(rule *bill.AllocationRule) error {
+	if err := rule.RuleType.Validate(); err != nil {
+		return err
+	}
+	if err := rule.SourceType.Validate(); err != nil {
+		return err
+	}
+	if vendor := rule.SourceType.Vendor(); len(vendor) != 0 && vendor != rule.Vendor {
+		return fmt.Errorf("source_type %s is only supported by vendor %s", rule.SourceType, vendor)
+	}
+	if rule.SourceType == enumor.BillAllocationSourceBillItem &&
+		len(rule.SourceMainAccountIDs) == 0 && len(rule.SourceHcProductCodes) == 0 {
+		return errors.New("source_main_account_ids or source_hc_product_codes is required when source_type " +
+			"is bill_item")
+	}
+	if rule.SourceType != enumor.BillAllocationSourceSavingsPlans && len(rule.SpArnPrefix) != 0 {
+		return fmt.Errorf("sp_arn_prefix is only allowed when source_type is %s",
+			enumor.BillAllocationSourceSavingsPlans)
+	}
+	if rule.SourceType != enumor.BillAllocationSourceCredits && len(rule.CreditIDs) != 0 {
+		return fmt.Errorf("credit_ids is only allowed when source_type is %s", enumor.BillAllocationSourceCredits)
+	}
+	if rule.SourceType == enumor.BillAllocationSourceCredits && len(rule.CreditIDs) == 0 {
+		return fmt.Errorf("credit_ids is required when source_type is %s", enumor.BillAllocationSourceCredits)
+	}
+	if rule.RuleType != enumor.BillAllocationFixed {
+		if len(rule.SourceType.Vendor()) != 0 {
+			// savings plans、credit归还给唯一的账号
+			return fmt.Errorf("rule_type must be %s when source_type is %s", enumor.BillAllocationFixed,
+				rule.SourceType)
+		}
+		if len(rule.Targets) != 0 {
+			return fmt.Errorf("targets is only allowed when rule_type is %s", enumor.BillAllocationFixed)
+		}
+		return nil
+	}
+
+	return validateAllocationTargets(rule.SourceType, rule.Targets)
+}
+
+func validateAllocationTargets(sourceType enumor.BillAllocationSourceType, targets []bill.AllocationTarget) error {
+	if len(targets) == 0 {
+		return fmt.Errorf("targets is required when rule_type is %s", enumor.BillAllocationFixed)
+	}
+	if len(sourceType.Vendor()) != 0 && len(targets) != 1 {
+		return fmt.Errorf("only one target is allowed when source_type is %s", sourceType)
+	}
+	total := decimal.Zero
+	targetMap := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		if _, exist := targetMap[target.MainAccountID]; exist {
+			return fmt.Errorf("duplicate target main account: %s", target.MainAccountID)
+		}
+		targetMap[target.MainAccountID] = struct{}{}
+		if !target.Ratio.IsPositive() {
+			return fmt.Errorf("ratio of target main account %s must be positive", target.MainAccountID)
+		}
+		total = total.Add(target.Ratio)
+	}
+	if !total.Equal(decimal.NewFromInt(1)) {
+		return fmt.Errorf("sum of target ratio must be 1, got: %s", total.String())
+	}
+	return nil
+}
+
+// BillAllocationRuleUpdateReq ...
+type BillAllocationRuleUpdateReq struct {
+	ID                    string                        `json:"id" validate:"required"`
+	Name                  string                        `json:"name" validate:"omitempty,max=64"`
+	RuleType              enumor.BillAllocationRuleType `json:"rule_type" validate:"omitempty"`
+	SourceMainAccountIDs  []string                      `json:"source_main_account_ids" validate:"omitempty,max=100"`
+	SourceHcProductCodes  []string                      `json:"source_hc_product_codes" validate:"omitempty,max=100"`
+	ExcludeMainAccountIDs []string                      `json:"exclude_main_account_ids" validate:"omitempty,max=500"`
+	Targets               []bill.AllocationTarget       `json:"targets" validate:"omitempty,max=500,dive"`
+	SpArnPrefix           *string                       `json:"sp_arn_prefix" validate:"omitempty,max=255"`
+	CreditIDs             []string                      `json:"credit_ids" validate:"omitempty,max=100"`
+	Enabled               *bool                         `json:"enabled" validate:"omitempty"`
+	Memo                  *string                       `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BillAllocationRuleUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if len(r.RuleType) != 0 {
+		if err := r.RuleType.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BillAllocationRuleListResult ...
+type BillAllocationRuleListResult = core.ListResultT[bill.AllocationRule]
@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"testing"
+
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateAllocationRule(t *testing.T) {
+	oneTarget := []bill.AllocationTarget{{MainAccountID: "m1", Ratio: decimal.NewFromInt(1)}}
+	twoTargets := []bill.AllocationTarget{
+		{MainAccountID: "m1", Ratio: decimal.NewFromFloat(0.5)},
+		{MainAccountID: "m2", Ratio: decimal.NewFromFloat(0.5)},
+	}
+
+	cases := []struct {
+		name    string
+		rule    bill.AllocationRule
+		wantErr bool
+	}{
+		{name: "bill item fixed", rule: bill.AllocationRule{Vendor: enumor.Aws, RuleType: enumor.BillAllocationFixed,
+			SourceType: enumor.BillAllocationSourceBillItem, SourceMainAccountIDs: []string{"m0"},
+			Targets: twoTargets}},
+		{name: "bill item without source", rule: bill.AllocationRule{Vendor: enumor.Aws,
+			RuleType: enumor.BillAllocationUsage, SourceType: enumor.BillAllocationSourceBillItem}, wantErr: true},
+		{name: "savings plans", rule: bill.AllocationRule{Vendor: enumor.Aws, RuleType: enumor.BillAllocationFixed,
+			SourceType: enumor.BillAllocationSourceSavingsPlans, SpArnPrefix: "arn:aws:savingsplans::1:",
+			Targets: oneTarget}},
+		{name: "savings plans of gcp", rule: bill.AllocationRule{Vendor: enumor.Gcp,
+			RuleType: enumor.BillAllocationFixed, SourceType: enumor.BillAllocationSourceSavingsPlans,
+			Targets: oneTarget}, wantErr: true},
+		{name: "savings plans by usage", rule: bill.AllocationRule{Vendor: enumor.Aws,
+			RuleType: enumor.BillAllocationUsage, SourceType: enumor.BillAllocationSourceSavingsPlans},
+			wantErr: true},
+		{name: "savings plans with two targets", rule: bill.AllocationRule{Vendor: enumor.Aws,
+			RuleType: enumor.BillAllocationFixed, SourceType: enumor.BillAllocationSourceSavingsPlans,
+			Targets: twoTargets}, wantErr: true},
+		{name: "credits", rule: bill.AllocationRule{Vendor: enumor.Gcp, RuleType: enumor.BillAllocationFixed,
+			SourceType: enumor.BillAllocationSourceCredits, CreditIDs: []string{"c1"}, Targets: oneTarget}},
+		{name: "credits without credit id", rule: bill.AllocationRule{Vendor: enumor.Gcp,
+			RuleType: enumor.BillAllocationFixed, SourceType: enumor.BillAllocationSourceCredits,
+			Targets: oneTarget}, wantErr: true},
+		{name: "credit id of common expense", rule: bill.AllocationRule{Vendor: enumor.Gcp,
+			RuleType: enumor.BillAllocationUsage, SourceType: enumor.BillAllocationSourceCommonExpense,
+			CreditIDs: []string{"c1"}}, wantErr: true},
+		{name: "arn prefix of bill item", rule: bill.AllocationRule{Vendor: enumor.Aws,
+			RuleType: enumor.BillAllocationUsage, SourceType: enumor.BillAllocationSourceBillItem,
+			SourceHcProductCodes: []string{"AmazonVPC"}, SpArnPrefix: "arn"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		err := ValidateAllocationRule(&c.rule)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: expect error %v, got: %v", c.name, c.wantErr, err)
+		}
+	}
+}
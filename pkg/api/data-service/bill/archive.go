@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+)
+
+// BillArchiveCreateReq ...
+type BillArchiveCreateReq struct {
+	Vendor    enumor.Vendor           `json:"vendor" validate:"required"`
+	BillYear  int                     `json:"bill_year" validate:"required"`
+	BillMonth int                     `json:"bill_month" validate:"required,min=1,max=12"`
+	State     enumor.BillArchiveState `json:"state" validate:"required"`
+}
+
+// Validate ...
+func (r *BillArchiveCreateReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// BillArchiveUpdateReq ...
+type BillArchiveUpdateReq struct {
+	ID         string                  `json:"id" validate:"required"`
+	State      enumor.BillArchiveState `json:"state" validate:"omitempty"`
+	ObjectPath string                  `json:"object_path" validate:"omitempty,max=255"`
+	ItemCount  *uint64                 `json:"item_count" validate:"omitempty"`
+	FileSize   *uint64                 `json:"file_size" validate:"omitempty"`
+	FlowID     string                  `json:"flow_id" validate:"omitempty,max=64"`
+	// Reason 为空字符串时清空失败原因
+	Reason     *string `json:"reason" validate:"omitempty,max=1024"`
+	RestoredAt string  `json:"restored_at" validate:"omitempty,max=32"`
+}
+
+// Validate ...
+func (r *BillArchiveUpdateReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// BillArchiveListResult ...
+type BillArchiveListResult = core.ListResultT[bill.Archive]
+
+// BillItemPartitionReq 指定云厂商、月份的账单明细分表
+type BillItemPartitionReq = ItemCommonOpt
+
+// BillItemPartitionListResult ...
+type BillItemPartitionListResult = core.ListResultT[typesbill.BillItemPartition]
@@ -63,6 +63,7 @@ type BillItemCreateReq[E bill.BillItemExtension] struct {
 	HcProductName string              `json:"hc_product_name,omitempty"`
 	ResAmount     decimal.Decimal     `json:"res_amount,omitempty"`
 	ResAmountUnit string              `json:"res_amount_unit,omitempty"`
+	CloudResID    string              `json:"cloud_res_id,omitempty"`
 	Extension     *E                  `json:"extension"`
 }
 
@@ -97,6 +98,9 @@ func (r *BillItemListReq) Validate() error {
 // BillItemBaseListResult ...
 type BillItemBaseListResult = core.ListResultT[*bill.BaseBillItem]
 
+// BillItemResCostListResult ...
+type BillItemResCostListResult = core.ListResultT[*bill.ResourceCost]
+
 // TCloudBillItemListResult ...
 type TCloudBillItemListResult = core.ListResultT[*bill.TCloudBillItem]
 
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+
+	"github.com/shopspring/decimal"
+)
+
+// BillBudgetCreateReq ...
+type BillBudgetCreateReq struct {
+	// Name 预算名称
+	Name string `json:"name" validate:"required,max=64"`
+	// Scope 预算范围
+	Scope enumor.BillBudgetScope `json:"scope" validate:"required"`
+	// BkBizID 业务ID，预算范围为业务时必填
+	BkBizID int64 `json:"bk_biz_id" validate:"omitempty"`
+	// MainAccountID 二级账号ID，预算范围为二级账号时必填
+	MainAccountID string `json:"main_account_id" validate:"omitempty,max=64"`
+	// ProductID 运营产品ID，预算范围为运营产品时必填
+	ProductID int64 `json:"product_id" validate:"omitempty"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `json:"currency" validate:"required"`
+	// Amount 月度预算金额
+	Amount decimal.Decimal `json:"amount" validate:"required"`
+	// Thresholds 告警阈值，预算金额的百分比
+	Thresholds []int64 `json:"thresholds" validate:"omitempty,max=10,dive,gt=0,lte=1000"`
+	// ForecastAlert 预测月末费用超出预算时是否告警
+	ForecastAlert bool `json:"forecast_alert" validate:"omitempty"`
+	// AnomalyRatio 日环比增长百分比告警阈值，为0时不检测
+	AnomalyRatio int64 `json:"anomaly_ratio" validate:"omitempty,gte=0"`
+	// Receivers 告警接收人
+	Receivers []string `json:"receivers" validate:"required,min=1,max=20"`
+	// Memo 备注
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BillBudgetCreateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if err := r.Scope.Validate(); err != nil {
+		return err
+	}
+
+	switch r.Scope {
+	case enumor.BillBudgetScopeBiz:
+		if r.BkBizID <= 0 {
+			return errors.New("bk_biz_id is required when scope is biz")
+		}
+	case enumor.BillBudgetScopeMainAccount:
+		if len(r.MainAccountID) == 0 {
+			return errors.New("main_account_id is required when scope is main_account")
+		}
+	case enumor.BillBudgetScopeProduct:
+		if r.ProductID <= 0 {
+			return errors.New("product_id is required when scope is product")
+		}
+	}
+
+	if !r.Amount.IsPositive() {
+		return errors.New("amount must be greater than 0")
+	}
+
+	return nil
+}
+
+// BillBudgetUpdateReq ...
+type BillBudgetUpdateReq struct {
+	ID            string           `json:"id" validate:"required"`
+	Name          string           `json:"name" validate:"omitempty,max=64"`
+	Amount        *decimal.Decimal `json:"amount" validate:"omitempty"`
+	Thresholds    []int64          `json:"thresholds" validate:"omitempty,max=10,dive,gt=0,lte=1000"`
+	ForecastAlert *bool            `json:"forecast_alert" validate:"omitempty"`
+	AnomalyRatio  *int64           `json:"anomaly_ratio" validate:"omitempty,gte=0"`
+	Receivers     []string         `json:"receivers" validate:"omitempty,max=20"`
+	Memo          *string          `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BillBudgetUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if r.Amount != nil && !r.Amount.IsPositive() {
+		return errors.New("amount must be greater than 0")
+	}
+
+	return nil
+}
+
+// BillBudgetListResult ...
+type BillBudgetListResult = core.ListResultT[bill.Budget]
+
+// BatchCreateBillBudgetAlertReq ...
+type BatchCreateBillBudgetAlertReq struct {
+	Alerts []BillBudgetAlertCreate `json:"alerts" validate:"required,min=1,max=100,dive,required"`
+}
+
+// Validate ...
+func (r *BatchCreateBillBudgetAlertReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// BillBudgetAlertCreate ...
+type BillBudgetAlertCreate struct {
+	BudgetID     string                     `json:"budget_id" validate:"required"`
+	BillYear     int                        `json:"bill_year" validate:"required"`
+	BillMonth    int                        `json:"bill_month" validate:"required,min=1,max=12"`
+	BillDay      int                        `json:"bill_day" validate:"omitempty,min=0,max=31"`
+	AlertType    enumor.BillBudgetAlertType `json:"alert_type" validate:"required"`
+	Threshold    int64                      `json:"threshold" validate:"omitempty"`
+	Currency     enumor.CurrencyCode        `json:"currency" validate:"required"`
+	BudgetAmount decimal.Decimal            `json:"budget_amount" validate:"omitempty"`
+	Cost         decimal.Decimal            `json:"cost" validate:"omitempty"`
+	ForecastCost decimal.Decimal            `json:"forecast_cost" validate:"omitempty"`
+	Message      string                     `json:"message" validate:"omitempty,max=1024"`
+}
+
+// BillBudgetAlertListResult ...
+type BillBudgetAlertListResult = core.ListResultT[bill.BudgetAlert]
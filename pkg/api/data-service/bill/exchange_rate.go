@@ -45,6 +45,10 @@ type ExchangeRateCreate struct {
 	ToCurrency enumor.CurrencyCode `json:"to_currency" validate:"required"`
 	// ExchangeRate 汇率
 	ExchangeRate *decimal.Decimal `json:"exchange_rate" validate:"required"`
+	// Source 汇率来源，为空时为手动录入
+	Source enumor.ExchangeRateSource `json:"source"`
+	// Locked 是否锁定
+	Locked *bool `json:"locked"`
 }
 
 // Validate ...
@@ -70,6 +74,10 @@ type ExchangeRateUpdateReq struct {
 	ToCurrency enumor.CurrencyCode `json:"to_currency"`
 	// ExchangeRate 汇率
 	ExchangeRate *decimal.Decimal `json:"exchange_rate" `
+	// Source 汇率来源
+	Source enumor.ExchangeRateSource `json:"source"`
+	// Locked 是否锁定
+	Locked *bool `json:"locked"`
 }
 
 // Validate ...
@@ -0,0 +1,56 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// BillReconciliationCreateReq ...
+type BillReconciliationCreateReq struct {
+	RootAccountID  string                         `json:"root_account_id" validate:"required"`
+	Vendor         enumor.Vendor                  `json:"vendor" validate:"required"`
+	BillYear       int                            `json:"bill_year" validate:"required"`
+	BillMonth      int                            `json:"bill_month" validate:"required,min=1,max=12"`
+	SummaryVersion int                            `json:"summary_version" validate:"min=0"`
+	State          enumor.BillReconciliationState `json:"state" validate:"required"`
+	Details        []bill.ReconciliationDetail    `json:"details" validate:"max=100"`
+}
+
+// Validate ...
+func (r *BillReconciliationCreateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	switch r.State {
+	case enumor.BillReconciliationMatched, enumor.BillReconciliationMismatched, enumor.BillReconciliationUnsupported:
+	default:
+		return errors.New("invalid reconciliation state: " + string(r.State))
+	}
+	return nil
+}
+
+// BillReconciliationListResult ...
+type BillReconciliationListResult = core.ListResultT[bill.Reconciliation]
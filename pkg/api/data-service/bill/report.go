@@ -0,0 +1,115 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/api/core/bill"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/runtime/filter"
+)
+
+// BillReportSubCreateReq ...
+type BillReportSubCreateReq struct {
+	// Name 订阅名称
+	Name string `json:"name" validate:"required,max=64"`
+	// ReportType 报表类型
+	ReportType enumor.BillReportType `json:"report_type" validate:"required"`
+	// Vendor 云厂商，报表类型为账单明细时必填
+	Vendor enumor.Vendor `json:"vendor" validate:"omitempty"`
+	// Filter 报表过滤条件
+	Filter *filter.Expression `json:"filter" validate:"omitempty"`
+	// Format 文件格式
+	Format enumor.BillReportFormat `json:"format" validate:"required"`
+	// Cadence 发送周期
+	Cadence enumor.BillReportCadence `json:"cadence" validate:"required"`
+	// SendDay 每月发送日期，发送周期为每月时必填
+	SendDay int `json:"send_day" validate:"omitempty,min=1,max=28"`
+	// Receivers 接收人
+	Receivers []string `json:"receivers" validate:"required,min=1,max=20"`
+	// Enabled 是否启用，默认启用
+	Enabled *bool `json:"enabled" validate:"omitempty"`
+	// Memo 备注
+	Memo *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate ...
+func (r *BillReportSubCreateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if err := r.ReportType.Validate(); err != nil {
+		return err
+	}
+	if err := r.Format.Validate(); err != nil {
+		return err
+	}
+	if err := r.Cadence.Validate(); err != nil {
+		return err
+	}
+	if r.ReportType == enumor.BillReportBillItem && len(r.Vendor) == 0 {
+		return errors.New("vendor is required when report_type is bill_item")
+	}
+	if r.Cadence == enumor.BillReportCadenceMonthly && r.SendDay == 0 {
+		return errors.New("send_day is required when cadence is monthly")
+	}
+	return nil
+}
+
+// BillReportSubUpdateReq ...
+type BillReportSubUpdateReq struct {
+	ID        string                   `json:"id" validate:"required"`
+	Name      string                   `json:"name" validate:"omitempty,max=64"`
+	Filter    *filter.Expression       `json:"filter" validate:"omitempty"`
+	Format    enumor.BillReportFormat  `json:"format" validate:"omitempty"`
+	Cadence   enumor.BillReportCadence `json:"cadence" validate:"omitempty"`
+	SendDay   int                      `json:"send_day" validate:"omitempty,min=1,max=28"`
+	Receivers []string                 `json:"receivers" validate:"omitempty,max=20"`
+	Enabled   *bool                    `json:"enabled" validate:"omitempty"`
+	Memo      *string                  `json:"memo" validate:"omitempty,max=255"`
+	// LastBillYear 最近一次发送的账单年份，由报表发送任务更新
+	LastBillYear int `json:"last_bill_year" validate:"omitempty"`
+	// LastBillMonth 最近一次发送的账单月份，由报表发送任务更新
+	LastBillMonth int `json:"last_bill_month" validate:"omitempty,min=1,max=12"`
+}
+
+// Validate ...
+func (r *BillReportSubUpdateReq) Validate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+	if len(r.Format) != 0 {
+		if err := r.Format.Validate(); err != nil {
+			return err
+		}
+	}
+	if len(r.Cadence) != 0 {
+		if err := r.Cadence.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BillReportSubListResult ...
+type BillReportSubListResult = core.ListResultT[bill.ReportSubscription]
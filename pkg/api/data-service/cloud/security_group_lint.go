@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"fmt"
+
+	corecloud "hcm/pkg/api/core/cloud"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Create --------------------------
+
+// SGLintFindingBatchCreateReq ...
+type SGLintFindingBatchCreateReq struct {
+	Findings []SGLintFindingCreate `json:"findings" validate:"required,min=1"`
+}
+
+// SGLintFindingCreate ...
+type SGLintFindingCreate struct {
+	Vendor               enumor.Vendor           `json:"vendor" validate:"required"`
+	AccountID            string                  `json:"account_id" validate:"required"`
+	BkBizID              int64                   `json:"bk_biz_id" validate:"omitempty"`
+	Region               string                  `json:"region" validate:"omitempty"`
+	SecurityGroupID      string                  `json:"security_group_id" validate:"required"`
+	CloudSecurityGroupID string                  `json:"cloud_security_group_id" validate:"omitempty"`
+	RuleID               string                  `json:"rule_id" validate:"omitempty"`
+	RuleType             string                  `json:"rule_type" validate:"omitempty"`
+	LintType             enumor.SGLintType       `json:"lint_type" validate:"required"`
+	RiskLevel            enumor.RiskLevel        `json:"risk_level" validate:"required"`
+	RiskScore            uint64                  `json:"risk_score" validate:"omitempty"`
+	Detail               *corecloud.SGLintDetail `json:"detail" validate:"omitempty"`
+}
+
+// Validate security group lint finding create request.
+func (req *SGLintFindingBatchCreateReq) Validate() error {
+	if len(req.Findings) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("findings count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// SGLintFindingListResult define security group lint finding list result.
+type SGLintFindingListResult struct {
+	Count   uint64                    `json:"count,omitempty"`
+	Details []corecloud.SGLintFinding `json:"details,omitempty"`
+}
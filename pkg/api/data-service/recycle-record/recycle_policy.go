@@ -0,0 +1,77 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recyclerecord
+
+import (
+	"fmt"
+
+	rr "hcm/pkg/api/core/recycle-record"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+)
+
+// -------------------------- Create --------------------------
+
+// RecyclePolicyBatchCreateReq defines batch create recycle policy request.
+type RecyclePolicyBatchCreateReq struct {
+	Policies []RecyclePolicyCreate `json:"policies" validate:"required,min=1"`
+}
+
+// RecyclePolicyCreate defines create one recycle policy request.
+type RecyclePolicyCreate struct {
+	BkBizID      int64                    `json:"bk_biz_id" validate:"required,min=-1"`
+	ResType      enumor.CloudResourceType `json:"res_type" validate:"required"`
+	ReserveTime  int                      `json:"reserve_time" validate:"required,min=1"`
+	NotifyBefore int                      `json:"notify_before" validate:"omitempty,min=0"`
+	Memo         *string                  `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RecyclePolicyBatchCreateReq.
+func (req *RecyclePolicyBatchCreateReq) Validate() error {
+	if len(req.Policies) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("policies count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- Update --------------------------
+
+// RecyclePolicyUpdateReq defines update recycle policy request.
+type RecyclePolicyUpdateReq struct {
+	ID           string  `json:"id" validate:"required"`
+	ReserveTime  int     `json:"reserve_time" validate:"omitempty,min=1"`
+	NotifyBefore int     `json:"notify_before" validate:"omitempty,min=0"`
+	Memo         *string `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate RecyclePolicyUpdateReq.
+func (req *RecyclePolicyUpdateReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// -------------------------- List --------------------------
+
+// RecyclePolicyListResult defines list recycle policy result.
+type RecyclePolicyListResult struct {
+	Count   uint64             `json:"count,omitempty"`
+	Details []rr.RecyclePolicy `json:"details,omitempty"`
+}
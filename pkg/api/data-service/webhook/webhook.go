@@ -0,0 +1,150 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook ...
+package webhook
+
+import (
+	"fmt"
+
+	corewebhook "hcm/pkg/api/core/webhook"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table/types"
+)
+
+// -------------------------- Subscription --------------------------
+
+// SubscriptionBatchCreateReq defines batch create webhook subscription request.
+type SubscriptionBatchCreateReq struct {
+	Subscriptions []SubscriptionCreate `json:"subscriptions" validate:"required,min=1,dive"`
+}
+
+// SubscriptionCreate defines create one webhook subscription request.
+type SubscriptionCreate struct {
+	Name string `json:"name" validate:"required,max=64"`
+	URL  string `json:"url" validate:"required,max=1024,url"`
+	// Secret 加密后的签名密钥
+	Secret     string                    `json:"secret" validate:"omitempty,max=255"`
+	EventTypes []enumor.WebhookEventType `json:"event_types" validate:"required,min=1"`
+	Enabled    *bool                     `json:"enabled" validate:"required"`
+	Memo       *string                   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionBatchCreateReq.
+func (req *SubscriptionBatchCreateReq) Validate() error {
+	if len(req.Subscriptions) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("subscriptions count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	for _, one := range req.Subscriptions {
+		for _, eventType := range one.EventTypes {
+			if err := eventType.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// SubscriptionUpdateReq defines update webhook subscription request.
+type SubscriptionUpdateReq struct {
+	ID   string `json:"id" validate:"required"`
+	Name string `json:"name" validate:"omitempty,max=64"`
+	URL  string `json:"url" validate:"omitempty,max=1024,url"`
+	// Secret 加密后的签名密钥
+	Secret     string                    `json:"secret" validate:"omitempty,max=255"`
+	EventTypes []enumor.WebhookEventType `json:"event_types" validate:"omitempty"`
+	Enabled    *bool                     `json:"enabled" validate:"omitempty"`
+	Memo       *string                   `json:"memo" validate:"omitempty,max=255"`
+}
+
+// Validate SubscriptionUpdateReq.
+func (req *SubscriptionUpdateReq) Validate() error {
+	for _, eventType := range req.EventTypes {
+		if err := eventType.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// SubscriptionListResult defines list webhook subscription result.
+type SubscriptionListResult struct {
+	Count   uint64                     `json:"count,omitempty"`
+	Details []corewebhook.Subscription `json:"details,omitempty"`
+}
+
+// -------------------------- Delivery --------------------------
+
+// DeliveryBatchCreateReq defines batch create webhook delivery request.
+type DeliveryBatchCreateReq struct {
+	Deliveries []DeliveryCreate `json:"deliveries" validate:"required,min=1,dive"`
+}
+
+// DeliveryCreate defines create one webhook delivery request.
+type DeliveryCreate struct {
+	SubscriptionID string                      `json:"subscription_id" validate:"required"`
+	EventType      enumor.WebhookEventType     `json:"event_type" validate:"required"`
+	EventID        string                      `json:"event_id" validate:"required"`
+	Payload        types.JsonField             `json:"payload" validate:"required"`
+	State          enumor.WebhookDeliveryState `json:"state" validate:"required"`
+}
+
+// Validate DeliveryBatchCreateReq.
+func (req *DeliveryBatchCreateReq) Validate() error {
+	if len(req.Deliveries) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("deliveries count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DeliveryBatchUpdateReq defines batch update webhook delivery request.
+type DeliveryBatchUpdateReq struct {
+	Deliveries []DeliveryUpdate `json:"deliveries" validate:"required,min=1,dive"`
+}
+
+// DeliveryUpdate defines update one webhook delivery request.
+type DeliveryUpdate struct {
+	ID         string                      `json:"id" validate:"required"`
+	State      enumor.WebhookDeliveryState `json:"state" validate:"omitempty"`
+	Attempts   int                         `json:"attempts" validate:"omitempty,min=0"`
+	StatusCode int                         `json:"status_code" validate:"omitempty,min=0"`
+	Response   *string                     `json:"response" validate:"omitempty,max=1024"`
+	FlowID     string                      `json:"flow_id" validate:"omitempty"`
+}
+
+// Validate DeliveryBatchUpdateReq.
+func (req *DeliveryBatchUpdateReq) Validate() error {
+	if len(req.Deliveries) > constant.BatchOperationMaxLimit {
+		return fmt.Errorf("deliveries count should <= %d", constant.BatchOperationMaxLimit)
+	}
+
+	return validator.Validate.Struct(req)
+}
+
+// DeliveryListResult defines list webhook delivery result.
+type DeliveryListResult struct {
+	Count   uint64                 `json:"count,omitempty"`
+	Details []corewebhook.Delivery `json:"details,omitempty"`
+}
@@ -407,3 +407,35 @@ type AzureRootBillListReq struct {
 func (r *AzureRootBillListReq) Validate() error {
 	return validator.Validate.Struct(r)
 }
+
+// -------------------------- Month Total --------------------------
+
+// RootAccountMonthTotalReq 查询一级账号云厂商月度账单总额
+type RootAccountMonthTotalReq struct {
+	RootAccountID string `json:"root_account_id" validate:"required"`
+	BillYear      uint   `json:"bill_year" validate:"required"`
+	BillMonth     uint   `json:"bill_month" validate:"required,min=1,max=12"`
+}
+
+// Validate ...
+func (r *RootAccountMonthTotalReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
+
+// RootAccountMonthTotalResult 一级账号云厂商月度账单总额，按币种汇总
+type RootAccountMonthTotalResult struct {
+	Details []typesBill.CurrencyTotal `json:"details"`
+}
+
+// AwsRootSpAnalysisReq 查询一级账号 savings plans 使用率及EC2实例覆盖情况
+type AwsRootSpAnalysisReq struct {
+	RootAccountID string `json:"root_account_id" validate:"required"`
+	BillYear      uint   `json:"bill_year" validate:"required"`
+	BillMonth     uint   `json:"bill_month" validate:"required,min=1,max=12"`
+	SpArnPrefix   string `json:"sp_arn_prefix" validate:"omitempty"`
+}
+
+// Validate ...
+func (r *AwsRootSpAnalysisReq) Validate() error {
+	return validator.Validate.Struct(r)
+}
@@ -161,3 +161,27 @@ type BatchCreateResp struct {
 	rest.BaseResp `json:",inline"`
 	Data          *BatchCreateResult `json:"data"`
 }
+
+// TCloudListReservedInstanceReq ...
+type TCloudListReservedInstanceReq struct {
+	AccountID string `json:"account_id" validate:"required"`
+	Region    string `json:"region" validate:"required"`
+	// States 预留实例状态（active、pending、retired），为空时查询所有状态
+	States []string `json:"states" validate:"omitempty,max=5"`
+}
+
+// Validate request.
+func (req *TCloudListReservedInstanceReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
+
+// TCloudListReservedInstanceOfferingReq ...
+type TCloudListReservedInstanceOfferingReq struct {
+	AccountID                                        string `json:"account_id" validate:"required"`
+	typecvm.TCloudListReservedInstanceOfferingOption `json:",inline"`
+}
+
+// Validate request.
+func (req *TCloudListReservedInstanceOfferingReq) Validate() error {
+	return validator.Validate.Struct(req)
+}
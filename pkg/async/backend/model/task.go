@@ -30,21 +30,22 @@ import (
 
 // Task define task struct.
 type Task struct {
-	ID         string             `json:"id"`
-	FlowID     string             `json:"flow_id"`
-	FlowName   enumor.FlowName    `json:"flow_name"`
-	ActionID   action.ActIDType   `json:"action_id"`
-	ActionName enumor.ActionName  `json:"action_name"`
-	Params     types.JsonField    `json:"params"`
-	Retry      *tableasync.Retry  `json:"can_retry"`
-	DependOn   []action.ActIDType `json:"depend_on"`
-	State      enumor.TaskState   `json:"state"`
-	Reason     *tableasync.Reason `json:"reason"`
-	Result     types.JsonField    `json:"result"`
-	Creator    string             `json:"creator"`
-	Reviser    string             `json:"reviser"`
-	CreatedAt  string             `json:"created_at"`
-	UpdatedAt  string             `json:"updated_at"`
+	ID           string             `json:"id"`
+	FlowID       string             `json:"flow_id"`
+	FlowName     enumor.FlowName    `json:"flow_name"`
+	ActionID     action.ActIDType   `json:"action_id"`
+	ActionName   enumor.ActionName  `json:"action_name"`
+	Params       types.JsonField    `json:"params"`
+	Retry        *tableasync.Retry  `json:"can_retry"`
+	DependOn     []action.ActIDType `json:"depend_on"`
+	State        enumor.TaskState   `json:"state"`
+	Reason       *tableasync.Reason `json:"reason"`
+	Result       types.JsonField    `json:"result"`
+	TraceContext string             `json:"trace_context"`
+	Creator      string             `json:"creator"`
+	Reviser      string             `json:"reviser"`
+	CreatedAt    string             `json:"created_at"`
+	UpdatedAt    string             `json:"updated_at"`
 }
 
 // CreateValidate Task create validate.
@@ -38,6 +38,7 @@ import (
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/converter"
+	"hcm/pkg/tracing"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -222,17 +223,18 @@ func (db *mysql) CreateFlow(kt *kit.Kit, flow *model.Flow) (string, error) {
 			}
 
 			mds = append(mds, tableasync.AsyncFlowTaskTable{
-				FlowID:     flowID,
-				FlowName:   one.FlowName,
-				ActionID:   string(one.ActionID),
-				ActionName: one.ActionName,
-				Params:     one.Params,
-				Retry:      one.Retry,
-				DependOn:   dependOnToStringArray(one.DependOn),
-				State:      taskState,
-				Reason:     new(tableasync.Reason),
-				Creator:    kt.User,
-				Reviser:    kt.User,
+				FlowID:       flowID,
+				FlowName:     one.FlowName,
+				ActionID:     string(one.ActionID),
+				ActionName:   one.ActionName,
+				Params:       one.Params,
+				Retry:        one.Retry,
+				DependOn:     dependOnToStringArray(one.DependOn),
+				State:        taskState,
+				Reason:       new(tableasync.Reason),
+				TraceContext: taskTraceContext(kt, one.TraceContext),
+				Creator:      kt.User,
+				Reviser:      kt.User,
 			})
 		}
 		if _, err = db.dao.AsyncFlowTask().BatchCreateWithTx(kt, txn, mds); err != nil {
@@ -320,17 +322,18 @@ func (db *mysql) BatchCreateTask(kt *kit.Kit, tasks []model.Task) ([]string, err
 	mds := make([]tableasync.AsyncFlowTaskTable, 0, len(tasks))
 	for _, one := range tasks {
 		mds = append(mds, tableasync.AsyncFlowTaskTable{
-			FlowID:     one.FlowID,
-			FlowName:   one.FlowName,
-			ActionID:   string(one.ActionID),
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   dependOnToStringArray(one.DependOn),
-			State:      enumor.TaskPending,
-			Reason:     one.Reason,
-			Creator:    one.Creator,
-			Reviser:    one.Reviser,
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     string(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     dependOnToStringArray(one.DependOn),
+			State:        enumor.TaskPending,
+			Reason:       one.Reason,
+			TraceContext: taskTraceContext(kt, one.TraceContext),
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
 		})
 	}
 
@@ -367,27 +370,37 @@ func (db *mysql) ListTask(kt *kit.Kit, input *ListInput) ([]model.Task, error) {
 	tasks := make([]model.Task, 0, len(list.Details))
 	for _, one := range list.Details {
 		tasks = append(tasks, model.Task{
-			ID:         one.ID,
-			FlowID:     one.FlowID,
-			FlowName:   one.FlowName,
-			ActionID:   action.ActIDType(one.ActionID),
-			ActionName: one.ActionName,
-			Params:     one.Params,
-			Retry:      one.Retry,
-			DependOn:   dependOnToActIDArray(one.DependOn),
-			State:      one.State,
-			Reason:     one.Reason,
-			Result:     one.Result,
-			Creator:    one.Creator,
-			Reviser:    one.Reviser,
-			CreatedAt:  one.CreatedAt.String(),
-			UpdatedAt:  one.UpdatedAt.String(),
+			ID:           one.ID,
+			FlowID:       one.FlowID,
+			FlowName:     one.FlowName,
+			ActionID:     action.ActIDType(one.ActionID),
+			ActionName:   one.ActionName,
+			Params:       one.Params,
+			Retry:        one.Retry,
+			DependOn:     dependOnToActIDArray(one.DependOn),
+			State:        one.State,
+			Reason:       one.Reason,
+			Result:       one.Result,
+			TraceContext: one.TraceContext,
+			Creator:      one.Creator,
+			Reviser:      one.Reviser,
+			CreatedAt:    one.CreatedAt.String(),
+			UpdatedAt:    one.UpdatedAt.String(),
 		})
 	}
 
 	return tasks, nil
 }
 
+// taskTraceContext 任务未指定链路上下文时，使用创建任务请求的链路上下文
+func taskTraceContext(kt *kit.Kit, traceContext string) string {
+	if len(traceContext) != 0 {
+		return traceContext
+	}
+
+	return tracing.TraceParent(kt.Ctx)
+}
+
 func dependOnToStringArray(d []action.ActIDType) tabletypes.StringArray {
 	result := make(tabletypes.StringArray, 0, len(d))
 	for _, one := range d {
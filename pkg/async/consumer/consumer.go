@@ -61,6 +61,8 @@ type Consumer interface {
 	// Start 启动消费者，开始消费异步任务。
 	Start() error
 	CancelFlow(kit *kit.Kit, flowId string) error
+	// Status 获取消费者在当前节点的调度器、执行器队列及协程使用情况。
+	Status() *Status
 }
 
 var _ Consumer = new(consumer)
@@ -195,3 +197,21 @@ func (csm *consumer) CancelFlow(kt *kit.Kit, flowId string) error {
 
 	return nil
 }
+
+// Status 获取消费者在当前节点的调度器、执行器队列及协程使用情况。
+func (csm *consumer) Status() *Status {
+	status := &Status{
+		Node:     csm.leader.CurrNode(),
+		IsLeader: csm.leader.IsLeader(),
+	}
+
+	// 消费者未启动时组件尚未初始化
+	if csm.scheduler != nil {
+		status.Scheduler = csm.scheduler.Status()
+	}
+	if csm.executor != nil {
+		status.Executor = csm.executor.Status()
+	}
+
+	return status
+}
@@ -23,7 +23,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/async/action"
@@ -40,6 +42,10 @@ import (
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/retry"
 	"hcm/pkg/tools/times"
+	"hcm/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Executor （执行器）: 准备任务执行所需要的超时控制，共享数据等工具，并执行任务。
@@ -56,6 +62,8 @@ type Executor interface {
 	// CancelTasks 关闭指定task_id的任务。
 	CancelTasks(taskIDs []string) error
 	CancelFlow(kt *kit.Kit, flowID string) error
+	// Status 获取执行器队列及协程使用情况。
+	Status() *ExecutorStatus
 }
 
 var _ Executor = new(executor)
@@ -73,6 +81,8 @@ type executor struct {
 	workerQueue chan *Task
 	initQueue   chan *initPayload
 	backend     backend.Backend
+	// busyWorker 正在执行任务的协程数量
+	busyWorker atomic.Int32
 
 	closeCh chan struct{}
 
@@ -134,6 +144,8 @@ func (exec *executor) initWorkerTask(flow *Flow, task *Task) {
 
 	// 设置超时控制
 	cancel := task.Kit.CtxWithTimeoutMS(int(exec.taskExecTimeoutSec) * 1000)
+	// 恢复创建任务时的链路上下文，任务执行的 span 挂在创建任务的调用链下
+	task.Kit.Ctx = tracing.WithTraceParent(task.Kit.Ctx, task.TraceContext)
 
 	// 设置共享数据更新函数
 	flow.ShareData.Save = func(kt *kit.Kit, data *tableasync.ShareData) error {
@@ -154,11 +166,13 @@ func (exec *executor) initWorkerTask(flow *Flow, task *Task) {
 // 任务实际执行协程
 func (exec *executor) subWorkerQueue() {
 	for task := range exec.workerQueue {
+		exec.busyWorker.Add(1)
 		if err := exec.workerDo(task); err != nil {
 			// Task执行失败告警通知
 			logs.Errorf("%s: executor sub worker workerDo exec failed, err: %v, taskID: %s, action: %s, rid: %s",
 				constant.AsyncTaskWarnSign, err, task.ID, task.ActionName, task.Kit.Rid)
 		}
+		exec.busyWorker.Add(-1)
 	}
 
 	exec.workerWg.Done()
@@ -185,6 +199,15 @@ func (exec *executor) workerDo(task *Task) (err error) {
 		return err
 	}
 
+	ctx, span := tracing.Tracer().Start(task.Kit.Ctx, "async "+string(task.ActionName),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(tracing.RidKey.String(task.Kit.Rid), attribute.String("hcm.flow_id", task.FlowID),
+			attribute.String("hcm.task_id", task.ID)))
+	task.Kit.Ctx = ctx
+	defer func() {
+		tracing.End(span, runErr)
+	}()
+
 	defer func() {
 		if runErr == nil {
 			return
@@ -408,3 +431,21 @@ func (exec *executor) UpdateTask(task *Task, state enumor.TaskState, reason stri
 
 	return nil
 }
+
+// Status 获取执行器队列及协程使用情况。
+func (exec *executor) Status() *ExecutorStatus {
+	taskIDs := make([]string, 0)
+	exec.cancelMap.Range(func(key, value any) bool {
+		taskIDs = append(taskIDs, key.(string))
+		return true
+	})
+	sort.Strings(taskIDs)
+
+	return &ExecutorStatus{
+		WorkerNumber:  exec.workerNumber,
+		BusyWorker:    exec.busyWorker.Load(),
+		QueueLength:   len(exec.workerQueue),
+		QueueCapacity: cap(exec.workerQueue),
+		RunningTasks:  taskIDs,
+	}
+}
@@ -21,7 +21,9 @@ package consumer
 
 import (
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"hcm/pkg/api/core"
@@ -55,6 +57,8 @@ type Scheduler interface {
 	EntryTask(task *Task)
 	// DeleteFlowTaskTree 清空任务树，阻止继续调度
 	DeleteFlowTaskTree(flowID string)
+	// Status 获取调度器队列及协程使用情况。
+	Status() *SchedulerStatus
 }
 
 // scheduler 定义任务流调度器
@@ -65,6 +69,8 @@ type scheduler struct {
 	taskTrees   sync.Map
 	workerQueue chan *Task
 	workerWg    sync.WaitGroup
+	// busyWorker 正在解析任务流的协程数量
+	busyWorker atomic.Int32
 
 	backend  backend.Backend
 	executor Executor
@@ -440,10 +446,12 @@ func (sch *scheduler) runScheduledFlow(kt *kit.Kit) error {
 // 任务流解析协程
 func (sch *scheduler) goWorker() {
 	for task := range sch.workerQueue {
+		sch.busyWorker.Add(1)
 		if err := sch.executeNext(task.Flow.Kit, task); err != nil {
 			logs.Errorf("%s: scheduler exec executeNext failed, err: %v, rid: %s", constant.AsyncTaskWarnSign,
 				err, task.Kit.Rid)
 		}
+		sch.busyWorker.Add(-1)
 	}
 
 	sch.workerWg.Done()
@@ -550,3 +558,21 @@ func (sch *scheduler) DeleteFlowTaskTree(flowID string) {
 
 	sch.taskTrees.Delete(flowID)
 }
+
+// Status 获取调度器队列及协程使用情况。
+func (sch *scheduler) Status() *SchedulerStatus {
+	flowIDs := make([]string, 0)
+	sch.taskTrees.Range(func(key, value any) bool {
+		flowIDs = append(flowIDs, key.(string))
+		return true
+	})
+	sort.Strings(flowIDs)
+
+	return &SchedulerStatus{
+		WorkerNumber:  sch.workerNumber,
+		BusyWorker:    sch.busyWorker.Load(),
+		QueueLength:   len(sch.workerQueue),
+		QueueCapacity: cap(sch.workerQueue),
+		RunningFlows:  flowIDs,
+	}
+}
@@ -58,3 +58,41 @@ type Flow struct {
 
 	Kit *kit.Kit `json:"-"`
 }
+
+// Status 消费者在当前节点的运行状态。
+type Status struct {
+	// Node 当前节点
+	Node string `json:"node"`
+	// IsLeader 当前节点是否为主节点，主节点负责派发任务流及处理超时任务
+	IsLeader  bool             `json:"is_leader"`
+	Scheduler *SchedulerStatus `json:"scheduler"`
+	Executor  *ExecutorStatus  `json:"executor"`
+}
+
+// SchedulerStatus 调度器队列及协程使用情况。
+type SchedulerStatus struct {
+	// WorkerNumber 任务流解析协程数量
+	WorkerNumber uint `json:"worker_number"`
+	// BusyWorker 正在解析任务流的协程数量
+	BusyWorker int32 `json:"busy_worker"`
+	// QueueLength 待解析的任务数量
+	QueueLength int `json:"queue_length"`
+	// QueueCapacity 待解析任务队列容量
+	QueueCapacity int `json:"queue_capacity"`
+	// RunningFlows 当前节点正在调度的任务流ID
+	RunningFlows []string `json:"running_flows"`
+}
+
+// ExecutorStatus 执行器队列及协程使用情况。
+type ExecutorStatus struct {
+	// WorkerNumber 任务执行协程数量
+	WorkerNumber uint `json:"worker_number"`
+	// BusyWorker 正在执行任务的协程数量
+	BusyWorker int32 `json:"busy_worker"`
+	// QueueLength 待执行的任务数量
+	QueueLength int `json:"queue_length"`
+	// QueueCapacity 待执行任务队列容量
+	QueueCapacity int `json:"queue_capacity"`
+	// RunningTasks 当前节点已下发执行且未结束的任务ID
+	RunningTasks []string `json:"running_tasks"`
+}
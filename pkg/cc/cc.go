@@ -21,6 +21,7 @@
 package cc
 
 import (
+	"net"
 	"sync"
 
 	"hcm/pkg/logs"
@@ -33,10 +34,13 @@ var runtimeOnce sync.Once
 // It can be called only after LoadSettings is executed successfully.
 var rt *runtime
 
-func initRuntime(s Setting) {
+func initRuntime(s Setting, raw []byte, bindIP net.IP) {
 	runtimeOnce.Do(func() {
 		rt = &runtime{
-			settings: s,
+			settings:  s,
+			raw:       raw,
+			bindIP:    bindIP,
+			overrides: make(map[string]string),
 		}
 	})
 }
@@ -44,6 +48,12 @@ func initRuntime(s Setting) {
 type runtime struct {
 	lock     sync.Mutex
 	settings Setting
+	// raw is the raw yaml content of config file, dynamic config overrides are applied on it.
+	raw []byte
+	// bindIP is the bind ip set by flag.
+	bindIP net.IP
+	// overrides is the applied dynamic config key to yaml value map.
+	overrides map[string]string
 }
 
 // Ready is used to test if the runtime configuration is
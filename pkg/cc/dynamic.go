@@ -0,0 +1,266 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"hcm/pkg/logs"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dynamicKeys 各服务允许通过etcd动态覆盖的配置项，配置项为以'.'分隔的yaml路径，只有运行时每次使用都重新读取或
+// 注册了变更回调的配置项才能加入。配置项可以是另一个配置项的子路径，子路径的值覆盖父配置项中的值
+var dynamicKeys = map[Name][]string{
+	CloudServerName: {"cloudResource.sync.enable", "cloudResource.sync.syncIntervalMin",
+		"cloudResource.sync.pausedVendors", "recycle", "recycle.paused"},
+	AccountServerName: {"billAllocation"},
+	DataServiceName:   {"database.limiter"},
+	WebServerName:     {"notice.enable"},
+}
+
+// DynamicKeys returns the dynamic config keys of current service.
+func DynamicKeys() []string {
+	return dynamicKeys[ServiceName()]
+}
+
+func isDynamicKey(key string) bool {
+	for _, one := range DynamicKeys() {
+		if one == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	changeHandlers    = make(map[string][]func())
+	changeHandlersMux sync.Mutex
+)
+
+// OnChange registers the handler which is called after the dynamic config key is changed, the handler should get
+// the new config by the service's setting func, e.g. CloudServer().
+func OnChange(key string, handler func()) {
+	changeHandlersMux.Lock()
+	defer changeHandlersMux.Unlock()
+
+	changeHandlers[key] = append(changeHandlers[key], handler)
+}
+
+// ApplyOverrides applies the dynamic config overrides, which is the full set of dynamic config key to yaml value
+// map, keys not in overrides restore to the config file's value. each changed key is validated with the whole
+// setting, invalid keys are rejected and keep the previous value, and the error of them is returned.
+func ApplyOverrides(overrides map[string]string) error {
+	// 运行时未初始化时rt为nil，需先检查再加锁
+	if !rt.Ready() {
+		return errors.New("runtime not ready")
+	}
+
+	rt.lock.Lock()
+
+	// 计算有变化的配置项，包括被删除的配置项
+	keys := make([]string, 0)
+	for key, value := range overrides {
+		if prev, exists := rt.overrides[key]; !exists || prev != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range rt.overrides {
+		if _, exists := overrides[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	applied := cloneOverrides(rt.overrides)
+	var setting Setting
+	changed := make([]string, 0)
+	errs := make([]string, 0)
+	for _, key := range keys {
+		if !isDynamicKey(key) {
+			errs = append(errs, fmt.Sprintf("%s is not dynamic config key", key))
+			continue
+		}
+
+		candidate := cloneOverrides(applied)
+		value, exists := overrides[key]
+		if exists {
+			candidate[key] = value
+		} else {
+			delete(candidate, key)
+		}
+
+		s, err := loadWithOverrides(rt.raw, rt.bindIP, candidate)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s is invalid, err: %v", key, err))
+			continue
+		}
+
+		applied = candidate
+		setting = s
+		changed = append(changed, key)
+	}
+
+	if setting != nil {
+		rt.settings = setting
+		rt.overrides = applied
+	}
+	rt.lock.Unlock()
+
+	for _, key := range changed {
+		logs.Infof("dynamic config %s changed, value: %s", key, applied[key])
+
+		changeHandlersMux.Lock()
+		handlers := changeHandlers[key]
+		changeHandlersMux.Unlock()
+
+		for _, handler := range handlers {
+			handler()
+		}
+	}
+
+	if len(errs) != 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// loadWithOverrides load setting from the raw yaml with the dynamic config overrides.
+func loadWithOverrides(raw []byte, bindIP net.IP, overrides map[string]string) (Setting, error) {
+	root := make(map[string]interface{})
+	if err := yaml.Unmarshal(raw, &root); err != nil {
+		return nil, err
+	}
+
+	// 按配置项排序后覆盖，父配置项先于子路径覆盖，保证子路径的值不被父配置项覆盖
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := overrides[key]
+		var val interface{}
+		if err := yaml.Unmarshal([]byte(value), &val); err != nil {
+			return nil, fmt.Errorf("unmarshal yaml value failed, err: %v", err)
+		}
+
+		if err := setPath(root, strings.Split(key, "."), val); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadFromYaml(data, bindIP)
+}
+
+func setPath(root map[string]interface{}, path []string, value interface{}) error {
+	node := root
+	for _, field := range path[:len(path)-1] {
+		child, exists := node[field]
+		if !exists || child == nil {
+			child = make(map[string]interface{})
+			node[field] = child
+		}
+
+		childMap, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("config field %s is not an object", field)
+		}
+		node = childMap
+	}
+
+	node[path[len(path)-1]] = value
+	return nil
+}
+
+func getPath(root map[string]interface{}, path []string) interface{} {
+	var node interface{} = root
+	for _, field := range path {
+		nodeMap, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = nodeMap[field]
+	}
+
+	return node
+}
+
+func cloneOverrides(overrides map[string]string) map[string]string {
+	cloned := make(map[string]string, len(overrides))
+	for key, value := range overrides {
+		cloned[key] = value
+	}
+	return cloned
+}
+
+// DynamicConfig defines the effective value of a dynamic config key.
+type DynamicConfig struct {
+	Key string `json:"key"`
+	// Source 配置来源，file: 配置文件，etcd: etcd动态配置
+	Source string      `json:"source"`
+	Value  interface{} `json:"value"`
+}
+
+// ListDynamicConfig returns the effective values of current service's dynamic config keys.
+func ListDynamicConfig() ([]DynamicConfig, error) {
+	if !rt.Ready() {
+		return nil, errors.New("runtime not ready")
+	}
+
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	data, err := yaml.Marshal(rt.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	root := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	list := make([]DynamicConfig, 0)
+	for _, key := range DynamicKeys() {
+		source := "file"
+		if _, exists := rt.overrides[key]; exists {
+			source = "etcd"
+		}
+
+		list = append(list, DynamicConfig{Key: key, Source: source, Value: getPath(root, strings.Split(key, "."))})
+	}
+
+	return list, nil
+}
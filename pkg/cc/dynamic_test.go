@@ -0,0 +1,129 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cc
+
+import (
+	"testing"
+)
+
+const testWebServerYaml = `
+network:
+  bindIP: 127.0.0.1
+  port: 9602
+web:
+  bkLoginUrl: http://paas.example.com/login/
+  bkComponentApiUrl: http://paas.example.com
+  bkItsmUrl: http://itsm.example.com
+  bkDomain: example.com
+esb:
+  endpoints:
+    - http://esb.example.com
+  appCode: hcm
+  appSecret: secret
+  user: admin
+itsm:
+  endpoints:
+    - http://itsm.example.com
+  appCode: hcm
+  appSecret: secret
+notice:
+  enable: true
+  endpoints:
+    - http://127.0.0.1:8080
+  appCode: hcm
+  appSecret: secret
+`
+
+func TestDynamicConfigNotReady(t *testing.T) {
+	prev := rt
+	rt = nil
+	defer func() { rt = prev }()
+
+	if err := ApplyOverrides(map[string]string{"notice.enable": "false"}); err == nil {
+		t.Errorf("apply overrides should fail before runtime is initialized")
+	}
+	if _, err := ListDynamicConfig(); err == nil {
+		t.Errorf("list dynamic config should fail before runtime is initialized")
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	InitService(WebServerName)
+
+	s, err := loadFromYaml([]byte(testWebServerYaml), nil)
+	if err != nil {
+		t.Fatalf("load setting failed, err: %v", err)
+	}
+	initRuntime(s, []byte(testWebServerYaml), nil)
+
+	changed := 0
+	OnChange("notice.enable", func() { changed++ })
+
+	if err := ApplyOverrides(map[string]string{"notice.enable": "false"}); err != nil {
+		t.Fatalf("apply overrides failed, err: %v", err)
+	}
+	if WebServer().Notice.Enable || changed != 1 {
+		t.Fatalf("notice should be disabled by override, changed: %d", changed)
+	}
+
+	list, err := ListDynamicConfig()
+	if err != nil {
+		t.Fatalf("list dynamic config failed, err: %v", err)
+	}
+	if len(list) != 1 || list[0].Source != "etcd" || list[0].Value != false {
+		t.Fatalf("dynamic config is invalid, got: %+v", list)
+	}
+
+	// 不合法及非动态配置项不生效，保留之前的配置
+	err = ApplyOverrides(map[string]string{"notice.enable": "[", "network.port": "1"})
+	if err == nil {
+		t.Fatalf("invalid overrides should be rejected")
+	}
+	if WebServer().Notice.Enable || WebServer().Network.Port != 9602 || changed != 1 {
+		t.Fatalf("invalid overrides should not change setting, changed: %d", changed)
+	}
+
+	// 删除动态配置后恢复为配置文件的值
+	if err := ApplyOverrides(map[string]string{}); err != nil {
+		t.Fatalf("apply overrides failed, err: %v", err)
+	}
+	if !WebServer().Notice.Enable || changed != 2 {
+		t.Fatalf("notice should be restored to file value, changed: %d", changed)
+	}
+}
+
+func TestLoadWithOverridesSubPath(t *testing.T) {
+	InitService(WebServerName)
+
+	// 子路径的值覆盖父配置项中的值，与map遍历顺序无关
+	overrides := map[string]string{
+		"notice":        "{enable: true, endpoints: [http://127.0.0.1:8080], appCode: hcm, appSecret: secret}",
+		"notice.enable": "false",
+	}
+	for i := 0; i < 10; i++ {
+		s, err := loadWithOverrides([]byte(testWebServerYaml), nil, overrides)
+		if err != nil {
+			t.Fatalf("load with overrides failed, err: %v", err)
+		}
+		if s.(*WebServerSetting).Notice.Enable {
+			t.Fatalf("sub path override should take precedence over its parent")
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 
 	"gopkg.in/yaml.v3"
 )
@@ -34,33 +35,48 @@ func LoadSettings(sys *SysOption) error {
 	}
 
 	// configure file is configured, then load configuration from file.
-	s, err := loadFromFile(sys.ConfigFile)
+	file, err := ioutil.ReadFile(sys.ConfigFile)
 	if err != nil {
-		return err
+		return fmt.Errorf("load setting from file: %s failed, err: %v", sys.ConfigFile, err)
 	}
 
-	if err = s.trySetFlagBindIP(sys.BindIP); err != nil {
-		return err
+	s, err := loadFromYaml(file, sys.BindIP)
+	if err != nil {
+		return fmt.Errorf("load setting from file: %s failed, err: %v", sys.ConfigFile, err)
+	}
+
+	initRuntime(s, file, sys.BindIP)
+
+	return nil
+}
+
+// loadFromYaml load service's configuration from yaml, set the default value and validate it.
+func loadFromYaml(data []byte, bindIP net.IP) (Setting, error) {
+	s, err := newSetting()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unmarshal setting yaml failed, err: %v", err)
+	}
+
+	if err = s.trySetFlagBindIP(bindIP); err != nil {
+		return nil, err
 	}
 
 	// s the default value if user not configured.
 	s.trySetDefault()
 
 	if err := s.Validate(); err != nil {
-		return err
+		return nil, err
 	}
 
-	initRuntime(s)
-
-	return nil
+	return s, nil
 }
 
-// loadFromFile load service's configuration from local config file.
-func loadFromFile(filename string) (Setting, error) {
-	if len(filename) == 0 {
-		return nil, errors.New("file name is not set")
-	}
-
+// newSetting returns the empty setting of current service.
+func newSetting() (Setting, error) {
 	var s Setting
 	switch ServiceName() {
 	case APIServerName:
@@ -83,14 +99,5 @@ func loadFromFile(filename string) (Setting, error) {
 		return nil, fmt.Errorf("unknown %s service name", ServiceName())
 	}
 
-	file, err := ioutil.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("load setting from file: %s failed, err: %v", filename, err)
-	}
-
-	if err := yaml.Unmarshal(file, s); err != nil {
-		return nil, fmt.Errorf("unmarshal setting yaml from file: %s failed, err: %v", filename, err)
-	}
-
 	return s, nil
 }
@@ -77,9 +77,11 @@ type Setting interface {
 
 // ApiServerSetting defines api server used setting options.
 type ApiServerSetting struct {
-	Network Network   `yaml:"network"`
-	Service Service   `yaml:"service"`
-	Log     LogOption `yaml:"log"`
+	Network   Network       `yaml:"network"`
+	Service   Service       `yaml:"service"`
+	Log       LogOption     `yaml:"log"`
+	Tracing   TracingOption `yaml:"tracing"`
+	RateLimit RateLimit     `yaml:"rateLimit"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -92,6 +94,8 @@ func (s *ApiServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.RateLimit.trySetDefault()
 
 	return
 }
@@ -107,6 +111,14 @@ func (s ApiServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
+	if err := s.RateLimit.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -115,6 +127,7 @@ type CloudServerSetting struct {
 	Network        Network        `yaml:"network"`
 	Service        Service        `yaml:"service"`
 	Log            LogOption      `yaml:"log"`
+	Tracing        TracingOption  `yaml:"tracing"`
 	Crypto         Crypto         `yaml:"crypto"`
 	Esb            Esb            `yaml:"esb"`
 	BkHcmUrl       string         `yaml:"bkHcmUrl"`
@@ -124,6 +137,7 @@ type CloudServerSetting struct {
 	Itsm           ApiGateway     `yaml:"itsm"`
 	CloudSelection CloudSelection `yaml:"cloudSelection"`
 	Cmsi           CMSI           `yaml:"cmsi"`
+	RateLimit      RateLimit      `yaml:"rateLimit"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -136,6 +150,8 @@ func (s *CloudServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	s.RateLimit.trySetDefault()
 
 	return
 }
@@ -178,18 +194,27 @@ func (s CloudServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
+	if err := s.RateLimit.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // DataServiceSetting defines data service used setting options.
 type DataServiceSetting struct {
-	Network     Network     `yaml:"network"`
-	Service     Service     `yaml:"service"`
-	Log         LogOption   `yaml:"log"`
-	Database    DataBase    `yaml:"database"`
-	Objectstore ObjectStore `yaml:"objectstore"`
-	Crypto      Crypto      `yaml:"crypto"`
-	Esb         Esb         `yaml:"esb"`
+	Network     Network       `yaml:"network"`
+	Service     Service       `yaml:"service"`
+	Log         LogOption     `yaml:"log"`
+	Tracing     TracingOption `yaml:"tracing"`
+	Database    DataBase      `yaml:"database"`
+	Objectstore ObjectStore   `yaml:"objectstore"`
+	Crypto      Crypto        `yaml:"crypto"`
+	Esb         Esb           `yaml:"esb"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -202,6 +227,7 @@ func (s *DataServiceSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
 	s.Database.trySetDefault()
 
 	return
@@ -229,14 +255,19 @@ func (s DataServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // HCServiceSetting defines hc service used setting options.
 type HCServiceSetting struct {
-	Network Network   `yaml:"network"`
-	Service Service   `yaml:"service"`
-	Log     LogOption `yaml:"log"`
+	Network Network       `yaml:"network"`
+	Service Service       `yaml:"service"`
+	Log     LogOption     `yaml:"log"`
+	Tracing TracingOption `yaml:"tracing"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -249,6 +280,7 @@ func (s *HCServiceSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
 
 	return
 }
@@ -264,6 +296,10 @@ func (s HCServiceSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -274,7 +310,8 @@ type AuthServerSetting struct {
 	Log     LogOption `yaml:"log"`
 	Esb     Esb       `yaml:"esb"`
 
-	IAM IAM `yaml:"iam"`
+	IAM        IAM        `yaml:"iam"`
+	Authorizer Authorizer `yaml:"authorizer"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -287,6 +324,7 @@ func (s *AuthServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Log.trySetDefault()
+	s.Authorizer.trySetDefault()
 
 	return
 }
@@ -305,10 +343,17 @@ func (s AuthServerSetting) Validate() error {
 		return err
 	}
 
-	if err := s.IAM.validate(); err != nil {
+	if err := s.Authorizer.validate(); err != nil {
 		return err
 	}
 
+	// 使用本地RBAC鉴权时不依赖IAM
+	if s.Authorizer.Backend == IAMAuthorizerBackend {
+		if err := s.IAM.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -370,11 +415,16 @@ func (s WebServerSetting) Validate() error {
 
 // TaskServerSetting defines task server used setting options.
 type TaskServerSetting struct {
-	Network  Network   `yaml:"network"`
-	Service  Service   `yaml:"service"`
-	Database DataBase  `yaml:"database"`
-	Log      LogOption `yaml:"log"`
-	Async    Async     `yaml:"async"`
+	Network  Network       `yaml:"network"`
+	Service  Service       `yaml:"service"`
+	Database DataBase      `yaml:"database"`
+	Log      LogOption     `yaml:"log"`
+	Tracing  TracingOption `yaml:"tracing"`
+	Async    Async         `yaml:"async"`
+	// TmpFileDir 账单归档、恢复时的临时文件目录
+	TmpFileDir string `yaml:"tmpFileDir"`
+	// Objectstore 账单明细归档文件存储，未配置时不执行归档
+	Objectstore ObjectStore `yaml:"objectstore"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -388,6 +438,10 @@ func (s *TaskServerSetting) trySetDefault() {
 	s.Service.trySetDefault()
 	s.Database.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
+	if s.TmpFileDir == "" {
+		s.TmpFileDir = "/tmp"
+	}
 
 	return
 }
@@ -407,18 +461,32 @@ func (s TaskServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // AccountServerSetting defines task server used setting options.
 type AccountServerSetting struct {
-	Network        Network              `yaml:"network"`
-	Service        Service              `yaml:"service"`
-	Controller     BillControllerOption `yaml:"controller"`
-	Log            LogOption            `yaml:"log"`
-	BillAllocation BillAllocationOption `yaml:"billAllocation"`
-	Esb            Esb                  `yaml:"esb"`
-	TmpFileDir     string               `yaml:"tmpFileDir"`
+	Network        Network                  `yaml:"network"`
+	Service        Service                  `yaml:"service"`
+	Controller     BillControllerOption     `yaml:"controller"`
+	Log            LogOption                `yaml:"log"`
+	Tracing        TracingOption            `yaml:"tracing"`
+	BillAllocation BillAllocationOption     `yaml:"billAllocation"`
+	Esb            Esb                      `yaml:"esb"`
+	TmpFileDir     string                   `yaml:"tmpFileDir"`
+	Budget         BillBudgetOption         `yaml:"budget"`
+	ExchangeRate   ExchangeRateOption       `yaml:"exchangeRate"`
+	Report         BillReportOption         `yaml:"report"`
+	Reconciliation BillReconciliationOption `yaml:"reconciliation"`
+	Retention      BillRetentionOption      `yaml:"retention"`
+	// Cmsi 预算告警、订阅报表邮件通知配置，未配置时预算只记录告警，订阅报表不发送
+	Cmsi CMSI `yaml:"cmsi"`
+	// Objectstore 订阅报表文件存储，未配置时订阅报表不发送
+	Objectstore ObjectStore `yaml:"objectstore"`
 }
 
 // trySetFlagBindIP try set flag bind ip.
@@ -431,7 +499,13 @@ func (s *AccountServerSetting) trySetDefault() {
 	s.Network.trySetDefault()
 	s.Service.trySetDefault()
 	s.Controller.trySetDefault()
+	s.Budget.trySetDefault()
+	s.ExchangeRate.trySetDefault()
+	s.Report.trySetDefault()
+	s.Reconciliation.trySetDefault()
+	s.Retention.trySetDefault()
 	s.Log.trySetDefault()
+	s.Tracing.trySetDefault()
 	if s.TmpFileDir == "" {
 		s.TmpFileDir = "/tmp"
 	}
@@ -452,6 +526,24 @@ func (s AccountServerSetting) Validate() error {
 		return err
 	}
 
+	if err := s.Reconciliation.validate(); err != nil {
+		return err
+	}
+
+	if err := s.Retention.validate(); err != nil {
+		return err
+	}
+
+	if len(s.Cmsi.Endpoints) != 0 {
+		if err := s.Cmsi.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := s.Tracing.validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -30,14 +30,18 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/logs"
 	"hcm/pkg/tools/ssl"
+	"hcm/pkg/tracing"
 	"hcm/pkg/version"
 
+	"github.com/shopspring/decimal"
 	etcd3 "go.etcd.io/etcd/client/v3"
 )
 
 // Service defines Setting related runtime.
 type Service struct {
 	Etcd Etcd `yaml:"etcd"`
+	// Balancer 调用其他服务时的节点熔断及异常节点摘除配置
+	Balancer BalancerOption `yaml:"balancer"`
 }
 
 // trySetDefault set the Setting default value if user not configured.
@@ -51,6 +55,47 @@ func (s Service) validate() error {
 		return err
 	}
 
+	if err := s.Balancer.validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BalancerOption defines circuit breaking and outlier ejection options of upstream services, zero values use
+// the default values.
+type BalancerOption struct {
+	// FailureThreshold 连续失败次数达到该值时熔断节点，默认5
+	FailureThreshold int `yaml:"failureThreshold"`
+	// OpenDurationSec 节点熔断后经过该时间进入半开状态，默认10s
+	OpenDurationSec uint `yaml:"openDurationSec"`
+	// LatencyDecay 延迟指数加权移动平均的衰减系数，取值(0,1]，默认0.3
+	LatencyDecay float64 `yaml:"latencyDecay"`
+	// OutlierFactor 节点平均延迟超过其余节点平均延迟中位数的倍数时视为异常节点，默认5
+	OutlierFactor float64 `yaml:"outlierFactor"`
+	// OutlierMinLatencyMS 节点平均延迟低于该值时不视为异常节点，默认2000ms
+	OutlierMinLatencyMS uint `yaml:"outlierMinLatencyMS"`
+	// LatencySampleMaxMS 耗时超过该值的请求视为长耗时请求，不计入节点延迟，默认5000ms
+	LatencySampleMaxMS uint `yaml:"latencySampleMaxMS"`
+	// EjectDurationSec 异常节点摘除时长，默认30s
+	EjectDurationSec uint `yaml:"ejectDurationSec"`
+	// MaxEjectPercent 同一上游服务最多允许摘除的节点百分比，默认50
+	MaxEjectPercent int `yaml:"maxEjectPercent"`
+}
+
+func (b BalancerOption) validate() error {
+	if b.FailureThreshold < 0 {
+		return errors.New("balancer failureThreshold should not be negative")
+	}
+	if b.LatencyDecay < 0 || b.LatencyDecay > 1 {
+		return errors.New("balancer latencyDecay should be in (0, 1]")
+	}
+	if b.OutlierFactor < 0 || (b.OutlierFactor > 0 && b.OutlierFactor <= 1) {
+		return errors.New("balancer outlierFactor should be greater than 1")
+	}
+	if b.MaxEjectPercent < 0 || b.MaxEjectPercent > 100 {
+		return errors.New("balancer maxEjectPercent should be in [0, 100]")
+	}
 	return nil
 }
 
@@ -367,6 +412,69 @@ func (log LogOption) Logs() logs.LogConfig {
 	return l
 }
 
+// TracingOption 分布式链路追踪配置
+type TracingOption struct {
+	// Exporter 链路数据导出方式，支持 otlp、file，为空时不开启链路追踪
+	Exporter string `yaml:"exporter"`
+	// Endpoint otlp http 接收地址，格式为 host:port
+	Endpoint string `yaml:"endpoint"`
+	// Insecure otlp 使用 http 而不是 https
+	Insecure bool `yaml:"insecure"`
+	// Headers otlp 请求附加的请求头
+	Headers map[string]string `yaml:"headers"`
+	// FilePath file 导出方式的文件路径
+	FilePath string `yaml:"filePath"`
+	// SampleRatio 根 span 采样比例，取值范围 [0, 1]，未配置时全部采样
+	SampleRatio *float64 `yaml:"sampleRatio"`
+}
+
+// trySetDefault set the tracing's default value if user not configured.
+func (t *TracingOption) trySetDefault() {
+	if t.SampleRatio == nil {
+		ratio := 1.0
+		t.SampleRatio = &ratio
+	}
+
+	if t.Exporter == string(tracing.FileExporter) && len(t.FilePath) == 0 {
+		t.FilePath = "./trace.json"
+	}
+}
+
+func (t TracingOption) validate() error {
+	switch tracing.ExporterType(t.Exporter) {
+	case "", tracing.FileExporter:
+	case tracing.OtlpExporter:
+		if len(t.Endpoint) == 0 {
+			return errors.New("tracing.endpoint is required when exporter is otlp")
+		}
+	default:
+		return fmt.Errorf("unsupported tracing.exporter: %s", t.Exporter)
+	}
+
+	if t.SampleRatio != nil && (*t.SampleRatio < 0 || *t.SampleRatio > 1) {
+		return errors.New("tracing.sampleRatio should be in [0, 1]")
+	}
+
+	return nil
+}
+
+// Config convert it to tracing.Config.
+func (t TracingOption) Config() tracing.Config {
+	c := tracing.Config{
+		Exporter:    tracing.ExporterType(t.Exporter),
+		Endpoint:    t.Endpoint,
+		Insecure:    t.Insecure,
+		Headers:     t.Headers,
+		FilePath:    t.FilePath,
+		SampleRatio: 1,
+	}
+	if t.SampleRatio != nil {
+		c.SampleRatio = *t.SampleRatio
+	}
+
+	return c
+}
+
 // Network defines all the network related options
 type Network struct {
 	// BindIP is ip where server working on
@@ -501,6 +609,147 @@ func (s IAM) validate() error {
 	return nil
 }
 
+// AuthorizerBackend is auth-server's authorize backend.
+type AuthorizerBackend string
+
+const (
+	// IAMAuthorizerBackend 使用蓝鲸权限中心鉴权
+	IAMAuthorizerBackend AuthorizerBackend = "iam"
+	// LocalAuthorizerBackend 使用存储在hcm数据库中的本地RBAC鉴权
+	LocalAuthorizerBackend AuthorizerBackend = "local"
+)
+
+// Authorizer defines auth-server's authorize backend options.
+type Authorizer struct {
+	// Backend 鉴权后端，默认为iam
+	Backend AuthorizerBackend `yaml:"backend"`
+	// Local 本地RBAC鉴权配置，仅在鉴权后端为local时生效
+	Local LocalRBAC `yaml:"local"`
+}
+
+// LocalRBAC defines local rbac authorize options.
+type LocalRBAC struct {
+	// Admins 超级管理员，拥有所有权限，用于初始化角色及角色绑定
+	Admins []string `yaml:"admins"`
+	// RefreshIntervalSec 本地RBAC数据的刷新间隔，权限变更在该时间后生效
+	RefreshIntervalSec uint `yaml:"refreshIntervalSec"`
+}
+
+// trySetDefault set the authorizer default value if user not configured.
+func (a *Authorizer) trySetDefault() {
+	if len(a.Backend) == 0 {
+		a.Backend = IAMAuthorizerBackend
+	}
+
+	if a.Local.RefreshIntervalSec == 0 {
+		a.Local.RefreshIntervalSec = 30
+	}
+}
+
+// validate authorizer options.
+func (a Authorizer) validate() error {
+	switch a.Backend {
+	case IAMAuthorizerBackend:
+	case LocalAuthorizerBackend:
+		if len(a.Local.Admins) == 0 {
+			return errors.New("local rbac admins is not set")
+		}
+	default:
+		return fmt.Errorf("unsupported authorizer backend: %s", a.Backend)
+	}
+
+	return nil
+}
+
+// RateLimitStore is the counter store of api rate limit.
+type RateLimitStore string
+
+const (
+	// LocalRateLimitStore 计数存储在各节点内存中，各节点独立限流
+	LocalRateLimitStore RateLimitStore = "local"
+	// EtcdRateLimitStore 计数定期汇总到etcd中，所有节点共享计数
+	EtcdRateLimitStore RateLimitStore = "etcd"
+)
+
+// RateLimitDimension is the dimension that api requests are limited by.
+type RateLimitDimension string
+
+const (
+	// AppCodeRateLimitDimension 按请求来源的应用限流
+	AppCodeRateLimitDimension RateLimitDimension = "app_code"
+	// UserRateLimitDimension 按请求用户限流
+	UserRateLimitDimension RateLimitDimension = "user"
+	// BizRateLimitDimension 按请求的业务限流，仅业务下的接口生效
+	BizRateLimitDimension RateLimitDimension = "biz"
+)
+
+// RateLimit defines the api rate limit and quota options keyed by app code, user and business.
+type RateLimit struct {
+	// Enable 是否开启接口限流
+	Enable bool `yaml:"enable"`
+	// Store 计数存储方式，默认为local
+	Store RateLimitStore `yaml:"store"`
+	// SyncIntervalMS 计数存储为etcd时，节点本地计数写入etcd的间隔，单位：毫秒，默认1000。
+	// 本地计数没有变化时间隔5个同步周期才从etcd刷新其他节点的计数
+	SyncIntervalMS uint `yaml:"syncIntervalMS"`
+	// Rules 限流规则，请求需满足所有匹配的规则
+	Rules []RateLimitRule `yaml:"rules"`
+}
+
+// RateLimitRule defines the request limit of each key of the dimension in a fixed time window.
+type RateLimitRule struct {
+	// Dimension 限流维度
+	Dimension RateLimitDimension `yaml:"dimension"`
+	// Keys 规则生效的维度取值，未设置时对该维度的每个取值生效，设置后该取值不再使用未设置Keys的同维度规则
+	Keys []string `yaml:"keys"`
+	// Limit 时间窗口内允许的最大请求数
+	Limit uint `yaml:"limit"`
+	// WindowSec 时间窗口，单位：秒，如1为每秒请求数，86400为每日配额
+	WindowSec uint `yaml:"windowSec"`
+}
+
+// trySetDefault set the rate limit default value if user not configured.
+func (r *RateLimit) trySetDefault() {
+	if len(r.Store) == 0 {
+		r.Store = LocalRateLimitStore
+	}
+
+	if r.SyncIntervalMS == 0 {
+		r.SyncIntervalMS = 1000
+	}
+
+	for i := range r.Rules {
+		if r.Rules[i].WindowSec == 0 {
+			r.Rules[i].WindowSec = 1
+		}
+	}
+}
+
+// validate rate limit options.
+func (r RateLimit) validate() error {
+	if !r.Enable {
+		return nil
+	}
+
+	if r.Store != LocalRateLimitStore && r.Store != EtcdRateLimitStore {
+		return fmt.Errorf("unsupported rate limit store: %s", r.Store)
+	}
+
+	for idx, rule := range r.Rules {
+		switch rule.Dimension {
+		case AppCodeRateLimitDimension, UserRateLimitDimension, BizRateLimitDimension:
+		default:
+			return fmt.Errorf("rate limit rules[%d] has unsupported dimension: %s", idx, rule.Dimension)
+		}
+
+		if rule.Limit == 0 {
+			return fmt.Errorf("rate limit rules[%d] limit should >= 1", idx)
+		}
+	}
+
+	return nil
+}
+
 // Web 服务依赖所需特有配置， 包括登录、静态文件等配置的定义
 type Web struct {
 	StaticFileDirPath string `yaml:"staticFileDirPath"`
@@ -619,6 +868,8 @@ type CloudResourceSync struct {
 	Enable                       bool   `yaml:"enable"`
 	SyncIntervalMin              uint64 `yaml:"syncIntervalMin"`
 	SyncFrequencyLimitingTimeMin uint64 `yaml:"syncFrequencyLimitingTimeMin"`
+	// PausedVendors 暂停定时同步的云厂商，运行时通过控制命令调整并持久化到etcd动态配置
+	PausedVendors []enumor.Vendor `yaml:"pausedVendors"`
 }
 
 func (c CloudResourceSync) validate() error {
@@ -628,12 +879,22 @@ func (c CloudResourceSync) validate() error {
 		}
 	}
 
+	for _, vendor := range c.PausedVendors {
+		if err := vendor.Validate(); err != nil {
+			return fmt.Errorf("invalid paused vendor, err: %v", err)
+		}
+	}
+
 	return nil
 }
 
 // Recycle configuration.
 type Recycle struct {
 	AutoDeleteTime uint `yaml:"autoDeleteTimeHour"`
+	// NotifyBeforeTime 回收资源到期前通知回收人的提前时长，单位：小时，为0时不通知
+	NotifyBeforeTime uint `yaml:"notifyBeforeHour"`
+	// Paused 回收定时任务是否暂停，运行时通过控制命令调整并持久化到etcd动态配置
+	Paused bool `yaml:"paused"`
 }
 
 func (a Recycle) validate() error {
@@ -829,6 +1090,14 @@ var (
 	defaultMainAccountSummarySyncDuration = 10 * time.Minute
 	defaultRootAccountSummarySyncDuration = 10 * time.Minute
 	defaultDailySummarySyncDuration       = 30 * time.Second
+	defaultBudgetEvaluateDuration         = 30 * time.Minute
+	defaultExchangeRateSyncDuration       = time.Hour
+	defaultExchangeRateProviderTimeout    = 10 * time.Second
+	defaultBillReportCheckDuration        = time.Hour
+	defaultBillReportLinkTTL              = 72 * time.Hour
+	defaultBillReconciliationTolerance    = "1"
+	defaultBillRetentionCheckDuration     = 6 * time.Hour
+	defaultBillRetentionRestoreTTL        = 7 * 24 * time.Hour
 )
 
 // BillControllerOption bill controller option
@@ -856,6 +1125,133 @@ func (bco *BillControllerOption) trySetDefault() {
 	}
 }
 
+// BillBudgetOption bill budget option
+type BillBudgetOption struct {
+	// 是否关闭预算告警检测，默认为不关闭
+	Disable          bool           `yaml:"disable"`
+	EvaluateDuration *time.Duration `yaml:"evaluateDuration,omitempty"`
+}
+
+func (bbo *BillBudgetOption) trySetDefault() {
+	if bbo.EvaluateDuration == nil {
+		bbo.EvaluateDuration = &defaultBudgetEvaluateDuration
+	}
+}
+
+// ExchangeRateOption bill exchange rate option
+type ExchangeRateOption struct {
+	// 是否关闭汇率自动同步及月末锁定，默认为不关闭
+	Disable      bool                       `yaml:"disable"`
+	SyncDuration *time.Duration             `yaml:"syncDuration,omitempty"`
+	Provider     ExchangeRateProviderOption `yaml:"provider"`
+}
+
+func (ero *ExchangeRateOption) trySetDefault() {
+	if ero.SyncDuration == nil {
+		ero.SyncDuration = &defaultExchangeRateSyncDuration
+	}
+	if ero.Provider.Timeout == nil {
+		ero.Provider.Timeout = &defaultExchangeRateProviderTimeout
+	}
+}
+
+// BillReportOption bill report subscription option
+type BillReportOption struct {
+	// 是否关闭订阅报表定时发送，默认为不关闭
+	Disable       bool           `yaml:"disable"`
+	CheckDuration *time.Duration `yaml:"checkDuration,omitempty"`
+	// LinkTTL 报表下载链接有效期
+	LinkTTL *time.Duration `yaml:"linkTTL,omitempty"`
+}
+
+func (bro *BillReportOption) trySetDefault() {
+	if bro.CheckDuration == nil {
+		bro.CheckDuration = &defaultBillReportCheckDuration
+	}
+	if bro.LinkTTL == nil {
+		bro.LinkTTL = &defaultBillReportLinkTTL
+	}
+}
+
+// BillReconciliationOption bill reconciliation option
+type BillReconciliationOption struct {
+	// Enable 开启后确认一级账号账单前必须对账通过，默认不开启
+	Enable bool `yaml:"enable"`
+	// Tolerance 每个币种允许的差额绝对值，默认为1
+	Tolerance string `yaml:"tolerance"`
+	// ToleranceRatio 每个币种允许的差额占云厂商账单金额的比例，与Tolerance取较大值，默认为0
+	ToleranceRatio float64 `yaml:"toleranceRatio"`
+	// SkipVendors 开启对账时不支持对账的云厂商默认不允许确认账单，配置在此列表中的云厂商跳过对账检查
+	SkipVendors []enumor.Vendor `yaml:"skipVendors"`
+}
+
+func (bro *BillReconciliationOption) trySetDefault() {
+	if len(bro.Tolerance) == 0 {
+		bro.Tolerance = defaultBillReconciliationTolerance
+	}
+}
+
+func (bro *BillReconciliationOption) validate() error {
+	tolerance, err := decimal.NewFromString(bro.Tolerance)
+	if err != nil {
+		return fmt.Errorf("invalid reconciliation tolerance %s, err: %v", bro.Tolerance, err)
+	}
+	if tolerance.IsNegative() {
+		return errors.New("reconciliation tolerance should not be negative")
+	}
+	if bro.ToleranceRatio < 0 || bro.ToleranceRatio >= 1 {
+		return errors.New("reconciliation tolerance ratio should be in [0, 1)")
+	}
+	return nil
+}
+
+// minBillItemRetentionMonths 账单明细至少保留的月数，避免归档仍在核算或调账的月份
+const minBillItemRetentionMonths = 3
+
+// BillRetentionOption bill data retention option, retention months exclude current month and 0 means keep forever
+type BillRetentionOption struct {
+	// 是否关闭账单数据清理及归档，默认为不关闭
+	Disable       bool           `yaml:"disable"`
+	CheckDuration *time.Duration `yaml:"checkDuration,omitempty"`
+	// BillItemMonths 账单明细分表保留的月数，超过后归档到对象存储并删除分表
+	BillItemMonths int `yaml:"billItemMonths"`
+	// DailyPullTaskMonths 日账单拉取任务保留的月数
+	DailyPullTaskMonths int `yaml:"dailyPullTaskMonths"`
+	// SummaryDailyMonths 每日汇总账单保留的月数
+	SummaryDailyMonths int `yaml:"summaryDailyMonths"`
+	// SummaryVersionMonths 月度汇总账单版本保留的月数
+	SummaryVersionMonths int `yaml:"summaryVersionMonths"`
+	// RestoreTTL 从归档恢复的分表保留时长，到期后重新删除分表
+	RestoreTTL *time.Duration `yaml:"restoreTTL,omitempty"`
+}
+
+func (bro *BillRetentionOption) trySetDefault() {
+	if bro.CheckDuration == nil {
+		bro.CheckDuration = &defaultBillRetentionCheckDuration
+	}
+	if bro.RestoreTTL == nil {
+		bro.RestoreTTL = &defaultBillRetentionRestoreTTL
+	}
+}
+
+func (bro *BillRetentionOption) validate() error {
+	if bro.BillItemMonths < 0 || bro.DailyPullTaskMonths < 0 || bro.SummaryDailyMonths < 0 ||
+		bro.SummaryVersionMonths < 0 {
+		return errors.New("bill retention months should not be negative")
+	}
+	if bro.BillItemMonths != 0 && bro.BillItemMonths < minBillItemRetentionMonths {
+		return fmt.Errorf("bill item retention months should be at least %d", minBillItemRetentionMonths)
+	}
+	return nil
+}
+
+// ExchangeRateProviderOption http exchange rate provider option
+type ExchangeRateProviderOption struct {
+	// Endpoint 汇率服务地址，为空时不自动拉取汇率，只能手动录入或导入
+	Endpoint string         `yaml:"endpoint"`
+	Timeout  *time.Duration `yaml:"timeout,omitempty"`
+}
+
 // CMSI cmsi config
 type CMSI struct {
 	CC         []string `yaml:"cc"`
@@ -880,7 +1276,7 @@ func (c *CMSI) validate() error {
 	return nil
 }
 
-// AwsSavingsPlansOption savings plans allocation option
+// AwsSavingsPlansOption savings plans allocation option, 一级账号配置了savings plans分摊规则时不再生效
 type AwsSavingsPlansOption struct {
 	// RootAccountCloudID which root account these savings plans belongs to
 	RootAccountCloudID string `yaml:"rootAccountCloudID" validate:"required"`
@@ -902,7 +1298,7 @@ func (opt *AwsSavingsPlansOption) validate() error {
 	return nil
 }
 
-// BillCommonExpense ...
+// BillCommonExpense 公共费用按费用占比分摊时排除的账号，一级账号配置了公共费用分摊规则时不再生效
 type BillCommonExpense struct {
 	ExcludeAccountCloudIDs []string `yaml:"excludeAccountCloudIDs" validate:"dive,required"`
 }
@@ -926,7 +1322,7 @@ func (r CreditReturn) Validate() error {
 	return nil
 }
 
-// GcpCreditConfig ...
+// GcpCreditConfig gcp credit归还配置，一级账号配置了credit分摊规则时不再生效
 type GcpCreditConfig struct {
 	// RootAccountCloudID which root account these savings plans belongs to
 	RootAccountCloudID string         `yaml:"rootAccountCloudID" validate:"required"`
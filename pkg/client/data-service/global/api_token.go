@@ -0,0 +1,95 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	coreapitoken "hcm/pkg/api/core/api-token"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/api-token"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewAPITokenClient create a new api token api client.
+func NewAPITokenClient(client rest.ClientInterface) *APITokenClient {
+	return &APITokenClient{
+		client: client,
+	}
+}
+
+// APITokenClient is data service service account and api token api client.
+type APITokenClient struct {
+	client rest.ClientInterface
+}
+
+// CreateServiceAccount create service account.
+func (cli *APITokenClient) CreateServiceAccount(kt *kit.Kit, request *proto.ServiceAccountCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[proto.ServiceAccountCreateReq, core.CreateResult](cli.client, rest.POST, kt, request,
+		"/service_accounts/create")
+}
+
+// UpdateServiceAccount update service account.
+func (cli *APITokenClient) UpdateServiceAccount(kt *kit.Kit, request *proto.ServiceAccountUpdateReq) error {
+	return common.RequestNoResp[proto.ServiceAccountUpdateReq](cli.client, rest.PATCH, kt, request,
+		"/service_accounts")
+}
+
+// ListServiceAccount list service accounts.
+func (cli *APITokenClient) ListServiceAccount(kt *kit.Kit, request *core.ListReq) (*proto.ServiceAccountListResult,
+	error) {
+
+	return common.Request[core.ListReq, proto.ServiceAccountListResult](cli.client, rest.POST, kt, request,
+		"/service_accounts/list")
+}
+
+// BatchDeleteServiceAccount batch delete service accounts and their api tokens.
+func (cli *APITokenClient) BatchDeleteServiceAccount(kt *kit.Kit, request *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/service_accounts/batch")
+}
+
+// CreateToken create api token.
+func (cli *APITokenClient) CreateToken(kt *kit.Kit, request *proto.TokenCreateReq) (*core.CreateResult, error) {
+	return common.Request[proto.TokenCreateReq, core.CreateResult](cli.client, rest.POST, kt, request,
+		"/api_tokens/create")
+}
+
+// UpdateToken update api token.
+func (cli *APITokenClient) UpdateToken(kt *kit.Kit, request *proto.TokenUpdateReq) error {
+	return common.RequestNoResp[proto.TokenUpdateReq](cli.client, rest.PATCH, kt, request, "/api_tokens")
+}
+
+// ListToken list api tokens.
+func (cli *APITokenClient) ListToken(kt *kit.Kit, request *core.ListReq) (*proto.TokenListResult, error) {
+	return common.Request[core.ListReq, proto.TokenListResult](cli.client, rest.POST, kt, request,
+		"/api_tokens/list")
+}
+
+// ValidateToken validate api token by token hash, returns token if it is valid.
+func (cli *APITokenClient) ValidateToken(kt *kit.Kit, request *proto.TokenValidateReq) (*coreapitoken.Token,
+	error) {
+
+	return common.Request[proto.TokenValidateReq, coreapitoken.Token](cli.client, rest.POST, kt, request,
+		"/api_tokens/validate")
+}
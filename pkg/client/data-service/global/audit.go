@@ -68,6 +68,30 @@ func (a *AuditClient) CloudResourceUpdateAudit(ctx context.Context, h http.Heade
 	return nil
 }
 
+// CloudResourceCreateAudit cloud resource create audit.
+func (a *AuditClient) CloudResourceCreateAudit(ctx context.Context, h http.Header,
+	request *protoaudit.CloudResourceCreateAuditReq) error {
+
+	resp := new(rest.BaseResp)
+
+	err := a.client.Post().
+		WithContext(ctx).
+		Body(request).
+		SubResourcef("/cloud/resources/create_audits/create").
+		WithHeaders(h).
+		Do().
+		Into(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Code != errf.OK {
+		return errf.New(resp.Code, resp.Message)
+	}
+
+	return nil
+}
+
 // CloudResourceDeleteAudit cloud resource delete audit.
 func (a *AuditClient) CloudResourceDeleteAudit(ctx context.Context, h http.Header,
 	request *protoaudit.CloudResourceDeleteAuditReq) error {
@@ -165,7 +189,7 @@ func (a *AuditClient) CloudResourceRecycleAudit(ctx context.Context, h http.Head
 }
 
 // ListAudit list audit.
-func (a *AuditClient) ListAudit(ctx context.Context, h http.Header, request *core.ListReq) (
+func (a *AuditClient) ListAudit(ctx context.Context, h http.Header, request *protoaudit.AuditListReq) (
 	*protoaudit.ListResult, error) {
 
 	resp := new(protoaudit.ListResp)
@@ -218,3 +242,33 @@ func (a *AuditClient) GetAuditRaw(kt *kit.Kit, id uint64) (
 	return common.Request[common.Empty, coreaudit.RawAudit](a.client, rest.GET, kt, nil,
 		"/audits/%d", id)
 }
+
+// ListAuditFieldChange list audit field change.
+func (a *AuditClient) ListAuditFieldChange(kt *kit.Kit, req *core.ListReq) (*protoaudit.FieldChangeListResult,
+	error) {
+
+	return common.Request[core.ListReq, protoaudit.FieldChangeListResult](a.client, rest.POST, kt, req,
+		"/audits/field_changes/list")
+}
+
+// CreateAuditExport export audits after the last export to object store.
+func (a *AuditClient) CreateAuditExport(kt *kit.Kit, req *protoaudit.AuditExportCreateReq) (*coreaudit.Export,
+	error) {
+
+	return common.Request[protoaudit.AuditExportCreateReq, coreaudit.Export](a.client, rest.POST, kt, req,
+		"/audits/exports/create")
+}
+
+// ListAuditExport list audit export.
+func (a *AuditClient) ListAuditExport(kt *kit.Kit, req *core.ListReq) (*protoaudit.AuditExportListResult, error) {
+	return common.Request[core.ListReq, protoaudit.AuditExportListResult](a.client, rest.POST, kt, req,
+		"/audits/exports/list")
+}
+
+// GetCloudSyncReport get out-of-band change report, count cloud sync audits by account, resource type and action.
+func (a *AuditClient) GetCloudSyncReport(kt *kit.Kit, req *protoaudit.CloudSyncReportReq) (
+	*protoaudit.CloudSyncReportResult, error) {
+
+	return common.Request[protoaudit.CloudSyncReportReq, protoaudit.CloudSyncReportResult](a.client, rest.POST, kt,
+		req, "/audits/cloud_sync/report")
+}
@@ -159,6 +159,14 @@ func (b *BillClient) ListBillItemRaw(kt *kit.Kit, req *billproto.BillItemListReq
 		b.client, rest.POST, kt, req, "/bills/items/list_with_extension")
 }
 
+// ListBillItemResCost list bill item cost group by cloud resource id and currency
+func (b *BillClient) ListBillItemResCost(kt *kit.Kit, req *billproto.BillItemListReq) (
+	*billproto.BillItemResCostListResult, error) {
+
+	return common.Request[billproto.BillItemListReq, billproto.BillItemResCostListResult](
+		b.client, rest.POST, kt, req, "/bills/items/res_costs/list")
+}
+
 // --- bill daily pull task ---
 
 // CreateBillDailyPullTask create bill daily pull task
@@ -471,6 +479,131 @@ func (b *BillClient) ListExchangeRate(kt *kit.Kit, req *core.ListReq) (*billprot
 		"/bills/exchange_rates/list")
 }
 
+// --- bill budget ---
+
+// CreateBillBudget create bill budget
+func (b *BillClient) CreateBillBudget(kt *kit.Kit, req *billproto.BillBudgetCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[billproto.BillBudgetCreateReq, core.CreateResult](
+		b.client, rest.POST, kt, req, "/bills/budgets/create")
+}
+
+// UpdateBillBudget update bill budget
+func (b *BillClient) UpdateBillBudget(kt *kit.Kit, req *billproto.BillBudgetUpdateReq) error {
+
+	return common.RequestNoResp[billproto.BillBudgetUpdateReq](b.client, rest.PATCH, kt, req, "/bills/budgets")
+}
+
+// BatchDeleteBillBudget batch delete bill budget
+func (b *BillClient) BatchDeleteBillBudget(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+
+	return common.RequestNoResp[dataservice.BatchDeleteReq](b.client, rest.DELETE, kt, req, "/bills/budgets/batch")
+}
+
+// ListBillBudget list bill budget
+func (b *BillClient) ListBillBudget(kt *kit.Kit, req *core.ListReq) (*billproto.BillBudgetListResult, error) {
+
+	return common.Request[core.ListReq, billproto.BillBudgetListResult](b.client, rest.POST, kt, req,
+		"/bills/budgets/list")
+}
+
+// BatchCreateBillBudgetAlert create bill budget alert
+func (b *BillClient) BatchCreateBillBudgetAlert(kt *kit.Kit, req *billproto.BatchCreateBillBudgetAlertReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[billproto.BatchCreateBillBudgetAlertReq, core.BatchCreateResult](
+		b.client, rest.POST, kt, req, "/bills/budgets/alerts/batch/create")
+}
+
+// ListBillBudgetAlert list bill budget alert
+func (b *BillClient) ListBillBudgetAlert(kt *kit.Kit, req *core.ListReq) (*billproto.BillBudgetAlertListResult,
+	error) {
+
+	return common.Request[core.ListReq, billproto.BillBudgetAlertListResult](b.client, rest.POST, kt, req,
+		"/bills/budgets/alerts/list")
+}
+
+// --- bill report subscription ---
+
+// CreateBillReportSub create bill report subscription
+func (b *BillClient) CreateBillReportSub(kt *kit.Kit, req *billproto.BillReportSubCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[billproto.BillReportSubCreateReq, core.CreateResult](
+		b.client, rest.POST, kt, req, "/bills/report_subscriptions/create")
+}
+
+// UpdateBillReportSub update bill report subscription
+func (b *BillClient) UpdateBillReportSub(kt *kit.Kit, req *billproto.BillReportSubUpdateReq) error {
+
+	return common.RequestNoResp[billproto.BillReportSubUpdateReq](b.client, rest.PATCH, kt, req,
+		"/bills/report_subscriptions")
+}
+
+// BatchDeleteBillReportSub batch delete bill report subscription
+func (b *BillClient) BatchDeleteBillReportSub(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+
+	return common.RequestNoResp[dataservice.BatchDeleteReq](b.client, rest.DELETE, kt, req,
+		"/bills/report_subscriptions/batch")
+}
+
+// ListBillReportSub list bill report subscription
+func (b *BillClient) ListBillReportSub(kt *kit.Kit, req *core.ListReq) (*billproto.BillReportSubListResult, error) {
+
+	return common.Request[core.ListReq, billproto.BillReportSubListResult](b.client, rest.POST, kt, req,
+		"/bills/report_subscriptions/list")
+}
+
+// --- bill allocation rule ---
+
+// CreateBillAllocationRule create bill allocation rule
+func (b *BillClient) CreateBillAllocationRule(kt *kit.Kit, req *billproto.BillAllocationRuleCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[billproto.BillAllocationRuleCreateReq, core.CreateResult](
+		b.client, rest.POST, kt, req, "/bills/allocation_rules/create")
+}
+
+// UpdateBillAllocationRule update bill allocation rule
+func (b *BillClient) UpdateBillAllocationRule(kt *kit.Kit, req *billproto.BillAllocationRuleUpdateReq) error {
+
+	return common.RequestNoResp[billproto.BillAllocationRuleUpdateReq](b.client, rest.PATCH, kt, req,
+		"/bills/allocation_rules")
+}
+
+// BatchDeleteBillAllocationRule batch delete bill allocation rule
+func (b *BillClient) BatchDeleteBillAllocationRule(kt *kit.Kit, req *dataservice.BatchDeleteReq) error {
+
+	return common.RequestNoResp[dataservice.BatchDeleteReq](b.client, rest.DELETE, kt, req,
+		"/bills/allocation_rules/batch")
+}
+
+// ListBillAllocationRule list bill allocation rule
+func (b *BillClient) ListBillAllocationRule(kt *kit.Kit, req *core.ListReq) (
+	*billproto.BillAllocationRuleListResult, error) {
+
+	return common.Request[core.ListReq, billproto.BillAllocationRuleListResult](b.client, rest.POST, kt, req,
+		"/bills/allocation_rules/list")
+}
+
+// --- bill reconciliation ---
+
+// CreateBillReconciliation create bill reconciliation
+func (b *BillClient) CreateBillReconciliation(kt *kit.Kit, req *billproto.BillReconciliationCreateReq) (
+	*core.CreateResult, error) {
+
+	return common.Request[billproto.BillReconciliationCreateReq, core.CreateResult](
+		b.client, rest.POST, kt, req, "/bills/reconciliations/create")
+}
+
+// ListBillReconciliation list bill reconciliation
+func (b *BillClient) ListBillReconciliation(kt *kit.Kit, req *core.ListReq) (
+	*billproto.BillReconciliationListResult, error) {
+
+	return common.Request[core.ListReq, billproto.BillReconciliationListResult](b.client, rest.POST, kt, req,
+		"/bills/reconciliations/list")
+}
+
 // --- bill adjustment item ---
 
 // BatchCreateBillSyncRecord create bill adjustment item
@@ -498,3 +631,48 @@ func (b *BillClient) ListBillSyncRecord(kt *kit.Kit, req *billproto.BillSyncReco
 	return common.Request[billproto.BillSyncRecordListReq, billproto.BillSyncRecordListResult](
 		b.client, rest.POST, kt, req, "/bills/sync_records/list")
 }
+
+// --- bill archive ---
+
+// CreateBillArchive create bill archive
+func (b *BillClient) CreateBillArchive(kt *kit.Kit, req *billproto.BillArchiveCreateReq) (*core.CreateResult, error) {
+
+	return common.Request[billproto.BillArchiveCreateReq, core.CreateResult](
+		b.client, rest.POST, kt, req, "/bills/archives/create")
+}
+
+// UpdateBillArchive update bill archive
+func (b *BillClient) UpdateBillArchive(kt *kit.Kit, req *billproto.BillArchiveUpdateReq) error {
+
+	return common.RequestNoResp[billproto.BillArchiveUpdateReq](b.client, rest.PATCH, kt, req, "/bills/archives")
+}
+
+// ListBillArchive list bill archive
+func (b *BillClient) ListBillArchive(kt *kit.Kit, req *core.ListReq) (*billproto.BillArchiveListResult, error) {
+
+	return common.Request[core.ListReq, billproto.BillArchiveListResult](b.client, rest.POST, kt, req,
+		"/bills/archives/list")
+}
+
+// --- bill item partition ---
+
+// ListBillItemPartition list existing bill item partition tables
+func (b *BillClient) ListBillItemPartition(kt *kit.Kit) (*billproto.BillItemPartitionListResult, error) {
+
+	return common.Request[common.Empty, billproto.BillItemPartitionListResult](b.client, rest.POST, kt, common.NoData,
+		"/bills/items/partitions/list")
+}
+
+// CreateBillItemPartition create bill item partition table if not exists
+func (b *BillClient) CreateBillItemPartition(kt *kit.Kit, req *billproto.BillItemPartitionReq) error {
+
+	return common.RequestNoResp[billproto.BillItemPartitionReq](b.client, rest.POST, kt, req,
+		"/bills/items/partitions/create")
+}
+
+// DropBillItemPartition drop bill item partition table
+func (b *BillClient) DropBillItemPartition(kt *kit.Kit, req *billproto.BillItemPartitionReq) error {
+
+	return common.RequestNoResp[billproto.BillItemPartitionReq](b.client, rest.DELETE, kt, req,
+		"/bills/items/partitions")
+}
@@ -45,6 +45,10 @@ type Client struct {
 	Auth          *AuthClient
 	Account       *AccountClient
 	RecycleRecord *RecycleRecordClient
+	RecyclePolicy *RecyclePolicyClient
+	Webhook       *WebhookClient
+	APIToken      *APITokenClient
+	RBAC          *RBACClient
 	Audit         *AuditClient
 
 	Application     *ApplicationClient
@@ -57,6 +61,7 @@ type Client struct {
 	ArgsTpl        *ArgsTplClient
 	LoadBalancer   *LoadBalancerClient
 	SGCommonRel    *SGCommonRelClient
+	SGLintFinding  *SGLintFindingClient
 
 	MainAccount *MainAccountClient
 	RootAccount *RootAccountClient
@@ -89,6 +94,10 @@ func NewClient(client rest.ClientInterface) *Client {
 		Auth:          NewAuthClient(client),
 		Account:       NewAccountClient(client),
 		RecycleRecord: NewRecycleRecordClient(client),
+		RecyclePolicy: NewRecyclePolicyClient(client),
+		Webhook:       NewWebhookClient(client),
+		APIToken:      NewAPITokenClient(client),
+		RBAC:          NewRBACClient(client),
 		Audit:         NewAuditClient(client),
 
 		Application:     NewApplicationClient(client),
@@ -101,6 +110,7 @@ func NewClient(client rest.ClientInterface) *Client {
 		ArgsTpl:        NewCloudArgumentTemplateClient(client),
 		LoadBalancer:   NewLoadBalancerClient(client),
 		SGCommonRel:    NewCloudSGCommonRelClient(client),
+		SGLintFinding:  NewSGLintFindingClient(client),
 		MainAccount:    NewMainAccountClient(client),
 		RootAccount:    NewRootAccountClient(client),
 		Cos:            NewCosClient(client),
@@ -0,0 +1,113 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/rbac"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewRBACClient create a new local rbac api client.
+func NewRBACClient(client rest.ClientInterface) *RBACClient {
+	return &RBACClient{
+		client: client,
+	}
+}
+
+// RBACClient is data service local rbac role, group and role binding api client.
+type RBACClient struct {
+	client rest.ClientInterface
+}
+
+// CreateRole create rbac role.
+func (cli *RBACClient) CreateRole(kt *kit.Kit, request *proto.RoleCreateReq) (*core.CreateResult, error) {
+	return common.Request[proto.RoleCreateReq, core.CreateResult](cli.client, rest.POST, kt, request,
+		"/rbac/roles/create")
+}
+
+// UpdateRole update rbac role.
+func (cli *RBACClient) UpdateRole(kt *kit.Kit, request *proto.RoleUpdateReq) error {
+	return common.RequestNoResp[proto.RoleUpdateReq](cli.client, rest.PATCH, kt, request, "/rbac/roles")
+}
+
+// ListRole list rbac roles.
+func (cli *RBACClient) ListRole(kt *kit.Kit, request *core.ListReq) (*proto.RoleListResult, error) {
+	return common.Request[core.ListReq, proto.RoleListResult](cli.client, rest.POST, kt, request,
+		"/rbac/roles/list")
+}
+
+// BatchDeleteRole batch delete rbac roles and their role bindings.
+func (cli *RBACClient) BatchDeleteRole(kt *kit.Kit, request *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/rbac/roles/batch")
+}
+
+// CreateGroup create rbac group.
+func (cli *RBACClient) CreateGroup(kt *kit.Kit, request *proto.GroupCreateReq) (*core.CreateResult, error) {
+	return common.Request[proto.GroupCreateReq, core.CreateResult](cli.client, rest.POST, kt, request,
+		"/rbac/groups/create")
+}
+
+// UpdateGroup update rbac group.
+func (cli *RBACClient) UpdateGroup(kt *kit.Kit, request *proto.GroupUpdateReq) error {
+	return common.RequestNoResp[proto.GroupUpdateReq](cli.client, rest.PATCH, kt, request, "/rbac/groups")
+}
+
+// ListGroup list rbac groups.
+func (cli *RBACClient) ListGroup(kt *kit.Kit, request *core.ListReq) (*proto.GroupListResult, error) {
+	return common.Request[core.ListReq, proto.GroupListResult](cli.client, rest.POST, kt, request,
+		"/rbac/groups/list")
+}
+
+// BatchDeleteGroup batch delete rbac groups and their role bindings.
+func (cli *RBACClient) BatchDeleteGroup(kt *kit.Kit, request *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/rbac/groups/batch")
+}
+
+// CreateRoleBinding create rbac role binding.
+func (cli *RBACClient) CreateRoleBinding(kt *kit.Kit, request *proto.RoleBindingCreateReq) (*core.CreateResult,
+	error) {
+
+	return common.Request[proto.RoleBindingCreateReq, core.CreateResult](cli.client, rest.POST, kt, request,
+		"/rbac/role_bindings/create")
+}
+
+// UpdateRoleBinding update rbac role binding.
+func (cli *RBACClient) UpdateRoleBinding(kt *kit.Kit, request *proto.RoleBindingUpdateReq) error {
+	return common.RequestNoResp[proto.RoleBindingUpdateReq](cli.client, rest.PATCH, kt, request,
+		"/rbac/role_bindings")
+}
+
+// ListRoleBinding list rbac role bindings.
+func (cli *RBACClient) ListRoleBinding(kt *kit.Kit, request *core.ListReq) (*proto.RoleBindingListResult, error) {
+	return common.Request[core.ListReq, proto.RoleBindingListResult](cli.client, rest.POST, kt, request,
+		"/rbac/role_bindings/list")
+}
+
+// BatchDeleteRoleBinding batch delete rbac role bindings.
+func (cli *RBACClient) BatchDeleteRoleBinding(kt *kit.Kit, request *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/rbac/role_bindings/batch")
+}
@@ -0,0 +1,69 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/recycle-record"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewRecyclePolicyClient create a new recycle policy api client.
+func NewRecyclePolicyClient(client rest.ClientInterface) *RecyclePolicyClient {
+	return &RecyclePolicyClient{
+		client: client,
+	}
+}
+
+// RecyclePolicyClient is data service recycle policy api client.
+type RecyclePolicyClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreate recycle policies.
+func (cli *RecyclePolicyClient) BatchCreate(kt *kit.Kit, request *proto.RecyclePolicyBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[proto.RecyclePolicyBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST,
+		kt, request, "/recycle_policies/batch/create")
+}
+
+// Update recycle policy.
+func (cli *RecyclePolicyClient) Update(kt *kit.Kit, request *proto.RecyclePolicyUpdateReq) error {
+	return common.RequestNoResp[proto.RecyclePolicyUpdateReq](cli.client, rest.PATCH, kt, request,
+		"/recycle_policies")
+}
+
+// List recycle policies.
+func (cli *RecyclePolicyClient) List(kt *kit.Kit, request *core.ListReq) (*proto.RecyclePolicyListResult,
+	error) {
+
+	return common.Request[core.ListReq, proto.RecyclePolicyListResult](cli.client, rest.POST, kt, request,
+		"/recycle_policies/list")
+}
+
+// BatchDelete recycle policies.
+func (cli *RecyclePolicyClient) BatchDelete(kt *kit.Kit, request *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/recycle_policies/batch")
+}
@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	proto "hcm/pkg/api/data-service"
+	protocloud "hcm/pkg/api/data-service/cloud"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewSGLintFindingClient create a new security group lint finding api client.
+func NewSGLintFindingClient(client rest.ClientInterface) *SGLintFindingClient {
+	return &SGLintFindingClient{
+		client: client,
+	}
+}
+
+// SGLintFindingClient is data service security group lint finding api client.
+type SGLintFindingClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreate security group lint findings.
+func (cli *SGLintFindingClient) BatchCreate(kt *kit.Kit, request *protocloud.SGLintFindingBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[protocloud.SGLintFindingBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST,
+		kt, request, "/security_groups/lint_findings/batch/create")
+}
+
+// List security group lint findings.
+func (cli *SGLintFindingClient) List(kt *kit.Kit, request *core.ListReq) (*protocloud.SGLintFindingListResult,
+	error) {
+
+	return common.Request[core.ListReq, protocloud.SGLintFindingListResult](cli.client, rest.POST, kt, request,
+		"/security_groups/lint_findings/list")
+}
+
+// BatchDelete security group lint findings.
+func (cli *SGLintFindingClient) BatchDelete(kt *kit.Kit, request *proto.BatchDeleteReq) error {
+	return common.RequestNoResp[proto.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/security_groups/lint_findings/batch")
+}
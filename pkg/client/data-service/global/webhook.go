@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package global
+
+import (
+	"hcm/pkg/api/core"
+	dataservice "hcm/pkg/api/data-service"
+	proto "hcm/pkg/api/data-service/webhook"
+	"hcm/pkg/client/common"
+	"hcm/pkg/kit"
+	"hcm/pkg/rest"
+)
+
+// NewWebhookClient create a new webhook api client.
+func NewWebhookClient(client rest.ClientInterface) *WebhookClient {
+	return &WebhookClient{
+		client: client,
+	}
+}
+
+// WebhookClient is data service webhook api client.
+type WebhookClient struct {
+	client rest.ClientInterface
+}
+
+// BatchCreateSubscription batch create webhook subscriptions.
+func (cli *WebhookClient) BatchCreateSubscription(kt *kit.Kit, request *proto.SubscriptionBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[proto.SubscriptionBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST,
+		kt, request, "/webhooks/subscriptions/batch/create")
+}
+
+// UpdateSubscription update webhook subscription.
+func (cli *WebhookClient) UpdateSubscription(kt *kit.Kit, request *proto.SubscriptionUpdateReq) error {
+	return common.RequestNoResp[proto.SubscriptionUpdateReq](cli.client, rest.PATCH, kt, request,
+		"/webhooks/subscriptions")
+}
+
+// ListSubscription list webhook subscriptions.
+func (cli *WebhookClient) ListSubscription(kt *kit.Kit, request *core.ListReq) (*proto.SubscriptionListResult,
+	error) {
+
+	return common.Request[core.ListReq, proto.SubscriptionListResult](cli.client, rest.POST, kt, request,
+		"/webhooks/subscriptions/list")
+}
+
+// BatchDeleteSubscription batch delete webhook subscriptions.
+func (cli *WebhookClient) BatchDeleteSubscription(kt *kit.Kit, request *dataservice.BatchDeleteReq) error {
+	return common.RequestNoResp[dataservice.BatchDeleteReq](cli.client, rest.DELETE, kt, request,
+		"/webhooks/subscriptions/batch")
+}
+
+// BatchCreateDelivery batch create webhook deliveries.
+func (cli *WebhookClient) BatchCreateDelivery(kt *kit.Kit, request *proto.DeliveryBatchCreateReq) (
+	*core.BatchCreateResult, error) {
+
+	return common.Request[proto.DeliveryBatchCreateReq, core.BatchCreateResult](cli.client, rest.POST,
+		kt, request, "/webhooks/deliveries/batch/create")
+}
+
+// BatchUpdateDelivery batch update webhook deliveries.
+func (cli *WebhookClient) BatchUpdateDelivery(kt *kit.Kit, request *proto.DeliveryBatchUpdateReq) error {
+	return common.RequestNoResp[proto.DeliveryBatchUpdateReq](cli.client, rest.PATCH, kt, request,
+		"/webhooks/deliveries/batch")
+}
+
+// ListDelivery list webhook deliveries.
+func (cli *WebhookClient) ListDelivery(kt *kit.Kit, request *core.ListReq) (*proto.DeliveryListResult, error) {
+	return common.Request[core.ListReq, proto.DeliveryListResult](cli.client, rest.POST, kt, request,
+		"/webhooks/deliveries/list")
+}
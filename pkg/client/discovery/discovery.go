@@ -22,18 +22,18 @@ package discovery
 
 import (
 	"fmt"
-	"sync"
+	"time"
 
 	"hcm/pkg/cc"
+	"hcm/pkg/rest/balancer"
 	"hcm/pkg/serviced"
 )
 
-// APIDiscovery is service discovery for api call.
+// APIDiscovery is service discovery for api call, servers are ordered by circuit breaker and latency states.
 type APIDiscovery struct {
 	discover serviced.Discover
 	service  cc.Name
-	index    int
-	sync.Mutex
+	upstream *balancer.Upstream
 }
 
 // NewAPIDiscovery create a new service discovery for api call.
@@ -41,33 +41,26 @@ func NewAPIDiscovery(service cc.Name, discover serviced.Discover) *APIDiscovery
 	return &APIDiscovery{
 		discover: discover,
 		service:  service,
-		index:    0,
-		Mutex:    sync.Mutex{},
+		upstream: balancer.GetUpstream(string(service)),
 	}
 }
 
-// GetServers get esb server host.
+// GetServers get server hosts, circuit broken and ejected servers are excluded, and the preferred server is the
+// first one.
 func (d *APIDiscovery) GetServers() ([]string, error) {
-	d.Lock()
-	defer d.Unlock()
-
 	servers, err := d.discover.Discover(d.service)
 	if err != nil {
 		return nil, err
 	}
 
-	num := len(servers)
-	if num == 0 {
+	if len(servers) == 0 {
 		return []string{}, fmt.Errorf("there is no server can be used for %s", d.service)
 	}
 
-	// move the servers in a round-robin way, e.g. last servers are [A, B, C], next server sequence is [B, C, A].
-	if d.index < num-1 {
-		d.index = d.index + 1
-		return append(servers[d.index-1:], servers[:d.index-1]...), nil
-	}
+	return d.upstream.Pick(servers), nil
+}
 
-	// all servers are traversed, start from the beginning again.
-	d.index = 0
-	return append(servers[num-1:], servers[:num-1]...), nil
+// Report reports the request result of the server host.
+func (d *APIDiscovery) Report(host string, latency time.Duration, statusCode int, err error) {
+	d.upstream.Report(host, latency, statusCode, err)
 }
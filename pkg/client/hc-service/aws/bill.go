@@ -23,6 +23,7 @@ import (
 	"context"
 	"net/http"
 
+	typesBill "hcm/pkg/adaptor/types/bill"
 	"hcm/pkg/api/core"
 	hcbill "hcm/pkg/api/hc-service/bill"
 	"hcm/pkg/client/common"
@@ -129,3 +130,19 @@ func (v *BillClient) GetRootAccountSpTotalUsage(kt *kit.Kit, req *hcbill.AwsRoot
 		v.client, rest.GET, kt, req, "/root_account_bills/sp_usage_total")
 
 }
+
+// GetRootAccountMonthTotal get root account month bill total group by currency
+func (v *BillClient) GetRootAccountMonthTotal(kt *kit.Kit, req *hcbill.RootAccountMonthTotalReq) (
+	*hcbill.RootAccountMonthTotalResult, error) {
+
+	return common.Request[hcbill.RootAccountMonthTotalReq, hcbill.RootAccountMonthTotalResult](
+		v.client, rest.POST, kt, req, "/root_account_bills/month_total")
+}
+
+// GetRootAccountSpAnalysis get root account savings plans utilization and ec2 coverage
+func (v *BillClient) GetRootAccountSpAnalysis(kt *kit.Kit, req *hcbill.AwsRootSpAnalysisReq) (
+	*typesBill.AwsSpAnalysisResult, error) {
+
+	return common.Request[hcbill.AwsRootSpAnalysisReq, typesBill.AwsSpAnalysisResult](
+		v.client, rest.POST, kt, req, "/root_account_bills/savings_plans/analysis")
+}
@@ -100,3 +100,11 @@ func (v *BillClient) RootCreditUsageList(kt *kit.Kit, req *hcbillservice.GcpRoot
 		rest.POST, kt, req, "/root_account_bills/credits/list")
 
 }
+
+// GetRootAccountMonthTotal get root account month bill total group by currency
+func (v *BillClient) GetRootAccountMonthTotal(kt *kit.Kit, req *hcbillservice.RootAccountMonthTotalReq) (
+	*hcbillservice.RootAccountMonthTotalResult, error) {
+
+	return common.Request[hcbillservice.RootAccountMonthTotalReq, hcbillservice.RootAccountMonthTotalResult](
+		v.client, rest.POST, kt, req, "/root_account_bills/month_total")
+}
@@ -27,6 +27,7 @@ import (
 	"hcm/pkg/api/core"
 	protocvm "hcm/pkg/api/hc-service/cvm"
 	"hcm/pkg/api/hc-service/sync"
+	"hcm/pkg/client/common"
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/rest"
@@ -233,3 +234,28 @@ func (cli *CvmClient) InquiryPrice(kt *kit.Kit, request *protocvm.TCloudBatchCre
 
 	return resp.Data, nil
 }
+
+// ListReservedInstance list reserved instances of region.
+func (cli *CvmClient) ListReservedInstance(kt *kit.Kit, request *protocvm.TCloudListReservedInstanceReq) (
+	[]typecvm.TCloudReservedInstance, error) {
+
+	result, err := common.Request[protocvm.TCloudListReservedInstanceReq, []typecvm.TCloudReservedInstance](
+		cli.client, http.MethodPost, kt, request, "/reserved_instances/list")
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return *result, nil
+}
+
+// ListReservedInstanceOffering list reserved instance offerings.
+func (cli *CvmClient) ListReservedInstanceOffering(kt *kit.Kit,
+	request *protocvm.TCloudListReservedInstanceOfferingReq) ([]typecvm.TCloudReservedInstanceOffering, error) {
+
+	result, err := common.Request[protocvm.TCloudListReservedInstanceOfferingReq,
+		[]typecvm.TCloudReservedInstanceOffering](cli.client, http.MethodPost, kt, request,
+		"/reserved_instances/offerings/list")
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return *result, nil
+}
@@ -46,6 +46,9 @@ const AwsCommonExpenseExcludeCloudIDKey = "aws_common_expense_exclude_account_cl
 const GcpCommonExpenseExcludeCloudIDKey = "gcp_common_expense_exclude_account_cloud_id"
 const GcpCreditReturnConfigKey = "gcp_credit_return_config"
 
+// BillCommonExpenseAllocationRuleKey 公共费用分摊规则，未配置时按费用占比分摊
+const BillCommonExpenseAllocationRuleKey = "common_expense_allocation_rule"
+
 // AwsLineItemTypeSavingPlanCoveredUsage aws savings plan cost line item type
 const AwsLineItemTypeSavingPlanCoveredUsage = "SavingsPlanCoveredUsage"
 
@@ -66,4 +69,9 @@ const (
 
 	// GcpCreditReturnCostReverse Gcp credit return cost reverse, positive value, e.g. 10.00000
 	GcpCreditReturnCostReverse = "CreditReverse"
+
+	// BillAllocationName shared cost allocated to target main account by allocation rule
+	BillAllocationName = "SharedCostAllocation"
+	// BillAllocationReverseName shared cost reverse of source main account
+	BillAllocationReverseName = "SharedCostAllocationReverse"
 )
@@ -46,6 +46,16 @@ const (
 
 	// BKGWAuthKey is blueking api gateway authorization header key.
 	BKGWAuthKey = "X-Bkapi-Authorization"
+
+	// APITokenKey is hcm api token header key, used to access api-server without blueking api gateway.
+	APITokenKey = "X-Bkhcm-Api-Token"
+
+	// TokenScopeKey is api token scope header key, set by api-server after api token is validated.
+	TokenScopeKey = "X-Bkhcm-Token-Scope"
+
+	// RateLimitedByKey is the name of the service that has rate limited the request, set by api-server after the
+	// request passes its rate limit, so that the backend services do not count the request again.
+	RateLimitedByKey = "X-Bkhcm-Rate-Limited-By"
 )
 
 const (
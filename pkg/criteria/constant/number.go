@@ -50,4 +50,6 @@ const (
 
 	// ExcelExportLimit two hundred thousands 二十万
 	ExcelExportLimit = 20_0000
+	// ObjectStoreExportLimit 流式上传至对象存储的导出最大数量，两百万
+	ObjectStoreExportLimit = 200_0000
 )
@@ -0,0 +1,42 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import "fmt"
+
+// APITokenPrincipalType is api token principal type.
+type APITokenPrincipalType string
+
+const (
+	// APITokenPrincipalUser 令牌代表用户本人
+	APITokenPrincipalUser APITokenPrincipalType = "user"
+	// APITokenPrincipalServiceAccount 令牌代表服务账号
+	APITokenPrincipalServiceAccount APITokenPrincipalType = "service_account"
+)
+
+// Validate APITokenPrincipalType.
+func (p APITokenPrincipalType) Validate() error {
+	switch p {
+	case APITokenPrincipalUser, APITokenPrincipalServiceAccount:
+		return nil
+	default:
+		return fmt.Errorf("unsupported api token principal type: %s", p)
+	}
+}
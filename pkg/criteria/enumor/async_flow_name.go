@@ -52,13 +52,18 @@ var defaultFlowNameMap = map[FlowName]struct{}{
 	FlowSleepTest:              {},
 	FlowDeleteSecurityGroup:    {},
 	FlowCreateHuaweiSGRule:     {},
+	FlowLintSecurityGroup:      {},
 	FlowDeleteEIP:              {},
+	FlowCascadeDelete:          {},
 	FlowPullRawBill:            {},
 	FlowSplitBill:              {},
 	FlowBillDailySummary:       {},
 	FlowBillMainAccountSummary: {},
 	FlowBillRootAccountSummary: {},
 	FlowBillMonthTask:          {},
+	FlowBillArchive:            {},
+	FlowBillArchiveRestore:     {},
+	FlowWebhookDeliver:         {},
 }
 
 // ValidateDefault validate default FlowName.
@@ -122,6 +127,7 @@ const (
 const (
 	FlowDeleteSecurityGroup FlowName = "delete_security_group"
 	FlowCreateHuaweiSGRule  FlowName = "create_huawei_sg_rule"
+	FlowLintSecurityGroup   FlowName = "lint_security_group"
 )
 
 // EIP 相关Flow
@@ -130,6 +136,12 @@ const (
 	FlowDeleteEIP FlowName = "delete_eip"
 )
 
+// 级联删除相关Flow
+const (
+	// FlowCascadeDelete 按依赖关系级联删除资源
+	FlowCascadeDelete FlowName = "cascade_delete"
+)
+
 // Flow 相关Flow
 const (
 	// FlowLoadBalancerOperateWatch 负载均衡操作查询
@@ -158,4 +170,12 @@ const (
 	FlowBillMainAccountSummary FlowName = "bill_main_account_summary"
 	FlowBillRootAccountSummary FlowName = "bill_root_account_summary"
 	FlowBillMonthTask          FlowName = "bill_month_task"
+	FlowBillArchive            FlowName = "bill_archive"
+	FlowBillArchiveRestore     FlowName = "bill_archive_restore"
+)
+
+// Webhook 相关Flow
+const (
+	// FlowWebhookDeliver 投递webhook事件
+	FlowWebhookDeliver FlowName = "webhook_deliver"
 )
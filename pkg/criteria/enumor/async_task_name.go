@@ -33,7 +33,8 @@ func (v ActionName) Validate() error {
 	case ActionDeleteFirewallRule:
 
 	case ActionDeleteSubnet:
-	case ActionDeleteSecurityGroup, ActionCreateHuaweiSGRule:
+	case ActionDeleteVpc:
+	case ActionDeleteSecurityGroup, ActionCreateHuaweiSGRule, ActionLintSecurityGroup:
 	case ActionDeleteEIP:
 
 	case VirRoot:
@@ -43,8 +44,10 @@ func (v ActionName) Validate() error {
 	case ActionListenerRuleAddTarget:
 	case ActionDeleteLoadBalancer:
 	case ActionPullDailyRawBill, ActionMainAccountSummary, ActionRootAccountSummary,
-		ActionDailyAccountSplit, ActionDailyAccountSummary, ActionMonthTaskAction:
+		ActionDailyAccountSplit, ActionDailyAccountSummary, ActionMonthTaskAction, ActionBillArchive,
+		ActionBillArchiveRestore:
 	case ActionLoadBalancerDeleteUrlRule, ActionLoadBalancerDeleteListener:
+	case ActionWebhookDeliver:
 
 	default:
 		return fmt.Errorf("unsupported action name type: %s", v)
@@ -77,6 +80,11 @@ const (
 	ActionDeleteSubnet ActionName = "delete_subnet"
 )
 
+// VPC相关Action
+const (
+	ActionDeleteVpc ActionName = "delete_vpc"
+)
+
 // 框架测试和框架中使用到的Action
 const (
 	// VirRoot vir root
@@ -93,6 +101,7 @@ const (
 const (
 	ActionDeleteSecurityGroup ActionName = "delete_security_group"
 	ActionCreateHuaweiSGRule  ActionName = "create_huawei_sg_rule"
+	ActionLintSecurityGroup   ActionName = "lint_security_group"
 )
 
 // EIP related action
@@ -129,4 +138,12 @@ const (
 	ActionDailyAccountSplit   = "bill_daily_account_split"
 	ActionDailyAccountSummary = "bill_daily_account_summary"
 	ActionMonthTaskAction     = "bill_month_task"
+	ActionBillArchive         = "bill_archive"
+	ActionBillArchiveRestore  = "bill_archive_restore"
+)
+
+// Webhook 相关Action
+const (
+	// ActionWebhookDeliver 投递webhook事件
+	ActionWebhookDeliver ActionName = "webhook_deliver"
 )
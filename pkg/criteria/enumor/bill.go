@@ -84,6 +84,42 @@ const (
 	CurrencyCNY CurrencyCode = "CNY"
 	// CurrencyRMB rmb currency
 	CurrencyRMB = CurrencyCNY
+	// CurrencyEUR euro currency
+	CurrencyEUR CurrencyCode = "EUR"
+	// CurrencyGBP pound sterling currency
+	CurrencyGBP CurrencyCode = "GBP"
+	// CurrencyHKD hong kong dollar currency
+	CurrencyHKD CurrencyCode = "HKD"
+	// CurrencyJPY japanese yen currency
+	CurrencyJPY CurrencyCode = "JPY"
+	// CurrencySGD singapore dollar currency
+	CurrencySGD CurrencyCode = "SGD"
+)
+
+// SupportedCurrencies 账单支持的币种，报表可以按其中任意币种换算
+var SupportedCurrencies = []CurrencyCode{CurrencyUSD, CurrencyCNY, CurrencyEUR, CurrencyGBP, CurrencyHKD,
+	CurrencyJPY, CurrencySGD}
+
+// Validate CurrencyCode.
+func (c CurrencyCode) Validate() error {
+	for _, one := range SupportedCurrencies {
+		if c == one {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported currency: %s", c)
+}
+
+// ExchangeRateSource 汇率来源
+type ExchangeRateSource string
+
+const (
+	// ExchangeRateSourceManual 手动录入
+	ExchangeRateSourceManual ExchangeRateSource = "manual"
+	// ExchangeRateSourceImport CSV文件导入
+	ExchangeRateSourceImport ExchangeRateSource = "import"
+	// ExchangeRateSourceHTTP HTTP汇率服务拉取
+	ExchangeRateSourceHTTP ExchangeRateSource = "http"
 )
 
 // BillAdjustmentType 调账类型
@@ -214,6 +250,9 @@ const (
 	GcpCreditsMonthTask MonthTaskType = "credits"
 	// GcpSupportMonthTask gcp support month task
 	GcpSupportMonthTask MonthTaskType = "support"
+
+	// BillAllocationMonthTask 按分摊规则分摊账单明细中共享费用的月度任务，各厂商通用
+	BillAllocationMonthTask MonthTaskType = "allocation"
 )
 
 // MonthTaskStep 月度任务步骤
@@ -261,3 +300,199 @@ var (
 		RootAccountBillSummaryStateStop:       "停止中",
 	}
 )
+
+// BillBudgetScope 账单预算范围
+type BillBudgetScope string
+
+// Validate BillBudgetScope.
+func (s BillBudgetScope) Validate() error {
+	switch s {
+	case BillBudgetScopeBiz, BillBudgetScopeMainAccount, BillBudgetScopeProduct:
+	default:
+		return fmt.Errorf("unsupported bill budget scope: %s", s)
+	}
+	return nil
+}
+
+const (
+	// BillBudgetScopeBiz 业务预算
+	BillBudgetScopeBiz BillBudgetScope = "biz"
+	// BillBudgetScopeMainAccount 二级账号预算
+	BillBudgetScopeMainAccount BillBudgetScope = "main_account"
+	// BillBudgetScopeProduct 运营产品预算
+	BillBudgetScopeProduct BillBudgetScope = "product"
+)
+
+// BillBudgetAlertType 预算告警类型
+type BillBudgetAlertType string
+
+const (
+	// BillBudgetAlertThreshold 当月累计费用超过预算阈值
+	BillBudgetAlertThreshold BillBudgetAlertType = "threshold"
+	// BillBudgetAlertForecast 预测月末费用超过预算
+	BillBudgetAlertForecast BillBudgetAlertType = "forecast"
+	// BillBudgetAlertAnomaly 日环比费用异常增长
+	BillBudgetAlertAnomaly BillBudgetAlertType = "anomaly"
+)
+
+// BillReportType 订阅报表类型
+type BillReportType string
+
+// Validate BillReportType.
+func (t BillReportType) Validate() error {
+	switch t {
+	case BillReportRootAccountSummary, BillReportMainAccountSummary, BillReportBizSummary, BillReportBillItem:
+	default:
+		return fmt.Errorf("unsupported bill report type: %s", t)
+	}
+	return nil
+}
+
+const (
+	// BillReportRootAccountSummary 一级账号账单汇总
+	BillReportRootAccountSummary BillReportType = "root_account_summary"
+	// BillReportMainAccountSummary 二级账号账单汇总
+	BillReportMainAccountSummary BillReportType = "main_account_summary"
+	// BillReportBizSummary 业务账单汇总
+	BillReportBizSummary BillReportType = "biz_summary"
+	// BillReportBillItem 账单明细，需要指定云厂商
+	BillReportBillItem BillReportType = "bill_item"
+)
+
+// BillReportFormat 订阅报表文件格式
+type BillReportFormat string
+
+// Validate BillReportFormat.
+func (f BillReportFormat) Validate() error {
+	switch f {
+	case BillReportFormatCsv, BillReportFormatExcel:
+	default:
+		return fmt.Errorf("unsupported bill report format: %s", f)
+	}
+	return nil
+}
+
+const (
+	// BillReportFormatCsv csv文件，压缩为zip
+	BillReportFormatCsv BillReportFormat = "csv"
+	// BillReportFormatExcel excel文件
+	BillReportFormatExcel BillReportFormat = "excel"
+)
+
+// BillReportCadence 订阅报表发送周期
+type BillReportCadence string
+
+// Validate BillReportCadence.
+func (c BillReportCadence) Validate() error {
+	switch c {
+	case BillReportCadenceMonthly, BillReportCadenceOnConfirm:
+	default:
+		return fmt.Errorf("unsupported bill report cadence: %s", c)
+	}
+	return nil
+}
+
+const (
+	// BillReportCadenceMonthly 每月指定日期发送上月报表
+	BillReportCadenceMonthly BillReportCadence = "monthly"
+	// BillReportCadenceOnConfirm 上月一级账号账单全部确认后发送
+	BillReportCadenceOnConfirm BillReportCadence = "on_confirm"
+)
+
+// BillAllocationRuleType 共享费用分摊方式
+type BillAllocationRuleType string
+
+// Validate BillAllocationRuleType.
+func (t BillAllocationRuleType) Validate() error {
+	switch t {
+	case BillAllocationFixed, BillAllocationUsage, BillAllocationCpu:
+	default:
+		return fmt.Errorf("unsupported bill allocation rule type: %s", t)
+	}
+	return nil
+}
+
+const (
+	// BillAllocationFixed 按固定比例分摊给指定二级账号
+	BillAllocationFixed BillAllocationRuleType = "fixed"
+	// BillAllocationUsage 按二级账号当月费用占比分摊
+	BillAllocationUsage BillAllocationRuleType = "usage"
+	// BillAllocationCpu 按二级账号所属业务下已同步主机的CPU核数占比分摊
+	BillAllocationCpu BillAllocationRuleType = "cpu"
+)
+
+// BillAllocationSourceType 共享费用来源
+type BillAllocationSourceType string
+
+// Validate BillAllocationSourceType.
+func (s BillAllocationSourceType) Validate() error {
+	switch s {
+	case BillAllocationSourceCommonExpense, BillAllocationSourceBillItem, BillAllocationSourceSavingsPlans,
+		BillAllocationSourceCredits:
+	default:
+		return fmt.Errorf("unsupported bill allocation source type: %s", s)
+	}
+	return nil
+}
+
+const (
+	// BillAllocationSourceCommonExpense 月度support任务拉取的公共费用，替代原有按费用占比的硬编码分摊
+	BillAllocationSourceCommonExpense BillAllocationSourceType = "common_expense"
+	// BillAllocationSourceBillItem 当月账单明细中匹配来源二级账号及产品编码的费用
+	BillAllocationSourceBillItem BillAllocationSourceType = "bill_item"
+	// BillAllocationSourceSavingsPlans aws月度savings_plans任务中savings plans的抵扣费用，作为收入归还给购买账号，
+	// 替代配置文件中的awsSavingsPlans
+	BillAllocationSourceSavingsPlans BillAllocationSourceType = "savings_plans"
+	// BillAllocationSourceCredits gcp月度credits任务中指定credit的抵扣金额，归还给指定账号，替代配置文件中的gcpCredits
+	BillAllocationSourceCredits BillAllocationSourceType = "credits"
+)
+
+// Vendor 返回只适用于特定云厂商的费用来源对应的云厂商，通用来源返回空
+func (s BillAllocationSourceType) Vendor() Vendor {
+	switch s {
+	case BillAllocationSourceSavingsPlans:
+		return Aws
+	case BillAllocationSourceCredits:
+		return Gcp
+	default:
+		return ""
+	}
+}
+
+// BillReconciliationState 账单对账结果
+type BillReconciliationState string
+
+const (
+	// BillReconciliationMatched 各币种差额均在允许范围内
+	BillReconciliationMatched BillReconciliationState = "matched"
+	// BillReconciliationMismatched 存在差额超出允许范围的币种
+	BillReconciliationMismatched BillReconciliationState = "mismatched"
+	// BillReconciliationUnsupported 云厂商不支持查询月度账单总额，无法对账
+	BillReconciliationUnsupported BillReconciliationState = "unsupported"
+)
+
+// BillForecastModel 费用预测模型
+type BillForecastModel string
+
+const (
+	// BillForecastLinear 按日费用线性趋势预测
+	BillForecastLinear BillForecastModel = "linear"
+	// BillForecastWeeklySeasonal 在线性趋势基础上叠加按星期的周期波动
+	BillForecastWeeklySeasonal BillForecastModel = "weekly_seasonal"
+)
+
+// BillArchiveState 账单明细归档状态
+type BillArchiveState string
+
+const (
+	// BillArchiveArchiving 正在导出到对象存储
+	BillArchiveArchiving BillArchiveState = "archiving"
+	// BillArchiveArchived 已归档，分表已删除
+	BillArchiveArchived BillArchiveState = "archived"
+	// BillArchiveRestoring 正在从归档文件恢复分表
+	BillArchiveRestoring BillArchiveState = "restoring"
+	// BillArchiveRestored 已恢复，保留期满后重新删除分表
+	BillArchiveRestored BillArchiveState = "restored"
+	// BillArchiveFailed 归档失败，下次检测时重试
+	BillArchiveFailed BillArchiveState = "failed"
+)
@@ -30,6 +30,16 @@ const (
 	Ingress SecurityGroupRuleType = "ingress"
 )
 
+// SGPolicyAction is security group policy action.
+type SGPolicyAction string
+
+const (
+	// SGPolicyAllow 放行
+	SGPolicyAllow SGPolicyAction = "allow"
+	// SGPolicyDeny 拒绝
+	SGPolicyDeny SGPolicyAction = "deny"
+)
+
 // RequestSourceType is request source type.
 type RequestSourceType string
 
@@ -38,12 +48,15 @@ const (
 	ApiCall RequestSourceType = "api_call"
 	// BackgroundSync 同步云上数据而发出的请求。
 	BackgroundSync RequestSourceType = "background_sync"
+	// CloudSync 资源同步发现云上资源在HCM之外被变更而发出的请求。
+	CloudSync RequestSourceType = "cloud_sync"
 )
 
 // RequestSourceEnums request type map.
 var RequestSourceEnums = map[RequestSourceType]bool{
 	ApiCall:        true,
 	BackgroundSync: true,
+	CloudSync:      true,
 }
 
 // Exist judge enum value exist.
@@ -42,8 +42,20 @@ const (
 
 // RecycleAuditResTypeMap recycle resource audit type to cloud resource type map.
 var RecycleAuditResTypeMap = map[AuditResourceType]CloudResourceType{
-	CvmAuditResType:  CvmCloudResType,
-	DiskAuditResType: DiskCloudResType,
+	CvmAuditResType:           CvmCloudResType,
+	DiskAuditResType:          DiskCloudResType,
+	EipAuditResType:           EipCloudResType,
+	LoadBalancerAuditResType:  LoadBalancerCloudResType,
+	SecurityGroupAuditResType: SecurityGroupCloudResType,
+}
+
+// RecyclableResTypeMap 支持加入回收站的资源类型
+var RecyclableResTypeMap = map[CloudResourceType]struct{}{
+	CvmCloudResType:           {},
+	DiskCloudResType:          {},
+	EipCloudResType:           {},
+	LoadBalancerCloudResType:  {},
+	SecurityGroupCloudResType: {},
 }
 
 // RecycleType 回收类型
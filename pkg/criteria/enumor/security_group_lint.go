@@ -0,0 +1,46 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+// SGLintType 安全组规则检查项类型
+type SGLintType string
+
+const (
+	// SGLintOpenAdminPort 管理端口(22/3389/3306)对全网开放
+	SGLintOpenAdminPort SGLintType = "open_admin_port"
+	// SGLintShadowedRule 规则被更高优先级的规则完全覆盖，永远不会生效
+	SGLintShadowedRule SGLintType = "shadowed_rule"
+	// SGLintDuplicateRule 同一安全组中存在完全重复的规则
+	SGLintDuplicateRule SGLintType = "duplicate_rule"
+	// SGLintUnusedGroup 安全组未关联任何资源
+	SGLintUnusedGroup SGLintType = "unused_group"
+)
+
+// RiskLevel 风险等级
+type RiskLevel string
+
+const (
+	// RiskLevelHigh 高风险
+	RiskLevelHigh RiskLevel = "high"
+	// RiskLevelMedium 中风险
+	RiskLevelMedium RiskLevel = "medium"
+	// RiskLevelLow 低风险
+	RiskLevelLow RiskLevel = "low"
+)
@@ -0,0 +1,97 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package enumor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WebhookEventType is webhook event type.
+type WebhookEventType string
+
+const (
+	// WebhookEventAll 订阅所有事件
+	WebhookEventAll WebhookEventType = "*"
+	// WebhookEventAuditPrefix 审计事件前缀，完整事件类型为 audit.{res_type}.{action}，如 audit.cvm.delete
+	WebhookEventAuditPrefix WebhookEventType = "audit."
+	// WebhookEventApplicationStatus 申请单状态变更事件
+	WebhookEventApplicationStatus WebhookEventType = "application.status_change"
+	// WebhookEventAsyncFlowComplete 异步任务流执行结束事件
+	WebhookEventAsyncFlowComplete WebhookEventType = "async_flow.complete"
+	// WebhookEventRecycleExpiry 回收站资源即将到期事件
+	WebhookEventRecycleExpiry WebhookEventType = "recycle.expiry"
+)
+
+// NewAuditWebhookEventType new audit webhook event type by audit resource type and action.
+func NewAuditWebhookEventType(resType AuditResourceType, action AuditAction) WebhookEventType {
+	return WebhookEventType(fmt.Sprintf("%s%s.%s", WebhookEventAuditPrefix, resType, action))
+}
+
+// Validate WebhookEventType, subscription event type supports wildcard suffix, e.g. audit.*, audit.cvm.*
+func (w WebhookEventType) Validate() error {
+	switch w {
+	case WebhookEventAll, WebhookEventApplicationStatus, WebhookEventAsyncFlowComplete, WebhookEventRecycleExpiry:
+		return nil
+	}
+
+	if strings.HasPrefix(string(w), string(WebhookEventAuditPrefix)) &&
+		len(w) > len(WebhookEventAuditPrefix) {
+		return nil
+	}
+
+	return fmt.Errorf("unsupported webhook event type: %s", w)
+}
+
+// Match 判断订阅的事件类型是否匹配实际发生的事件，支持以 * 结尾的前缀匹配
+func (w WebhookEventType) Match(event WebhookEventType) bool {
+	if w == event || w == WebhookEventAll {
+		return true
+	}
+
+	if strings.HasSuffix(string(w), "*") {
+		return strings.HasPrefix(string(event), strings.TrimSuffix(string(w), "*"))
+	}
+
+	return false
+}
+
+// WebhookDeliveryState is webhook delivery state.
+type WebhookDeliveryState string
+
+const (
+	// WebhookDeliveryPending 待投递/投递重试中
+	WebhookDeliveryPending WebhookDeliveryState = "pending"
+	// WebhookDeliverySuccess 投递成功
+	WebhookDeliverySuccess WebhookDeliveryState = "success"
+	// WebhookDeliveryFailed 投递失败
+	WebhookDeliveryFailed WebhookDeliveryState = "failed"
+)
+
+// Validate WebhookDeliveryState.
+func (s WebhookDeliveryState) Validate() error {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliverySuccess, WebhookDeliveryFailed:
+	default:
+		return fmt.Errorf("unsupported webhook delivery state: %s", s)
+	}
+
+	return nil
+}
@@ -22,10 +22,12 @@ package audit
 
 import (
 	"fmt"
+	"strings"
 
 	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
+	idgen "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
 	"hcm/pkg/dal/dao/tools"
 	"hcm/pkg/dal/dao/types"
@@ -44,20 +46,27 @@ type Interface interface {
 	BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error
 	BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error
 	List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails, error)
+	Search(kt *kit.Kit, opt *types.ListOption, keyword string) (*types.ListAuditDetails, error)
+	ListFieldChange(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[audit.FieldChangeTable], error)
+	CreateExport(kt *kit.Kit, one *audit.ExportTable) (string, error)
+	ListExport(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[audit.ExportTable], error)
+	CountChange(kt *kit.Kit, expr *filter.Expression) ([]types.AuditChangeCount, error)
 }
 
 var _ Interface = new(Dao)
 
 // NewAudit new audit.
-func NewAudit(orm orm.Interface) Interface {
+func NewAudit(orm orm.Interface, idGen idgen.IDGenInterface) Interface {
 	return &Dao{
-		Orm: orm,
+		Orm:   orm,
+		IDGen: idGen,
 	}
 }
 
 // Dao audit dao.
 type Dao struct {
-	Orm orm.Interface
+	Orm   orm.Interface
+	IDGen idgen.IDGenInterface
 }
 
 // Create audit.
@@ -67,31 +76,59 @@ func (d Dao) Create(kt *kit.Kit, one *audit.AuditTable) error {
 
 // BatchCreate batch create audit.
 func (d Dao) BatchCreate(kt *kit.Kit, audits []*audit.AuditTable) error {
+	_, err := d.Orm.AutoTxn(kt, func(txn *sqlx.Tx, opt *orm.TxnOption) (interface{}, error) {
+		return nil, d.BatchCreateWithTx(kt, txn, audits)
+	})
+	return err
+}
+
+// BatchCreateWithTx batch create audit with tx, full-text search records and field changes of update audits
+// are created in the same tx.
+func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error {
+	if len(audits) == 0 {
+		return nil
+	}
 	for _, one := range audits {
 		if err := one.CreateValidate(); err != nil {
 			return err
 		}
 	}
 
-	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
-		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
-
-	if err := d.Orm.Do().BulkInsert(kt.Ctx, sql, audits); err != nil {
-		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditTable, err, kt.Rid)
-		return fmt.Errorf("insert %s failed, err: %v", table.AuditTable, err)
+	if err := d.insertAuditsWithTx(kt, tx, audits); err != nil {
+		return err
 	}
 
-	return nil
-}
+	ids, err := d.insertedAuditIDs(kt, tx, len(audits))
+	if err != nil {
+		return err
+	}
 
-// BatchCreateWithTx batch create audit with tx.
-func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error {
-	for _, one := range audits {
-		if err := one.CreateValidate(); err != nil {
+	searches := make([]*audit.SearchTable, 0, len(audits))
+	for idx, one := range audits {
+		search, err := audit.NewSearchTable(ids[idx], one)
+		if err != nil {
+			logs.Errorf("build audit search failed, err: %v, res_id: %s, rid: %s", err, one.ResID, kt.Rid)
 			return err
 		}
+		searches = append(searches, search)
+	}
+	if err = d.createSearchWithTx(kt, tx, searches); err != nil {
+		return err
+	}
+
+	changes := buildFieldChanges(kt, audits)
+	models := make([]*audit.FieldChangeTable, 0)
+	for idx := range audits {
+		for _, change := range changes[idx] {
+			change.AuditID = ids[idx]
+			models = append(models, change)
+		}
 	}
 
+	return d.createFieldChangesWithTx(kt, tx, models)
+}
+
+func (d Dao) insertAuditsWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTable) error {
 	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditTable,
 		audit.AuditColumns.ColumnExpr(), audit.AuditColumns.ColonNameExpr())
 
@@ -103,8 +140,70 @@ func (d Dao) BatchCreateWithTx(kt *kit.Kit, tx *sqlx.Tx, audits []*audit.AuditTa
 	return nil
 }
 
+type lastInsertInfo struct {
+	FirstID uint64 `db:"first_id"`
+	Step    uint64 `db:"step"`
+}
+
+// insertedAuditIDs 获取刚批量插入的审计ID。同一条多行INSERT语句插入的行数已知，InnoDB一次分配连续的自增ID，
+// LAST_INSERT_ID为事务连接上该语句的第一个ID，后续ID按auto_increment_increment递增
+func (d Dao) insertedAuditIDs(kt *kit.Kit, tx *sqlx.Tx, count int) ([]uint64, error) {
+	infos := make([]lastInsertInfo, 0, 1)
+	sql := `SELECT LAST_INSERT_ID() AS first_id, @@auto_increment_increment AS step`
+	if err := d.Orm.Txn(tx).Select(kt.Ctx, &infos, sql, map[string]interface{}{}); err != nil {
+		logs.Errorf("get last insert audit id failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+	if len(infos) != 1 || infos[0].FirstID == 0 {
+		return nil, errf.New(errf.Aborted, "get last insert audit id failed")
+	}
+
+	return consecutiveIDs(infos[0].FirstID, infos[0].Step, count), nil
+}
+
+func consecutiveIDs(firstID, step uint64, count int) []uint64 {
+	if step == 0 {
+		step = 1
+	}
+	ids := make([]uint64, count)
+	for idx := range ids {
+		ids[idx] = firstID + uint64(idx)*step
+	}
+	return ids
+}
+
+func (d Dao) createSearchWithTx(kt *kit.Kit, tx *sqlx.Tx, searches []*audit.SearchTable) error {
+	if len(searches) == 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditSearchTable,
+		audit.SearchColumns.ColumnExpr(), audit.SearchColumns.ColonNameExpr())
+
+	if err := d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, searches); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditSearchTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.AuditSearchTable, err)
+	}
+
+	return nil
+}
+
 // List audit.
 func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails, error) {
+	return d.list(kt, opt, "")
+}
+
+// Search audit by full-text search over res_name, cloud_res_id and detail, and filter by list option.
+func (d Dao) Search(kt *kit.Kit, opt *types.ListOption, keyword string) (*types.ListAuditDetails, error) {
+	keyword = strings.TrimSpace(strings.ReplaceAll(keyword, `"`, " "))
+	if len(keyword) == 0 {
+		return nil, errf.New(errf.InvalidParameter, "search keyword is empty")
+	}
+
+	return d.list(kt, opt, keyword)
+}
+
+func (d Dao) list(kt *kit.Kit, opt *types.ListOption, keyword string) (*types.ListAuditDetails, error) {
 	if opt == nil {
 		return nil, errf.New(errf.InvalidParameter, "list options is nil")
 	}
@@ -120,6 +219,10 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails,
 		return nil, err
 	}
 
+	if len(keyword) != 0 {
+		whereExpr, whereValue = withSearchExpr(whereExpr, whereValue, keyword)
+	}
+
 	if opt.Page.Count {
 		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AuditTable, whereExpr)
 
@@ -147,3 +250,48 @@ func (d Dao) List(kt *kit.Kit, opt *types.ListOption) (*types.ListAuditDetails,
 
 	return &types.ListAuditDetails{Details: details}, nil
 }
+
+// CountChange 按账号、资源类型、操作类型分组统计满足过滤条件的审计数量。
+func (d Dao) CountChange(kt *kit.Kit, expr *filter.Expression) ([]types.AuditChangeCount, error) {
+	if expr == nil {
+		return nil, errf.New(errf.InvalidParameter, "filter expr is nil")
+	}
+
+	exprOpt := filter.NewExprOption(filter.RuleFields(audit.AuditColumns.ColumnTypes()))
+	if err := expr.Validate(exprOpt); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := expr.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT account_id, vendor, res_type, action, COUNT(*) AS count FROM %s %s `+
+		`GROUP BY account_id, vendor, res_type, action`, table.AuditTable, whereExpr)
+
+	counts := make([]types.AuditChangeCount, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &counts, sql, whereValue); err != nil {
+		logs.ErrorJson("count audit change failed, err: %v, filter: %s, rid: %s", err, expr, kt.Rid)
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// withSearchExpr 在过滤条件上追加全文检索条件，关键字作为短语匹配，避免其中的 -、+ 等字符被当作布尔检索操作符
+func withSearchExpr(whereExpr string, whereValue map[string]interface{}, keyword string) (
+	string, map[string]interface{}) {
+
+	searchExpr := fmt.Sprintf("id IN (SELECT audit_id FROM %s WHERE MATCH(res_name, cloud_res_id, detail_text) "+
+		"AGAINST(:audit_search_keyword IN BOOLEAN MODE))", table.AuditSearchTable)
+	if whereValue == nil {
+		whereValue = make(map[string]interface{})
+	}
+	whereValue["audit_search_keyword"] = `"` + keyword + `"`
+
+	if len(whereExpr) == 0 {
+		return "WHERE " + searchExpr, whereValue
+	}
+	return fmt.Sprintf("WHERE (%s) AND %s", strings.TrimPrefix(whereExpr, "WHERE "), searchExpr), whereValue
+}
@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConsecutiveIDs(t *testing.T) {
+	cases := []struct {
+		firstID uint64
+		step    uint64
+		count   int
+		want    []uint64
+	}{
+		{firstID: 100, step: 1, count: 3, want: []uint64{100, 101, 102}},
+		// 多主部署时auto_increment_increment大于1
+		{firstID: 101, step: 2, count: 3, want: []uint64{101, 103, 105}},
+		{firstID: 7, step: 0, count: 2, want: []uint64{7, 8}},
+		{firstID: 7, step: 1, count: 0, want: []uint64{}},
+	}
+	for _, c := range cases {
+		if got := consecutiveIDs(c.firstID, c.step, c.count); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("consecutiveIDs(%d, %d, %d) = %v, want %v", c.firstID, c.step, c.count, got, c.want)
+		}
+	}
+}
+
+func TestWithSearchExpr(t *testing.T) {
+	expr, value := withSearchExpr("WHERE res_type = :res_type", map[string]interface{}{"res_type": "cvm"}, "ins-1")
+	if !strings.HasPrefix(expr, "WHERE (res_type = :res_type) AND id IN (SELECT audit_id FROM audit_search ") {
+		t.Errorf("unexpected search expr: %s", expr)
+	}
+	if value["audit_search_keyword"] != `"ins-1"` || value["res_type"] != "cvm" {
+		t.Errorf("unexpected search value: %v", value)
+	}
+
+	expr, _ = withSearchExpr("", nil, "ins-1")
+	if !strings.HasPrefix(expr, "WHERE id IN (SELECT audit_id FROM audit_search WHERE MATCH(") {
+		t.Errorf("unexpected search expr without filter: %s", expr)
+	}
+}
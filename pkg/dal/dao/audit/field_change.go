@@ -0,0 +1,128 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"fmt"
+
+	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/dal/dao/types"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/audit"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/runtime/filter"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// buildFieldChanges 生成更新审计的字段变更记录，key为审计在audits中的下标。
+// 字段变更只用于查询，生成失败时不影响审计记录的保存。
+func buildFieldChanges(kt *kit.Kit, audits []*audit.AuditTable) map[int][]*audit.FieldChangeTable {
+	changes := make(map[int][]*audit.FieldChangeTable)
+	for idx, one := range audits {
+		fieldChanges, err := audit.BuildFieldChanges(one)
+		if err != nil {
+			logs.Warnf("build audit field changes failed, err: %v, res_type: %s, res_id: %s, rid: %s", err,
+				one.ResType, one.ResID, kt.Rid)
+			continue
+		}
+
+		if len(fieldChanges) != 0 {
+			changes[idx] = fieldChanges
+		}
+	}
+
+	return changes
+}
+
+// createFieldChangesWithTx 保存已关联审计ID的字段变更
+func (d Dao) createFieldChangesWithTx(kt *kit.Kit, tx *sqlx.Tx, models []*audit.FieldChangeTable) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	for _, one := range models {
+		if err := one.CreateValidate(); err != nil {
+			return err
+		}
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO %s (%s)	VALUES(%s)`, table.AuditFieldChangeTable,
+		audit.FieldChangeColumns.ColumnExpr(), audit.FieldChangeColumns.ColonNameExpr())
+
+	if err := d.Orm.Txn(tx).BulkInsert(kt.Ctx, sql, models); err != nil {
+		logs.Errorf("insert %s failed, err: %v, rid: %s", table.AuditFieldChangeTable, err, kt.Rid)
+		return fmt.Errorf("insert %s failed, err: %v", table.AuditFieldChangeTable, err)
+	}
+
+	return nil
+}
+
+// ListFieldChange list audit field change.
+func (d Dao) ListFieldChange(kt *kit.Kit, opt *types.ListOption) (*types.ListResult[audit.FieldChangeTable],
+	error) {
+
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list options is nil")
+	}
+
+	columnTypes := audit.FieldChangeColumns.ColumnTypes()
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(columnTypes)),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(*) FROM %s %s`, table.AuditFieldChangeTable, whereExpr)
+
+		count, err := d.Orm.Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count audit field change failed, err: %v, filter: %s, rid: %s", err, opt.Filter,
+				kt.Rid)
+			return nil, err
+		}
+
+		return &types.ListResult[audit.FieldChangeTable]{Count: count}, nil
+	}
+
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT %s FROM %s %s %s`, audit.FieldChangeColumns.FieldsNamedExpr(opt.Fields),
+		table.AuditFieldChangeTable, whereExpr, pageExpr)
+
+	details := make([]audit.FieldChangeTable, 0)
+	if err = d.Orm.Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.ErrorJson("select audit field change failed, err: %v, filter: %s, rid: %s", err, opt.Filter, kt.Rid)
+		return nil, err
+	}
+
+	return &types.ListResult[audit.FieldChangeTable]{Details: details}, nil
+}
@@ -23,8 +23,11 @@ package bill
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 
 	"hcm/pkg/api/core"
+	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
@@ -52,6 +55,12 @@ type AccountBillItem interface {
 		updateData *tablebill.AccountBillItem) error
 
 	DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *typesbill.ItemCommonOpt, filterExpr *filter.Expression) error
+	ListResCost(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt, opt *types.ListOption) (
+		*typesbill.ListBillItemResCostDetails, error)
+
+	ListPartition(kt *kit.Kit) ([]typesbill.BillItemPartition, error)
+	CreatePartition(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt) error
+	DropPartition(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt) error
 }
 
 // AccountBillItemDao account bill item dao
@@ -240,6 +249,200 @@ func (a AccountBillItemDao) DeleteWithTx(kt *kit.Kit, tx *sqlx.Tx, commonOpt *ty
 	return nil
 }
 
+// ListResCost 按云资源ID和币种聚合账单明细费用
+func (a AccountBillItemDao) ListResCost(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt, opt *types.ListOption) (
+	*typesbill.ListBillItemResCostDetails, error) {
+
+	if commonOpt == nil {
+		return nil, errf.New(errf.InvalidParameter, "common options is nil")
+	}
+	if opt == nil {
+		return nil, errf.New(errf.InvalidParameter, "list bill item resource cost options is nil")
+	}
+
+	if err := opt.Validate(filter.NewExprOption(filter.RuleFields(tablebill.AccountBillItemColumns.ColumnTypes())),
+		core.NewDefaultPageOption()); err != nil {
+		return nil, err
+	}
+
+	whereExpr, whereValue, err := opt.Filter.SQLWhereExpr(tools.DefaultSqlWhereOption)
+	if err != nil {
+		return nil, err
+	}
+
+	tableName := table.AccountBillItemTable
+	shardingOpt, err := convertShardingOpt(tableName, commonOpt)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Page.Count {
+		sql := fmt.Sprintf(`SELECT COUNT(distinct cloud_res_id, currency) FROM %s %s`, tableName, whereExpr)
+		count, err := a.Orm.TableSharding(shardingOpt).Do().Count(kt.Ctx, sql, whereValue)
+		if err != nil {
+			logs.ErrorJson("count bill item resource cost failed, err: %v, shardingOpt: %v, opt: %+v, rid: %s",
+				err, shardingOpt, opt, kt.Rid)
+			return nil, err
+		}
+
+		return &typesbill.ListBillItemResCostDetails{Count: count}, nil
+	}
+
+	// 仅支持按聚合字段排序，默认按cloud_res_id排序，避免因为设置成根据id排序导致sql执行失败
+	if opt.Page.Sort != "cost" && opt.Page.Sort != "currency" {
+		opt.Page.Sort = "cloud_res_id"
+	}
+	pageExpr, err := types.PageSQLExpr(opt.Page, types.DefaultPageSQLOption)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(`SELECT cloud_res_id, currency, SUM(cost) as cost FROM %s %s `+
+		`group by cloud_res_id, currency %s`, tableName, whereExpr, pageExpr)
+	details := make([]typesbill.BillItemResCost, 0)
+	if err = a.Orm.TableSharding(shardingOpt).Do().Select(kt.Ctx, &details, sql, whereValue); err != nil {
+		logs.Errorf("list bill item resource cost failed, err: %v, shardingOpt: %v, sql: %s, rid: %s",
+			err, shardingOpt, sql, kt.Rid)
+		return nil, err
+	}
+
+	return &typesbill.ListBillItemResCostDetails{Details: details}, nil
+}
+
+// billItemPartitionRegexp 账单明细分表名，如 account_bill_item_aws_202409
+var billItemPartitionRegexp = regexp.MustCompile(`^` + string(table.AccountBillItemTable) +
+	`_([a-z_]+)_(\d{4})(\d{2})$`)
+
+// ListPartition 查询当前库中已存在的账单明细分表，行数为 information_schema 中的估算值
+func (a AccountBillItemDao) ListPartition(kt *kit.Kit) ([]typesbill.BillItemPartition, error) {
+	sql := `SELECT TABLE_NAME AS table_name, IFNULL(TABLE_ROWS, 0) AS table_rows, ` +
+		`IFNULL(DATA_LENGTH, 0) + IFNULL(INDEX_LENGTH, 0) AS data_size FROM information_schema.TABLES ` +
+		`WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME LIKE :prefix`
+
+	tables := make([]struct {
+		TableName string `db:"table_name"`
+		TableRows uint64 `db:"table_rows"`
+		DataSize  uint64 `db:"data_size"`
+	}, 0)
+	prefix := map[string]interface{}{"prefix": string(table.AccountBillItemTable) + `\_%`}
+	if err := a.Orm.Do().Select(kt.Ctx, &tables, sql, prefix); err != nil {
+		logs.Errorf("list bill item partition failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	partitions := make([]typesbill.BillItemPartition, 0, len(tables))
+	for _, one := range tables {
+		matches := billItemPartitionRegexp.FindStringSubmatch(one.TableName)
+		if len(matches) != 4 {
+			continue
+		}
+		vendor := enumor.Vendor(matches[1])
+		if err := vendor.Validate(); err != nil {
+			continue
+		}
+		year, _ := strconv.Atoi(matches[2])
+		month, _ := strconv.Atoi(matches[3])
+		partitions = append(partitions, typesbill.BillItemPartition{
+			Vendor:    vendor,
+			BillYear:  year,
+			BillMonth: month,
+			TableName: one.TableName,
+			TableRows: one.TableRows,
+			DataSize:  one.DataSize,
+		})
+	}
+
+	return partitions, nil
+}
+
+// CreatePartition 按账单明细模板表创建指定云厂商、月份的分表，分表已存在时忽略
+func (a AccountBillItemDao) CreatePartition(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt) error {
+	shardingOpt, err := convertShardingOpt(table.AccountBillItemTable, commonOpt)
+	if err != nil {
+		return err
+	}
+	partition := shardingOpt.ReplaceTableName(table.AccountBillItemTable)
+
+	sql := fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` LIKE `%s`", partition, table.AccountBillItemTable)
+	if _, err = a.Orm.Do().Exec(kt.Ctx, sql); err != nil {
+		logs.Errorf("create bill item partition %s failed, err: %v, rid: %s", partition, err, kt.Rid)
+		return err
+	}
+
+	// 分表已存在时不会随模板表变更，需要补齐模板表后续新增的字段
+	return a.patchPartitionColumns(kt, partition)
+}
+
+// billItemPartitionPatch 账单明细模板表新增的字段及对应的分表变更语句
+type billItemPartitionPatch struct {
+	column string
+	alter  string
+}
+
+var billItemPartitionPatches = []billItemPartitionPatch{
+	{
+		column: "cloud_res_id",
+		alter: "ALTER TABLE `%s` ADD COLUMN `cloud_res_id` varchar(255) DEFAULT '' AFTER `res_amount_unit`, " +
+			"ADD INDEX `idx_vendor_year_month_cloud_res_id` (`vendor`, `bill_year`, `bill_month`, `cloud_res_id`)",
+	},
+}
+
+// patchPartitionColumns 已存在的分表缺少模板表新增字段时执行变更补齐
+func (a AccountBillItemDao) patchPartitionColumns(kt *kit.Kit, partition string) error {
+	sql := `SELECT COLUMN_NAME AS column_name FROM information_schema.COLUMNS ` +
+		`WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = :table_name`
+	columns := make([]struct {
+		ColumnName string `db:"column_name"`
+	}, 0)
+	args := map[string]interface{}{"table_name": partition}
+	if err := a.Orm.Do().Select(kt.Ctx, &columns, sql, args); err != nil {
+		logs.Errorf("list bill item partition %s columns failed, err: %v, rid: %s", partition, err, kt.Rid)
+		return err
+	}
+
+	existColumns := make(map[string]struct{}, len(columns))
+	for _, one := range columns {
+		existColumns[one.ColumnName] = struct{}{}
+	}
+
+	for _, alterSQL := range partitionPatchSQLs(partition, existColumns) {
+		if _, err := a.Orm.Do().Exec(kt.Ctx, alterSQL); err != nil {
+			logs.Errorf("patch bill item partition %s failed, err: %v, sql: %s, rid: %s", partition, err, alterSQL,
+				kt.Rid)
+			return err
+		}
+		logs.Infof("patch bill item partition %s success, sql: %s, rid: %s", partition, alterSQL, kt.Rid)
+	}
+	return nil
+}
+
+// partitionPatchSQLs 根据分表已有字段生成需要执行的变更语句
+func partitionPatchSQLs(partition string, existColumns map[string]struct{}) []string {
+	sqls := make([]string, 0)
+	for _, patch := range billItemPartitionPatches {
+		if _, exists := existColumns[patch.column]; exists {
+			continue
+		}
+		sqls = append(sqls, fmt.Sprintf(patch.alter, partition))
+	}
+	return sqls
+}
+
+// DropPartition 删除指定云厂商、月份的分表，调用方需确保分表数据已归档
+func (a AccountBillItemDao) DropPartition(kt *kit.Kit, commonOpt *typesbill.ItemCommonOpt) error {
+	shardingOpt, err := convertShardingOpt(table.AccountBillItemTable, commonOpt)
+	if err != nil {
+		return err
+	}
+	partition := shardingOpt.ReplaceTableName(table.AccountBillItemTable)
+
+	sql := fmt.Sprintf("DROP TABLE IF EXISTS `%s`", partition)
+	if _, err = a.Orm.Do().Exec(kt.Ctx, sql); err != nil {
+		logs.Errorf("drop bill item partition %s failed, err: %v, rid: %s", partition, err, kt.Rid)
+		return err
+	}
+	return nil
+}
+
 func convertShardingOpt(tableName string, commonOpt *typesbill.ItemCommonOpt) (*orm.TableSuffixShardingOpt, error) {
 	if commonOpt == nil {
 		return nil, errors.New("common opt is required")
@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"strings"
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+	typesbill "hcm/pkg/dal/dao/types/bill"
+	"hcm/pkg/dal/table"
+)
+
+func TestPartitionPatchSQLs(t *testing.T) {
+	shardingOpt, err := convertShardingOpt(table.AccountBillItemTable,
+		&typesbill.ItemCommonOpt{Vendor: enumor.Aws, Year: 2024, Month: 9})
+	if err != nil {
+		t.Fatalf("convert sharding opt failed, err: %v", err)
+	}
+	partition := shardingOpt.ReplaceTableName(table.AccountBillItemTable)
+	if partition != "account_bill_item_aws_202409" {
+		t.Fatalf("unexpected partition name: %s", partition)
+	}
+	if !billItemPartitionRegexp.MatchString(partition) {
+		t.Errorf("partition %s should match partition regexp", partition)
+	}
+
+	// 早于字段变更创建的分表缺少cloud_res_id
+	sqls := partitionPatchSQLs(partition, map[string]struct{}{"id": {}, "res_amount_unit": {}})
+	if len(sqls) != 1 {
+		t.Fatalf("expect 1 patch sql, got %d: %v", len(sqls), sqls)
+	}
+	if !strings.HasPrefix(sqls[0], "ALTER TABLE `account_bill_item_aws_202409` ADD COLUMN `cloud_res_id`") {
+		t.Errorf("patch sql should alter the partition table, got: %s", sqls[0])
+	}
+
+	// 已包含字段的分表无需变更
+	sqls = partitionPatchSQLs(partition, map[string]struct{}{"id": {}, "cloud_res_id": {}})
+	if len(sqls) != 0 {
+		t.Errorf("expect no patch sql, got: %v", sqls)
+	}
+}
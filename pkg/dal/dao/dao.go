@@ -27,6 +27,7 @@ import (
 
 	"hcm/pkg/cc"
 	accountset "hcm/pkg/dal/dao/account-set"
+	daoapitoken "hcm/pkg/dal/dao/api-token"
 	"hcm/pkg/dal/dao/application"
 	daoasync "hcm/pkg/dal/dao/async"
 	"hcm/pkg/dal/dao/audit"
@@ -53,13 +54,17 @@ import (
 	securitygroup "hcm/pkg/dal/dao/cloud/security-group"
 	sgcomrel "hcm/pkg/dal/dao/cloud/security-group-common-rel"
 	sgcvmrel "hcm/pkg/dal/dao/cloud/security-group-cvm-rel"
+	sglint "hcm/pkg/dal/dao/cloud/security-group-lint"
 	daosubaccount "hcm/pkg/dal/dao/cloud/sub-account"
 	daosync "hcm/pkg/dal/dao/cloud/sync"
 	"hcm/pkg/dal/dao/cloud/zone"
 	idgenerator "hcm/pkg/dal/dao/id-generator"
 	"hcm/pkg/dal/dao/orm"
+	daorbac "hcm/pkg/dal/dao/rbac"
+	recyclepolicy "hcm/pkg/dal/dao/recycle-policy"
 	recyclerecord "hcm/pkg/dal/dao/recycle-record"
 	daouser "hcm/pkg/dal/dao/user"
+	daowebhook "hcm/pkg/dal/dao/webhook"
 	"hcm/pkg/kit"
 	"hcm/pkg/metrics"
 
@@ -99,6 +104,14 @@ type Set interface {
 	ApprovalProcess() application.ApprovalProcess
 	NetworkInterface() networkinterface.NetworkInterface
 	RecycleRecord() recyclerecord.RecycleRecord
+	RecyclePolicy() recyclepolicy.Interface
+	WebhookSubscription() daowebhook.Subscription
+	WebhookDelivery() daowebhook.Delivery
+	ServiceAccount() daoapitoken.ServiceAccount
+	APIToken() daoapitoken.Token
+	RBACRole() daorbac.Role
+	RBACGroup() daorbac.Group
+	RBACRoleBinding() daorbac.RoleBinding
 	Eip() eip.Eip
 	Disk() disk.Disk
 	NiCvmRel() nicvmrel.NiCvmRel
@@ -117,6 +130,11 @@ type Set interface {
 	RootAccountBillConfig() bill.RootAccountBillConfig
 	AccountBillExchangeRate() bill.AccountBillExchangeRate
 	AccountBillSyncRecord() bill.AccountBillSyncRecord
+	AccountBillBudget() bill.AccountBillBudget
+	AccountBillReportSub() bill.AccountBillReportSub
+	AccountBillAllocationRule() bill.AccountBillAllocationRule
+	AccountBillReconciliation() bill.AccountBillReconciliation
+	AccountBillArchive() bill.AccountBillArchive
 	AsyncFlow() daoasync.AsyncFlow
 	AsyncFlowTask() daoasync.AsyncFlowTask
 	UserCollection() daouser.Interface
@@ -134,10 +152,13 @@ type Set interface {
 	ResourceFlowRel() resflow.ResourceFlowRelInterface
 	ResourceFlowLock() resflow.ResourceFlowLockInterface
 	SGCommonRel() sgcomrel.Interface
+	SGLintFinding() sglint.Interface
 	MainAccount() accountset.MainAccount
 	RootAccount() accountset.RootAccount
 
 	Txn() *Txn
+	// SetIngressLimit update db request limiter related params.
+	SetIngressLimit(qps, burst uint)
 }
 
 // NewDaoSet create the DAO set instance.
@@ -156,7 +177,7 @@ func NewDaoSet(opt cc.DataBase) (Set, error) {
 		idGen: idGen,
 		orm:   ormInst,
 		db:    db,
-		audit: audit.NewAudit(ormInst),
+		audit: audit.NewAudit(ormInst, idGen),
 	}
 
 	return s, nil
@@ -436,6 +457,11 @@ func (s *set) Route() routetable.Route {
 	return routetable.NewRouteDao(s.orm, s.idGen, s.audit)
 }
 
+// SetIngressLimit update db request limiter related params.
+func (s *set) SetIngressLimit(qps, burst uint) {
+	s.orm.SetIngressLimit(qps, burst)
+}
+
 // Audit return audit dao.
 func (s *set) Audit() audit.Interface {
 	return s.audit
@@ -586,6 +612,46 @@ func (s *set) AccountBillSyncRecord() bill.AccountBillSyncRecord {
 	}
 }
 
+// AccountBillBudget return bill.AccountBillBudget dao
+func (s *set) AccountBillBudget() bill.AccountBillBudget {
+	return &bill.AccountBillBudgetDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountBillReportSub return bill.AccountBillReportSub dao
+func (s *set) AccountBillReportSub() bill.AccountBillReportSub {
+	return &bill.AccountBillReportSubDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountBillAllocationRule return bill.AccountBillAllocationRule dao
+func (s *set) AccountBillAllocationRule() bill.AccountBillAllocationRule {
+	return &bill.AccountBillAllocationRuleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountBillReconciliation return bill.AccountBillReconciliation dao
+func (s *set) AccountBillReconciliation() bill.AccountBillReconciliation {
+	return &bill.AccountBillReconciliationDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// AccountBillArchive return bill.AccountBillArchive dao
+func (s *set) AccountBillArchive() bill.AccountBillArchive {
+	return &bill.AccountBillArchiveDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // UserCollection returns user collection dao.
 func (s *set) UserCollection() daouser.Interface {
 	return &daouser.Dao{
@@ -731,6 +797,78 @@ func (s *set) SGCommonRel() sgcomrel.Interface {
 	}
 }
 
+// RecyclePolicy return recycle policy dao.
+func (s *set) RecyclePolicy() recyclepolicy.Interface {
+	return &recyclepolicy.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// WebhookSubscription return webhook subscription dao.
+func (s *set) WebhookSubscription() daowebhook.Subscription {
+	return &daowebhook.SubscriptionDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// WebhookDelivery return webhook delivery dao.
+func (s *set) WebhookDelivery() daowebhook.Delivery {
+	return &daowebhook.DeliveryDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// ServiceAccount return service account dao.
+func (s *set) ServiceAccount() daoapitoken.ServiceAccount {
+	return &daoapitoken.ServiceAccountDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// APIToken return api token dao.
+func (s *set) APIToken() daoapitoken.Token {
+	return &daoapitoken.TokenDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// RBACRole return local rbac role dao.
+func (s *set) RBACRole() daorbac.Role {
+	return &daorbac.RoleDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// RBACGroup return local rbac group dao.
+func (s *set) RBACGroup() daorbac.Group {
+	return &daorbac.GroupDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// RBACRoleBinding return local rbac role binding dao.
+func (s *set) RBACRoleBinding() daorbac.RoleBinding {
+	return &daorbac.RoleBindingDao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
+// SGLintFinding return security group lint finding dao.
+func (s *set) SGLintFinding() sglint.Interface {
+	return &sglint.Dao{
+		Orm:   s.orm,
+		IDGen: s.idGen,
+	}
+}
+
 // MainAccount return mainaccount dao
 func (s *set) MainAccount() accountset.MainAccount {
 	return &accountset.MainAccountDao{
@@ -70,6 +70,8 @@ type Interface interface {
 	AutoTxn(kt *kit.Kit, run TxnFunc) (interface{}, error)
 	// TableSharding at least one TableSharding option
 	TableSharding(opts ...TableShardingOpt) Interface
+	// SetIngressLimit update db request limiter related params.
+	SetIngressLimit(qps, burst uint)
 }
 
 // InitOrm return orm operations.
@@ -239,6 +241,12 @@ func (o *runtimeOrm) autoTxn(kit *kit.Kit, run TxnFunc) (bool, interface{}, erro
 	return false, result, nil
 }
 
+// SetIngressLimit update db request limiter related params.
+func (o *runtimeOrm) SetIngressLimit(qps, burst uint) {
+	o.ingressLimiter.SetLimit(rate.Limit(qps))
+	o.ingressLimiter.SetBurst(int(burst))
+}
+
 // TableSharding ...
 func (o *runtimeOrm) TableSharding(opts ...TableShardingOpt) Interface {
 	return &tableShardingOrm{
@@ -378,6 +386,11 @@ func (t tableShardingOrm) AutoTxn(kt *kit.Kit, run TxnFunc) (interface{}, error)
 	return t.orm.AutoTxn(kt, run)
 }
 
+// SetIngressLimit ...
+func (t tableShardingOrm) SetIngressLimit(qps, burst uint) {
+	t.orm.SetIngressLimit(qps, burst)
+}
+
 // TableSharding ...
 func (t tableShardingOrm) TableSharding(opts ...TableShardingOpt) Interface {
 
@@ -167,3 +167,27 @@ func (r *AppendSuffixOpt) ReplaceTableName(old string) string {
 	}
 	return old
 }
+
+func TestRedactSQL(t *testing.T) {
+	tests := []struct {
+		origin string
+		want   string
+	}{
+		{
+			origin: "select * from table_1 where id = :id and name in (:names)",
+			want:   "select * from table_1 where id = :id and name in (:names)",
+		},
+		{
+			origin: "update `table_1` set memo = 'it''s \\' secret', size = 10.5 where id = \"00000001\" limit 1",
+			want:   "update `table_1` set memo = ?, size = ? where id = ? limit ?",
+		},
+		{
+			origin: "insert into t1 (col_2, v2) values (-3, 0x1F)",
+			want:   "insert into t1 (col_2, v2) values (-?, ?)",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, redactSQL(tt.origin))
+	}
+}
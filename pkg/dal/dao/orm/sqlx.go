@@ -25,6 +25,8 @@ import (
 	"errors"
 	"time"
 
+	"hcm/pkg/tracing"
+
 	"github.com/jmoiron/sqlx"
 	prm "github.com/prometheus/client_golang/prometheus"
 )
@@ -44,12 +46,16 @@ type do struct {
 }
 
 // Select a collection of data, and decode into dest *[]struct{}.
-func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) (err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "select", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -81,12 +87,16 @@ func (do *do) Select(ctx context.Context, dest interface{}, expr string, arg map
 }
 
 // Count the number of the filtered resource.
-func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}) (uint64, error) {
+func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}) (_ uint64, err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return 0, err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "count", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -121,12 +131,16 @@ func (do *do) Count(ctx context.Context, expr string, arg map[string]interface{}
 }
 
 // Delete a collection of data.
-func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
+func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{}) (_ int64, err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return 0, err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "delete", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -159,7 +173,7 @@ func (do *do) Delete(ctx context.Context, expr string, arg map[string]interface{
 }
 
 // Update a collection of data
-func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
+func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{}) (_ int64, err error) {
 	if arg == nil {
 		return 0, errors.New("update args is required")
 	}
@@ -169,6 +183,10 @@ func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "update", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -201,14 +219,18 @@ func (do *do) Update(ctx context.Context, expr string, arg map[string]interface{
 }
 
 // Insert a row data to db
-func (do *do) Insert(ctx context.Context, expr string, data interface{}) error {
+func (do *do) Insert(ctx context.Context, expr string, data interface{}) (err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "insert", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
-	_, err := do.db.NamedExecContext(ctx, expr, data)
+	_, err = do.db.NamedExecContext(ctx, expr, data)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
 		return err
@@ -221,12 +243,16 @@ func (do *do) Insert(ctx context.Context, expr string, data interface{}) error {
 }
 
 // Exec a command
-func (do *do) Exec(ctx context.Context, expr string) (int64, error) {
+func (do *do) Exec(ctx context.Context, expr string) (_ int64, err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return 0, err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "exec", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	result, err := do.db.ExecContext(ctx, expr)
 	if err != nil {
@@ -248,14 +274,18 @@ func (do *do) Exec(ctx context.Context, expr string) (int64, error) {
 
 // BulkInsert insert multiple data at one time, the order in which ids is returned
 // is the same as the order in which data is inserted.
-func (do *do) BulkInsert(ctx context.Context, expr string, args interface{}) error {
+func (do *do) BulkInsert(ctx context.Context, expr string, args interface{}) (err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "bulk-insert", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
-	_, err := do.db.NamedExecContext(ctx, expr, args)
+	_, err = do.db.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
 		return err
@@ -275,12 +305,16 @@ type doTxn struct {
 }
 
 // Count the number of the filtered resource.
-func (do *doTxn) Count(ctx context.Context, expr string, arg map[string]interface{}) (uint64, error) {
+func (do *doTxn) Count(ctx context.Context, expr string, arg map[string]interface{}) (_ uint64, err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return 0, err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "count", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -315,12 +349,16 @@ func (do *doTxn) Count(ctx context.Context, expr string, arg map[string]interfac
 }
 
 // Select a collection of data, and decode into dest *[]struct{}.
-func (do *doTxn) Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) error {
+func (do *doTxn) Select(ctx context.Context, dest interface{}, expr string, arg map[string]interface{}) (err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "select", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -352,12 +390,16 @@ func (do *doTxn) Select(ctx context.Context, dest interface{}, expr string, arg
 }
 
 // Delete a collection of data with transaction.
-func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
+func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interface{}) (_ int64, err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return 0, err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "delete", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -390,7 +432,7 @@ func (do *doTxn) Delete(ctx context.Context, expr string, arg map[string]interfa
 }
 
 // Insert data with transaction
-func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) error {
+func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) (err error) {
 	if args == nil {
 		return errors.New("insert args is required")
 	}
@@ -400,8 +442,12 @@ func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) erro
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "insert", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
-	_, err := do.tx.NamedExecContext(ctx, expr, args)
+	_, err = do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "insert"}).Inc()
 		return err
@@ -415,14 +461,18 @@ func (do *doTxn) Insert(ctx context.Context, expr string, args interface{}) erro
 
 // BulkInsert insert data batch with transaction, the order in which ids is
 // returned is the same as the order in which data is inserted.
-func (do *doTxn) BulkInsert(ctx context.Context, expr string, args interface{}) error {
+func (do *doTxn) BulkInsert(ctx context.Context, expr string, args interface{}) (err error) {
 	if err := do.ro.tryAccept(); err != nil {
 		return err
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "bulk-insert", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
-	_, err := do.tx.NamedExecContext(ctx, expr, args)
+	_, err = do.tx.NamedExecContext(ctx, expr, args)
 	if err != nil {
 		do.ro.mc.errCounter.With(prm.Labels{"cmd": "bulk-insert"}).Inc()
 		return err
@@ -435,7 +485,7 @@ func (do *doTxn) BulkInsert(ctx context.Context, expr string, args interface{})
 }
 
 // Update with transaction
-func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interface{}) (int64, error) {
+func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interface{}) (_ int64, err error) {
 	if arg == nil {
 		return 0, errors.New("update args is required")
 	}
@@ -445,6 +495,10 @@ func (do *doTxn) Update(ctx context.Context, expr string, arg map[string]interfa
 	}
 
 	start := time.Now()
+	ctx, span := startSpan(ctx, "update", expr)
+	defer func() {
+		tracing.End(span, err)
+	}()
 
 	query, args, err := sqlx.Named(expr, arg)
 	if err != nil {
@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package orm
+
+import (
+	"context"
+	"strings"
+
+	"hcm/pkg/tracing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan 创建数据库操作的 span，语句经 redactSQL 脱敏后记录，命名参数保留，字面量替换为占位符，
+// 避免 Exec 等直接拼接了参数值的语句泄露敏感数据
+func startSpan(ctx context.Context, cmd string, expr string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "mysql "+cmd, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemMySQL, semconv.DBOperation(cmd), semconv.DBStatement(redactSQL(expr))))
+}
+
+// redactSQL 将 sql 中的字符串和数字字面量替换为 ?，标识符（包括反引号包裹的）及命名参数保持不变
+func redactSQL(expr string) string {
+	var b strings.Builder
+	b.Grow(len(expr))
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '\'' || c == '"':
+			i = skipQuoted(expr, i)
+			b.WriteByte('?')
+		case c == '`':
+			end := skipQuoted(expr, i)
+			b.WriteString(expr[i:end])
+			i = end
+		case isDigit(c) && (i == 0 || !isIdentChar(expr[i-1])):
+			for i < len(expr) && (isIdentChar(expr[i]) || expr[i] == '.') {
+				i++
+			}
+			b.WriteByte('?')
+		case isIdentChar(c):
+			start := i
+			for i < len(expr) && isIdentChar(expr[i]) {
+				i++
+			}
+			b.WriteString(expr[start:i])
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+// skipQuoted 返回从 start 处引号开始的字面量结束后的位置，支持反斜杠转义及连续两个引号的转义
+func skipQuoted(expr string, start int) int {
+	quote := expr[start]
+	for i := start + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			i++
+		case quote:
+			if i+1 < len(expr) && expr[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(expr)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return isDigit(c) || c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -137,6 +137,11 @@ func RuleGreaterThanEqual(fieldName string, value any) *filter.AtomRule {
 	return &filter.AtomRule{Field: fieldName, Op: filter.GreaterThanEqual.Factory(), Value: value}
 }
 
+// RuleLessThan 生成资源字段小于给定值的AtomRule，即fieldName < values
+func RuleLessThan(fieldName string, value any) *filter.AtomRule {
+	return &filter.AtomRule{Field: fieldName, Op: filter.LessThan.Factory(), Value: value}
+}
+
 // RuleLessThanEqual 生成资源字段小于等于给定值的AtomRule，即fieldName <= values
 func RuleLessThanEqual(fieldName string, value any) *filter.AtomRule {
 	return &filter.AtomRule{Field: fieldName, Op: filter.LessThanEqual.Factory(), Value: value}
@@ -19,10 +19,22 @@
 
 package types
 
-import "hcm/pkg/dal/table/audit"
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table/audit"
+)
 
 // ListAuditDetails list audit details.
 type ListAuditDetails struct {
 	Count   uint64             `json:"count"`
 	Details []audit.AuditTable `json:"details"`
 }
+
+// AuditChangeCount 按账号、资源类型、操作类型分组统计的审计数量。
+type AuditChangeCount struct {
+	AccountID string                   `db:"account_id" json:"account_id"`
+	Vendor    enumor.Vendor            `db:"vendor" json:"vendor"`
+	ResType   enumor.AuditResourceType `db:"res_type" json:"res_type"`
+	Action    enumor.AuditAction       `db:"action" json:"action"`
+	Count     uint64                   `db:"count" json:"count"`
+}
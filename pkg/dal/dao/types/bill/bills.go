@@ -23,6 +23,7 @@ import (
 	"hcm/pkg/criteria/enumor"
 	"hcm/pkg/criteria/errf"
 	tablebill "hcm/pkg/dal/table/bill"
+	"hcm/pkg/dal/table/types"
 )
 
 // ListAccountBillSummaryMainDetails list account bill config details.
@@ -49,6 +50,19 @@ type ListAccountBillItemDetails struct {
 	Details []tablebill.AccountBillItem `json:"details,omitempty"`
 }
 
+// BillItemResCost 按云资源ID和币种聚合的账单费用
+type BillItemResCost struct {
+	CloudResID string              `db:"cloud_res_id" json:"cloud_res_id"`
+	Currency   enumor.CurrencyCode `db:"currency" json:"currency"`
+	Cost       *types.Decimal      `db:"cost" json:"cost"`
+}
+
+// ListBillItemResCostDetails list bill item resource cost details.
+type ListBillItemResCostDetails struct {
+	Count   uint64            `json:"count,omitempty"`
+	Details []BillItemResCost `json:"details,omitempty"`
+}
+
 // ListAccountBillMonthPullTaskDetails list account bill month pull details
 type ListAccountBillMonthPullTaskDetails struct {
 	Count   uint64                           `json:"count,omitempty"`
@@ -91,6 +105,18 @@ type ListAccountBillSyncRecordDetails struct {
 	Details []tablebill.AccountBillSyncRecord `json:"details,omitempty"`
 }
 
+// ListAccountBillBudgetDetails list account bill budget details
+type ListAccountBillBudgetDetails struct {
+	Count   uint64                        `json:"count,omitempty"`
+	Details []tablebill.AccountBillBudget `json:"details,omitempty"`
+}
+
+// ListAccountBillBudgetAlertDetails list account bill budget alert details
+type ListAccountBillBudgetAlertDetails struct {
+	Count   uint64                             `json:"count,omitempty"`
+	Details []tablebill.AccountBillBudgetAlert `json:"details,omitempty"`
+}
+
 // ItemCommonOpt  bill item table partition parameters
 type ItemCommonOpt struct {
 	Vendor enumor.Vendor `json:"vendor" validate:"required"`
@@ -117,3 +143,39 @@ func (p *ItemCommonOpt) Validate() error {
 	}
 	return nil
 }
+
+// ListAccountBillReportSubDetails list account bill report subscription details
+type ListAccountBillReportSubDetails struct {
+	Count   uint64                           `json:"count,omitempty"`
+	Details []tablebill.AccountBillReportSub `json:"details,omitempty"`
+}
+
+// ListAccountBillAllocationRuleDetails list account bill allocation rule details
+type ListAccountBillAllocationRuleDetails struct {
+	Count   uint64                                `json:"count,omitempty"`
+	Details []tablebill.AccountBillAllocationRule `json:"details,omitempty"`
+}
+
+// ListAccountBillReconciliationDetails list account bill reconciliation details
+type ListAccountBillReconciliationDetails struct {
+	Count   uint64                                `json:"count,omitempty"`
+	Details []tablebill.AccountBillReconciliation `json:"details,omitempty"`
+}
+
+// ListAccountBillArchiveDetails list account bill archive details
+type ListAccountBillArchiveDetails struct {
+	Count   uint64                         `json:"count,omitempty"`
+	Details []tablebill.AccountBillArchive `json:"details,omitempty"`
+}
+
+// BillItemPartition 按云厂商、月份拆分的账单明细分表
+type BillItemPartition struct {
+	Vendor    enumor.Vendor `json:"vendor"`
+	BillYear  int           `json:"bill_year"`
+	BillMonth int           `json:"bill_month"`
+	TableName string        `json:"table_name"`
+	// TableRows 分表的估算行数
+	TableRows uint64 `json:"table_rows"`
+	// DataSize 分表数据及索引占用的空间，单位为字节
+	DataSize uint64 `json:"data_size"`
+}
@@ -0,0 +1,244 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package apitoken 服务账号及API令牌表
+package apitoken
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ServiceAccountColumns defines all the service account table's columns.
+var ServiceAccountColumns = utils.MergeColumns(nil, ServiceAccountColumnDescriptor)
+
+// ServiceAccountColumnDescriptor is service account table's column descriptors.
+var ServiceAccountColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// ServiceAccountTable 服务账号表，服务账号作为API令牌的主体，在IAM中以同名用户进行鉴权
+type ServiceAccountTable struct {
+	// ID 服务账号ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 服务账号名称，即通过令牌访问时的用户名
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the service account's database table name.
+func (s ServiceAccountTable) TableName() table.Name {
+	return table.ServiceAccountTable
+}
+
+// InsertValidate validate service account on insertion.
+func (s ServiceAccountTable) InsertValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(s.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(s.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate service account on update.
+func (s ServiceAccountTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.Name) != 0 {
+		return errors.New("name can not update")
+	}
+
+	if len(s.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(s.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
+
+// TokenColumns defines all the api token table's columns.
+var TokenColumns = utils.MergeColumns(nil, TokenColumnDescriptor)
+
+// TokenColumnDescriptor is api token table's column descriptors.
+var TokenColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "token_hash", NamedC: "token_hash", Type: enumor.String},
+	{Column: "token_prefix", NamedC: "token_prefix", Type: enumor.String},
+	{Column: "principal_type", NamedC: "principal_type", Type: enumor.String},
+	{Column: "principal", NamedC: "principal", Type: enumor.String},
+	{Column: "bk_biz_ids", NamedC: "bk_biz_ids", Type: enumor.Json},
+	{Column: "actions", NamedC: "actions", Type: enumor.Json},
+	{Column: "expire_at", NamedC: "expire_at", Type: enumor.String},
+	{Column: "revoked", NamedC: "revoked", Type: enumor.Boolean},
+	{Column: "last_used_at", NamedC: "last_used_at", Type: enumor.String},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// TokenTable API令牌表，只保存令牌的哈希值，令牌明文仅在创建时返回一次
+type TokenTable struct {
+	// ID 令牌ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 令牌名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// TokenHash 令牌的sha256哈希值
+	TokenHash string `db:"token_hash" json:"token_hash" validate:"omitempty,len=64"`
+	// TokenPrefix 令牌前缀，用于展示和识别令牌
+	TokenPrefix string `db:"token_prefix" json:"token_prefix" validate:"lte=16"`
+	// PrincipalType 令牌主体类型
+	PrincipalType enumor.APITokenPrincipalType `db:"principal_type" json:"principal_type" validate:"lte=32"`
+	// Principal 令牌主体，用户名或服务账号名称
+	Principal string `db:"principal" json:"principal" validate:"lte=64"`
+	// BkBizIDs 令牌可访问的业务，为空表示不限制业务
+	BkBizIDs types.Int64Array `db:"bk_biz_ids" json:"bk_biz_ids"`
+	// Actions 令牌可执行的操作
+	Actions types.StringArray `db:"actions" json:"actions"`
+	// ExpireAt 过期时间
+	ExpireAt string `db:"expire_at" json:"expire_at" validate:"lte=32"`
+	// Revoked 是否已吊销
+	Revoked *bool `db:"revoked" json:"revoked"`
+	// LastUsedAt 最近使用时间
+	LastUsedAt string `db:"last_used_at" json:"last_used_at" validate:"lte=32"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the api token's database table name.
+func (t TokenTable) TableName() table.Name {
+	return table.APITokenTable
+}
+
+// InsertValidate validate api token on insertion.
+func (t TokenTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(t.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(t.TokenHash) == 0 || len(t.TokenPrefix) == 0 {
+		return errors.New("token hash and prefix can not be empty")
+	}
+
+	if err := t.PrincipalType.Validate(); err != nil {
+		return err
+	}
+
+	if len(t.Principal) == 0 {
+		return errors.New("principal can not be empty")
+	}
+
+	if len(t.Actions) == 0 {
+		return errors.New("actions can not be empty")
+	}
+
+	if len(t.ExpireAt) == 0 {
+		return errors.New("expire at can not be empty")
+	}
+
+	if t.Revoked == nil {
+		return errors.New("revoked can not be empty")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate api token on update, token hash and principal can not be updated.
+func (t TokenTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.TokenHash) != 0 || len(t.TokenPrefix) != 0 {
+		return errors.New("token can not update")
+	}
+
+	if len(t.PrincipalType) != 0 || len(t.Principal) != 0 {
+		return errors.New("principal can not update")
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -45,6 +45,7 @@ var AsyncFlowTaskTableColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "state", NamedC: "state", Type: enumor.String},
 	{Column: "reason", NamedC: "reason", Type: enumor.Json},
 	{Column: "result", NamedC: "result", Type: enumor.Json},
+	{Column: "trace_context", NamedC: "trace_context", Type: enumor.String},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
 	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
@@ -53,21 +54,22 @@ var AsyncFlowTaskTableColumnDescriptor = utils.ColumnDescriptors{
 
 // AsyncFlowTaskTable define async_flow_task table.
 type AsyncFlowTaskTable struct {
-	ID         string            `db:"id" json:"id" validate:"lte=64"`
-	FlowID     string            `db:"flow_id" json:"flow_id"`
-	FlowName   enumor.FlowName   `db:"flow_name" json:"flow_name"`
-	ActionID   string            `db:"action_id" json:"action_id"`
-	ActionName enumor.ActionName `db:"action_name" json:"action_name"`
-	Params     types.JsonField   `db:"params" json:"params"`
-	Retry      *Retry            `db:"retry" json:"retry"`
-	DependOn   types.StringArray `db:"depend_on" json:"depend_on"`
-	State      enumor.TaskState  `db:"state" json:"state"`
-	Reason     *Reason           `db:"reason" json:"reason"`
-	Result     types.JsonField   `db:"result" json:"result"`
-	Creator    string            `db:"creator" json:"creator" validate:"lte=64"`
-	Reviser    string            `db:"reviser" json:"reviser" validate:"lte=64"`
-	CreatedAt  types.Time        `db:"created_at" json:"created_at" validate:"excluded_unless"`
-	UpdatedAt  types.Time        `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
+	ID           string            `db:"id" json:"id" validate:"lte=64"`
+	FlowID       string            `db:"flow_id" json:"flow_id"`
+	FlowName     enumor.FlowName   `db:"flow_name" json:"flow_name"`
+	ActionID     string            `db:"action_id" json:"action_id"`
+	ActionName   enumor.ActionName `db:"action_name" json:"action_name"`
+	Params       types.JsonField   `db:"params" json:"params"`
+	Retry        *Retry            `db:"retry" json:"retry"`
+	DependOn     types.StringArray `db:"depend_on" json:"depend_on"`
+	State        enumor.TaskState  `db:"state" json:"state"`
+	Reason       *Reason           `db:"reason" json:"reason"`
+	Result       types.JsonField   `db:"result" json:"result"`
+	TraceContext string            `db:"trace_context" json:"trace_context" validate:"lte=64"`
+	Creator      string            `db:"creator" json:"creator" validate:"lte=64"`
+	Reviser      string            `db:"reviser" json:"reviser" validate:"lte=64"`
+	CreatedAt    types.Time        `db:"created_at" json:"created_at" validate:"excluded_unless"`
+	UpdatedAt    types.Time        `db:"updated_at" json:"updated_at" validate:"excluded_unless"`
 }
 
 // TableName return async_flow_task table name.
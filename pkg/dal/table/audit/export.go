@@ -0,0 +1,99 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// ExportColumns defines all the audit export table's columns.
+var ExportColumns = utils.MergeColumns(nil, ExportColumnDescriptor)
+
+// ExportColumnDescriptor is ExportTable's column descriptors.
+var ExportColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "start_id", NamedC: "start_id", Type: enumor.Numeric},
+	{Column: "end_id", NamedC: "end_id", Type: enumor.Numeric},
+	{Column: "record_count", NamedC: "record_count", Type: enumor.Numeric},
+	{Column: "prev_hash", NamedC: "prev_hash", Type: enumor.String},
+	{Column: "last_hash", NamedC: "last_hash", Type: enumor.String},
+	{Column: "object_path", NamedC: "object_path", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// ExportTable 审计导出记录表，每次导出ID连续的一段审计记录，导出文件中的记录以哈希链相连，
+// 相邻两次导出通过 PrevHash 与上次导出的 LastHash 相连
+type ExportTable struct {
+	// ID 导出记录ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// StartID 导出的第一条审计记录ID
+	StartID uint64 `db:"start_id" json:"start_id"`
+	// EndID 导出的最后一条审计记录ID
+	EndID uint64 `db:"end_id" json:"end_id"`
+	// RecordCount 导出的审计记录数量
+	RecordCount uint64 `db:"record_count" json:"record_count"`
+	// PrevHash 哈希链的起始哈希，即上次导出的最后一条记录的哈希
+	PrevHash string `db:"prev_hash" json:"prev_hash" validate:"len=64"`
+	// LastHash 本次导出的最后一条记录的哈希
+	LastHash string `db:"last_hash" json:"last_hash" validate:"len=64"`
+	// ObjectPath 导出文件在对象存储中的路径
+	ObjectPath string `db:"object_path" json:"object_path" validate:"lte=1024"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator" validate:"lte=64"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at" validate:"isdefault"`
+}
+
+// TableName is the audit export's database table name.
+func (e ExportTable) TableName() table.Name {
+	return table.AuditExportTable
+}
+
+// InsertValidate validate audit export on insertion.
+func (e ExportTable) InsertValidate() error {
+	if err := validator.Validate.Struct(e); err != nil {
+		return err
+	}
+
+	if len(e.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if e.RecordCount == 0 || e.StartID > e.EndID {
+		return errors.New("invalid export record range")
+	}
+
+	if len(e.ObjectPath) == 0 {
+		return errors.New("object path can not be empty")
+	}
+
+	if len(e.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// FieldChangeColumns defines all the audit field change table's columns.
+var FieldChangeColumns = utils.MergeColumns(utils.InsertWithoutPrimaryID, FieldChangeColumnDescriptor)
+
+// FieldChangeColumnDescriptor is FieldChangeTable's column descriptors.
+var FieldChangeColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.Numeric},
+	{Column: "audit_id", NamedC: "audit_id", Type: enumor.Numeric},
+	{Column: "res_id", NamedC: "res_id", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "child_res_type", NamedC: "child_res_type", Type: enumor.String},
+	{Column: "field", NamedC: "field", Type: enumor.String},
+	{Column: "before_value", NamedC: "before_value", Type: enumor.String},
+	{Column: "after_value", NamedC: "after_value", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "operator", NamedC: "operator", Type: enumor.String},
+	{Column: "rid", NamedC: "rid", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// FieldChangeTable 审计字段变更表，将更新类审计拆分为字段级别的变更前后值，便于按字段查询变更历史
+type FieldChangeTable struct {
+	ID         uint64                   `db:"id" json:"id"`
+	AuditID    uint64                   `db:"audit_id" json:"audit_id"`
+	ResID      string                   `db:"res_id" json:"res_id" validate:"lte=64"`
+	CloudResID string                   `db:"cloud_res_id" json:"cloud_res_id" validate:"lte=255"`
+	ResName    string                   `db:"res_name" json:"res_name" validate:"lte=255"`
+	ResType    enumor.AuditResourceType `db:"res_type" json:"res_type" validate:"lte=50"`
+	// ChildResType 子资源类型，变更的是子资源（如安全组规则）时不为空，此时ResID为子资源ID
+	ChildResType enumor.AuditResourceType `db:"child_res_type" json:"child_res_type" validate:"lte=50"`
+	// Field 变更字段，嵌套字段以.连接，如 extension.port
+	Field string `db:"field" json:"field" validate:"lte=255"`
+	// BeforeValue 变更前的值，json格式，字段不存在时为null
+	BeforeValue string `db:"before_value" json:"before_value"`
+	// AfterValue 变更后的值，json格式
+	AfterValue string        `db:"after_value" json:"after_value"`
+	BkBizID    int64         `db:"bk_biz_id" json:"bk_biz_id"`
+	Vendor     enumor.Vendor `db:"vendor" json:"vendor" validate:"lte=16"`
+	AccountID  string        `db:"account_id" json:"account_id" validate:"lte=64"`
+	Operator   string        `db:"operator" json:"operator" validate:"lte=64"`
+	Rid        string        `db:"rid" json:"rid" validate:"lte=64"`
+	CreatedAt  types.Time    `db:"created_at" json:"created_at"`
+}
+
+// CreateValidate audit field change when created
+func (f FieldChangeTable) CreateValidate() error {
+	if err := validator.Validate.Struct(f); err != nil {
+		return err
+	}
+
+	if f.AuditID == 0 {
+		return fmt.Errorf("audit id is required")
+	}
+
+	if len(f.Field) == 0 {
+		return fmt.Errorf("field is required")
+	}
+
+	return nil
+}
+
+// TableName is the audit field change's database table name.
+func (f FieldChangeTable) TableName() table.Name {
+	return table.AuditFieldChangeTable
+}
+
+// BuildFieldChanges 根据更新审计的变更前资源(Detail.Data)和变更内容(Detail.Changed)生成字段级别的变更记录，
+// 变更前后值相同的字段会被忽略，返回的记录未设置AuditID。
+func BuildFieldChanges(one *AuditTable) ([]*FieldChangeTable, error) {
+	if one == nil || one.Action != enumor.Update || one.Detail == nil || one.Detail.Changed == nil {
+		return nil, nil
+	}
+
+	var childResType enumor.AuditResourceType
+	before := one.Detail.Data
+	switch data := one.Detail.Data.(type) {
+	case *ChildResAuditData:
+		childResType, before = data.ChildResType, data.ChildRes
+	case ChildResAuditData:
+		childResType, before = data.ChildResType, data.ChildRes
+	}
+
+	beforeFields, err := flattenJson(before)
+	if err != nil {
+		return nil, fmt.Errorf("flatten audit data failed, err: %v", err)
+	}
+	afterFields, err := flattenJson(one.Detail.Changed)
+	if err != nil {
+		return nil, fmt.Errorf("flatten audit changed failed, err: %v", err)
+	}
+
+	paths := make([]string, 0, len(afterFields))
+	for path := range afterFields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	changes := make([]*FieldChangeTable, 0, len(paths))
+	for _, path := range paths {
+		field := resolveFieldPath(beforeFields, path)
+		beforeValue, exist := beforeFields[field]
+		if !exist {
+			beforeValue = json.RawMessage("null")
+		}
+		afterValue := afterFields[path]
+		if bytes.Equal(beforeValue, afterValue) {
+			continue
+		}
+
+		changes = append(changes, &FieldChangeTable{
+			ResID:        one.ResID,
+			CloudResID:   one.CloudResID,
+			ResName:      one.ResName,
+			ResType:      one.ResType,
+			ChildResType: childResType,
+			Field:        field,
+			BeforeValue:  string(beforeValue),
+			AfterValue:   string(afterValue),
+			BkBizID:      one.BkBizID,
+			Vendor:       one.Vendor,
+			AccountID:    one.AccountID,
+			Operator:     one.Operator,
+			Rid:          one.Rid,
+		})
+	}
+
+	return changes, nil
+}
+
+// resolveFieldPath 变更内容中的字段可能是资源扩展字段中的字段名，如更新请求中的port对应资源的extension.port，
+// 变更字段在资源中不存在且资源中有唯一的同名嵌套字段时使用该嵌套字段的路径
+func resolveFieldPath(beforeFields map[string]json.RawMessage, path string) string {
+	if _, exist := beforeFields[path]; exist {
+		return path
+	}
+
+	matched := ""
+	for field := range beforeFields {
+		if !strings.HasSuffix(field, "."+path) {
+			continue
+		}
+		if matched != "" {
+			return path
+		}
+		matched = field
+	}
+
+	if matched == "" {
+		return path
+	}
+	return matched
+}
+
+// flattenJson 将对象按json格式展开为 字段路径->json值，嵌套对象的字段路径以.连接，数组作为整体不再展开
+func flattenJson(obj interface{}) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if obj == nil {
+		return fields, nil
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = flattenRaw(fields, "", raw); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func flattenRaw(fields map[string]json.RawMessage, prefix string, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '{' {
+		if prefix == "" {
+			return nil
+		}
+		// 数组中的对象字段顺序可能不同，统一重新编码以便比较
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		normalized, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fields[prefix] = normalized
+		return nil
+	}
+
+	object := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return err
+	}
+
+	for key, value := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if err := flattenRaw(fields, path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
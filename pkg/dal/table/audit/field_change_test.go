@@ -0,0 +1,89 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"testing"
+
+	"hcm/pkg/criteria/enumor"
+)
+
+type testRule struct {
+	ID        string         `json:"id"`
+	Protocol  string         `json:"protocol"`
+	Memo      *string        `json:"memo"`
+	Extension *testRuleExtra `json:"extension"`
+}
+
+type testRuleExtra struct {
+	Port     int      `json:"port"`
+	CidrList []string `json:"cidr_list"`
+}
+
+func TestBuildFieldChanges(t *testing.T) {
+	one := &AuditTable{
+		ResID:    "rule-1",
+		ResType:  enumor.SecurityGroupAuditResType,
+		Action:   enumor.Update,
+		Operator: "tester",
+		Detail: &BasicDetail{
+			Data: &ChildResAuditData{
+				ChildResType: enumor.SecurityGroupRuleAuditResType,
+				Action:       enumor.Update,
+				ChildRes: &testRule{ID: "rule-1", Protocol: "tcp",
+					Extension: &testRuleExtra{Port: 80, CidrList: []string{"10.0.0.0/8"}}},
+			},
+			Changed: map[string]interface{}{
+				"protocol":  "tcp",
+				"port":      float64(8080),
+				"memo":      "new memo",
+				"cidr_list": []interface{}{"10.0.0.0/8"},
+			},
+		},
+	}
+
+	changes, err := BuildFieldChanges(one)
+	if err != nil {
+		t.Fatalf("build field changes failed, err: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expect 2 field changes, got %d: %+v", len(changes), changes)
+	}
+
+	expects := []struct{ field, before, after string }{
+		{field: "memo", before: "null", after: `"new memo"`},
+		{field: "extension.port", before: "80", after: "8080"},
+	}
+	for i, expect := range expects {
+		got := changes[i]
+		if got.Field != expect.field || got.BeforeValue != expect.before || got.AfterValue != expect.after {
+			t.Errorf("unexpected field change %d: %+v", i, got)
+		}
+		if got.ChildResType != enumor.SecurityGroupRuleAuditResType || got.ResID != "rule-1" {
+			t.Errorf("unexpected field change resource: %+v", got)
+		}
+	}
+
+	one.Action = enumor.Delete
+	if changes, _ = BuildFieldChanges(one); len(changes) != 0 {
+		t.Errorf("expect no field changes for delete audit, got %d", len(changes))
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SearchColumns defines all the audit search table's columns.
+var SearchColumns = utils.MergeColumns(nil, SearchColumnDescriptor)
+
+// SearchColumnDescriptor is SearchTable's column descriptors.
+var SearchColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "audit_id", NamedC: "audit_id", Type: enumor.Numeric},
+	{Column: "res_name", NamedC: "res_name", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
+	{Column: "detail_text", NamedC: "detail_text", Type: enumor.String},
+}
+
+// SearchTable 审计全文检索表，审计写入时同步写入资源名称、云资源ID及详情文本，避免在审计表上建全文索引
+type SearchTable struct {
+	AuditID    uint64 `db:"audit_id" json:"audit_id"`
+	ResName    string `db:"res_name" json:"res_name"`
+	CloudResID string `db:"cloud_res_id" json:"cloud_res_id"`
+	DetailText string `db:"detail_text" json:"detail_text"`
+}
+
+// TableName is the audit search's database table name.
+func (s SearchTable) TableName() table.Name {
+	return table.AuditSearchTable
+}
+
+// NewSearchTable 根据已插入的审计生成全文检索记录
+func NewSearchTable(auditID uint64, one *AuditTable) (*SearchTable, error) {
+	if auditID == 0 {
+		return nil, fmt.Errorf("audit id is required")
+	}
+
+	search := &SearchTable{AuditID: auditID, ResName: one.ResName, CloudResID: one.CloudResID}
+	if one.Detail != nil {
+		detail, err := json.Marshal(one.Detail)
+		if err != nil {
+			return nil, fmt.Errorf("marshal audit detail failed, err: %v", err)
+		}
+		search.DetailText = string(detail)
+	}
+
+	return search, nil
+}
@@ -42,6 +42,8 @@ var AccountBillExchangeRateColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "from_currency", NamedC: "from_currency", Type: enumor.String},
 	{Column: "to_currency", NamedC: "to_currency", Type: enumor.String},
 	{Column: "exchange_rate", NamedC: "exchange_rate", Type: enumor.Numeric},
+	{Column: "source", NamedC: "source", Type: enumor.String},
+	{Column: "locked", NamedC: "locked", Type: enumor.Boolean},
 
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
@@ -64,6 +66,10 @@ type AccountBillExchangeRate struct {
 	ToCurrency enumor.CurrencyCode `db:"to_currency" json:"to_currency"`
 	// ExchangeRate 汇率
 	ExchangeRate *types.Decimal `db:"exchange_rate" json:"exchange_rate"`
+	// Source 汇率来源
+	Source enumor.ExchangeRateSource `db:"source" json:"source"`
+	// Locked 是否已锁定，月末锁定后不允许修改
+	Locked *bool `db:"locked" json:"locked"`
 
 	// Creator 创建人
 	Creator string `db:"creator" json:"creator"`
@@ -0,0 +1,148 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountBillAllocationRuleColumns defines account_bill_allocation_rule's columns.
+var AccountBillAllocationRuleColumns = utils.MergeColumns(nil, AccountBillAllocationRuleColumnDescriptor)
+
+// AccountBillAllocationRuleColumnDescriptor is account_bill_allocation_rule's column descriptors.
+var AccountBillAllocationRuleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "root_account_id", NamedC: "root_account_id", Type: enumor.String},
+	{Column: "rule_type", NamedC: "rule_type", Type: enumor.String},
+	{Column: "source_type", NamedC: "source_type", Type: enumor.String},
+	{Column: "source_main_account_ids", NamedC: "source_main_account_ids", Type: enumor.Json},
+	{Column: "source_hc_product_codes", NamedC: "source_hc_product_codes", Type: enumor.Json},
+	{Column: "exclude_main_account_ids", NamedC: "exclude_main_account_ids", Type: enumor.Json},
+	{Column: "targets", NamedC: "targets", Type: enumor.Json},
+	{Column: "sp_arn_prefix", NamedC: "sp_arn_prefix", Type: enumor.String},
+	{Column: "credit_ids", NamedC: "credit_ids", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountBillAllocationRule 共享费用分摊规则表
+type AccountBillAllocationRule struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Name 规则名称
+	Name string `db:"name" validate:"lte=64" json:"name"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor"`
+	// RootAccountID 一级账号ID
+	RootAccountID string `db:"root_account_id" validate:"lte=64" json:"root_account_id"`
+	// RuleType 分摊方式
+	RuleType enumor.BillAllocationRuleType `db:"rule_type" json:"rule_type"`
+	// SourceType 共享费用来源
+	SourceType enumor.BillAllocationSourceType `db:"source_type" json:"source_type"`
+	// SourceMainAccountIDs 共享费用所在二级账号，来源为账单明细时有效
+	SourceMainAccountIDs types.StringArray `db:"source_main_account_ids" json:"source_main_account_ids"`
+	// SourceHcProductCodes 共享费用的产品编码，来源为账单明细时有效
+	SourceHcProductCodes types.StringArray `db:"source_hc_product_codes" json:"source_hc_product_codes"`
+	// ExcludeMainAccountIDs 不参与分摊的二级账号，按比例分摊时有效
+	ExcludeMainAccountIDs types.StringArray `db:"exclude_main_account_ids" json:"exclude_main_account_ids"`
+	// Targets 固定比例分摊的目标二级账号及比例
+	Targets types.JsonField `db:"targets" json:"targets"`
+	// SpArnPrefix 匹配savings plans的ARN前缀，来源为savings_plans时有效
+	SpArnPrefix *string `db:"sp_arn_prefix" validate:"omitempty,lte=255" json:"sp_arn_prefix"`
+	// CreditIDs 归还给目标账号的credit ID，来源为credits时有效
+	CreditIDs types.StringArray `db:"credit_ids" json:"credit_ids"`
+	// Enabled 是否启用
+	Enabled *bool `db:"enabled" json:"enabled"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+
+	// Creator 创建人
+	Creator string `db:"creator" json:"creator"`
+	// Reviser 修改人
+	Reviser string `db:"reviser" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName 返回共享费用分摊规则表名
+func (r *AccountBillAllocationRule) TableName() table.Name {
+	return table.AccountBillAllocationRuleTable
+}
+
+// InsertValidate validate bill allocation rule on insert
+func (r *AccountBillAllocationRule) InsertValidate() error {
+	if len(r.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(r.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if err := r.Vendor.Validate(); err != nil {
+		return err
+	}
+	if len(r.RootAccountID) == 0 {
+		return errors.New("root_account_id is required")
+	}
+	if err := r.RuleType.Validate(); err != nil {
+		return err
+	}
+	if err := r.SourceType.Validate(); err != nil {
+		return err
+	}
+	if len(r.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	return validator.Validate.Struct(r)
+}
+
+// UpdateValidate validate bill allocation rule on update
+func (r *AccountBillAllocationRule) UpdateValidate() error {
+	if len(r.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	if len(r.Creator) != 0 {
+		return errors.New("creator is not allowed")
+	}
+	if len(r.Vendor) != 0 || len(r.RootAccountID) != 0 || len(r.SourceType) != 0 {
+		return errors.New("vendor, root_account_id and source_type are not allowed to update")
+	}
+	return validator.Validate.Struct(r)
+}
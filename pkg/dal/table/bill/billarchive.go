@@ -0,0 +1,136 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountBillArchiveColumns defines account_bill_archive's columns.
+var AccountBillArchiveColumns = utils.MergeColumns(nil, AccountBillArchiveColumnDescriptor)
+
+// AccountBillArchiveColumnDescriptor is account_bill_archive's column descriptors.
+var AccountBillArchiveColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "bill_year", NamedC: "bill_year", Type: enumor.Numeric},
+	{Column: "bill_month", NamedC: "bill_month", Type: enumor.Numeric},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "object_path", NamedC: "object_path", Type: enumor.String},
+	{Column: "item_count", NamedC: "item_count", Type: enumor.Numeric},
+	{Column: "file_size", NamedC: "file_size", Type: enumor.Numeric},
+	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
+	{Column: "reason", NamedC: "reason", Type: enumor.String},
+	{Column: "restored_at", NamedC: "restored_at", Type: enumor.String},
+
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountBillArchive 账单明细归档记录表，每个云厂商每月的账单明细分表对应一条记录
+type AccountBillArchive struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor"`
+	// BillYear 账单年份
+	BillYear int `db:"bill_year" json:"bill_year"`
+	// BillMonth 账单月份
+	BillMonth int `db:"bill_month" json:"bill_month"`
+	// State 归档状态
+	State enumor.BillArchiveState `db:"state" json:"state"`
+	// ObjectPath 归档文件在对象存储中的路径
+	ObjectPath string `db:"object_path" validate:"lte=255" json:"object_path"`
+	// ItemCount 归档的账单明细数量
+	ItemCount *uint64 `db:"item_count" json:"item_count"`
+	// FileSize 归档文件大小，单位为字节
+	FileSize *uint64 `db:"file_size" json:"file_size"`
+	// FlowID 最近一次归档或恢复的异步任务ID
+	FlowID string `db:"flow_id" validate:"lte=64" json:"flow_id"`
+	// Reason 最近一次归档或恢复失败的原因
+	Reason *string `db:"reason" validate:"omitempty,lte=1024" json:"reason"`
+	// RestoredAt 恢复完成时间，保留期满后重新删除分表
+	RestoredAt string `db:"restored_at" validate:"omitempty,lte=32" json:"restored_at"`
+
+	// Creator 创建人
+	Creator string `db:"creator" json:"creator"`
+	// Reviser 修改人
+	Reviser string `db:"reviser" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName 返回账单明细归档记录表名
+func (r *AccountBillArchive) TableName() table.Name {
+	return table.AccountBillArchiveTable
+}
+
+// InsertValidate validate bill archive on insert
+func (r *AccountBillArchive) InsertValidate() error {
+	if len(r.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if err := r.Vendor.Validate(); err != nil {
+		return err
+	}
+	if r.BillYear == 0 {
+		return errors.New("bill_year is required")
+	}
+	if r.BillMonth == 0 {
+		return errors.New("bill_month is required")
+	}
+	if len(r.State) == 0 {
+		return errors.New("state is required")
+	}
+	if len(r.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	return validator.Validate.Struct(r)
+}
+
+// UpdateValidate validate bill archive on update
+func (r *AccountBillArchive) UpdateValidate() error {
+	if len(r.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	if len(r.Creator) != 0 {
+		return errors.New("creator is not allowed")
+	}
+	if len(r.Vendor) != 0 || r.BillYear != 0 || r.BillMonth != 0 {
+		return errors.New("vendor, bill_year and bill_month are not allowed to update")
+	}
+	return validator.Validate.Struct(r)
+}
@@ -0,0 +1,218 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+	cvt "hcm/pkg/tools/converter"
+)
+
+// AccountBillBudgetColumns defines account_bill_budget's columns.
+var AccountBillBudgetColumns = utils.MergeColumns(nil, AccountBillBudgetColumnDescriptor)
+
+// AccountBillBudgetColumnDescriptor is account_bill_budget's column descriptors.
+var AccountBillBudgetColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "scope", NamedC: "scope", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "main_account_id", NamedC: "main_account_id", Type: enumor.String},
+	{Column: "product_id", NamedC: "product_id", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "amount", NamedC: "amount", Type: enumor.Numeric},
+	{Column: "thresholds", NamedC: "thresholds", Type: enumor.Json},
+	{Column: "forecast_alert", NamedC: "forecast_alert", Type: enumor.Boolean},
+	{Column: "anomaly_ratio", NamedC: "anomaly_ratio", Type: enumor.Numeric},
+	{Column: "receivers", NamedC: "receivers", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountBillBudget 账单预算表
+type AccountBillBudget struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Name 预算名称
+	Name string `db:"name" validate:"lte=64" json:"name"`
+	// Scope 预算范围，业务、二级账号或运营产品
+	Scope enumor.BillBudgetScope `db:"scope" json:"scope"`
+	// BkBizID 业务ID，预算范围为业务时有效
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// MainAccountID 二级账号ID，预算范围为二级账号时有效
+	MainAccountID string `db:"main_account_id" validate:"lte=64" json:"main_account_id"`
+	// ProductID 运营产品ID，预算范围为运营产品时有效
+	ProductID int64 `db:"product_id" json:"product_id"`
+	// Currency 预算币种，只统计相同币种的费用
+	Currency enumor.CurrencyCode `db:"currency" json:"currency"`
+	// Amount 月度预算金额
+	Amount *types.Decimal `db:"amount" json:"amount"`
+	// Thresholds 告警阈值，预算金额的百分比
+	Thresholds types.Int64Array `db:"thresholds" json:"thresholds"`
+	// ForecastAlert 预测月末费用超出预算时是否告警
+	ForecastAlert *bool `db:"forecast_alert" json:"forecast_alert"`
+	// AnomalyRatio 日环比增长百分比超过该值时告警，为0时不检测
+	AnomalyRatio *int64 `db:"anomaly_ratio" json:"anomaly_ratio"`
+	// Receivers 告警接收人
+	Receivers types.StringArray `db:"receivers" json:"receivers"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+
+	// Creator 创建人
+	Creator string `db:"creator" json:"creator"`
+	// Reviser 修改人
+	Reviser string `db:"reviser" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName 返回账单预算表名
+func (b *AccountBillBudget) TableName() table.Name {
+	return table.AccountBillBudgetTable
+}
+
+// InsertValidate validate bill budget on insert
+func (b *AccountBillBudget) InsertValidate() error {
+	if len(b.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(b.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if err := b.Scope.Validate(); err != nil {
+		return err
+	}
+	if len(b.Currency) == 0 {
+		return errors.New("currency is required")
+	}
+	if cvt.PtrToVal(b.Amount).IsZero() {
+		return errors.New("amount is required")
+	}
+	if len(b.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	return validator.Validate.Struct(b)
+}
+
+// UpdateValidate validate bill budget on update
+func (b *AccountBillBudget) UpdateValidate() error {
+	if len(b.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	if len(b.Creator) != 0 {
+		return errors.New("creator is not allowed")
+	}
+	if len(b.Scope) != 0 {
+		return errors.New("scope is not allowed to update")
+	}
+	return validator.Validate.Struct(b)
+}
+
+// AccountBillBudgetAlertColumns defines account_bill_budget_alert's columns.
+var AccountBillBudgetAlertColumns = utils.MergeColumns(nil, AccountBillBudgetAlertColumnDescriptor)
+
+// AccountBillBudgetAlertColumnDescriptor is account_bill_budget_alert's column descriptors.
+var AccountBillBudgetAlertColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "budget_id", NamedC: "budget_id", Type: enumor.String},
+	{Column: "bill_year", NamedC: "bill_year", Type: enumor.Numeric},
+	{Column: "bill_month", NamedC: "bill_month", Type: enumor.Numeric},
+	{Column: "bill_day", NamedC: "bill_day", Type: enumor.Numeric},
+	{Column: "alert_type", NamedC: "alert_type", Type: enumor.String},
+	{Column: "threshold", NamedC: "threshold", Type: enumor.Numeric},
+	{Column: "currency", NamedC: "currency", Type: enumor.String},
+	{Column: "budget_amount", NamedC: "budget_amount", Type: enumor.Numeric},
+	{Column: "cost", NamedC: "cost", Type: enumor.Numeric},
+	{Column: "forecast_cost", NamedC: "forecast_cost", Type: enumor.Numeric},
+	{Column: "message", NamedC: "message", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// AccountBillBudgetAlert 预算告警记录表，同一预算同一周期同类告警只记录一次
+type AccountBillBudgetAlert struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// BudgetID 预算ID
+	BudgetID string `db:"budget_id" validate:"lte=64" json:"budget_id"`
+	// BillYear 账单年份
+	BillYear int `db:"bill_year" json:"bill_year"`
+	// BillMonth 账单月份
+	BillMonth int `db:"bill_month" json:"bill_month"`
+	// BillDay 账单日期，阈值与预测告警按月记录，该字段为0
+	BillDay int `db:"bill_day" json:"bill_day"`
+	// AlertType 告警类型
+	AlertType enumor.BillBudgetAlertType `db:"alert_type" json:"alert_type"`
+	// Threshold 触发的告警阈值
+	Threshold int64 `db:"threshold" json:"threshold"`
+	// Currency 币种
+	Currency enumor.CurrencyCode `db:"currency" json:"currency"`
+	// BudgetAmount 告警时的预算金额
+	BudgetAmount *types.Decimal `db:"budget_amount" json:"budget_amount"`
+	// Cost 告警时的当月累计费用，日环比告警时为当日费用
+	Cost *types.Decimal `db:"cost" json:"cost"`
+	// ForecastCost 告警时预测的月末费用
+	ForecastCost *types.Decimal `db:"forecast_cost" json:"forecast_cost"`
+	// Message 告警信息
+	Message string `db:"message" validate:"lte=1024" json:"message"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName 返回预算告警记录表名
+func (a *AccountBillBudgetAlert) TableName() table.Name {
+	return table.AccountBillBudgetAlertTable
+}
+
+// InsertValidate validate bill budget alert on insert
+func (a *AccountBillBudgetAlert) InsertValidate() error {
+	if len(a.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(a.BudgetID) == 0 {
+		return errors.New("budget_id is required")
+	}
+	if a.BillYear == 0 {
+		return errors.New("bill_year is required")
+	}
+	if a.BillMonth == 0 {
+		return errors.New("bill_month is required")
+	}
+	if len(a.AlertType) == 0 {
+		return errors.New("alert_type is required")
+	}
+	return validator.Validate.Struct(a)
+}
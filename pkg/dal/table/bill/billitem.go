@@ -51,6 +51,7 @@ var AccountBillItemColumnDescriptor = utils.ColumnDescriptors{
 	{Column: "hc_product_name", NamedC: "hc_product_name", Type: enumor.String},
 	{Column: "res_amount", NamedC: "res_amount", Type: enumor.Numeric},
 	{Column: "res_amount_unit", NamedC: "res_amount_unit", Type: enumor.String},
+	{Column: "cloud_res_id", NamedC: "cloud_res_id", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
@@ -92,6 +93,8 @@ type AccountBillItem struct {
 	ResAmount *types.Decimal `db:"res_amount" json:"res_amount,omitempty"`
 	// ResAmountUnit 用量单位
 	ResAmountUnit string `db:"res_amount_unit" json:"res_amount_unit,omitempty"`
+	// CloudResID 账单对应的云资源ID，用于关联已同步资源的cloud_id，部分明细没有
+	CloudResID string `db:"cloud_res_id" validate:"max=255" json:"cloud_res_id,omitempty"`
 	// Extension 云原始字段
 	Extension types.JsonField `db:"extension" json:"extension"`
 	// Creator 创建者
@@ -0,0 +1,102 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountBillReconciliationColumns defines account_bill_reconciliation's columns.
+var AccountBillReconciliationColumns = utils.MergeColumns(nil, AccountBillReconciliationColumnDescriptor)
+
+// AccountBillReconciliationColumnDescriptor is account_bill_reconciliation's column descriptors.
+var AccountBillReconciliationColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "root_account_id", NamedC: "root_account_id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "bill_year", NamedC: "bill_year", Type: enumor.Numeric},
+	{Column: "bill_month", NamedC: "bill_month", Type: enumor.Numeric},
+	{Column: "summary_version", NamedC: "summary_version", Type: enumor.Numeric},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "details", NamedC: "details", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+}
+
+// AccountBillReconciliation 账单对账记录表，每次对账生成一条记录
+type AccountBillReconciliation struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// RootAccountID 一级账号ID
+	RootAccountID string `db:"root_account_id" validate:"lte=64" json:"root_account_id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" json:"vendor"`
+	// BillYear 账单年份
+	BillYear int `db:"bill_year" json:"bill_year"`
+	// BillMonth 账单月份
+	BillMonth int `db:"bill_month" json:"bill_month"`
+	// SummaryVersion 对账时一级账号账单汇总的版本，重新核算后需要重新对账
+	SummaryVersion int `db:"summary_version" json:"summary_version"`
+	// State 对账结果
+	State enumor.BillReconciliationState `db:"state" json:"state"`
+	// Details 各币种对账明细
+	Details types.JsonField `db:"details" json:"details"`
+	// Creator 创建者
+	Creator string `db:"creator" json:"creator"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+}
+
+// TableName 返回账单对账记录表名
+func (r *AccountBillReconciliation) TableName() table.Name {
+	return table.AccountBillReconciliationTable
+}
+
+// InsertValidate validate bill reconciliation on insert
+func (r *AccountBillReconciliation) InsertValidate() error {
+	if len(r.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(r.RootAccountID) == 0 {
+		return errors.New("root_account_id is required")
+	}
+	if err := r.Vendor.Validate(); err != nil {
+		return err
+	}
+	if r.BillYear == 0 {
+		return errors.New("bill_year is required")
+	}
+	if r.BillMonth == 0 {
+		return errors.New("bill_month is required")
+	}
+	if len(r.State) == 0 {
+		return errors.New("state is required")
+	}
+	if len(r.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	return validator.Validate.Struct(r)
+}
@@ -0,0 +1,145 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package bill
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// AccountBillReportSubColumns defines account_bill_report_subscription's columns.
+var AccountBillReportSubColumns = utils.MergeColumns(nil, AccountBillReportSubColumnDescriptor)
+
+// AccountBillReportSubColumnDescriptor is account_bill_report_subscription's column descriptors.
+var AccountBillReportSubColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "report_type", NamedC: "report_type", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "filter", NamedC: "filter", Type: enumor.Json},
+	{Column: "format", NamedC: "format", Type: enumor.String},
+	{Column: "cadence", NamedC: "cadence", Type: enumor.String},
+	{Column: "send_day", NamedC: "send_day", Type: enumor.Numeric},
+	{Column: "receivers", NamedC: "receivers", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "last_bill_year", NamedC: "last_bill_year", Type: enumor.Numeric},
+	{Column: "last_bill_month", NamedC: "last_bill_month", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// AccountBillReportSub 账单报表订阅表
+type AccountBillReportSub struct {
+	// ID 自增ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Name 订阅名称
+	Name string `db:"name" validate:"lte=64" json:"name"`
+	// ReportType 报表类型
+	ReportType enumor.BillReportType `db:"report_type" json:"report_type"`
+	// Vendor 云厂商，报表类型为账单明细时必填
+	Vendor enumor.Vendor `db:"vendor" json:"vendor"`
+	// Filter 报表过滤条件，与导出接口的filter一致
+	Filter types.JsonField `db:"filter" json:"filter"`
+	// Format 文件格式
+	Format enumor.BillReportFormat `db:"format" json:"format"`
+	// Cadence 发送周期
+	Cadence enumor.BillReportCadence `db:"cadence" json:"cadence"`
+	// SendDay 每月发送日期，发送周期为每月时有效
+	SendDay int `db:"send_day" json:"send_day"`
+	// Receivers 接收人
+	Receivers types.StringArray `db:"receivers" json:"receivers"`
+	// Enabled 是否启用
+	Enabled *bool `db:"enabled" json:"enabled"`
+	// LastBillYear 最近一次发送的账单年份
+	LastBillYear int `db:"last_bill_year" json:"last_bill_year"`
+	// LastBillMonth 最近一次发送的账单月份
+	LastBillMonth int `db:"last_bill_month" json:"last_bill_month"`
+	// Memo 备注
+	Memo *string `db:"memo" validate:"omitempty,lte=255" json:"memo"`
+
+	// Creator 创建人
+	Creator string `db:"creator" json:"creator"`
+	// Reviser 修改人
+	Reviser string `db:"reviser" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" json:"updated_at"`
+}
+
+// TableName 返回账单报表订阅表名
+func (s *AccountBillReportSub) TableName() table.Name {
+	return table.AccountBillReportSubTable
+}
+
+// InsertValidate validate bill report subscription on insert
+func (s *AccountBillReportSub) InsertValidate() error {
+	if len(s.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(s.Name) == 0 {
+		return errors.New("name is required")
+	}
+	if err := s.ReportType.Validate(); err != nil {
+		return err
+	}
+	if err := s.Format.Validate(); err != nil {
+		return err
+	}
+	if err := s.Cadence.Validate(); err != nil {
+		return err
+	}
+	if len(s.Receivers) == 0 {
+		return errors.New("receivers is required")
+	}
+	if len(s.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+	if len(s.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	return validator.Validate.Struct(s)
+}
+
+// UpdateValidate validate bill report subscription on update
+func (s *AccountBillReportSub) UpdateValidate() error {
+	if len(s.ID) == 0 {
+		return errors.New("id is required")
+	}
+	if len(s.Reviser) == 0 {
+		return errors.New("reviser is required")
+	}
+	if len(s.Creator) != 0 {
+		return errors.New("creator is not allowed")
+	}
+	if len(s.ReportType) != 0 {
+		return errors.New("report_type is not allowed to update")
+	}
+	return validator.Validate.Struct(s)
+}
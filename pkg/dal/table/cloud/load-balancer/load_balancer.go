@@ -62,6 +62,7 @@ var LoadBalancerColumnsDescriptor = utils.ColumnDescriptors{
 	{Column: "cloud_status_time", NamedC: "cloud_status_time", Type: enumor.String},
 	{Column: "cloud_expired_time", NamedC: "cloud_expired_time", Type: enumor.String},
 	{Column: "extension", NamedC: "extension", Type: enumor.Json},
+	{Column: "recycle_status", NamedC: "recycle_status", Type: enumor.String},
 
 	{Column: "creator", NamedC: "creator", Type: enumor.String},
 	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
@@ -97,6 +98,7 @@ type LoadBalancerTable struct {
 	CloudStatusTime      string            `db:"cloud_status_time" json:"cloud_status_time"`
 	CloudExpiredTime     string            `db:"cloud_expired_time" json:"cloud_expired_time"`
 	Extension            types.JsonField   `db:"extension" json:"extension"`
+	RecycleStatus        string            `db:"recycle_status" validate:"lte=32" json:"recycle_status"`
 
 	Creator   string     `db:"creator" validate:"lte=64" json:"creator"`
 	Reviser   string     `db:"reviser" validate:"lte=64" json:"reviser"`
@@ -0,0 +1,147 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cloud
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SecurityGroupLintFindingColumns defines all the security group lint finding table's columns.
+var SecurityGroupLintFindingColumns = utils.MergeColumns(nil, SecurityGroupLintFindingColumnDescriptor)
+
+// SecurityGroupLintFindingColumnDescriptor is security group lint finding table column descriptors.
+var SecurityGroupLintFindingColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "vendor", NamedC: "vendor", Type: enumor.String},
+	{Column: "account_id", NamedC: "account_id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "region", NamedC: "region", Type: enumor.String},
+	{Column: "security_group_id", NamedC: "security_group_id", Type: enumor.String},
+	{Column: "cloud_security_group_id", NamedC: "cloud_security_group_id", Type: enumor.String},
+	{Column: "rule_id", NamedC: "rule_id", Type: enumor.String},
+	{Column: "rule_type", NamedC: "rule_type", Type: enumor.String},
+	{Column: "lint_type", NamedC: "lint_type", Type: enumor.String},
+	{Column: "risk_level", NamedC: "risk_level", Type: enumor.String},
+	{Column: "risk_score", NamedC: "risk_score", Type: enumor.Numeric},
+	{Column: "detail", NamedC: "detail", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SecurityGroupLintFindingTable define security group lint finding table.
+type SecurityGroupLintFindingTable struct {
+	// ID 检查结果ID
+	ID string `db:"id" validate:"lte=64" json:"id"`
+	// Vendor 云厂商
+	Vendor enumor.Vendor `db:"vendor" validate:"lte=16" json:"vendor"`
+	// AccountID 账号ID
+	AccountID string `db:"account_id" validate:"lte=64" json:"account_id"`
+	// BkBizID 安全组所属业务ID
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id"`
+	// Region 地域
+	Region string `db:"region" validate:"lte=64" json:"region"`
+	// SecurityGroupID 安全组ID
+	SecurityGroupID string `db:"security_group_id" validate:"lte=64" json:"security_group_id"`
+	// CloudSecurityGroupID 云上安全组ID
+	CloudSecurityGroupID string `db:"cloud_security_group_id" validate:"lte=255" json:"cloud_security_group_id"`
+	// RuleID 命中的安全组规则ID，安全组级别的检查项为空
+	RuleID string `db:"rule_id" validate:"lte=64" json:"rule_id"`
+	// RuleType 规则类型(ingress/egress)
+	RuleType string `db:"rule_type" validate:"lte=16" json:"rule_type"`
+	// LintType 检查项类型
+	LintType enumor.SGLintType `db:"lint_type" validate:"lte=32" json:"lint_type"`
+	// RiskLevel 风险等级
+	RiskLevel enumor.RiskLevel `db:"risk_level" validate:"lte=16" json:"risk_level"`
+	// RiskScore 风险分
+	RiskScore uint64 `db:"risk_score" json:"risk_score"`
+	// Detail 检查详情
+	Detail types.JsonField `db:"detail" json:"detail"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"lte=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"lte=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"excluded_unless" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"excluded_unless" json:"updated_at"`
+}
+
+// TableName return security group lint finding table name.
+func (t SecurityGroupLintFindingTable) TableName() table.Name {
+	return table.SecurityGroupLintFindingTable
+}
+
+// InsertValidate security group lint finding table when insert.
+func (t SecurityGroupLintFindingTable) InsertValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Vendor) == 0 {
+		return errors.New("vendor is required")
+	}
+
+	if len(t.AccountID) == 0 {
+		return errors.New("account_id is required")
+	}
+
+	if len(t.SecurityGroupID) == 0 {
+		return errors.New("security_group_id is required")
+	}
+
+	if len(t.LintType) == 0 {
+		return errors.New("lint_type is required")
+	}
+
+	if len(t.RiskLevel) == 0 {
+		return errors.New("risk_level is required")
+	}
+
+	if len(t.Creator) == 0 {
+		return errors.New("creator is required")
+	}
+
+	return nil
+}
+
+// UpdateValidate security group lint finding table when update.
+func (t SecurityGroupLintFindingTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(t); err != nil {
+		return err
+	}
+
+	if len(t.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(t.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地RBAC鉴权的角色、用户组及角色绑定表
+package rbac
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RoleColumns defines all the rbac role table's columns.
+var RoleColumns = utils.MergeColumns(nil, RoleColumnDescriptor)
+
+// RoleColumnDescriptor is rbac role table's column descriptors.
+var RoleColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "permissions", NamedC: "permissions", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RoleTable 本地RBAC角色表，角色由一组资源类型及其操作组成
+type RoleTable struct {
+	// ID 角色ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 角色名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// Permissions 角色拥有的权限，资源类型及操作列表
+	Permissions types.JsonField `db:"permissions" json:"permissions"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the rbac role's database table name.
+func (r RoleTable) TableName() table.Name {
+	return table.RBACRoleTable
+}
+
+// InsertValidate validate rbac role on insertion.
+func (r RoleTable) InsertValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(r.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if r.Permissions.IsEmpty() {
+		return errors.New("permissions can not be empty")
+	}
+
+	if len(r.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate rbac role on update.
+func (r RoleTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
+
+// GroupColumns defines all the rbac group table's columns.
+var GroupColumns = utils.MergeColumns(nil, GroupColumnDescriptor)
+
+// GroupColumnDescriptor is rbac group table's column descriptors.
+var GroupColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "members", NamedC: "members", Type: enumor.Json},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// GroupTable 本地RBAC用户组表
+type GroupTable struct {
+	// ID 用户组ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 用户组名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// Members 用户组成员
+	Members types.StringArray `db:"members" json:"members"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the rbac group's database table name.
+func (g GroupTable) TableName() table.Name {
+	return table.RBACGroupTable
+}
+
+// InsertValidate validate rbac group on insertion.
+func (g GroupTable) InsertValidate() error {
+	if err := validator.Validate.Struct(g); err != nil {
+		return err
+	}
+
+	if len(g.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(g.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if g.Members == nil {
+		return errors.New("members can not be nil")
+	}
+
+	if len(g.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate rbac group on update.
+func (g GroupTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(g); err != nil {
+		return err
+	}
+
+	if len(g.Name) != 0 {
+		return errors.New("name can not update")
+	}
+
+	if len(g.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(g.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
+
+// RoleBindingColumns defines all the rbac role binding table's columns.
+var RoleBindingColumns = utils.MergeColumns(nil, RoleBindingColumnDescriptor)
+
+// RoleBindingColumnDescriptor is rbac role binding table's column descriptors.
+var RoleBindingColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "role_id", NamedC: "role_id", Type: enumor.String},
+	{Column: "subject_type", NamedC: "subject_type", Type: enumor.String},
+	{Column: "subject", NamedC: "subject", Type: enumor.String},
+	{Column: "bk_biz_ids", NamedC: "bk_biz_ids", Type: enumor.Json},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RoleBindingTable 本地RBAC角色绑定表，将角色授予用户或用户组，并限定生效的业务范围
+type RoleBindingTable struct {
+	// ID 角色绑定ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// RoleID 角色ID
+	RoleID string `db:"role_id" json:"role_id" validate:"lte=64"`
+	// SubjectType 授权对象类型
+	SubjectType enumor.RBACSubjectType `db:"subject_type" json:"subject_type" validate:"lte=32"`
+	// Subject 授权对象，用户名或用户组名称
+	Subject string `db:"subject" json:"subject" validate:"lte=64"`
+	// BkBizIDs 角色生效的业务，为空表示对所有业务及平台资源生效
+	BkBizIDs types.Int64Array `db:"bk_biz_ids" json:"bk_biz_ids"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the rbac role binding's database table name.
+func (b RoleBindingTable) TableName() table.Name {
+	return table.RBACRoleBindingTable
+}
+
+// InsertValidate validate rbac role binding on insertion.
+func (b RoleBindingTable) InsertValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(b.RoleID) == 0 {
+		return errors.New("role_id can not be empty")
+	}
+
+	if err := b.SubjectType.Validate(); err != nil {
+		return err
+	}
+
+	if len(b.Subject) == 0 {
+		return errors.New("subject can not be empty")
+	}
+
+	if b.BkBizIDs == nil {
+		return errors.New("bk_biz_ids can not be nil")
+	}
+
+	if len(b.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate rbac role binding on update, only business scope can be updated.
+func (b RoleBindingTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(b); err != nil {
+		return err
+	}
+
+	if len(b.RoleID) != 0 || len(b.SubjectType) != 0 || len(b.Subject) != 0 {
+		return errors.New("role_id, subject_type and subject can not update")
+	}
+
+	if len(b.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(b.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package recyclerecord
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// RecyclePolicyColumns defines all the recycle policy table's columns.
+var RecyclePolicyColumns = utils.MergeColumns(nil, RecyclePolicyColumnDescriptor)
+
+// RecyclePolicyColumnDescriptor is RecyclePolicyTable's column descriptors.
+var RecyclePolicyColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "bk_biz_id", NamedC: "bk_biz_id", Type: enumor.Numeric},
+	{Column: "res_type", NamedC: "res_type", Type: enumor.String},
+	{Column: "reserve_time", NamedC: "reserve_time", Type: enumor.Numeric},
+	{Column: "notify_before", NamedC: "notify_before", Type: enumor.Numeric},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// RecyclePolicyTable is used to save recycle reserve policy of business and resource type.
+type RecyclePolicyTable struct {
+	// ID 策略ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// BkBizID 业务ID，-1 表示未分配业务的资源
+	BkBizID int64 `db:"bk_biz_id" json:"bk_biz_id" validate:"min=-1"`
+	// ResType 资源类型
+	ResType enumor.CloudResourceType `db:"res_type" json:"res_type" validate:"lte=64"`
+	// ReserveTime 回收站保留时长，单位: 小时
+	ReserveTime int `db:"reserve_time" json:"reserve_time" validate:"min=0"`
+	// NotifyBefore 到期前提前通知时长，单位: 小时，为0时使用全局配置
+	NotifyBefore int `db:"notify_before" json:"notify_before" validate:"min=0"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the recycle policy's database table name.
+func (r RecyclePolicyTable) TableName() table.Name {
+	return table.RecyclePolicyTable
+}
+
+// InsertValidate validate recycle policy on insertion.
+func (r RecyclePolicyTable) InsertValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if len(r.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if r.BkBizID == 0 {
+		return errors.New("biz id can not be empty")
+	}
+
+	if len(r.ResType) == 0 {
+		return errors.New("resource type can not be empty")
+	}
+
+	if r.ReserveTime == 0 {
+		return errors.New("reserve time can not be empty")
+	}
+
+	if len(r.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate recycle policy on update.
+func (r RecyclePolicyTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(r); err != nil {
+		return err
+	}
+
+	if r.BkBizID != 0 {
+		return errors.New("biz id can not update")
+	}
+
+	if len(r.ResType) != 0 {
+		return errors.New("resource type can not update")
+	}
+
+	if len(r.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(r.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
@@ -38,8 +38,30 @@ const (
 	IDGenerator Name = "id_generator"
 	// AuditTable is audit table's name
 	AuditTable Name = "audit"
+	// AuditFieldChangeTable is audit field change table's name
+	AuditFieldChangeTable Name = "audit_field_change"
+	// AuditExportTable is audit export table's name
+	AuditExportTable Name = "audit_export"
+	// AuditSearchTable is audit full-text search table's name
+	AuditSearchTable Name = "audit_search"
 	// RecycleRecordTable is recycle record table name
 	RecycleRecordTable Name = "recycle_record"
+	// RecyclePolicyTable is recycle policy table name
+	RecyclePolicyTable Name = "recycle_policy"
+	// WebhookSubscriptionTable is webhook subscription table name
+	WebhookSubscriptionTable Name = "webhook_subscription"
+	// WebhookDeliveryTable is webhook delivery table name
+	WebhookDeliveryTable Name = "webhook_delivery"
+	// ServiceAccountTable is service account table name
+	ServiceAccountTable Name = "service_account"
+	// APITokenTable is api token table name
+	APITokenTable Name = "api_token"
+	// RBACRoleTable is local rbac role table name
+	RBACRoleTable Name = "rbac_role"
+	// RBACGroupTable is local rbac group table name
+	RBACGroupTable Name = "rbac_group"
+	// RBACRoleBindingTable is local rbac role binding table name
+	RBACRoleBindingTable Name = "rbac_role_binding"
 	// AccountTable is account table's name.
 	AccountTable Name = "account"
 	// SubAccountTable is sub account table's name.
@@ -156,6 +178,8 @@ const (
 	LoadBalancerTable Name = "load_balancer"
 	// SecurityGroupCommonRelTable is security group common rel table's name.
 	SecurityGroupCommonRelTable Name = "security_group_common_rel"
+	// SecurityGroupLintFindingTable is security_group_lint_finding table's name.
+	SecurityGroupLintFindingTable Name = "security_group_lint_finding"
 	// LoadBalancerListenerTable is load_balancer_listener table's name.
 	LoadBalancerListenerTable Name = "load_balancer_listener"
 	// TCloudLbUrlRuleTable is tcloud_lb_url_rule table's name.
@@ -198,6 +222,18 @@ const (
 	AccountBillExchangeRateTable = "account_bill_exchange_rate"
 	// AccountBillSyncRecordTable 账单同步记录
 	AccountBillSyncRecordTable = "account_bill_sync_record"
+	// AccountBillBudgetTable 账单预算
+	AccountBillBudgetTable = "account_bill_budget"
+	// AccountBillBudgetAlertTable 账单预算告警记录
+	AccountBillBudgetAlertTable = "account_bill_budget_alert"
+	// AccountBillReportSubTable 账单报表订阅
+	AccountBillReportSubTable = "account_bill_report_subscription"
+	// AccountBillAllocationRuleTable 共享费用分摊规则
+	AccountBillAllocationRuleTable = "account_bill_allocation_rule"
+	// AccountBillReconciliationTable 账单对账记录
+	AccountBillReconciliationTable = "account_bill_reconciliation"
+	// AccountBillArchiveTable 账单明细归档记录
+	AccountBillArchiveTable = "account_bill_archive"
 )
 
 // Validate whether the table name is valid or not.
@@ -217,6 +253,9 @@ func (n Name) Validate() error {
 // TableMap table map config
 var TableMap = map[Name]struct{}{
 	AuditTable:                   {},
+	AuditFieldChangeTable:        {},
+	AuditExportTable:             {},
+	AuditSearchTable:             {},
 	AccountTable:                 {},
 	SubAccountTable:              {},
 	AccountBizRelTable:           {},
@@ -253,6 +292,14 @@ var TableMap = map[Name]struct{}{
 	NetworkInterfaceTable:        {},
 	NetworkInterfaceCvmRelTable:  {},
 	RecycleRecordTable:           {},
+	RecyclePolicyTable:           {},
+	WebhookSubscriptionTable:     {},
+	WebhookDeliveryTable:         {},
+	ServiceAccountTable:          {},
+	APITokenTable:                {},
+	RBACRoleTable:                {},
+	RBACGroupTable:               {},
+	RBACRoleBindingTable:         {},
 	EipTable:                     {},
 	DiskTable:                    {},
 	ImageTable:                   {},
@@ -285,8 +332,15 @@ var TableMap = map[Name]struct{}{
 	RootAccountBillConfigTable:      {},
 	AccountBillExchangeRateTable:    {},
 	AccountBillSyncRecordTable:      {},
+	AccountBillBudgetTable:          {},
+	AccountBillBudgetAlertTable:     {},
+	AccountBillReportSubTable:       {},
+	AccountBillAllocationRuleTable:  {},
+	AccountBillReconciliationTable:  {},
+	AccountBillArchiveTable:         {},
 	LoadBalancerTable:               {},
 	SecurityGroupCommonRelTable:     {},
+	SecurityGroupLintFindingTable:   {},
 	LoadBalancerListenerTable:       {},
 	TCloudLbUrlRuleTable:            {},
 	LoadBalancerTargetTable:         {},
@@ -0,0 +1,270 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package webhook webhook订阅及投递记录表
+package webhook
+
+import (
+	"errors"
+
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/validator"
+	"hcm/pkg/dal/table"
+	"hcm/pkg/dal/table/types"
+	"hcm/pkg/dal/table/utils"
+)
+
+// SubscriptionColumns defines all the webhook subscription table's columns.
+var SubscriptionColumns = utils.MergeColumns(nil, SubscriptionColumnDescriptor)
+
+// SubscriptionColumnDescriptor is webhook subscription table's column descriptors.
+var SubscriptionColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "name", NamedC: "name", Type: enumor.String},
+	{Column: "url", NamedC: "url", Type: enumor.String},
+	{Column: "secret", NamedC: "secret", Type: enumor.String},
+	{Column: "event_types", NamedC: "event_types", Type: enumor.Json},
+	{Column: "enabled", NamedC: "enabled", Type: enumor.Boolean},
+	{Column: "memo", NamedC: "memo", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// SubscriptionTable webhook订阅表
+type SubscriptionTable struct {
+	// ID 订阅ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// Name 订阅名称
+	Name string `db:"name" json:"name" validate:"lte=64"`
+	// URL 事件推送地址
+	URL string `db:"url" json:"url" validate:"omitempty,lte=1024,url"`
+	// Secret 签名密钥，加密存储
+	Secret string `db:"secret" json:"secret" validate:"lte=255"`
+	// EventTypes 订阅的事件类型
+	EventTypes types.StringArray `db:"event_types" json:"event_types"`
+	// Enabled 是否启用
+	Enabled *bool `db:"enabled" json:"enabled"`
+	// Memo 备注
+	Memo *string `db:"memo" json:"memo" validate:"omitempty,lte=255"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the webhook subscription's database table name.
+func (s SubscriptionTable) TableName() table.Name {
+	return table.WebhookSubscriptionTable
+}
+
+// InsertValidate validate webhook subscription on insertion.
+func (s SubscriptionTable) InsertValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if len(s.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(s.Name) == 0 {
+		return errors.New("name can not be empty")
+	}
+
+	if len(s.URL) == 0 {
+		return errors.New("url can not be empty")
+	}
+
+	if len(s.EventTypes) == 0 {
+		return errors.New("event types can not be empty")
+	}
+
+	if err := validateEventTypes(s.EventTypes); err != nil {
+		return err
+	}
+
+	if s.Enabled == nil {
+		return errors.New("enabled can not be empty")
+	}
+
+	if len(s.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate webhook subscription on update.
+func (s SubscriptionTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(s); err != nil {
+		return err
+	}
+
+	if err := validateEventTypes(s.EventTypes); err != nil {
+		return err
+	}
+
+	if len(s.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(s.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
+
+func validateEventTypes(eventTypes types.StringArray) error {
+	for _, one := range eventTypes {
+		if err := enumor.WebhookEventType(one).Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeliveryColumns defines all the webhook delivery table's columns.
+var DeliveryColumns = utils.MergeColumns(nil, DeliveryColumnDescriptor)
+
+// DeliveryColumnDescriptor is webhook delivery table's column descriptors.
+var DeliveryColumnDescriptor = utils.ColumnDescriptors{
+	{Column: "id", NamedC: "id", Type: enumor.String},
+	{Column: "subscription_id", NamedC: "subscription_id", Type: enumor.String},
+	{Column: "event_type", NamedC: "event_type", Type: enumor.String},
+	{Column: "event_id", NamedC: "event_id", Type: enumor.String},
+	{Column: "payload", NamedC: "payload", Type: enumor.Json},
+	{Column: "state", NamedC: "state", Type: enumor.String},
+	{Column: "attempts", NamedC: "attempts", Type: enumor.Numeric},
+	{Column: "status_code", NamedC: "status_code", Type: enumor.Numeric},
+	{Column: "response", NamedC: "response", Type: enumor.String},
+	{Column: "flow_id", NamedC: "flow_id", Type: enumor.String},
+	{Column: "creator", NamedC: "creator", Type: enumor.String},
+	{Column: "reviser", NamedC: "reviser", Type: enumor.String},
+	{Column: "created_at", NamedC: "created_at", Type: enumor.Time},
+	{Column: "updated_at", NamedC: "updated_at", Type: enumor.Time},
+}
+
+// DeliveryTable webhook投递记录表
+type DeliveryTable struct {
+	// ID 投递记录ID
+	ID string `db:"id" json:"id" validate:"lte=64"`
+	// SubscriptionID 订阅ID
+	SubscriptionID string `db:"subscription_id" json:"subscription_id" validate:"lte=64"`
+	// EventType 事件类型
+	EventType enumor.WebhookEventType `db:"event_type" json:"event_type" validate:"lte=128"`
+	// EventID 事件ID，同一事件投递到多个订阅时相同
+	EventID string `db:"event_id" json:"event_id" validate:"lte=64"`
+	// Payload 投递的事件内容
+	Payload types.JsonField `db:"payload" json:"payload"`
+	// State 投递状态
+	State enumor.WebhookDeliveryState `db:"state" json:"state" validate:"lte=32"`
+	// Attempts 已投递次数
+	Attempts int `db:"attempts" json:"attempts" validate:"min=0"`
+	// StatusCode 最近一次投递的HTTP状态码
+	StatusCode int `db:"status_code" json:"status_code" validate:"min=0"`
+	// Response 最近一次投递的响应状态摘要或错误信息
+	Response *string `db:"response" json:"response" validate:"omitempty,lte=1024"`
+	// FlowID 投递任务所在的异步任务流ID
+	FlowID string `db:"flow_id" json:"flow_id" validate:"lte=64"`
+	// Creator 创建者
+	Creator string `db:"creator" validate:"max=64" json:"creator"`
+	// Reviser 更新者
+	Reviser string `db:"reviser" validate:"max=64" json:"reviser"`
+	// CreatedAt 创建时间
+	CreatedAt types.Time `db:"created_at" validate:"isdefault" json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.Time `db:"updated_at" validate:"isdefault" json:"updated_at"`
+}
+
+// TableName is the webhook delivery's database table name.
+func (d DeliveryTable) TableName() table.Name {
+	return table.WebhookDeliveryTable
+}
+
+// InsertValidate validate webhook delivery on insertion.
+func (d DeliveryTable) InsertValidate() error {
+	if err := validator.Validate.Struct(d); err != nil {
+		return err
+	}
+
+	if len(d.ID) == 0 {
+		return errors.New("id can not be empty")
+	}
+
+	if len(d.SubscriptionID) == 0 {
+		return errors.New("subscription id can not be empty")
+	}
+
+	if len(d.EventType) == 0 {
+		return errors.New("event type can not be empty")
+	}
+
+	if len(d.EventID) == 0 {
+		return errors.New("event id can not be empty")
+	}
+
+	if len(d.Payload) == 0 {
+		return errors.New("payload can not be empty")
+	}
+
+	if err := d.State.Validate(); err != nil {
+		return err
+	}
+
+	if len(d.Creator) == 0 {
+		return errors.New("creator can not be empty")
+	}
+
+	return nil
+}
+
+// UpdateValidate validate webhook delivery on update.
+func (d DeliveryTable) UpdateValidate() error {
+	if err := validator.Validate.Struct(d); err != nil {
+		return err
+	}
+
+	if len(d.State) != 0 {
+		if err := d.State.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if len(d.SubscriptionID) != 0 || len(d.EventID) != 0 || len(d.EventType) != 0 || len(d.Payload) != 0 {
+		return errors.New("subscription id, event and payload can not update")
+	}
+
+	if len(d.Creator) != 0 {
+		return errors.New("creator can not update")
+	}
+
+	if len(d.Reviser) == 0 {
+		return errors.New("reviser can not be empty")
+	}
+
+	return nil
+}
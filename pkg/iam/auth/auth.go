@@ -96,15 +96,24 @@ type authorizer struct {
 func (a authorizer) Authorize(kt *kit.Kit, resources ...meta.ResourceAttribute) ([]meta.Decision, bool, error) {
 	userInfo := &meta.UserInfo{UserName: kt.User}
 
-	req := &asproto.AuthorizeBatchReq{
-		User:      userInfo,
-		Resources: resources,
-	}
+	// 通过API令牌访问时，超出令牌授权范围的资源直接判定为无权限，范围内的资源仍然通过IAM鉴权
+	inScope, indexes := splitByTokenScope(kt, resources)
+	decisions := make([]meta.Decision, len(resources))
+	if len(inScope) != 0 {
+		req := &asproto.AuthorizeBatchReq{
+			User:      userInfo,
+			Resources: inScope,
+		}
 
-	decisions, err := a.authClient.AuthorizeBatch(kt.Ctx, kt.Header(), req)
-	if err != nil {
-		logs.Errorf("authorize failed, req: %#v, err: %v, rid: %s", req, err, kt.Rid)
-		return nil, false, err
+		scopeDecisions, err := a.authClient.AuthorizeBatch(kt.Ctx, kt.Header(), req)
+		if err != nil {
+			logs.Errorf("authorize failed, req: %#v, err: %v, rid: %s", req, err, kt.Rid)
+			return nil, false, err
+		}
+
+		for idx, decision := range scopeDecisions {
+			decisions[indexes[idx]] = decision
+		}
 	}
 
 	authorized := true
@@ -122,17 +131,27 @@ func (a authorizer) Authorize(kt *kit.Kit, resources ...meta.ResourceAttribute)
 func (a authorizer) AuthorizeAny(kt *kit.Kit, resources ...meta.ResourceAttribute) ([]meta.Decision, error) {
 	userInfo := &meta.UserInfo{UserName: kt.User}
 
+	inScope, indexes := splitByTokenScope(kt, resources)
+	decisions := make([]meta.Decision, len(resources))
+	if len(inScope) == 0 {
+		return decisions, nil
+	}
+
 	req := &asproto.AuthorizeBatchReq{
 		User:      userInfo,
-		Resources: resources,
+		Resources: inScope,
 	}
 
-	decisions, err := a.authClient.AuthorizeAnyBatch(kt.Ctx, kt.Header(), req)
+	scopeDecisions, err := a.authClient.AuthorizeAnyBatch(kt.Ctx, kt.Header(), req)
 	if err != nil {
 		logs.Errorf("authorize any failed, req: %#v, err: %v, rid: %s", req, err, kt.Rid)
 		return nil, err
 	}
 
+	for idx, decision := range scopeDecisions {
+		decisions[indexes[idx]] = decision
+	}
+
 	return decisions, nil
 }
 
@@ -144,6 +163,10 @@ func (a authorizer) AuthorizeWithPerm(kt *kit.Kit, resources ...meta.ResourceAtt
 	}
 
 	if !authorized {
+		if inScope, _ := splitByTokenScope(kt, resources); len(inScope) != len(resources) {
+			return errf.New(errf.PermissionDenied, "operation is out of api token scope")
+		}
+
 		permission, err := a.GetPermissionToApply(kt, resources...)
 		if err != nil {
 			logs.Errorf("get permission to apply failed, resources: %#v, err: %v, rid: %s", resources, err, kt.Rid)
@@ -178,7 +201,7 @@ func (a authorizer) ListAuthorizedInstances(kt *kit.Kit, input *meta.ListAuthRes
 		return nil, err
 	}
 
-	return resources, nil
+	return limitAuthInstByTokenScope(kt, input, resources), nil
 }
 
 // ListAuthInstWithFilter returns resource filter with authorized instances info & if user has no permission flag.
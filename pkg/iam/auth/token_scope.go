@@ -0,0 +1,121 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"strconv"
+
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/tools/slice"
+)
+
+// tokenActionAllowed 判断API令牌是否授予了该操作，业务访问和无需鉴权的操作由令牌的业务范围控制，不需要单独授予。
+func tokenActionAllowed(scope *kit.TokenScope, action meta.Action) bool {
+	if action == meta.Access || action == meta.SkipAction {
+		return true
+	}
+
+	for _, one := range scope.Actions {
+		if meta.Action(one) == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenBizAllowed 判断API令牌是否可以访问该业务，令牌限制了业务时，非业务下的资源不允许访问。
+func tokenBizAllowed(scope *kit.TokenScope, bizID int64) bool {
+	if len(scope.BkBizIDs) == 0 {
+		return true
+	}
+
+	for _, one := range scope.BkBizIDs {
+		if one == bizID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenResourceAllowed 判断资源是否在API令牌的授权范围内。
+func tokenResourceAllowed(scope *kit.TokenScope, res meta.ResourceAttribute) bool {
+	if res.Basic == nil || !tokenActionAllowed(scope, res.Basic.Action) {
+		return false
+	}
+
+	bizID := res.BizID
+	if bizID == 0 && res.Basic.Type == meta.Biz {
+		bizID, _ = strconv.ParseInt(res.Basic.ResourceID, 10, 64)
+	}
+
+	return tokenBizAllowed(scope, bizID)
+}
+
+// splitByTokenScope 按API令牌的授权范围拆分资源，返回范围内的资源及其在原列表中的下标，非令牌访问时全部资源都在范围内。
+func splitByTokenScope(kt *kit.Kit, resources []meta.ResourceAttribute) ([]meta.ResourceAttribute, []int) {
+	inScope := make([]meta.ResourceAttribute, 0, len(resources))
+	indexes := make([]int, 0, len(resources))
+	for idx, res := range resources {
+		if kt.TokenScope != nil && !tokenResourceAllowed(kt.TokenScope, res) {
+			continue
+		}
+
+		inScope = append(inScope, res)
+		indexes = append(indexes, idx)
+	}
+
+	return inScope, indexes
+}
+
+// limitAuthInstByTokenScope 按API令牌的授权范围收敛有权限的实例，令牌限制了业务时，业务实例只保留令牌可访问的业务。
+func limitAuthInstByTokenScope(kt *kit.Kit, input *meta.ListAuthResInput,
+	authInst *meta.AuthorizedInstances) *meta.AuthorizedInstances {
+
+	scope := kt.TokenScope
+	if scope == nil {
+		return authInst
+	}
+
+	if !tokenActionAllowed(scope, input.Action) {
+		return &meta.AuthorizedInstances{IDs: make([]string, 0)}
+	}
+
+	if len(scope.BkBizIDs) == 0 {
+		return authInst
+	}
+
+	if input.Type != meta.Biz {
+		// 令牌限制了业务时，不允许访问业务之外的资源
+		return &meta.AuthorizedInstances{IDs: make([]string, 0)}
+	}
+
+	ids := make([]string, 0, len(scope.BkBizIDs))
+	for _, bizID := range scope.BkBizIDs {
+		id := strconv.FormatInt(bizID, 10)
+		if authInst.IsAny || slice.IsItemInSlice(authInst.IDs, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	return &meta.AuthorizedInstances{IDs: ids}
+}
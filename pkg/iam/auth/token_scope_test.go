@@ -0,0 +1,144 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package auth
+
+import (
+	"reflect"
+	"testing"
+
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+)
+
+func TestSplitByTokenScope(t *testing.T) {
+	resources := []meta.ResourceAttribute{
+		{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find, ResourceID: "cvm-1"}, BizID: 1},
+		{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Delete, ResourceID: "cvm-2"}, BizID: 1},
+		{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find, ResourceID: "cvm-3"}, BizID: 2},
+		{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find, ResourceID: "cvm-4"}},
+		{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access, ResourceID: "1"}},
+		{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access, ResourceID: "2"}},
+		{},
+	}
+
+	cases := []struct {
+		name    string
+		scope   *kit.TokenScope
+		indexes []int
+	}{
+		{
+			name:    "not token access",
+			scope:   nil,
+			indexes: []int{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:    "token without biz limit",
+			scope:   &kit.TokenScope{Actions: []string{string(meta.Find)}},
+			indexes: []int{0, 2, 3, 4, 5},
+		},
+		{
+			name:    "token limited to biz",
+			scope:   &kit.TokenScope{BkBizIDs: []int64{1}, Actions: []string{string(meta.Find)}},
+			indexes: []int{0, 4},
+		},
+		{
+			name:    "token without actions",
+			scope:   &kit.TokenScope{BkBizIDs: []int64{2}},
+			indexes: []int{5},
+		},
+	}
+
+	for _, c := range cases {
+		inScope, indexes := splitByTokenScope(&kit.Kit{TokenScope: c.scope}, resources)
+		if !reflect.DeepEqual(indexes, c.indexes) {
+			t.Errorf("%s: got indexes %v, expect %v", c.name, indexes, c.indexes)
+			continue
+		}
+
+		for i, idx := range indexes {
+			if !reflect.DeepEqual(inScope[i], resources[idx]) {
+				t.Errorf("%s: resource %d not match its index %d", c.name, i, idx)
+			}
+		}
+	}
+}
+
+func TestLimitAuthInstByTokenScope(t *testing.T) {
+	bizFind := &meta.ListAuthResInput{Type: meta.Biz, Action: meta.Access}
+	cvmFind := &meta.ListAuthResInput{Type: meta.Cvm, Action: meta.Find}
+	cvmDelete := &meta.ListAuthResInput{Type: meta.Cvm, Action: meta.Delete}
+
+	cases := []struct {
+		name     string
+		scope    *kit.TokenScope
+		input    *meta.ListAuthResInput
+		authInst *meta.AuthorizedInstances
+		expect   *meta.AuthorizedInstances
+	}{
+		{
+			name:     "not token access",
+			input:    cvmDelete,
+			authInst: &meta.AuthorizedInstances{IsAny: true},
+			expect:   &meta.AuthorizedInstances{IsAny: true},
+		},
+		{
+			name:     "action not granted",
+			scope:    &kit.TokenScope{Actions: []string{string(meta.Find)}},
+			input:    cvmDelete,
+			authInst: &meta.AuthorizedInstances{IsAny: true},
+			expect:   &meta.AuthorizedInstances{IDs: []string{}},
+		},
+		{
+			name:     "no biz limit keeps iam result",
+			scope:    &kit.TokenScope{Actions: []string{string(meta.Find)}},
+			input:    cvmFind,
+			authInst: &meta.AuthorizedInstances{IDs: []string{"cvm-1"}},
+			expect:   &meta.AuthorizedInstances{IDs: []string{"cvm-1"}},
+		},
+		{
+			name:     "biz limit rejects non biz resource",
+			scope:    &kit.TokenScope{BkBizIDs: []int64{1}, Actions: []string{string(meta.Find)}},
+			input:    cvmFind,
+			authInst: &meta.AuthorizedInstances{IsAny: true},
+			expect:   &meta.AuthorizedInstances{IDs: []string{}},
+		},
+		{
+			name:     "biz limit narrows any",
+			scope:    &kit.TokenScope{BkBizIDs: []int64{1, 2}},
+			input:    bizFind,
+			authInst: &meta.AuthorizedInstances{IsAny: true},
+			expect:   &meta.AuthorizedInstances{IDs: []string{"1", "2"}},
+		},
+		{
+			name:     "biz limit intersects iam result",
+			scope:    &kit.TokenScope{BkBizIDs: []int64{1, 2}},
+			input:    bizFind,
+			authInst: &meta.AuthorizedInstances{IDs: []string{"2", "3"}},
+			expect:   &meta.AuthorizedInstances{IDs: []string{"2"}},
+		},
+	}
+
+	for _, c := range cases {
+		got := limitAuthInstByTokenScope(&kit.Kit{TokenScope: c.scope}, c.input, c.authInst)
+		if !reflect.DeepEqual(got, c.expect) {
+			t.Errorf("%s: got %+v, expect %+v", c.name, got, c.expect)
+		}
+	}
+}
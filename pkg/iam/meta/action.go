@@ -70,3 +70,22 @@ const (
 	// Apply 资源申请权限
 	Apply Action = "apply"
 )
+
+// TokenActions API令牌可授予的操作，密钥查看、重置密码等敏感操作不允许通过令牌授予
+var TokenActions = map[Action]struct{}{
+	Create:       {},
+	Update:       {},
+	Delete:       {},
+	Find:         {},
+	Assign:       {},
+	Recycle:      {},
+	Destroy:      {},
+	Recover:      {},
+	Start:        {},
+	Stop:         {},
+	Reboot:       {},
+	Import:       {},
+	Associate:    {},
+	Disassociate: {},
+	Apply:        {},
+}
@@ -104,4 +104,14 @@ const (
 
 	// AccountBillThirdParty 第三方账单拉取
 	AccountBillThirdParty ResourceType = "account_bill_third_party"
+
+	// Webhook webhook订阅
+	Webhook ResourceType = "webhook"
+	// ServiceAccount 服务账号及其API令牌
+	ServiceAccount ResourceType = "service_account"
+	// RBAC 本地鉴权的角色、用户组及角色绑定
+	RBAC ResourceType = "rbac"
+
+	// AuditExport 审计导出
+	AuditExport ResourceType = "audit_export"
 )
@@ -0,0 +1,180 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package rbac 本地RBAC鉴权后端，角色、用户组及角色绑定存储在MySQL中，鉴权模型与IAM一致，使用 meta.ResourceAttribute
+// 描述被鉴权的资源。auth-server 配置为本地鉴权后端时使用，调用方的 ListAuthInstWithFilter 基于
+// ListAuthorizedInstances 的结果生成过滤条件，无需单独实现。
+package rbac
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"hcm/pkg/api/core"
+	corerbac "hcm/pkg/api/core/rbac"
+	dataservice "hcm/pkg/client/data-service"
+	"hcm/pkg/dal/dao/tools"
+	"hcm/pkg/iam/meta"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// Authorizer local rbac authorizer, rbac data is loaded to snapshot and refreshed after refresh interval.
+type Authorizer struct {
+	ds              *dataservice.Client
+	admins          []string
+	refreshInterval time.Duration
+
+	lock     sync.Mutex
+	snapshot *Snapshot
+	loadedAt time.Time
+}
+
+// NewAuthorizer create local rbac authorizer.
+func NewAuthorizer(ds *dataservice.Client, admins []string, refreshInterval time.Duration) *Authorizer {
+	return &Authorizer{
+		ds:              ds,
+		admins:          admins,
+		refreshInterval: refreshInterval,
+	}
+}
+
+// Authorize if user has permission to the resources, returns auth status per resource.
+func (a *Authorizer) Authorize(kt *kit.Kit, user string, resources ...meta.ResourceAttribute) (
+	[]meta.Decision, error) {
+
+	return a.authorize(kt, user, resources, true)
+}
+
+// AuthorizeAny authorize if user has any permission to the resources, returns auth status per resource.
+func (a *Authorizer) AuthorizeAny(kt *kit.Kit, user string, resources ...meta.ResourceAttribute) (
+	[]meta.Decision, error) {
+
+	return a.authorize(kt, user, resources, false)
+}
+
+func (a *Authorizer) authorize(kt *kit.Kit, user string, resources []meta.ResourceAttribute, exact bool) (
+	[]meta.Decision, error) {
+
+	snapshot, err := a.getSnapshot(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	decisions := make([]meta.Decision, len(resources))
+	for idx, res := range resources {
+		decisions[idx] = meta.Decision{Authorized: snapshot.Authorize(user, res, exact)}
+	}
+
+	return decisions, nil
+}
+
+// ListAuthorizedInstances list user's authorized instances.
+func (a *Authorizer) ListAuthorizedInstances(kt *kit.Kit, user string, input *meta.ListAuthResInput) (
+	*meta.AuthorizedInstances, error) {
+
+	snapshot, err := a.getSnapshot(kt)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.ListAuthorizedInstances(user, input), nil
+}
+
+// getSnapshot get local rbac snapshot, reload it when it is expired.
+func (a *Authorizer) getSnapshot(kt *kit.Kit) (*Snapshot, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.snapshot != nil && time.Since(a.loadedAt) < a.refreshInterval {
+		return a.snapshot, nil
+	}
+
+	snapshot, err := a.loadSnapshot(kt)
+	if err != nil {
+		logs.Errorf("load local rbac snapshot failed, err: %v, rid: %s", err, kt.Rid)
+		return nil, err
+	}
+
+	a.snapshot = snapshot
+	a.loadedAt = time.Now()
+	return snapshot, nil
+}
+
+func (a *Authorizer) loadSnapshot(kt *kit.Kit) (*Snapshot, error) {
+	roles, err := listAll(kt, func(req *core.ListReq) ([]corerbac.Role, error) {
+		result, err := a.ds.Global.RBAC.ListRole(kt, req)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list rbac role failed, err: %v", err)
+	}
+
+	groups, err := listAll(kt, func(req *core.ListReq) ([]corerbac.Group, error) {
+		result, err := a.ds.Global.RBAC.ListGroup(kt, req)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list rbac group failed, err: %v", err)
+	}
+
+	bindings, err := listAll(kt, func(req *core.ListReq) ([]corerbac.RoleBinding, error) {
+		result, err := a.ds.Global.RBAC.ListRoleBinding(kt, req)
+		if err != nil {
+			return nil, err
+		}
+		return result.Details, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list rbac role binding failed, err: %v", err)
+	}
+
+	return NewSnapshot(a.admins, roles, groups, bindings), nil
+}
+
+func listAll[T any](kt *kit.Kit, list func(req *core.ListReq) ([]T, error)) ([]T, error) {
+	req := &core.ListReq{
+		Filter: tools.AllExpression(),
+		Page:   core.NewDefaultBasePage(),
+	}
+
+	all := make([]T, 0)
+	for {
+		details, err := list(req)
+		if err != nil {
+			logs.Errorf("list local rbac data failed, err: %v, rid: %s", err, kt.Rid)
+			return nil, err
+		}
+
+		all = append(all, details...)
+		if uint(len(details)) < req.Page.Limit {
+			break
+		}
+		req.Page.Start += uint32(req.Page.Limit)
+	}
+
+	return all, nil
+}
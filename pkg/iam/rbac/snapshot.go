@@ -0,0 +1,189 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"strconv"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/meta"
+)
+
+// Snapshot 本地RBAC数据快照，鉴权时只读，数据变更后通过重新加载生成新的快照
+type Snapshot struct {
+	// admins 超级管理员，拥有所有权限
+	admins map[string]struct{}
+	// rolePerms 角色ID到角色权限的映射
+	rolePerms map[string][]corerbac.Permission
+	// userGroups 用户名到所属用户组名称的映射
+	userGroups map[string][]string
+	// bindings 授权对象到角色绑定的映射，key为 subject_type/subject
+	bindings map[string][]corerbac.RoleBinding
+}
+
+// NewSnapshot create local rbac snapshot.
+func NewSnapshot(admins []string, roles []corerbac.Role, groups []corerbac.Group,
+	bindings []corerbac.RoleBinding) *Snapshot {
+
+	s := &Snapshot{
+		admins:     make(map[string]struct{}, len(admins)),
+		rolePerms:  make(map[string][]corerbac.Permission, len(roles)),
+		userGroups: make(map[string][]string),
+		bindings:   make(map[string][]corerbac.RoleBinding),
+	}
+
+	for _, admin := range admins {
+		s.admins[admin] = struct{}{}
+	}
+
+	for _, role := range roles {
+		s.rolePerms[role.ID] = role.Permissions
+	}
+
+	for _, group := range groups {
+		for _, member := range group.Members {
+			s.userGroups[member] = append(s.userGroups[member], group.Name)
+		}
+	}
+
+	for _, binding := range bindings {
+		key := subjectKey(binding.SubjectType, binding.Subject)
+		s.bindings[key] = append(s.bindings[key], binding)
+	}
+
+	return s
+}
+
+func subjectKey(subjectType enumor.RBACSubjectType, subject string) string {
+	return string(subjectType) + "/" + subject
+}
+
+// IsAdmin returns if the user is local rbac super admin.
+func (s *Snapshot) IsAdmin(user string) bool {
+	_, exists := s.admins[user]
+	return exists
+}
+
+// matchedBindings returns user's role bindings, include bindings of user's groups, which grant the action of the
+// resource type. 业务访问权限不需要单独授予，用户在业务下有任意角色即可访问该业务。
+func (s *Snapshot) matchedBindings(user string, resType meta.ResourceType,
+	action meta.Action) []corerbac.RoleBinding {
+
+	candidates := make([]corerbac.RoleBinding, 0)
+	candidates = append(candidates, s.bindings[subjectKey(enumor.RBACSubjectUser, user)]...)
+	for _, group := range s.userGroups[user] {
+		candidates = append(candidates, s.bindings[subjectKey(enumor.RBACSubjectGroup, group)]...)
+	}
+
+	if resType == meta.Biz && action == meta.Access {
+		return candidates
+	}
+
+	matched := make([]corerbac.RoleBinding, 0, len(candidates))
+	for _, binding := range candidates {
+		for _, perm := range s.rolePerms[binding.RoleID] {
+			if perm.Match(resType, action) {
+				matched = append(matched, binding)
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+// Authorize returns if user has permission to the resource. exact为false时，资源不属于任何业务的情况下，
+// 用户在任意业务下拥有该权限即认为有权限。
+func (s *Snapshot) Authorize(user string, res meta.ResourceAttribute, exact bool) bool {
+	if res.Basic == nil {
+		return false
+	}
+
+	if res.Basic.Action == meta.SkipAction || s.IsAdmin(user) {
+		return true
+	}
+
+	bizID := resourceBizID(res)
+	for _, binding := range s.matchedBindings(user, res.Basic.Type, res.Basic.Action) {
+		if len(binding.BkBizIDs) == 0 {
+			return true
+		}
+
+		if bizID == 0 {
+			if !exact {
+				return true
+			}
+			continue
+		}
+
+		for _, one := range binding.BkBizIDs {
+			if one == bizID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ListAuthorizedInstances list user's authorized instances of the resource type and action. 本地RBAC只支持业务级别的
+// 授权，业务类型返回有权限的业务ID，其他类型只有不限制业务的授权才有权限。
+func (s *Snapshot) ListAuthorizedInstances(user string, input *meta.ListAuthResInput) *meta.AuthorizedInstances {
+	if s.IsAdmin(user) {
+		return &meta.AuthorizedInstances{IsAny: true}
+	}
+
+	bizIDs := make(map[int64]struct{})
+	for _, binding := range s.matchedBindings(user, input.Type, input.Action) {
+		if len(binding.BkBizIDs) == 0 {
+			return &meta.AuthorizedInstances{IsAny: true}
+		}
+
+		for _, bizID := range binding.BkBizIDs {
+			bizIDs[bizID] = struct{}{}
+		}
+	}
+
+	ids := make([]string, 0)
+	if input.Type != meta.Biz {
+		return &meta.AuthorizedInstances{IDs: ids}
+	}
+
+	for bizID := range bizIDs {
+		ids = append(ids, strconv.FormatInt(bizID, 10))
+	}
+
+	return &meta.AuthorizedInstances{IDs: ids}
+}
+
+// resourceBizID returns business id of the resource, business resource's id is the business id.
+func resourceBizID(res meta.ResourceAttribute) int64 {
+	if res.BizID > 0 {
+		return res.BizID
+	}
+
+	if res.Basic.Type == meta.Biz {
+		bizID, _ := strconv.ParseInt(res.Basic.ResourceID, 10, 64)
+		return bizID
+	}
+
+	return 0
+}
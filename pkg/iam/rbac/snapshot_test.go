@@ -0,0 +1,98 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package rbac
+
+import (
+	"testing"
+
+	corerbac "hcm/pkg/api/core/rbac"
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/iam/meta"
+)
+
+func newTestSnapshot() *Snapshot {
+	roles := []corerbac.Role{
+		{ID: "r1", Permissions: []corerbac.Permission{
+			{ResType: meta.Cvm, Actions: []meta.Action{meta.Find, meta.Start}},
+		}},
+		{ID: "r2", Permissions: []corerbac.Permission{{ResType: corerbac.Any, Actions: []meta.Action{corerbac.Any}}}},
+	}
+	groups := []corerbac.Group{{Name: "ops", Members: []string{"bob"}}}
+	bindings := []corerbac.RoleBinding{
+		{RoleID: "r1", SubjectType: enumor.RBACSubjectUser, Subject: "alice", BkBizIDs: []int64{100}},
+		{RoleID: "r2", SubjectType: enumor.RBACSubjectGroup, Subject: "ops", BkBizIDs: []int64{}},
+	}
+
+	return NewSnapshot([]string{"admin"}, roles, groups, bindings)
+}
+
+func TestSnapshot_Authorize(t *testing.T) {
+	s := newTestSnapshot()
+
+	cases := []struct {
+		user   string
+		res    meta.ResourceAttribute
+		exact  bool
+		expect bool
+	}{
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}, BizID: 100}, true,
+			true},
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}, BizID: 200}, true,
+			false},
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Delete}, BizID: 100}, true,
+			false},
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}}, true, false},
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}}, false, true},
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access, ResourceID: "100"}},
+			true, true},
+		{"alice", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Biz, Action: meta.Access, ResourceID: "200"}},
+			true, false},
+		{"bob", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Delete}}, true, true},
+		{"admin", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Account, Action: meta.Delete}}, true, true},
+		{"carol", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.SkipAction}}, true, true},
+		{"carol", meta.ResourceAttribute{Basic: &meta.Basic{Type: meta.Cvm, Action: meta.Find}, BizID: 100}, true,
+			false},
+	}
+
+	for idx, c := range cases {
+		if got := s.Authorize(c.user, c.res, c.exact); got != c.expect {
+			t.Errorf("case %d: authorize %s %+v expect %v, got %v", idx, c.user, c.res.Basic, c.expect, got)
+		}
+	}
+}
+
+func TestSnapshot_ListAuthorizedInstances(t *testing.T) {
+	s := newTestSnapshot()
+
+	inst := s.ListAuthorizedInstances("alice", &meta.ListAuthResInput{Type: meta.Biz, Action: meta.Access})
+	if inst.IsAny || len(inst.IDs) != 1 || inst.IDs[0] != "100" {
+		t.Errorf("alice biz access expect [100], got %+v", inst)
+	}
+
+	inst = s.ListAuthorizedInstances("alice", &meta.ListAuthResInput{Type: meta.Account, Action: meta.Find})
+	if inst.IsAny || len(inst.IDs) != 0 {
+		t.Errorf("alice account find expect none, got %+v", inst)
+	}
+
+	inst = s.ListAuthorizedInstances("bob", &meta.ListAuthResInput{Type: meta.Account, Action: meta.Find})
+	if !inst.IsAny {
+		t.Errorf("bob account find expect any, got %+v", inst)
+	}
+}
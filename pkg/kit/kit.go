@@ -22,7 +22,9 @@ package kit
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -64,6 +66,47 @@ type Kit struct {
 	// 因为来自前端和第三方系统调用的请求均为 ApiCall，所以没必要将该字段暴漏出去，仅同步请求需要设
 	// 置该字段为 BackgroundSync。
 	RequestSource enumor.RequestSourceType
+
+	// TokenScope 通过API令牌访问时令牌的授权范围，为空表示非令牌访问，鉴权时在IAM权限的基础上再按该范围收敛。
+	TokenScope *TokenScope
+}
+
+// TokenScope api token's scope.
+type TokenScope struct {
+	// TokenID API令牌ID
+	TokenID string `json:"token_id"`
+	// BkBizIDs 令牌可访问的业务，为空表示不限制业务
+	BkBizIDs []int64 `json:"bk_biz_ids,omitempty"`
+	// Actions 令牌可执行的操作
+	Actions []string `json:"actions"`
+}
+
+// encodeTokenScope encode token scope to header value, returns empty string if scope is nil.
+func encodeTokenScope(scope *TokenScope) string {
+	if scope == nil {
+		return ""
+	}
+
+	raw, err := json.Marshal(scope)
+	if err != nil {
+		return ""
+	}
+
+	return string(raw)
+}
+
+// decodeTokenScope decode token scope from header value.
+func decodeTokenScope(value string) (*TokenScope, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	scope := new(TokenScope)
+	if err := json.Unmarshal([]byte(value), scope); err != nil {
+		return nil, fmt.Errorf("decode token scope failed, err: %v", err)
+	}
+
+	return scope, nil
 }
 
 // NewSubKit 在当前kit后缀加上6位随机字符串
@@ -141,13 +184,19 @@ func (kt *Kit) Validate() error {
 
 // Header generate header by kit
 func (kt *Kit) Header() http.Header {
-	return http.Header{
+	header := http.Header{
 		constant.UserKey:          []string{kt.User},
 		constant.RidKey:           []string{kt.Rid},
 		constant.AppCodeKey:       []string{kt.AppCode},
 		constant.TenantIDKey:      []string{kt.TenantID},
 		constant.RequestSourceKey: []string{string(kt.RequestSource)},
 	}
+
+	if kt.TokenScope != nil {
+		header.Set(constant.TokenScopeKey, encodeTokenScope(kt.TokenScope))
+	}
+
+	return header
 }
 
 // FromHeader http request header to context kit and validate.
@@ -165,6 +214,12 @@ func FromHeader(ctx context.Context, header http.Header) (*Kit, error) {
 		RequestSource: enumor.RequestSourceType(header.Get(constant.RequestSourceKey)),
 	}
 
+	scope, err := decodeTokenScope(header.Get(constant.TokenScopeKey))
+	if err != nil {
+		return nil, err
+	}
+	kt.TokenScope = scope
+
 	if kt.Ctx.Value(constant.RidKey) == nil {
 		kt.Ctx = context.WithValue(kt.Ctx, constant.RidKey, kt.Rid)
 	}
@@ -0,0 +1,491 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package balancer 服务间调用的节点熔断、异常节点摘除及基于延迟的负载均衡
+package balancer
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"hcm/pkg/logs"
+)
+
+// State is the circuit breaker state of a target.
+type State string
+
+const (
+	// Closed 正常状态，请求正常转发到该节点
+	Closed State = "closed"
+	// Open 熔断状态，请求不会转发到该节点
+	Open State = "open"
+	// HalfOpen 半开状态，允许一个探测请求，成功后恢复为正常状态，失败后重新熔断
+	HalfOpen State = "half_open"
+)
+
+// Option defines circuit breaking and outlier ejection options.
+type Option struct {
+	// FailureThreshold 连续失败次数达到该值时熔断节点
+	FailureThreshold int
+	// OpenDuration 节点熔断后经过该时间进入半开状态
+	OpenDuration time.Duration
+	// LatencyDecay 延迟指数加权移动平均的衰减系数，取值(0,1]，越大越偏向最近的请求
+	LatencyDecay float64
+	// OutlierFactor 节点平均延迟超过其余节点平均延迟中位数的倍数时视为异常节点
+	OutlierFactor float64
+	// OutlierMinLatency 节点平均延迟低于该值时不视为异常节点
+	OutlierMinLatency time.Duration
+	// LatencySampleMax 耗时超过该值的请求视为长耗时请求（如导出、同步类接口），不计入节点延迟，为0时不限制
+	LatencySampleMax time.Duration
+	// EjectDuration 异常节点摘除时长
+	EjectDuration time.Duration
+	// MaxEjectPercent 同一上游服务最多允许摘除的节点百分比
+	MaxEjectPercent int
+}
+
+// DefaultOption returns the default circuit breaking and outlier ejection options.
+func DefaultOption() Option {
+	return Option{
+		FailureThreshold:  5,
+		OpenDuration:      10 * time.Second,
+		LatencyDecay:      0.3,
+		OutlierFactor:     5,
+		OutlierMinLatency: 2 * time.Second,
+		LatencySampleMax:  5 * time.Second,
+		EjectDuration:     30 * time.Second,
+		MaxEjectPercent:   50,
+	}
+}
+
+// withDefault returns the option whose zero fields are set to the default values.
+func (o Option) withDefault() Option {
+	def := DefaultOption()
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = def.FailureThreshold
+	}
+	if o.OpenDuration <= 0 {
+		o.OpenDuration = def.OpenDuration
+	}
+	if o.LatencyDecay <= 0 || o.LatencyDecay > 1 {
+		o.LatencyDecay = def.LatencyDecay
+	}
+	if o.OutlierFactor <= 0 {
+		o.OutlierFactor = def.OutlierFactor
+	}
+	if o.OutlierMinLatency <= 0 {
+		o.OutlierMinLatency = def.OutlierMinLatency
+	}
+	if o.LatencySampleMax <= 0 {
+		o.LatencySampleMax = def.LatencySampleMax
+	}
+	if o.EjectDuration <= 0 {
+		o.EjectDuration = def.EjectDuration
+	}
+	if o.MaxEjectPercent <= 0 || o.MaxEjectPercent > 100 {
+		o.MaxEjectPercent = def.MaxEjectPercent
+	}
+	return o
+}
+
+var (
+	upstreams    = make(map[string]*Upstream)
+	upstreamOpt  = DefaultOption()
+	upstreamsMux sync.Mutex
+)
+
+// SetOption sets the option of all the upstreams in process, zero fields use the default values.
+func SetOption(opt Option) {
+	upstreamsMux.Lock()
+	defer upstreamsMux.Unlock()
+
+	upstreamOpt = opt.withDefault()
+	for _, u := range upstreams {
+		u.lock.Lock()
+		u.opt = upstreamOpt
+		u.lock.Unlock()
+	}
+}
+
+// GetUpstream returns the upstream of the name, it is created with the option set by SetOption if not exists.
+// upstreams are shared in process so that all clients of the same service share the target states.
+func GetUpstream(name string) *Upstream {
+	upstreamsMux.Lock()
+	defer upstreamsMux.Unlock()
+
+	if u, exists := upstreams[name]; exists {
+		return u
+	}
+
+	initMetric()
+	u := NewUpstream(name, upstreamOpt)
+	upstreams[name] = u
+	return u
+}
+
+// ListUpstream returns all the upstreams in process sorted by name.
+func ListUpstream() []*Upstream {
+	upstreamsMux.Lock()
+	defer upstreamsMux.Unlock()
+
+	list := make([]*Upstream, 0, len(upstreams))
+	for _, u := range upstreams {
+		list = append(list, u)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+
+	return list
+}
+
+// NewUpstream create a new upstream.
+func NewUpstream(name string, opt Option) *Upstream {
+	return &Upstream{
+		name:    name,
+		opt:     opt,
+		targets: make(map[string]*target),
+		now:     time.Now,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Upstream holds the circuit breaker and latency states of all targets of an upstream service.
+type Upstream struct {
+	name    string
+	opt     Option
+	lock    sync.Mutex
+	targets map[string]*target
+	now     func() time.Time
+	rand    *rand.Rand
+}
+
+type target struct {
+	host     string
+	state    State
+	failures int
+	openedAt time.Time
+	// probeAt 半开状态下探测请求的发送时间，探测请求超过熔断时长未上报结果时允许重新探测
+	probeAt time.Time
+	// latency 延迟的指数加权移动平均值，单位毫秒，0表示暂无数据
+	latency      float64
+	ejectedUntil time.Time
+}
+
+// Name returns the upstream name.
+func (u *Upstream) Name() string {
+	return u.name
+}
+
+// Pick returns the hosts ordered by preference, open and ejected targets are excluded. the first host is chosen by
+// power of two choices with latency, and the others are sorted by latency. if none of the targets is available,
+// all hosts are returned so that requests are still able to be sent.
+func (u *Upstream) Pick(hosts []string) []string {
+	if len(hosts) <= 1 {
+		return hosts
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	u.prune(hosts)
+
+	now := u.now()
+	var probe string
+	available := make([]*target, 0, len(hosts))
+	for _, host := range hosts {
+		t := u.getTarget(host)
+
+		if t.state == Open && now.Sub(t.openedAt) >= u.opt.OpenDuration {
+			u.setState(t, HalfOpen)
+		}
+
+		switch t.state {
+		case Open:
+			continue
+		case HalfOpen:
+			// 半开状态只允许一个探测请求，探测请求优先发送到该节点
+			if len(probe) == 0 && (t.probeAt.IsZero() || now.Sub(t.probeAt) >= u.opt.OpenDuration) {
+				t.probeAt = now
+				probe = t.host
+			}
+			continue
+		}
+
+		if !t.ejectedUntil.IsZero() {
+			if now.Before(t.ejectedUntil) {
+				continue
+			}
+			// 摘除时间结束后清空延迟数据，重新参与负载均衡
+			t.ejectedUntil = time.Time{}
+			t.latency = 0
+			ejectedGauge.WithLabelValues(u.name, t.host).Set(0)
+		}
+
+		available = append(available, t)
+	}
+
+	ordered := make([]string, 0, len(hosts))
+	if len(probe) != 0 {
+		ordered = append(ordered, probe)
+	}
+
+	if len(available) == 0 && len(probe) == 0 {
+		logs.Warnf("all targets of upstream %s are unavailable, try all of them, hosts: %v", u.name, hosts)
+		return hosts
+	}
+
+	sort.SliceStable(available, func(i, j int) bool { return available[i].latency < available[j].latency })
+
+	// power of two choices, choose the faster one from two random targets as the first one.
+	if len(available) >= 2 {
+		i, j := u.rand.Intn(len(available)), u.rand.Intn(len(available)-1)
+		if j >= i {
+			j++
+		}
+		first := i
+		if available[j].latency < available[i].latency {
+			first = j
+		}
+		ordered = append(ordered, available[first].host)
+		available = append(available[:first:first], available[first+1:]...)
+	}
+
+	for _, t := range available {
+		ordered = append(ordered, t.host)
+	}
+
+	return ordered
+}
+
+// Report reports the request result of the host, statusCode is ignored when err is not nil.
+func (u *Upstream) Report(host string, latency time.Duration, statusCode int, err error) {
+	// 调用方取消的请求不能说明节点异常
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	t := u.getTarget(host)
+	failed := err != nil || isFailureStatus(statusCode)
+
+	result := "success"
+	if failed {
+		result = "failure"
+	}
+	requestCounter.WithLabelValues(u.name, host, result).Inc()
+
+	// 长耗时请求的延迟由接口本身决定，不能说明节点变慢，不计入节点延迟
+	sampled := u.opt.LatencySampleMax <= 0 || latency <= u.opt.LatencySampleMax
+	if sampled {
+		ms := float64(latency) / float64(time.Millisecond)
+		if t.latency == 0 {
+			t.latency = ms
+		} else {
+			t.latency = u.opt.LatencyDecay*ms + (1-u.opt.LatencyDecay)*t.latency
+		}
+		latencyGauge.WithLabelValues(u.name, host).Set(t.latency)
+	}
+
+	if failed {
+		u.onFailure(t)
+		return
+	}
+
+	t.failures = 0
+	if t.state != Closed {
+		logs.Infof("target %s of upstream %s recovered from %s state", host, u.name, t.state)
+		u.setState(t, Closed)
+	}
+
+	if sampled {
+		u.tryEject(t)
+	}
+}
+
+func (u *Upstream) onFailure(t *target) {
+	t.failures++
+
+	switch t.state {
+	case HalfOpen:
+		logs.Warnf("probe target %s of upstream %s failed, open circuit breaker again", t.host, u.name)
+		u.setState(t, Open)
+	case Closed:
+		if t.failures >= u.opt.FailureThreshold {
+			logs.Warnf("target %s of upstream %s failed %d times continuously, open circuit breaker", t.host,
+				u.name, t.failures)
+			u.setState(t, Open)
+		}
+	}
+}
+
+// tryEject ejects the target if its latency is an outlier compared with the other targets.
+func (u *Upstream) tryEject(t *target) {
+	if t.latency < float64(u.opt.OutlierMinLatency)/float64(time.Millisecond) {
+		return
+	}
+
+	now := u.now()
+	ejected := 0
+	others := make([]float64, 0, len(u.targets))
+	for _, one := range u.targets {
+		if now.Before(one.ejectedUntil) {
+			ejected++
+			continue
+		}
+
+		if one.host != t.host && one.state == Closed && one.latency > 0 {
+			others = append(others, one.latency)
+		}
+	}
+
+	if len(others) == 0 || (ejected+1)*100 > len(u.targets)*u.opt.MaxEjectPercent {
+		return
+	}
+
+	sort.Float64s(others)
+	median := others[len(others)/2]
+	if t.latency <= median*u.opt.OutlierFactor {
+		return
+	}
+
+	logs.Warnf("target %s of upstream %s latency %.2fms is outlier compared with median %.2fms, eject it for %s",
+		t.host, u.name, t.latency, median, u.opt.EjectDuration)
+	t.ejectedUntil = now.Add(u.opt.EjectDuration)
+	ejectedGauge.WithLabelValues(u.name, t.host).Set(1)
+	ejectionCounter.WithLabelValues(u.name, t.host).Inc()
+}
+
+// Reset resets the states of the host, all targets are reset if host is empty.
+func (u *Upstream) Reset(host string) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if len(host) != 0 {
+		if _, exists := u.targets[host]; !exists {
+			return errors.New("target not found")
+		}
+	}
+
+	for _, t := range u.targets {
+		if len(host) != 0 && t.host != host {
+			continue
+		}
+
+		t.failures = 0
+		t.latency = 0
+		t.ejectedUntil = time.Time{}
+		u.setState(t, Closed)
+		ejectedGauge.WithLabelValues(u.name, t.host).Set(0)
+	}
+
+	return nil
+}
+
+// TargetStatus defines the circuit breaker status of a target.
+type TargetStatus struct {
+	Host  string `json:"host"`
+	State State  `json:"state"`
+	// Failures 连续失败次数
+	Failures int `json:"failures"`
+	// LatencyMS 延迟的指数加权移动平均值，单位毫秒
+	LatencyMS    float64    `json:"latency_ms"`
+	OpenedAt     *time.Time `json:"opened_at,omitempty"`
+	EjectedUntil *time.Time `json:"ejected_until,omitempty"`
+}
+
+// Status returns the status of all targets sorted by host.
+func (u *Upstream) Status() []TargetStatus {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	now := u.now()
+	list := make([]TargetStatus, 0, len(u.targets))
+	for _, t := range u.targets {
+		status := TargetStatus{Host: t.host, State: t.state, Failures: t.failures, LatencyMS: t.latency}
+		if t.state != Closed {
+			openedAt := t.openedAt
+			status.OpenedAt = &openedAt
+		}
+		if now.Before(t.ejectedUntil) {
+			ejectedUntil := t.ejectedUntil
+			status.EjectedUntil = &ejectedUntil
+		}
+		list = append(list, status)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Host < list[j].Host })
+
+	return list
+}
+
+func (u *Upstream) getTarget(host string) *target {
+	t, exists := u.targets[host]
+	if !exists {
+		t = &target{host: host, state: Closed}
+		u.targets[host] = t
+		stateGauge.WithLabelValues(u.name, host).Set(stateValue(Closed))
+	}
+
+	return t
+}
+
+func (u *Upstream) setState(t *target, state State) {
+	t.state = state
+	t.probeAt = time.Time{}
+	if state == Open {
+		t.openedAt = u.now()
+	}
+	if state == Closed {
+		t.failures = 0
+	}
+	stateGauge.WithLabelValues(u.name, t.host).Set(stateValue(state))
+}
+
+// prune removes the targets which are no longer discovered.
+func (u *Upstream) prune(hosts []string) {
+	if len(u.targets) <= len(hosts) {
+		return
+	}
+
+	hostMap := make(map[string]struct{}, len(hosts))
+	for _, host := range hosts {
+		hostMap[host] = struct{}{}
+	}
+
+	for host := range u.targets {
+		if _, exists := hostMap[host]; !exists {
+			delete(u.targets, host)
+			deleteMetric(u.name, host)
+		}
+	}
+}
+
+// isFailureStatus returns if the status code means the target is unavailable.
+func isFailureStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,142 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package balancer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestUpstream(now *time.Time) *Upstream {
+	u := NewUpstream("test", DefaultOption())
+	u.now = func() time.Time { return *now }
+	return u
+}
+
+func TestUpstream_CircuitBreaker(t *testing.T) {
+	now := time.Now()
+	u := newTestUpstream(&now)
+	hosts := []string{"a", "b"}
+
+	for i := 0; i < u.opt.FailureThreshold; i++ {
+		u.Report("a", time.Millisecond, 0, errors.New("connection refused"))
+	}
+	u.Report("b", time.Millisecond, 200, nil)
+
+	if got := u.Pick(hosts); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("open target should be excluded, got: %v", got)
+	}
+
+	// 熔断时长结束后进入半开状态，只允许一个探测请求
+	now = now.Add(u.opt.OpenDuration)
+	if got := u.Pick(hosts); len(got) != 2 || got[0] != "a" {
+		t.Fatalf("half open target should be probed first, got: %v", got)
+	}
+	if got := u.Pick(hosts); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("half open target should only be probed once, got: %v", got)
+	}
+
+	u.Report("a", time.Millisecond, 503, nil)
+	if state := u.targets["a"].state; state != Open {
+		t.Fatalf("failed probe should open the breaker again, got: %s", state)
+	}
+
+	now = now.Add(u.opt.OpenDuration)
+	u.Pick(hosts)
+	u.Report("a", time.Millisecond, 200, nil)
+	if state := u.targets["a"].state; state != Closed {
+		t.Fatalf("succeeded probe should close the breaker, got: %s", state)
+	}
+}
+
+func TestUpstream_OutlierEjection(t *testing.T) {
+	now := time.Now()
+	u := newTestUpstream(&now)
+	hosts := []string{"a", "b", "c"}
+
+	u.Report("a", 10*time.Millisecond, 200, nil)
+	u.Report("b", 12*time.Millisecond, 200, nil)
+	u.Report("c", 3*time.Second, 200, nil)
+
+	got := u.Pick(hosts)
+	if len(got) != 2 {
+		t.Fatalf("outlier target should be ejected, got: %v", got)
+	}
+	for _, host := range got {
+		if host == "c" {
+			t.Fatalf("outlier target should be ejected, got: %v", got)
+		}
+	}
+
+	now = now.Add(u.opt.EjectDuration)
+	if got := u.Pick(hosts); len(got) != 3 {
+		t.Fatalf("ejected target should be back after eject duration, got: %v", got)
+	}
+}
+
+func TestUpstream_PickAllUnavailable(t *testing.T) {
+	now := time.Now()
+	u := newTestUpstream(&now)
+	hosts := []string{"a", "b"}
+
+	for _, host := range hosts {
+		for i := 0; i < u.opt.FailureThreshold; i++ {
+			u.Report(host, time.Millisecond, 0, errors.New("connection refused"))
+		}
+	}
+
+	if got := u.Pick(hosts); len(got) != len(hosts) {
+		t.Fatalf("all hosts should be returned when none is available, got: %v", got)
+	}
+}
+
+func TestUpstream_LongRunningNotSampled(t *testing.T) {
+	now := time.Now()
+	u := newTestUpstream(&now)
+	hosts := []string{"a", "b", "c"}
+
+	u.Report("a", 10*time.Millisecond, 200, nil)
+	u.Report("b", 12*time.Millisecond, 200, nil)
+	u.Report("c", 11*time.Millisecond, 200, nil)
+	// 长耗时请求不计入延迟，不会导致节点被摘除
+	for i := 0; i < 3; i++ {
+		u.Report("c", u.opt.LatencySampleMax+time.Second, 200, nil)
+	}
+
+	if got := u.Pick(hosts); len(got) != 3 {
+		t.Fatalf("long running requests should not eject target, got: %v", got)
+	}
+	if latency := u.targets["c"].latency; latency != 11 {
+		t.Fatalf("long running requests should not be sampled, latency: %v", latency)
+	}
+}
+
+func TestOption_WithDefault(t *testing.T) {
+	opt := Option{OutlierFactor: 10, LatencyDecay: 2, MaxEjectPercent: 120}.withDefault()
+	def := DefaultOption()
+	if opt.OutlierFactor != 10 {
+		t.Errorf("configured outlier factor should be kept, got: %v", opt.OutlierFactor)
+	}
+	if opt.LatencyDecay != def.LatencyDecay || opt.MaxEjectPercent != def.MaxEjectPercent ||
+		opt.OutlierMinLatency != def.OutlierMinLatency || opt.LatencySampleMax != def.LatencySampleMax {
+		t.Errorf("invalid and zero fields should use default values, got: %+v", opt)
+	}
+}
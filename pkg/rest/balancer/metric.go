@@ -0,0 +1,101 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package balancer
+
+import (
+	"sync"
+
+	"hcm/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const subSys = "upstream"
+
+var (
+	metricOnce sync.Once
+
+	// stateGauge record the circuit breaker state of target, 0: closed, 1: half open, 2: open.
+	stateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subSys,
+		Name:      "circuit_breaker_state",
+		Help:      "the circuit breaker state of the upstream target, 0: closed, 1: half open, 2: open",
+	}, []string{"upstream", "target"})
+
+	// ejectedGauge record if the target is ejected as outlier, 1 means ejected.
+	ejectedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subSys,
+		Name:      "ejected",
+		Help:      "whether the upstream target is ejected as latency outlier, 1 means ejected",
+	}, []string{"upstream", "target"})
+
+	// latencyGauge record the exponentially weighted moving average latency of target.
+	latencyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subSys,
+		Name:      "latency_ewma_milliseconds",
+		Help:      "the exponentially weighted moving average latency(milliseconds) of the upstream target",
+	}, []string{"upstream", "target"})
+
+	// requestCounter record the request count of target by result.
+	requestCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subSys,
+		Name:      "request_total",
+		Help:      "the total request count to the upstream target by result",
+	}, []string{"upstream", "target", "result"})
+
+	// ejectionCounter record the outlier ejection count of target.
+	ejectionCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subSys,
+		Name:      "ejection_total",
+		Help:      "the total outlier ejection count of the upstream target",
+	}, []string{"upstream", "target"})
+)
+
+// initMetric register metrics, it must be called after metrics register is initialized.
+func initMetric() {
+	metricOnce.Do(func() {
+		metrics.Register().MustRegister(stateGauge, ejectedGauge, latencyGauge, requestCounter, ejectionCounter)
+	})
+}
+
+func stateValue(state State) float64 {
+	switch state {
+	case HalfOpen:
+		return 1
+	case Open:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func deleteMetric(upstream, target string) {
+	labels := prometheus.Labels{"upstream": upstream, "target": target}
+	stateGauge.Delete(labels)
+	ejectedGauge.Delete(labels)
+	latencyGauge.Delete(labels)
+	ejectionCounter.Delete(labels)
+	requestCounter.DeletePartialMatch(labels)
+}
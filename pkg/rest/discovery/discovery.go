@@ -22,6 +22,7 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	"hcm/pkg/cc"
 )
@@ -32,6 +33,13 @@ type Interface interface {
 	GetServers() ([]string, error)
 }
 
+// Reporter is an optional interface of discovery, if implemented, the result of each request to the server is
+// reported, it is used by circuit breaking and load balancing.
+type Reporter interface {
+	// Report 上报请求节点的调用结果，err不为空时表示请求未收到响应
+	Report(host string, latency time.Duration, statusCode int, err error)
+}
+
 // DeniedServers are virtual servers instance which is used to deny
 // access to illegal services.
 func DeniedServers(nm cc.Name) Interface {
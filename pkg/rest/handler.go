@@ -34,6 +34,7 @@ import (
 	"hcm/pkg/criteria/errf"
 	"hcm/pkg/kit"
 	"hcm/pkg/logs"
+	"hcm/pkg/tracing"
 
 	"github.com/emicklei/go-restful/v3"
 	prm "github.com/prometheus/client_golang/prometheus"
@@ -127,9 +128,16 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 		cts.Request = req
 		cts.resp = resp
 
-		kt, err := kit.FromHeader(req.Request.Context(), req.Request.Header)
+		rid := req.Request.Header.Get(constant.RidKey)
+		ctx, span := tracing.StartServer(req.Request.Context(), action.Alias, req.Request.Header, rid)
+		var spanErr error
+		defer func() {
+			tracing.End(span, spanErr)
+		}()
+
+		kt, err := kit.FromHeader(ctx, req.Request.Header)
 		if err != nil {
-			rid := req.Request.Header.Get(constant.RidKey)
+			spanErr = err
 			logs.Errorf("invalid request for %s, err: %v, rid: %s", action.Alias, err, rid)
 			cts.WithStatusCode(http.StatusBadRequest)
 			cts.respError(err)
@@ -139,7 +147,8 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 
 		defer func() {
 			if fatalErr := recover(); fatalErr != nil {
-				cts.respError(fmt.Errorf("panic err: %v", fatalErr))
+				spanErr = fmt.Errorf("panic err: %v", fatalErr)
+				cts.respError(spanErr)
 				logs.Errorf("[hcm server panic], err: %v, rid: %s, debug strace: %s", fatalErr, kt.Rid, debug.Stack())
 				logs.CloseLogs()
 			}
@@ -153,6 +162,7 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 
 			byt, err := ioutil.ReadAll(req.Request.Body)
 			if err != nil {
+				spanErr = err
 				logs.Errorf("restful request %s peek failed, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
 
 				cts.WithStatusCode(http.StatusBadRequest)
@@ -174,6 +184,7 @@ func (r *Handler) wrapperAction(action *action) func(req *restful.Request, resp
 		start := time.Now()
 		reply, err := action.Handler(cts)
 		if err != nil {
+			spanErr = err
 			if logs.V(2) {
 				logs.Errorf("do restful request %s failed, err: %v, rid: %s", action.Alias, err, cts.Kit.Rid)
 			}
@@ -0,0 +1,309 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/uuid"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	etcd3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	etcdTimeout = 3 * time.Second
+	// maxTxnOps is the max operations in one etcd txn, which is the default --max-txn-ops of etcd server.
+	maxTxnOps = 128
+	// idleRefreshFactor 本节点计数没有变化时，间隔该倍数的同步周期才从etcd刷新其他节点的计数
+	idleRefreshFactor = 5
+	// leaseMarginSec is the extra ttl seconds of counter lease after the window ends.
+	leaseMarginSec = 10
+)
+
+// KeyPrefix returns the etcd key prefix of service's rate limit counters.
+func KeyPrefix(serviceName cc.Name) string {
+	return fmt.Sprintf("/hcm/ratelimit/%s/", serviceName)
+}
+
+var _ Store = new(etcdStore)
+
+// NewEtcdStore create a counter store shared by all nodes through etcd. requests are counted in memory, and each
+// node writes its own count of every counter to etcd periodically, the changed counters are written in batch txns
+// and the counts of all nodes are refreshed by one prefix range request, so the etcd requests of each sync do not
+// grow with the number of counters. the limit is approximate within the sync interval, it's more suitable for rules
+// with longer windows such as per minute or daily quota. counters are written frequently when the rate limit is
+// busy, it's recommended to use an etcd cluster separated from service discovery in large deployments.
+func NewEtcdStore(svc cc.Service, interval time.Duration) (Store, error) {
+	etcdOpt, err := svc.Etcd.ToConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get etcd config failed, err: %v", err)
+	}
+
+	cli, err := etcd3.New(etcdOpt)
+	if err != nil {
+		return nil, fmt.Errorf("new etcd client failed, err: %v", err)
+	}
+
+	s := &etcdStore{
+		cli:      cli,
+		prefix:   KeyPrefix(cc.ServiceName()),
+		node:     uuid.UUID(),
+		interval: interval,
+		counters: make(map[string]*etcdCounter),
+		leases:   make(map[int64]etcd3.LeaseID),
+		now:      time.Now,
+	}
+
+	go s.syncLoop()
+	return s, nil
+}
+
+type etcdStore struct {
+	cli    *etcd3.Client
+	prefix string
+	// node is the unique id of current node, each node writes its own count to the counter key suffixed with it.
+	node     string
+	interval time.Duration
+	lock     sync.Mutex
+	counters map[string]*etcdCounter
+	// refreshedAt is the last time that the counts of other nodes are refreshed, only accessed by sync loop.
+	refreshedAt time.Time
+	// leases is the leases of counters keyed by window end unix time, only accessed by sync loop.
+	leases map[int64]etcd3.LeaseID
+	now    func() time.Time
+}
+
+type etcdCounter struct {
+	expireAt time.Time
+	// others is the count of other nodes when last refreshed.
+	others uint64
+	// local is the count of current node to be written to etcd.
+	local int64
+	// dirty means local count is not written to etcd yet.
+	dirty bool
+	// pending is the local count not added to local yet.
+	pending int64
+}
+
+// count returns the estimated count of all nodes.
+func (c *etcdCounter) count() int64 {
+	return int64(c.others) + c.local + c.pending
+}
+
+// Take one request from the counter if the estimated count of all nodes is less than the limit.
+func (s *etcdStore) Take(c Counter, limit uint) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := s.etcdKey(c)
+	counter, exists := s.counters[key]
+	if !exists {
+		counter = &etcdCounter{expireAt: c.ExpireAt}
+		s.counters[key] = counter
+	}
+
+	if counter.count() >= int64(limit) {
+		return false
+	}
+
+	counter.pending++
+	return true
+}
+
+// Return one request taken from the counter.
+func (s *etcdStore) Return(c Counter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if counter, exists := s.counters[s.etcdKey(c)]; exists {
+		counter.pending--
+	}
+}
+
+func (s *etcdStore) etcdKey(c Counter) string {
+	return fmt.Sprintf("%s%s/%d", s.prefix, c.Key, c.Window)
+}
+
+func (s *etcdStore) syncLoop() {
+	for {
+		time.Sleep(s.interval)
+		s.sync()
+	}
+}
+
+type counterPut struct {
+	key      string
+	expireAt time.Time
+	value    int64
+}
+
+// sync writes the changed local counts to etcd and refreshes the counts of other nodes. refreshing is skipped
+// when none of the local counts changes and the counts are refreshed recently.
+func (s *etcdStore) sync() {
+	now := s.now()
+
+	s.lock.Lock()
+	puts := make([]counterPut, 0)
+	for key, counter := range s.counters {
+		if now.After(counter.expireAt) {
+			delete(s.counters, key)
+			continue
+		}
+
+		if counter.pending != 0 {
+			counter.local += counter.pending
+			if counter.local < 0 {
+				counter.local = 0
+			}
+			counter.pending, counter.dirty = 0, true
+		}
+		if counter.dirty {
+			puts = append(puts, counterPut{key: key, expireAt: counter.expireAt, value: counter.local})
+		}
+	}
+	refresh := len(s.counters) != 0 &&
+		(len(puts) != 0 || now.Sub(s.refreshedAt) >= s.interval*idleRefreshFactor)
+	s.lock.Unlock()
+
+	// 写入失败的计数保持dirty，下次同步时重新写入，写入的是本节点的累计值，重复写入不会重复计数
+	if err := s.put(puts); err != nil {
+		logs.Errorf("put %d rate limit counters to etcd failed, err: %v", len(puts), err)
+	} else {
+		s.lock.Lock()
+		for _, put := range puts {
+			if counter, exists := s.counters[put.key]; exists && counter.local == put.value {
+				counter.dirty = false
+			}
+		}
+		s.lock.Unlock()
+	}
+
+	if refresh {
+		if counts, err := s.refresh(); err != nil {
+			logs.Errorf("refresh rate limit counters from etcd failed, err: %v", err)
+		} else {
+			s.lock.Lock()
+			for key, counter := range s.counters {
+				counter.others = counts[key]
+			}
+			s.lock.Unlock()
+			s.refreshedAt = now
+		}
+	}
+
+	for expireAt := range s.leases {
+		if now.Unix() > expireAt {
+			delete(s.leases, expireAt)
+		}
+	}
+}
+
+// put writes the local counts to the node keys of counters in batch txns.
+func (s *etcdStore) put(puts []counterPut) error {
+	if len(puts) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), etcdTimeout)
+	defer cancel()
+
+	for start := 0; start < len(puts); start += maxTxnOps {
+		end := start + maxTxnOps
+		if end > len(puts) {
+			end = len(puts)
+		}
+
+		ops := make([]etcd3.Op, 0, end-start)
+		for _, put := range puts[start:end] {
+			leaseID, err := s.lease(ctx, put.expireAt)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, etcd3.OpPut(put.key+"/"+s.node, strconv.FormatInt(put.value, 10),
+				etcd3.WithLease(leaseID)))
+		}
+
+		if _, err := s.cli.Txn(ctx).Then(ops...).Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refresh gets the counts of all nodes by one prefix range request, returns the counts of other nodes keyed by
+// counter key.
+func (s *etcdStore) refresh() (map[string]uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), etcdTimeout)
+	defer cancel()
+
+	resp, err := s.cli.Get(ctx, s.prefix, etcd3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	return sumOtherNodes(resp.Kvs, s.node), nil
+}
+
+// sumOtherNodes sums the counts of nodes other than current node by counter key, node keys with invalid format or
+// value are ignored.
+func sumOtherNodes(kvs []*mvccpb.KeyValue, node string) map[string]uint64 {
+	counts := make(map[string]uint64)
+	for _, kv := range kvs {
+		key := string(kv.Key)
+		idx := strings.LastIndex(key, "/")
+		if idx < 0 || key[idx+1:] == node {
+			continue
+		}
+
+		count, err := strconv.ParseUint(string(kv.Value), 10, 64)
+		if err != nil {
+			logs.Warnf("rate limit counter %s has invalid value %s, skip it", key, kv.Value)
+			continue
+		}
+		counts[key[:idx]] += count
+	}
+
+	return counts
+}
+
+// lease returns the lease of counters whose window ends at expireAt, the lease is shared by these counters.
+func (s *etcdStore) lease(ctx context.Context, expireAt time.Time) (etcd3.LeaseID, error) {
+	if leaseID, exists := s.leases[expireAt.Unix()]; exists {
+		return leaseID, nil
+	}
+
+	ttl := int64(expireAt.Sub(s.now()).Seconds()) + leaseMarginSec
+	resp, err := s.cli.Grant(ctx, ttl)
+	if err != nil {
+		return 0, fmt.Errorf("grant counter lease failed, err: %v", err)
+	}
+
+	s.leases[expireAt.Unix()] = resp.ID
+	return resp.ID, nil
+}
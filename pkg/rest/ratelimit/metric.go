@@ -0,0 +1,55 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"sync"
+
+	"hcm/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	subSys = "rate_limit"
+
+	acceptedResult = "accepted"
+	rejectedResult = "rejected"
+)
+
+var (
+	metricOnce sync.Once
+
+	// requestCounter record the limited request count of each dimension by result, the dimension values are
+	// unbounded, so they are not used as labels, the rejected values are logged instead.
+	requestCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: subSys,
+		Name:      "request_total",
+		Help:      "the total limited request count of the dimension by result, accepted or rejected",
+	}, []string{"dimension", "result"})
+)
+
+// initMetric register metrics, it must be called after metrics register is initialized.
+func initMetric() {
+	metricOnce.Do(func() {
+		metrics.Register().MustRegister(requestCounter)
+	})
+}
@@ -0,0 +1,258 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package ratelimit limits api requests by app code, user and business with fixed window counters, the counters
+// can be stored in each node's memory or shared by all nodes through etcd.
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// Request is the identity of an api request to be limited, the empty dimension is not limited.
+type Request struct {
+	AppCode string
+	User    string
+	Biz     string
+}
+
+// get the value of the dimension.
+func (r Request) get(dimension cc.RateLimitDimension) string {
+	switch dimension {
+	case cc.AppCodeRateLimitDimension:
+		return r.AppCode
+	case cc.UserRateLimitDimension:
+		return r.User
+	case cc.BizRateLimitDimension:
+		return r.Biz
+	default:
+		return ""
+	}
+}
+
+var bizPathRegexp = regexp.MustCompile(`/bizs/(\d+)(/|$)`)
+
+// ParseBiz parse the business id from the api path, returns empty if it's not a business api.
+// e.g: /api/v1/cloud/bizs/100/cvms/list returns 100.
+func ParseBiz(path string) string {
+	matches := bizPathRegexp.FindStringSubmatch(path)
+	if len(matches) < 2 {
+		return ""
+	}
+
+	return matches[1]
+}
+
+// Rejection is the result of a rejected request.
+type Rejection struct {
+	Dimension cc.RateLimitDimension `json:"dimension"`
+	Key       string                `json:"key"`
+	Limit     uint                  `json:"limit"`
+	WindowSec uint                  `json:"window_sec"`
+	// RetryAfter is the duration until the window of the exceeded rule ends.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// Error returns the message of the rejection.
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("too many requests, %s %s exceeded the limit of %d requests per %d seconds", r.Dimension,
+		r.Key, r.Limit, r.WindowSec)
+}
+
+// Limiter limits api requests by the rate limit rules.
+type Limiter struct {
+	rules []cc.RateLimitRule
+	store Store
+	now   func() time.Time
+}
+
+// New create a limiter with the rate limit options, returns nil if rate limit is not enabled.
+func New(opt cc.RateLimit, svc cc.Service) (*Limiter, error) {
+	if !opt.Enable {
+		return nil, nil
+	}
+
+	var store Store
+	switch opt.Store {
+	case cc.LocalRateLimitStore:
+		store = NewLocalStore()
+	case cc.EtcdRateLimitStore:
+		var err error
+		store, err = NewEtcdStore(svc, time.Duration(opt.SyncIntervalMS)*time.Millisecond)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store: %s", opt.Store)
+	}
+
+	initMetric()
+	return NewLimiter(opt.Rules, store), nil
+}
+
+// NewLimiter create a limiter with the rules and counter store.
+func NewLimiter(rules []cc.RateLimitRule, store Store) *Limiter {
+	return &Limiter{
+		rules: rules,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Accept checks whether the request is allowed by all matched rules, returns the rejection if not allowed. the
+// request is counted only when it's allowed, so the rejected requests do not consume the quota.
+func (l *Limiter) Accept(req Request) *Rejection {
+	now := l.now()
+	taken := make([]Counter, 0)
+	limited := make(map[cc.RateLimitDimension]struct{})
+
+	for _, rule := range l.matchRules(req) {
+		key := req.get(rule.Dimension)
+		limited[rule.Dimension] = struct{}{}
+
+		windowSec := int64(rule.WindowSec)
+		window := now.Unix() / windowSec
+		counter := Counter{
+			Key:      fmt.Sprintf("%s/%s/%d", rule.Dimension, key, rule.WindowSec),
+			Window:   window,
+			ExpireAt: time.Unix((window+1)*windowSec, 0),
+		}
+
+		if l.store.Take(counter, rule.Limit) {
+			taken = append(taken, counter)
+			continue
+		}
+
+		// 请求被拒绝时归还其他规则已占用的计数
+		for _, one := range taken {
+			l.store.Return(one)
+		}
+
+		requestCounter.WithLabelValues(string(rule.Dimension), rejectedResult).Inc()
+		return &Rejection{
+			Dimension:  rule.Dimension,
+			Key:        key,
+			Limit:      rule.Limit,
+			WindowSec:  rule.WindowSec,
+			RetryAfter: counter.ExpireAt.Sub(now),
+		}
+	}
+
+	for dimension := range limited {
+		requestCounter.WithLabelValues(string(dimension), acceptedResult).Inc()
+	}
+
+	return nil
+}
+
+// matchRules returns the rules that the request matches, the rules with keys matched take precedence over the rules
+// without keys of the same dimension.
+func (l *Limiter) matchRules(req Request) []cc.RateLimitRule {
+	specific := make(map[cc.RateLimitDimension][]cc.RateLimitRule)
+	general := make(map[cc.RateLimitDimension][]cc.RateLimitRule)
+
+	for _, rule := range l.rules {
+		key := req.get(rule.Dimension)
+		if len(key) == 0 {
+			continue
+		}
+
+		if len(rule.Keys) == 0 {
+			general[rule.Dimension] = append(general[rule.Dimension], rule)
+			continue
+		}
+
+		for _, one := range rule.Keys {
+			if one == key {
+				specific[rule.Dimension] = append(specific[rule.Dimension], rule)
+				break
+			}
+		}
+	}
+
+	matched := make([]cc.RateLimitRule, 0)
+	for _, dimension := range []cc.RateLimitDimension{cc.AppCodeRateLimitDimension, cc.UserRateLimitDimension,
+		cc.BizRateLimitDimension} {
+
+		if len(specific[dimension]) != 0 {
+			matched = append(matched, specific[dimension]...)
+			continue
+		}
+		matched = append(matched, general[dimension]...)
+	}
+
+	return matched
+}
+
+// RestFilter returns the restful filter that limits requests by the app code and user in header and the business
+// in path, it should be used after the request header is parsed. the requests that have been limited by one of the
+// trusted upstream services are not limited again, so that the forwarded requests are counted only once.
+func (l *Limiter) RestFilter(trustedUpstreams ...cc.Name) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		r := req.Request
+		if limitedBy := r.Header.Get(constant.RateLimitedByKey); len(limitedBy) != 0 {
+			for _, one := range trustedUpstreams {
+				if cc.Name(limitedBy) == one {
+					chain.ProcessFilter(req, resp)
+					return
+				}
+			}
+		}
+
+		limitReq := Request{
+			AppCode: r.Header.Get(constant.AppCodeKey),
+			User:    r.Header.Get(constant.UserKey),
+			Biz:     ParseBiz(r.URL.Path),
+		}
+
+		if rejection := l.Accept(limitReq); rejection != nil {
+			logs.Warnf("request is rate limited, dimension: %s, key: %s, limit: %d/%ds, uri: %s, rid: %s",
+				rejection.Dimension, rejection.Key, rejection.Limit, rejection.WindowSec, r.RequestURI,
+				r.Header.Get(constant.RidKey))
+			WriteRejection(resp.ResponseWriter, rejection)
+			return
+		}
+
+		chain.ProcessFilter(req, resp)
+	}
+}
+
+// WriteRejection writes the standard too many requests response of the rejection.
+func WriteRejection(w http.ResponseWriter, rejection *Rejection) {
+	retryAfter := int64(rejection.RetryAfter.Seconds())
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	w.WriteHeader(http.StatusTooManyRequests)
+	rest.WriteResp(w, &rest.Response{Code: errf.TooManyRequest, Message: rejection.Error(), Data: rejection})
+}
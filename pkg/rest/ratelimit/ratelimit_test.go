@@ -0,0 +1,188 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/criteria/constant"
+
+	"github.com/emicklei/go-restful/v3"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func newTestLimiter(now *time.Time, rules []cc.RateLimitRule) *Limiter {
+	l := NewLimiter(rules, NewLocalStore())
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLimiter_Accept(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(&now, []cc.RateLimitRule{
+		{Dimension: cc.UserRateLimitDimension, Limit: 2, WindowSec: 1},
+		{Dimension: cc.UserRateLimitDimension, Keys: []string{"admin"}, Limit: 5, WindowSec: 1},
+		{Dimension: cc.BizRateLimitDimension, Limit: 3, WindowSec: 60},
+	})
+
+	for i := 0; i < 2; i++ {
+		if rejection := l.Accept(Request{User: "tom"}); rejection != nil {
+			t.Fatalf("request %d should be accepted, got: %v", i, rejection)
+		}
+	}
+	rejection := l.Accept(Request{User: "tom"})
+	if rejection == nil || rejection.Dimension != cc.UserRateLimitDimension || rejection.Key != "tom" {
+		t.Fatalf("request exceeded user limit should be rejected, got: %v", rejection)
+	}
+
+	// 指定取值的规则优先于同维度的通用规则
+	for i := 0; i < 5; i++ {
+		if rejection := l.Accept(Request{User: "admin"}); rejection != nil {
+			t.Fatalf("admin request %d should be accepted, got: %v", i, rejection)
+		}
+	}
+	if rejection := l.Accept(Request{User: "admin"}); rejection == nil {
+		t.Fatalf("admin request exceeded specific limit should be rejected")
+	}
+
+	// 新的时间窗口重新计数
+	now = now.Add(time.Second)
+	if rejection := l.Accept(Request{User: "tom"}); rejection != nil {
+		t.Fatalf("request in new window should be accepted, got: %v", rejection)
+	}
+}
+
+func TestLimiter_RejectNotConsumeQuota(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(&now, []cc.RateLimitRule{
+		{Dimension: cc.AppCodeRateLimitDimension, Limit: 10, WindowSec: 86400},
+		{Dimension: cc.UserRateLimitDimension, Limit: 1, WindowSec: 1},
+	})
+
+	if rejection := l.Accept(Request{AppCode: "app", User: "tom"}); rejection != nil {
+		t.Fatalf("first request should be accepted, got: %v", rejection)
+	}
+
+	// 被用户规则拒绝的请求不占用应用的每日配额
+	for i := 0; i < 20; i++ {
+		if rejection := l.Accept(Request{AppCode: "app", User: "tom"}); rejection == nil {
+			t.Fatalf("request exceeded user limit should be rejected")
+		}
+	}
+
+	for i := 0; i < 9; i++ {
+		if rejection := l.Accept(Request{AppCode: "app", User: "user" + string(rune('a'+i))}); rejection != nil {
+			t.Fatalf("request %d should be accepted by app quota, got: %v", i, rejection)
+		}
+	}
+
+	rejection := l.Accept(Request{AppCode: "app", User: "jerry"})
+	if rejection == nil || rejection.Dimension != cc.AppCodeRateLimitDimension {
+		t.Fatalf("request exceeded app quota should be rejected, got: %v", rejection)
+	}
+	if rejection.RetryAfter <= 0 || rejection.RetryAfter > 86400*time.Second {
+		t.Fatalf("retry after should be the rest of the window, got: %v", rejection.RetryAfter)
+	}
+}
+
+func TestLimiter_RestFilterTrustedUpstream(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(&now, []cc.RateLimitRule{{Dimension: cc.UserRateLimitDimension, Limit: 1, WindowSec: 1}})
+
+	ws := new(restful.WebService)
+	ws.Filter(l.RestFilter(cc.APIServerName))
+	ws.Route(ws.GET("/api/v1/cloud/cvms").To(func(req *restful.Request, resp *restful.Response) {
+		resp.WriteHeader(http.StatusOK)
+	}))
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	do := func(limitedBy string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/cloud/cvms", nil)
+		req.Header.Set(constant.UserKey, "tom")
+		if len(limitedBy) != 0 {
+			req.Header.Set(constant.RateLimitedByKey, limitedBy)
+		}
+		w := httptest.NewRecorder()
+		container.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := do(""); code != http.StatusOK {
+		t.Fatalf("first request should be accepted, got: %d", code)
+	}
+	if code := do(""); code != http.StatusTooManyRequests {
+		t.Fatalf("request exceeded user limit should be rejected, got: %d", code)
+	}
+
+	// 已在api-server限流的请求不再重复计数
+	for i := 0; i < 3; i++ {
+		if code := do(string(cc.APIServerName)); code != http.StatusOK {
+			t.Fatalf("request limited by api-server should not be limited again, got: %d", code)
+		}
+	}
+
+	// 非受信上游的标记不生效
+	if code := do(string(cc.CloudServerName)); code != http.StatusTooManyRequests {
+		t.Fatalf("request limited by untrusted upstream should be limited, got: %d", code)
+	}
+}
+
+func TestParseBiz(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/cloud/bizs/100/cvms/list": "100",
+		"/api/v1/cloud/bizs/100":           "100",
+		"/api/v1/cloud/cvms/list":          "",
+		"/api/v1/cloud/bizs/list":          "",
+	}
+
+	for path, expected := range cases {
+		if got := ParseBiz(path); got != expected {
+			t.Errorf("parse biz of %s, expected: %s, got: %s", path, expected, got)
+		}
+	}
+}
+
+func TestSumOtherNodes(t *testing.T) {
+	prefix := KeyPrefix("api-server")
+	kvs := []*mvccpb.KeyValue{
+		{Key: []byte(prefix + "user:a/100/node-1"), Value: []byte("3")},
+		{Key: []byte(prefix + "user:a/100/node-2"), Value: []byte("4")},
+		{Key: []byte(prefix + "user:a/100/self"), Value: []byte("10")},
+		{Key: []byte(prefix + "biz:2/100/node-1"), Value: []byte("1")},
+		{Key: []byte(prefix + "biz:2/100/node-2"), Value: []byte("invalid")},
+	}
+
+	counts := sumOtherNodes(kvs, "self")
+	if len(counts) != 2 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+	// 本节点的计数以本地为准，不从etcd累加
+	if counts[prefix+"user:a/100"] != 7 {
+		t.Errorf("user counter should sum other nodes, got: %d", counts[prefix+"user:a/100"])
+	}
+	if counts[prefix+"biz:2/100"] != 1 {
+		t.Errorf("invalid value should be skipped, got: %d", counts[prefix+"biz:2/100"])
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Counter is the request counter of a key in a fixed time window.
+type Counter struct {
+	// Key is the counter key, which is composed of the dimension, dimension value and window seconds.
+	Key string
+	// Window is the index of the fixed time window since unix epoch.
+	Window int64
+	// ExpireAt is the end time of the window, the counter can be dropped after it.
+	ExpireAt time.Time
+}
+
+// Store is the counter store of rate limit.
+type Store interface {
+	// Take takes one request from the counter if its count is less than the limit, returns whether it's taken.
+	Take(c Counter, limit uint) bool
+	// Return returns one request taken from the counter, used when the request is rejected by other rules.
+	Return(c Counter)
+}
+
+// gcInterval is the interval of dropping expired counters.
+const gcInterval = time.Minute
+
+var _ Store = new(localStore)
+
+// NewLocalStore create a counter store in memory, the counters are not shared with other nodes.
+func NewLocalStore() Store {
+	return &localStore{
+		counters: make(map[string]*localCounter),
+		lastGC:   time.Now(),
+		now:      time.Now,
+	}
+}
+
+type localStore struct {
+	lock     sync.Mutex
+	counters map[string]*localCounter
+	lastGC   time.Time
+	now      func() time.Time
+}
+
+type localCounter struct {
+	window   int64
+	expireAt time.Time
+	count    uint
+}
+
+// Take one request from the counter if its count is less than the limit.
+func (s *localStore) Take(c Counter, limit uint) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tryGC()
+
+	counter, exists := s.counters[c.Key]
+	if !exists || counter.window != c.Window {
+		counter = &localCounter{window: c.Window, expireAt: c.ExpireAt}
+		s.counters[c.Key] = counter
+	}
+
+	if counter.count >= limit {
+		return false
+	}
+
+	counter.count++
+	return true
+}
+
+// Return one request taken from the counter.
+func (s *localStore) Return(c Counter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	counter, exists := s.counters[c.Key]
+	if !exists || counter.window != c.Window || counter.count == 0 {
+		return
+	}
+
+	counter.count--
+}
+
+// tryGC drops the expired counters periodically, it must be called with lock held.
+func (s *localStore) tryGC() {
+	now := s.now()
+	if now.Sub(s.lastGC) < gcInterval {
+		return
+	}
+	s.lastGC = now
+
+	for key, counter := range s.counters {
+		if now.After(counter.expireAt) {
+			delete(s.counters, key)
+		}
+	}
+}
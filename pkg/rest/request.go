@@ -40,8 +40,11 @@ import (
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/logs"
 	"hcm/pkg/rest/client"
+	"hcm/pkg/rest/discovery"
+	"hcm/pkg/tracing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
 // VerbType http request verb type
@@ -362,11 +365,22 @@ func (r *Request) Do() *Result {
 		return result
 	}
 
+	// 每个请求一个客户端 span，重试都在该 span 内，链路上下文在 getRequest 中注入到请求头。
+	// span 名称只使用请求方法和服务的 baseURL 以控制基数，具体路径记录在属性中
+	ctx, span := tracing.StartClient(r.ctx, string(r.verb)+" "+r.baseURL, nil,
+		semconv.HTTPRequestMethodKey.String(string(r.verb)), semconv.HTTPRoute(r.baseURL+r.subPath),
+		semconv.URLPath(r.WrapURL().Path))
+	r.ctx = ctx
+	defer func() {
+		tracing.End(span, result.Err)
+	}()
+
 	maxRetryCycle := 3
 	for try := 0; try < maxRetryCycle; try++ {
 		for index, host := range hosts {
-			result, isComplete := r.doWithHost(client, host, try+index, rid)
+			res, isComplete := r.doWithHost(client, host, try+index, rid)
 			if isComplete {
+				result = res
 				return result
 			}
 		}
@@ -400,6 +414,7 @@ func (r *Request) doWithHost(client client.HTTPClient, host string, retries int,
 
 	start := time.Now()
 	resp, err := client.Do(req)
+	r.report(host, time.Since(start), resp, err)
 	if err != nil {
 		// "Connection reset by peer" is a special err which in most scenario is a transient error.
 		// Which means that we can retry it. And so does the GET operation.
@@ -459,6 +474,20 @@ func (r *Request) doWithHost(client client.HTTPClient, host string, retries int,
 	}, true
 }
 
+// report reports the request result of host to discovery if it supports.
+func (r *Request) report(host string, latency time.Duration, resp *http.Response, err error) {
+	reporter, ok := r.capability.Discover.(discovery.Reporter)
+	if !ok {
+		return
+	}
+
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	reporter.Report(host, latency, statusCode, err)
+}
+
 func (r *Request) getRequest(url string, contentType ContentType) (*http.Request, error) {
 	req, err := http.NewRequest(string(r.verb), url, bytes.NewReader(r.body))
 	if err != nil {
@@ -474,6 +503,7 @@ func (r *Request) getRequest(url string, contentType ContentType) (*http.Request
 		req.Header = make(http.Header)
 	}
 
+	tracing.Inject(r.ctx, req.Header)
 	req.Header.Del("Accept-Encoding")
 	req.Header.Set("Content-Type", string(contentType))
 	req.Header.Set("Accept", "application/json")
@@ -0,0 +1,116 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+	"hcm/pkg/rest/balancer"
+)
+
+// WithGetCircuitBreaker init and returns the getting circuit breaker states of upstream targets command.
+func WithGetCircuitBreaker() Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "get-circuit-breaker",
+			Usage: "get circuit breaker, outlier ejection and latency states of upstream service targets",
+			Parameters: []Parameter{{
+				Name:  "upstream",
+				Usage: "defines the upstream service name to get, eg. \"data-service\", get all if not set",
+				Value: new(string),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				name := ""
+				if val, exists := params["upstream"]; exists {
+					name = *val.(*string)
+				}
+
+				result := make(map[string][]balancer.TargetStatus)
+				for _, upstream := range balancer.ListUpstream() {
+					if len(name) != 0 && upstream.Name() != name {
+						continue
+					}
+					result[upstream.Name()] = upstream.Status()
+				}
+
+				return result, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithResetCircuitBreaker init and returns the resetting circuit breaker states of upstream targets command.
+func WithResetCircuitBreaker() Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "reset-circuit-breaker",
+			Usage: "reset circuit breaker, outlier ejection and latency states of upstream service targets",
+			Parameters: []Parameter{{
+				Name:  "upstream",
+				Usage: "defines the upstream service name to reset, eg. \"data-service\"",
+				Value: new(string),
+			}, {
+				Name:  "target",
+				Usage: "defines the target host to reset, eg. \"http://127.0.0.1:9600\", reset all if not set",
+				Value: new(string),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				val, exists := params["upstream"]
+				if !exists || len(*val.(*string)) == 0 {
+					return nil, errf.New(errf.InvalidParameter, "upstream is not set")
+				}
+				name := *val.(*string)
+
+				host := ""
+				if val, exists := params["target"]; exists {
+					host = *val.(*string)
+				}
+
+				for _, upstream := range balancer.ListUpstream() {
+					if upstream.Name() != name {
+						continue
+					}
+
+					if err := upstream.Reset(host); err != nil {
+						return nil, errf.NewFromErr(errf.InvalidParameter, err)
+					}
+
+					logs.Infof("successfully reset circuit breaker of upstream %s, target: %s, rid: %s", name, host,
+						kt.Rid)
+					return nil, nil
+				}
+
+				return nil, errf.Newf(errf.InvalidParameter, "upstream %s not found", name)
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithCircuitBreaker init and returns the circuit breaker commands that servers calling other services needed.
+func WithCircuitBreaker() []Cmd {
+	return []Cmd{WithGetCircuitBreaker(), WithResetCircuitBreaker()}
+}
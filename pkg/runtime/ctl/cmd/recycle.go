@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// RecycleController defines the operations to control the recycle timer at runtime.
+type RecycleController interface {
+	// Pause the recycle timer, expired recycle records are neither destroyed nor notified until resumed.
+	Pause() error
+	// Resume the paused recycle timer.
+	Resume() error
+	// IsPaused returns whether the recycle timer is paused.
+	IsPaused() bool
+}
+
+// WithPauseRecycle init and returns the pausing recycle timer command.
+func WithPauseRecycle(ctrl RecycleController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:    "pause-recycle",
+			Usage:   "pause the recycle timer which destroys expired recycled resources and sends expire notices",
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				if err := ctrl.Pause(); err != nil {
+					logs.Errorf("pause recycle timer failed, err: %v, rid: %s", err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully paused recycle timer, rid: %s", kt.Rid)
+				return nil, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithResumeRecycle init and returns the resuming recycle timer command.
+func WithResumeRecycle(ctrl RecycleController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:    "resume-recycle",
+			Usage:   "resume the paused recycle timer",
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				if err := ctrl.Resume(); err != nil {
+					logs.Errorf("resume recycle timer failed, err: %v, rid: %s", err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully resumed recycle timer, rid: %s", kt.Rid)
+				return nil, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithGetRecycle init and returns the getting recycle timer state command.
+func WithGetRecycle(ctrl RecycleController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:    "get-recycle",
+			Usage:   "get whether the recycle timer is paused",
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				return map[string]interface{}{"paused": ctrl.IsPaused()}, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithRecycle init and returns the recycle timer control commands.
+func WithRecycle(ctrl RecycleController) []Cmd {
+	return []Cmd{WithPauseRecycle(ctrl), WithResumeRecycle(ctrl), WithGetRecycle(ctrl)}
+}
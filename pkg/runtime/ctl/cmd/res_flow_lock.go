@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ResFlowLockReleaser defines the operation to force release the resource flow lock.
+type ResFlowLockReleaser interface {
+	// ReleaseResFlowLock force release the flow lock of the resource, returns the flow id which owned the lock.
+	ReleaseResFlowLock(kt *kit.Kit, resType enumor.CloudResourceType, resID string) (string, error)
+}
+
+// WithReleaseResFlowLock init and returns the force releasing resource flow lock command.
+func WithReleaseResFlowLock(releaser ResFlowLockReleaser) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name: "release-res-flow-lock",
+			Usage: "force release the flow lock of the resource which is left locked by an abnormal flow, " +
+				"the flow owned the lock is not canceled",
+			Parameters: []Parameter{{
+				Name:  "res_type",
+				Usage: "defines the locked resource type, eg. \"load_balancer\"",
+				Value: new(enumor.CloudResourceType),
+			}, {
+				Name:  "res_id",
+				Usage: "defines the locked resource id, eg. \"00000001\"",
+				Value: new(string),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				typeVal, exists := params["res_type"]
+				if !exists || len(*typeVal.(*enumor.CloudResourceType)) == 0 {
+					return nil, errf.New(errf.InvalidParameter, "res_type is not set")
+				}
+				resType := *typeVal.(*enumor.CloudResourceType)
+
+				idVal, exists := params["res_id"]
+				if !exists || len(*idVal.(*string)) == 0 {
+					return nil, errf.New(errf.InvalidParameter, "res_id is not set")
+				}
+				resID := *idVal.(*string)
+
+				flowID, err := releaser.ReleaseResFlowLock(kt, resType, resID)
+				if err != nil {
+					logs.Errorf("release %s(%s) flow lock failed, err: %v, rid: %s", resType, resID, err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully released %s(%s) flow lock owned by flow %s, rid: %s", resType, resID,
+					flowID, kt.Rid)
+				return map[string]string{"flow_id": flowID}, nil
+			},
+		},
+	}
+
+	return cmd
+}
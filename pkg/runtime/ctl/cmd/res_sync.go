@@ -0,0 +1,171 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package cmd
+
+import (
+	"hcm/pkg/criteria/enumor"
+	"hcm/pkg/criteria/errf"
+	"hcm/pkg/kit"
+	"hcm/pkg/logs"
+)
+
+// ResSyncController defines the operations to control the periodic cloud resource sync at runtime.
+type ResSyncController interface {
+	// PauseVendor pause the periodic sync of the vendor, the sync in progress is not affected.
+	PauseVendor(vendor enumor.Vendor) error
+	// ResumeVendor resume the periodic sync of the vendor.
+	ResumeVendor(vendor enumor.Vendor) error
+	// PausedVendors returns the vendors whose periodic sync is paused.
+	PausedVendors() []enumor.Vendor
+	// SyncAccount trigger an immediate sync of all resources of the account asynchronously.
+	SyncAccount(kt *kit.Kit, accountID string) error
+}
+
+// WithPauseResSync init and returns the pausing periodic resource sync of vendor command.
+func WithPauseResSync(ctrl ResSyncController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "pause-res-sync",
+			Usage: "pause the periodic cloud resource sync of the vendor",
+			Parameters: []Parameter{{
+				Name:  "vendor",
+				Usage: "defines the vendor to pause the periodic resource sync, eg. \"tcloud\"",
+				Value: new(enumor.Vendor),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				vendor, err := getVendorParam(params)
+				if err != nil {
+					return nil, err
+				}
+
+				if err = ctrl.PauseVendor(vendor); err != nil {
+					logs.Errorf("pause %s periodic resource sync failed, err: %v, rid: %s", vendor, err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully paused %s periodic resource sync, rid: %s", vendor, kt.Rid)
+				return nil, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithResumeResSync init and returns the resuming periodic resource sync of vendor command.
+func WithResumeResSync(ctrl ResSyncController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "resume-res-sync",
+			Usage: "resume the paused periodic cloud resource sync of the vendor",
+			Parameters: []Parameter{{
+				Name:  "vendor",
+				Usage: "defines the vendor to resume the periodic resource sync, eg. \"tcloud\"",
+				Value: new(enumor.Vendor),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				vendor, err := getVendorParam(params)
+				if err != nil {
+					return nil, err
+				}
+
+				if err = ctrl.ResumeVendor(vendor); err != nil {
+					logs.Errorf("resume %s periodic resource sync failed, err: %v, rid: %s", vendor, err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully resumed %s periodic resource sync, rid: %s", vendor, kt.Rid)
+				return nil, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithGetResSync init and returns the getting paused vendors of periodic resource sync command.
+func WithGetResSync(ctrl ResSyncController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:    "get-res-sync",
+			Usage:   "get the vendors whose periodic cloud resource sync is paused",
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				return map[string]interface{}{"paused_vendors": ctrl.PausedVendors()}, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithSyncAccount init and returns the triggering an immediate resource sync of account command.
+func WithSyncAccount(ctrl ResSyncController) Cmd {
+	cmd := &defaultCmd{
+		cmd: &Command{
+			Name:  "sync-account",
+			Usage: "trigger an immediate sync of all cloud resources of the account, the sync runs asynchronously",
+			Parameters: []Parameter{{
+				Name:  "account_id",
+				Usage: "defines the account id to sync, eg. \"00000001\"",
+				Value: new(string),
+			}},
+			FromURL: true,
+			Run: func(kt *kit.Kit, params map[string]interface{}) (interface{}, error) {
+				val, exists := params["account_id"]
+				if !exists || len(*val.(*string)) == 0 {
+					return nil, errf.New(errf.InvalidParameter, "account_id is not set")
+				}
+				accountID := *val.(*string)
+
+				if err := ctrl.SyncAccount(kt, accountID); err != nil {
+					logs.Errorf("trigger account %s sync failed, err: %v, rid: %s", accountID, err, kt.Rid)
+					return nil, err
+				}
+
+				logs.Infof("successfully triggered account %s resource sync, rid: %s", accountID, kt.Rid)
+				return nil, nil
+			},
+		},
+	}
+
+	return cmd
+}
+
+// WithResSync init and returns the periodic resource sync control commands.
+func WithResSync(ctrl ResSyncController) []Cmd {
+	return []Cmd{WithPauseResSync(ctrl), WithResumeResSync(ctrl), WithGetResSync(ctrl), WithSyncAccount(ctrl)}
+}
+
+func getVendorParam(params map[string]interface{}) (enumor.Vendor, error) {
+	val, exists := params["vendor"]
+	if !exists {
+		return "", errf.New(errf.InvalidParameter, "vendor is not set")
+	}
+
+	vendor := *val.(*enumor.Vendor)
+	if err := vendor.Validate(); err != nil {
+		return "", errf.NewFromErr(errf.InvalidParameter, err)
+	}
+
+	return vendor, nil
+}
@@ -27,9 +27,9 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"hcm/pkg/api/core"
 	"hcm/pkg/criteria/constant"
 	"hcm/pkg/criteria/errf"
-	"hcm/pkg/kit"
 	"hcm/pkg/rest"
 	"hcm/pkg/runtime/ctl/cmd"
 	"hcm/pkg/serviced"
@@ -77,10 +77,12 @@ func LoadCtl(commands ...cmd.Cmd) error {
 	return nil
 }
 
-// WithBasics init and returns the basic commands(register & deregister & log) that all servers needed.
+// WithBasics init and returns the basic commands(register & deregister & log & config & circuit breaker) that all
+// servers needed.
 func WithBasics(sd serviced.Service) []cmd.Cmd {
-	return []cmd.Cmd{cmd.WithLog(), cmd.WithRegister(sd), cmd.WithDeregister(sd), cmd.WithEnableMasterSlave(sd),
-		cmd.WithDisableMasterSlave(sd)}
+	basics := []cmd.Cmd{cmd.WithLog(), cmd.WithRegister(sd), cmd.WithDeregister(sd), cmd.WithEnableMasterSlave(sd),
+		cmd.WithDisableMasterSlave(sd), cmd.WithGetConfig()}
+	return append(basics, cmd.WithCircuitBreaker()...)
 }
 
 func (b *Ctl) httpHandler(w http.ResponseWriter, req *http.Request) {
@@ -151,8 +153,8 @@ func (b *Ctl) httpHandler(w http.ResponseWriter, req *http.Request) {
 		params[param.Name] = value
 	}
 
-	// run command
-	kt := kit.New()
+	// run command, with backend operation user so that commands can call other services.
+	kt := core.NewBackendKit()
 	w.Header().Set(constant.RidKey, kt.Rid)
 
 	data, err := command.Run(kt, params)
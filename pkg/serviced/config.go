@@ -0,0 +1,165 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package serviced
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hcm/pkg/cc"
+	"hcm/pkg/logs"
+	"hcm/pkg/tools/slice"
+
+	etcd3 "go.etcd.io/etcd/client/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigKeyPrefix returns the etcd key prefix of service's dynamic config, the key of a dynamic config is the prefix
+// with the dynamic config key, and the value is the yaml value of it.
+// e.g: /hcm/config/cloud-server/recycle
+func ConfigKeyPrefix(serviceName cc.Name) string {
+	return fmt.Sprintf("/hcm/config/%s/", serviceName)
+}
+
+// WatchConfig loads the dynamic config overrides of current service from etcd, and keeps watching the changes to
+// apply them. it does nothing if current service has no dynamic config keys.
+func WatchConfig(config cc.Service) error {
+	if len(cc.DynamicKeys()) == 0 {
+		return nil
+	}
+
+	etcdOpt, err := config.Etcd.ToConfig()
+	if err != nil {
+		return fmt.Errorf("get etcd config failed, err: %v", err)
+	}
+
+	cli, err := etcd3.New(etcdOpt)
+	if err != nil {
+		return fmt.Errorf("new etcd client failed, err: %v", err)
+	}
+
+	w := &configWatcher{cli: cli, prefix: ConfigKeyPrefix(cc.ServiceName())}
+	rev, err := w.load()
+	if err != nil {
+		return err
+	}
+
+	go w.watch(rev)
+	watcher = w
+	return nil
+}
+
+// watcher is the dynamic config watcher of current service, it's nil if the dynamic config is not watched.
+var watcher *configWatcher
+
+// PutConfig writes the value of current service's dynamic config key to etcd in yaml format and applies it
+// immediately, the value is persisted and takes effect on all nodes of the service.
+func PutConfig(key string, value interface{}) error {
+	if watcher == nil {
+		return errors.New("dynamic config is not watched")
+	}
+
+	if !slice.IsItemInSlice(cc.DynamicKeys(), key) {
+		return fmt.Errorf("%s is not dynamic config key", key)
+	}
+
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshal dynamic config %s value failed, err: %v", key, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+
+	if _, err = watcher.cli.Put(ctx, watcher.prefix+key, string(data)); err != nil {
+		return fmt.Errorf("put dynamic config %s to etcd failed, err: %v", key, err)
+	}
+
+	// 立即加载使当前节点生效，其他节点通过监听生效
+	if _, err = watcher.load(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type configWatcher struct {
+	cli    *etcd3.Client
+	prefix string
+}
+
+// load loads all dynamic config overrides and applies them, returns the etcd revision of them.
+func (w *configWatcher) load() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultEtcdTimeout)
+	defer cancel()
+
+	resp, err := w.cli.Get(ctx, w.prefix, etcd3.WithPrefix())
+	if err != nil {
+		return 0, fmt.Errorf("get dynamic config from etcd failed, err: %v", err)
+	}
+
+	overrides := make(map[string]string, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		overrides[strings.TrimPrefix(string(kv.Key), w.prefix)] = string(kv.Value)
+	}
+
+	// 不合法的配置项不生效，不影响其他配置项
+	if err := cc.ApplyOverrides(overrides); err != nil {
+		logs.Errorf("apply dynamic config overrides failed, err: %v", err)
+	}
+
+	return resp.Header.Revision, nil
+}
+
+// watch watches the dynamic config changes, and reloads all of them when changed.
+func (w *configWatcher) watch(rev int64) {
+	for {
+		watchCh := w.cli.Watch(context.Background(), w.prefix, etcd3.WithPrefix(), etcd3.WithRev(rev+1))
+		for resp := range watchCh {
+			if err := resp.Err(); err != nil {
+				logs.Errorf("watch dynamic config %s failed, err: %v", w.prefix, err)
+				break
+			}
+
+			if len(resp.Events) == 0 {
+				continue
+			}
+
+			newRev, err := w.load()
+			if err != nil {
+				logs.Errorf("reload dynamic config failed, err: %v", err)
+				continue
+			}
+			rev = newRev
+		}
+
+		// 监听中断后重新加载全部配置，避免遗漏中断期间的变更
+		time.Sleep(defaultErrSleepTime)
+		newRev, err := w.load()
+		if err != nil {
+			logs.Errorf("reload dynamic config failed, err: %v", err)
+			continue
+		}
+		rev = newRev
+	}
+}
@@ -69,6 +69,8 @@ func NewDiscovery(config cc.Service, opt DiscoveryOption) (Discover, error) {
 		addresses: make(map[cc.Name][]serviceAddress),
 	}
 
+	setBalancerOption(config.Balancer)
+
 	// keep synchronizing server addresses for discovery
 	d.syncAddresses()
 	return d, nil
@@ -28,6 +28,7 @@ import (
 
 	"hcm/pkg/cc"
 	"hcm/pkg/logs"
+	"hcm/pkg/rest/balancer"
 
 	etcd3 "go.etcd.io/etcd/client/v3"
 )
@@ -80,11 +81,28 @@ func NewServiceD(config cc.Service, svcOpt ServiceOption, discOpt DiscoveryOptio
 	// keep synchronizing current node's master state.
 	s.syncMasterState()
 
+	setBalancerOption(config.Balancer)
+
 	// keep synchronizing server addresses for discovery
 	s.syncAddresses()
 	return s, nil
 }
 
+// setBalancerOption sets the circuit breaking and outlier ejection options of the upstreams discovered by this
+// process.
+func setBalancerOption(opt cc.BalancerOption) {
+	balancer.SetOption(balancer.Option{
+		FailureThreshold:  opt.FailureThreshold,
+		OpenDuration:      time.Duration(opt.OpenDurationSec) * time.Second,
+		LatencyDecay:      opt.LatencyDecay,
+		OutlierFactor:     opt.OutlierFactor,
+		OutlierMinLatency: time.Duration(opt.OutlierMinLatencyMS) * time.Millisecond,
+		LatencySampleMax:  time.Duration(opt.LatencySampleMaxMS) * time.Millisecond,
+		EjectDuration:     time.Duration(opt.EjectDurationSec) * time.Second,
+		MaxEjectPercent:   opt.MaxEjectPercent,
+	})
+}
+
 type serviced struct {
 	*service
 	*discovery
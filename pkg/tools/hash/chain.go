@@ -0,0 +1,127 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package hash
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ChainGenesisHash 哈希链第一条记录的前序哈希
+var ChainGenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// ChainLine 哈希链文件中的一行，Hash = hex(sha256(PrevHash + "\n" + Record))
+type ChainLine struct {
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+	Record   json.RawMessage `json:"record"`
+}
+
+// ChainHash 计算记录在哈希链中的哈希
+func ChainHash(prevHash string, record []byte) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(prevHash))
+	hasher.Write([]byte("\n"))
+	hasher.Write(record)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// ChainWriter 以json lines格式写入哈希链，每条记录的哈希以前一条记录的哈希为前缀计算，
+// 任意记录被篡改、删除或调换顺序都会导致后续记录的哈希校验失败
+type ChainWriter struct {
+	encoder  *json.Encoder
+	lastHash string
+	count    uint64
+}
+
+// NewChainWriter new chain writer, prevHash is the hash of the last record before this chain.
+func NewChainWriter(w io.Writer, prevHash string) *ChainWriter {
+	return &ChainWriter{encoder: json.NewEncoder(w), lastHash: prevHash}
+}
+
+// Write encode record as json and append it to the chain.
+func (c *ChainWriter) Write(record interface{}) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	line := &ChainLine{PrevHash: c.lastHash, Hash: ChainHash(c.lastHash, raw), Record: raw}
+	if err = c.encoder.Encode(line); err != nil {
+		return err
+	}
+
+	c.lastHash = line.Hash
+	c.count++
+	return nil
+}
+
+// LastHash return the hash of the last written record.
+func (c *ChainWriter) LastHash() string {
+	return c.lastHash
+}
+
+// Count return the count of written records.
+func (c *ChainWriter) Count() uint64 {
+	return c.count
+}
+
+// VerifyChain 校验哈希链文件，prevHash为链的起始哈希，返回校验的记录数量及最后一条记录的哈希
+func VerifyChain(r io.Reader, prevHash string) (uint64, string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	count := uint64(0)
+	lastHash := prevHash
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		line := new(ChainLine)
+		if err := json.Unmarshal(scanner.Bytes(), line); err != nil {
+			return count, lastHash, fmt.Errorf("decode line %d failed, err: %v", count+1, err)
+		}
+
+		if line.PrevHash != lastHash {
+			return count, lastHash, fmt.Errorf("line %d prev hash mismatch, expect: %s, actual: %s", count+1,
+				lastHash, line.PrevHash)
+		}
+
+		if hash := ChainHash(lastHash, line.Record); hash != line.Hash {
+			return count, lastHash, fmt.Errorf("line %d hash mismatch, expect: %s, actual: %s", count+1, hash,
+				line.Hash)
+		}
+
+		lastHash = line.Hash
+		count++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return count, lastHash, err
+	}
+
+	return count, lastHash, nil
+}
@@ -0,0 +1,68 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package hash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHashChain(t *testing.T) {
+	buf := new(bytes.Buffer)
+	writer := NewChainWriter(buf, ChainGenesisHash)
+	records := []map[string]interface{}{
+		{"id": 1, "res_name": "cvm-1", "operator": "tom"},
+		{"id": 2, "res_name": "<sg>", "operator": "jerry"},
+		{"id": 3, "res_name": "vpc-1", "operator": "tom"},
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			t.Fatalf("write chain failed, err: %v", err)
+		}
+	}
+
+	count, lastHash, err := VerifyChain(bytes.NewReader(buf.Bytes()), ChainGenesisHash)
+	if err != nil {
+		t.Fatalf("verify chain failed, err: %v", err)
+	}
+	if count != 3 || lastHash != writer.LastHash() {
+		t.Fatalf("unexpected verify result, count: %d, last hash: %s, expect: %s", count, lastHash,
+			writer.LastHash())
+	}
+
+	// 篡改记录内容
+	tampered := strings.Replace(buf.String(), `"operator":"jerry"`, `"operator":"alice"`, 1)
+	if _, _, err = VerifyChain(strings.NewReader(tampered), ChainGenesisHash); err == nil {
+		t.Errorf("expect tampered record verify failed")
+	}
+
+	// 删除中间记录
+	lines := strings.SplitAfter(buf.String(), "\n")
+	removed := lines[0] + lines[2]
+	if _, _, err = VerifyChain(strings.NewReader(removed), ChainGenesisHash); err == nil {
+		t.Errorf("expect removed record verify failed")
+	}
+
+	// 起始哈希不匹配
+	if _, _, err = VerifyChain(bytes.NewReader(buf.Bytes()), writer.LastHash()); err == nil {
+		t.Errorf("expect prev hash mismatch verify failed")
+	}
+}
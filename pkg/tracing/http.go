@@ -0,0 +1,52 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+package tracing
+
+import (
+	"net/http"
+
+	"hcm/pkg/criteria/enumor"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tcloudActionHeader 腾讯云 API 3.0 请求头中的接口名称
+const tcloudActionHeader = "X-TC-Action"
+
+// NewTransport 返回记录云厂商接口调用 span 的 http.RoundTripper，span 名称为云厂商和请求域名，
+// 腾讯云请求会追加接口名称，便于区分同一域名下的不同接口。
+func NewTransport(vendor enumor.Vendor) http.RoundTripper {
+	return otelhttp.NewTransport(http.DefaultTransport,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			name := string(vendor) + " " + r.URL.Host
+			if action := r.Header.Get(tcloudActionHeader); len(action) != 0 {
+				name += " " + action
+			}
+			return name
+		}),
+		otelhttp.WithSpanOptions(trace.WithAttributes(VendorKey.String(string(vendor)))),
+	)
+}
+
+// NewHTTPClient 返回记录云厂商接口调用 span 的 http.Client
+func NewHTTPClient(vendor enumor.Vendor) *http.Client {
+	return &http.Client{Transport: NewTransport(vendor)}
+}
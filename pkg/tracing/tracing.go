@@ -0,0 +1,214 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+// Package tracing 基于 OpenTelemetry 的分布式链路追踪，链路上下文通过 W3C traceparent 请求头在服务间传递
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"hcm/pkg/logs"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExporterType 链路数据导出方式
+type ExporterType string
+
+const (
+	// OtlpExporter 通过 otlp http 协议导出到链路追踪后端，如 jaeger、tempo、otel collector
+	OtlpExporter ExporterType = "otlp"
+	// FileExporter 以 json 格式写入本地文件，用于没有链路追踪后端的环境
+	FileExporter ExporterType = "file"
+)
+
+const (
+	// tracerName 全部服务共用的 tracer 名称
+	tracerName = "hcm"
+	// shutdownTimeout 进程退出时导出剩余 span 的超时时间
+	shutdownTimeout = 5 * time.Second
+	// RidKey span 中记录请求ID的属性
+	RidKey = attribute.Key("hcm.rid")
+	// VendorKey span 中记录云厂商的属性
+	VendorKey = attribute.Key("hcm.vendor")
+)
+
+// Config 链路追踪配置
+type Config struct {
+	// Exporter 导出方式，为空时不开启链路追踪
+	Exporter ExporterType
+	// Endpoint otlp 接收地址，格式为 host:port
+	Endpoint string
+	// Insecure otlp 使用 http 而不是 https
+	Insecure bool
+	// Headers otlp 请求附加的请求头，可用于鉴权
+	Headers map[string]string
+	// FilePath 本地文件导出路径
+	FilePath string
+	// SampleRatio 根 span 的采样比例，子 span 跟随上游的采样决定
+	SampleRatio float64
+}
+
+// Init 按配置初始化全局 TracerProvider，未开启时使用 OpenTelemetry 默认的空实现。
+// 无论是否开启都会设置 W3C 链路上下文传播，保证经过未开启追踪的服务时链路不中断。
+// 返回的函数用于进程退出前导出剩余的 span。
+func Init(serviceName string, cfg Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	hostname, _ := os.Hostname()
+	res := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.HostName(hostname),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case "":
+		return nil, nil
+	case OtlpExporter:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(cfg.Headers) != 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	case FileExporter:
+		if err := os.MkdirAll(filepath.Dir(cfg.FilePath), 0755); err != nil {
+			return nil, fmt.Errorf("create trace file dir failed, err: %v", err)
+		}
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("open trace file failed, err: %v", err)
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter: %s", cfg.Exporter)
+	}
+}
+
+// Tracer 返回全局 tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start 以 ctx 中的 span 为父 span 创建内部 span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServer 从请求头提取上游链路上下文并创建服务端 span
+func StartServer(ctx context.Context, name string, header http.Header, rid string) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(RidKey.String(rid)))
+}
+
+// StartClient 创建客户端 span，并将链路上下文注入到请求头
+func StartClient(ctx context.Context, name string, header http.Header,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	Inject(ctx, header)
+	return ctx, span
+}
+
+// Inject 将 ctx 中的链路上下文注入到请求头
+func Inject(ctx context.Context, header http.Header) {
+	if ctx == nil || header == nil {
+		return
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// End 根据 err 设置 span 状态并结束 span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent 将 ctx 中的链路上下文序列化为 W3C traceparent，用于持久化后在其他进程中恢复，没有链路时返回空
+func TraceParent(ctx context.Context) string {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return ""
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// WithTraceParent 将 TraceParent 序列化的链路上下文恢复到 ctx 中，作为后续 span 的父 span
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if len(traceParent) == 0 {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{"traceparent": traceParent}
+	return propagation.TraceContext{}.Extract(ctx, carrier)
+}
+
+// Shutdown 进程退出前导出剩余的 span，shutdown 为 Init 的返回值
+func Shutdown(shutdown func(ctx context.Context) error) {
+	if shutdown == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logs.Errorf("shutdown tracer provider failed, err: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0026,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加安全组规则检查结果表`security_group_lint_finding`
+*/
+
+START TRANSACTION;
+
+create table if not exists `security_group_lint_finding`
+(
+    `id`                      varchar(64)      not null,
+    `vendor`                  varchar(16)      not null,
+    `account_id`              varchar(64)      not null,
+    `bk_biz_id`               bigint           not null default -1,
+    `region`                  varchar(64)      not null default '',
+    `security_group_id`       varchar(64)      not null,
+    `cloud_security_group_id` varchar(255)     not null default '',
+    `rule_id`                 varchar(64)      not null default '',
+    `rule_type`               varchar(16)      not null default '',
+    `lint_type`               varchar(32)      not null,
+    `risk_level`              varchar(16)      not null,
+    `risk_score`              bigint unsigned  not null default 0,
+    `detail`                  json,
+
+    `creator`                 varchar(64)      not null,
+    `reviser`                 varchar(64)      not null,
+    `created_at`              timestamp        not null default current_timestamp,
+    `updated_at`              timestamp        not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    index `idx_account_id` (`account_id`),
+    index `idx_bk_biz_id_lint_type` (`bk_biz_id`, `lint_type`),
+    index `idx_security_group_id` (`security_group_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='安全组规则检查结果表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('security_group_lint_finding', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0026' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,83 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+
+/*
+    SQLVER=0029,HCMVER=v1.7.0
+
+    Notes:
+    1. 账单明细表`account_bill_item`添加云资源ID字段`cloud_res_id`及索引，用于资源级费用归属
+    2. 按月分表`account_bill_item_<vendor>_<yyyymm>`由账单模板表创建，通过存储过程遍历当前库中已存在且缺少该字段的分表执行相同的变更
+    3. 分表数据量较大时ALTER TABLE耗时较长，MySQL 8.0 下添加字段为INSTANT变更，添加索引为INPLACE在线变更，执行期间不阻塞读写
+*/
+
+START TRANSACTION;
+
+alter table `account_bill_item`
+    add column `cloud_res_id` varchar(255) default '' after `res_amount_unit`;
+
+alter table `account_bill_item`
+    add index `idx_vendor_year_month_cloud_res_id` (`vendor`, `bill_year`, `bill_month`, `cloud_res_id`);
+
+DROP PROCEDURE IF EXISTS `add_bill_item_partition_cloud_res_id`;
+
+DELIMITER $$
+CREATE PROCEDURE `add_bill_item_partition_cloud_res_id`()
+BEGIN
+    DECLARE done INT DEFAULT 0;
+    DECLARE partition_name VARCHAR(64);
+    DECLARE partitions CURSOR FOR
+        SELECT t.`TABLE_NAME`
+        FROM information_schema.`TABLES` t
+        WHERE t.`TABLE_SCHEMA` = DATABASE()
+          AND t.`TABLE_NAME` LIKE 'account\_bill\_item\_%'
+          AND NOT EXISTS (SELECT 1
+                          FROM information_schema.`COLUMNS` c
+                          WHERE c.`TABLE_SCHEMA` = t.`TABLE_SCHEMA`
+                            AND c.`TABLE_NAME` = t.`TABLE_NAME`
+                            AND c.`COLUMN_NAME` = 'cloud_res_id');
+    DECLARE CONTINUE HANDLER FOR NOT FOUND SET done = 1;
+
+    OPEN partitions;
+    partition_loop:
+    LOOP
+        FETCH partitions INTO partition_name;
+        IF done = 1 THEN
+            LEAVE partition_loop;
+        END IF;
+
+        SET @alter_sql = CONCAT('alter table `', partition_name,
+                                '` add column `cloud_res_id` varchar(255) default \'\' after `res_amount_unit`, ',
+                                'add index `idx_vendor_year_month_cloud_res_id` ',
+                                '(`vendor`, `bill_year`, `bill_month`, `cloud_res_id`)');
+        PREPARE stmt FROM @alter_sql;
+        EXECUTE stmt;
+        DEALLOCATE PREPARE stmt;
+    END LOOP;
+    CLOSE partitions;
+END $$
+DELIMITER ;
+
+CALL `add_bill_item_partition_cloud_res_id`();
+DROP PROCEDURE IF EXISTS `add_bill_item_partition_cloud_res_id`;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0029' as `sql_ver`;
+
+COMMIT;
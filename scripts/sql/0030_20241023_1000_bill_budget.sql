@@ -0,0 +1,87 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+
+/*
+    SQLVER=0030,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加账单预算表`account_bill_budget`
+    2. 添加账单预算告警记录表`account_bill_budget_alert`
+*/
+
+START TRANSACTION;
+
+create table if not exists `account_bill_budget`
+(
+    `id`              varchar(64)     not null,
+    `name`            varchar(64)     not null,
+    `scope`           varchar(32)     not null,
+    `bk_biz_id`       bigint          not null default 0,
+    `main_account_id` varchar(64)     not null default '',
+    `product_id`      bigint          not null default 0,
+    `currency`        varchar(16)     not null,
+    `amount`          decimal(38, 10) not null,
+    `thresholds`      json            not null,
+    `forecast_alert`  tinyint(1)      not null default 0,
+    `anomaly_ratio`   bigint          not null default 0,
+    `receivers`       json            not null,
+    `memo`            varchar(255)             default '',
+
+    `creator`         varchar(64)     not null,
+    `reviser`         varchar(64)     not null,
+    `created_at`      timestamp       not null default current_timestamp,
+    `updated_at`      timestamp       not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_scope` (`scope`, `bk_biz_id`, `main_account_id`, `product_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='账单预算表';
+
+create table if not exists `account_bill_budget_alert`
+(
+    `id`            varchar(64)     not null,
+    `budget_id`     varchar(64)     not null,
+    `bill_year`     bigint          not null,
+    `bill_month`    tinyint         not null,
+    `bill_day`      tinyint         not null default 0,
+    `alert_type`    varchar(32)     not null,
+    `threshold`     bigint          not null default 0,
+    `currency`      varchar(16)     not null,
+    `budget_amount` decimal(38, 10) not null,
+    `cost`          decimal(38, 10) not null,
+    `forecast_cost` decimal(38, 10) not null,
+    `message`       varchar(1024)            default '',
+
+    `created_at`    timestamp       not null default current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_budget_period_alert` (`budget_id`, `bill_year`, `bill_month`, `bill_day`, `alert_type`,
+                                             `threshold`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='账单预算告警记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_budget', '0'),
+       ('account_bill_budget_alert', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0030' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,36 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0031,HCMVER=v1.7.0
+
+    Notes:
+    1. 汇率表`account_bill_exchange_rate`添加汇率来源字段`source`及锁定标记字段`locked`，月末锁定后的汇率不再允许修改
+*/
+
+START TRANSACTION;
+
+alter table `account_bill_exchange_rate`
+    add column `source` varchar(32) not null default 'manual' comment '汇率来源' after `exchange_rate`,
+    add column `locked` tinyint(1) unsigned not null default 0 comment '是否锁定' after `source`;
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0031' as `sql_ver`;
+
+COMMIT;
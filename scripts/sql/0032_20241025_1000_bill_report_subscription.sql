@@ -0,0 +1,61 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0032,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加账单报表订阅表`account_bill_report_subscription`
+*/
+
+START TRANSACTION;
+
+create table if not exists `account_bill_report_subscription`
+(
+    `id`              varchar(64)  not null,
+    `name`            varchar(64)  not null,
+    `report_type`     varchar(32)  not null,
+    `vendor`          varchar(16)  not null default '',
+    `filter`          json,
+    `format`          varchar(16)  not null,
+    `cadence`         varchar(16)  not null,
+    `send_day`        tinyint      not null default 0,
+    `receivers`       json         not null,
+    `enabled`         tinyint(1)   not null default 1,
+    `last_bill_year`  bigint       not null default 0,
+    `last_bill_month` tinyint      not null default 0,
+    `memo`            varchar(255)          default '',
+
+    `creator`         varchar(64)  not null,
+    `reviser`         varchar(64)  not null,
+    `created_at`      timestamp    not null default current_timestamp,
+    `updated_at`      timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_enabled` (`enabled`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='账单报表订阅表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_report_subscription', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0032' as `sql_ver`;
+
+COMMIT;
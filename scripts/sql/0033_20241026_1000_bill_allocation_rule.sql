@@ -0,0 +1,62 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0033,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加共享费用分摊规则表`account_bill_allocation_rule`
+*/
+
+START TRANSACTION;
+
+create table if not exists `account_bill_allocation_rule`
+(
+    `id`                       varchar(64)  not null,
+    `name`                     varchar(64)  not null,
+    `vendor`                   varchar(16)  not null,
+    `root_account_id`          varchar(64)  not null,
+    `rule_type`                varchar(16)  not null,
+    `source_type`              varchar(32)  not null,
+    `source_main_account_ids`  json,
+    `source_hc_product_codes`  json,
+    `exclude_main_account_ids` json,
+    `targets`                  json,
+    `sp_arn_prefix`            varchar(255)          default '',
+    `credit_ids`               json,
+    `enabled`                  tinyint(1)   not null default 1,
+    `memo`                     varchar(255)          default '',
+
+    `creator`                  varchar(64)  not null,
+    `reviser`                  varchar(64)  not null,
+    `created_at`               timestamp    not null default current_timestamp,
+    `updated_at`               timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_root_account_id` (`root_account_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='共享费用分摊规则表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_allocation_rule', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0033' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,54 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0034,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加账单对账记录表`account_bill_reconciliation`
+*/
+
+START TRANSACTION;
+
+create table if not exists `account_bill_reconciliation`
+(
+    `id`              varchar(64) not null,
+    `root_account_id` varchar(64) not null,
+    `vendor`          varchar(16) not null,
+    `bill_year`       bigint      not null,
+    `bill_month`      tinyint     not null,
+    `summary_version` bigint      not null,
+    `state`           varchar(16) not null,
+    `details`         json,
+
+    `creator`         varchar(64) not null,
+    `created_at`      timestamp   not null default current_timestamp,
+    primary key (`id`),
+    key `idx_root_account_month` (`root_account_id`, `bill_year`, `bill_month`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='账单对账记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_reconciliation', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0034' as `sql_ver`;
+
+COMMIT;
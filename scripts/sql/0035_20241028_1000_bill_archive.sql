@@ -0,0 +1,59 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0035,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加账单明细归档记录表`account_bill_archive`，超过保留期的月度账单明细分表归档到对象存储后删除
+*/
+
+START TRANSACTION;
+
+create table if not exists `account_bill_archive`
+(
+    `id`          varchar(64)   not null,
+    `vendor`      varchar(16)   not null,
+    `bill_year`   bigint        not null,
+    `bill_month`  tinyint       not null,
+    `state`       varchar(16)   not null,
+    `object_path` varchar(255)  not null default '',
+    `item_count`  bigint        not null default 0,
+    `file_size`   bigint        not null default 0,
+    `flow_id`     varchar(64)   not null default '',
+    `reason`      varchar(1024) not null default '',
+    `restored_at` varchar(32)   not null default '',
+
+    `creator`     varchar(64)   not null,
+    `reviser`     varchar(64)   not null,
+    `created_at`  timestamp     not null default current_timestamp,
+    `updated_at`  timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_vendor_year_month` (`vendor`, `bill_year`, `bill_month`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='账单明细归档记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('account_bill_archive', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0035' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,81 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0037,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加webhook订阅表`webhook_subscription`
+    2. 添加webhook投递记录表`webhook_delivery`
+*/
+
+START TRANSACTION;
+
+create table if not exists `webhook_subscription`
+(
+    `id`          varchar(64)   not null,
+    `name`        varchar(64)   not null,
+    `url`         varchar(1024) not null,
+    `secret`      varchar(255)  not null default '',
+    `event_types` json          not null,
+    `enabled`     boolean       not null default true,
+    `memo`        varchar(255)           default '',
+
+    `creator`     varchar(64)   not null,
+    `reviser`     varchar(64)   not null,
+    `created_at`  timestamp     not null default current_timestamp,
+    `updated_at`  timestamp     not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='webhook订阅表';
+
+create table if not exists `webhook_delivery`
+(
+    `id`              varchar(64)  not null,
+    `subscription_id` varchar(64)  not null,
+    `event_type`      varchar(128) not null,
+    `event_id`        varchar(64)  not null,
+    `payload`         json         not null,
+    `state`           varchar(32)  not null,
+    `attempts`        bigint       not null default 0,
+    `status_code`     bigint       not null default 0,
+    `response`        varchar(1024)         default '',
+    `flow_id`         varchar(64)           default '',
+
+    `creator`         varchar(64)  not null,
+    `reviser`         varchar(64)  not null,
+    `created_at`      timestamp    not null default current_timestamp,
+    `updated_at`      timestamp    not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    key `idx_subscription_id` (`subscription_id`),
+    key `idx_event_id` (`event_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='webhook投递记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('webhook_subscription', '0'),
+       ('webhook_delivery', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0037' as `sql_ver`;
+
+COMMIT;
@@ -0,0 +1,100 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0038,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加审计全文检索表`audit_search`，审计写入时同步写入资源名称、云资源ID及详情文本，并添加ngram全文索引
+    2. 添加审计字段变更表`audit_field_change`
+    3. 添加审计导出记录表`audit_export`
+
+    Online DDL:
+    本脚本只新建表，不修改审计表`audit`。在`audit`上添加STORED生成列会重建整张表（ALGORITHM=COPY，期间阻塞写入），
+    且InnoDB不支持在VIRTUAL生成列上建全文索引，因此全文检索数据放在独立的`audit_search`表中，建表开销与审计表数据量无关。
+    升级前已存在的审计不会写入`audit_search`，关键字检索只覆盖升级后的审计。如需检索历史审计，可在业务低峰期按ID分批回填，
+    每批执行后全文索引会同步更新，批次不宜过大，例如：
+        insert ignore into `audit_search` (`audit_id`, `res_name`, `cloud_res_id`, `detail_text`)
+        select `id`, `res_name`, `cloud_res_id`, cast(`detail` as char) from `audit` where `id` > ? and `id` <= ?;
+*/
+
+START TRANSACTION;
+
+create table if not exists `audit_search`
+(
+    `audit_id`     bigint(1) unsigned not null,
+    `res_name`     varchar(255)       default '',
+    `cloud_res_id` varchar(255)       default '',
+    `detail_text`  longtext,
+    primary key (`audit_id`),
+    fulltext key `idx_ft_search` (`res_name`, `cloud_res_id`, `detail_text`) with parser ngram
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='审计全文检索表';
+
+create table if not exists `audit_field_change`
+(
+    `id`             bigint(1) unsigned not null auto_increment,
+    `audit_id`       bigint(1) unsigned not null,
+    `res_id`         varchar(64)                 default '',
+    `cloud_res_id`   varchar(255)                default '',
+    `res_name`       varchar(255)                default '',
+    `res_type`       varchar(50)        not null,
+    `child_res_type` varchar(50)                 default '',
+    `field`          varchar(255)       not null,
+    `before_value`   text                        default null,
+    `after_value`    text                        default null,
+    `bk_biz_id`      bigint(1)          not null default -1,
+    `vendor`         varchar(16)                 default '',
+    `account_id`     varchar(64)                 default '',
+    `operator`       varchar(64)        not null,
+    `rid`            varchar(64)        not null,
+    `created_at`     timestamp          not null default current_timestamp,
+    primary key (`id`),
+    key `idx_audit_id` (`audit_id`),
+    key `idx_res_id_field` (`res_id`, `field`),
+    key `idx_created_at` (`created_at`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='审计字段变更表';
+
+create table if not exists `audit_export`
+(
+    `id`           varchar(64)         not null,
+    `start_id`     bigint(1) unsigned  not null,
+    `end_id`       bigint(1) unsigned  not null,
+    `record_count` bigint(1) unsigned  not null,
+    `prev_hash`    char(64)            not null,
+    `last_hash`    char(64)            not null,
+    `object_path`  varchar(1024)       not null,
+    `creator`      varchar(64)         not null,
+    `created_at`   timestamp           not null default current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_start_id` (`start_id`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='审计导出记录表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('audit_export', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0038' as `sql_ver`;
+
+COMMIT;
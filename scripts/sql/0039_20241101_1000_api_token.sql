@@ -0,0 +1,79 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0039,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加服务账号表`service_account`
+    2. 添加API令牌表`api_token`
+*/
+
+START TRANSACTION;
+
+create table if not exists `service_account`
+(
+    `id`         varchar(64) not null,
+    `name`       varchar(64) not null,
+    `memo`       varchar(255)         default '',
+
+    `creator`    varchar(64) not null,
+    `reviser`    varchar(64) not null,
+    `created_at` timestamp   not null default current_timestamp,
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='服务账号表';
+
+create table if not exists `api_token`
+(
+    `id`             varchar(64) not null,
+    `name`           varchar(64) not null,
+    `token_hash`     char(64)    not null,
+    `token_prefix`   varchar(16) not null,
+    `principal_type` varchar(32) not null,
+    `principal`      varchar(64) not null,
+    `bk_biz_ids`     json        not null,
+    `actions`        json        not null,
+    `expire_at`      varchar(32) not null,
+    `revoked`        boolean     not null default false,
+    `last_used_at`   varchar(32) not null default '',
+    `memo`           varchar(255)         default '',
+
+    `creator`        varchar(64) not null,
+    `reviser`        varchar(64) not null,
+    `created_at`     timestamp   not null default current_timestamp,
+    `updated_at`     timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_token_hash` (`token_hash`),
+    key `idx_principal` (`principal_type`, `principal`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='API令牌表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('service_account', '0'),
+       ('api_token', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0039' as `sql_ver`;
+
+COMMIT;
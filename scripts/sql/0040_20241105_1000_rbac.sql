@@ -0,0 +1,92 @@
+/*
+ * TencentBlueKing is pleased to support the open source community by making
+ * 蓝鲸智云 - 混合云管理平台 (BlueKing - Hybrid Cloud Management System) available.
+ * Copyright (C) 2024 THL A29 Limited,
+ * a Tencent company. All rights reserved.
+ * Licensed under the MIT License (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at http://opensource.org/licenses/MIT
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND,
+ * either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * We undertake not to change the open source license (MIT license) applicable
+ *
+ * to the current version of the project delivered to anyone in the future.
+ */
+
+/*
+    SQLVER=0040,HCMVER=v1.7.0
+
+    Notes:
+    1. 添加本地RBAC角色表`rbac_role`
+    2. 添加本地RBAC用户组表`rbac_group`
+    3. 添加本地RBAC角色绑定表`rbac_role_binding`
+*/
+
+START TRANSACTION;
+
+create table if not exists `rbac_role`
+(
+    `id`          varchar(64) not null,
+    `name`        varchar(64) not null,
+    `permissions` json        not null,
+    `memo`        varchar(255)         default '',
+
+    `creator`     varchar(64) not null,
+    `reviser`     varchar(64) not null,
+    `created_at`  timestamp   not null default current_timestamp,
+    `updated_at`  timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='本地RBAC角色表';
+
+create table if not exists `rbac_group`
+(
+    `id`         varchar(64) not null,
+    `name`       varchar(64) not null,
+    `members`    json        not null,
+    `memo`       varchar(255)         default '',
+
+    `creator`    varchar(64) not null,
+    `reviser`    varchar(64) not null,
+    `created_at` timestamp   not null default current_timestamp,
+    `updated_at` timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_name` (`name`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='本地RBAC用户组表';
+
+create table if not exists `rbac_role_binding`
+(
+    `id`           varchar(64) not null,
+    `role_id`      varchar(64) not null,
+    `subject_type` varchar(32) not null,
+    `subject`      varchar(64) not null,
+    `bk_biz_ids`   json        not null,
+
+    `creator`      varchar(64) not null,
+    `reviser`      varchar(64) not null,
+    `created_at`   timestamp   not null default current_timestamp,
+    `updated_at`   timestamp   not null default current_timestamp on update current_timestamp,
+    primary key (`id`),
+    unique key `idx_uk_role_id_subject` (`role_id`, `subject_type`, `subject`),
+    key `idx_subject` (`subject_type`, `subject`)
+) engine = innodb
+  default charset = utf8mb4
+  collate = utf8mb4_bin comment ='本地RBAC角色绑定表';
+
+insert into id_generator(`resource`, `max_id`)
+values ('rbac_role', '0'),
+       ('rbac_group', '0'),
+       ('rbac_role_binding', '0');
+
+CREATE OR REPLACE VIEW `hcm_version`(`hcm_ver`, `sql_ver`) AS
+SELECT 'v1.7.0' as `hcm_ver`, '0040' as `sql_ver`;
+
+COMMIT;